@@ -72,6 +72,12 @@ func (c *Client) GetAttachment(ctx context.Context, attachmentID string) (*Attac
 
 // DownloadAttachment downloads an attachment and returns a reader.
 func (c *Client) DownloadAttachment(ctx context.Context, attachmentID string) (io.ReadCloser, error) {
+	return c.DownloadAttachmentVersion(ctx, attachmentID, 0)
+}
+
+// DownloadAttachmentVersion downloads a version of an attachment and
+// returns a reader. Version 0 downloads the current version.
+func (c *Client) DownloadAttachmentVersion(ctx context.Context, attachmentID string, version int) (io.ReadCloser, error) {
 	// Get attachment metadata which includes the download URL
 	att, err := c.GetAttachment(ctx, attachmentID)
 	if err != nil {
@@ -83,13 +89,31 @@ func (c *Client) DownloadAttachment(ctx context.Context, attachmentID string) (i
 	}
 
 	// downloadLink is relative (e.g., /download/attachments/...)
-	downloadURL := c.baseURL + att.DownloadLink
+	link := att.DownloadLink
+	if version > 0 {
+		u, err := url.Parse(link)
+		if err != nil {
+			return nil, fmt.Errorf("invalid download link: %w", err)
+		}
+		// The link pins the current version and its modification date
+		q := u.Query()
+		q.Del("modificationDate")
+		q.Set("version", strconv.Itoa(version))
+		u.RawQuery = q.Encode()
+		link = u.String()
+	}
+	return c.download(ctx, link)
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", downloadURL, nil)
+// download performs an authenticated GET of a binary resource. ref is a
+// path relative to the base URL or an absolute URL.
+func (c *Client) download(ctx context.Context, ref string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.resolveURL(ref), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.SetBasicAuth(c.email, c.apiToken)
+	req.Header.Set("X-Atlassian-Token", "no-check")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -107,6 +131,19 @@ func (c *Client) DownloadAttachment(ctx context.Context, attachmentID string) (i
 // UploadAttachment uploads a file as an attachment to a page.
 // Note: This uses the v1 API as v2 doesn't support uploads yet.
 func (c *Client) UploadAttachment(ctx context.Context, pageID, filename string, content io.Reader, comment string) (*Attachment, error) {
+	return c.uploadAttachment(ctx, http.MethodPost, pageID, filename, content, comment)
+}
+
+// CreateOrUpdateAttachment uploads a file as an attachment to a page, adding
+// a new version if an attachment with the same filename already exists.
+// Note: This uses the v1 API as v2 doesn't support uploads yet.
+func (c *Client) CreateOrUpdateAttachment(ctx context.Context, pageID, filename string, content io.Reader, comment string) (*Attachment, error) {
+	return c.uploadAttachment(ctx, http.MethodPut, pageID, filename, content, comment)
+}
+
+// uploadAttachment sends a multipart attachment upload. POST creates a new
+// attachment; PUT creates or updates one by filename.
+func (c *Client) uploadAttachment(ctx context.Context, method, pageID, filename string, content io.Reader, comment string) (*Attachment, error) {
 	// Create multipart form
 	var buf bytes.Buffer
 	writer := multipart.NewWriter(&buf)
@@ -133,7 +170,7 @@ func (c *Client) UploadAttachment(ctx context.Context, pageID, filename string,
 
 	// Use v1 API for uploads
 	path := fmt.Sprintf("/rest/api/content/%s/child/attachment", pageID)
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+path, &buf)
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, &buf)
 	if err != nil {
 		return nil, err
 	}
@@ -158,12 +195,21 @@ func (c *Client) UploadAttachment(ctx context.Context, pageID, filename string,
 		if err := json.Unmarshal(respBody, &errResp); err != nil {
 			return nil, fmt.Errorf("upload failed (status %d): %s", resp.StatusCode, string(respBody))
 		}
+		errResp.StatusCode = resp.StatusCode
 		return nil, &errResp
 	}
 
-	// v1 API returns results in a different format
+	// v1 API returns results in a different format, with media details
+	// nested under "extensions"
 	var result struct {
-		Results []Attachment `json:"results"`
+		Results []struct {
+			Attachment
+			Extensions struct {
+				MediaType string `json:"mediaType"`
+				FileSize  int64  `json:"fileSize"`
+				FileID    string `json:"fileId"`
+			} `json:"extensions"`
+		} `json:"results"`
 	}
 	if err := json.Unmarshal(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse upload response: %w", err)
@@ -173,7 +219,18 @@ func (c *Client) UploadAttachment(ctx context.Context, pageID, filename string,
 		return nil, fmt.Errorf("no attachment returned from upload")
 	}
 
-	return &result.Results[0], nil
+	r := result.Results[0]
+	att := r.Attachment
+	if att.MediaType == "" {
+		att.MediaType = r.Extensions.MediaType
+	}
+	if att.FileSize == 0 {
+		att.FileSize = r.Extensions.FileSize
+	}
+	if att.FileID == "" {
+		att.FileID = r.Extensions.FileID
+	}
+	return &att, nil
 }
 
 // DeleteAttachment deletes an attachment by ID.
@@ -2,8 +2,10 @@ package api
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -122,6 +124,35 @@ func TestClient_DownloadAttachment(t *testing.T) {
 	assert.Equal(t, fileContent, buf[:n])
 }
 
+func TestClient_DownloadAttachmentVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/attachments/att111" {
+			_, _ = w.Write([]byte(`{
+				"id": "att111",
+				"title": "screenshot.png",
+				"downloadLink": "/download/attachments/98765/screenshot.png?version=3&modificationDate=1700000000000&api=v2"
+			}`))
+			return
+		}
+
+		assert.Equal(t, "/download/attachments/98765/screenshot.png", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("version"))
+		assert.Empty(t, r.URL.Query().Get("modificationDate"))
+		assert.Equal(t, "v2", r.URL.Query().Get("api"))
+		_, _ = w.Write([]byte("version 2"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	reader, err := client.DownloadAttachmentVersion(context.Background(), "att111", 2)
+	require.NoError(t, err)
+	defer func() { _ = reader.Close() }()
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "version 2", string(data))
+}
+
 func TestClient_DownloadAttachment_NoDownloadLink(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -159,3 +190,33 @@ func TestClient_DeleteAttachment_NotFound(t *testing.T) {
 	err := client.DeleteAttachment(context.Background(), "invalid")
 	require.Error(t, err)
 }
+
+func TestClient_CreateOrUpdateAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/content/98765/child/attachment", r.URL.Path)
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "nocheck", r.Header.Get("X-Atlassian-Token"))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+		assert.Equal(t, "diagram.svg", header.Filename)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results": [{
+			"id": "att555",
+			"title": "diagram.svg",
+			"extensions": {"mediaType": "image/svg+xml", "fileSize": 42, "fileId": "file-uuid"}
+		}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	att, err := client.CreateOrUpdateAttachment(context.Background(), "98765", "diagram.svg", strings.NewReader("<svg/>"), "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "att555", att.ID)
+	assert.Equal(t, "image/svg+xml", att.MediaType)
+	assert.Equal(t, int64(42), att.FileSize)
+	assert.Equal(t, "file-uuid", att.FileID)
+}
@@ -0,0 +1,236 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// capabilityTTL is how long probed capabilities are cached.
+const capabilityTTL = 24 * time.Hour
+
+// Feature is an optional API feature a Confluence site may not support.
+type Feature string
+
+// Features detected by ProbeCapabilities.
+const (
+	FeatureV2            Feature = "v2"
+	FeatureADF           Feature = "adf"
+	FeaturePublicLinks   Feature = "public-links"
+	FeatureContentStates Feature = "content-states"
+)
+
+// featureNames are the human readable names of features, for errors.
+var featureNames = map[Feature]string{
+	FeatureV2:            "the v2 REST API",
+	FeatureADF:           "the cloud editor (ADF page bodies)",
+	FeaturePublicLinks:   "public links",
+	FeatureContentStates: "content states",
+}
+
+// Capabilities records which optional features a Confluence site supports.
+type Capabilities struct {
+	Site          string    `json:"site"`
+	V2            bool      `json:"v2"`
+	ADF           bool      `json:"adf"`
+	PublicLinks   bool      `json:"publicLinks"`
+	ContentStates bool      `json:"contentStates"`
+	ProbedAt      time.Time `json:"probedAt"`
+}
+
+// Supports reports whether the site supports feature.
+func (c *Capabilities) Supports(feature Feature) bool {
+	switch feature {
+	case FeatureV2:
+		return c.V2
+	case FeatureADF:
+		return c.ADF
+	case FeaturePublicLinks:
+		return c.PublicLinks
+	case FeatureContentStates:
+		return c.ContentStates
+	}
+	return false
+}
+
+// Deployment types returned by Capabilities.Deployment.
+const (
+	DeploymentCloud      = "cloud"
+	DeploymentDataCenter = "data-center" // Data Center or Server
+)
+
+// Deployment returns how the site is deployed. Only Confluence Cloud has
+// the v2 API.
+func (c *Capabilities) Deployment() string {
+	if c.V2 {
+		return DeploymentCloud
+	}
+	return DeploymentDataCenter
+}
+
+// NotSupportedError is returned when a site lacks a feature a request needs.
+type NotSupportedError struct {
+	Feature Feature
+}
+
+func (e *NotSupportedError) Error() string {
+	name, ok := featureNames[e.Feature]
+	if !ok {
+		name = string(e.Feature)
+	}
+	return name + ": not supported on this site"
+}
+
+// WithCapabilityCache caches probed capabilities in the JSON file at path,
+// keyed by site. Without it capabilities are probed once per client.
+//
+// It also turns 404 responses from v2 endpoints into a NotSupportedError
+// when the site has no v2 API.
+func WithCapabilityCache(path string) Option {
+	return func(c *Client) {
+		c.capCachePath = path
+	}
+}
+
+// Capabilities returns the site's capabilities, probing them if they aren't
+// cached or the cache is more than a day old.
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	if c.caps != nil {
+		return c.caps, nil
+	}
+
+	if caps, ok := c.loadCapabilityCache()[c.baseURL]; ok && time.Since(caps.ProbedAt) < capabilityTTL {
+		c.caps = caps
+		return caps, nil
+	}
+
+	return c.RefreshCapabilities(ctx)
+}
+
+// RefreshCapabilities probes the site's capabilities and updates the cache.
+func (c *Client) RefreshCapabilities(ctx context.Context) (*Capabilities, error) {
+	caps, err := c.ProbeCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.caps = caps
+
+	if c.capCachePath != "" {
+		cache := c.loadCapabilityCache()
+		if cache == nil {
+			cache = map[string]*Capabilities{}
+		}
+		cache[c.baseURL] = caps
+		// A failed save only means the next client probes the site again
+		_ = saveCapabilityCache(c.capCachePath, cache)
+	}
+	return caps, nil
+}
+
+// Require returns a NotSupportedError if the site doesn't support feature.
+func (c *Client) Require(ctx context.Context, feature Feature) error {
+	caps, err := c.Capabilities(ctx)
+	if err != nil {
+		return err
+	}
+	if !caps.Supports(feature) {
+		return &NotSupportedError{Feature: feature}
+	}
+	return nil
+}
+
+// ProbeCapabilities detects the site's capabilities with a request per
+// feature, bypassing any cache.
+func (c *Client) ProbeCapabilities(ctx context.Context) (*Capabilities, error) {
+	caps := &Capabilities{Site: c.baseURL, ProbedAt: time.Now().UTC()}
+
+	var err error
+	if caps.V2, err = c.probe(ctx, "/api/v2/spaces?limit=1"); err != nil {
+		return nil, err
+	}
+	if caps.V2 {
+		if caps.ADF, err = c.probe(ctx, "/api/v2/pages?limit=1&body-format=atlas_doc_format"); err != nil {
+			return nil, err
+		}
+		// Public links only exist on Confluence Cloud, which is also the
+		// only deployment with the v2 API
+		caps.PublicLinks = true
+	}
+	if caps.ContentStates, err = c.probe(ctx, "/rest/api/content-states"); err != nil {
+		return nil, err
+	}
+
+	return caps, nil
+}
+
+// probe reports whether a GET of path succeeds. Responses saying the
+// endpoint doesn't exist or can't serve the request mean false; other
+// failures, such as bad credentials, are returned as errors.
+func (c *Client) probe(ctx context.Context, path string) (bool, error) {
+	// Call doOnce directly so the probe isn't itself mapped to a
+	// NotSupportedError
+	_, _, err := c.doOnce(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err == nil {
+		return true, nil
+	}
+
+	var apiErr *ErrorResponse
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("failed to probe site capabilities: %w", err)
+}
+
+// notSupported returns a NotSupportedError for a 404 from a v2 endpoint on
+// a site without the v2 API, or nil.
+func (c *Client) notSupported(ctx context.Context, path string, err error) error {
+	if c.capCachePath == "" || !strings.HasPrefix(path, "/api/v2/") {
+		return nil
+	}
+	var apiErr *ErrorResponse
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		return nil
+	}
+	if caps, capErr := c.Capabilities(ctx); capErr == nil && !caps.V2 {
+		return &NotSupportedError{Feature: FeatureV2}
+	}
+	return nil
+}
+
+// loadCapabilityCache reads the capability cache, returning nil if there
+// is none.
+func (c *Client) loadCapabilityCache() map[string]*Capabilities {
+	if c.capCachePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(c.capCachePath)
+	if err != nil {
+		return nil
+	}
+	var cache map[string]*Capabilities
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil
+	}
+	return cache
+}
+
+// saveCapabilityCache writes the capability cache to path.
+func saveCapabilityCache(path string, cache map[string]*Capabilities) error {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
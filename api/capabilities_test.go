@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSite serves the capability probe endpoints. Endpoints not in ok
+// answer with an HTML 404, as Data Center does for unknown REST paths.
+func mockSite(t *testing.T, probes *int32, ok ...string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(probes, 1)
+		for _, path := range ok {
+			if r.URL.Path == path {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"results": []}`))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`<html><body>Not Found</body></html>`))
+	}))
+}
+
+func TestProbeCapabilities_Cloud(t *testing.T) {
+	var probes int32
+	server := mockSite(t, &probes, "/api/v2/spaces", "/api/v2/pages", "/rest/api/content-states")
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	caps, err := client.ProbeCapabilities(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, server.URL, caps.Site)
+	assert.True(t, caps.V2)
+	assert.True(t, caps.ADF)
+	assert.True(t, caps.PublicLinks)
+	assert.True(t, caps.ContentStates)
+	assert.False(t, caps.ProbedAt.IsZero())
+	assert.Equal(t, DeploymentCloud, caps.Deployment())
+}
+
+func TestProbeCapabilities_DataCenter(t *testing.T) {
+	var probes int32
+	server := mockSite(t, &probes)
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	caps, err := client.ProbeCapabilities(context.Background())
+	require.NoError(t, err)
+
+	assert.False(t, caps.V2)
+	assert.False(t, caps.ADF)
+	assert.False(t, caps.PublicLinks)
+	assert.False(t, caps.ContentStates)
+	assert.Equal(t, int32(2), probes, "ADF isn't probed without v2")
+	assert.Equal(t, DeploymentDataCenter, caps.Deployment())
+}
+
+func TestProbeCapabilities_AuthError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message": "Unauthorized"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	_, err := client.ProbeCapabilities(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to probe site capabilities")
+}
+
+func TestCapabilities_Cache(t *testing.T) {
+	var probes int32
+	server := mockSite(t, &probes, "/api/v2/spaces")
+	defer server.Close()
+
+	cachePath := filepath.Join(t.TempDir(), "cfl", "capabilities.json")
+
+	client := NewClient(server.URL, "user@example.com", "token", WithCapabilityCache(cachePath))
+	caps, err := client.Capabilities(context.Background())
+	require.NoError(t, err)
+	assert.True(t, caps.V2)
+	firstProbes := atomic.LoadInt32(&probes)
+
+	// A new client for the same site reads the cache
+	client = NewClient(server.URL, "user@example.com", "token", WithCapabilityCache(cachePath))
+	caps, err = client.Capabilities(context.Background())
+	require.NoError(t, err)
+	assert.True(t, caps.V2)
+	assert.Equal(t, firstProbes, atomic.LoadInt32(&probes))
+
+	// Refreshing probes again
+	_, err = client.RefreshCapabilities(context.Background())
+	require.NoError(t, err)
+	assert.Greater(t, atomic.LoadInt32(&probes), firstProbes)
+}
+
+func TestRequire(t *testing.T) {
+	var probes int32
+	server := mockSite(t, &probes, "/api/v2/spaces")
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	require.NoError(t, client.Require(context.Background(), FeatureV2))
+
+	err := client.Require(context.Background(), FeatureADF)
+	var nsErr *NotSupportedError
+	require.True(t, errors.As(err, &nsErr))
+	assert.Equal(t, FeatureADF, nsErr.Feature)
+	assert.Contains(t, err.Error(), "not supported on this site")
+}
+
+func TestNotSupported_V2Request(t *testing.T) {
+	var probes int32
+	server := mockSite(t, &probes)
+	defer server.Close()
+
+	cachePath := filepath.Join(t.TempDir(), "capabilities.json")
+
+	// Without a capability cache the raw 404 is returned
+	client := NewClient(server.URL, "user@example.com", "token")
+	_, err := client.GetPage(context.Background(), "12345", nil)
+	var apiErr *ErrorResponse
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+	client = NewClient(server.URL, "user@example.com", "token", WithCapabilityCache(cachePath))
+	_, err = client.GetPage(context.Background(), "12345", nil)
+	require.Error(t, err)
+	assert.Equal(t, "the v2 REST API: not supported on this site", strings.TrimPrefix(err.Error(), "failed to get page: "))
+}
+
+func TestNotSupported_V2SiteKeeps404(t *testing.T) {
+	var probes int32
+	server := mockSite(t, &probes, "/api/v2/spaces")
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", WithCapabilityCache(filepath.Join(t.TempDir(), "caps.json")))
+	_, err := client.GetPage(context.Background(), "12345", nil)
+	var apiErr *ErrorResponse
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
@@ -1,19 +1,29 @@
-// Package api provides a client for the Confluence REST API.
 package api
 
 import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const (
-	defaultTimeout = 30 * time.Second
+	defaultTimeout    = 30 * time.Second
+	defaultRetryDelay = 500 * time.Millisecond
+	defaultPollDelay  = 2 * time.Second
+	maxRetryDelay     = 30 * time.Second // Cap on exponential backoff
+	maxRetryAfter     = 2 * time.Minute  // Cap on waits requested by Retry-After
+	maxLoggedBody     = 4 << 10          // Bytes of each body logged by WithBodyLogging
 )
 
 // Client is the Confluence Cloud API client.
@@ -22,18 +32,132 @@ type Client struct {
 	email      string
 	apiToken   string
 	httpClient *http.Client
+	logger     *slog.Logger
+	logBodies  bool
+	maxRetries int
+	retryDelay time.Duration
+	pollDelay  time.Duration
+	quietEdits bool // Save page updates as minor edits
+
+	capCachePath string
+	caps         *Capabilities
+
+	respCacheDir string // Directory of cached GET responses; empty to disable
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithTimeout sets the per-request timeout (default 30s).
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = d
+	}
+}
+
+// WithHTTPClient replaces the underlying HTTP client with a copy of hc.
+// Options applied after this one (such as WithTimeout) modify the copy, so hc
+// itself is left unchanged.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		copied := *hc
+		c.httpClient = &copied
+	}
+}
+
+// WithTransport sets the HTTP transport used for requests.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = rt
+	}
+}
+
+// WithLogger logs each HTTP request and response at debug level.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// WithBodyLogging adds request and response bodies to the log written by
+// WithLogger. Only text and JSON bodies are logged, truncated to 4 KiB, with
+// the values of credential-like fields redacted.
+func WithBodyLogging() Option {
+	return func(c *Client) {
+		c.logBodies = true
+	}
+}
+
+// WithRetry retries idempotent requests (GET, PUT, DELETE) up to maxRetries
+// times when they fail with a network error or a 502/503/504 response. PUTs
+// that carry a version number are not retried, as a lost response to an
+// applied update would make the retry fail with a version conflict.
+// Requests of any method rejected with 429 Too Many Requests are retried
+// too, as the server did not process them, and so are page creates, after
+// checking the failed attempt didn't create the page.
+//
+// Retries wait for the server's Retry-After if it sends one, and otherwise
+// for an exponentially growing, jittered delay. Retries are disabled by
+// default.
+func WithRetry(maxRetries int) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithNotifyWatchers sets whether page updates notify the page's watchers
+// (default true). When false, updates are saved as minor edits, for which
+// Confluence sends no notifications. Label changes never notify watchers.
+func WithNotifyWatchers(notify bool) Option {
+	return func(c *Client) {
+		c.quietEdits = !notify
+	}
 }
 
 // NewClient creates a new Confluence API client.
-func NewClient(baseURL, email, apiToken string) *Client {
-	return &Client{
+func NewClient(baseURL, email, apiToken string, opts ...Option) *Client {
+	c := &Client{
 		baseURL:  strings.TrimSuffix(baseURL, "/"),
 		email:    email,
 		apiToken: apiToken,
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
+		retryDelay: defaultRetryDelay,
+		pollDelay:  defaultPollDelay,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// Log at the transport level so uploads and downloads are covered too
+	if c.logger != nil {
+		c.httpClient.Transport = &loggingTransport{next: c.httpClient.Transport, logger: c.logger, bodies: c.logBodies}
 	}
+
+	return c
+}
+
+// BaseURL returns the Confluence base URL the client was created with.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// resolveURL returns the absolute URL of ref. Absolute URLs are returned
+// unchanged; paths that already include the base URL's path (such as
+// "/wiki/download/...") are resolved against its host.
+func (c *Client) resolveURL(ref string) string {
+	if strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") {
+		return ref
+	}
+	if !strings.HasPrefix(ref, "/") {
+		ref = "/" + ref
+	}
+	if base, err := url.Parse(c.baseURL); err == nil && base.Path != "" && strings.HasPrefix(ref, base.Path+"/") {
+		return base.Scheme + "://" + base.Host + ref
+	}
+	return c.baseURL + ref
 }
 
 // do executes an HTTP request and returns the response body.
@@ -45,18 +169,90 @@ func (c *Client) do(ctx context.Context, method, path string, body interface{})
 
 	url := c.baseURL + path
 
-	var reqBody io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		respBody, retryable, err := c.doOnce(ctx, method, url, jsonBody)
+		if err == nil || !retryable || attempt >= c.maxRetries || !(isIdempotent(method, jsonBody) || isRateLimited(err)) {
+			if nsErr := c.notSupported(ctx, path, err); nsErr != nil {
+				return nil, nsErr
+			}
+			return respBody, err
+		}
+
+		if !c.backoff(ctx, attempt, err) {
+			return nil, err
+		}
+	}
+}
+
+// backoff waits before retrying an attempt that failed with err, returning
+// false if ctx is done first.
+func (c *Client) backoff(ctx context.Context, attempt int, err error) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(c.retryWait(attempt, err)):
+		return true
+	}
+}
+
+// retryWait returns how long to wait before retrying an attempt that failed
+// with err: the server's Retry-After if it sent one, or else a delay that
+// doubles with each attempt. The delay is jittered so that concurrent
+// clients backing off together don't retry in lockstep.
+func (c *Client) retryWait(attempt int, err error) time.Duration {
+	var apiErr *ErrorResponse
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return min(apiErr.RetryAfter, maxRetryAfter)
+	}
+
+	d := maxRetryDelay
+	if attempt < 16 {
+		d = min(c.retryDelay<<attempt, maxRetryDelay)
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// isRateLimited reports whether err is a 429 Too Many Requests response.
+func isRateLimited(err error) bool {
+	var apiErr *ErrorResponse
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// parseRetryAfter parses a Retry-After header, given either as seconds or
+// as an HTTP date, into a wait from now. It returns 0 if there is none.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return max(0, time.Duration(secs)*time.Second)
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return max(0, t.Sub(now))
+	}
+	return 0
+}
+
+// doOnce performs a single HTTP attempt. retryable reports whether the
+// failure is transient and the request may be retried.
+func (c *Client) doOnce(ctx context.Context, method, url string, jsonBody []byte) (respBody []byte, retryable bool, err error) {
+	var reqBody io.Reader
+	if jsonBody != nil {
 		reqBody = bytes.NewReader(jsonBody)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
@@ -64,28 +260,160 @@ func (c *Client) do(ctx context.Context, method, path string, body interface{})
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 
+	var cached *cachedResponse
+	if method == http.MethodGet && c.respCacheDir != "" {
+		if cached = c.loadResponse(url); cached != nil {
+			setConditionalHeaders(req, cached)
+		}
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, ctx.Err() == nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, true, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return cached.Body, false, nil
 	}
 
 	// Handle error responses
 	if resp.StatusCode >= 400 {
+		retryable = resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode == http.StatusBadGateway ||
+			resp.StatusCode == http.StatusServiceUnavailable ||
+			resp.StatusCode == http.StatusGatewayTimeout
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+
 		var errResp ErrorResponse
 		if err := json.Unmarshal(respBody, &errResp); err != nil {
-			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+			// Keep the status for non-JSON errors, such as HTML 404 pages
+			return nil, retryable, &ErrorResponse{
+				StatusCode: resp.StatusCode,
+				Message:    fmt.Sprintf("API error (status %d): %s", resp.StatusCode, string(respBody)),
+				RetryAfter: retryAfter,
+			}
 		}
 		errResp.StatusCode = resp.StatusCode
-		return nil, &errResp
+		errResp.RetryAfter = retryAfter
+		return nil, retryable, &errResp
+	}
+
+	if method == http.MethodGet && c.respCacheDir != "" {
+		c.storeResponse(url, resp.Header, respBody)
 	}
 
-	return respBody, nil
+	return respBody, false, nil
+}
+
+// isIdempotent reports whether a request with this method and JSON body is
+// safe to repeat. PUTs that set a version number are not: if the first
+// attempt was applied, repeating it conflicts with the version it created.
+func isIdempotent(method string, body []byte) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		return true
+	case http.MethodPut:
+		return !hasVersionNumber(body)
+	}
+	return false
+}
+
+// hasVersionNumber reports whether a JSON request body sets version.number.
+func hasVersionNumber(body []byte) bool {
+	var req struct {
+		Version *struct {
+			Number *int `json:"number"`
+		} `json:"version"`
+	}
+	if len(body) == 0 || json.Unmarshal(body, &req) != nil {
+		return false
+	}
+	return req.Version != nil && req.Version.Number != nil
+}
+
+// loggingTransport logs requests and responses to a structured logger.
+type loggingTransport struct {
+	next   http.RoundTripper
+	logger *slog.Logger
+	bodies bool // Log request and response bodies
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	attrs := []any{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.Redacted()),
+	}
+	if t.bodies && req.GetBody != nil && isTextContent(req.Header.Get("Content-Type")) {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+			_ = body.Close()
+			attrs = append(attrs, slog.String("request_body", redactBody(data)))
+		}
+	}
+
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+	if err != nil {
+		t.logger.DebugContext(req.Context(), "http request failed", append(attrs, slog.String("error", err.Error()))...)
+		return nil, err
+	}
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+
+	// Only the logged prefix is read; the caller still gets the whole body
+	if t.bodies && isTextContent(resp.Header.Get("Content-Type")) {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBody+1))
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+		attrs = append(attrs, slog.String("response_body", redactBody(data)))
+	}
+
+	t.logger.DebugContext(req.Context(), "http request", attrs...)
+	return resp, nil
+}
+
+// isTextContent reports whether a body of the given content type is
+// readable in a log.
+func isTextContent(contentType string) bool {
+	return strings.HasPrefix(contentType, "text/") ||
+		strings.Contains(contentType, "json") ||
+		strings.Contains(contentType, "xml") ||
+		strings.HasPrefix(contentType, "application/x-www-form-urlencoded")
+}
+
+// secretJSONField and secretFormValue match JSON string fields and form
+// values whose names look like they hold credentials.
+var (
+	secretJSONField = regexp.MustCompile(`(?i)("[^"]*(?:token|password|secret|authorization|api_?key)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	secretFormValue = regexp.MustCompile(`(?i)\b([\w.-]*(?:token|password|secret|api_?key)[\w.-]*=)[^&\s"]*`)
+)
+
+// redactBody returns a body for logging, with credential-like values
+// replaced and anything beyond maxLoggedBody cut off.
+func redactBody(data []byte) string {
+	truncated := len(data) > maxLoggedBody
+	if truncated {
+		data = data[:maxLoggedBody]
+	}
+	s := secretJSONField.ReplaceAllString(string(data), `$1"REDACTED"`)
+	s = secretFormValue.ReplaceAllString(s, `${1}REDACTED`)
+	if truncated {
+		s += "…(truncated)"
+	}
+	return s
 }
 
 // Get performs a GET request.
@@ -1,12 +1,17 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -159,3 +164,283 @@ func TestClient_URLConstruction(t *testing.T) {
 		assert.Equal(t, tt.expectedPath, capturedPath)
 	}
 }
+
+func TestNewClient_Options(t *testing.T) {
+	transport := &http.Transport{}
+	client := NewClient("https://example.atlassian.net/wiki/", "user@example.com", "token",
+		WithTimeout(5*time.Second),
+		WithTransport(transport),
+		WithRetry(2),
+	)
+
+	assert.Equal(t, "https://example.atlassian.net/wiki", client.BaseURL())
+	assert.Equal(t, 5*time.Second, client.httpClient.Timeout)
+	assert.Same(t, transport, client.httpClient.Transport)
+	assert.Equal(t, 2, client.maxRetries)
+}
+
+func TestNewClient_WithHTTPClient(t *testing.T) {
+	transport := &http.Transport{}
+	hc := &http.Client{Transport: transport}
+	client := NewClient("https://example.atlassian.net/wiki", "user@example.com", "token",
+		WithHTTPClient(hc), WithTimeout(5*time.Second), WithTransport(http.DefaultTransport),
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+
+	assert.Equal(t, 5*time.Second, client.httpClient.Timeout)
+	// The given client is copied, not modified
+	assert.Zero(t, hc.Timeout)
+	assert.Same(t, transport, hc.Transport)
+}
+
+func TestClient_RetryOnServiceUnavailable(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"message": "try later"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", WithRetry(3))
+	client.retryDelay = time.Millisecond
+
+	_, err := client.do(context.Background(), "GET", "/test", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestClient_RetryExhausted(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(`{"message": "bad gateway"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", WithRetry(2))
+	client.retryDelay = time.Millisecond
+
+	_, err := client.do(context.Background(), "GET", "/test", nil)
+	require.Error(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestClient_NoRetryForPost(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"message": "try later"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", WithRetry(3))
+	client.retryDelay = time.Millisecond
+
+	_, err := client.do(context.Background(), "POST", "/test", map[string]string{"a": "b"})
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestClient_NoRetryForVersionedPut(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"message": "try later"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", WithRetry(3))
+	client.retryDelay = time.Millisecond
+
+	_, err := client.do(context.Background(), "PUT", "/test", &UpdatePageRequest{ID: "1", Version: &Version{Number: 2}})
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+
+	attempts = 0
+	_, err = client.do(context.Background(), "PUT", "/test", map[string]string{"a": "b"})
+	require.Error(t, err)
+	assert.Equal(t, 4, attempts)
+}
+
+func TestClient_NoRetryForClientErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", WithRetry(3))
+	client.retryDelay = time.Millisecond
+
+	_, err := client.do(context.Background(), "GET", "/test", nil)
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestClient_RetryOnRateLimit(t *testing.T) {
+	for _, method := range []string{"GET", "POST"} {
+		t.Run(method, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts++
+				if attempts < 2 {
+					w.WriteHeader(http.StatusTooManyRequests)
+					_, _ = w.Write([]byte(`{"message": "rate limited"}`))
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token", WithRetry(3))
+			client.retryDelay = time.Millisecond
+
+			// Rate-limited requests weren't processed, so even POSTs are retried
+			_, err := client.do(context.Background(), method, "/test", map[string]string{"a": "b"})
+			require.NoError(t, err)
+			assert.Equal(t, 2, attempts)
+		})
+	}
+}
+
+func TestClient_RetryAfterHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"message": "rate limited"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	_, err := client.do(context.Background(), "GET", "/test", nil)
+
+	var apiErr *ErrorResponse
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+	assert.Equal(t, 7*time.Second, apiErr.RetryAfter)
+	assert.Equal(t, 7*time.Second, client.retryWait(0, err))
+}
+
+func TestClient_RetryWait(t *testing.T) {
+	client := NewClient("https://example.atlassian.net/wiki", "user@example.com", "token")
+	client.retryDelay = time.Second
+
+	// Delays double with each attempt, jittered into the upper half
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second} {
+		for range 20 {
+			got := client.retryWait(attempt, errors.New("network"))
+			assert.GreaterOrEqual(t, got, want/2)
+			assert.LessOrEqual(t, got, want)
+		}
+	}
+
+	// Capped for late attempts
+	assert.LessOrEqual(t, client.retryWait(10, nil), maxRetryDelay)
+	assert.LessOrEqual(t, client.retryWait(100, nil), maxRetryDelay)
+
+	// Long Retry-After waits are capped too
+	err := &ErrorResponse{StatusCode: 429, RetryAfter: time.Hour}
+	assert.Equal(t, maxRetryAfter, client.retryWait(0, err))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"30", 30 * time.Second},
+		{"0", 0},
+		{"-5", 0},
+		{"Mon, 01 Jan 2024 12:00:45 GMT", 45 * time.Second},
+		{"Mon, 01 Jan 2024 11:00:00 GMT", 0},
+		{"soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseRetryAfter(tt.header, now))
+		})
+	}
+}
+
+func TestClient_WithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(server.URL, "user@example.com", "token", WithLogger(logger))
+
+	_, err := client.do(context.Background(), "GET", "/api/v2/spaces", nil)
+	require.NoError(t, err)
+
+	logged := buf.String()
+	assert.Contains(t, logged, "method=GET")
+	assert.Contains(t, logged, "/api/v2/spaces")
+	assert.Contains(t, logged, "status=200")
+	assert.NotContains(t, logged, "token")
+}
+
+func TestClient_WithBodyLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "42", "accessToken": "s3cret"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(server.URL, "user@example.com", "token", WithLogger(logger), WithBodyLogging())
+
+	body, err := client.do(context.Background(), "POST", "/api/v2/pages", map[string]string{"title": "Hello", "password": "hunter2"})
+	require.NoError(t, err)
+
+	// The response is still read in full by the caller
+	assert.JSONEq(t, `{"id": "42", "accessToken": "s3cret"}`, string(body))
+
+	logged := buf.String()
+	assert.Contains(t, logged, "request_body=")
+	assert.Contains(t, logged, "Hello")
+	assert.Contains(t, logged, "response_body=")
+	assert.NotContains(t, logged, "hunter2")
+	assert.NotContains(t, logged, "s3cret")
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"json", `{"api_token": "abc", "title": "x"}`, `{"api_token": "REDACTED", "title": "x"}`},
+		{"escaped quote", `{"secret":"a\"b","n":1}`, `{"secret":"REDACTED","n":1}`},
+		{"form", `user=me&password=abc&x=1`, `user=me&password=REDACTED&x=1`},
+		{"plain", `nothing to hide`, `nothing to hide`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, redactBody([]byte(tt.body)))
+		})
+	}
+
+	long := redactBody(bytes.Repeat([]byte("a"), maxLoggedBody+1))
+	assert.True(t, strings.HasSuffix(long, "…(truncated)"))
+	assert.Len(t, strings.TrimSuffix(long, "…(truncated)"), maxLoggedBody)
+}
@@ -0,0 +1,236 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Comment types.
+const (
+	CommentFooter = "footer" // Comment at the bottom of a page
+	CommentInline = "inline" // Comment on a text selection
+)
+
+// Comment represents a Confluence footer or inline comment.
+type Comment struct {
+	ID               string                   `json:"id"`
+	Status           string                   `json:"status"`
+	Title            string                   `json:"title,omitempty"`
+	PageID           string                   `json:"pageId,omitempty"`
+	ParentCommentID  string                   `json:"parentCommentId,omitempty"`
+	Version          *Version                 `json:"version,omitempty"`
+	Body             *Body                    `json:"body,omitempty"`
+	ResolutionStatus string                   `json:"resolutionStatus,omitempty"` // Inline comments: open, resolved, reopened, dangling
+	Properties       *InlineCommentProperties `json:"properties,omitempty"`
+	Links            Links                    `json:"_links,omitempty"`
+}
+
+// InlineCommentProperties describes the text an inline comment is attached to.
+type InlineCommentProperties struct {
+	OriginalSelection string `json:"inlineOriginalSelection,omitempty"`
+	MarkerRef         string `json:"inlineMarkerRef,omitempty"`
+}
+
+// ListCommentsOptions contains options for listing comments.
+type ListCommentsOptions struct {
+	Limit            int
+	Cursor           string
+	BodyFormat       string // storage, atlas_doc_format
+	ResolutionStatus string // Inline comments: open, resolved, reopened, dangling
+}
+
+// CreateCommentRequest is the request body for creating a comment.
+type CreateCommentRequest struct {
+	PageID                  string                     `json:"pageId,omitempty"`
+	ParentCommentID         string                     `json:"parentCommentId,omitempty"`
+	Body                    *BodyRepresentation        `json:"body"`
+	InlineCommentProperties *NewInlineCommentSelection `json:"inlineCommentProperties,omitempty"`
+}
+
+// NewInlineCommentSelection identifies the text a new inline comment is
+// attached to: the MatchIndex'th (zero-based) of MatchCount occurrences of
+// TextSelection in the page.
+type NewInlineCommentSelection struct {
+	TextSelection           string `json:"textSelection"`
+	TextSelectionMatchCount int    `json:"textSelectionMatchCount"`
+	TextSelectionMatchIndex int    `json:"textSelectionMatchIndex"`
+}
+
+// updateCommentRequest is the request body for updating a comment.
+type updateCommentRequest struct {
+	Version  *Version            `json:"version"`
+	Body     *BodyRepresentation `json:"body"`
+	Resolved *bool               `json:"resolved,omitempty"`
+}
+
+// commentPath returns the v2 collection path for a comment type.
+func commentPath(kind string) (string, error) {
+	switch kind {
+	case CommentFooter:
+		return "/api/v2/footer-comments", nil
+	case CommentInline:
+		return "/api/v2/inline-comments", nil
+	}
+	return "", fmt.Errorf("invalid comment type %q", kind)
+}
+
+// ListPageComments returns the top-level comments of one type on a page.
+func (c *Client) ListPageComments(ctx context.Context, pageID, kind string, opts *ListCommentsOptions) (*PaginatedResponse[Comment], error) {
+	if _, err := commentPath(kind); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/api/v2/pages/%s/%s-comments?%s", pageID, kind, commentParams(opts).Encode())
+	return c.listComments(ctx, path)
+}
+
+// ListCommentReplies returns the replies to a comment.
+func (c *Client) ListCommentReplies(ctx context.Context, kind, commentID string, opts *ListCommentsOptions) (*PaginatedResponse[Comment], error) {
+	base, err := commentPath(kind)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/children?%s", base, commentID, commentParams(opts).Encode())
+	return c.listComments(ctx, path)
+}
+
+func (c *Client) listComments(ctx context.Context, path string) (*PaginatedResponse[Comment], error) {
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PaginatedResponse[Comment]
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse comments response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// commentParams builds query parameters for listing comments.
+func commentParams(opts *ListCommentsOptions) url.Values {
+	params := url.Values{}
+	params.Set("limit", "25") // Default limit
+
+	if opts != nil {
+		if opts.Limit > 0 {
+			params.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.Cursor != "" {
+			params.Set("cursor", opts.Cursor)
+		}
+		if opts.BodyFormat != "" {
+			params.Set("body-format", opts.BodyFormat)
+		}
+		if opts.ResolutionStatus != "" {
+			params.Set("resolution-status", opts.ResolutionStatus)
+		}
+	}
+	return params
+}
+
+// GetComment returns a single comment with its storage format body.
+func (c *Client) GetComment(ctx context.Context, kind, commentID string) (*Comment, error) {
+	base, err := commentPath(kind)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.Get(ctx, fmt.Sprintf("%s/%s?body-format=storage", base, commentID))
+	if err != nil {
+		return nil, err
+	}
+
+	var comment Comment
+	if err := json.Unmarshal(body, &comment); err != nil {
+		return nil, fmt.Errorf("failed to parse comment response: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// GetCommentVersion returns one version of a comment. Version 1 records who
+// created the comment; the comment's own Version is its latest edit.
+func (c *Client) GetCommentVersion(ctx context.Context, kind, commentID string, version int) (*Version, error) {
+	base, err := commentPath(kind)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.Get(ctx, fmt.Sprintf("%s/%s/versions/%d", base, commentID, version))
+	if err != nil {
+		return nil, err
+	}
+
+	var result Version
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse comment version response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// CreateComment creates a comment, or a reply if ParentCommentID is set.
+// New inline comments need InlineCommentProperties; replies inherit their
+// parent's selection.
+func (c *Client) CreateComment(ctx context.Context, kind string, req *CreateCommentRequest) (*Comment, error) {
+	path, err := commentPath(kind)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.Post(ctx, path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment Comment
+	if err := json.Unmarshal(body, &comment); err != nil {
+		return nil, fmt.Errorf("failed to parse comment response: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// ResolveComment resolves or reopens an inline comment.
+func (c *Client) ResolveComment(ctx context.Context, commentID string, resolved bool) (*Comment, error) {
+	current, err := c.GetComment(ctx, CommentInline, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The update replaces the body, so the current one is sent back unchanged
+	req := updateCommentRequest{
+		Version:  &Version{Number: 1},
+		Body:     &BodyRepresentation{Representation: "storage"},
+		Resolved: &resolved,
+	}
+	if current.Version != nil {
+		req.Version.Number = current.Version.Number + 1
+	}
+	if current.Body != nil && current.Body.Storage != nil {
+		req.Body.Value = current.Body.Storage.Value
+	}
+
+	body, err := c.Put(ctx, fmt.Sprintf("/api/v2/inline-comments/%s", commentID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment Comment
+	if err := json.Unmarshal(body, &comment); err != nil {
+		return nil, fmt.Errorf("failed to parse comment response: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// DeleteComment deletes a comment.
+func (c *Client) DeleteComment(ctx context.Context, kind, commentID string) error {
+	base, err := commentPath(kind)
+	if err != nil {
+		return err
+	}
+	_, err = c.Delete(ctx, fmt.Sprintf("%s/%s", base, commentID))
+	return err
+}
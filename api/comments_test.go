@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListPageComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/pages/12345/inline-comments", r.URL.Path)
+		assert.Equal(t, "storage", r.URL.Query().Get("body-format"))
+		assert.Equal(t, "open", r.URL.Query().Get("resolution-status"))
+
+		_, _ = w.Write([]byte(`{"results": [{
+			"id": "1",
+			"status": "current",
+			"pageId": "12345",
+			"resolutionStatus": "open",
+			"properties": {"inlineOriginalSelection": "some text", "inlineMarkerRef": "abc"},
+			"body": {"storage": {"value": "<p>Hi</p>"}}
+		}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	result, err := client.ListPageComments(context.Background(), "12345", CommentInline, &ListCommentsOptions{BodyFormat: "storage", ResolutionStatus: "open"})
+
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+	assert.Equal(t, "open", result.Results[0].ResolutionStatus)
+	assert.Equal(t, "some text", result.Results[0].Properties.OriginalSelection)
+	assert.Equal(t, "<p>Hi</p>", result.Results[0].Body.Storage.Value)
+}
+
+func TestClient_ListCommentReplies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/footer-comments/1/children", r.URL.Path)
+		_, _ = w.Write([]byte(`{"results": [{"id": "2", "parentCommentId": "1"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	result, err := client.ListCommentReplies(context.Background(), CommentFooter, "1", nil)
+
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+	assert.Equal(t, "1", result.Results[0].ParentCommentID)
+}
+
+func TestClient_GetCommentVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/inline-comments/5/versions/1", r.URL.Path)
+		_, _ = w.Write([]byte(`{"number": 1, "authorId": "creator", "createdAt": "2024-01-02T03:04:05.000Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	version, err := client.GetCommentVersion(context.Background(), CommentInline, "5", 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, version.Number)
+	assert.Equal(t, "creator", version.AuthorID)
+}
+
+func TestClient_CreateComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/inline-comments", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var req CreateCommentRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "12345", req.PageID)
+		assert.Equal(t, "storage", req.Body.Representation)
+		require.NotNil(t, req.InlineCommentProperties)
+		assert.Equal(t, "the text", req.InlineCommentProperties.TextSelection)
+		assert.Equal(t, 1, req.InlineCommentProperties.TextSelectionMatchCount)
+
+		_, _ = w.Write([]byte(`{"id": "9", "pageId": "12345"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	comment, err := client.CreateComment(context.Background(), CommentInline, &CreateCommentRequest{
+		PageID: "12345",
+		Body:   &BodyRepresentation{Representation: "storage", Value: "<p>Note</p>"},
+		InlineCommentProperties: &NewInlineCommentSelection{
+			TextSelection:           "the text",
+			TextSelectionMatchCount: 1,
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "9", comment.ID)
+}
+
+func TestClient_ResolveComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/inline-comments/7", r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"id": "7", "version": {"number": 2}, "body": {"storage": {"value": "<p>Fix this</p>"}}}`))
+		case http.MethodPut:
+			var req map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, true, req["resolved"])
+			assert.Equal(t, float64(3), req["version"].(map[string]interface{})["number"])
+			assert.Equal(t, "<p>Fix this</p>", req["body"].(map[string]interface{})["value"])
+			_, _ = w.Write([]byte(`{"id": "7", "resolutionStatus": "resolved"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	comment, err := client.ResolveComment(context.Background(), "7", true)
+
+	require.NoError(t, err)
+	assert.Equal(t, "resolved", comment.ResolutionStatus)
+}
+
+func TestClient_DeleteComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/footer-comments/7", r.URL.Path)
+		assert.Equal(t, "DELETE", r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	err := client.DeleteComment(context.Background(), CommentFooter, "7")
+
+	require.NoError(t, err)
+}
+
+func TestClient_Comments_InvalidType(t *testing.T) {
+	client := NewClient("http://unused", "user@example.com", "token")
+
+	_, err := client.ListPageComments(context.Background(), "1", "page", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid comment type")
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"context"
+)
+
+// Database represents a Confluence database.
+type Database = ContentItem
+
+// ListDatabases returns a page of databases. The v2 API has no listing
+// endpoint for databases, so this searches for them with CQL.
+func (c *Client) ListDatabases(ctx context.Context, opts *ListContentOptions) (*SearchResponse, error) {
+	return c.Search(ctx, contentSearch("database", opts))
+}
+
+// GetDatabase returns a database's metadata. The API doesn't expose the
+// entries of a database.
+// Uses the v2 REST API: GET /api/v2/databases/{id}
+func (c *Client) GetDatabase(ctx context.Context, databaseID string) (*Database, error) {
+	return c.getContentItem(ctx, "databases", databaseID)
+}
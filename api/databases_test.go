@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetDatabase(t *testing.T) {
+	const response = `{"id": "888", "type": "database", "status": "current", "title": "Roadmap",
+		"spaceId": "777", "version": {"number": 2}, "_links": {"webui": "/spaces/DEV/database/888"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v2/databases/888", r.URL.Path)
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	database, err := client.GetDatabase(context.Background(), "888")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Roadmap", database.Title)
+	assert.Equal(t, "database", database.Type)
+	assert.Equal(t, 2, database.Version.Number)
+	assert.JSONEq(t, response, string(database.Raw))
+}
+
+func TestClient_ListDatabases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/search", r.URL.Path)
+		assert.Equal(t, `space = "DEV" AND type = "database"`, r.URL.Query().Get("cql"))
+		assert.Equal(t, "50", r.URL.Query().Get("limit"))
+		_, _ = w.Write([]byte(`{"results": [{"content": {"id": "888", "type": "database", "title": "Roadmap"}}], "size": 1, "totalSize": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	result, err := client.ListDatabases(context.Background(), &ListContentOptions{SpaceKey: "DEV", Limit: 50})
+
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+	assert.Equal(t, "Roadmap", result.Results[0].Content.Title)
+}
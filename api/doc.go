@@ -0,0 +1,23 @@
+// Package api provides a client for the Confluence Cloud REST API.
+//
+// The package is usable on its own by other Go programs:
+//
+//	client := api.NewClient(
+//		"https://example.atlassian.net/wiki",
+//		"user@example.com",
+//		os.Getenv("CFL_API_TOKEN"),
+//		api.WithTimeout(10*time.Second),
+//		api.WithRetry(3),
+//	)
+//	page, err := client.GetPage(ctx, "12345", &api.GetPageOptions{BodyFormat: "storage"})
+//
+// Exported identifiers follow semantic versioning with the cfl module:
+// breaking changes to them are only made in a major release. Options are
+// added as new Option functions so existing NewClient calls keep compiling.
+//
+// The service interfaces (PageService, SpaceService and so on) describe
+// subsets of *Client so callers can substitute fakes in tests. They are not
+// covered by the promise above: methods are added to them as *Client grows,
+// which breaks implementations outside this module. Prefer defining a smaller
+// interface at the point of use when only a few methods are needed.
+package api
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// exportTaskIDPattern finds the task ID in the PDF export action's page.
+var exportTaskIDPattern = regexp.MustCompile(`name="ajs-taskId"\s+content="([^"]+)"`)
+
+// ExportTask is the progress of a long-running export task.
+type ExportTask struct {
+	State    string `json:"state"`
+	Progress int    `json:"progress"`
+	Result   string `json:"result"` // Download URL, set when the export is done
+}
+
+// Failed reports whether the export task failed.
+func (t *ExportTask) Failed() bool {
+	return strings.EqualFold(t.State, "FAILED")
+}
+
+// ExportPageWord exports a page as a Word document.
+// Uses the legacy export action as the REST APIs don't support exports.
+func (c *Client) ExportPageWord(ctx context.Context, pageID string) (io.ReadCloser, error) {
+	return c.download(ctx, "/exportword?pageId="+url.QueryEscape(pageID))
+}
+
+// StartPDFExport starts exporting a page as a PDF and returns the export task ID.
+// Uses the legacy export action as the REST APIs don't support exports.
+func (c *Client) StartPDFExport(ctx context.Context, pageID string) (string, error) {
+	body, err := c.download(ctx, "/spaces/flyingpdf/pdfpageexport.action?pageId="+url.QueryEscape(pageID))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = body.Close() }()
+
+	page, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read export response: %w", err)
+	}
+
+	m := exportTaskIDPattern.FindSubmatch(page)
+	if m == nil {
+		return "", fmt.Errorf("PDF export did not return a task ID")
+	}
+	return string(m[1]), nil
+}
+
+// GetExportTask returns the progress of an export task.
+func (c *Client) GetExportTask(ctx context.Context, taskID string) (*ExportTask, error) {
+	path := fmt.Sprintf("/services/api/v1/task/%s/progress", url.PathEscape(taskID))
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var task ExportTask
+	if err := json.Unmarshal(body, &task); err != nil {
+		return nil, fmt.Errorf("failed to parse export task response: %w", err)
+	}
+
+	return &task, nil
+}
+
+// ExportPagePDF exports a page as a PDF, waiting for the export task to
+// finish before downloading the result.
+func (c *Client) ExportPagePDF(ctx context.Context, pageID string) (io.ReadCloser, error) {
+	taskID, err := c.StartPDFExport(ctx, pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start PDF export: %w", err)
+	}
+
+	var result string
+	err = c.poll(ctx, 0, func() (bool, error) {
+		task, err := c.GetExportTask(ctx, taskID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get PDF export progress: %w", err)
+		}
+		if task.Failed() {
+			return false, fmt.Errorf("PDF export failed")
+		}
+		result = task.Result
+		return result != "", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.download(ctx, result)
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ExportPageWord(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wiki/exportword", r.URL.Path)
+		assert.Equal(t, "12345", r.URL.Query().Get("pageId"))
+		assert.Equal(t, "no-check", r.Header.Get("X-Atlassian-Token"))
+		_, _ = w.Write([]byte("DOC"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/wiki", "user@example.com", "token")
+	body, err := client.ExportPageWord(context.Background(), "12345")
+	require.NoError(t, err)
+	defer body.Close()
+
+	data, _ := io.ReadAll(body)
+	assert.Equal(t, "DOC", string(data))
+}
+
+func TestClient_ExportPagePDF(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/spaces/flyingpdf/pdfpageexport.action":
+			assert.Equal(t, "12345", r.URL.Query().Get("pageId"))
+			_, _ = w.Write([]byte(`<html><head><meta name="ajs-taskId" content="task-1"></head></html>`))
+		case "/wiki/services/api/v1/task/task-1/progress":
+			polls++
+			if polls < 2 {
+				_, _ = w.Write([]byte(`{"state": "IN_PROGRESS", "progress": 50}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"state": "UPLOADED_TO_S3", "progress": 100, "result": "/wiki/download/temp/export.pdf"}`))
+		case "/wiki/download/temp/export.pdf":
+			_, _ = w.Write([]byte("%PDF"))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/wiki", "user@example.com", "token")
+	client.pollDelay = 0
+	body, err := client.ExportPagePDF(context.Background(), "12345")
+	require.NoError(t, err)
+	defer body.Close()
+
+	data, _ := io.ReadAll(body)
+	assert.Equal(t, "%PDF", string(data))
+	assert.Equal(t, 2, polls)
+}
+
+func TestClient_ExportPagePDF_Failed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/spaces/flyingpdf/pdfpageexport.action" {
+			_, _ = w.Write([]byte(`<meta name="ajs-taskId" content="t">`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"state": "FAILED"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	_, err := client.ExportPagePDF(context.Background(), "12345")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "PDF export failed")
+}
+
+func TestClient_ExportPagePDF_NoTaskID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html></html>`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	_, err := client.ExportPagePDF(context.Background(), "12345")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did not return a task ID")
+}
+
+func TestClient_ResolveURL(t *testing.T) {
+	client := NewClient("https://example.atlassian.net/wiki", "user@example.com", "token")
+
+	assert.Equal(t, "https://example.atlassian.net/wiki/download/x", client.resolveURL("/download/x"))
+	assert.Equal(t, "https://example.atlassian.net/wiki/download/x", client.resolveURL("/wiki/download/x"))
+	assert.Equal(t, "https://s3.example.com/x", client.resolveURL("https://s3.example.com/x"))
+}
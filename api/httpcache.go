@@ -0,0 +1,97 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// cachedResponse is a GET response body stored by WithResponseCache, with
+// the validators used to revalidate it.
+type cachedResponse struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// WithResponseCache stores the bodies of GET responses that carry an ETag
+// or Last-Modified header in the directory dir, one file per URL and user.
+// Later GETs of the URL send If-None-Match and If-Modified-Since, and a
+// 304 Not Modified response is served from the stored body.
+//
+// Every request still reaches the server, so cached bodies are never
+// stale; the savings are in transfer size and server work.
+func WithResponseCache(dir string) Option {
+	return func(c *Client) {
+		c.respCacheDir = dir
+	}
+}
+
+// responseCachePath returns the file caching GET responses for url. The
+// user is part of the key, as responses depend on their permissions.
+func (c *Client) responseCachePath(url string) string {
+	sum := sha256.Sum256([]byte(c.email + "\n" + url))
+	return filepath.Join(c.respCacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// loadResponse returns the cached response for url, or nil if there is
+// none.
+func (c *Client) loadResponse(url string) *cachedResponse {
+	data, err := os.ReadFile(c.responseCachePath(url))
+	if err != nil {
+		return nil
+	}
+	var cached cachedResponse
+	if err := json.Unmarshal(data, &cached); err != nil || cached.URL != url {
+		return nil
+	}
+	return &cached
+}
+
+// setConditionalHeaders adds the validators of cached to req.
+func setConditionalHeaders(req *http.Request, cached *cachedResponse) {
+	if cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+}
+
+// storeResponse caches a successful GET response for url if it has
+// validators, and otherwise removes any stale entry for it.
+func (c *Client) storeResponse(url string, header http.Header, body []byte) {
+	path := c.responseCachePath(url)
+	cached := cachedResponse{
+		URL:          url,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		Body:         body,
+	}
+	if cached.ETag == "" && cached.LastModified == "" {
+		_ = os.Remove(path)
+		return
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return
+	}
+	// Without an entry the next GET is sent unconditionally, so write failures are dropped
+	if err := os.MkdirAll(c.respCacheDir, 0700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(c.respCacheDir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, werr := tmp.Write(data)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil || os.Rename(tmp.Name(), path) != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ResponseCache(t *testing.T) {
+	var requests, notModified int
+	body := `{"id": "1", "version": 1}`
+	etag := `"v1"`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/no-validators" {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := NewClient(server.URL, "user@example.com", "token", WithResponseCache(dir))
+	ctx := context.Background()
+
+	// The first GET is stored, the second is served from the cache
+	got, err := client.Get(ctx, "/page")
+	require.NoError(t, err)
+	assert.JSONEq(t, body, string(got))
+
+	got, err = client.Get(ctx, "/page")
+	require.NoError(t, err)
+	assert.JSONEq(t, body, string(got))
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 1, notModified)
+
+	// A changed resource is fetched and replaces the cached body
+	body, etag = `{"id": "1", "version": 2}`, `"v2"`
+	got, err = client.Get(ctx, "/page")
+	require.NoError(t, err)
+	assert.JSONEq(t, body, string(got))
+	assert.Equal(t, 1, notModified)
+	assert.Equal(t, etag, client.loadResponse(server.URL+"/page").ETag)
+
+	// Responses without validators aren't stored
+	_, err = client.Get(ctx, "/no-validators")
+	require.NoError(t, err)
+	assert.Nil(t, client.loadResponse(server.URL+"/no-validators"))
+
+	// Entries are per user
+	other := NewClient(server.URL, "other@example.com", "token", WithResponseCache(dir))
+	assert.Nil(t, other.loadResponse(server.URL+"/page"))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestClient_ResponseCache_IfModifiedSince(t *testing.T) {
+	const lastModified = "Mon, 02 Jan 2006 15:04:05 GMT"
+	var conditional string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if conditional = r.Header.Get("If-Modified-Since"); conditional == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", WithResponseCache(t.TempDir()))
+	for range 2 {
+		got, err := client.Get(context.Background(), "/content")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"ok": true}`, string(got))
+	}
+	assert.Equal(t, lastModified, conditional)
+}
+
+func TestClient_ResponseCache_OnlyGET(t *testing.T) {
+	var conditional bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = conditional || r.Header.Get("If-None-Match") != ""
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := NewClient(server.URL, "user@example.com", "token", WithResponseCache(dir))
+	for range 2 {
+		_, err := client.Put(context.Background(), "/page", map[string]string{"title": "x"})
+		require.NoError(t, err)
+	}
+	assert.False(t, conditional)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Inline task statuses.
+const (
+	InlineTaskComplete   = "complete"
+	InlineTaskIncomplete = "incomplete"
+)
+
+// InlineTask is a task (action item) in the content of a page or blog post.
+type InlineTask struct {
+	ID          string `json:"id"`
+	LocalID     string `json:"localId,omitempty"`
+	SpaceID     string `json:"spaceId,omitempty"`
+	PageID      string `json:"pageId,omitempty"`
+	BlogPostID  string `json:"blogPostId,omitempty"`
+	Status      string `json:"status"` // complete, incomplete
+	Body        *Body  `json:"body,omitempty"`
+	CreatedBy   string `json:"createdBy,omitempty"`
+	AssignedTo  string `json:"assignedTo,omitempty"`
+	CompletedBy string `json:"completedBy,omitempty"`
+	CreatedAt   Time   `json:"createdAt,omitempty"`
+	UpdatedAt   Time   `json:"updatedAt,omitempty"`
+	DueAt       Time   `json:"dueAt,omitempty"`
+	CompletedAt Time   `json:"completedAt,omitempty"`
+}
+
+// ListInlineTasksOptions contains options for listing inline tasks.
+type ListInlineTasksOptions struct {
+	SpaceIDs   []string
+	PageIDs    []string
+	AssignedTo []string // Account IDs
+	CreatedBy  []string // Account IDs
+	Status     string   // complete, incomplete; all when empty
+	BodyFormat string   // storage, atlas_doc_format
+	Limit      int
+	Cursor     string
+}
+
+// ListInlineTasks returns a page of the inline tasks matching opts.
+// Uses the v2 REST API: GET /api/v2/tasks
+func (c *Client) ListInlineTasks(ctx context.Context, opts *ListInlineTasksOptions) (*PaginatedResponse[InlineTask], error) {
+	params := url.Values{}
+	params.Set("limit", "25")
+
+	if opts != nil {
+		for _, id := range opts.SpaceIDs {
+			params.Add("space-id", id)
+		}
+		for _, id := range opts.PageIDs {
+			params.Add("page-id", id)
+		}
+		for _, id := range opts.AssignedTo {
+			params.Add("assigned-to", id)
+		}
+		for _, id := range opts.CreatedBy {
+			params.Add("created-by", id)
+		}
+		if opts.Status != "" {
+			params.Set("status", opts.Status)
+		}
+		if opts.BodyFormat != "" {
+			params.Set("body-format", opts.BodyFormat)
+		}
+		if opts.Limit > 0 {
+			params.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.Cursor != "" {
+			params.Set("cursor", opts.Cursor)
+		}
+	}
+
+	body, err := c.Get(ctx, "/api/v2/tasks?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result PaginatedResponse[InlineTask]
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse tasks response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetInlineTask returns an inline task.
+// Uses the v2 REST API: GET /api/v2/tasks/{id}
+func (c *Client) GetInlineTask(ctx context.Context, taskID string, bodyFormat string) (*InlineTask, error) {
+	path := "/api/v2/tasks/" + url.PathEscape(taskID)
+	if bodyFormat != "" {
+		path += "?body-format=" + url.QueryEscape(bodyFormat)
+	}
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var task InlineTask
+	if err := json.Unmarshal(body, &task); err != nil {
+		return nil, fmt.Errorf("failed to parse task response: %w", err)
+	}
+
+	return &task, nil
+}
+
+// SetInlineTaskStatus marks an inline task complete or incomplete.
+// Uses the v2 REST API: PUT /api/v2/tasks/{id}
+func (c *Client) SetInlineTaskStatus(ctx context.Context, taskID, status string) (*InlineTask, error) {
+	req := map[string]string{"id": taskID, "status": status}
+	body, err := c.Put(ctx, "/api/v2/tasks/"+url.PathEscape(taskID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var task InlineTask
+	if err := json.Unmarshal(body, &task); err != nil {
+		return nil, fmt.Errorf("failed to parse task response: %w", err)
+	}
+
+	return &task, nil
+}
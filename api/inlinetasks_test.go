@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListInlineTasks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v2/tasks", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, []string{"777"}, q["space-id"])
+		assert.Equal(t, []string{"u1", "u2"}, q["assigned-to"])
+		assert.Equal(t, "incomplete", q.Get("status"))
+		assert.Equal(t, "storage", q.Get("body-format"))
+		assert.Equal(t, "50", q.Get("limit"))
+		_, _ = w.Write([]byte(`{"results": [{"id": "1", "pageId": "42", "status": "incomplete",
+			"assignedTo": "u1", "dueAt": "2026-11-01T00:00:00.000Z",
+			"body": {"storage": {"representation": "storage", "value": "<span>Ship it</span>"}}}],
+			"_links": {"next": "/wiki/api/v2/tasks?cursor=abc"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	result, err := client.ListInlineTasks(context.Background(), &ListInlineTasksOptions{
+		SpaceIDs:   []string{"777"},
+		AssignedTo: []string{"u1", "u2"},
+		Status:     InlineTaskIncomplete,
+		BodyFormat: "storage",
+		Limit:      50,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+	task := result.Results[0]
+	assert.Equal(t, "42", task.PageID)
+	assert.Equal(t, "<span>Ship it</span>", task.Body.Storage.Value)
+	assert.Equal(t, 2026, task.DueAt.Year())
+	assert.Equal(t, "abc", result.NextCursor())
+}
+
+func TestClient_GetInlineTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/tasks/1", r.URL.Path)
+		assert.Equal(t, "storage", r.URL.Query().Get("body-format"))
+		_, _ = w.Write([]byte(`{"id": "1", "status": "complete", "completedBy": "u1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	task, err := client.GetInlineTask(context.Background(), "1", "storage")
+
+	require.NoError(t, err)
+	assert.Equal(t, InlineTaskComplete, task.Status)
+	assert.Equal(t, "u1", task.CompletedBy)
+}
+
+func TestClient_SetInlineTaskStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v2/tasks/1", r.URL.Path)
+		var req map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, map[string]string{"id": "1", "status": "complete"}, req)
+		_, _ = w.Write([]byte(`{"id": "1", "status": "complete"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	task, err := client.SetInlineTaskStatus(context.Background(), "1", InlineTaskComplete)
+
+	require.NoError(t, err)
+	assert.Equal(t, InlineTaskComplete, task.Status)
+}
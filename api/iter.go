@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// IterOption configures an iterator over paginated results.
+type IterOption func(*iterConfig)
+
+type iterConfig struct {
+	prefetch bool
+}
+
+// WithPrefetch requests the next page of results while the current one is
+// being consumed, so that slow consumers and slow responses overlap.
+func WithPrefetch() IterOption {
+	return func(c *iterConfig) {
+		c.prefetch = true
+	}
+}
+
+// fetchResult is one page of results fetched in the background.
+type fetchResult[T any] struct {
+	page *PaginatedResponse[T]
+	err  error
+}
+
+// paginate returns an iterator over every result of a paginated endpoint,
+// following cursors until there are none. fetch requests the page of results
+// for a cursor ("" for the first page). Iteration stops at the first error,
+// which is yielded with a zero value.
+func paginate[T any](ctx context.Context, fetch func(ctx context.Context, cursor string) (*PaginatedResponse[T], error), options []IterOption) iter.Seq2[T, error] {
+	var cfg iterConfig
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(yield func(T, error) bool) {
+		// Cancels an outstanding prefetch when the consumer stops early
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		page, err := fetch(ctx, "")
+		for {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			cursor := page.NextCursor()
+			var next chan fetchResult[T]
+			if cfg.prefetch && cursor != "" {
+				// Buffered so the fetch never blocks if iteration stops early
+				next = make(chan fetchResult[T], 1)
+				go func() {
+					p, err := fetch(ctx, cursor)
+					next <- fetchResult[T]{p, err}
+				}()
+			}
+
+			for _, item := range page.Results {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			switch {
+			case cursor == "":
+				return
+			case next != nil:
+				r := <-next
+				page, err = r.page, r.err
+			default:
+				page, err = fetch(ctx, cursor)
+			}
+		}
+	}
+}
+
+// ListAll collects every result of an iterator, stopping at the first error.
+func ListAll[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var all []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, item)
+	}
+	return all, nil
+}
+
+// PagesIter returns an iterator over all pages in a space. opts.Cursor is
+// ignored and opts.Limit sets the number of pages requested at a time.
+func (c *Client) PagesIter(ctx context.Context, spaceID string, opts *ListPagesOptions, options ...IterOption) iter.Seq2[Page, error] {
+	var base ListPagesOptions
+	if opts != nil {
+		base = *opts
+	}
+	return paginate(ctx, func(ctx context.Context, cursor string) (*PaginatedResponse[Page], error) {
+		o := base
+		o.Cursor = cursor
+		return c.ListPages(ctx, spaceID, &o)
+	}, options)
+}
+
+// SpacesIter returns an iterator over all spaces. opts.Cursor is ignored and
+// opts.Limit sets the number of spaces requested at a time.
+func (c *Client) SpacesIter(ctx context.Context, opts *ListSpacesOptions, options ...IterOption) iter.Seq2[Space, error] {
+	var base ListSpacesOptions
+	if opts != nil {
+		base = *opts
+	}
+	return paginate(ctx, func(ctx context.Context, cursor string) (*PaginatedResponse[Space], error) {
+		o := base
+		o.Cursor = cursor
+		return c.ListSpaces(ctx, &o)
+	}, options)
+}
+
+// AttachmentsIter returns an iterator over all attachments on a page.
+// opts.Cursor is ignored and opts.Limit sets the number of attachments
+// requested at a time.
+func (c *Client) AttachmentsIter(ctx context.Context, pageID string, opts *ListAttachmentsOptions, options ...IterOption) iter.Seq2[Attachment, error] {
+	var base ListAttachmentsOptions
+	if opts != nil {
+		base = *opts
+	}
+	return paginate(ctx, func(ctx context.Context, cursor string) (*PaginatedResponse[Attachment], error) {
+		o := base
+		o.Cursor = cursor
+		return c.ListAttachments(ctx, pageID, &o)
+	}, options)
+}
+
+// InlineTasksIter returns an iterator over all inline tasks matching opts.
+// opts.Cursor is ignored and opts.Limit sets the number of tasks requested
+// at a time.
+func (c *Client) InlineTasksIter(ctx context.Context, opts *ListInlineTasksOptions, options ...IterOption) iter.Seq2[InlineTask, error] {
+	var base ListInlineTasksOptions
+	if opts != nil {
+		base = *opts
+	}
+	return paginate(ctx, func(ctx context.Context, cursor string) (*PaginatedResponse[InlineTask], error) {
+		o := base
+		o.Cursor = cursor
+		return c.ListInlineTasks(ctx, &o)
+	}, options)
+}
+
+// SpacePermissionsIter returns an iterator over all permissions granted on a
+// space. opts.Cursor is ignored and opts.Limit sets the number of permissions
+// requested at a time.
+func (c *Client) SpacePermissionsIter(ctx context.Context, spaceID string, opts *ListSpacePermissionsOptions, options ...IterOption) iter.Seq2[SpacePermission, error] {
+	var base ListSpacePermissionsOptions
+	if opts != nil {
+		base = *opts
+	}
+	return paginate(ctx, func(ctx context.Context, cursor string) (*PaginatedResponse[SpacePermission], error) {
+		o := base
+		o.Cursor = cursor
+		return c.ListSpacePermissions(ctx, spaceID, &o)
+	}, options)
+}
+
+// PageLabelsIter returns an iterator over all labels on a page. opts.Cursor
+// is ignored and opts.Limit sets the number of labels requested at a time.
+func (c *Client) PageLabelsIter(ctx context.Context, pageID string, opts *ListLabelsOptions, options ...IterOption) iter.Seq2[Label, error] {
+	var base ListLabelsOptions
+	if opts != nil {
+		base = *opts
+	}
+	return paginate(ctx, func(ctx context.Context, cursor string) (*PaginatedResponse[Label], error) {
+		o := base
+		o.Cursor = cursor
+		return c.ListPageLabels(ctx, pageID, &o)
+	}, options)
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPagedServer serves three pages of two results each, linked by cursors
+// c1 and c2, and records the cursors requested.
+func mockPagedServer(t *testing.T, path string, cursors *[]string) *httptest.Server {
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, path, r.URL.Path)
+		cursor := r.URL.Query().Get("cursor")
+		mu.Lock()
+		*cursors = append(*cursors, cursor)
+		mu.Unlock()
+
+		var first int
+		var next string
+		switch cursor {
+		case "":
+			first, next = 1, path+"?cursor=c1"
+		case "c1":
+			first, next = 3, path+"?cursor=c2"
+		case "c2":
+			first = 5
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"results": [{"id": "%d"}, {"id": "%d"}], "_links": {"next": %q}}`, first, first+1, next)
+	}))
+}
+
+func TestListAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []IterOption
+	}{
+		{"sequential", nil},
+		{"prefetch", []IterOption{WithPrefetch()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cursors []string
+			server := mockPagedServer(t, "/api/v2/spaces/100/pages", &cursors)
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token")
+			pages, err := ListAll(client.PagesIter(context.Background(), "100", &ListPagesOptions{Limit: 2, Cursor: "ignored"}, tt.options...))
+			require.NoError(t, err)
+
+			var ids []string
+			for _, p := range pages {
+				ids = append(ids, p.ID)
+			}
+			assert.Equal(t, []string{"1", "2", "3", "4", "5", "6"}, ids)
+			assert.ElementsMatch(t, []string{"", "c1", "c2"}, cursors)
+		})
+	}
+}
+
+func TestPaginate_StopsEarly(t *testing.T) {
+	var cursors []string
+	server := mockPagedServer(t, "/api/v2/spaces", &cursors)
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	var ids []string
+	for space, err := range client.SpacesIter(context.Background(), nil) {
+		require.NoError(t, err)
+		ids = append(ids, space.ID)
+		if len(ids) == 3 {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	assert.Equal(t, []string{"", "c1"}, cursors, "pages after the last one consumed aren't fetched")
+}
+
+func TestListAll_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(w, `{"results": [{"id": "1"}], "_links": {"next": "/api/v2/pages/123/attachments?cursor=c1"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"message": "bad cursor"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	attachments, err := ListAll(client.AttachmentsIter(context.Background(), "123", nil, WithPrefetch()))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad cursor")
+	assert.Nil(t, attachments)
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Label is a label on a piece of content.
+type Label struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Prefix string `json:"prefix"`
+}
+
+// ListLabelsOptions contains options for listing labels.
+type ListLabelsOptions struct {
+	Limit  int
+	Cursor string
+	Prefix string // global, my, team, system
+}
+
+// ListPageLabels returns the labels on a page.
+func (c *Client) ListPageLabels(ctx context.Context, pageID string, opts *ListLabelsOptions) (*PaginatedResponse[Label], error) {
+	return c.listLabels(ctx, "pages", pageID, opts)
+}
+
+// ListAttachmentLabels returns the labels on an attachment.
+func (c *Client) ListAttachmentLabels(ctx context.Context, attachmentID string, opts *ListLabelsOptions) (*PaginatedResponse[Label], error) {
+	return c.listLabels(ctx, "attachments", attachmentID, opts)
+}
+
+// listLabels returns the labels on content of a v2 content type (pages,
+// attachments).
+func (c *Client) listLabels(ctx context.Context, contentType, id string, opts *ListLabelsOptions) (*PaginatedResponse[Label], error) {
+	params := url.Values{}
+	params.Set("limit", "25")
+
+	if opts != nil {
+		if opts.Limit > 0 {
+			params.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.Cursor != "" {
+			params.Set("cursor", opts.Cursor)
+		}
+		if opts.Prefix != "" {
+			params.Set("prefix", opts.Prefix)
+		}
+	}
+
+	path := fmt.Sprintf("/api/v2/%s/%s/labels?%s", contentType, id, params.Encode())
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PaginatedResponse[Label]
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse labels response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetLabels returns all labels on a page, following pagination.
+func (c *Client) GetLabels(ctx context.Context, pageID string) ([]Label, error) {
+	return allLabels(ctx, c.ListPageLabels, pageID)
+}
+
+// GetAttachmentLabels returns all labels on an attachment, following pagination.
+func (c *Client) GetAttachmentLabels(ctx context.Context, attachmentID string) ([]Label, error) {
+	return allLabels(ctx, c.ListAttachmentLabels, attachmentID)
+}
+
+// allLabels returns all labels listed by list, following pagination.
+func allLabels(ctx context.Context, list func(context.Context, string, *ListLabelsOptions) (*PaginatedResponse[Label], error), id string) ([]Label, error) {
+	var labels []Label
+	opts := &ListLabelsOptions{Limit: 200}
+	for {
+		result, err := list(ctx, id, opts)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, result.Results...)
+		if opts.Cursor = result.NextCursor(); opts.Cursor == "" {
+			return labels, nil
+		}
+	}
+}
+
+// newLabel is a label in a v1 add labels request.
+type newLabel struct {
+	Prefix string `json:"prefix"`
+	Name   string `json:"name"`
+}
+
+// AddLabels adds global labels to a page and returns the page's labels.
+// Labels the page already has are left as they are.
+func (c *Client) AddLabels(ctx context.Context, pageID string, names []string) ([]Label, error) {
+	return c.addLabels(ctx, pageID, names)
+}
+
+// AddAttachmentLabels adds global labels to an attachment and returns the
+// attachment's labels. Labels it already has are left as they are.
+func (c *Client) AddAttachmentLabels(ctx context.Context, attachmentID string, names []string) ([]Label, error) {
+	return c.addLabels(ctx, v1AttachmentID(attachmentID), names)
+}
+
+// addLabels adds global labels to content by its v1 content ID.
+func (c *Client) addLabels(ctx context.Context, contentID string, names []string) ([]Label, error) {
+	req := make([]newLabel, 0, len(names))
+	for _, name := range names {
+		if err := ValidateLabel(name); err != nil {
+			return nil, err
+		}
+		req = append(req, newLabel{Prefix: "global", Name: name})
+	}
+
+	// The v2 API has no endpoint for adding labels
+	body, err := c.Post(ctx, fmt.Sprintf("/rest/api/content/%s/label", contentID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Results []Label `json:"results"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse labels response: %w", err)
+	}
+
+	return result.Results, nil
+}
+
+// RemoveLabel removes a label from a page.
+func (c *Client) RemoveLabel(ctx context.Context, pageID, name string) error {
+	return c.removeLabel(ctx, pageID, name)
+}
+
+// RemoveAttachmentLabel removes a label from an attachment.
+func (c *Client) RemoveAttachmentLabel(ctx context.Context, attachmentID, name string) error {
+	return c.removeLabel(ctx, v1AttachmentID(attachmentID), name)
+}
+
+// removeLabel removes a label from content by its v1 content ID.
+func (c *Client) removeLabel(ctx context.Context, contentID, name string) error {
+	// The query form allows label names the path form can't carry
+	path := fmt.Sprintf("/rest/api/content/%s/label?%s", contentID, url.Values{"name": {name}}.Encode())
+	_, err := c.Delete(ctx, path)
+	return err
+}
+
+// v1AttachmentID returns the v1 content ID of an attachment, which is its
+// v2 ID ("att123") without the prefix.
+func v1AttachmentID(id string) string {
+	return strings.TrimPrefix(id, "att")
+}
+
+// ValidateLabel checks that name can be used as a Confluence label.
+func ValidateLabel(name string) error {
+	if name == "" {
+		return fmt.Errorf("label name cannot be empty")
+	}
+	if strings.ContainsAny(name, " \t\n:;,.?&[]()#^*@!") {
+		return fmt.Errorf("invalid label %q: labels cannot contain spaces or any of :;,.?&[]()#^*@!", name)
+	}
+	return nil
+}
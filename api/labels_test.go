@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListPageLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/pages/12345/labels", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		assert.Equal(t, "global", r.URL.Query().Get("prefix"))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results": [{"id": "1", "name": "howto", "prefix": "global"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	result, err := client.ListPageLabels(context.Background(), "12345", &ListLabelsOptions{Limit: 100, Prefix: "global"})
+
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+	assert.Equal(t, Label{ID: "1", Name: "howto", Prefix: "global"}, result.Results[0])
+}
+
+func TestClient_GetLabels_Paginates(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{
+				"results": [{"id": "1", "name": "a", "prefix": "global"}],
+				"_links": {"next": "/api/v2/pages/12345/labels?cursor=abc"}
+			}`))
+			return
+		}
+		assert.Equal(t, "abc", r.URL.Query().Get("cursor"))
+		_, _ = w.Write([]byte(`{"results": [{"id": "2", "name": "b", "prefix": "my"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	labels, err := client.GetLabels(context.Background(), "12345")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	require.Len(t, labels, 2)
+	assert.Equal(t, "a", labels[0].Name)
+	assert.Equal(t, "b", labels[1].Name)
+}
+
+func TestClient_AddLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/content/12345/label", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `[{"prefix": "global", "name": "release"}, {"prefix": "global", "name": "v2"}]`, string(body))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results": [
+			{"id": "1", "name": "release", "prefix": "global"},
+			{"id": "2", "name": "v2", "prefix": "global"}
+		], "size": 2}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	labels, err := client.AddLabels(context.Background(), "12345", []string{"release", "v2"})
+
+	require.NoError(t, err)
+	require.Len(t, labels, 2)
+	assert.Equal(t, "v2", labels[1].Name)
+}
+
+func TestClient_AddLabels_Invalid(t *testing.T) {
+	client := NewClient("http://unused", "user@example.com", "token")
+	_, err := client.AddLabels(context.Background(), "12345", []string{"ok", "not ok"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid label "not ok"`)
+}
+
+func TestClient_RemoveLabel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/content/12345/label", r.URL.Path)
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "release", r.URL.Query().Get("name"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	err := client.RemoveLabel(context.Background(), "12345", "release")
+	require.NoError(t, err)
+}
+
+func TestClient_AttachmentLabels(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		switch r.Method {
+		case "GET":
+			_, _ = w.Write([]byte(`{"results": [{"id": "1", "name": "contract", "prefix": "global"}]}`))
+		case "POST":
+			_, _ = w.Write([]byte(`{"results": [{"id": "1", "name": "contract", "prefix": "global"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	ctx := context.Background()
+
+	labels, err := client.GetAttachmentLabels(ctx, "att123")
+	require.NoError(t, err)
+	assert.Equal(t, []Label{{ID: "1", Name: "contract", Prefix: "global"}}, labels)
+
+	_, err = client.AddAttachmentLabels(ctx, "att123", []string{"contract"})
+	require.NoError(t, err)
+	require.NoError(t, client.RemoveAttachmentLabel(ctx, "att123", "contract"))
+
+	// The v1 API addresses attachments by their ID without the prefix
+	assert.Equal(t, []string{
+		"GET /api/v2/attachments/att123/labels",
+		"POST /rest/api/content/123/label",
+		"DELETE /rest/api/content/123/label",
+	}, requests)
+}
+
+func TestValidateLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		label   string
+		wantErr bool
+	}{
+		{"simple", "release", false},
+		{"dashes and digits", "release-2024", false},
+		{"empty", "", true},
+		{"space", "two words", true},
+		{"colon", "team:docs", true},
+		{"hash", "#tag", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLabel(tt.label)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// macroBrowserResponse is the subset of the macro browser's metadata that
+// names the installed macros.
+type macroBrowserResponse struct {
+	Macros []struct {
+		MacroName      string   `json:"macroName"`
+		AlternateNames []string `json:"alternateNames"`
+	} `json:"macros"`
+}
+
+// ListInstalledMacros returns the names, including aliases, of the macros
+// that are installed on the site, sorted. It includes the macros of
+// installed apps as well as the built-in ones.
+func (c *Client) ListInstalledMacros(ctx context.Context) ([]string, error) {
+	// Neither REST API lists macros; this is the editor's macro browser
+	body, err := c.Get(ctx, "/plugins/macrobrowser/browse-macros.action?detailed=false")
+	if err != nil {
+		return nil, err
+	}
+
+	var result macroBrowserResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse macro browser response: %w", err)
+	}
+
+	var names []string
+	for _, m := range result.Macros {
+		names = append(names, m.MacroName)
+		names = append(names, m.AlternateNames...)
+	}
+	sort.Strings(names)
+	return names, nil
+}
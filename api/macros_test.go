@@ -0,0 +1,30 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListInstalledMacros(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/plugins/macrobrowser/browse-macros.action", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(`{"categories": [], "macros": [
+			{"macroName": "toc"},
+			{"macroName": "code", "alternateNames": ["code-block"]},
+			{"macroName": "info"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	names, err := client.ListInstalledMacros(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"code", "code-block", "info", "toc"}, names)
+}
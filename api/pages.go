@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 )
@@ -15,12 +16,22 @@ type ListPagesOptions struct {
 	Status     string // current, archived, trashed, deleted
 	Sort       string // title, -title, created-date, -created-date, modified-date, -modified-date
 	Title      string // Filter by title (contains)
-	BodyFormat string // storage, atlas_doc_format, view
+	BodyFormat string // storage, atlas_doc_format, view, export_view
+	Depth      string // root for top-level pages only, all (default)
+}
+
+// ListChildPagesOptions contains options for listing child pages.
+type ListChildPagesOptions struct {
+	Limit  int
+	Cursor string
+	Sort   string // child-position, -child-position, title, -title, created-date, -created-date, modified-date, -modified-date
 }
 
 // GetPageOptions contains options for getting a page.
 type GetPageOptions struct {
-	BodyFormat string // storage, atlas_doc_format, view
+	BodyFormat string // storage, atlas_doc_format, view, export_view
+	Version    int    // Version number to get; 0 for the current version
+	Draft      bool   // Get the page's unpublished draft instead
 }
 
 // ListPages returns a list of pages in a space.
@@ -47,6 +58,9 @@ func (c *Client) ListPages(ctx context.Context, spaceID string, opts *ListPagesO
 		if opts.BodyFormat != "" {
 			params.Set("body-format", opts.BodyFormat)
 		}
+		if opts.Depth != "" {
+			params.Set("depth", opts.Depth)
+		}
 	}
 
 	path := fmt.Sprintf("/api/v2/spaces/%s/pages?%s", spaceID, params.Encode())
@@ -63,12 +77,49 @@ func (c *Client) ListPages(ctx context.Context, spaceID string, opts *ListPagesO
 	return &result, nil
 }
 
+// ListChildPages returns the direct children of a page.
+func (c *Client) ListChildPages(ctx context.Context, pageID string, opts *ListChildPagesOptions) (*PaginatedResponse[Page], error) {
+	params := url.Values{}
+	params.Set("limit", "25") // Default limit
+
+	if opts != nil {
+		if opts.Limit > 0 {
+			params.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.Cursor != "" {
+			params.Set("cursor", opts.Cursor)
+		}
+		if opts.Sort != "" {
+			params.Set("sort", opts.Sort)
+		}
+	}
+
+	path := fmt.Sprintf("/api/v2/pages/%s/children?%s", pageID, params.Encode())
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PaginatedResponse[Page]
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse child pages response: %w", err)
+	}
+
+	return &result, nil
+}
+
 // GetPage returns a single page by ID.
 func (c *Client) GetPage(ctx context.Context, pageID string, opts *GetPageOptions) (*Page, error) {
 	params := url.Values{}
 	if opts != nil && opts.BodyFormat != "" {
 		params.Set("body-format", opts.BodyFormat)
 	}
+	if opts != nil && opts.Version > 0 {
+		params.Set("version", strconv.Itoa(opts.Version))
+	}
+	if opts != nil && opts.Draft {
+		params.Set("get-draft", "true")
+	}
 
 	path := fmt.Sprintf("/api/v2/pages/%s", pageID)
 	if len(params) > 0 {
@@ -89,22 +140,82 @@ func (c *Client) GetPage(ctx context.Context, pageID string, opts *GetPageOption
 }
 
 // CreatePage creates a new page.
+//
+// Creates aren't idempotent, but with WithRetry they are retried like
+// idempotent requests: a failed attempt may have created the page before
+// the connection dropped, so before each retry the space is searched for
+// the page, which is returned rather than creating it twice.
 func (c *Client) CreatePage(ctx context.Context, req *CreatePageRequest) (*Page, error) {
-	body, err := c.Post(ctx, "/api/v2/pages", req)
+	const path = "/api/v2/pages"
+
+	jsonBody, err := json.Marshal(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	var page Page
-	if err := json.Unmarshal(body, &page); err != nil {
-		return nil, fmt.Errorf("failed to parse create page response: %w", err)
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if page, err := c.findCreatedPage(ctx, req); err == nil && page != nil {
+				return page, nil
+			}
+		}
+
+		body, retryable, err := c.doOnce(ctx, http.MethodPost, c.baseURL+path, jsonBody)
+		if err == nil {
+			var page Page
+			if err := json.Unmarshal(body, &page); err != nil {
+				return nil, fmt.Errorf("failed to parse create page response: %w", err)
+			}
+			return &page, nil
+		}
+		if !retryable || attempt >= c.maxRetries || !c.backoff(ctx, attempt, err) {
+			if nsErr := c.notSupported(ctx, path, err); nsErr != nil {
+				return nil, nsErr
+			}
+			return nil, err
+		}
 	}
+}
 
-	return &page, nil
+// findCreatedPage returns the page a create request made, or nil if it
+// wasn't created.
+func (c *Client) findCreatedPage(ctx context.Context, req *CreatePageRequest) (*Page, error) {
+	page, err := c.FindPageByTitle(ctx, req.SpaceID, req.Title)
+	if err != nil || page == nil {
+		return nil, err
+	}
+	if req.ParentID != "" && page.ParentID != req.ParentID {
+		return nil, nil
+	}
+	return page, nil
+}
+
+// FindPageByTitle returns the current page with exactly this title in a
+// space, or nil if there is none.
+func (c *Client) FindPageByTitle(ctx context.Context, spaceID, title string) (*Page, error) {
+	result, err := c.ListPages(ctx, spaceID, &ListPagesOptions{Title: title, Status: "current"})
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Results {
+		if result.Results[i].Title == title {
+			return &result.Results[i], nil
+		}
+	}
+	return nil, nil
 }
 
-// UpdatePage updates an existing page.
+// UpdatePage updates an existing page. The v2 API requires the full body on
+// every update; use UpdatePageTitle to rename a page without resending it.
 func (c *Client) UpdatePage(ctx context.Context, pageID string, req *UpdatePageRequest) (*Page, error) {
+	if c.quietEdits && req.Version != nil && !req.Version.MinorEdit {
+		version := *req.Version
+		version.MinorEdit = true
+		quiet := *req
+		quiet.Version = &version
+		req = &quiet
+	}
+
 	path := fmt.Sprintf("/api/v2/pages/%s", pageID)
 	body, err := c.Put(ctx, path, req)
 	if err != nil {
@@ -119,17 +230,98 @@ func (c *Client) UpdatePage(ctx context.Context, pageID string, req *UpdatePageR
 	return &page, nil
 }
 
-// DeletePage deletes a page.
+// updatePageTitleRequest is the request body for renaming a page.
+type updatePageTitleRequest struct {
+	Status string `json:"status"`
+	Title  string `json:"title"`
+}
+
+// UpdatePageTitle renames a page. Only the title is sent, so the body is
+// left exactly as stored; the server increments the version.
+func (c *Client) UpdatePageTitle(ctx context.Context, pageID, title string) (*Page, error) {
+	path := fmt.Sprintf("/api/v2/pages/%s/title", pageID)
+	body, err := c.Put(ctx, path, updatePageTitleRequest{Status: "current", Title: title})
+	if err != nil {
+		return nil, err
+	}
+
+	var page Page
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse update page title response: %w", err)
+	}
+
+	return &page, nil
+}
+
+// DeletePage deletes a page, moving it to the space's trash.
 func (c *Client) DeletePage(ctx context.Context, pageID string) error {
 	path := fmt.Sprintf("/api/v2/pages/%s", pageID)
 	_, err := c.Delete(ctx, path)
 	return err
 }
 
+// PurgePage permanently deletes a page that is already in the trash.
+func (c *Client) PurgePage(ctx context.Context, pageID string) error {
+	path := fmt.Sprintf("/api/v2/pages/%s?purge=true", pageID)
+	_, err := c.Delete(ctx, path)
+	return err
+}
+
+// archivePagesRequest is the v1 API request body for archiving pages.
+type archivePagesRequest struct {
+	Pages []archivePage `json:"pages"`
+}
+
+type archivePage struct {
+	ID string `json:"id"`
+}
+
+// ArchivePages moves pages to the space archive, where they can be restored
+// later. Archiving runs as a long task on the server; the ID of the task is
+// returned.
+// Uses the v1 REST API: POST /rest/api/content/archive
+func (c *Client) ArchivePages(ctx context.Context, pageIDs ...string) (string, error) {
+	if len(pageIDs) == 0 {
+		return "", fmt.Errorf("at least one page ID is required")
+	}
+
+	req := archivePagesRequest{}
+	for _, id := range pageIDs {
+		req.Pages = append(req.Pages, archivePage{ID: id})
+	}
+
+	body, err := c.Post(ctx, "/rest/api/content/archive", req)
+	if err != nil {
+		return "", err
+	}
+
+	var task struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &task); err != nil {
+		return "", fmt.Errorf("failed to parse archive response: %w", err)
+	}
+	return task.ID, nil
+}
+
+// Move positions for MovePageTo.
+const (
+	MoveAppend = "append" // As the last child of the target
+	MoveBefore = "before" // As the sibling before the target
+	MoveAfter  = "after"  // As the sibling after the target
+)
+
 // MovePage moves a page to be a child of the target parent page.
 // Uses the v1 REST API as v2 doesn't support page moves.
 func (c *Client) MovePage(ctx context.Context, pageID, targetParentID string) error {
-	path := fmt.Sprintf("/rest/api/content/%s/move/append/%s", pageID, targetParentID)
+	return c.MovePageTo(ctx, pageID, MoveAppend, targetParentID)
+}
+
+// MovePageTo moves a page relative to a target page: appended as its child,
+// or placed as its sibling before or after it.
+// Uses the v1 REST API as v2 doesn't support page moves.
+func (c *Client) MovePageTo(ctx context.Context, pageID, position, targetID string) error {
+	path := fmt.Sprintf("/rest/api/content/%s/move/%s/%s", pageID, position, targetID)
 	_, err := c.Put(ctx, path, nil)
 	return err
 }
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -44,6 +45,7 @@ func TestClient_ListPages_WithOptions(t *testing.T) {
 		assert.Equal(t, "50", r.URL.Query().Get("limit"))
 		assert.Equal(t, "current", r.URL.Query().Get("status"))
 		assert.Equal(t, "title", r.URL.Query().Get("sort"))
+		assert.Equal(t, "root", r.URL.Query().Get("depth"))
 
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte(`{"results": []}`))
@@ -55,6 +57,7 @@ func TestClient_ListPages_WithOptions(t *testing.T) {
 		Limit:  50,
 		Status: "current",
 		Sort:   "title",
+		Depth:  "root",
 	}
 	_, err := client.ListPages(context.Background(), "123456", opts)
 	require.NoError(t, err)
@@ -100,6 +103,21 @@ func TestClient_GetPage_WithBodyFormat(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestClient_GetPage_Draft(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("get-draft"))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": "98765", "status": "draft", "title": "Test"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	page, err := client.GetPage(context.Background(), "98765", &GetPageOptions{Draft: true})
+	require.NoError(t, err)
+	assert.Equal(t, "draft", page.Status)
+}
+
 func TestClient_CreatePage(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v2/pages", r.URL.Path)
@@ -146,6 +164,98 @@ func TestClient_CreatePage(t *testing.T) {
 	assert.Equal(t, "New Page", page.Title)
 }
 
+func TestClient_CreatePage_Retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		createdBy503 bool // The failed attempt created the page anyway
+		wantPosts    int
+		wantPageID   string
+	}{
+		{"lost response finds page", true, 1, "500"},
+		{"failed create is retried", false, 2, "501"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts := 0
+			var created bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/api/v2/pages":
+					posts++
+					if posts == 1 {
+						created = tt.createdBy503
+						w.WriteHeader(http.StatusServiceUnavailable)
+						_, _ = w.Write([]byte(`{"message": "try later"}`))
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{"id": "501", "title": "New Page", "parentId": "42"}`))
+				case r.Method == "GET" && r.URL.Path == "/api/v2/spaces/123456/pages":
+					assert.Equal(t, "New Page", r.URL.Query().Get("title"))
+					w.WriteHeader(http.StatusOK)
+					if created {
+						_, _ = w.Write([]byte(`{"results": [{"id": "500", "title": "New Page", "parentId": "42"}]}`))
+						return
+					}
+					_, _ = w.Write([]byte(`{"results": []}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token", WithRetry(2))
+			client.retryDelay = time.Millisecond
+
+			page, err := client.CreatePage(context.Background(), &CreatePageRequest{SpaceID: "123456", Title: "New Page", ParentID: "42"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPageID, page.ID)
+			assert.Equal(t, tt.wantPosts, posts)
+		})
+	}
+}
+
+func TestClient_CreatePage_NoRetryByDefault(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"message": "try later"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	_, err := client.CreatePage(context.Background(), &CreatePageRequest{SpaceID: "123456", Title: "New Page"})
+	require.Error(t, err)
+	assert.Equal(t, 1, posts)
+}
+
+func TestClient_FindPageByTitle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/spaces/123456/pages", r.URL.Path)
+		assert.Equal(t, "current", r.URL.Query().Get("status"))
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("title") == "Guide" {
+			_, _ = w.Write([]byte(`{"results": [{"id": "1", "title": "Guide"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+
+	page, err := client.FindPageByTitle(context.Background(), "123456", "Guide")
+	require.NoError(t, err)
+	require.NotNil(t, page)
+	assert.Equal(t, "1", page.ID)
+
+	page, err = client.FindPageByTitle(context.Background(), "123456", "Missing")
+	require.NoError(t, err)
+	assert.Nil(t, page)
+}
+
 func TestClient_UpdatePage(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v2/pages/98765", r.URL.Path)
@@ -191,6 +301,59 @@ func TestClient_UpdatePage(t *testing.T) {
 	assert.Equal(t, 6, page.Version.Number)
 }
 
+func TestClient_UpdatePage_NotifyWatchers(t *testing.T) {
+	tests := []struct {
+		name      string
+		notify    bool
+		wantMinor bool
+	}{
+		{"notify", true, false},
+		{"quiet", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req UpdatePageRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, tt.wantMinor, req.Version.MinorEdit)
+
+				w.Write([]byte(`{"id": "98765", "version": {"number": 6}}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token", WithNotifyWatchers(tt.notify))
+			req := &UpdatePageRequest{ID: "98765", Status: "current", Title: "Title", Version: &Version{Number: 6}}
+			_, err := client.UpdatePage(context.Background(), "98765", req)
+			require.NoError(t, err)
+
+			// The caller's request is left as it was
+			assert.False(t, req.Version.MinorEdit)
+		})
+	}
+}
+
+func TestClient_UpdatePageTitle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/pages/12345/title", r.URL.Path)
+		assert.Equal(t, "PUT", r.Method)
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"status": "current", "title": "Renamed"}`, string(body))
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "12345", "title": "Renamed", "version": {"number": 4}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	page, err := client.UpdatePageTitle(context.Background(), "12345", "Renamed")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", page.Title)
+	assert.Equal(t, 4, page.Version.Number)
+}
+
 func TestClient_DeletePage(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v2/pages/98765", r.URL.Path)
@@ -206,6 +369,48 @@ func TestClient_DeletePage(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestClient_PurgePage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/pages/98765", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("purge"))
+		assert.Equal(t, "DELETE", r.Method)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	err := client.PurgePage(context.Background(), "98765")
+
+	require.NoError(t, err)
+}
+
+func TestClient_ArchivePages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/content/archive", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"pages": [{"id": "111"}, {"id": "222"}]}`, string(body))
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"id": "task-1", "links": {"status": "/rest/api/longtask/task-1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	taskID, err := client.ArchivePages(context.Background(), "111", "222")
+
+	require.NoError(t, err)
+	assert.Equal(t, "task-1", taskID)
+}
+
+func TestClient_ArchivePages_NoIDs(t *testing.T) {
+	client := NewClient("http://unused", "user@example.com", "token")
+	_, err := client.ArchivePages(context.Background())
+	require.Error(t, err)
+}
+
 func TestClient_MovePage_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/rest/api/content/12345/move/append/67890", r.URL.Path)
@@ -222,6 +427,22 @@ func TestClient_MovePage_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestClient_MovePageTo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/content/12345/move/before/67890", r.URL.Path)
+		assert.Equal(t, "PUT", r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	err := client.MovePageTo(context.Background(), "12345", MoveBefore, "67890")
+
+	require.NoError(t, err)
+}
+
 func TestClient_MovePage_NotFound(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
@@ -584,3 +805,67 @@ func TestClient_ListPages_NullVersion(t *testing.T) {
 	require.Len(t, result.Results, 1)
 	assert.Nil(t, result.Results[0].Version)
 }
+
+func TestClient_ListChildPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/pages/98765/children", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		assert.Equal(t, "child-position", r.URL.Query().Get("sort"))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"results": [{"id": "98766", "title": "Installation"}],
+			"_links": {"next": "/api/v2/pages/98765/children?cursor=next123"}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	result, err := client.ListChildPages(context.Background(), "98765", &ListChildPagesOptions{
+		Limit: 100,
+		Sort:  "child-position",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+	assert.Equal(t, "98766", result.Results[0].ID)
+	assert.Equal(t, "next123", result.NextCursor())
+}
+
+func TestPaginatedResponse_NextCursor(t *testing.T) {
+	tests := []struct {
+		name string
+		next string
+		want string
+	}{
+		{"no next link", "", ""},
+		{"relative link", "/api/v2/spaces?cursor=abc&limit=25", "abc"},
+		{"no cursor param", "/api/v2/spaces?limit=25", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PaginatedResponse[Page]{Links: Links{Next: tt.next}}
+			assert.Equal(t, tt.want, p.NextCursor())
+		})
+	}
+}
+
+func TestClient_GetPage_Version(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/pages/12345", r.URL.Path)
+		assert.Equal(t, "4", r.URL.Query().Get("version"))
+		assert.Equal(t, "storage", r.URL.Query().Get("body-format"))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": "12345", "title": "Old Title", "version": {"number": 4}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	page, err := client.GetPage(context.Background(), "12345", &GetPageOptions{BodyFormat: "storage", Version: 4})
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, page.Version.Number)
+}
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Principal types.
+const (
+	PrincipalUser  = "user"
+	PrincipalGroup = "group"
+	PrincipalRole  = "role"
+)
+
+// Content restriction operations.
+const (
+	RestrictionRead   = "read"
+	RestrictionUpdate = "update"
+)
+
+// Principal is a user, group or role that permissions are granted to.
+type Principal struct {
+	Type string `json:"type"` // user, group, role
+	ID   string `json:"id"`   // Account ID of a user; ID of a group or role
+}
+
+// SpacePermission is a permission granted on a space.
+type SpacePermission struct {
+	ID        string              `json:"id"`
+	Principal Principal           `json:"principal"`
+	Operation PermissionOperation `json:"operation"`
+}
+
+// PermissionOperation is what a space permission allows: an operation,
+// such as read or create, on a target type, such as space or page.
+type PermissionOperation struct {
+	Key        string `json:"key"`
+	TargetType string `json:"targetType"`
+}
+
+// String returns the operation as key:target, as in "create:page".
+func (o PermissionOperation) String() string {
+	return o.Key + ":" + o.TargetType
+}
+
+// ListSpacePermissionsOptions contains options for listing space permissions.
+type ListSpacePermissionsOptions struct {
+	Limit  int
+	Cursor string
+}
+
+// ListSpacePermissions returns a page of the permissions granted on a space.
+// Uses the v2 REST API: GET /api/v2/spaces/{id}/permissions
+func (c *Client) ListSpacePermissions(ctx context.Context, spaceID string, opts *ListSpacePermissionsOptions) (*PaginatedResponse[SpacePermission], error) {
+	params := url.Values{}
+	params.Set("limit", "25")
+	if opts != nil {
+		if opts.Limit > 0 {
+			params.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.Cursor != "" {
+			params.Set("cursor", opts.Cursor)
+		}
+	}
+
+	path := fmt.Sprintf("/api/v2/spaces/%s/permissions?%s", url.PathEscape(spaceID), params.Encode())
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PaginatedResponse[SpacePermission]
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse space permissions response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// AddSpacePermission grants a user or group an operation on a space.
+// Uses the v1 REST API: POST /rest/api/space/{key}/permission
+func (c *Client) AddSpacePermission(ctx context.Context, spaceKey string, principal Principal, op PermissionOperation) (*SpacePermission, error) {
+	req := v1SpacePermission{
+		Subject:   v1PermissionSubject{Type: principal.Type, Identifier: principal.ID},
+		Operation: v1PermissionOperation{Key: op.Key, Target: op.TargetType},
+	}
+	path := fmt.Sprintf("/rest/api/space/%s/permission", url.PathEscape(spaceKey))
+	body, err := c.Post(ctx, path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result v1SpacePermission
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse space permission response: %w", err)
+	}
+
+	return &SpacePermission{
+		ID:        result.ID.String(),
+		Principal: Principal{Type: result.Subject.Type, ID: result.Subject.Identifier},
+		Operation: PermissionOperation{Key: result.Operation.Key, TargetType: result.Operation.Target},
+	}, nil
+}
+
+// RemoveSpacePermission revokes a permission granted on a space.
+// Uses the v1 REST API: DELETE /rest/api/space/{key}/permission/{id}
+func (c *Client) RemoveSpacePermission(ctx context.Context, spaceKey, permissionID string) error {
+	path := fmt.Sprintf("/rest/api/space/%s/permission/%s", url.PathEscape(spaceKey), url.PathEscape(permissionID))
+	_, err := c.Delete(ctx, path)
+	return err
+}
+
+// v1SpacePermission is a space permission in the v1 API.
+type v1SpacePermission struct {
+	ID        json.Number           `json:"id,omitempty"`
+	Subject   v1PermissionSubject   `json:"subject"`
+	Operation v1PermissionOperation `json:"operation"`
+}
+
+type v1PermissionSubject struct {
+	Type       string `json:"type"`
+	Identifier string `json:"identifier"`
+}
+
+type v1PermissionOperation struct {
+	Key    string `json:"key"`
+	Target string `json:"target"`
+}
+
+// Group is a Confluence user group.
+type Group struct {
+	Type string `json:"type,omitempty"`
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+// GetGroupByName returns the group with a name.
+// Uses the v1 REST API: GET /rest/api/group/by-name
+func (c *Client) GetGroupByName(ctx context.Context, name string) (*Group, error) {
+	body, err := c.Get(ctx, "/rest/api/group/by-name?"+url.Values{"name": {name}}.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var group Group
+	if err := json.Unmarshal(body, &group); err != nil {
+		return nil, fmt.Errorf("failed to parse group response: %w", err)
+	}
+
+	return &group, nil
+}
+
+// ContentRestriction lists the users and groups an operation on a page is
+// restricted to. An operation without users or groups isn't restricted.
+type ContentRestriction struct {
+	Operation string  `json:"operation"` // read, update
+	Users     []User  `json:"users"`
+	Groups    []Group `json:"groups"`
+}
+
+// GetPageRestrictions returns the restrictions of each operation on a page.
+// Uses the v1 REST API: GET /rest/api/content/{id}/restriction
+func (c *Client) GetPageRestrictions(ctx context.Context, pageID string) ([]ContentRestriction, error) {
+	params := url.Values{"expand": {"restrictions.user,restrictions.group"}}
+	path := fmt.Sprintf("/rest/api/content/%s/restriction?%s", url.PathEscape(pageID), params.Encode())
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Results []struct {
+			Operation    string `json:"operation"`
+			Restrictions struct {
+				User struct {
+					Results []User `json:"results"`
+				} `json:"user"`
+				Group struct {
+					Results []Group `json:"results"`
+				} `json:"group"`
+			} `json:"restrictions"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse restrictions response: %w", err)
+	}
+
+	restrictions := make([]ContentRestriction, 0, len(result.Results))
+	for _, r := range result.Results {
+		restriction := ContentRestriction{
+			Operation: r.Operation,
+			Users:     r.Restrictions.User.Results,
+			Groups:    r.Restrictions.Group.Results,
+		}
+		if restriction.Users == nil {
+			restriction.Users = []User{}
+		}
+		if restriction.Groups == nil {
+			restriction.Groups = []Group{}
+		}
+		restrictions = append(restrictions, restriction)
+	}
+	return restrictions, nil
+}
+
+// AddPageRestriction restricts an operation on a page to a user or group,
+// in addition to those it is already restricted to.
+// Uses the v1 REST API: PUT /rest/api/content/{id}/restriction/byOperation/{operation}/...
+func (c *Client) AddPageRestriction(ctx context.Context, pageID, operation string, principal Principal) error {
+	path, err := restrictionPath(pageID, operation, principal)
+	if err != nil {
+		return err
+	}
+	_, err = c.Put(ctx, path, nil)
+	return err
+}
+
+// RemovePageRestriction removes a user or group from the restriction of an
+// operation on a page.
+// Uses the v1 REST API: DELETE /rest/api/content/{id}/restriction/byOperation/{operation}/...
+func (c *Client) RemovePageRestriction(ctx context.Context, pageID, operation string, principal Principal) error {
+	path, err := restrictionPath(pageID, operation, principal)
+	if err != nil {
+		return err
+	}
+	_, err = c.Delete(ctx, path)
+	return err
+}
+
+// restrictionPath returns the path of the restriction of an operation on a
+// page to a user or group.
+func restrictionPath(pageID, operation string, principal Principal) (string, error) {
+	base := fmt.Sprintf("/rest/api/content/%s/restriction/byOperation/%s", url.PathEscape(pageID), url.PathEscape(operation))
+	switch principal.Type {
+	case PrincipalUser:
+		return base + "/user?" + url.Values{"accountId": {principal.ID}}.Encode(), nil
+	case PrincipalGroup:
+		return base + "/byGroupId/" + url.PathEscape(principal.ID), nil
+	}
+	return "", fmt.Errorf("pages can't be restricted to a %s", principal.Type)
+}
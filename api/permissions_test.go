@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListSpacePermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v2/spaces/98304/permissions", r.URL.Path)
+		assert.Equal(t, "50", r.URL.Query().Get("limit"))
+		_, _ = w.Write([]byte(`{"results": [
+			{"id": "1", "principal": {"type": "user", "id": "abc"}, "operation": {"key": "read", "targetType": "space"}},
+			{"id": "2", "principal": {"type": "group", "id": "g-1"}, "operation": {"key": "create", "targetType": "page"}}
+		], "_links": {"next": "/api/v2/spaces/98304/permissions?cursor=xyz"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	result, err := client.ListSpacePermissions(context.Background(), "98304", &ListSpacePermissionsOptions{Limit: 50})
+	require.NoError(t, err)
+	require.Len(t, result.Results, 2)
+	assert.Equal(t, Principal{Type: PrincipalUser, ID: "abc"}, result.Results[0].Principal)
+	assert.Equal(t, "create:page", result.Results[1].Operation.String())
+	assert.Equal(t, "xyz", result.NextCursor())
+}
+
+func TestClient_AddSpacePermission(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/rest/api/space/DEV/permission", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"subject": {"type": "group", "identifier": "g-1"},
+			"operation": {"key": "create", "target": "page"}}`, string(body))
+		_, _ = w.Write([]byte(`{"id": 4567, "subject": {"type": "group", "identifier": "g-1"},
+			"operation": {"key": "create", "target": "page"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	perm, err := client.AddSpacePermission(context.Background(), "DEV",
+		Principal{Type: PrincipalGroup, ID: "g-1"}, PermissionOperation{Key: "create", TargetType: "page"})
+	require.NoError(t, err)
+	assert.Equal(t, "4567", perm.ID)
+	assert.Equal(t, "g-1", perm.Principal.ID)
+	assert.Equal(t, "page", perm.Operation.TargetType)
+}
+
+func TestClient_RemoveSpacePermission(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/rest/api/space/DEV/permission/4567", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	require.NoError(t, client.RemoveSpacePermission(context.Background(), "DEV", "4567"))
+}
+
+func TestClient_GetGroupByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/group/by-name", r.URL.Path)
+		assert.Equal(t, "site admins", r.URL.Query().Get("name"))
+		_, _ = w.Write([]byte(`{"type": "group", "name": "site admins", "id": "g-1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	group, err := client.GetGroupByName(context.Background(), "site admins")
+	require.NoError(t, err)
+	assert.Equal(t, "g-1", group.ID)
+}
+
+func TestClient_GetPageRestrictions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/content/123/restriction", r.URL.Path)
+		assert.Equal(t, "restrictions.user,restrictions.group", r.URL.Query().Get("expand"))
+		_, _ = w.Write([]byte(`{"results": [
+			{"operation": "read", "restrictions": {"user": {"results": [], "size": 0}, "group": {"results": [], "size": 0}}},
+			{"operation": "update", "restrictions": {
+				"user": {"results": [{"type": "known", "accountId": "abc", "displayName": "Ada"}], "size": 1},
+				"group": {"results": [{"type": "group", "name": "editors", "id": "g-2"}], "size": 1}}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	restrictions, err := client.GetPageRestrictions(context.Background(), "123")
+	require.NoError(t, err)
+	require.Len(t, restrictions, 2)
+	assert.Equal(t, RestrictionRead, restrictions[0].Operation)
+	assert.Empty(t, restrictions[0].Users)
+	assert.Equal(t, "abc", restrictions[1].Users[0].AccountID)
+	assert.Equal(t, "editors", restrictions[1].Groups[0].Name)
+
+	// Unrestricted operations have empty lists, not null, in JSON
+	data, err := json.Marshal(restrictions[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"operation": "read", "users": [], "groups": []}`, string(data))
+}
+
+func TestClient_PageRestrictionChanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		call      func(c *Client) error
+		method    string
+		path      string
+		accountID string
+	}{
+		{"add user", func(c *Client) error {
+			return c.AddPageRestriction(context.Background(), "123", RestrictionRead, Principal{Type: PrincipalUser, ID: "abc"})
+		}, "PUT", "/rest/api/content/123/restriction/byOperation/read/user", "abc"},
+		{"add group", func(c *Client) error {
+			return c.AddPageRestriction(context.Background(), "123", RestrictionUpdate, Principal{Type: PrincipalGroup, ID: "g-1"})
+		}, "PUT", "/rest/api/content/123/restriction/byOperation/update/byGroupId/g-1", ""},
+		{"remove user", func(c *Client) error {
+			return c.RemovePageRestriction(context.Background(), "123", RestrictionUpdate, Principal{Type: PrincipalUser, ID: "abc"})
+		}, "DELETE", "/rest/api/content/123/restriction/byOperation/update/user", "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.method, r.Method)
+				assert.Equal(t, tt.path, r.URL.Path)
+				assert.Equal(t, tt.accountID, r.URL.Query().Get("accountId"))
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token")
+			require.NoError(t, tt.call(client))
+		})
+	}
+}
+
+func TestClient_AddPageRestriction_Role(t *testing.T) {
+	client := NewClient("http://unused", "user@example.com", "token")
+	err := client.AddPageRestriction(context.Background(), "123", RestrictionRead, Principal{Type: PrincipalRole, ID: "r"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "can't be restricted to a role")
+}
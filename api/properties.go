@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Editors recorded in a page's "editor" content property.
+const (
+	EditorCloud  = "v2" // The cloud editor, with ADF page bodies
+	EditorLegacy = "v1" // The legacy editor, with storage format page bodies
+)
+
+// ContentProperty is a key/value pair stored on a piece of content.
+type ContentProperty struct {
+	ID      string          `json:"id"`
+	Key     string          `json:"key"`
+	Value   json.RawMessage `json:"value"`
+	Version *Version        `json:"version,omitempty"`
+}
+
+// GetPageProperty returns the page's content property with the given key,
+// or nil if the page doesn't have it.
+func (c *Client) GetPageProperty(ctx context.Context, pageID, key string) (*ContentProperty, error) {
+	path := fmt.Sprintf("/api/v2/pages/%s/properties?%s", pageID, url.Values{"key": {key}}.Encode())
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PaginatedResponse[ContentProperty]
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse content properties response: %w", err)
+	}
+
+	for i := range result.Results {
+		if result.Results[i].Key == key {
+			return &result.Results[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// GetPageEditor returns the editor a page is edited with, EditorCloud or
+// EditorLegacy, or "" if the page doesn't record it.
+func (c *Client) GetPageEditor(ctx context.Context, pageID string) (string, error) {
+	prop, err := c.GetPageProperty(ctx, pageID, "editor")
+	if err != nil || prop == nil {
+		return "", err
+	}
+
+	var editor string
+	if err := json.Unmarshal(prop.Value, &editor); err != nil {
+		// Not a value we know how to interpret
+		return "", nil
+	}
+	switch editor {
+	case EditorCloud, EditorLegacy:
+		return editor, nil
+	}
+	return "", nil
+}
+
+// ListPageProperties returns all of a page's content properties.
+func (c *Client) ListPageProperties(ctx context.Context, pageID string) ([]ContentProperty, error) {
+	return ListAll(paginate(ctx, func(ctx context.Context, cursor string) (*PaginatedResponse[ContentProperty], error) {
+		params := url.Values{"limit": {"250"}}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+		body, err := c.Get(ctx, fmt.Sprintf("/api/v2/pages/%s/properties?%s", pageID, params.Encode()))
+		if err != nil {
+			return nil, err
+		}
+
+		var result PaginatedResponse[ContentProperty]
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse content properties response: %w", err)
+		}
+		return &result, nil
+	}, nil))
+}
+
+// contentPropertyRequest is the request body for creating or updating a
+// content property.
+type contentPropertyRequest struct {
+	Key     string           `json:"key"`
+	Value   json.RawMessage  `json:"value"`
+	Version *propertyVersion `json:"version,omitempty"`
+}
+
+// propertyVersion is the version of a content property being updated.
+type propertyVersion struct {
+	Number int `json:"number"`
+}
+
+// SetPageProperty sets a page's content property to a JSON value. existing
+// is the property as last fetched, which is updated to its next version,
+// or nil to create the property.
+func (c *Client) SetPageProperty(ctx context.Context, pageID, key string, value json.RawMessage, existing *ContentProperty) (*ContentProperty, error) {
+	var body []byte
+	var err error
+	if existing == nil {
+		path := fmt.Sprintf("/api/v2/pages/%s/properties", pageID)
+		body, err = c.Post(ctx, path, contentPropertyRequest{Key: key, Value: value})
+	} else {
+		version := 1
+		if existing.Version != nil {
+			version = existing.Version.Number + 1
+		}
+		path := fmt.Sprintf("/api/v2/pages/%s/properties/%s", pageID, existing.ID)
+		body, err = c.Put(ctx, path, contentPropertyRequest{Key: key, Value: value, Version: &propertyVersion{Number: version}})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var prop ContentProperty
+	if err := json.Unmarshal(body, &prop); err != nil {
+		return nil, fmt.Errorf("failed to parse content property response: %w", err)
+	}
+	return &prop, nil
+}
+
+// DeletePageProperty deletes a page's content property by its ID.
+func (c *Client) DeletePageProperty(ctx context.Context, pageID, propertyID string) error {
+	path := fmt.Sprintf("/api/v2/pages/%s/properties/%s", pageID, propertyID)
+	_, err := c.Delete(ctx, path)
+	return err
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetPageProperty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/pages/12345/properties", r.URL.Path)
+		assert.Equal(t, "owner", r.URL.Query().Get("key"))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results": [{"id": "p1", "key": "owner", "value": {"team": "docs"}, "version": {"number": 2}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	prop, err := client.GetPageProperty(context.Background(), "12345", "owner")
+
+	require.NoError(t, err)
+	require.NotNil(t, prop)
+	assert.Equal(t, "p1", prop.ID)
+	assert.JSONEq(t, `{"team": "docs"}`, string(prop.Value))
+	assert.Equal(t, 2, prop.Version.Number)
+}
+
+func TestClient_GetPageEditor(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     string
+	}{
+		{"cloud editor", `{"results": [{"key": "editor", "value": "v2"}]}`, EditorCloud},
+		{"legacy editor", `{"results": [{"key": "editor", "value": "v1"}]}`, EditorLegacy},
+		{"not recorded", `{"results": []}`, ""},
+		{"unknown value", `{"results": [{"key": "editor", "value": {"v": 3}}]}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "editor", r.URL.Query().Get("key"))
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token")
+			editor, err := client.GetPageEditor(context.Background(), "12345")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, editor)
+		})
+	}
+}
+
+func TestClient_ListPageProperties(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/pages/12345/properties", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"results": [{"id": "p1", "key": "owner", "value": "docs"}],
+				"_links": {"next": "/api/v2/pages/12345/properties?cursor=abc"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results": [{"id": "p2", "key": "reviewed", "value": true}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	props, err := client.ListPageProperties(context.Background(), "12345")
+
+	require.NoError(t, err)
+	require.Len(t, props, 2)
+	assert.Equal(t, "owner", props[0].Key)
+	assert.Equal(t, "reviewed", props[1].Key)
+}
+
+func TestClient_SetPageProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *ContentProperty
+		method   string
+		path     string
+		request  string
+	}{
+		{
+			name:    "create",
+			method:  http.MethodPost,
+			path:    "/api/v2/pages/12345/properties",
+			request: `{"key": "reviewed", "value": {"by": "alex"}}`,
+		},
+		{
+			name:     "update",
+			existing: &ContentProperty{ID: "p1", Key: "reviewed", Version: &Version{Number: 3}},
+			method:   http.MethodPut,
+			path:     "/api/v2/pages/12345/properties/p1",
+			request:  `{"key": "reviewed", "value": {"by": "alex"}, "version": {"number": 4}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.method, r.Method)
+				assert.Equal(t, tt.path, r.URL.Path)
+				body, _ := io.ReadAll(r.Body)
+				assert.JSONEq(t, tt.request, string(body))
+
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"id": "p1", "key": "reviewed", "value": {"by": "alex"}}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token")
+			prop, err := client.SetPageProperty(context.Background(), "12345", "reviewed", json.RawMessage(`{"by": "alex"}`), tt.existing)
+
+			require.NoError(t, err)
+			assert.Equal(t, "p1", prop.ID)
+		})
+	}
+}
+
+func TestClient_DeletePageProperty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/api/v2/pages/12345/properties/p1", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	require.NoError(t, client.DeletePageProperty(context.Background(), "12345", "p1"))
+}
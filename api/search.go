@@ -18,6 +18,7 @@ type SearchOptions struct {
 	Title string // Title contains filter
 	Label string // Label filter
 	Limit int    // Max results (default 25, max 200)
+	Start int    // Offset of the first result, for paging
 }
 
 // SearchResult represents a single search result from the v1 API.
@@ -97,6 +98,9 @@ func (c *Client) Search(ctx context.Context, opts *SearchOptions) (*SearchRespon
 	} else {
 		params.Set("limit", "25")
 	}
+	if opts != nil && opts.Start > 0 {
+		params.Set("start", strconv.Itoa(opts.Start))
+	}
 
 	// Include excerpt for context
 	params.Set("excerpt", "highlight")
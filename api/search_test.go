@@ -288,3 +288,17 @@ func TestBuildCQL_QuotesInValue(t *testing.T) {
 	// Go's %q escapes quotes properly
 	assert.Contains(t, cql, `text ~ "search \"quoted\" term"`)
 }
+
+func TestClient_Search_Start(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "50", r.URL.Query().Get("start"))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results": [], "start": 50, "totalSize": 50}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	_, err := client.Search(context.Background(), &SearchOptions{CQL: "type=page", Start: 50})
+	require.NoError(t, err)
+}
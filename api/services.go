@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"io"
+)
+
+// PageService is the page subset of the Client API.
+type PageService interface {
+	ListPages(ctx context.Context, spaceID string, opts *ListPagesOptions) (*PaginatedResponse[Page], error)
+	ListChildPages(ctx context.Context, pageID string, opts *ListChildPagesOptions) (*PaginatedResponse[Page], error)
+	GetPage(ctx context.Context, pageID string, opts *GetPageOptions) (*Page, error)
+	CreatePage(ctx context.Context, req *CreatePageRequest) (*Page, error)
+	FindPageByTitle(ctx context.Context, spaceID, title string) (*Page, error)
+	UpdatePage(ctx context.Context, pageID string, req *UpdatePageRequest) (*Page, error)
+	UpdatePageTitle(ctx context.Context, pageID, title string) (*Page, error)
+	DeletePage(ctx context.Context, pageID string) error
+	PurgePage(ctx context.Context, pageID string) error
+	ArchivePages(ctx context.Context, pageIDs ...string) (string, error)
+	MovePage(ctx context.Context, pageID, targetParentID string) error
+	MovePageTo(ctx context.Context, pageID, position, targetID string) error
+	CopyPage(ctx context.Context, pageID string, opts *CopyPageOptions) (*Page, error)
+	ExportPageWord(ctx context.Context, pageID string) (io.ReadCloser, error)
+	ExportPagePDF(ctx context.Context, pageID string) (io.ReadCloser, error)
+	GetPageProperty(ctx context.Context, pageID, key string) (*ContentProperty, error)
+	GetPageEditor(ctx context.Context, pageID string) (string, error)
+	ListPageVersions(ctx context.Context, pageID string, opts *ListVersionsOptions) (*PaginatedResponse[Version], error)
+	RestorePageVersion(ctx context.Context, pageID string, version int, message string) (*Version, error)
+}
+
+// SpaceService is the space subset of the Client API.
+type SpaceService interface {
+	ListSpaces(ctx context.Context, opts *ListSpacesOptions) (*PaginatedResponse[Space], error)
+	GetSpace(ctx context.Context, spaceID string) (*Space, error)
+	GetSpaceByKey(ctx context.Context, key string) (*Space, error)
+	SetSpaceHomepage(ctx context.Context, key, pageID string) error
+	CreateSpace(ctx context.Context, req *CreateSpaceRequest) (*Space, error)
+	UpdateSpace(ctx context.Context, key string, req *UpdateSpaceRequest) (*Space, error)
+	ArchiveSpace(ctx context.Context, key string) error
+	DeleteSpace(ctx context.Context, key string) (string, error)
+}
+
+// AttachmentService is the attachment subset of the Client API.
+type AttachmentService interface {
+	ListAttachments(ctx context.Context, pageID string, opts *ListAttachmentsOptions) (*PaginatedResponse[Attachment], error)
+	GetAttachment(ctx context.Context, attachmentID string) (*Attachment, error)
+	DownloadAttachment(ctx context.Context, attachmentID string) (io.ReadCloser, error)
+	UploadAttachment(ctx context.Context, pageID, filename string, content io.Reader, comment string) (*Attachment, error)
+	CreateOrUpdateAttachment(ctx context.Context, pageID, filename string, content io.Reader, comment string) (*Attachment, error)
+	DeleteAttachment(ctx context.Context, attachmentID string) error
+}
+
+// SearchService is the search subset of the Client API.
+type SearchService interface {
+	Search(ctx context.Context, opts *SearchOptions) (*SearchResponse, error)
+}
+
+// LabelService is the label subset of the Client API.
+type LabelService interface {
+	ListPageLabels(ctx context.Context, pageID string, opts *ListLabelsOptions) (*PaginatedResponse[Label], error)
+	GetLabels(ctx context.Context, pageID string) ([]Label, error)
+	AddLabels(ctx context.Context, pageID string, names []string) ([]Label, error)
+	RemoveLabel(ctx context.Context, pageID, name string) error
+}
+
+// CommentService is the comment subset of the Client API.
+type CommentService interface {
+	ListPageComments(ctx context.Context, pageID, kind string, opts *ListCommentsOptions) (*PaginatedResponse[Comment], error)
+	ListCommentReplies(ctx context.Context, kind, commentID string, opts *ListCommentsOptions) (*PaginatedResponse[Comment], error)
+	GetComment(ctx context.Context, kind, commentID string) (*Comment, error)
+	GetCommentVersion(ctx context.Context, kind, commentID string, version int) (*Version, error)
+	CreateComment(ctx context.Context, kind string, req *CreateCommentRequest) (*Comment, error)
+	ResolveComment(ctx context.Context, commentID string, resolved bool) (*Comment, error)
+	DeleteComment(ctx context.Context, kind, commentID string) error
+}
+
+// UserService is the user subset of the Client API.
+type UserService interface {
+	GetCurrentUser(ctx context.Context) (*User, error)
+}
+
+// TaskService is the long task and inline task subset of the Client API.
+type TaskService interface {
+	GetTask(ctx context.Context, taskID string) (*LongTask, error)
+	WaitForTask(ctx context.Context, taskID string, opts *PollOptions) (*LongTask, error)
+	ListInlineTasks(ctx context.Context, opts *ListInlineTasksOptions) (*PaginatedResponse[InlineTask], error)
+	GetInlineTask(ctx context.Context, taskID string, bodyFormat string) (*InlineTask, error)
+	SetInlineTaskStatus(ctx context.Context, taskID, status string) (*InlineTask, error)
+}
+
+// WhiteboardService is the whiteboard subset of the Client API.
+type WhiteboardService interface {
+	ListWhiteboards(ctx context.Context, opts *ListContentOptions) (*SearchResponse, error)
+	GetWhiteboard(ctx context.Context, whiteboardID string) (*Whiteboard, error)
+}
+
+// DatabaseService is the database subset of the Client API.
+type DatabaseService interface {
+	ListDatabases(ctx context.Context, opts *ListContentOptions) (*SearchResponse, error)
+	GetDatabase(ctx context.Context, databaseID string) (*Database, error)
+}
+
+// WatchService is the watch subset of the Client API.
+type WatchService interface {
+	WatchPage(ctx context.Context, pageID, accountID string) error
+	UnwatchPage(ctx context.Context, pageID, accountID string) error
+	WatchSpace(ctx context.Context, spaceKey, accountID string) error
+	UnwatchSpace(ctx context.Context, spaceKey, accountID string) error
+	ListPageWatchers(ctx context.Context, pageID string, opts *ListWatchersOptions) (*WatchList, error)
+	GetPageWatchers(ctx context.Context, pageID string) ([]Watch, error)
+}
+
+// PermissionService is the space permission and content restriction subset
+// of the Client API.
+type PermissionService interface {
+	ListSpacePermissions(ctx context.Context, spaceID string, opts *ListSpacePermissionsOptions) (*PaginatedResponse[SpacePermission], error)
+	AddSpacePermission(ctx context.Context, spaceKey string, principal Principal, op PermissionOperation) (*SpacePermission, error)
+	RemoveSpacePermission(ctx context.Context, spaceKey, permissionID string) error
+	GetGroupByName(ctx context.Context, name string) (*Group, error)
+	GetPageRestrictions(ctx context.Context, pageID string) ([]ContentRestriction, error)
+	AddPageRestriction(ctx context.Context, pageID, operation string, principal Principal) error
+	RemovePageRestriction(ctx context.Context, pageID, operation string, principal Principal) error
+}
+
+// Compile-time checks that Client implements every service interface.
+var (
+	_ PageService       = (*Client)(nil)
+	_ SpaceService      = (*Client)(nil)
+	_ AttachmentService = (*Client)(nil)
+	_ SearchService     = (*Client)(nil)
+	_ LabelService      = (*Client)(nil)
+	_ CommentService    = (*Client)(nil)
+	_ UserService       = (*Client)(nil)
+	_ TaskService       = (*Client)(nil)
+	_ WhiteboardService = (*Client)(nil)
+	_ DatabaseService   = (*Client)(nil)
+	_ WatchService      = (*Client)(nil)
+	_ PermissionService = (*Client)(nil)
+)
@@ -90,3 +90,185 @@ func (c *Client) GetSpaceByKey(ctx context.Context, key string) (*Space, error)
 
 	return &result.Results[0], nil
 }
+
+// CreateSpaceRequest describes a new space.
+type CreateSpaceRequest struct {
+	Key         string // Required: letters and digits only
+	Name        string // Required
+	Description string // Optional plain text description
+}
+
+// UpdateSpaceRequest changes a space's details. Empty fields are left as
+// they are.
+type UpdateSpaceRequest struct {
+	Name        string
+	Description *string // Plain text; a pointer to "" clears the description
+	HomepageID  string
+	Status      string // current, archived
+}
+
+// v1SpaceRequest is the v1 API request body for creating or updating a space.
+type v1SpaceRequest struct {
+	Key         string              `json:"key,omitempty"`
+	Name        string              `json:"name,omitempty"`
+	Description *v1SpaceDescription `json:"description,omitempty"`
+	Homepage    *v1SpaceHomepage    `json:"homepage,omitempty"`
+	Status      string              `json:"status,omitempty"`
+}
+
+type v1SpaceDescription struct {
+	Plain struct {
+		Value          string `json:"value"`
+		Representation string `json:"representation"`
+	} `json:"plain"`
+}
+
+type v1SpaceHomepage struct {
+	ID string `json:"id"`
+}
+
+func newV1SpaceDescription(value string) *v1SpaceDescription {
+	d := &v1SpaceDescription{}
+	d.Plain.Value = value
+	d.Plain.Representation = "plain"
+	return d
+}
+
+// v1SpaceResponse represents the v1 API space response structure.
+type v1SpaceResponse struct {
+	ID          int64  `json:"id"`
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Status      string `json:"status"`
+	Description *struct {
+		Plain *DescriptionValue `json:"plain"`
+	} `json:"description"`
+	Homepage *struct {
+		ID string `json:"id"`
+	} `json:"homepage"`
+	Links struct {
+		WebUI string `json:"webui"`
+	} `json:"_links"`
+}
+
+// toSpace converts a v1 API response to a Space.
+func (r *v1SpaceResponse) toSpace() *Space {
+	space := &Space{
+		ID:     strconv.FormatInt(r.ID, 10),
+		Key:    r.Key,
+		Name:   r.Name,
+		Type:   r.Type,
+		Status: r.Status,
+		Links:  Links{WebUI: r.Links.WebUI},
+	}
+	if r.Description != nil && r.Description.Plain != nil {
+		space.Description = &SpaceDescription{Plain: r.Description.Plain}
+	}
+	if r.Homepage != nil {
+		space.HomepageID = r.Homepage.ID
+	}
+	return space
+}
+
+// ValidateSpaceKey checks that key can be used as a Confluence space key.
+func ValidateSpaceKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("space key cannot be empty")
+	}
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return fmt.Errorf("invalid space key %q: keys can only contain letters and digits", key)
+		}
+	}
+	return nil
+}
+
+// CreateSpace creates a global space.
+// Uses the v1 REST API: POST /rest/api/space
+func (c *Client) CreateSpace(ctx context.Context, req *CreateSpaceRequest) (*Space, error) {
+	if req == nil || req.Name == "" {
+		return nil, fmt.Errorf("space name is required")
+	}
+	if err := ValidateSpaceKey(req.Key); err != nil {
+		return nil, err
+	}
+
+	body := v1SpaceRequest{Key: req.Key, Name: req.Name}
+	if req.Description != "" {
+		body.Description = newV1SpaceDescription(req.Description)
+	}
+
+	resp, err := c.Post(ctx, "/rest/api/space", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var space v1SpaceResponse
+	if err := json.Unmarshal(resp, &space); err != nil {
+		return nil, fmt.Errorf("failed to parse space response: %w", err)
+	}
+	return space.toSpace(), nil
+}
+
+// UpdateSpace changes a space's name, description, homepage or status.
+// Uses the v1 REST API as v2 doesn't support updating spaces.
+func (c *Client) UpdateSpace(ctx context.Context, key string, req *UpdateSpaceRequest) (*Space, error) {
+	if req == nil {
+		return nil, fmt.Errorf("nothing to update")
+	}
+
+	body := v1SpaceRequest{Name: req.Name, Status: req.Status}
+	if req.Description != nil {
+		body.Description = newV1SpaceDescription(*req.Description)
+	}
+	if req.HomepageID != "" {
+		body.Homepage = &v1SpaceHomepage{ID: req.HomepageID}
+	}
+
+	path := fmt.Sprintf("/rest/api/space/%s", url.PathEscape(key))
+	resp, err := c.Put(ctx, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var space v1SpaceResponse
+	if err := json.Unmarshal(resp, &space); err != nil {
+		return nil, fmt.Errorf("failed to parse space response: %w", err)
+	}
+	return space.toSpace(), nil
+}
+
+// SetSpaceHomepage makes a page the homepage of a space.
+func (c *Client) SetSpaceHomepage(ctx context.Context, key, pageID string) error {
+	_, err := c.UpdateSpace(ctx, key, &UpdateSpaceRequest{HomepageID: pageID})
+	return err
+}
+
+// ArchiveSpace archives a space. Archived spaces are read-only and hidden
+// from search and the space directory until restored.
+func (c *Client) ArchiveSpace(ctx context.Context, key string) error {
+	_, err := c.UpdateSpace(ctx, key, &UpdateSpaceRequest{Status: "archived"})
+	return err
+}
+
+// DeleteSpace moves a space and all of its content to the trash. Deletion
+// runs as a long task on the server; the ID of the task is returned.
+// Uses the v1 REST API: DELETE /rest/api/space/{key}
+func (c *Client) DeleteSpace(ctx context.Context, key string) (string, error) {
+	path := fmt.Sprintf("/rest/api/space/%s", url.PathEscape(key))
+	resp, err := c.Delete(ctx, path)
+	if err != nil {
+		return "", err
+	}
+
+	var task struct {
+		ID string `json:"id"`
+	}
+	if len(resp) > 0 {
+		if err := json.Unmarshal(resp, &task); err != nil {
+			return "", fmt.Errorf("failed to parse delete space response: %w", err)
+		}
+	}
+	return task.ID, nil
+}
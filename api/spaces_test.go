@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -229,3 +230,150 @@ func TestClient_ListSpaces_APIError(t *testing.T) {
 
 	require.Error(t, err)
 }
+
+func TestClient_SetSpaceHomepage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/space/DOCS", r.URL.Path)
+		assert.Equal(t, "PUT", r.Method)
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"homepage": {"id": "12345"}}`, string(body))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"key": "DOCS"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	err := client.SetSpaceHomepage(context.Background(), "DOCS", "12345")
+
+	require.NoError(t, err)
+}
+
+func TestClient_CreateSpace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/space", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"key": "DOCS", "name": "Docs", "description": {"plain": {"value": "Team docs", "representation": "plain"}}}`, string(body))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"id": 98304,
+			"key": "DOCS",
+			"name": "Docs",
+			"type": "global",
+			"status": "current",
+			"description": {"plain": {"value": "Team docs"}},
+			"homepage": {"id": "12345"},
+			"_links": {"webui": "/spaces/DOCS"}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	space, err := client.CreateSpace(context.Background(), &CreateSpaceRequest{Key: "DOCS", Name: "Docs", Description: "Team docs"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "98304", space.ID)
+	assert.Equal(t, "DOCS", space.Key)
+	assert.Equal(t, "12345", space.HomepageID)
+	assert.Equal(t, "Team docs", space.Description.Plain.Value)
+	assert.Equal(t, "/spaces/DOCS", space.Links.WebUI)
+}
+
+func TestClient_CreateSpace_Invalid(t *testing.T) {
+	client := NewClient("http://unused", "user@example.com", "token")
+
+	tests := []struct {
+		name    string
+		req     *CreateSpaceRequest
+		wantErr string
+	}{
+		{"nil request", nil, "space name is required"},
+		{"missing name", &CreateSpaceRequest{Key: "DOCS"}, "space name is required"},
+		{"missing key", &CreateSpaceRequest{Name: "Docs"}, "space key cannot be empty"},
+		{"invalid key", &CreateSpaceRequest{Key: "MY-DOCS", Name: "Docs"}, "letters and digits"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.CreateSpace(context.Background(), tt.req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestClient_UpdateSpace(t *testing.T) {
+	cleared := ""
+
+	tests := []struct {
+		name string
+		req  *UpdateSpaceRequest
+		want string
+	}{
+		{"name", &UpdateSpaceRequest{Name: "Renamed"}, `{"name": "Renamed"}`},
+		{"clear description", &UpdateSpaceRequest{Description: &cleared}, `{"description": {"plain": {"value": "", "representation": "plain"}}}`},
+		{"homepage and name", &UpdateSpaceRequest{Name: "Docs", HomepageID: "42"}, `{"name": "Docs", "homepage": {"id": "42"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/rest/api/space/DOCS", r.URL.Path)
+				assert.Equal(t, "PUT", r.Method)
+
+				body, _ := io.ReadAll(r.Body)
+				assert.JSONEq(t, tt.want, string(body))
+
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"id": 98304, "key": "DOCS", "name": "Docs"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token")
+			space, err := client.UpdateSpace(context.Background(), "DOCS", tt.req)
+
+			require.NoError(t, err)
+			assert.Equal(t, "98304", space.ID)
+		})
+	}
+}
+
+func TestClient_ArchiveSpace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/space/DOCS", r.URL.Path)
+		assert.Equal(t, "PUT", r.Method)
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"status": "archived"}`, string(body))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": 98304, "key": "DOCS", "status": "archived"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	err := client.ArchiveSpace(context.Background(), "DOCS")
+
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteSpace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/space/DOCS", r.URL.Path)
+		assert.Equal(t, "DELETE", r.Method)
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"id": "task-7", "links": {"status": "/rest/api/longtask/task-7"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	taskID, err := client.DeleteSpace(context.Background(), "DOCS")
+
+	require.NoError(t, err)
+	assert.Equal(t, "task-7", taskID)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// LongTask is the progress of a long-running server task, such as deleting
+// a space or archiving pages.
+type LongTask struct {
+	ID                 string        `json:"id"`
+	Name               LongTaskName  `json:"name"`
+	ElapsedTime        int64         `json:"elapsedTime"` // Milliseconds
+	PercentageComplete int           `json:"percentageComplete"`
+	Successful         bool          `json:"successful"`
+	Finished           bool          `json:"finished"`
+	Status             string        `json:"status,omitempty"`
+	Messages           []TaskMessage `json:"messages,omitempty"`
+}
+
+// LongTaskName identifies the kind of a long task.
+type LongTaskName struct {
+	Key string `json:"key"`
+}
+
+// TaskMessage is a message reported by a long task.
+type TaskMessage struct {
+	Translation string `json:"translation"`
+}
+
+// Failed reports whether the task finished without success.
+func (t *LongTask) Failed() bool {
+	return t.Finished && !t.Successful
+}
+
+// Message returns the task's messages joined for display.
+func (t *LongTask) Message() string {
+	var msgs []string
+	for _, m := range t.Messages {
+		if m.Translation != "" {
+			msgs = append(msgs, m.Translation)
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Elapsed returns how long the task has been running.
+func (t *LongTask) Elapsed() time.Duration {
+	return time.Duration(t.ElapsedTime) * time.Millisecond
+}
+
+// GetTask returns the progress of a long task.
+// Uses the v1 REST API: GET /rest/api/longtask/{id}
+func (c *Client) GetTask(ctx context.Context, taskID string) (*LongTask, error) {
+	path := fmt.Sprintf("/rest/api/longtask/%s", url.PathEscape(taskID))
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var task LongTask
+	if err := json.Unmarshal(body, &task); err != nil {
+		return nil, fmt.Errorf("failed to parse task response: %w", err)
+	}
+
+	return &task, nil
+}
+
+// PollOptions configures waiting for a long task.
+type PollOptions struct {
+	Interval   time.Duration   // Time between checks; defaults to the client's poll delay
+	OnProgress func(*LongTask) // Called with the progress after each check
+}
+
+// WaitForTask polls a long task until it finishes and returns its final
+// progress. A task that finishes without success is returned with an error.
+func (c *Client) WaitForTask(ctx context.Context, taskID string, opts *PollOptions) (*LongTask, error) {
+	var interval time.Duration
+	var onProgress func(*LongTask)
+	if opts != nil {
+		interval, onProgress = opts.Interval, opts.OnProgress
+	}
+
+	var task *LongTask
+	err := c.poll(ctx, interval, func() (bool, error) {
+		var err error
+		if task, err = c.GetTask(ctx, taskID); err != nil {
+			return false, err
+		}
+		if onProgress != nil {
+			onProgress(task)
+		}
+		return task.Finished, nil
+	})
+	if err != nil {
+		return task, err
+	}
+	if task.Failed() {
+		if msg := task.Message(); msg != "" {
+			return task, fmt.Errorf("task %s failed: %s", taskID, msg)
+		}
+		return task, fmt.Errorf("task %s failed", taskID)
+	}
+	return task, nil
+}
+
+// poll calls check every interval, or the client's poll delay when interval
+// is zero, until it reports done, fails, or ctx is cancelled.
+func (c *Client) poll(ctx context.Context, interval time.Duration, check func() (bool, error)) error {
+	if interval <= 0 {
+		interval = c.pollDelay
+	}
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
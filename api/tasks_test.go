@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/rest/api/longtask/task-1", r.URL.Path)
+		_, _ = w.Write([]byte(`{"id": "task-1", "name": {"key": "com.atlassian.confluence.space.delete"},
+			"elapsedTime": 1500, "percentageComplete": 40, "finished": false,
+			"messages": [{"translation": "Deleting pages"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	task, err := client.GetTask(context.Background(), "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, 40, task.PercentageComplete)
+	assert.Equal(t, "Deleting pages", task.Message())
+	assert.Equal(t, 1500*time.Millisecond, task.Elapsed())
+	assert.False(t, task.Failed())
+}
+
+func TestClient_WaitForTask(t *testing.T) {
+	responses := []string{
+		`{"id": "task-1", "percentageComplete": 10, "finished": false}`,
+		`{"id": "task-1", "percentageComplete": 60, "finished": false}`,
+		`{"id": "task-1", "percentageComplete": 100, "finished": true, "successful": true}`,
+	}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(responses[calls]))
+		calls++
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	var progress []int
+	task, err := client.WaitForTask(context.Background(), "task-1", &PollOptions{
+		Interval:   time.Millisecond,
+		OnProgress: func(t *LongTask) { progress = append(progress, t.PercentageComplete) },
+	})
+	require.NoError(t, err)
+	assert.True(t, task.Successful)
+	assert.Equal(t, []int{10, 60, 100}, progress)
+}
+
+func TestClient_WaitForTask_Failed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "task-1", "finished": true, "successful": false,
+			"messages": [{"translation": "Permission denied"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	task, err := client.WaitForTask(context.Background(), "task-1", nil)
+	require.Error(t, err)
+	assert.Equal(t, "task task-1 failed: Permission denied", err.Error())
+	require.NotNil(t, task)
+	assert.True(t, task.Failed())
+}
+
+func TestClient_WaitForTask_Cancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "task-1", "finished": false}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := NewClient(server.URL, "user@example.com", "token")
+	_, err := client.WaitForTask(ctx, "task-1", &PollOptions{
+		Interval:   time.Hour,
+		OnProgress: func(*LongTask) { cancel() },
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *ListTemplatesOptions
+		path     string
+		spaceKey string
+	}{
+		{"global page templates", nil, "/rest/api/template/page", ""},
+		{"space templates", &ListTemplatesOptions{SpaceKey: "DEV"}, "/rest/api/template/page", "DEV"},
+		{"blueprints", &ListTemplatesOptions{SpaceKey: "DEV", Blueprints: true}, "/rest/api/template/blueprint", "DEV"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "GET", r.Method)
+				assert.Equal(t, tt.path, r.URL.Path)
+				assert.Equal(t, tt.spaceKey, r.URL.Query().Get("spaceKey"))
+				_, _ = w.Write([]byte(`{"results": [{"templateId": "98305", "name": "Release notes",
+					"templateType": "page", "space": {"key": "DEV"}}], "start": 0, "limit": 25, "size": 1}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token")
+			result, err := client.ListTemplates(context.Background(), tt.opts)
+			require.NoError(t, err)
+			require.Len(t, result.Results, 1)
+			assert.Equal(t, "98305", result.Results[0].TemplateID)
+			assert.Equal(t, "DEV", result.Results[0].Space.Key)
+			assert.False(t, result.HasMore())
+		})
+	}
+}
+
+func TestClient_GetTemplates_Paginates(t *testing.T) {
+	var starts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		starts = append(starts, r.URL.Query().Get("start"))
+		if r.URL.Query().Get("start") == "" {
+			_, _ = w.Write([]byte(`{"results": [{"templateId": "1"}, {"templateId": "2"}], "start": 0, "size": 2,
+				"_links": {"next": "/rest/api/template/page?start=2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results": [{"templateId": "3"}], "start": 2, "size": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	templates, err := client.GetTemplates(context.Background(), &ListTemplatesOptions{SpaceKey: "DEV"})
+	require.NoError(t, err)
+	assert.Len(t, templates, 3)
+	assert.Equal(t, []string{"", "2"}, starts)
+}
+
+func TestClient_GetTemplate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/template/98305", r.URL.Path)
+		assert.Equal(t, "body.storage", r.URL.Query().Get("expand"))
+		_, _ = w.Write([]byte(`{"templateId": "98305", "name": "Release notes",
+			"body": {"storage": {"value": "<p>Hi</p>", "representation": "storage"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	template, err := client.GetTemplate(context.Background(), "98305")
+	require.NoError(t, err)
+	assert.Equal(t, "Release notes", template.Name)
+	assert.Equal(t, "<p>Hi</p>", template.Body.Storage.Value)
+}
+
+func TestContentTemplate_Expand(t *testing.T) {
+	template := &ContentTemplate{
+		Name: "Release notes",
+		Body: &TemplateBody{Storage: &BodyRepresentation{Value: `<at:declarations><at:string at:name="version" />` +
+			`<at:textarea at:name="notes" at:columns="40" at:rows="5" /></at:declarations>` +
+			`<h1>Release <at:var at:name="version" /></h1><p><at:var at:name="notes" at:rawxhtml="true"></at:var></p>` +
+			`<p>Version <at:var at:name="version" /></p>`}},
+	}
+
+	assert.Equal(t, []string{"version", "notes"}, template.Variables())
+
+	storage, err := template.Expand(map[string]string{"version": "2.3", "notes": "Fixes & features"})
+	require.NoError(t, err)
+	assert.Equal(t, `<h1>Release 2.3</h1><p>Fixes &amp; features</p><p>Version 2.3</p>`, storage)
+
+	_, err = template.Expand(map[string]string{"version": "2.3"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "needs values for notes")
+
+	storage, err = (&ContentTemplate{Body: &TemplateBody{Storage: &BodyRepresentation{Value: "<p>Plain</p>"}}}).Expand(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Plain</p>", storage)
+}
+
+func TestClient_ConvertStorageToADF(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/rest/api/contentbody/convert/atlas_doc_format", r.URL.Path)
+		data, _ := io.ReadAll(r.Body)
+		var req BodyRepresentation
+		require.NoError(t, json.Unmarshal(data, &req))
+		assert.Equal(t, BodyRepresentation{Representation: "storage", Value: "<p>Hi</p>"}, req)
+		_, _ = w.Write([]byte(`{"representation": "atlas_doc_format", "value": "{\"type\":\"doc\"}"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	adf, err := client.ConvertStorageToADF(context.Background(), "<p>Hi</p>")
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"doc"}`, adf)
+}
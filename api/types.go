@@ -1,7 +1,9 @@
-// Package api provides the Confluence Cloud REST API client.
 package api
 
-import "time"
+import (
+	"net/url"
+	"time"
+)
 
 // PaginatedResponse wraps paginated API responses.
 type PaginatedResponse[T any] struct {
@@ -22,6 +24,18 @@ func (p *PaginatedResponse[T]) HasMore() bool {
 	return p.Links.Next != ""
 }
 
+// NextCursor returns the cursor for the next page of results, or "" if there are none.
+func (p *PaginatedResponse[T]) NextCursor() string {
+	if p.Links.Next == "" {
+		return ""
+	}
+	u, err := url.Parse(p.Links.Next)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("cursor")
+}
+
 // Space represents a Confluence space.
 type Space struct {
 	ID          string            `json:"id"`
@@ -29,6 +43,7 @@ type Space struct {
 	Name        string            `json:"name"`
 	Type        string            `json:"type"`
 	Status      string            `json:"status"`
+	HomepageID  string            `json:"homepageId,omitempty"`
 	Description *SpaceDescription `json:"description,omitempty"`
 	Links       Links             `json:"_links,omitempty"`
 }
@@ -75,6 +90,7 @@ type Body struct {
 	Storage        *BodyRepresentation `json:"storage,omitempty"`
 	AtlasDocFormat *BodyRepresentation `json:"atlas_doc_format,omitempty"`
 	View           *BodyRepresentation `json:"view,omitempty"`
+	ExportView     *BodyRepresentation `json:"export_view,omitempty"`
 }
 
 // BodyRepresentation holds content in a specific format.
@@ -92,6 +108,7 @@ type Attachment struct {
 	MediaTypeDescription string   `json:"mediaTypeDescription,omitempty"`
 	Comment              string   `json:"comment,omitempty"`
 	FileSize             int64    `json:"fileSize"`
+	FileID               string   `json:"fileId,omitempty"`
 	WebuiLink            string   `json:"webuiLink,omitempty"`
 	DownloadLink         string   `json:"downloadLink,omitempty"`
 	Version              *Version `json:"version,omitempty"`
@@ -162,9 +179,10 @@ type UpdatePageRequest struct {
 
 // ErrorResponse represents an API error.
 type ErrorResponse struct {
-	StatusCode int      `json:"statusCode"`
-	Message    string   `json:"message"`
-	Errors     []string `json:"errors,omitempty"`
+	StatusCode int           `json:"statusCode"`
+	Message    string        `json:"message"`
+	Errors     []string      `json:"errors,omitempty"`
+	RetryAfter time.Duration `json:"-"` // Wait requested by a Retry-After header, if any
 }
 
 func (e *ErrorResponse) Error() string {
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// User is a Confluence user.
+type User struct {
+	Type        string `json:"type"` // known, anonymous
+	AccountID   string `json:"accountId"`
+	AccountType string `json:"accountType"` // atlassian, app
+	Email       string `json:"email"`       // Empty when hidden by profile visibility
+	PublicName  string `json:"publicName"`
+	DisplayName string `json:"displayName"`
+}
+
+// IsAnonymous reports whether the user is the anonymous user, which is
+// what a site allowing anonymous access returns for unauthenticated
+// requests.
+func (u *User) IsAnonymous() bool {
+	return u.Type == "anonymous"
+}
+
+// GetCurrentUser returns the user the client authenticates as.
+func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
+	// The v2 API has no current user endpoint
+	body, err := c.Get(ctx, "/rest/api/user/current")
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := json.Unmarshal(body, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+
+	return &user, nil
+}
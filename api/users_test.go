@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetCurrentUser(t *testing.T) {
+	tests := []struct {
+		name          string
+		response      string
+		wantEmail     string
+		wantAnonymous bool
+	}{
+		{
+			name:      "known user",
+			response:  `{"type": "known", "accountId": "abc", "accountType": "atlassian", "email": "user@example.com", "displayName": "User"}`,
+			wantEmail: "user@example.com",
+		},
+		{
+			name:          "anonymous",
+			response:      `{"type": "anonymous", "displayName": "Anonymous"}`,
+			wantAnonymous: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/rest/api/user/current", r.URL.Path)
+				assert.Equal(t, "GET", r.Method)
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token")
+			user, err := client.GetCurrentUser(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEmail, user.Email)
+			assert.Equal(t, tt.wantAnonymous, user.IsAnonymous())
+		})
+	}
+}
+
+func TestClient_GetCurrentUser_Unauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message": "Unauthorized"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	_, err := client.GetCurrentUser(context.Background())
+
+	var apiErr *ErrorResponse
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
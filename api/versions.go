@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ListVersionsOptions contains options for listing page versions.
+type ListVersionsOptions struct {
+	Limit  int
+	Cursor string
+}
+
+// ListPageVersions returns the versions of a page, newest first.
+func (c *Client) ListPageVersions(ctx context.Context, pageID string, opts *ListVersionsOptions) (*PaginatedResponse[Version], error) {
+	params := url.Values{}
+	params.Set("limit", "25")
+	params.Set("sort", "-modified-date")
+
+	if opts != nil {
+		if opts.Limit > 0 {
+			params.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.Cursor != "" {
+			params.Set("cursor", opts.Cursor)
+		}
+	}
+
+	path := fmt.Sprintf("/api/v2/pages/%s/versions?%s", pageID, params.Encode())
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PaginatedResponse[Version]
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse versions response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// restoreVersionRequest is the v1 request to restore a historical version.
+type restoreVersionRequest struct {
+	OperationKey string `json:"operationKey"`
+	Params       struct {
+		VersionNumber int    `json:"versionNumber"`
+		Message       string `json:"message"`
+	} `json:"params"`
+}
+
+// RestorePageVersion makes an old version of a page current again by
+// publishing its content as a new version, which is returned. History is
+// kept: the versions in between are not removed. Uses the v1 API, as v2
+// has no restore operation.
+func (c *Client) RestorePageVersion(ctx context.Context, pageID string, version int, message string) (*Version, error) {
+	req := restoreVersionRequest{OperationKey: "restore"}
+	req.Params.VersionNumber = version
+	req.Params.Message = message
+
+	path := fmt.Sprintf("/rest/api/content/%s/version", pageID)
+	body, err := c.Post(ctx, path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Number    int    `json:"number"`
+		Message   string `json:"message"`
+		MinorEdit bool   `json:"minorEdit"`
+		When      Time   `json:"when"`
+		By        struct {
+			AccountID string `json:"accountId"`
+		} `json:"by"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse restore response: %w", err)
+	}
+
+	return &Version{
+		Number:    result.Number,
+		Message:   result.Message,
+		MinorEdit: result.MinorEdit,
+		AuthorID:  result.By.AccountID,
+		CreatedAt: result.When,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListPageVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/pages/12345/versions", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		assert.Equal(t, "abc", r.URL.Query().Get("cursor"))
+		assert.Equal(t, "-modified-date", r.URL.Query().Get("sort"))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"results": [
+				{"number": 2, "message": "Fix typo", "minorEdit": true, "authorId": "u1", "createdAt": "2024-01-02T10:00:00.000Z"},
+				{"number": 1, "authorId": "u1", "createdAt": "2024-01-01T10:00:00.000Z"}
+			],
+			"_links": {}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	result, err := client.ListPageVersions(context.Background(), "12345", &ListVersionsOptions{Limit: 10, Cursor: "abc"})
+
+	require.NoError(t, err)
+	require.Len(t, result.Results, 2)
+	assert.Equal(t, 2, result.Results[0].Number)
+	assert.Equal(t, "Fix typo", result.Results[0].Message)
+	assert.True(t, result.Results[0].MinorEdit)
+	assert.False(t, result.HasMore())
+}
+
+func TestClient_RestorePageVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/content/12345/version", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "restore", req["operationKey"])
+		params := req["params"].(map[string]any)
+		assert.Equal(t, float64(3), params["versionNumber"])
+		assert.Equal(t, "Revert", params["message"])
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"number": 6, "message": "Revert", "by": {"accountId": "u1"}, "when": "2024-01-03T10:00:00.000Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	version, err := client.RestorePageVersion(context.Background(), "12345", 3, "Revert")
+
+	require.NoError(t, err)
+	assert.Equal(t, 6, version.Number)
+	assert.Equal(t, "Revert", version.Message)
+	assert.Equal(t, "u1", version.AuthorID)
+	assert.False(t, version.CreatedAt.IsZero())
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WatchAndUnwatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		call      func(c *Client) error
+		method    string
+		path      string
+		accountID string
+	}{
+		{"watch page", func(c *Client) error { return c.WatchPage(context.Background(), "123", "") },
+			"POST", "/rest/api/user/watch/content/123", ""},
+		{"watch page for user", func(c *Client) error { return c.WatchPage(context.Background(), "123", "abc") },
+			"POST", "/rest/api/user/watch/content/123", "abc"},
+		{"unwatch page", func(c *Client) error { return c.UnwatchPage(context.Background(), "123", "") },
+			"DELETE", "/rest/api/user/watch/content/123", ""},
+		{"watch space", func(c *Client) error { return c.WatchSpace(context.Background(), "DEV", "") },
+			"POST", "/rest/api/user/watch/space/DEV", ""},
+		{"unwatch space for user", func(c *Client) error { return c.UnwatchSpace(context.Background(), "DEV", "abc") },
+			"DELETE", "/rest/api/user/watch/space/DEV", "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.method, r.Method)
+				assert.Equal(t, tt.path, r.URL.Path)
+				assert.Equal(t, tt.accountID, r.URL.Query().Get("accountId"))
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token")
+			require.NoError(t, tt.call(client))
+		})
+	}
+}
+
+func TestClient_WatchPage_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Not permitted"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	err := client.WatchPage(context.Background(), "123", "someone")
+	require.Error(t, err)
+}
+
+func TestClient_ListPageWatchers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/rest/api/content/123/notification/child-created", r.URL.Path)
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		_, _ = w.Write([]byte(`{"results": [{"type": "watch", "contentId": "123",
+			"watcher": {"type": "known", "accountId": "abc", "displayName": "Ada"}}],
+			"start": 0, "limit": 10, "size": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	result, err := client.ListPageWatchers(context.Background(), "123", &ListWatchersOptions{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+	assert.Equal(t, "abc", result.Results[0].Watcher.AccountID)
+	assert.Equal(t, "Ada", result.Results[0].Watcher.DisplayName)
+	assert.False(t, result.HasMore())
+}
+
+func TestClient_GetPageWatchers_Paginates(t *testing.T) {
+	var starts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		starts = append(starts, r.URL.Query().Get("start"))
+		if r.URL.Query().Get("start") == "" {
+			_, _ = w.Write([]byte(`{"results": [{"watcher": {"accountId": "a"}}, {"watcher": {"accountId": "b"}}],
+				"start": 0, "size": 2, "_links": {"next": "/rest/api/content/123/notification/child-created?start=2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results": [{"watcher": {"accountId": "c"}}], "start": 2, "size": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	watches, err := client.GetPageWatchers(context.Background(), "123")
+	require.NoError(t, err)
+	require.Len(t, watches, 3)
+	assert.Equal(t, "c", watches[2].Watcher.AccountID)
+	assert.Equal(t, []string{"", "2"}, starts)
+}
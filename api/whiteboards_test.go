@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetWhiteboard(t *testing.T) {
+	const response = `{"id": "555", "type": "whiteboard", "status": "current", "title": "Architecture",
+		"spaceId": "777", "parentId": "42", "parentType": "page", "ownerId": "u1",
+		"version": {"number": 3}, "_links": {"webui": "/spaces/DEV/whiteboard/555"}, "extra": true}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v2/whiteboards/555", r.URL.Path)
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	whiteboard, err := client.GetWhiteboard(context.Background(), "555")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Architecture", whiteboard.Title)
+	assert.Equal(t, "777", whiteboard.SpaceID)
+	assert.Equal(t, "page", whiteboard.ParentType)
+	assert.Equal(t, 3, whiteboard.Version.Number)
+	assert.Equal(t, "/spaces/DEV/whiteboard/555", whiteboard.Links.WebUI)
+	assert.JSONEq(t, response, string(whiteboard.Raw), "raw keeps unmodeled fields")
+}
+
+func TestClient_GetWhiteboard_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token")
+	_, err := client.GetWhiteboard(context.Background(), "999")
+
+	require.Error(t, err)
+}
+
+func TestClient_ListWhiteboards(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *ListContentOptions
+		wantCQL string
+	}{
+		{name: "all", opts: nil, wantCQL: `type = "whiteboard"`},
+		{name: "space and title", opts: &ListContentOptions{SpaceKey: "DEV", Title: "arch"}, wantCQL: `space = "DEV" AND type = "whiteboard" AND title ~ "arch"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/rest/api/search", r.URL.Path)
+				assert.Equal(t, tt.wantCQL, r.URL.Query().Get("cql"))
+				_, _ = w.Write([]byte(`{"results": [{"content": {"id": "555", "type": "whiteboard", "title": "Architecture"}}], "size": 1, "totalSize": 1}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token")
+			result, err := client.ListWhiteboards(context.Background(), tt.opts)
+
+			require.NoError(t, err)
+			require.Len(t, result.Results, 1)
+			assert.Equal(t, "555", result.Results[0].Content.ID)
+		})
+	}
+}
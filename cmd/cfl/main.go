@@ -2,7 +2,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/open-cli-collective/confluence-cli/internal/cmd/root"
@@ -11,7 +10,7 @@ import (
 func main() {
 	cmd := root.NewCmdRoot()
 	if err := cmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		root.PrintError(os.Stderr, err)
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,6 @@
+//go:build plugins
+
+package main
+
+// Register converter plugins from the plugins package.
+import _ "github.com/open-cli-collective/confluence-cli/plugins"
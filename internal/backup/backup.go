@@ -0,0 +1,95 @@
+// Package backup records space exports so destructive commands can check
+// that a recent backup exists before running.
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+// Record describes a completed export of a space.
+type Record struct {
+	Site     string    `json:"site"` // Confluence base URL
+	SpaceKey string    `json:"spaceKey"`
+	Dir      string    `json:"dir"` // Absolute path of the export directory
+	Format   string    `json:"format"`
+	Pages    int       `json:"pages"`
+	Time     time.Time `json:"time"`
+}
+
+// DefaultPath returns the path of the export registry.
+func DefaultPath() string {
+	return filepath.Join(config.DefaultCacheDir(), "backups.json")
+}
+
+// Load reads the records in the registry at path. A missing registry has
+// no records.
+func Load(path string) ([]Record, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup registry: %w", err)
+	}
+
+	var records []Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse backup registry: %w", err)
+	}
+	return records, nil
+}
+
+// Add appends rec to the registry at path.
+func Add(path string, rec Record) error {
+	records, err := Load(path)
+	if err != nil {
+		return err
+	}
+	records = append(records, rec)
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal backup registry: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write backup registry: %w", err)
+	}
+	return nil
+}
+
+// Latest returns the most recent export of a space on a site whose
+// directory still exists, or nil if there is none.
+func Latest(path, site, spaceKey string) (*Record, error) {
+	records, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	site = strings.TrimSuffix(site, "/")
+	var latest *Record
+	for i := range records {
+		r := &records[i]
+		if strings.TrimSuffix(r.Site, "/") != site || !strings.EqualFold(r.SpaceKey, spaceKey) {
+			continue
+		}
+		if latest != nil && !r.Time.After(latest.Time) {
+			continue
+		}
+		if info, err := os.Stat(r.Dir); err != nil || !info.IsDir() {
+			continue
+		}
+		latest = r
+	}
+	return latest, nil
+}
@@ -0,0 +1,58 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatest(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cache", "backups.json")
+	site := "https://example.atlassian.net/wiki"
+
+	older := filepath.Join(dir, "older")
+	newer := filepath.Join(dir, "newer")
+	require.NoError(t, os.Mkdir(older, 0755))
+	require.NoError(t, os.Mkdir(newer, 0755))
+
+	now := time.Now()
+	for _, rec := range []Record{
+		{Site: site, SpaceKey: "DOCS", Dir: older, Time: now.Add(-48 * time.Hour)},
+		{Site: site, SpaceKey: "DOCS", Dir: newer, Time: now.Add(-time.Hour)},
+		{Site: site, SpaceKey: "DOCS", Dir: filepath.Join(dir, "removed"), Time: now},
+		{Site: "https://other.atlassian.net/wiki", SpaceKey: "DOCS", Dir: newer, Time: now},
+		{Site: site, SpaceKey: "ENG", Dir: newer, Time: now},
+	} {
+		require.NoError(t, Add(path, rec))
+	}
+
+	// Newest export whose directory still exists
+	rec, err := Latest(path, site+"/", "docs")
+	require.NoError(t, err)
+	require.NotNil(t, rec)
+	assert.Equal(t, newer, rec.Dir)
+
+	rec, err = Latest(path, site, "HR")
+	require.NoError(t, err)
+	assert.Nil(t, rec)
+}
+
+func TestLatest_NoRegistry(t *testing.T) {
+	rec, err := Latest(filepath.Join(t.TempDir(), "backups.json"), "https://example.atlassian.net/wiki", "DOCS")
+	require.NoError(t, err)
+	assert.Nil(t, rec)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backups.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0600))
+
+	_, err := Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse backup registry")
+}
@@ -11,13 +11,14 @@ func NewCmdAttachment() *cobra.Command {
 		Use:     "attachment",
 		Aliases: []string{"attachments", "att"},
 		Short:   "Manage Confluence attachments",
-		Long:    `Commands for listing, uploading, and downloading Confluence page attachments.`,
+		Long:    `Commands for listing, uploading, downloading, and labeling Confluence page attachments.`,
 	}
 
 	cmd.AddCommand(NewCmdList())
 	cmd.AddCommand(NewCmdUpload())
 	cmd.AddCommand(NewCmdDownload())
 	cmd.AddCommand(NewCmdDelete())
+	cmd.AddCommand(NewCmdLabel())
 
 	return cmd
 }
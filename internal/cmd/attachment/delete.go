@@ -62,7 +62,7 @@ func runDeleteAttachment(attachmentID string, opts *deleteOptions, client *api.C
 			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
 		}
 
-		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken)
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
 	}
 
 	// Get attachment info first to show what we're deleting
@@ -95,7 +95,7 @@ func runDeleteAttachment(attachmentID string, opts *deleteOptions, client *api.C
 		return fmt.Errorf("failed to delete attachment: %w", err)
 	}
 
-	if opts.output == "json" {
+	if view.IsStructured(opts.output) {
 		return renderer.RenderJSON(map[string]string{
 			"status":        "deleted",
 			"attachment_id": attachmentID,
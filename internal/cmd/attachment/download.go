@@ -19,6 +19,7 @@ type downloadOptions struct {
 	outputFile string
 	noColor    bool
 	force      bool
+	version    int
 }
 
 // NewCmdDownload creates the attachment download command.
@@ -28,12 +29,18 @@ func NewCmdDownload() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "download <attachment-id>",
 		Short: "Download an attachment",
-		Long:  `Download an attachment by its ID.`,
+		Long: `Download an attachment by its ID.
+
+With --version, that version of the attachment is downloaded instead of
+the current one.`,
 		Example: `  # Download an attachment
   cfl attachment download abc123
 
   # Download to a specific file
-  cfl attachment download abc123 -O document.pdf`,
+  cfl attachment download abc123 -O document.pdf
+
+  # Download the version that was reviewed
+  cfl attachment download abc123 --version 2`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.output, _ = cmd.Flags().GetString("output")
@@ -44,11 +51,16 @@ func NewCmdDownload() *cobra.Command {
 
 	cmd.Flags().StringVarP(&opts.outputFile, "output-file", "O", "", "Output file path (default: original filename)")
 	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Overwrite existing file without warning")
+	cmd.Flags().IntVar(&opts.version, "version", 0, "Download a specific version of the attachment (default: current)")
 
 	return cmd
 }
 
 func runDownload(attachmentID string, opts *downloadOptions, client *api.Client) error {
+	if opts.version < 0 {
+		return fmt.Errorf("invalid version: %d (must be >= 1)", opts.version)
+	}
+
 	// Create API client if not provided (allows injection for testing)
 	if client == nil {
 		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
@@ -60,7 +72,7 @@ func runDownload(attachmentID string, opts *downloadOptions, client *api.Client)
 			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
 		}
 
-		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken)
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
 	}
 
 	// Get attachment info first to get the filename
@@ -87,7 +99,7 @@ func runDownload(attachmentID string, opts *downloadOptions, client *api.Client)
 	}
 
 	// Download the attachment
-	reader, err := client.DownloadAttachment(context.Background(), attachmentID)
+	reader, err := client.DownloadAttachmentVersion(context.Background(), attachmentID, opts.version)
 	if err != nil {
 		return fmt.Errorf("failed to download attachment: %w", err)
 	}
@@ -110,7 +122,7 @@ func runDownload(attachmentID string, opts *downloadOptions, client *api.Client)
 	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
 
 	renderer.Success(fmt.Sprintf("Downloaded: %s", outputPath))
-	renderer.RenderKeyValue("Size", formatFileSize(bytesWritten))
+	renderer.RenderKeyValue("Size", view.FormatFileSize(bytesWritten))
 
 	return nil
 }
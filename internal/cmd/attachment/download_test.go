@@ -111,7 +111,11 @@ func mockDownloadServer(t *testing.T) *httptest.Server {
 			// Download content
 			w.Header().Set("Content-Type", "application/pdf")
 			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("fake pdf content"))
+			content := "fake pdf content"
+			if v := r.URL.Query().Get("version"); v != "" {
+				content += " v" + v
+			}
+			w.Write([]byte(content))
 		default:
 			t.Errorf("unexpected request: %s", r.URL.Path)
 			w.WriteHeader(http.StatusNotFound)
@@ -165,6 +169,27 @@ func TestRunDownload_CustomOutputFile(t *testing.T) {
 	assert.Equal(t, "fake pdf content", string(content))
 }
 
+func TestRunDownload_Version(t *testing.T) {
+	server := mockDownloadServer(t)
+	defer server.Close()
+
+	outputPath := filepath.Join(t.TempDir(), "reviewed.pdf")
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runDownload("att123", &downloadOptions{outputFile: outputPath, version: 2, noColor: true}, client)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, "fake pdf content v2", string(content))
+}
+
+func TestRunDownload_InvalidVersion(t *testing.T) {
+	err := runDownload("att123", &downloadOptions{version: -1, noColor: true}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid version")
+}
+
 func TestRunDownload_FileExists_NoForce(t *testing.T) {
 	server := mockDownloadServer(t)
 	defer server.Close()
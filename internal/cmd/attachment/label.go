@@ -0,0 +1,263 @@
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type labelOptions struct {
+	output  string
+	noColor bool
+}
+
+// NewCmdLabel creates the attachment label command.
+func NewCmdLabel() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "label",
+		Aliases: []string{"labels"},
+		Short:   "Manage attachment labels",
+		Long: `Commands for listing, adding, and removing the labels on attachments.
+
+Labels classify files for governance, such as retention or confidentiality;
+'cfl attachment list --label' lists the attachments with a label.`,
+	}
+
+	cmd.AddCommand(NewCmdLabelList())
+	cmd.AddCommand(NewCmdLabelAdd())
+	cmd.AddCommand(NewCmdLabelRemove())
+
+	return cmd
+}
+
+// NewCmdLabelList creates the attachment label list command.
+func NewCmdLabelList() *cobra.Command {
+	opts := &labelOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list <attachment-id>",
+		Aliases: []string{"ls"},
+		Short:   "List labels on an attachment",
+		Example: `  # List labels on an attachment
+  cfl attachment label list att123
+
+  # Output as JSON
+  cfl attachment label list att123 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runLabelList(args[0], opts, nil)
+		},
+	}
+
+	return cmd
+}
+
+// NewCmdLabelAdd creates the attachment label add command.
+func NewCmdLabelAdd() *cobra.Command {
+	opts := &labelOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "add <attachment-id> <label>...",
+		Short: "Add labels to an attachment",
+		Long: `Add one or more labels to an attachment.
+
+Labels the attachment already has are left unchanged. Labels cannot contain
+spaces; Confluence stores them in lowercase.`,
+		Example: `  # Classify a file
+  cfl attachment label add att123 contract confidential`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runLabelAdd(args[0], args[1:], opts, nil)
+		},
+	}
+
+	return cmd
+}
+
+// NewCmdLabelRemove creates the attachment label remove command.
+func NewCmdLabelRemove() *cobra.Command {
+	opts := &labelOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "remove <attachment-id> <label>...",
+		Aliases: []string{"rm"},
+		Short:   "Remove labels from an attachment",
+		Example: `  # Remove a label
+  cfl attachment label remove att123 draft`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runLabelRemove(args[0], args[1:], opts, nil)
+		},
+	}
+
+	return cmd
+}
+
+func runLabelList(attachmentID string, opts *labelOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	labels, err := client.GetAttachmentLabels(context.Background(), attachmentID)
+	if err != nil {
+		return fmt.Errorf("failed to list labels: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		if labels == nil {
+			labels = []api.Label{}
+		}
+		return renderer.RenderJSON(labels)
+	}
+
+	if len(labels) == 0 {
+		fmt.Println("No labels found.")
+		return nil
+	}
+
+	headers := []string{"NAME", "PREFIX", "ID"}
+	var rows [][]string
+	for _, l := range labels {
+		rows = append(rows, []string{l.Name, l.Prefix, l.ID})
+	}
+	renderer.RenderTable(headers, rows)
+	return nil
+}
+
+func runLabelAdd(attachmentID string, names []string, opts *labelOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Validate before loading config so typos fail fast
+	for _, name := range names {
+		if err := api.ValidateLabel(name); err != nil {
+			return err
+		}
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	labels, err := client.AddAttachmentLabels(context.Background(), attachmentID, names)
+	if err != nil {
+		return fmt.Errorf("failed to add labels: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(labels)
+	}
+
+	renderer.Success(fmt.Sprintf("Added %s to attachment %s", labelList(names), attachmentID))
+	return nil
+}
+
+func runLabelRemove(attachmentID string, names []string, opts *labelOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	for _, name := range names {
+		if err := client.RemoveAttachmentLabel(context.Background(), attachmentID, name); err != nil {
+			return fmt.Errorf("failed to remove label %s: %w", name, err)
+		}
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]interface{}{
+			"status":        "removed",
+			"attachment_id": attachmentID,
+			"labels":        names,
+		})
+	}
+
+	renderer.Success(fmt.Sprintf("Removed %s from attachment %s", labelList(names), attachmentID))
+	return nil
+}
+
+// labelList formats label names for messages.
+func labelList(names []string) string {
+	if len(names) == 1 {
+		return "label " + names[0]
+	}
+	return "labels " + strings.Join(names, ", ")
+}
+
+// filterLabeled returns the attachments that have the label.
+func filterLabeled(ctx context.Context, client *api.Client, attachments []api.Attachment, label string) ([]api.Attachment, error) {
+	var labeled []api.Attachment
+	for _, att := range attachments {
+		labels, err := client.GetAttachmentLabels(ctx, att.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list labels of %s: %w", att.ID, err)
+		}
+		for _, l := range labels {
+			// Confluence stores labels in lowercase
+			if strings.EqualFold(l.Name, label) {
+				labeled = append(labeled, att)
+				break
+			}
+		}
+	}
+	return labeled, nil
+}
@@ -0,0 +1,106 @@
+package attachment
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunLabelList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/attachments/att123/labels", r.URL.Path)
+		w.Write([]byte(`{"results": [{"id": "1", "name": "contract", "prefix": "global"}]}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runLabelList("att123", &labelOptions{noColor: true}, client)
+	require.NoError(t, err)
+}
+
+func TestRunLabelAdd(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/rest/api/content/123/label", r.URL.Path)
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runLabelAdd("att123", []string{"contract", "confidential"}, &labelOptions{noColor: true}, client)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"prefix": "global", "name": "contract"}, {"prefix": "global", "name": "confidential"}]`, body)
+}
+
+func TestRunLabelAdd_Invalid(t *testing.T) {
+	err := runLabelAdd("att123", []string{"not ok"}, &labelOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid label "not ok"`)
+}
+
+func TestRunLabelRemove(t *testing.T) {
+	var removed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/rest/api/content/123/label", r.URL.Path)
+		removed = append(removed, r.URL.Query().Get("name"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runLabelRemove("att123", []string{"draft", "wip"}, &labelOptions{output: "json"}, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"draft", "wip"}, removed)
+}
+
+func TestRunList_LabelFilter(t *testing.T) {
+	var labelRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/pages/12345/attachments":
+			w.Write([]byte(`{"results": [{"id": "att1", "title": "nda.pdf"}, {"id": "att2", "title": "logo.png"}]}`))
+		case "/api/v2/attachments/att1/labels":
+			labelRequests = append(labelRequests, "att1")
+			w.Write([]byte(`{"results": [{"id": "1", "name": "contract", "prefix": "global"}]}`))
+		case "/api/v2/attachments/att2/labels":
+			labelRequests = append(labelRequests, "att2")
+			w.Write([]byte(`{"results": []}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runList(&listOptions{pageID: "12345", limit: 25, label: "Contract", noColor: true}, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"att1", "att2"}, labelRequests)
+}
+
+func TestFilterLabeled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/attachments/att1/labels" {
+			w.Write([]byte(`{"results": [{"id": "1", "name": "contract", "prefix": "global"}]}`))
+			return
+		}
+		w.Write([]byte(`{"results": [{"id": "2", "name": "logo", "prefix": "global"}]}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	attachments := []api.Attachment{{ID: "att1", Title: "nda.pdf"}, {ID: "att2", Title: "logo.png"}}
+	labeled, err := filterLabeled(t.Context(), client, attachments, "contract")
+	require.NoError(t, err)
+	assert.Equal(t, []api.Attachment{{ID: "att1", Title: "nda.pdf"}}, labeled)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
 	"github.com/open-cli-collective/confluence-cli/internal/config"
 	"github.com/open-cli-collective/confluence-cli/internal/view"
 )
@@ -16,7 +17,9 @@ import (
 type listOptions struct {
 	pageID  string
 	limit   int
+	all     bool
 	unused  bool
+	label   string
 	output  string
 	noColor bool
 }
@@ -36,8 +39,14 @@ func NewCmdList() *cobra.Command {
   # List with custom limit
   cfl attachment list --page 12345 --limit 50
 
+  # List every attachment, following pagination
+  cfl attachment list --page 12345 --all
+
   # List unused (orphaned) attachments not referenced in page content
-  cfl attachment list --page 12345 --unused`,
+  cfl attachment list --page 12345 --unused
+
+  # List attachments labeled "contract"
+  cfl attachment list --page 12345 --all --label contract`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			opts.output, _ = cmd.Flags().GetString("output")
 			opts.noColor, _ = cmd.Flags().GetBool("no-color")
@@ -45,15 +54,20 @@ func NewCmdList() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.pageID, "page", "p", "", "Page ID (required)")
+	cmd.Flags().StringVarP(&opts.pageID, "page", "p", "", "Page ID, URL, or SPACE/Title (required)")
 	cmd.Flags().IntVarP(&opts.limit, "limit", "l", 25, "Maximum number of attachments to return")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Return all attachments, following pagination (ignores --limit)")
 	cmd.Flags().BoolVar(&opts.unused, "unused", false, "Show only attachments not referenced in page content")
+	cmd.Flags().StringVar(&opts.label, "label", "", "Show only attachments with this label")
 
 	_ = cmd.MarkFlagRequired("page")
 
 	return cmd
 }
 
+// listAllBatchSize is the number of attachments requested at a time with --all.
+const listAllBatchSize = 250
+
 func runList(opts *listOptions, client *api.Client) error {
 	// Validate output format
 	if err := view.ValidateFormat(opts.output); err != nil {
@@ -71,21 +85,36 @@ func runList(opts *listOptions, client *api.Client) error {
 			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
 		}
 
-		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken)
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, opts.pageID)
+	if err != nil {
+		return err
 	}
+	opts.pageID = pageID
 
 	// List attachments
 	apiOpts := &api.ListAttachmentsOptions{
 		Limit: opts.limit,
 	}
 
-	result, err := client.ListAttachments(context.Background(), opts.pageID, apiOpts)
-	if err != nil {
-		return fmt.Errorf("failed to list attachments: %w", err)
+	var attachments []api.Attachment
+	hasMore := false
+	if opts.all {
+		apiOpts.Limit = listAllBatchSize
+		attachments, err = api.ListAll(client.AttachmentsIter(context.Background(), opts.pageID, apiOpts, api.WithPrefetch()))
+		if err != nil {
+			return fmt.Errorf("failed to list attachments: %w", err)
+		}
+	} else {
+		result, err := client.ListAttachments(context.Background(), opts.pageID, apiOpts)
+		if err != nil {
+			return fmt.Errorf("failed to list attachments: %w", err)
+		}
+		attachments, hasMore = result.Results, result.HasMore()
 	}
 
-	attachments := result.Results
-
 	// Filter to unused attachments if requested
 	if opts.unused {
 		// Fetch page content in storage format
@@ -104,6 +133,14 @@ func runList(opts *listOptions, client *api.Client) error {
 		attachments = filterUnusedAttachments(attachments, pageContent)
 	}
 
+	// Filter to labeled attachments if requested
+	if opts.label != "" {
+		attachments, err = filterLabeled(context.Background(), client, attachments, opts.label)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Render output
 	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
 
@@ -111,24 +148,27 @@ func runList(opts *listOptions, client *api.Client) error {
 	headers := []string{"ID", "Title", "Media Type", "File Size"}
 	var rows [][]string
 	for _, att := range attachments {
-		size := formatFileSize(att.FileSize)
+		size := view.FormatFileSize(att.FileSize)
 		rows = append(rows, []string{att.ID, att.Title, att.MediaType, size})
 	}
 
 	// Handle empty result for non-JSON output
-	if len(attachments) == 0 && opts.output != "json" {
-		if opts.unused {
+	if len(attachments) == 0 && !view.IsStructured(opts.output) {
+		switch {
+		case opts.unused:
 			fmt.Println("No unused attachments found.")
-		} else {
+		case opts.label != "":
+			fmt.Printf("No attachments labeled %s found.\n", opts.label)
+		default:
 			fmt.Println("No attachments found.")
 		}
 		return nil
 	}
 
-	renderer.RenderList(headers, rows, result.HasMore())
+	renderer.RenderList(headers, rows, hasMore)
 
-	if result.HasMore() && opts.output != "json" {
-		fmt.Fprintf(os.Stderr, "\n(showing first %d results, use --limit to see more)\n", len(attachments))
+	if hasMore && !view.IsStructured(opts.output) {
+		fmt.Fprintf(os.Stderr, "\n(showing first %d results, use --limit or --all to see more)\n", len(attachments))
 	}
 
 	return nil
@@ -168,22 +208,3 @@ func isAttachmentReferenced(filename, content string) bool {
 
 	return false
 }
-
-func formatFileSize(bytes int64) string {
-	const (
-		KB = 1024
-		MB = KB * 1024
-		GB = MB * 1024
-	)
-
-	switch {
-	case bytes >= GB:
-		return fmt.Sprintf("%.1f GB", float64(bytes)/GB)
-	case bytes >= MB:
-		return fmt.Sprintf("%.1f MB", float64(bytes)/MB)
-	case bytes >= KB:
-		return fmt.Sprintf("%.1f KB", float64(bytes)/KB)
-	default:
-		return fmt.Sprintf("%d B", bytes)
-	}
-}
@@ -3,6 +3,7 @@ package attachment
 import (
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -108,29 +109,6 @@ func TestRunList_InvalidOutputFormat(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid output format")
 }
 
-func TestFormatFileSize(t *testing.T) {
-	tests := []struct {
-		bytes    int64
-		expected string
-	}{
-		{0, "0 B"},
-		{500, "500 B"},
-		{1024, "1.0 KB"},
-		{1536, "1.5 KB"},
-		{1048576, "1.0 MB"},
-		{1572864, "1.5 MB"},
-		{1073741824, "1.0 GB"},
-		{1610612736, "1.5 GB"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.expected, func(t *testing.T) {
-			result := formatFileSize(tt.bytes)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
 func TestIsAttachmentReferenced(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -309,3 +287,34 @@ func TestRunList_UnusedFlag_NoUnused(t *testing.T) {
 	err := runList(opts, client)
 	require.NoError(t, err)
 }
+
+func TestRunList_All(t *testing.T) {
+	var mu sync.Mutex
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/pages/12345/attachments", r.URL.Path)
+		assert.Equal(t, "250", r.URL.Query().Get("limit"))
+		mu.Lock()
+		cursors = append(cursors, r.URL.Query().Get("cursor"))
+		mu.Unlock()
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"results": [{"id": "att1", "title": "doc.pdf"}],
+				"_links": {"next": "/api/v2/pages/12345/attachments?cursor=next"}}`))
+			return
+		}
+		w.Write([]byte(`{"results": [{"id": "att2", "title": "image.png"}]}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &listOptions{
+		pageID:  "12345",
+		limit:   25,
+		all:     true,
+		noColor: true,
+	}
+
+	err := runList(opts, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "next"}, cursors)
+}
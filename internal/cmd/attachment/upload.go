@@ -1,6 +1,7 @@
 package attachment
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -9,7 +10,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
 	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/imageopt"
 	"github.com/open-cli-collective/confluence-cli/internal/view"
 )
 
@@ -19,6 +22,12 @@ type uploadOptions struct {
 	comment string
 	output  string
 	noColor bool
+
+	optimize    bool
+	optimizeSet bool // --optimize was given, so images.optimize is ignored
+	maxWidth    int  // Zero for config or the default
+	maxHeight   int  // Zero for config or the default
+	quality     int  // Zero for config or the default
 }
 
 // NewCmdUpload creates the attachment upload command.
@@ -28,22 +37,35 @@ func NewCmdUpload() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "upload",
 		Short: "Upload an attachment to a page",
-		Long:  `Upload a file as an attachment to a Confluence page.`,
+		Long: `Upload a file as an attachment to a Confluence page.
+
+With --optimize, JPEG and PNG images larger than the maximum dimensions are
+scaled down and recompressed before upload. Other files, including SVG and
+GIF, are uploaded unchanged. The images section of the config file (or
+CFL_OPTIMIZE_IMAGES and CFL_IMAGE_*) sets the defaults of these flags.`,
 		Example: `  # Upload a file
   cfl attachment upload --page 12345 --file document.pdf
 
   # Upload with a comment (-m for message/comment)
-  cfl attachment upload --page 12345 --file image.png -m "Screenshot"`,
+  cfl attachment upload --page 12345 --file image.png -m "Screenshot"
+
+  # Shrink a large screenshot before uploading
+  cfl attachment upload --page 12345 --file screenshot.png --optimize --max-width 1280`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			opts.output, _ = cmd.Flags().GetString("output")
 			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.optimizeSet = cmd.Flags().Changed("optimize")
 			return runUpload(opts, nil)
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.pageID, "page", "p", "", "Page ID (required)")
+	cmd.Flags().StringVarP(&opts.pageID, "page", "p", "", "Page ID, URL, or SPACE/Title (required)")
 	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "File to upload (required)")
 	cmd.Flags().StringVarP(&opts.comment, "comment", "m", "", "Comment for the attachment")
+	cmd.Flags().BoolVar(&opts.optimize, "optimize", false, "Downscale and recompress JPEG/PNG images before upload (default: config images.optimize)")
+	cmd.Flags().IntVar(&opts.maxWidth, "max-width", 0, fmt.Sprintf("Maximum image width in pixels with --optimize (default: config images.max_width or %d)", imageopt.DefaultMaxWidth))
+	cmd.Flags().IntVar(&opts.maxHeight, "max-height", 0, fmt.Sprintf("Maximum image height in pixels with --optimize (default: config images.max_height or %d)", imageopt.DefaultMaxHeight))
+	cmd.Flags().IntVar(&opts.quality, "quality", 0, fmt.Sprintf("JPEG quality 1-100 with --optimize (default: config images.quality or %d)", imageopt.DefaultQuality))
 
 	_ = cmd.MarkFlagRequired("page")
 	_ = cmd.MarkFlagRequired("file")
@@ -63,21 +85,52 @@ func runUpload(opts *uploadOptions, client *api.Client) error {
 			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
 		}
 
-		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken)
+		if !opts.optimizeSet {
+			opts.optimize = cfg.Images.Optimize
+		}
+		if opts.maxWidth == 0 {
+			opts.maxWidth = cfg.Images.MaxWidth
+		}
+		if opts.maxHeight == 0 {
+			opts.maxHeight = cfg.Images.MaxHeight
+		}
+		if opts.quality == 0 {
+			opts.quality = cfg.Images.Quality
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
 	}
 
-	// Open file
-	file, err := os.Open(opts.file)
+	pageID, err := pageref.Resolve(context.Background(), client, opts.pageID)
+	if err != nil {
+		return err
+	}
+	opts.pageID = pageID
+
+	// Read file
+	data, err := os.ReadFile(opts.file)
 	if err != nil {
 		return fmt.Errorf("failed to open file: %w", err)
 	}
-	defer func() { _ = file.Close() }()
 
 	// Get filename from path
 	filename := filepath.Base(opts.file)
 
+	var optimized *imageopt.Result
+	if opts.optimize {
+		optimized, err = imageopt.Optimize(filename, data, imageopt.Options{
+			MaxWidth:  opts.maxWidth,
+			MaxHeight: opts.maxHeight,
+			Quality:   opts.quality,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to optimize image: %w", err)
+		}
+		data = optimized.Data
+	}
+
 	// Upload attachment
-	attachment, err := client.UploadAttachment(context.Background(), opts.pageID, filename, file, opts.comment)
+	attachment, err := client.UploadAttachment(context.Background(), opts.pageID, filename, bytes.NewReader(data), opts.comment)
 	if err != nil {
 		return fmt.Errorf("failed to upload attachment: %w", err)
 	}
@@ -85,14 +138,19 @@ func runUpload(opts *uploadOptions, client *api.Client) error {
 	// Render output
 	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
 
-	if opts.output == "json" {
+	if view.IsStructured(opts.output) {
 		return renderer.RenderJSON(attachment)
 	}
 
 	renderer.Success(fmt.Sprintf("Uploaded: %s", filename))
 	renderer.RenderKeyValue("ID", attachment.ID)
 	renderer.RenderKeyValue("Title", attachment.Title)
-	renderer.RenderKeyValue("Size", formatFileSize(attachment.FileSize))
+	renderer.RenderKeyValue("Size", view.FormatFileSize(attachment.FileSize))
+	if optimized != nil && optimized.Optimized {
+		renderer.RenderKeyValue("Optimized", fmt.Sprintf("%s → %s (%dx%d → %dx%d)",
+			view.FormatFileSize(int64(optimized.OriginalSize)), view.FormatFileSize(int64(len(optimized.Data))),
+			optimized.OriginalWidth, optimized.OriginalHeight, optimized.Width, optimized.Height))
+	}
 
 	return nil
 }
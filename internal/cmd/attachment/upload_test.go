@@ -1,6 +1,11 @@
 package attachment
 
 import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -151,3 +156,48 @@ func TestRunUpload_JSONOutput(t *testing.T) {
 	err = runUpload(opts, client)
 	require.NoError(t, err)
 }
+
+func TestRunUpload_Optimize(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "screenshot.png")
+
+	img := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 400; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, (&png.Encoder{CompressionLevel: png.NoCompression}).Encode(&buf, img))
+	require.NoError(t, os.WriteFile(testFile, buf.Bytes(), 0644))
+
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		require.NoError(t, err)
+		uploaded, err = io.ReadAll(file)
+		require.NoError(t, err)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"results": [{"id": "att123", "title": "screenshot.png", "fileSize": 100}]}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &uploadOptions{
+		pageID:   "12345",
+		file:     testFile,
+		optimize: true,
+		maxWidth: 100,
+		noColor:  true,
+	}
+
+	err := runUpload(opts, client)
+	require.NoError(t, err)
+
+	assert.Less(t, len(uploaded), buf.Len())
+	cfg, err := png.DecodeConfig(bytes.NewReader(uploaded))
+	require.NoError(t, err)
+	assert.Equal(t, 100, cfg.Width)
+	assert.Equal(t, 50, cfg.Height)
+}
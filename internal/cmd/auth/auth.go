@@ -0,0 +1,19 @@
+// Package auth provides authentication diagnostic commands.
+package auth
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmdAuth creates the auth command.
+func NewCmdAuth() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Diagnose authentication",
+		Long:  `Commands for checking the credentials cfl authenticates with.`,
+	}
+
+	cmd.AddCommand(NewCmdTokenCheck())
+
+	return cmd
+}
@@ -0,0 +1,284 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+// apiTokenURL is where Atlassian Cloud API tokens are created.
+const apiTokenURL = "https://id.atlassian.com/manage-profile/security/api-tokens"
+
+// Token types detected from a token's format.
+const (
+	tokenCloud       = "cloud-api-token"
+	tokenCloudLegacy = "cloud-api-token-legacy"
+	tokenDCPAT       = "datacenter-pat"
+	tokenUnknown     = "unknown"
+)
+
+// Check statuses.
+const (
+	statusOK   = "ok"
+	statusWarn = "warn"
+	statusFail = "fail"
+)
+
+var (
+	legacyTokenPattern = regexp.MustCompile(`^[A-Za-z0-9]{24}$`)
+	patPattern         = regexp.MustCompile(`^[A-Za-z0-9+/]{44}$`)
+)
+
+type tokenCheckOptions struct {
+	output  string
+	noColor bool
+}
+
+// check is the result of one diagnostic.
+type check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// tokenReport is the outcome of a token check.
+type tokenReport struct {
+	URL       string  `json:"url"`
+	Email     string  `json:"email"`
+	Token     string  `json:"token"` // Masked
+	TokenType string  `json:"tokenType"`
+	Owner     string  `json:"owner,omitempty"`
+	Checks    []check `json:"checks"`
+}
+
+func (r *tokenReport) add(name, status, format string, args ...interface{}) {
+	r.Checks = append(r.Checks, check{Name: name, Status: status, Detail: fmt.Sprintf(format, args...)})
+}
+
+func (r *tokenReport) failed() bool {
+	for _, c := range r.Checks {
+		if c.Status == statusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// NewCmdTokenCheck creates the auth token-check command.
+func NewCmdTokenCheck() *cobra.Command {
+	opts := &tokenCheckOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "token-check",
+		Short: "Diagnose the configured API token",
+		Long: `Check the configured API token and explain what is wrong with it.
+
+The check looks at the token's format to tell an Atlassian Cloud API token
+from a Data Center personal access token, which cfl can't use, then signs
+in to confirm the token is accepted and belongs to the configured email.
+The token is masked in all output.
+
+Create a Cloud API token at ` + apiTokenURL + `.`,
+		Example: `  # Check the configured token
+  cfl auth token-check
+
+  # Check a token from the environment before saving it
+  CFL_API_TOKEN=ATATT3x... cfl auth token-check`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runTokenCheck(opts, nil, nil)
+		},
+	}
+
+	return cmd
+}
+
+func runTokenCheck(opts *tokenCheckOptions, cfg *config.Config, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Load config if not provided (allows injection for testing)
+	if cfg == nil {
+		var err error
+		cfg, err = config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+	}
+	if cfg.APIToken == "" {
+		return fmt.Errorf("no API token configured (run 'cfl init' or set CFL_API_TOKEN)")
+	}
+
+	report := &tokenReport{
+		URL:       cfg.URL,
+		Email:     cfg.Email,
+		Token:     maskToken(cfg.APIToken),
+		TokenType: tokenType(cfg.APIToken),
+	}
+
+	checkFormat(report, cfg.APIToken)
+	checkEmail(report, cfg.Email)
+
+	if cfg.URL == "" {
+		report.add("sign-in", statusFail, "no URL configured; skipped")
+	} else {
+		if client == nil {
+			client = api.NewClient(cfg.URL, cfg.Email, strings.TrimSpace(cfg.APIToken))
+		}
+		checkSignIn(report, client, cfg.Email)
+	}
+
+	if view.IsStructured(opts.output) {
+		renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+		if err := renderer.RenderJSON(report); err != nil {
+			return err
+		}
+	} else {
+		printReport(report, opts.noColor)
+	}
+
+	if report.failed() {
+		return fmt.Errorf("token check failed")
+	}
+	return nil
+}
+
+// checkFormat reports what kind of token the token looks like.
+func checkFormat(r *tokenReport, token string) {
+	if strings.TrimSpace(token) != token {
+		r.add("format", statusFail, "token has leading or trailing whitespace (copy and paste error?)")
+		return
+	}
+	if strings.ContainsAny(token, "\"' \t") {
+		r.add("format", statusFail, "token contains quotes or spaces (copy and paste error?)")
+		return
+	}
+
+	switch r.TokenType {
+	case tokenCloud:
+		r.add("format", statusOK, "Atlassian Cloud API token (%d characters)", len(token))
+	case tokenCloudLegacy:
+		r.add("format", statusOK, "Atlassian Cloud API token, legacy format")
+	case tokenDCPAT:
+		r.add("format", statusFail, "looks like a Data Center personal access token; cfl needs a Cloud API token from %s", apiTokenURL)
+	default:
+		r.add("format", statusWarn, "unrecognized format (%d characters); Cloud API tokens start with ATATT", len(token))
+	}
+}
+
+// checkEmail checks the configured email looks like an address.
+func checkEmail(r *tokenReport, email string) {
+	switch {
+	case email == "":
+		r.add("email", statusFail, "no email configured; Cloud API tokens are used with the account's email")
+	case !strings.Contains(email, "@"):
+		r.add("email", statusFail, "%q is not an email address; use the email of the token's Atlassian account", email)
+	default:
+		r.add("email", statusOK, "%s", email)
+	}
+}
+
+// checkSignIn signs in with the token and compares the account it belongs
+// to with the configured email.
+func checkSignIn(r *tokenReport, client *api.Client, email string) {
+	user, err := client.GetCurrentUser(context.Background())
+	if err != nil {
+		var apiErr *api.ErrorResponse
+		switch {
+		case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized:
+			r.add("sign-in", statusFail, "rejected (401): the token is invalid, expired or revoked, or doesn't belong to %s", email)
+		case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden:
+			r.add("sign-in", statusFail, "forbidden (403): the account may lack access to this site")
+		default:
+			r.add("sign-in", statusFail, "%v", err)
+		}
+		return
+	}
+
+	if user.IsAnonymous() {
+		r.add("sign-in", statusFail, "the site treated the request as anonymous: the credentials were ignored")
+		return
+	}
+	r.add("sign-in", statusOK, "signed in as %s", user.DisplayName)
+
+	r.Owner = user.Email
+	switch {
+	case user.Email == "":
+		r.add("owner", statusWarn, "the account hides its email, so it can't be compared with %s", email)
+	case !strings.EqualFold(user.Email, email):
+		r.add("owner", statusFail, "token belongs to %s, but the configured email is %s", user.Email, email)
+	default:
+		r.add("owner", statusOK, "token belongs to the configured email")
+	}
+}
+
+// tokenType classifies a token by its format.
+func tokenType(token string) string {
+	token = strings.TrimSpace(token)
+	switch {
+	case strings.HasPrefix(token, "ATATT"):
+		return tokenCloud
+	case legacyTokenPattern.MatchString(token):
+		return tokenCloudLegacy
+	case patPattern.MatchString(token):
+		return tokenDCPAT
+	}
+	return tokenUnknown
+}
+
+// maskToken hides all but the ends of a token.
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return strings.Repeat("*", len(token))
+	}
+	return token[:4] + strings.Repeat("*", 8) + token[len(token)-4:]
+}
+
+// printReport prints the checks as a checklist.
+func printReport(r *tokenReport, noColor bool) {
+	if noColor {
+		color.NoColor = true
+	}
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+	red := color.New(color.FgRed)
+
+	host := r.URL
+	if u, err := url.Parse(r.URL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	fmt.Printf("Site:  %s\n", host)
+	fmt.Printf("Token: %s\n\n", r.Token)
+
+	for _, c := range r.Checks {
+		switch c.Status {
+		case statusOK:
+			_, _ = green.Printf("✓ %-8s", c.Name)
+		case statusWarn:
+			_, _ = yellow.Printf("! %-8s", c.Name)
+		default:
+			_, _ = red.Printf("✗ %-8s", c.Name)
+		}
+		fmt.Println(c.Detail)
+	}
+
+	if r.failed() {
+		fmt.Printf("\nCreate a Cloud API token at %s\n", apiTokenURL)
+		fmt.Println("Reconfigure with: cfl init")
+	}
+}
@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+const cloudToken = "ATATT3xFfGF0abcdefghijklmnopqrstuvwxyz0123456789"
+
+func TestTokenType(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{"cloud", cloudToken, tokenCloud},
+		{"cloud legacy", "abcdefghijklmnopqrstuvwx", tokenCloudLegacy},
+		{"data center pat", "NjQ2MTY0NzQ2NTczNzQ6Ax6dKo3fFhQ4qa5KXy+Zn/Ab", tokenDCPAT},
+		{"unknown", "secret", tokenUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tokenType(tt.token))
+		})
+	}
+}
+
+func TestMaskToken(t *testing.T) {
+	assert.Equal(t, "ATAT********6789", maskToken(cloudToken))
+	assert.Equal(t, "******", maskToken("secret"))
+}
+
+func mockUserServer(t *testing.T, status int, response string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/user/current", r.URL.Path)
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+}
+
+func TestRunTokenCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		email      string
+		status     int
+		response   string
+		wantErr    bool
+		wantStatus map[string]string
+	}{
+		{
+			name:       "valid",
+			token:      cloudToken,
+			email:      "user@example.com",
+			status:     http.StatusOK,
+			response:   `{"type": "known", "email": "User@Example.com", "displayName": "User"}`,
+			wantStatus: map[string]string{"format": statusOK, "email": statusOK, "sign-in": statusOK, "owner": statusOK},
+		},
+		{
+			name:       "email mismatch",
+			token:      cloudToken,
+			email:      "other@example.com",
+			status:     http.StatusOK,
+			response:   `{"type": "known", "email": "user@example.com", "displayName": "User"}`,
+			wantErr:    true,
+			wantStatus: map[string]string{"owner": statusFail},
+		},
+		{
+			name:       "hidden email",
+			token:      cloudToken,
+			email:      "user@example.com",
+			status:     http.StatusOK,
+			response:   `{"type": "known", "displayName": "User"}`,
+			wantStatus: map[string]string{"owner": statusWarn},
+		},
+		{
+			name:       "rejected",
+			token:      cloudToken,
+			email:      "user@example.com",
+			status:     http.StatusUnauthorized,
+			response:   `{"message": "Unauthorized"}`,
+			wantErr:    true,
+			wantStatus: map[string]string{"sign-in": statusFail},
+		},
+		{
+			name:       "anonymous",
+			token:      cloudToken,
+			email:      "user@example.com",
+			status:     http.StatusOK,
+			response:   `{"type": "anonymous"}`,
+			wantErr:    true,
+			wantStatus: map[string]string{"sign-in": statusFail},
+		},
+		{
+			name:       "data center pat",
+			token:      "NjQ2MTY0NzQ2NTczNzQ6Ax6dKo3fFhQ4qa5KXy+Zn/Ab",
+			email:      "user@example.com",
+			status:     http.StatusUnauthorized,
+			response:   `{"message": "Unauthorized"}`,
+			wantErr:    true,
+			wantStatus: map[string]string{"format": statusFail},
+		},
+		{
+			name:       "trailing newline",
+			token:      cloudToken + "\n",
+			email:      "user@example.com",
+			status:     http.StatusOK,
+			response:   `{"type": "known", "email": "user@example.com"}`,
+			wantErr:    true,
+			wantStatus: map[string]string{"format": statusFail, "sign-in": statusOK},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := mockUserServer(t, tt.status, tt.response)
+			defer server.Close()
+
+			cfg := &config.Config{URL: server.URL, Email: tt.email, APIToken: tt.token}
+			report := &tokenReport{TokenType: tokenType(tt.token)}
+			checkFormat(report, tt.token)
+			checkEmail(report, tt.email)
+			checkSignIn(report, api.NewClient(server.URL, tt.email, tt.token), tt.email)
+
+			got := map[string]string{}
+			for _, c := range report.Checks {
+				got[c.Name] = c.Status
+			}
+			for name, status := range tt.wantStatus {
+				assert.Equal(t, status, got[name], name)
+			}
+
+			err := runTokenCheck(&tokenCheckOptions{noColor: true}, cfg, api.NewClient(server.URL, tt.email, tt.token))
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRunTokenCheck_JSON(t *testing.T) {
+	server := mockUserServer(t, http.StatusOK, `{"type": "known", "email": "user@example.com"}`)
+	defer server.Close()
+
+	cfg := &config.Config{URL: server.URL, Email: "user@example.com", APIToken: cloudToken}
+	err := runTokenCheck(&tokenCheckOptions{output: "json"}, cfg, api.NewClient(server.URL, cfg.Email, cfg.APIToken))
+	require.NoError(t, err)
+}
+
+func TestRunTokenCheck_NoToken(t *testing.T) {
+	err := runTokenCheck(&tokenCheckOptions{}, &config.Config{URL: "https://example.atlassian.net/wiki"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no API token configured")
+}
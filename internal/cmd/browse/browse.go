@@ -0,0 +1,96 @@
+// Package browse provides the interactive cfl browse command.
+package browse
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/browser"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+type browseOptions struct {
+	space string
+}
+
+// NewCmdBrowse creates the browse command.
+func NewCmdBrowse() *cobra.Command {
+	opts := &browseOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "browse",
+		Short: "Browse spaces and pages interactively",
+		Long: `Browse Confluence interactively in the terminal.
+
+Navigate from spaces to their page trees and preview pages rendered from
+markdown. Keys:
+
+  ↑/↓ j/k      Move
+  enter        Open a space, or preview a page
+  →/l          Show a page's children
+  ←/h esc      Go back
+  /            Fuzzy filter the current list
+  o            Open in the browser
+  e            Edit the page in your editor
+  y            Copy the ID to the clipboard
+  q            Quit`,
+		Example: `  # Start at the list of spaces
+  cfl browse
+
+  # Start in a space's page tree
+  cfl browse --space DOCS`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runBrowse(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key to start in")
+
+	return cmd
+}
+
+func runBrowse(opts *browseOptions) error {
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return fmt.Errorf("cfl browse needs an interactive terminal")
+	}
+
+	cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+	}
+
+	client := api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+
+	m := newModel(client, cfg.URL, opts.space)
+	m.copy = clipboard.WriteAll
+	m.open = browser.Open
+	m.editCmd = func(pageID string) *exec.Cmd {
+		exe, err := os.Executable()
+		if err != nil {
+			exe = os.Args[0]
+		}
+		return exec.Command(exe, "page", "edit", pageID)
+	}
+
+	_, err = tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
+
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return (stat.Mode() & os.ModeCharDevice) != 0
+}
@@ -0,0 +1,553 @@
+package browse
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// Item kinds.
+const (
+	itemSpace = iota
+	itemPage
+)
+
+// chromeHeight is the number of lines taken by the header and footer.
+const chromeHeight = 3
+
+// item is a space or page in a list.
+type item struct {
+	kind  int
+	id    string
+	title string
+	key   string // Space key, for spaces
+	webUI string
+}
+
+func (it item) label() string {
+	if it.kind == itemSpace {
+		return fmt.Sprintf("%-10s %s", it.key, it.title)
+	}
+	return it.title
+}
+
+// level is one list in the navigation stack: the spaces, the top-level
+// pages of a space, or the children of a page.
+type level struct {
+	name   string
+	items  []item
+	shown  []int // Indexes of the items matching filter
+	cursor int   // Index into shown
+	filter string
+}
+
+func newLevel(name string, items []item) *level {
+	l := &level{name: name, items: items}
+	l.applyFilter("")
+	return l
+}
+
+// applyFilter shows only the items fuzzily matching query.
+func (l *level) applyFilter(query string) {
+	l.filter = query
+	l.shown = l.shown[:0]
+	for i, it := range l.items {
+		if fuzzyMatch(query, it.label()) {
+			l.shown = append(l.shown, i)
+		}
+	}
+	l.cursor = max(0, min(l.cursor, len(l.shown)-1))
+}
+
+func (l *level) selected() (item, bool) {
+	if len(l.shown) == 0 {
+		return item{}, false
+	}
+	return l.items[l.shown[l.cursor]], true
+}
+
+// Messages sent when data loads or an edit finishes.
+type (
+	levelMsg struct {
+		name  string
+		items []item
+		err   error
+	}
+	previewMsg struct {
+		page     item
+		markdown string
+		err      error
+	}
+	editedMsg struct{ err error }
+)
+
+// model is the Bubble Tea model of the browser.
+type model struct {
+	client     *api.Client
+	baseURL    string
+	startSpace string
+
+	stack     []*level
+	preview   *item // Page being previewed; nil when showing a list
+	markdown  string
+	viewport  viewport.Model
+	search    textinput.Model
+	searching bool
+	loading   bool
+	status    string
+	width     int
+	height    int
+
+	copy    func(string) error
+	open    func(string) error
+	editCmd func(pageID string) *exec.Cmd
+}
+
+func newModel(client *api.Client, baseURL, startSpace string) *model {
+	search := textinput.New()
+	search.Prompt = "/"
+	_ = search.Cursor.SetMode(cursor.CursorStatic)
+
+	return &model{
+		client:     client,
+		baseURL:    baseURL,
+		startSpace: startSpace,
+		viewport:   viewport.New(80, 24-chromeHeight),
+		search:     search,
+		loading:    true,
+		width:      80,
+		height:     24,
+	}
+}
+
+func (m *model) Init() tea.Cmd {
+	if m.startSpace != "" {
+		return m.loadSpaceByKey(m.startSpace)
+	}
+	return m.loadSpaces()
+}
+
+func (m *model) top() *level {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1]
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.viewport.Width = msg.Width
+		m.viewport.Height = max(1, msg.Height-chromeHeight)
+		if m.preview != nil {
+			m.viewport.SetContent(renderMarkdown(m.markdown, m.width))
+		}
+		return m, nil
+
+	case levelMsg:
+		m.loading = false
+		switch {
+		case msg.err != nil:
+			m.status = msg.err.Error()
+		case len(msg.items) == 0 && len(m.stack) > 0:
+			m.status = "No pages found."
+		default:
+			m.stack = append(m.stack, newLevel(msg.name, msg.items))
+		}
+		return m, nil
+
+	case previewMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		page := msg.page
+		m.preview = &page
+		m.markdown = msg.markdown
+		m.viewport.SetContent(renderMarkdown(msg.markdown, m.width))
+		m.viewport.GotoTop()
+		return m, nil
+
+	case editedMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Edit failed: %v", msg.err)
+			return m, nil
+		}
+		if m.preview != nil {
+			m.loading = true
+			return m, m.loadPreview(*m.preview)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	if m.searching {
+		switch key {
+		case "esc":
+			m.searching = false
+			m.search.Blur()
+			m.top().applyFilter("")
+		case "enter":
+			m.searching = false
+			m.search.Blur()
+		default:
+			var cmd tea.Cmd
+			m.search, cmd = m.search.Update(msg)
+			m.top().applyFilter(m.search.Value())
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	m.status = ""
+	if key == "q" {
+		return m, tea.Quit
+	}
+	if m.loading {
+		return m, nil
+	}
+
+	if m.preview != nil {
+		switch key {
+		case "esc", "left", "h", "backspace":
+			m.preview = nil
+		case "o":
+			m.openItem(*m.preview)
+		case "y":
+			m.copyID(*m.preview)
+		case "e":
+			return m, m.edit(*m.preview)
+		default:
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	l := m.top()
+	if l == nil {
+		return m, nil
+	}
+	selected, ok := l.selected()
+
+	switch key {
+	case "up", "k":
+		l.cursor = max(0, l.cursor-1)
+	case "down", "j":
+		l.cursor = max(0, min(l.cursor+1, len(l.shown)-1))
+	case "home", "g":
+		l.cursor = 0
+	case "end", "G":
+		l.cursor = max(0, len(l.shown)-1)
+	case "/":
+		m.searching = true
+		m.search.SetValue(l.filter)
+		m.search.CursorEnd()
+		_ = m.search.Focus()
+	case "esc", "left", "h", "backspace":
+		switch {
+		case l.filter != "":
+			l.applyFilter("")
+		case len(m.stack) > 1:
+			m.stack = m.stack[:len(m.stack)-1]
+		}
+	case "enter":
+		if !ok {
+			break
+		}
+		m.loading = true
+		if selected.kind == itemSpace {
+			return m, m.loadRootPages(selected)
+		}
+		return m, m.loadPreview(selected)
+	case "right", "l":
+		if !ok {
+			break
+		}
+		m.loading = true
+		if selected.kind == itemSpace {
+			return m, m.loadRootPages(selected)
+		}
+		return m, m.loadChildren(selected)
+	case "o":
+		if ok {
+			m.openItem(selected)
+		}
+	case "y":
+		if ok {
+			m.copyID(selected)
+		}
+	case "e":
+		if ok {
+			return m, m.edit(selected)
+		}
+	}
+	return m, nil
+}
+
+func (m *model) openItem(it item) {
+	if it.webUI == "" {
+		m.status = "No web link for " + it.title
+		return
+	}
+	if err := m.open(m.baseURL + it.webUI); err != nil {
+		m.status = fmt.Sprintf("Failed to open browser: %v", err)
+		return
+	}
+	m.status = "Opened " + it.title + " in the browser"
+}
+
+func (m *model) copyID(it item) {
+	if err := m.copy(it.id); err != nil {
+		m.status = fmt.Sprintf("Failed to copy: %v", err)
+		return
+	}
+	m.status = "Copied ID " + it.id
+}
+
+func (m *model) edit(it item) tea.Cmd {
+	if it.kind != itemPage {
+		m.status = "Only pages can be edited"
+		return nil
+	}
+	return tea.ExecProcess(m.editCmd(it.id), func(err error) tea.Msg {
+		return editedMsg{err: err}
+	})
+}
+
+func (m *model) loadSpaces() tea.Cmd {
+	return func() tea.Msg {
+		var items []item
+		opts := &api.ListSpacesOptions{Limit: 250, Status: "current"}
+		for {
+			result, err := m.client.ListSpaces(context.Background(), opts)
+			if err != nil {
+				return levelMsg{err: fmt.Errorf("failed to list spaces: %w", err)}
+			}
+			for _, s := range result.Results {
+				items = append(items, spaceItem(s))
+			}
+			if opts.Cursor = result.NextCursor(); opts.Cursor == "" {
+				return levelMsg{name: "Spaces", items: items}
+			}
+		}
+	}
+}
+
+func (m *model) loadSpaceByKey(key string) tea.Cmd {
+	return func() tea.Msg {
+		space, err := m.client.GetSpaceByKey(context.Background(), key)
+		if err != nil {
+			return levelMsg{err: fmt.Errorf("failed to find space '%s': %w", key, err)}
+		}
+		return m.loadRootPages(spaceItem(*space))()
+	}
+}
+
+func (m *model) loadRootPages(space item) tea.Cmd {
+	return func() tea.Msg {
+		var items []item
+		opts := &api.ListPagesOptions{Limit: 250, Status: "current", Depth: "root"}
+		for {
+			result, err := m.client.ListPages(context.Background(), space.id, opts)
+			if err != nil {
+				return levelMsg{err: fmt.Errorf("failed to list pages: %w", err)}
+			}
+			for _, p := range result.Results {
+				items = append(items, pageItem(p))
+			}
+			if opts.Cursor = result.NextCursor(); opts.Cursor == "" {
+				return levelMsg{name: space.key, items: items}
+			}
+		}
+	}
+}
+
+func (m *model) loadChildren(page item) tea.Cmd {
+	return func() tea.Msg {
+		var items []item
+		opts := &api.ListChildPagesOptions{Limit: 250, Sort: "child-position"}
+		for {
+			result, err := m.client.ListChildPages(context.Background(), page.id, opts)
+			if err != nil {
+				return levelMsg{err: fmt.Errorf("failed to list child pages: %w", err)}
+			}
+			for _, p := range result.Results {
+				items = append(items, pageItem(p))
+			}
+			if opts.Cursor = result.NextCursor(); opts.Cursor == "" {
+				return levelMsg{name: page.title, items: items}
+			}
+		}
+	}
+}
+
+func (m *model) loadPreview(page item) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		p, err := m.client.GetPage(ctx, page.id, &api.GetPageOptions{BodyFormat: "storage"})
+		if err != nil {
+			return previewMsg{err: fmt.Errorf("failed to get page: %w", err)}
+		}
+		page.title, page.webUI = p.Title, p.Links.WebUI
+
+		var markdown string
+		switch {
+		case p.Body != nil && p.Body.Storage != nil && p.Body.Storage.Value != "":
+			markdown, err = md.FromConfluenceStorage(p.Body.Storage.Value)
+		default:
+			// Pages authored in the cloud editor may only have an ADF body
+			adf, adfErr := m.client.GetPage(ctx, page.id, &api.GetPageOptions{BodyFormat: "atlas_doc_format"})
+			if adfErr == nil && adf.Body != nil && adf.Body.AtlasDocFormat != nil {
+				markdown, err = md.FromADF(adf.Body.AtlasDocFormat.Value)
+			}
+		}
+		if err != nil {
+			return previewMsg{err: fmt.Errorf("failed to convert page to markdown: %w", err)}
+		}
+		return previewMsg{page: page, markdown: markdown}
+	}
+}
+
+func spaceItem(s api.Space) item {
+	return item{kind: itemSpace, id: s.ID, title: s.Name, key: s.Key, webUI: s.Links.WebUI}
+}
+
+func pageItem(p api.Page) item {
+	return item{kind: itemPage, id: p.ID, title: p.Title, webUI: p.Links.WebUI}
+}
+
+var (
+	headerStyle   = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	footerStyle   = lipgloss.NewStyle().Faint(true)
+	headingStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	codeStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+)
+
+func (m *model) View() string {
+	var crumbs []string
+	for _, l := range m.stack {
+		crumbs = append(crumbs, l.name)
+	}
+	if m.preview != nil {
+		crumbs = append(crumbs, m.preview.title)
+	}
+	if len(crumbs) == 0 {
+		crumbs = []string{"cfl browse"}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(headerStyle.Render(strings.Join(crumbs, " › ")))
+	sb.WriteString("\n\n")
+
+	rows := max(1, m.height-chromeHeight)
+	switch l := m.top(); {
+	case m.preview != nil:
+		sb.WriteString(m.viewport.View())
+	case l == nil:
+	case len(l.shown) == 0:
+		sb.WriteString("No matches.")
+	default:
+		start := max(0, l.cursor-rows+1)
+		end := min(len(l.shown), start+rows)
+		for i := start; i < end; i++ {
+			line := l.items[l.shown[i]].label()
+			if i == l.cursor {
+				line = selectedStyle.Render("> " + line)
+			} else {
+				line = "  " + line
+			}
+			sb.WriteString(line)
+			if i < end-1 {
+				sb.WriteString("\n")
+			}
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(m.footer())
+	return sb.String()
+}
+
+func (m *model) footer() string {
+	switch {
+	case m.searching:
+		return m.search.View()
+	case m.loading:
+		return footerStyle.Render("Loading…")
+	case m.status != "":
+		return m.status
+	case m.preview != nil:
+		return footerStyle.Render("↑/↓ scroll · ← back · o browser · e edit · y copy id · q quit")
+	default:
+		return footerStyle.Render("enter open · → children · ← back · / filter · o browser · e edit · y copy id · q quit")
+	}
+}
+
+// renderMarkdown styles markdown for the terminal: headings are
+// highlighted, code blocks coloured and long lines wrapped to width.
+func renderMarkdown(markdown string, width int) string {
+	wrap := lipgloss.NewStyle().Width(max(20, width))
+	var lines []string
+	inCode := false
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			inCode = !inCode
+			lines = append(lines, codeStyle.Render(line))
+		case inCode:
+			lines = append(lines, codeStyle.Render(line))
+		case strings.HasPrefix(trimmed, "#"):
+			lines = append(lines, headingStyle.Render(strings.TrimSpace(strings.TrimLeft(trimmed, "#"))))
+		default:
+			lines = append(lines, wrap.Render(line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fuzzyMatch reports whether the letters of query appear in text in order,
+// ignoring case and spaces in the query.
+func fuzzyMatch(query, text string) bool {
+	remaining := []rune(strings.ToLower(text))
+	for _, q := range strings.ToLower(query) {
+		if unicode.IsSpace(q) {
+			continue
+		}
+		i := 0
+		for i < len(remaining) && remaining[i] != q {
+			i++
+		}
+		if i == len(remaining) {
+			return false
+		}
+		remaining = remaining[i+1:]
+	}
+	return true
+}
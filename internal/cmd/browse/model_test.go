@@ -0,0 +1,213 @@
+package browse
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// newBrowseServer serves space DOCS with top-level pages 1 "Handbook" and
+// 2 "Runbooks", where 1 has child 3 "Onboarding".
+func newBrowseServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/spaces":
+			if r.URL.Query().Get("keys") == "DOCS" {
+				w.Write([]byte(`{"results": [{"id": "100", "key": "DOCS", "name": "Documentation"}]}`))
+				return
+			}
+			w.Write([]byte(`{"results": [
+				{"id": "100", "key": "DOCS", "name": "Documentation", "_links": {"webui": "/spaces/DOCS"}},
+				{"id": "200", "key": "ENG", "name": "Engineering"}
+			]}`))
+		case "/api/v2/spaces/100/pages":
+			assert.Equal(t, "root", r.URL.Query().Get("depth"))
+			w.Write([]byte(`{"results": [{"id": "1", "title": "Handbook"}, {"id": "2", "title": "Runbooks"}]}`))
+		case "/api/v2/pages/1/children":
+			w.Write([]byte(`{"results": [{"id": "3", "title": "Onboarding"}]}`))
+		case "/api/v2/pages/2/children":
+			w.Write([]byte(`{"results": []}`))
+		case "/api/v2/pages/1":
+			w.Write([]byte(`{"id": "1", "title": "Handbook", "body": {"storage": {"value": "<h1>Welcome</h1><p>Read this first.</p>"}}, "_links": {"webui": "/pages/1"}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestModel(t *testing.T, startSpace string) (*model, *[]string) {
+	server := newBrowseServer(t)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	var actions []string
+	m := newModel(client, "https://example.atlassian.net/wiki", startSpace)
+	m.copy = func(s string) error {
+		actions = append(actions, "copy "+s)
+		return nil
+	}
+	m.open = func(url string) error {
+		actions = append(actions, "open "+url)
+		return nil
+	}
+	run(m, m.Init())
+	return m, &actions
+}
+
+// run feeds the message produced by cmd back into the model, as the Bubble
+// Tea runtime would.
+func run(m *model, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	_, next := m.Update(cmd())
+	run(m, next)
+}
+
+// press sends a key to the model and runs the resulting command.
+func press(m *model, keys ...string) {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "right":
+			msg = tea.KeyMsg{Type: tea.KeyRight}
+		case "left":
+			msg = tea.KeyMsg{Type: tea.KeyLeft}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		_, cmd := m.Update(msg)
+		if k != "q" {
+			run(m, cmd)
+		}
+	}
+}
+
+func selectedTitle(m *model) string {
+	it, _ := m.top().selected()
+	return it.title
+}
+
+func TestBrowse_Navigate(t *testing.T) {
+	m, _ := newTestModel(t, "")
+	require.Len(t, m.stack, 1)
+	assert.Equal(t, "Documentation", selectedTitle(m))
+
+	// Into the space, then into Handbook's children
+	press(m, "enter")
+	require.Len(t, m.stack, 2)
+	assert.Equal(t, "DOCS", m.top().name)
+	assert.Equal(t, "Handbook", selectedTitle(m))
+
+	press(m, "right")
+	require.Len(t, m.stack, 3)
+	assert.Equal(t, "Onboarding", selectedTitle(m))
+	assert.Contains(t, m.View(), "Spaces › DOCS › Handbook")
+
+	// Back out
+	press(m, "left")
+	require.Len(t, m.stack, 2)
+
+	// A page without children stays put
+	press(m, "down", "right")
+	require.Len(t, m.stack, 2)
+	assert.Equal(t, "No pages found.", m.status)
+}
+
+func TestBrowse_StartSpace(t *testing.T) {
+	m, _ := newTestModel(t, "DOCS")
+	require.Len(t, m.stack, 1)
+	assert.Equal(t, "DOCS", m.top().name)
+	assert.Equal(t, "Handbook", selectedTitle(m))
+}
+
+func TestBrowse_Preview(t *testing.T) {
+	m, actions := newTestModel(t, "DOCS")
+
+	press(m, "enter")
+	require.NotNil(t, m.preview)
+	assert.Contains(t, m.View(), "Welcome")
+	assert.Contains(t, m.View(), "Read this first.")
+
+	press(m, "o", "y")
+	assert.Equal(t, []string{"open https://example.atlassian.net/wiki/pages/1", "copy 1"}, *actions)
+
+	press(m, "esc")
+	assert.Nil(t, m.preview)
+}
+
+func TestBrowse_Filter(t *testing.T) {
+	m, _ := newTestModel(t, "")
+
+	press(m, "/", "e", "n", "g", "enter")
+	assert.False(t, m.searching)
+	assert.Equal(t, "Engineering", selectedTitle(m))
+	assert.Len(t, m.top().shown, 1)
+
+	press(m, "/", "x", "y", "z", "enter")
+	assert.Empty(t, m.top().shown)
+	assert.Contains(t, m.View(), "No matches.")
+
+	// Esc clears the filter
+	press(m, "esc")
+	assert.Len(t, m.top().shown, 2)
+}
+
+func TestBrowse_EditSpace(t *testing.T) {
+	m, _ := newTestModel(t, "")
+
+	press(m, "e")
+	assert.Equal(t, "Only pages can be edited", m.status)
+}
+
+func TestBrowse_Quit(t *testing.T) {
+	m, _ := newTestModel(t, "")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	require.NotNil(t, cmd)
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		text  string
+		want  bool
+	}{
+		{"", "anything", true},
+		{"hbk", "Handbook", true},
+		{"HAND", "handbook", true},
+		{"run book", "Runbooks", true},
+		{"kh", "Handbook", false},
+		{"handbooks", "Handbook", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query+"/"+tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.want, fuzzyMatch(tt.query, tt.text))
+		})
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	out := renderMarkdown("# Title\n\ntext\n\n```\ncode\n```", 80)
+	assert.Contains(t, out, "Title")
+	assert.NotContains(t, out, "# Title")
+	assert.Contains(t, out, "code")
+	assert.Equal(t, 7, len(strings.Split(out, "\n")))
+}
@@ -0,0 +1,43 @@
+// Package cachecmd provides commands for managing the local page cache.
+package cachecmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/pagecache"
+)
+
+// NewCmdCache creates the cache command.
+func NewCmdCache() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local page cache",
+		Long: `Commands for filling, inspecting and clearing the local page cache.
+
+The cache keeps pages by ID and version, per site, under the cfl cache
+directory. 'cfl page view --offline' reads pages from it without connecting.
+Pages are cached as they're viewed when page_cache: true is set in the
+config (or CFL_PAGE_CACHE=1), and a whole space with 'cfl cache sync'.`,
+	}
+
+	cmd.AddCommand(NewCmdSync())
+	cmd.AddCommand(NewCmdList())
+	cmd.AddCommand(NewCmdClear())
+
+	return cmd
+}
+
+// siteCache returns the page cache of the configured site.
+func siteCache() (*pagecache.Cache, error) {
+	cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+	}
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("invalid config: url is required (run 'cfl init' to configure)")
+	}
+	return pagecache.New(pagecache.DefaultDir(), cfg.URL), nil
+}
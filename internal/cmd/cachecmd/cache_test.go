@@ -0,0 +1,97 @@
+package cachecmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/pagecache"
+)
+
+func TestRunSync(t *testing.T) {
+	version := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "100", "key": "DEV"}]}`))
+		case "/api/v2/spaces/100/pages":
+			assert.Equal(t, "storage", r.URL.Query().Get("body-format"))
+			w.Write([]byte(`{"results": [
+				{"id": "1", "title": "Home", "version": {"number": 4}, "body": {"storage": {"value": "<p>Home</p>"}}},
+				{"id": "2", "title": "Guide", "version": {"number": ` + strconv.Itoa(version) + `}, "body": {"storage": {"value": "<p>Guide</p>"}}}
+			]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	cache := pagecache.New(t.TempDir(), server.URL)
+
+	var out bytes.Buffer
+	require.NoError(t, runSync(&syncOptions{space: "DEV", output: "json", stdout: &out, cache: cache}, client))
+	var result syncResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, syncResult{Space: "DEV", Pages: 2, Cached: 2, Dir: cache.Dir()}, result)
+
+	// Only pages changed since are cached again
+	version = 2
+	out.Reset()
+	require.NoError(t, runSync(&syncOptions{space: "DEV", output: "json", stdout: &out, cache: cache}, client))
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, 1, result.Cached)
+
+	infos, err := cache.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 3)
+	assert.Equal(t, "DEV", infos[0].SpaceKey)
+}
+
+func TestRunSync_RequiresSpace(t *testing.T) {
+	client := api.NewClient("https://example.atlassian.net/wiki", "test@example.com", "token")
+	err := runSync(&syncOptions{}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "space is required")
+}
+
+func TestRunListAndClear(t *testing.T) {
+	cache := pagecache.New(t.TempDir(), "https://example.atlassian.net/wiki")
+	for _, p := range []api.Page{
+		{ID: "1", Title: "Home", Version: &api.Version{Number: 1}, Body: &api.Body{}},
+		{ID: "1", Title: "Home", Version: &api.Version{Number: 2}, Body: &api.Body{}},
+		{ID: "2", Title: "Guide", Version: &api.Version{Number: 1}, Body: &api.Body{}},
+	} {
+		require.NoError(t, cache.Put(&p, "DEV"))
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, runList(&listOptions{noColor: true, stdout: &out, cache: cache}))
+	assert.Contains(t, out.String(), "Guide")
+	assert.Contains(t, out.String(), "3 page versions")
+
+	out.Reset()
+	require.NoError(t, runClear("1", &clearOptions{noColor: true, stdout: &out, cache: cache}))
+	assert.Contains(t, out.String(), "Removed 2 cached page versions")
+
+	out.Reset()
+	require.NoError(t, runList(&listOptions{output: "json", stdout: &out, cache: cache}))
+	var infos []pagecache.Info
+	require.NoError(t, json.Unmarshal(out.Bytes(), &infos))
+	require.Len(t, infos, 1)
+	assert.Equal(t, "2", infos[0].ID)
+
+	out.Reset()
+	require.NoError(t, runClear("", &clearOptions{noColor: true, stdout: &out, cache: cache}))
+	out.Reset()
+	require.NoError(t, runList(&listOptions{noColor: true, stdout: &out, cache: cache}))
+	assert.Equal(t, "No cached pages.\n", out.String())
+}
@@ -0,0 +1,82 @@
+package cachecmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/internal/pagecache"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type clearOptions struct {
+	output  string
+	noColor bool
+	stdout  io.Writer        // For testing; defaults to os.Stdout
+	cache   *pagecache.Cache // For testing; defaults to the configured site's cache
+}
+
+// NewCmdClear creates the cache clear command.
+func NewCmdClear() *cobra.Command {
+	opts := &clearOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "clear [page-id]",
+		Short: "Remove cached pages",
+		Long: `Remove every cached version of a page from the local page cache, or
+every cached page of the configured site when no page is given.`,
+		Example: `  # Remove one page
+  cfl cache clear 12345
+
+  # Remove everything
+  cfl cache clear`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			var pageID string
+			if len(args) == 1 {
+				pageID = args[0]
+			}
+			return runClear(pageID, opts)
+		},
+	}
+
+	return cmd
+}
+
+func runClear(pageID string, opts *clearOptions) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	cache := opts.cache
+	if cache == nil {
+		var err error
+		if cache, err = siteCache(); err != nil {
+			return err
+		}
+	}
+
+	removed, err := cache.Clear(pageID)
+	if err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]interface{}{
+			"status":  "cleared",
+			"removed": removed,
+		})
+	}
+
+	renderer.Success(fmt.Sprintf("Removed %d cached page versions", removed))
+	return nil
+}
@@ -0,0 +1,99 @@
+package cachecmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/internal/pagecache"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type listOptions struct {
+	output  string
+	noColor bool
+	stdout  io.Writer        // For testing; defaults to os.Stdout
+	cache   *pagecache.Cache // For testing; defaults to the configured site's cache
+}
+
+// NewCmdList creates the cache list command.
+func NewCmdList() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List cached pages",
+		Long:    `List the page versions in the local page cache of the configured site.`,
+		Example: `  # List cached pages
+  cfl cache list
+
+  # Output as JSON
+  cfl cache list -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runList(opts)
+		},
+	}
+
+	return cmd
+}
+
+func runList(opts *listOptions) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	cache := opts.cache
+	if cache == nil {
+		var err error
+		if cache, err = siteCache(); err != nil {
+			return err
+		}
+	}
+
+	infos, err := cache.List()
+	if err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	if view.IsStructured(opts.output) {
+		if infos == nil {
+			infos = []pagecache.Info{}
+		}
+		return renderer.RenderJSON(infos)
+	}
+
+	if len(infos) == 0 {
+		renderer.RenderText("No cached pages.")
+		return nil
+	}
+
+	var total int64
+	headers := []string{"ID", "TITLE", "SPACE", "VERSION", "SIZE", "CACHED"}
+	var rows [][]string
+	for _, info := range infos {
+		total += info.Size
+		rows = append(rows, []string{
+			info.ID,
+			view.Truncate(info.Title, 50),
+			info.SpaceKey,
+			strconv.Itoa(info.Version),
+			view.FormatFileSize(info.Size),
+			info.CachedAt.Local().Format("2006-01-02 15:04"),
+		})
+	}
+	renderer.RenderTable(headers, rows)
+	renderer.RenderText(fmt.Sprintf("\n%d page versions, %s, in %s", len(infos), view.FormatFileSize(total), cache.Dir()))
+	return nil
+}
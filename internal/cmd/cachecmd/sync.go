@@ -0,0 +1,140 @@
+package cachecmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/pagecache"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type syncOptions struct {
+	space   string
+	output  string
+	noColor bool
+	stdout  io.Writer        // For testing; defaults to os.Stdout
+	cache   *pagecache.Cache // For testing; defaults to the configured site's cache
+}
+
+// syncResult summarizes a cache sync.
+type syncResult struct {
+	Space  string `json:"space"`
+	Pages  int    `json:"pages"`
+	Cached int    `json:"cached"` // Pages whose current version wasn't cached yet
+	Failed int    `json:"failed"`
+	Dir    string `json:"dir"`
+}
+
+// NewCmdSync creates the cache sync command.
+func NewCmdSync() *cobra.Command {
+	opts := &syncOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Cache every page in a space",
+		Long: `Fetch the current version of every page in a space into the local page
+cache, for reading with 'cfl page view --offline'. Versions already cached
+are kept, so syncing again only adds pages changed since.`,
+		Example: `  # Cache a space before a flight
+  cfl cache sync --space DEV
+
+  # Then read its pages offline
+  cfl page view "DEV/Release Runbook" --offline`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runSync(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key (default: default_space in config)")
+
+	return cmd
+}
+
+func runSync(opts *syncOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	spaceKey := opts.space
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		// Use default space from config if not specified
+		if spaceKey == "" {
+			spaceKey = cfg.DefaultSpace
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	if spaceKey == "" {
+		return fmt.Errorf("space is required: use --space flag or set default_space in config")
+	}
+
+	cache := opts.cache
+	if cache == nil {
+		cache = pagecache.New(pagecache.DefaultDir(), client.BaseURL())
+	}
+
+	ctx := context.Background()
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+	pages, err := api.ListAll(client.PagesIter(ctx, space.ID, &api.ListPagesOptions{BodyFormat: "storage"}, api.WithPrefetch()))
+	if err != nil {
+		return fmt.Errorf("failed to list pages: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	result := syncResult{Space: space.Key, Pages: len(pages), Dir: cache.Dir()}
+	progress := view.NewProgress(len(pages))
+	for i := range pages {
+		page := &pages[i]
+		progress.Increment(page.Title)
+		if page.Version != nil {
+			if _, err := cache.Get(page.ID, page.Version.Number); err == nil {
+				continue
+			}
+		}
+		if err := cache.Put(page, space.Key); err != nil {
+			renderer.Warning(fmt.Sprintf("Skipped %s: %v", page.Title, err))
+			result.Failed++
+			continue
+		}
+		result.Cached++
+	}
+	progress.Done()
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(result)
+	}
+
+	renderer.Success(fmt.Sprintf("Cached %d pages from %s (%d new or changed)", result.Pages-result.Failed, space.Key, result.Cached))
+	if result.Failed > 0 {
+		return fmt.Errorf("%d of %d pages could not be cached", result.Failed, result.Pages)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+type addOptions struct {
+	file       string
+	noMarkdown bool
+	selection  string
+	match      int
+	output     string
+	noColor    bool
+	stdin      io.Reader // For testing; defaults to piped os.Stdin
+}
+
+// NewCmdAdd creates the comment add command.
+func NewCmdAdd() *cobra.Command {
+	opts := &addOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "add <page-id> [message]",
+		Short: "Add a comment to a page",
+		Long: `Add a footer comment to a page, or an inline comment with --selection.
+
+The comment is read from the message argument, --file, or stdin, and is
+converted from markdown unless --no-markdown is given.
+
+An inline comment is attached to text on the page given by --selection.
+If the text occurs more than once, --match picks the occurrence (1-based).`,
+		Example: `  # Add a footer comment
+  cfl comment add 12345 "Looks good to me"
+
+  # Add an inline comment on a phrase
+  cfl comment add 12345 "Is this still true?" --selection "supports Windows"
+
+  # Comment from a markdown file
+  cfl comment add 12345 --file review.md`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			if opts.stdin == nil {
+				opts.stdin = pipedStdin()
+			}
+			var message string
+			if len(args) > 1 {
+				message = args[1]
+			}
+			return runAdd(args[0], message, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Read the comment from a file")
+	cmd.Flags().BoolVar(&opts.noMarkdown, "no-markdown", false, "Disable markdown conversion (use raw XHTML)")
+	cmd.Flags().StringVar(&opts.selection, "selection", "", "Page text to attach an inline comment to")
+	cmd.Flags().IntVar(&opts.match, "match", 1, "Occurrence of --selection to comment on (1-based)")
+
+	return cmd
+}
+
+func runAdd(pageID, message string, opts *addOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	body, err := commentBody(message, opts.file, opts.stdin, opts.noMarkdown)
+	if err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err = pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	kind := api.CommentFooter
+	req := &api.CreateCommentRequest{PageID: pageID, Body: body}
+
+	if opts.selection != "" {
+		kind = api.CommentInline
+		req.InlineCommentProperties, err = inlineSelection(ctx, client, pageID, opts.selection, opts.match)
+		if err != nil {
+			return err
+		}
+	}
+
+	comment, err := client.CreateComment(ctx, kind, req)
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(comment)
+	}
+
+	renderer.Success(fmt.Sprintf("Added %s comment (ID: %s)", kind, comment.ID))
+	return nil
+}
+
+// inlineSelection locates the match'th occurrence of text in a page's body
+// for an inline comment.
+func inlineSelection(ctx context.Context, client *api.Client, pageID, text string, match int) (*api.NewInlineCommentSelection, error) {
+	page, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: "storage"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+
+	var count int
+	if page.Body != nil && page.Body.Storage != nil {
+		count = md.CountStorageText(page.Body.Storage.Value, text)
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("selection %q not found on page %s", text, pageID)
+	}
+	if match < 1 || match > count {
+		return nil, fmt.Errorf("--match %d out of range: selection occurs %d times", match, count)
+	}
+
+	return &api.NewInlineCommentSelection{
+		TextSelection:           text,
+		TextSelectionMatchCount: count,
+		TextSelectionMatchIndex: match - 1,
+	}, nil
+}
@@ -0,0 +1,115 @@
+package comment
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunAdd_Footer(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v2/footer-comments", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "999"}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runAdd("12345", "Looks **good**", &addOptions{noColor: true}, client)
+	require.NoError(t, err)
+
+	assert.Equal(t, "12345", received["pageId"])
+	assert.NotContains(t, received, "inlineCommentProperties")
+	bodyRep := received["body"].(map[string]interface{})
+	assert.Contains(t, bodyRep["value"], "<strong>good</strong>")
+}
+
+func TestRunAdd_Inline(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "12345", "body": {"storage": {"value": "<p>one fish, two <em>fish</em></p>"}}}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v2/inline-comments":
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &received))
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "999"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &addOptions{selection: "fish", match: 2, noColor: true}
+	err := runAdd("12345", "Which fish?", opts, client)
+	require.NoError(t, err)
+
+	props := received["inlineCommentProperties"].(map[string]interface{})
+	assert.Equal(t, "fish", props["textSelection"])
+	assert.Equal(t, float64(2), props["textSelectionMatchCount"])
+	assert.Equal(t, float64(1), props["textSelectionMatchIndex"])
+}
+
+func TestRunAdd_SelectionErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "12345", "body": {"storage": {"value": "<p>one fish</p>"}}}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		opts    *addOptions
+		wantErr string
+	}{
+		{"not found", &addOptions{selection: "cat", match: 1}, "not found on page"},
+		{"match out of range", &addOptions{selection: "fish", match: 2}, "out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			err := runAdd("12345", "comment", tt.opts, client)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunAdd_Stdin(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "999"}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &addOptions{noMarkdown: true, stdin: strings.NewReader("<p>raw</p>"), noColor: true}
+	err := runAdd("12345", "", opts, client)
+	require.NoError(t, err)
+
+	bodyRep := received["body"].(map[string]interface{})
+	assert.Equal(t, "<p>raw</p>", bodyRep["value"])
+}
@@ -0,0 +1,116 @@
+// Package comment provides page comment commands.
+package comment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// NewCmdComment creates the comment command.
+func NewCmdComment() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "comment",
+		Aliases: []string{"comments"},
+		Short:   "Manage page comments",
+		Long: `Commands for listing, adding, replying to, resolving and deleting the
+footer and inline comments on Confluence pages.`,
+	}
+
+	cmd.AddCommand(NewCmdList())
+	cmd.AddCommand(NewCmdAdd())
+	cmd.AddCommand(NewCmdReply())
+	cmd.AddCommand(NewCmdResolve())
+	cmd.AddCommand(NewCmdDelete())
+
+	return cmd
+}
+
+// findComment looks a comment up by ID, returning it and its type. Comment
+// IDs don't say whether a comment is a footer or inline comment, so both
+// are tried.
+func findComment(ctx context.Context, client *api.Client, commentID string) (*api.Comment, string, error) {
+	comment, err := client.GetComment(ctx, api.CommentFooter, commentID)
+	if err == nil {
+		return comment, api.CommentFooter, nil
+	}
+	if !isNotFound(err) {
+		return nil, "", fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	comment, err = client.GetComment(ctx, api.CommentInline, commentID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get comment: %w", err)
+	}
+	return comment, api.CommentInline, nil
+}
+
+// isNotFound reports whether err is a 404 API error.
+func isNotFound(err error) bool {
+	var apiErr *api.ErrorResponse
+	return errors.As(err, &apiErr) && apiErr.StatusCode == 404
+}
+
+// commentBody reads a comment body from the message argument, a file or
+// stdin, converting markdown to storage format unless noMarkdown is set.
+func commentBody(message, file string, stdin io.Reader, noMarkdown bool) (*api.BodyRepresentation, error) {
+	content := message
+	switch {
+	case message != "" && file != "":
+		return nil, fmt.Errorf("give the comment as an argument or with --file, not both")
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		content = string(data)
+	case message == "" && stdin != nil:
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdin: %w", err)
+		}
+		content = string(data)
+	}
+
+	if strings.TrimSpace(content) == "" {
+		return nil, fmt.Errorf("comment is empty: give it as an argument, with --file, or on stdin")
+	}
+
+	if !noMarkdown {
+		storage, err := md.ToConfluenceStorage([]byte(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert markdown: %w", err)
+		}
+		content = storage
+	}
+	return &api.BodyRepresentation{Representation: "storage", Value: content}, nil
+}
+
+// pipedStdin returns os.Stdin if input is piped to it, or nil.
+func pipedStdin() io.Reader {
+	stat, err := os.Stdin.Stat()
+	if err != nil || stat.Mode()&os.ModeCharDevice != 0 {
+		return nil
+	}
+	return os.Stdin
+}
+
+// commentText returns a comment's body as markdown on a single line.
+func commentText(c *api.Comment) string {
+	if c.Body == nil || c.Body.Storage == nil {
+		return ""
+	}
+	text, err := md.FromConfluenceStorage(c.Body.Storage.Value)
+	if err != nil {
+		text = c.Body.Storage.Value
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,132 @@
+package comment
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// mockCommentServer serves a footer or inline comment with the given ID and
+// records every other request it receives in calls.
+func mockCommentServer(t *testing.T, kind, commentID string, calls *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if r.Method == "GET" && path == "/api/v2/"+kind+"-comments/"+commentID {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"id": "` + commentID + `",
+				"status": "current",
+				"pageId": "12345",
+				"resolutionStatus": "open",
+				"version": {"number": 1},
+				"body": {"storage": {"representation": "storage", "value": "<p>Please fix</p>"}}
+			}`))
+			return
+		}
+		if r.Method == "GET" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found"}`))
+			return
+		}
+		*calls = append(*calls, r.Method+" "+path)
+		switch r.Method {
+		case "POST":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "999", "status": "current"}`))
+		case "PUT":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "` + commentID + `", "resolutionStatus": "resolved"}`))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestFindComment(t *testing.T) {
+	tests := []struct {
+		name string
+		kind string
+	}{
+		{"footer", api.CommentFooter},
+		{"inline", api.CommentInline},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			server := mockCommentServer(t, tt.kind, "67890", &calls)
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			comment, kind, err := findComment(context.Background(), client, "67890")
+			require.NoError(t, err)
+			assert.Equal(t, tt.kind, kind)
+			assert.Equal(t, "67890", comment.ID)
+		})
+	}
+}
+
+func TestFindComment_NotFound(t *testing.T) {
+	var calls []string
+	server := mockCommentServer(t, api.CommentFooter, "67890", &calls)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	_, _, err := findComment(context.Background(), client, "11111")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get comment")
+}
+
+func TestCommentBody(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "comment.md")
+	require.NoError(t, os.WriteFile(file, []byte("From **file**"), 0600))
+
+	tests := []struct {
+		name       string
+		message    string
+		file       string
+		stdin      string
+		noMarkdown bool
+		want       string
+		wantErr    string
+	}{
+		{name: "markdown message", message: "Looks **good**", want: "<p>Looks <strong>good</strong></p>"},
+		{name: "raw message", message: "<p>raw</p>", noMarkdown: true, want: "<p>raw</p>"},
+		{name: "file", file: file, want: "<p>From <strong>file</strong></p>"},
+		{name: "stdin", stdin: "piped", want: "<p>piped</p>"},
+		{name: "message and file", message: "hi", file: file, wantErr: "not both"},
+		{name: "empty", message: "  ", wantErr: "comment is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdin io.Reader
+			if tt.stdin != "" {
+				stdin = strings.NewReader(tt.stdin)
+			}
+			body, err := commentBody(tt.message, tt.file, stdin, tt.noMarkdown)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "storage", body.Representation)
+			assert.Equal(t, tt.want, strings.TrimSpace(body.Value))
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package comment
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type deleteOptions struct {
+	force   bool
+	output  string
+	noColor bool
+	stdin   io.Reader // injectable for testing
+}
+
+// NewCmdDelete creates the comment delete command.
+func NewCmdDelete() *cobra.Command {
+	opts := &deleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "delete <comment-id>",
+		Short: "Delete a comment",
+		Long:  `Delete a footer or inline comment by its ID.`,
+		Example: `  # Delete a comment
+  cfl comment delete 67890
+
+  # Delete without confirmation
+  cfl comment delete 67890 --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.stdin = os.Stdin // default to os.Stdin, can be overridden in tests
+			return runDelete(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runDelete(commentID string, opts *deleteOptions, client *api.Client) error {
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	// Get the comment first to show what we're deleting
+	ctx := context.Background()
+	comment, kind, err := findComment(ctx, client, commentID)
+	if err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	// Confirm deletion unless --force is used
+	if !opts.force {
+		fmt.Printf("About to delete %s comment %s: %s\n", kind, comment.ID, view.Truncate(commentText(comment), 60))
+		fmt.Print("Are you sure? [y/N]: ")
+
+		scanner := bufio.NewScanner(opts.stdin)
+		var confirm string
+		if scanner.Scan() {
+			confirm = scanner.Text()
+		}
+
+		if confirm != "y" && confirm != "Y" {
+			fmt.Println("Deletion cancelled.")
+			return nil
+		}
+	}
+
+	if err := client.DeleteComment(ctx, kind, commentID); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]string{
+			"status":     "deleted",
+			"comment_id": commentID,
+			"type":       kind,
+		})
+	}
+
+	renderer.Success(fmt.Sprintf("Deleted %s comment %s", kind, commentID))
+	return nil
+}
@@ -0,0 +1,50 @@
+package comment
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunDelete(t *testing.T) {
+	tests := []struct {
+		name  string
+		kind  string
+		force bool
+		input string
+		want  []string
+	}{
+		{"confirm yes", api.CommentFooter, false, "y\n", []string{"DELETE /api/v2/footer-comments/67890"}},
+		{"confirm no", api.CommentFooter, false, "n\n", nil},
+		{"force inline", api.CommentInline, true, "", []string{"DELETE /api/v2/inline-comments/67890"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			server := mockCommentServer(t, tt.kind, "67890", &calls)
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			opts := &deleteOptions{force: tt.force, noColor: true, stdin: strings.NewReader(tt.input)}
+			err := runDelete("67890", opts, client)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, calls)
+		})
+	}
+}
+
+func TestRunDelete_NotFound(t *testing.T) {
+	var calls []string
+	server := mockCommentServer(t, api.CommentFooter, "67890", &calls)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runDelete("11111", &deleteOptions{force: true}, client)
+	require.Error(t, err)
+	assert.Empty(t, calls)
+}
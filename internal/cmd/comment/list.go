@@ -0,0 +1,188 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type listOptions struct {
+	kind    string
+	status  string
+	output  string
+	noColor bool
+}
+
+// commentThread is a top-level comment with its replies, for JSON output.
+type commentThread struct {
+	api.Comment
+	Type    string        `json:"type"`
+	Replies []api.Comment `json:"replies,omitempty"`
+}
+
+// NewCmdList creates the comment list command.
+func NewCmdList() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list <page-id>",
+		Aliases: []string{"ls"},
+		Short:   "List comments on a page",
+		Long: `List the comments on a page with their replies.
+
+Footer and inline comments are listed by default; use --type to show only
+one kind. Inline comments show the text they are attached to and whether
+they are resolved.`,
+		Example: `  # List all comments
+  cfl comment list 12345
+
+  # Only open inline comments
+  cfl comment list 12345 --type inline --status open
+
+  # Output as JSON
+  cfl comment list 12345 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runList(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.kind, "type", "t", "all", "Comment type: all, footer, inline")
+	cmd.Flags().StringVar(&opts.status, "status", "", "Only inline comments with this status: open, resolved")
+
+	return cmd
+}
+
+func runList(pageID string, opts *listOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	var kinds []string
+	switch opts.kind {
+	case "all", "":
+		kinds = []string{api.CommentFooter, api.CommentInline}
+	case api.CommentFooter, api.CommentInline:
+		kinds = []string{opts.kind}
+	default:
+		return fmt.Errorf("invalid --type %q: must be all, footer or inline", opts.kind)
+	}
+	switch opts.status {
+	case "":
+	case "open", "resolved":
+		// Resolution status only applies to inline comments
+		kinds = []string{api.CommentInline}
+		if opts.kind == api.CommentFooter {
+			return fmt.Errorf("--status only applies to inline comments")
+		}
+	default:
+		return fmt.Errorf("invalid --status %q: must be open or resolved", opts.status)
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var threads []commentThread
+	for _, kind := range kinds {
+		comments, err := listAllComments(func(o *api.ListCommentsOptions) (*api.PaginatedResponse[api.Comment], error) {
+			o.ResolutionStatus = opts.status
+			return client.ListPageComments(ctx, pageID, kind, o)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list %s comments: %w", kind, err)
+		}
+		for _, c := range comments {
+			replies, err := listAllComments(func(o *api.ListCommentsOptions) (*api.PaginatedResponse[api.Comment], error) {
+				return client.ListCommentReplies(ctx, kind, c.ID, o)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list replies to %s: %w", c.ID, err)
+			}
+			threads = append(threads, commentThread{Comment: c, Type: kind, Replies: replies})
+		}
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		if threads == nil {
+			threads = []commentThread{}
+		}
+		return renderer.RenderJSON(threads)
+	}
+
+	if len(threads) == 0 {
+		renderer.RenderText("No comments found.")
+		return nil
+	}
+
+	headers := []string{"ID", "TYPE", "STATUS", "AUTHOR", "CREATED", "COMMENT"}
+	var rows [][]string
+	for _, t := range threads {
+		text := commentText(&t.Comment)
+		if t.Properties != nil && t.Properties.OriginalSelection != "" {
+			text = fmt.Sprintf("%q: %s", view.Truncate(t.Properties.OriginalSelection, 30), text)
+		}
+		rows = append(rows, commentRow(&t.Comment, t.Type, t.ResolutionStatus, text))
+		for i := range t.Replies {
+			reply := &t.Replies[i]
+			rows = append(rows, commentRow(reply, "  reply", "", commentText(reply)))
+		}
+	}
+	renderer.RenderTable(headers, rows)
+	return nil
+}
+
+// commentRow formats a comment as a table row.
+func commentRow(c *api.Comment, kind, status, text string) []string {
+	var author, created string
+	if c.Version != nil {
+		author = c.Version.AuthorID
+		if !c.Version.CreatedAt.IsZero() {
+			created = c.Version.CreatedAt.Format("2006-01-02 15:04")
+		}
+	}
+	return []string{c.ID, kind, status, author, created, view.Truncate(text, 60)}
+}
+
+// listAllComments calls list until every page of comments has been fetched.
+func listAllComments(list func(*api.ListCommentsOptions) (*api.PaginatedResponse[api.Comment], error)) ([]api.Comment, error) {
+	var comments []api.Comment
+	opts := &api.ListCommentsOptions{Limit: 100, BodyFormat: "storage"}
+	for {
+		result, err := list(opts)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, result.Results...)
+		if opts.Cursor = result.NextCursor(); opts.Cursor == "" {
+			return comments, nil
+		}
+	}
+}
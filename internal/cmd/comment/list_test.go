@@ -0,0 +1,100 @@
+package comment
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func mockListServer(t *testing.T, queries *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.Path+"?"+r.URL.RawQuery)
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/api/v2/pages/12345/footer-comments":
+			w.Write([]byte(`{"results": [{"id": "1", "body": {"storage": {"value": "<p>Footer</p>"}}}]}`))
+		case "/api/v2/pages/12345/inline-comments":
+			w.Write([]byte(`{"results": [{
+				"id": "2",
+				"resolutionStatus": "open",
+				"properties": {"inlineOriginalSelection": "some text"},
+				"body": {"storage": {"value": "<p>Inline</p>"}}
+			}]}`))
+		case "/api/v2/footer-comments/1/children":
+			w.Write([]byte(`{"results": [{"id": "3", "body": {"storage": {"value": "<p>Reply</p>"}}}]}`))
+		case "/api/v2/inline-comments/2/children":
+			w.Write([]byte(`{"results": []}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunList(t *testing.T) {
+	var queries []string
+	server := mockListServer(t, &queries)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runList("12345", &listOptions{kind: "all", noColor: true}, client)
+	require.NoError(t, err)
+	assert.Len(t, queries, 4)
+}
+
+func TestRunList_JSON(t *testing.T) {
+	var queries []string
+	server := mockListServer(t, &queries)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runList("12345", &listOptions{kind: "footer", output: "json", noColor: true}, client)
+	require.NoError(t, err)
+	for _, q := range queries {
+		assert.NotContains(t, q, "inline")
+	}
+}
+
+func TestRunList_Status(t *testing.T) {
+	var queries []string
+	server := mockListServer(t, &queries)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runList("12345", &listOptions{kind: "all", status: "open", noColor: true}, client)
+	require.NoError(t, err)
+	require.NotEmpty(t, queries)
+	assert.True(t, strings.HasPrefix(queries[0], "/api/v2/pages/12345/inline-comments?"))
+	assert.Contains(t, queries[0], "resolution-status=open")
+	for _, q := range queries {
+		assert.NotContains(t, q, "footer")
+	}
+}
+
+func TestRunList_InvalidFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *listOptions
+		wantErr string
+	}{
+		{"bad type", &listOptions{kind: "page"}, "invalid --type"},
+		{"bad status", &listOptions{kind: "all", status: "done"}, "invalid --status"},
+		{"status on footer", &listOptions{kind: "footer", status: "open"}, "only applies to inline"},
+		{"bad output", &listOptions{kind: "all", output: "xml"}, "invalid output format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := api.NewClient("http://unused", "test@example.com", "token")
+			err := runList("12345", tt.opts, client)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type replyOptions struct {
+	file       string
+	noMarkdown bool
+	output     string
+	noColor    bool
+	stdin      io.Reader // For testing; defaults to piped os.Stdin
+}
+
+// NewCmdReply creates the comment reply command.
+func NewCmdReply() *cobra.Command {
+	opts := &replyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "reply <comment-id> [message]",
+		Short: "Reply to a comment",
+		Long: `Reply to a footer or inline comment.
+
+The reply is read from the message argument, --file, or stdin, and is
+converted from markdown unless --no-markdown is given.`,
+		Example: `  # Reply to a comment
+  cfl comment reply 67890 "Fixed, thanks!"
+
+  # Reply from stdin
+  echo "Done in **v2.1**" | cfl comment reply 67890`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			if opts.stdin == nil {
+				opts.stdin = pipedStdin()
+			}
+			var message string
+			if len(args) > 1 {
+				message = args[1]
+			}
+			return runReply(args[0], message, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Read the reply from a file")
+	cmd.Flags().BoolVar(&opts.noMarkdown, "no-markdown", false, "Disable markdown conversion (use raw XHTML)")
+
+	return cmd
+}
+
+func runReply(commentID, message string, opts *replyOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	body, err := commentBody(message, opts.file, opts.stdin, opts.noMarkdown)
+	if err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	_, kind, err := findComment(ctx, client, commentID)
+	if err != nil {
+		return err
+	}
+
+	reply, err := client.CreateComment(ctx, kind, &api.CreateCommentRequest{
+		ParentCommentID: commentID,
+		Body:            body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reply: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(reply)
+	}
+
+	renderer.Success(fmt.Sprintf("Replied to comment %s (ID: %s)", commentID, reply.ID))
+	return nil
+}
@@ -0,0 +1,41 @@
+package comment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunReply(t *testing.T) {
+	tests := []struct {
+		name string
+		kind string
+		want string
+	}{
+		{"footer", api.CommentFooter, "POST /api/v2/footer-comments"},
+		{"inline", api.CommentInline, "POST /api/v2/inline-comments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			server := mockCommentServer(t, tt.kind, "67890", &calls)
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			err := runReply("67890", "Fixed", &replyOptions{noColor: true}, client)
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.want}, calls)
+		})
+	}
+}
+
+func TestRunReply_EmptyMessage(t *testing.T) {
+	client := api.NewClient("http://unused", "test@example.com", "token")
+	err := runReply("67890", "", &replyOptions{}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "comment is empty")
+}
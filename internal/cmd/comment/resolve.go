@@ -0,0 +1,311 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type resolveOptions struct {
+	reopen    bool
+	page      string
+	olderThan string
+	author    string
+	dryRun    bool
+	output    string
+	noColor   bool
+}
+
+// NewCmdResolve creates the comment resolve command.
+func NewCmdResolve() *cobra.Command {
+	opts := &resolveOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "resolve [comment-id]",
+		Short: "Resolve an inline comment",
+		Long: `Mark an inline comment as resolved, or reopen it with --reopen.
+
+Only inline comments can be resolved.
+
+With --page instead of a comment ID, the open inline comments on that page
+are resolved in bulk. Choose which with --all-older-than, which matches
+comments not updated within a duration such as 90d, 12w or 36h, and
+--author, which matches comments written by an account ID ("me" for
+yourself), whoever edited them last; comments must match every filter
+given. --dry-run lists the comments that would be resolved without
+changing anything. A report of each comment and
+its outcome is printed at the end, as a table or with --output json.`,
+		Example: `  # Resolve a comment
+  cfl comment resolve 67890
+
+  # Reopen it
+  cfl comment resolve 67890 --reopen
+
+  # Preview resolving comments untouched for 90 days
+  cfl comment resolve --page 12345 --all-older-than 90d --dry-run
+
+  # Resolve your own stale comments, with a JSON report
+  cfl comment resolve --page 12345 --all-older-than 30d --author me -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			if opts.page != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("a comment ID is incompatible with --page")
+				}
+				return runResolveAll(opts, nil)
+			}
+			if opts.olderThan != "" || opts.author != "" || opts.dryRun {
+				return fmt.Errorf("--all-older-than, --author and --dry-run require --page")
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("comment ID or --page is required")
+			}
+			return runResolve(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.reopen, "reopen", false, "Reopen a resolved comment")
+	cmd.Flags().StringVarP(&opts.page, "page", "p", "", "Resolve open inline comments on this page in bulk")
+	cmd.Flags().StringVar(&opts.olderThan, "all-older-than", "", "With --page: only comments not updated within this long (e.g. 90d, 12w, 36h)")
+	cmd.Flags().StringVar(&opts.author, "author", "", "With --page: only comments by this account ID (\"me\" for yourself)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "With --page: list the comments that would be resolved")
+
+	return cmd
+}
+
+func runResolve(commentID string, opts *resolveOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	_, kind, err := findComment(ctx, client, commentID)
+	if err != nil {
+		return err
+	}
+	if kind != api.CommentInline {
+		return fmt.Errorf("comment %s is a footer comment: only inline comments can be resolved", commentID)
+	}
+
+	comment, err := client.ResolveComment(ctx, commentID, !opts.reopen)
+	if err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(comment)
+	}
+
+	if opts.reopen {
+		renderer.Success(fmt.Sprintf("Reopened comment %s", commentID))
+	} else {
+		renderer.Success(fmt.Sprintf("Resolved comment %s", commentID))
+	}
+	return nil
+}
+
+// resolveResult is the outcome of resolving one comment in bulk.
+type resolveResult struct {
+	ID        string `json:"id"`
+	Author    string `json:"author"`
+	Updated   string `json:"updated"`
+	Selection string `json:"selection"`
+	Status    string `json:"status"` // resolved, would-resolve or failed
+	Error     string `json:"error,omitempty"`
+}
+
+func runResolveAll(opts *resolveOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.reopen {
+		return fmt.Errorf("--reopen is incompatible with --page")
+	}
+	if opts.olderThan == "" && opts.author == "" {
+		return fmt.Errorf("--all-older-than or --author is required with --page")
+	}
+	var maxAge time.Duration
+	if opts.olderThan != "" {
+		var err error
+		if maxAge, err = parseAge(opts.olderThan); err != nil {
+			return err
+		}
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, opts.page)
+	if err != nil {
+		return err
+	}
+	opts.page = pageID
+
+	ctx := context.Background()
+	author := opts.author
+	if author == "me" {
+		user, err := client.GetCurrentUser(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get current user: %w", err)
+		}
+		author = user.AccountID
+	}
+
+	comments, err := listAllComments(func(o *api.ListCommentsOptions) (*api.PaginatedResponse[api.Comment], error) {
+		return client.ListPageComments(ctx, opts.page, api.CommentInline, o)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list inline comments: %w", err)
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	var results []resolveResult
+	failed := 0
+	for _, c := range comments {
+		if c.ResolutionStatus != "open" && c.ResolutionStatus != "reopened" {
+			continue
+		}
+		var updated time.Time
+		if c.Version != nil {
+			updated = c.Version.CreatedAt.Time
+		}
+		if maxAge > 0 && (updated.IsZero() || updated.After(cutoff)) {
+			continue
+		}
+		commentAuthor, err := commentCreator(ctx, client, c)
+		if err != nil {
+			return err
+		}
+		if author != "" && commentAuthor != author {
+			continue
+		}
+
+		result := resolveResult{ID: c.ID, Author: commentAuthor, Status: "would-resolve"}
+		if !updated.IsZero() {
+			result.Updated = updated.Format("2006-01-02 15:04")
+		}
+		if c.Properties != nil {
+			result.Selection = c.Properties.OriginalSelection
+		}
+		if !opts.dryRun {
+			result.Status = "resolved"
+			if _, err := client.ResolveComment(ctx, c.ID, true); err != nil {
+				result.Status = "failed"
+				result.Error = err.Error()
+				failed++
+			}
+		}
+		results = append(results, result)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		if results == nil {
+			results = []resolveResult{}
+		}
+		if err := renderer.RenderJSON(results); err != nil {
+			return err
+		}
+	} else if len(results) == 0 {
+		renderer.RenderText("No matching open inline comments.")
+	} else {
+		headers := []string{"ID", "AUTHOR", "UPDATED", "SELECTION", "STATUS", "ERROR"}
+		var rows [][]string
+		for _, r := range results {
+			rows = append(rows, []string{r.ID, r.Author, r.Updated, view.Truncate(r.Selection, 30), r.Status, r.Error})
+		}
+		renderer.RenderTable(headers, rows)
+		if opts.output != "plain" {
+			if opts.dryRun {
+				fmt.Printf("\n%d comment(s) would be resolved (dry run).\n", len(results))
+			} else {
+				fmt.Printf("\n%d resolved, %d failed.\n", len(results)-failed, failed)
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to resolve %d of %d comment(s)", failed, len(results))
+	}
+	return nil
+}
+
+// parseAge parses a duration such as 90d, 12w or 36h. Days and weeks are
+// accepted on top of the units time.ParseDuration knows.
+func parseAge(s string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	switch unit := s[len(s)-1:]; unit {
+	case "d", "w":
+		var n int
+		n, err = strconv.Atoi(strings.TrimSuffix(s, unit))
+		d = time.Duration(n) * 24 * time.Hour
+		if unit == "w" {
+			d *= 7
+		}
+	default:
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q: use a positive number of days, weeks or hours such as 90d, 12w or 36h", s)
+	}
+	return d, nil
+}
+
+// commentCreator returns the account ID of the user who wrote an inline
+// comment. The comment's version names its last editor, so for an edited
+// comment the author is read from its first version.
+func commentCreator(ctx context.Context, client *api.Client, c api.Comment) (string, error) {
+	if c.Version == nil {
+		return "", nil
+	}
+	if c.Version.Number <= 1 {
+		return c.Version.AuthorID, nil
+	}
+	first, err := client.GetCommentVersion(ctx, api.CommentInline, c.ID, 1)
+	if err != nil {
+		return "", fmt.Errorf("failed to get author of comment %s: %w", c.ID, err)
+	}
+	return first.AuthorID, nil
+}
@@ -0,0 +1,186 @@
+package comment
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunResolve(t *testing.T) {
+	var calls []string
+	server := mockCommentServer(t, api.CommentInline, "67890", &calls)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runResolve("67890", &resolveOptions{noColor: true}, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PUT /api/v2/inline-comments/67890"}, calls)
+}
+
+func TestRunResolve_FooterComment(t *testing.T) {
+	var calls []string
+	server := mockCommentServer(t, api.CommentFooter, "67890", &calls)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runResolve("67890", &resolveOptions{noColor: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only inline comments can be resolved")
+	assert.Empty(t, calls)
+}
+
+// newBulkResolveServer serves the inline comments on page 12345 and records
+// the comments resolved. Resolving a comment in failIDs fails.
+func newBulkResolveServer(t *testing.T, comments []api.Comment, failIDs map[string]bool, resolved *[]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/rest/api/user/current":
+			w.Write([]byte(`{"accountId": "me-123"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345/inline-comments":
+			_ = json.NewEncoder(w).Encode(map[string]any{"results": comments})
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/versions/1"):
+			// Edited comments were written by bob
+			w.Write([]byte(`{"number": 1, "authorId": "bob"}`))
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/v2/inline-comments/"):
+			id := strings.TrimPrefix(r.URL.Path, "/api/v2/inline-comments/")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "version": map[string]int{"number": 1}})
+		case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/api/v2/inline-comments/"):
+			id := strings.TrimPrefix(r.URL.Path, "/api/v2/inline-comments/")
+			if failIDs[id] {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			*resolved = append(*resolved, id)
+			w.Write([]byte(`{"id": "` + id + `", "resolutionStatus": "resolved"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func inlineComment(id, status, author string, age time.Duration) api.Comment {
+	return api.Comment{
+		ID:               id,
+		ResolutionStatus: status,
+		Version:          &api.Version{Number: 1, AuthorID: author, CreatedAt: api.Time{Time: time.Now().Add(-age)}},
+		Properties:       &api.InlineCommentProperties{OriginalSelection: "deploy step " + id},
+	}
+}
+
+func TestRunResolveAll(t *testing.T) {
+	day := 24 * time.Hour
+	comments := []api.Comment{
+		inlineComment("1", "open", "alice", 100*day),
+		inlineComment("2", "open", "me-123", 100*day),
+		inlineComment("3", "open", "alice", 10*day),
+		inlineComment("4", "resolved", "alice", 200*day),
+		inlineComment("5", "reopened", "me-123", 95*day),
+		inlineComment("6", "open", "alice", time.Hour),
+	}
+	// Comment 6 was written by bob and last edited by alice
+	comments[5].Version.Number = 2
+
+	tests := []struct {
+		name         string
+		opts         *resolveOptions
+		wantResolved []string
+	}{
+		{"older than", &resolveOptions{olderThan: "90d"}, []string{"1", "2", "5"}},
+		{"older than in weeks", &resolveOptions{olderThan: "1w"}, []string{"1", "2", "3", "5"}},
+		{"by author", &resolveOptions{author: "alice"}, []string{"1", "3"}},
+		{"by author of an edited comment", &resolveOptions{author: "bob"}, []string{"6"}},
+		{"by me and older than", &resolveOptions{author: "me", olderThan: "96d"}, []string{"2"}},
+		{"dry run", &resolveOptions{olderThan: "90d", dryRun: true}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resolved []string
+			server := newBulkResolveServer(t, comments, nil, &resolved)
+			client := api.NewClient(server.URL, "test@example.com", "token")
+
+			tt.opts.page = "12345"
+			tt.opts.output = "json"
+			tt.opts.noColor = true
+			err := runResolveAll(tt.opts, client)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResolved, resolved)
+		})
+	}
+}
+
+func TestRunResolveAll_ContinuesOnFailure(t *testing.T) {
+	comments := []api.Comment{
+		inlineComment("1", "open", "alice", time.Hour),
+		inlineComment("2", "open", "alice", time.Hour),
+		inlineComment("3", "open", "alice", time.Hour),
+	}
+	var resolved []string
+	server := newBulkResolveServer(t, comments, map[string]bool{"2": true}, &resolved)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	err := runResolveAll(&resolveOptions{page: "12345", author: "alice", noColor: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to resolve 1 of 3 comment(s)")
+	assert.Equal(t, []string{"1", "3"}, resolved)
+}
+
+func TestRunResolveAll_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *resolveOptions
+		wantErr string
+	}{
+		{"no filter", &resolveOptions{page: "12345"}, "--all-older-than or --author is required"},
+		{"reopen", &resolveOptions{page: "12345", author: "me", reopen: true}, "--reopen is incompatible"},
+		{"bad duration", &resolveOptions{page: "12345", olderThan: "soon"}, "invalid duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runResolveAll(tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"90d", 90 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"36h", 36 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"0d", 0, true},
+		{"-3d", 0, true},
+		{"d", 0, true},
+		{"ninety", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseAge(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
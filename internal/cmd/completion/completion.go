@@ -13,13 +13,15 @@ func NewCmdCompletion() *cobra.Command {
 		Long: `Generate shell completion scripts for cfl.
 
 These scripts enable tab-completion for commands, flags, and arguments.
-See each sub-command's help for installation instructions.`,
+Run 'cfl completion install' to set them up for your shell, or see each
+sub-command's help for manual installation instructions.`,
 	}
 
 	cmd.AddCommand(NewCmdBash())
 	cmd.AddCommand(NewCmdZsh())
 	cmd.AddCommand(NewCmdFish())
 	cmd.AddCommand(NewCmdPowerShell())
+	cmd.AddCommand(NewCmdInstall())
 
 	return cmd
 }
@@ -24,8 +24,8 @@ func TestNewCmdCompletion(t *testing.T) {
 	assert.NotEmpty(t, cmd.Short)
 	assert.NotEmpty(t, cmd.Long)
 
-	// Should have 4 subcommands
-	assert.Len(t, cmd.Commands(), 4)
+	// One subcommand per shell, plus install
+	assert.Len(t, cmd.Commands(), 5)
 }
 
 func TestBashCompletion(t *testing.T) {
@@ -0,0 +1,297 @@
+package completion
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+// errShellNotFound is returned by a verification run when the shell isn't
+// installed.
+var errShellNotFound = errors.New("shell not found")
+
+type installOptions struct {
+	shell    string
+	path     string
+	noVerify bool
+
+	// Injectable for testing
+	home   string
+	goos   string
+	getenv func(string) string
+	run    func(name string, args ...string) error
+}
+
+// NewCmdInstall creates the completion install command.
+func NewCmdInstall() *cobra.Command {
+	opts := &installOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install the completion script for your shell",
+		Long: `Detect your shell, write the completion script where the shell loads it
+from, and check that it loads.
+
+Locations:
+  bash        ~/.local/share/bash-completion/completions/cfl
+              (loaded by bash-completion 2)
+  zsh         ~/.zsh/completions/_cfl
+              (the directory must be in your fpath)
+  fish        ~/.config/fish/completions/cfl.fish
+  powershell  ~/.config/cfl/completion.ps1, dot-sourced from your profile
+
+The shell is detected from $SHELL; use --shell to choose another. Start a
+new shell afterwards for completions to take effect.`,
+		Example: `  # Install for the current shell
+  cfl completion install
+
+  # Install for zsh to a directory already in your fpath
+  cfl completion install --shell zsh --path /usr/local/share/zsh/site-functions/_cfl`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runInstall(cmd.Root(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.shell, "shell", "", "Shell to install for: bash, zsh, fish, powershell (default: detected)")
+	cmd.Flags().StringVar(&opts.path, "path", "", "Write the script to this file instead of the default location")
+	cmd.Flags().BoolVar(&opts.noVerify, "no-verify", false, "Skip checking that the script loads")
+
+	return cmd
+}
+
+func runInstall(root *cobra.Command, out io.Writer, opts *installOptions) error {
+	if opts.getenv == nil {
+		opts.getenv = os.Getenv
+	}
+	if opts.goos == "" {
+		opts.goos = runtime.GOOS
+	}
+	if opts.home == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to find home directory: %w", err)
+		}
+		opts.home = home
+	}
+	if opts.run == nil {
+		opts.run = runShell
+	}
+
+	shell := opts.shell
+	if shell == "" {
+		var err error
+		if shell, err = detectShell(opts.getenv, opts.goos); err != nil {
+			return err
+		}
+	}
+	// The default locations and the PowerShell profile are in the home directory
+	if config.NoConfig() && (opts.path == "" || shell == "powershell") {
+		return fmt.Errorf("CFL_NO_CONFIG is set, so nothing is installed in the home directory: use --path with bash, zsh or fish")
+	}
+
+	var script bytes.Buffer
+	var err error
+	switch shell {
+	case "bash":
+		err = root.GenBashCompletion(&script)
+	case "zsh":
+		err = root.GenZshCompletion(&script)
+	case "fish":
+		err = root.GenFishCompletion(&script, true)
+	case "powershell":
+		err = root.GenPowerShellCompletionWithDesc(&script)
+	default:
+		return fmt.Errorf("unsupported shell %q: must be bash, zsh, fish or powershell", shell)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate completion script: %w", err)
+	}
+
+	path := opts.path
+	if path == "" {
+		path = installPath(shell, opts.home, opts.getenv)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, script.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write completion script: %w", err)
+	}
+	fmt.Fprintf(out, "Installed %s completions to %s\n", shell, path)
+
+	// PowerShell has no completion directory, so the profile loads the script
+	if shell == "powershell" {
+		profile := powerShellProfile(opts.home, opts.goos, opts.getenv)
+		added, err := addToProfile(profile, ". '"+strings.ReplaceAll(path, "'", "''")+"'")
+		if err != nil {
+			return err
+		}
+		if added {
+			fmt.Fprintf(out, "Added the script to %s\n", profile)
+		}
+	}
+
+	if !opts.noVerify {
+		name, args := verifyCommand(shell, path)
+		switch err := opts.run(name, args...); {
+		case errors.Is(err, errShellNotFound):
+			fmt.Fprintf(out, "Skipped verification: %s not found\n", name)
+		case err != nil:
+			return fmt.Errorf("completion script was written but failed to load in %s: %w", shell, err)
+		default:
+			fmt.Fprintf(out, "Verified the script loads in %s\n", shell)
+		}
+	}
+
+	if hint := installHint(shell, path, opts.home); hint != "" {
+		fmt.Fprintln(out, hint)
+	}
+	fmt.Fprintln(out, "Start a new shell for completions to take effect.")
+	return nil
+}
+
+// detectShell returns the user's shell from $SHELL, defaulting to
+// PowerShell on Windows.
+func detectShell(getenv func(string) string, goos string) (string, error) {
+	if sh := getenv("SHELL"); sh != "" {
+		name := strings.TrimSuffix(filepath.Base(sh), ".exe")
+		switch name {
+		case "bash", "zsh", "fish":
+			return name, nil
+		case "pwsh", "powershell":
+			return "powershell", nil
+		}
+		return "", fmt.Errorf("unsupported shell %q: use --shell bash, zsh, fish or powershell", name)
+	}
+	if goos == "windows" {
+		return "powershell", nil
+	}
+	return "", fmt.Errorf("could not detect your shell: use --shell bash, zsh, fish or powershell")
+}
+
+// installPath returns the default location of a shell's completion script.
+func installPath(shell, home string, getenv func(string) string) string {
+	dataHome := getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	configHome := getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+
+	switch shell {
+	case "bash":
+		return filepath.Join(dataHome, "bash-completion", "completions", "cfl")
+	case "zsh":
+		return filepath.Join(home, ".zsh", "completions", "_cfl")
+	case "fish":
+		return filepath.Join(configHome, "fish", "completions", "cfl.fish")
+	default:
+		return filepath.Join(configHome, "cfl", "completion.ps1")
+	}
+}
+
+// powerShellProfile returns the path of the current user's PowerShell
+// profile.
+func powerShellProfile(home, goos string, getenv func(string) string) string {
+	if goos == "windows" {
+		return filepath.Join(home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1")
+	}
+	configHome := getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "powershell", "Microsoft.PowerShell_profile.ps1")
+}
+
+// addToProfile appends line to a profile unless it already contains it,
+// and reports whether it was added.
+func addToProfile(profile, line string) (bool, error) {
+	data, err := os.ReadFile(profile)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read profile: %w", err)
+	}
+	if strings.Contains(string(data), line) {
+		return false, nil
+	}
+
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		line = "\n" + line
+	}
+	if err := os.MkdirAll(filepath.Dir(profile), 0755); err != nil {
+		return false, fmt.Errorf("failed to create profile directory: %w", err)
+	}
+	f, err := os.OpenFile(profile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return false, fmt.Errorf("failed to open profile: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.WriteString(line + "\n"); err != nil {
+		return false, fmt.Errorf("failed to write profile: %w", err)
+	}
+	return true, nil
+}
+
+// verifyCommand returns a command that fails unless the installed script
+// loads and registers completions for cfl.
+func verifyCommand(shell, path string) (string, []string) {
+	switch shell {
+	case "bash":
+		return "bash", []string{"-c", `source "$1" && complete -p cfl >/dev/null`, "_", path}
+	case "zsh":
+		return "zsh", []string{"-c", `fpath=("$1" $fpath) && autoload -U +X _cfl`, "_", filepath.Dir(path)}
+	case "fish":
+		return "fish", []string{"-c", "source '" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(path) + "'; and complete -c cfl | string length -q"}
+	default:
+		return "pwsh", []string{"-NoProfile", "-Command", ". '" + strings.ReplaceAll(path, "'", "''") + "'"}
+	}
+}
+
+// installHint returns what else the user must do for the shell to find the
+// script, if anything.
+func installHint(shell, path, home string) string {
+	if shell != "zsh" {
+		return ""
+	}
+	dir := filepath.Dir(path)
+	zshrc, _ := os.ReadFile(filepath.Join(home, ".zshrc"))
+	if strings.Contains(string(zshrc), dir) || strings.Contains(string(zshrc), "~/.zsh/completions") {
+		return ""
+	}
+	return fmt.Sprintf("Add this to ~/.zshrc before compinit runs, if %s isn't in your fpath:\n  fpath=(%s $fpath)", dir, dir)
+}
+
+// runShell runs a shell command, returning errShellNotFound if the shell
+// isn't installed.
+func runShell(name string, args ...string) error {
+	bin, err := exec.LookPath(name)
+	if err != nil && name == "pwsh" {
+		bin, err = exec.LookPath("powershell")
+	}
+	if err != nil {
+		return errShellNotFound
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(bin, args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
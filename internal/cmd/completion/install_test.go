@@ -0,0 +1,186 @@
+package completion
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectShell(t *testing.T) {
+	tests := []struct {
+		name    string
+		shell   string
+		goos    string
+		want    string
+		wantErr string
+	}{
+		{"bash", "/bin/bash", "linux", "bash", ""},
+		{"zsh", "/usr/local/bin/zsh", "darwin", "zsh", ""},
+		{"fish", "/usr/bin/fish", "linux", "fish", ""},
+		{"pwsh", "/usr/bin/pwsh", "linux", "powershell", ""},
+		{"windows", "", "windows", "powershell", ""},
+		{"unsupported", "/bin/tcsh", "linux", "", `unsupported shell "tcsh"`},
+		{"unknown", "", "linux", "", "could not detect your shell"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string {
+				if key == "SHELL" {
+					return tt.shell
+				}
+				return ""
+			}
+			got, err := detectShell(getenv, tt.goos)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunInstall(t *testing.T) {
+	tests := []struct {
+		shell string
+		file  string
+	}{
+		{"bash", ".local/share/bash-completion/completions/cfl"},
+		{"zsh", ".zsh/completions/_cfl"},
+		{"fish", ".config/fish/completions/cfl.fish"},
+		{"powershell", ".config/cfl/completion.ps1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			home := t.TempDir()
+			var ran string
+			opts := &installOptions{
+				shell:  tt.shell,
+				home:   home,
+				goos:   "linux",
+				getenv: func(string) string { return "" },
+				run: func(name string, _ ...string) error {
+					ran = name
+					return nil
+				},
+			}
+
+			var out bytes.Buffer
+			require.NoError(t, runInstall(createTestRootCmd(), &out, opts))
+
+			assert.FileExists(t, filepath.Join(home, filepath.FromSlash(tt.file)))
+			assert.NotEmpty(t, ran)
+			assert.Contains(t, out.String(), "Verified the script loads")
+		})
+	}
+}
+
+func TestRunInstall_PowerShellProfile(t *testing.T) {
+	home := t.TempDir()
+	opts := &installOptions{
+		shell:    "powershell",
+		home:     home,
+		goos:     "linux",
+		noVerify: true,
+		getenv:   func(string) string { return "" },
+	}
+
+	// Installing twice adds the profile line once
+	var out bytes.Buffer
+	require.NoError(t, runInstall(createTestRootCmd(), &out, opts))
+	require.NoError(t, runInstall(createTestRootCmd(), &out, opts))
+
+	profile, err := os.ReadFile(filepath.Join(home, ".config", "powershell", "Microsoft.PowerShell_profile.ps1"))
+	require.NoError(t, err)
+	assert.Equal(t, ". '"+filepath.Join(home, ".config", "cfl", "completion.ps1")+"'\n", string(profile))
+}
+
+func TestRunInstall_Verification(t *testing.T) {
+	tests := []struct {
+		name    string
+		runErr  error
+		wantOut string
+		wantErr string
+	}{
+		{"shell missing", errShellNotFound, "Skipped verification: bash not found", ""},
+		{"script fails", errors.New("exit status 1"), "", "failed to load in bash"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &installOptions{
+				path:   filepath.Join(t.TempDir(), "cfl"),
+				home:   t.TempDir(),
+				goos:   "linux",
+				getenv: func(key string) string { return map[string]string{"SHELL": "/bin/bash"}[key] },
+				run:    func(string, ...string) error { return tt.runErr },
+			}
+
+			var out bytes.Buffer
+			err := runInstall(createTestRootCmd(), &out, opts)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), tt.wantOut)
+			assert.FileExists(t, opts.path)
+		})
+	}
+}
+
+func TestRunInstall_NoConfig(t *testing.T) {
+	t.Setenv("CFL_NO_CONFIG", "1")
+
+	tests := []struct {
+		name    string
+		shell   string
+		path    string
+		wantErr bool
+	}{
+		{"default location", "bash", "", true},
+		{"powershell profile", "powershell", "completion.ps1", true},
+		{"explicit path", "zsh", "_cfl", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			dir := t.TempDir()
+			opts := &installOptions{
+				shell:    tt.shell,
+				home:     home,
+				goos:     "linux",
+				noVerify: true,
+				getenv:   func(string) string { return "" },
+			}
+			if tt.path != "" {
+				opts.path = filepath.Join(dir, tt.path)
+			}
+
+			var out bytes.Buffer
+			err := runInstall(createTestRootCmd(), &out, opts)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "CFL_NO_CONFIG")
+			} else {
+				require.NoError(t, err)
+				assert.FileExists(t, opts.path)
+			}
+
+			entries, err := os.ReadDir(home)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package configcmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type capabilitiesOptions struct {
+	refresh bool
+	output  string
+	noColor bool
+}
+
+// NewCmdCapabilities creates the config capabilities command.
+func NewCmdCapabilities() *cobra.Command {
+	opts := &capabilitiesOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "capabilities",
+		Short: "Show which optional features the site supports",
+		Long: `Show which optional Confluence features the configured site supports.
+
+Capabilities are probed on first use and cached per site for a day.
+Commands that need a missing feature fail with "not supported on this
+site". Use --refresh to probe again, for example after a site upgrade.`,
+		Example: `  # Show cached capabilities
+  cfl config capabilities
+
+  # Probe the site again
+  cfl config capabilities --refresh`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runCapabilities(opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.refresh, "refresh", false, "Probe the site instead of using the cache")
+
+	return cmd
+}
+
+func runCapabilities(opts *capabilitiesOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	var caps *api.Capabilities
+	var err error
+	if opts.refresh {
+		caps, err = client.RefreshCapabilities(ctx)
+	} else {
+		caps, err = client.Capabilities(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(caps)
+	}
+
+	yesNo := func(ok bool) string {
+		if ok {
+			return "yes"
+		}
+		return "no"
+	}
+	renderer.RenderKeyValue("Site", caps.Site)
+	renderer.RenderKeyValue("v2 API", yesNo(caps.V2))
+	renderer.RenderKeyValue("Cloud editor", yesNo(caps.ADF))
+	renderer.RenderKeyValue("Public links", yesNo(caps.PublicLinks))
+	renderer.RenderKeyValue("Content states", yesNo(caps.ContentStates))
+	renderer.RenderKeyValue("Probed", caps.ProbedAt.Local().Format("2006-01-02 15:04"))
+	return nil
+}
@@ -0,0 +1,54 @@
+package configcmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunCapabilities(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/api/v2/spaces" || r.URL.Path == "/api/v2/pages" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"results": []}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cachePath := filepath.Join(t.TempDir(), "capabilities.json")
+
+	tests := []struct {
+		name      string
+		opts      *capabilitiesOptions
+		wantProbe bool
+	}{
+		{"first run probes", &capabilitiesOptions{noColor: true}, true},
+		{"cached", &capabilitiesOptions{output: "json", noColor: true}, false},
+		{"refresh probes", &capabilitiesOptions{refresh: true, noColor: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = 0
+			client := api.NewClient(server.URL, "test@example.com", "token", api.WithCapabilityCache(cachePath))
+			err := runCapabilities(tt.opts, client)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProbe, requests > 0)
+		})
+	}
+}
+
+func TestRunCapabilities_InvalidOutput(t *testing.T) {
+	err := runCapabilities(&capabilitiesOptions{output: "xml"}, api.NewClient("http://unused", "", ""))
+	require.Error(t, err)
+}
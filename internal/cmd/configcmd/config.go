@@ -10,12 +10,15 @@ func NewCmdConfig() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "config",
 		Short: "Manage cfl configuration",
-		Long:  `Commands for viewing, testing, and clearing cfl configuration.`,
+		Long: `Commands for viewing, testing, and clearing cfl configuration, switching
+between profiles, and showing what the configured site supports.`,
 	}
 
 	cmd.AddCommand(NewCmdShow())
 	cmd.AddCommand(NewCmdTest())
 	cmd.AddCommand(NewCmdClear())
+	cmd.AddCommand(NewCmdCapabilities())
+	cmd.AddCommand(NewCmdUseContext())
 
 	return cmd
 }
@@ -35,14 +35,22 @@ func runShow(noColor bool) error {
 
 	configPath := config.DefaultConfigPath()
 
-	// Load file config (may not exist)
-	fileCfg, fileErr := config.Load(configPath)
-	if fileErr != nil {
-		fileCfg = &config.Config{}
+	// Load file config (may not exist, and is ignored with CFL_NO_CONFIG)
+	fileCfg, fileErr := &config.Config{}, config.ErrNoConfig
+	if !config.NoConfig() {
+		fileCfg, fileErr = config.Load(configPath)
+		if fileErr != nil {
+			fileCfg = &config.Config{}
+		}
 	}
 
-	// Load full config with env overrides
-	cfg, _ := config.LoadWithEnv(configPath)
+	// Load full config with the active profile and env overrides
+	cfg, err := config.LoadWithEnv(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	profile := cfg.ActiveProfile()
+	_ = fileCfg.UseProfile(profile)
 
 	bold := color.New(color.Bold)
 	dim := color.New(color.Faint)
@@ -80,6 +88,10 @@ func runShow(noColor bool) error {
 		_, _ = dim.Printf("  (source: %s)\n", source)
 	}
 
+	if profile != "" {
+		_, _ = bold.Printf("%-12s", "Profile:")
+		fmt.Println(profile)
+	}
 	printField("URL", cfg.URL, fileCfg.URL, "CFL_URL", "ATLASSIAN_URL")
 	printField("Email", cfg.Email, fileCfg.Email, "CFL_EMAIL", "ATLASSIAN_EMAIL")
 	printField("API Token", cfg.APIToken, fileCfg.APIToken, "CFL_API_TOKEN", "ATLASSIAN_API_TOKEN")
@@ -87,7 +99,10 @@ func runShow(noColor bool) error {
 
 	fmt.Println()
 	_, _ = dim.Printf("Config file: %s\n", configPath)
-	if fileErr != nil {
+	switch {
+	case config.NoConfig():
+		_, _ = dim.Println("(ignored: CFL_NO_CONFIG is set)")
+	case fileErr != nil:
 		_, _ = dim.Println("(file not found)")
 	}
 
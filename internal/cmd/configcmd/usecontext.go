@@ -0,0 +1,124 @@
+package configcmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+// NewCmdUseContext creates the config use-context command.
+func NewCmdUseContext() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "use-context [name]",
+		Short: "Switch the default profile",
+		Long: `Set the profile (context) used when neither --profile nor CFL_PROFILE
+is given. Profiles are defined under contexts: in the config file, and
+their settings override the top-level ones:
+
+  current_context: work
+  contexts:
+    work:
+      url: https://work.atlassian.net/wiki
+      email: me@work.example
+      api_token: ...
+    personal:
+      url: https://me.atlassian.net/wiki
+      email: me@example.com
+      api_token: ...
+
+Without a name, the available profiles are listed. Use "-" as the name to
+go back to the top-level settings.`,
+		Example: `  # List profiles
+  cfl config use-context
+
+  # Make "personal" the default
+  cfl config use-context personal
+
+  # Run a single command against another profile
+  cfl space list --profile work`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			noColor, _ := cmd.Flags().GetBool("no-color")
+			var name string
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return runUseContext(name, noColor)
+		},
+	}
+
+	return cmd
+}
+
+func runUseContext(name string, noColor bool) error {
+	if noColor {
+		color.NoColor = true
+	}
+
+	if config.NoConfig() {
+		return config.ErrNoConfig
+	}
+
+	configPath := config.DefaultConfigPath()
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+	}
+
+	if name == "" {
+		listContexts(cfg)
+		return nil
+	}
+
+	if name == "-" {
+		cfg.CurrentContext = ""
+	} else {
+		if _, ok := cfg.Contexts[name]; !ok {
+			return fmt.Errorf("unknown profile %q (run 'cfl config use-context' to list profiles)", name)
+		}
+		cfg.CurrentContext = name
+	}
+
+	if err := cfg.Save(configPath); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen)
+	if cfg.CurrentContext == "" {
+		_, _ = green.Println("✓ Using the top-level settings")
+	} else {
+		_, _ = green.Printf("✓ Switched to profile %q\n", cfg.CurrentContext)
+	}
+	return nil
+}
+
+// listContexts prints the profile names, marking the default with "*".
+func listContexts(cfg *config.Config) {
+	dim := color.New(color.Faint)
+	if len(cfg.Contexts) == 0 {
+		_, _ = dim.Println("No profiles defined (add them under contexts: in the config file)")
+		return
+	}
+
+	names := make([]string, 0, len(cfg.Contexts))
+	for name := range cfg.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		marker := " "
+		if name == cfg.CurrentContext {
+			marker = "*"
+		}
+		fmt.Printf("%s %s", marker, name)
+		if url := cfg.Contexts[name].URL; url != "" {
+			_, _ = dim.Printf("  %s", url)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,52 @@
+package configcmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+func TestRunUseContext(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	configPath := config.DefaultConfigPath()
+
+	cfg := &config.Config{
+		URL:      "https://default.atlassian.net/wiki",
+		Email:    "me@example.com",
+		APIToken: "token",
+		Contexts: map[string]config.Profile{
+			"work": {URL: "https://work.atlassian.net/wiki"},
+		},
+	}
+	require.NoError(t, cfg.Save(configPath))
+
+	require.NoError(t, runUseContext("work", true))
+	saved, err := config.Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "work", saved.CurrentContext)
+	assert.Equal(t, "https://default.atlassian.net/wiki", saved.URL)
+
+	err = runUseContext("home", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown profile "home"`)
+
+	require.NoError(t, runUseContext("-", true))
+	saved, err = config.Load(configPath)
+	require.NoError(t, err)
+	assert.Empty(t, saved.CurrentContext)
+
+	// Listing doesn't change anything
+	require.NoError(t, runUseContext("", true))
+}
+
+func TestRunUseContext_NoConfig(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(t.TempDir(), "missing"))
+
+	err := runUseContext("work", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cfl init")
+}
@@ -0,0 +1,47 @@
+// Package contentcmd builds the commands for content types cfl can only
+// list and view the metadata of, such as whiteboards and databases.
+package contentcmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// ContentType describes a content type to build commands for.
+type ContentType struct {
+	Name    string   // Singular name, e.g. "whiteboard"; also the command name
+	Aliases []string // Aliases of the command
+	Hidden  string   // What the API doesn't expose, e.g. "what is drawn on a whiteboard"
+	Example string   // A word to filter titles by in the list example
+
+	List func(*api.Client, context.Context, *api.ListContentOptions) (*api.SearchResponse, error)
+	Get  func(*api.Client, context.Context, string) (*api.ContentItem, error)
+}
+
+// plural returns the type's name in the plural.
+func (t ContentType) plural() string {
+	return t.Name + "s"
+}
+
+// NewCmd creates the command for a content type, with its list and view
+// subcommands.
+func NewCmd(t ContentType) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     t.Name,
+		Aliases: t.Aliases,
+		Short:   fmt.Sprintf("List and view Confluence %s", t.plural()),
+		Long: fmt.Sprintf(`Commands for listing %[1]s and viewing their details.
+
+The Confluence API doesn't expose %[2]s, only its
+metadata; 'cfl %[3]s view --raw' saves the metadata as the API returns it.`, t.plural(), t.Hidden, t.Name),
+	}
+
+	cmd.AddCommand(newCmdList(t))
+	cmd.AddCommand(newCmdView(t))
+
+	return cmd
+}
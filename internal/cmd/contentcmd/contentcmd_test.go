@@ -0,0 +1,100 @@
+package contentcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestContentTypeCommands(t *testing.T) {
+	tests := []struct {
+		contentType ContentType
+		collection  string
+	}{
+		{
+			contentType: ContentType{Name: "whiteboard", List: (*api.Client).ListWhiteboards, Get: (*api.Client).GetWhiteboard},
+			collection:  "whiteboards",
+		},
+		{
+			contentType: ContentType{Name: "database", List: (*api.Client).ListDatabases, Get: (*api.Client).GetDatabase},
+			collection:  "databases",
+		},
+	}
+
+	for _, tt := range tests {
+		name := tt.contentType.Name
+		t.Run(name, func(t *testing.T) {
+			item := fmt.Sprintf(`{"id":"555","type":"%[1]s","status":"current","title":"Architecture",`+
+				`"spaceId":"777","parentId":"42","parentType":"page","version":{"number":3},`+
+				`"_links":{"webui":"/spaces/DEV/%[1]s/555"},"extra":true}`, name)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v2/" + tt.collection + "/555":
+					w.Write([]byte(item))
+				case "/rest/api/search":
+					assert.Equal(t, `space = "DEV" AND type = "`+name+`"`, r.URL.Query().Get("cql"))
+					w.Write([]byte(`{"results": [{"content": {"id": "555", "type": "` + name + `", "title": "Architecture"},
+						"resultGlobalContainer": {"title": "Development", "displayUrl": "/spaces/DEV"}}],
+						"start": 0, "size": 1, "totalSize": 1}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(`{"message": "Not found"}`))
+				}
+			}))
+			defer server.Close()
+			client := api.NewClient(server.URL, "test@example.com", "token")
+
+			// List
+			require.NoError(t, runList(tt.contentType, &listOptions{space: "DEV", limit: 25, noColor: true}, client))
+			err := runList(tt.contentType, &listOptions{limit: -1}, client)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid limit")
+			err = runList(tt.contentType, &listOptions{limit: 25, output: "xml"}, client)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid output format")
+
+			// View
+			var stdout bytes.Buffer
+			require.NoError(t, runView(tt.contentType, "555", &viewOptions{noColor: true, stdout: &stdout}, client))
+			assert.Contains(t, stdout.String(), "Architecture")
+			assert.Contains(t, stdout.String(), "page 42")
+			assert.Contains(t, stdout.String(), server.URL+"/spaces/DEV/"+name+"/555")
+
+			stdout.Reset()
+			require.NoError(t, runView(tt.contentType, "555", &viewOptions{output: "json", stdout: &stdout}, client))
+			var got api.ContentItem
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &got))
+			assert.Equal(t, "555", got.ID)
+
+			stdout.Reset()
+			require.NoError(t, runView(tt.contentType, "555", &viewOptions{raw: true, stdout: &stdout}, client))
+			assert.JSONEq(t, item, stdout.String())
+
+			err = runView(tt.contentType, "999", &viewOptions{}, client)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "failed to get "+name)
+		})
+	}
+}
+
+func TestNewCmd(t *testing.T) {
+	cmd := NewCmd(ContentType{Name: "whiteboard", Aliases: []string{"wb"}, Hidden: "what is drawn on a whiteboard", Example: "architecture"})
+
+	assert.Equal(t, "whiteboard", cmd.Use)
+	assert.Equal(t, []string{"wb"}, cmd.Aliases)
+	assert.Contains(t, cmd.Long, "doesn't expose what is drawn on a whiteboard")
+	list, _, err := cmd.Find([]string{"list"})
+	require.NoError(t, err)
+	assert.Contains(t, list.Example, `# Whiteboards with a title containing "architecture"`)
+	viewCmd, _, err := cmd.Find([]string{"view"})
+	require.NoError(t, err)
+	assert.Equal(t, "view <whiteboard-id>", viewCmd.Use)
+}
@@ -0,0 +1,131 @@
+package contentcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type listOptions struct {
+	space   string
+	title   string
+	limit   int
+	output  string
+	noColor bool
+}
+
+// newCmdList creates the list command of a content type.
+func newCmdList(t ContentType) *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List " + t.plural(),
+		Long:    fmt.Sprintf(`List the %s in a space, or in all spaces you can see.`, t.plural()),
+		Example: fmt.Sprintf(`  # List %[1]s in a space
+  cfl %[2]s list --space DEV
+
+  # %[3]s with a title containing "%[4]s"
+  cfl %[2]s list --title %[4]s
+
+  # Output as JSON
+  cfl %[2]s list -s DEV -o json`, t.plural(), t.Name, strings.ToUpper(t.plural()[:1])+t.plural()[1:], t.Example),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runList(t, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key (default: the configured default space)")
+	cmd.Flags().StringVar(&opts.title, "title", "", "Filter by title (contains)")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "l", 25, fmt.Sprintf("Maximum number of %s to return", t.plural()))
+
+	return cmd
+}
+
+func runList(t ContentType, opts *listOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Validate limit
+	if opts.limit < 0 {
+		return fmt.Errorf("invalid limit: %d (must be >= 0)", opts.limit)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	// Handle limit 0 - return empty list
+	if opts.limit == 0 {
+		if view.IsStructured(opts.output) {
+			return renderer.RenderJSON([]interface{}{})
+		}
+		renderer.RenderText(fmt.Sprintf("No %s found.", t.plural()))
+		return nil
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if opts.space == "" {
+			opts.space = cfg.DefaultSpace
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	result, err := t.List(client, context.Background(), &api.ListContentOptions{
+		SpaceKey: opts.space,
+		Title:    opts.title,
+		Limit:    opts.limit,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", t.plural(), err)
+	}
+
+	if len(result.Results) == 0 {
+		if view.IsStructured(opts.output) {
+			return renderer.RenderJSON([]interface{}{})
+		}
+		renderer.RenderText(fmt.Sprintf("No %s found.", t.plural()))
+		return nil
+	}
+
+	headers := []string{"ID", "TITLE", "SPACE", "LAST MODIFIED"}
+	var rows [][]string
+	for _, r := range result.Results {
+		rows = append(rows, []string{
+			r.Content.ID,
+			view.Truncate(r.Content.Title, 50),
+			r.ResultGlobalContainer.SpaceKey(),
+			r.FriendlyLastModified,
+		})
+	}
+
+	renderer.RenderList(headers, rows, result.HasMore())
+
+	if result.HasMore() && !view.IsStructured(opts.output) {
+		fmt.Fprintf(os.Stderr, "\n(showing %d of %d %s, use --limit to see more)\n",
+			len(result.Results), result.TotalSize, t.plural())
+	}
+
+	return nil
+}
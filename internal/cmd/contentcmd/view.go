@@ -0,0 +1,117 @@
+package contentcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type viewOptions struct {
+	raw     bool
+	output  string
+	noColor bool
+	stdout  io.Writer // For testing; defaults to os.Stdout
+}
+
+// newCmdView creates the view command of a content type.
+func newCmdView(t ContentType) *cobra.Command {
+	opts := &viewOptions{}
+
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("view <%s-id>", t.Name),
+		Short: "View a " + t.Name,
+		Long: fmt.Sprintf(`View the details of a %[1]s: its title, space, parent, owner and
+version.
+
+Use --raw to print the %[1]s exactly as the API returns it, including
+fields cfl doesn't show, for saving or scripting.`, t.Name),
+		Example: fmt.Sprintf(`  # View a %[1]s
+  cfl %[1]s view 123456
+
+  # Save the API response
+  cfl %[1]s view 123456 --raw > %[1]s.json`, t.Name),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runView(t, args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.raw, "raw", false, "Print the API response as returned")
+
+	return cmd
+}
+
+func runView(t ContentType, id string, opts *viewOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	item, err := t.Get(client, context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", t.Name, err)
+	}
+
+	stdout := opts.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+
+	if opts.raw {
+		_, err := fmt.Fprintln(stdout, string(item.Raw))
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	renderer.SetWriter(stdout)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(item)
+	}
+
+	renderer.RenderKeyValue("Title", item.Title)
+	renderer.RenderKeyValue("ID", item.ID)
+	renderer.RenderKeyValue("Status", item.Status)
+	renderer.RenderKeyValue("Space ID", item.SpaceID)
+	if item.ParentID != "" {
+		renderer.RenderKeyValue("Parent", fmt.Sprintf("%s %s", item.ParentType, item.ParentID))
+	}
+	if item.OwnerID != "" {
+		renderer.RenderKeyValue("Owner ID", item.OwnerID)
+	}
+	if !item.CreatedAt.IsZero() {
+		renderer.RenderKeyValue("Created", item.CreatedAt.Format("2006-01-02 15:04"))
+	}
+	if item.Version != nil {
+		renderer.RenderKeyValue("Version", strconv.Itoa(item.Version.Number))
+	}
+	if item.Links.WebUI != "" {
+		renderer.RenderKeyValue("URL", client.BaseURL()+item.Links.WebUI)
+	}
+
+	return nil
+}
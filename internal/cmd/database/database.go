@@ -0,0 +1,24 @@
+// Package database provides database-related commands.
+package database
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/contentcmd"
+)
+
+// NewCmdDatabase creates the database command.
+func NewCmdDatabase() *cobra.Command {
+	return contentcmd.NewCmd(contentType)
+}
+
+// contentType describes databases to the content type commands.
+var contentType = contentcmd.ContentType{
+	Name:    "database",
+	Aliases: []string{"databases", "db"},
+	Hidden:  "the entries of a database",
+	Example: "roadmap",
+	List:    (*api.Client).ListDatabases,
+	Get:     (*api.Client).GetDatabase,
+}
@@ -0,0 +1,148 @@
+package debugcmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type convertOptions struct {
+	input      string
+	showMacros bool
+	output     string
+	noColor    bool
+	stdout     io.Writer // For testing; defaults to os.Stdout
+}
+
+// convertResult is a rerun conversion compared with the recorded one.
+type convertResult struct {
+	conversion
+	Changed  bool   `json:"changed"`
+	Recorded string `json:"recorded"`
+}
+
+// NewCmdConvert creates the debug convert command.
+func NewCmdConvert() *cobra.Command {
+	opts := &convertOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "convert <file>",
+		Short: "Rerun the conversions in a dump-page file",
+		Long: `Rerun the conversions recorded by 'cfl debug dump-page' with this build of
+cfl, and print the markdown each produces, noting whether it differs from
+the markdown recorded in the file. No Confluence site is needed.
+
+Use --input and --show-macros to rerun a single conversion.`,
+		Example: `  # Rerun all conversions
+  cfl debug convert bug.json
+
+  # Only the storage body, with macro placeholders
+  cfl debug convert bug.json --input storage --show-macros`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			var showMacros *bool
+			if cmd.Flags().Changed("show-macros") {
+				showMacros = &opts.showMacros
+			}
+			return runConvert(args[0], opts, showMacros)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.input, "input", "", "Body to convert: storage or adf (default: both)")
+	cmd.Flags().BoolVar(&opts.showMacros, "show-macros", false, "Only rerun the conversion with macro placeholders (--show-macros=false for without)")
+
+	return cmd
+}
+
+// runConvert reruns the conversions in the file at path. showMacros selects
+// the conversions with or without macro placeholders; nil selects both.
+func runConvert(path string, opts *convertOptions, showMacros *bool) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.input != "" && opts.input != "storage" && opts.input != "adf" {
+		return fmt.Errorf("invalid input %q: must be storage or adf", opts.input)
+	}
+
+	f, err := readFixture(path)
+	if err != nil {
+		return err
+	}
+
+	recorded := make(map[string]conversion)
+	for _, c := range f.Conversions {
+		recorded[conversionKey(c)] = c
+	}
+
+	var results []convertResult
+	for _, c := range convertBodies(f) {
+		if opts.input != "" && c.Input != opts.input {
+			continue
+		}
+		if showMacros != nil && c.ShowMacros != *showMacros {
+			continue
+		}
+		r := convertResult{conversion: c}
+		if old, ok := recorded[conversionKey(c)]; ok {
+			r.Recorded = old.Markdown
+			r.Changed = old.Markdown != c.Markdown || old.Error != c.Error
+		} else {
+			r.Changed = true
+		}
+		results = append(results, r)
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("%s has no %s body to convert", path, describeInput(opts.input))
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	stdout := opts.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	renderer.SetWriter(stdout)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(results)
+	}
+
+	fmt.Fprintf(stdout, "Page %s %q, dumped with cfl %s\n", f.Page.ID, f.Page.Title, f.CFL.Version)
+	for _, r := range results {
+		status := "unchanged"
+		if r.Changed {
+			status = "changed"
+		}
+		macros := "macros hidden"
+		if r.ShowMacros {
+			macros = "macros shown"
+		}
+		fmt.Fprintf(stdout, "\n=== %s (%s): %s\n", r.Input, macros, status)
+		for _, w := range r.Warnings {
+			renderer.Warning(w)
+		}
+		if r.Error != "" {
+			renderer.Error(r.Error)
+			continue
+		}
+		fmt.Fprintln(stdout, r.Markdown)
+	}
+	return nil
+}
+
+func conversionKey(c conversion) string {
+	return fmt.Sprintf("%s/%t", c.Input, c.ShowMacros)
+}
+
+func describeInput(input string) string {
+	if input == "" {
+		return "storage or ADF"
+	}
+	return input
+}
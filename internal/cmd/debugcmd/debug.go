@@ -0,0 +1,113 @@
+// Package debugcmd provides commands for reporting and reproducing
+// conversion bugs.
+package debugcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/internal/version"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// fixtureFormatVersion is the version of the dump-page file format.
+const fixtureFormatVersion = 1
+
+// NewCmdDebug creates the debug command.
+func NewCmdDebug() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Capture and reproduce conversion bugs",
+		Long: `Commands for reporting bugs in how cfl converts pages.
+
+dump-page saves a page's bodies, the markdown cfl converts them to, and the
+warnings raised on the way into one file to attach to an issue. convert
+reruns the conversions recorded in such a file, to reproduce the bug
+without access to the page.`,
+	}
+
+	cmd.AddCommand(NewCmdDumpPage())
+	cmd.AddCommand(NewCmdConvert())
+
+	return cmd
+}
+
+// fixture is the file written by dump-page and read by convert.
+type fixture struct {
+	FormatVersion int          `json:"formatVersion"`
+	Created       time.Time    `json:"created"`
+	CFL           version.Info `json:"cfl"`
+	Page          fixturePage  `json:"page"`
+	Storage       string       `json:"storage,omitempty"`
+	ADF           string       `json:"adf,omitempty"`
+	Conversions   []conversion `json:"conversions"`
+}
+
+// fixturePage is the metadata of the dumped page.
+type fixturePage struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Status  string `json:"status,omitempty"`
+	Version int    `json:"version,omitempty"`
+	Editor  string `json:"editor,omitempty"`
+}
+
+// conversion is the result of converting one body to markdown.
+type conversion struct {
+	Input      string   `json:"input"` // "storage" or "adf"
+	ShowMacros bool     `json:"showMacros"`
+	Markdown   string   `json:"markdown"`
+	Error      string   `json:"error,omitempty"`
+	Warnings   []string `json:"warnings,omitempty"`
+}
+
+// convertBodies converts each body of f to markdown, with and without
+// macro placeholders.
+func convertBodies(f *fixture) []conversion {
+	var conversions []conversion
+	for _, showMacros := range []bool{false, true} {
+		opts := md.ConvertOptions{ShowMacros: showMacros}
+		if f.Storage != "" {
+			c := conversion{Input: "storage", ShowMacros: showMacros}
+			markdown, err := md.FromConfluenceStorageWithOptions(f.Storage, opts)
+			c.Markdown = markdown
+			if err != nil {
+				c.Error = err.Error()
+			}
+			if result, err := md.ParseConfluenceXML(f.Storage); err == nil {
+				c.Warnings = result.Warnings
+			}
+			conversions = append(conversions, c)
+		}
+		if f.ADF != "" {
+			c := conversion{Input: "adf", ShowMacros: showMacros}
+			markdown, err := md.FromADFWithOptions(f.ADF, opts)
+			c.Markdown = markdown
+			if err != nil {
+				c.Error = err.Error()
+			}
+			conversions = append(conversions, c)
+		}
+	}
+	return conversions
+}
+
+// readFixture reads a file written by dump-page.
+func readFixture(path string) (*fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if f.FormatVersion < 1 || f.FormatVersion > fixtureFormatVersion {
+		return nil, fmt.Errorf("unsupported file format version %d (this cfl reads version %d)", f.FormatVersion, fixtureFormatVersion)
+	}
+	return &f, nil
+}
@@ -0,0 +1,180 @@
+package debugcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func mockPageServer(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/pages/123" && r.URL.Query().Get("body-format") == "storage":
+			storage := `<p>See <a href="` + server.URL + `/wiki/x/abc">this</a>, token s3cret</p>` +
+				`<ac:structured-macro ac:name="toc"></ac:structured-macro>`
+			body, _ := json.Marshal(map[string]any{
+				"id": "123", "title": "Bug page", "status": "current",
+				"version": map[string]any{"number": 4},
+				"body":    map[string]any{"storage": map[string]any{"value": storage, "representation": "storage"}},
+			})
+			w.Write(body)
+		case r.URL.Path == "/api/v2/pages/123":
+			assert.Equal(t, "atlas_doc_format", r.URL.Query().Get("body-format"))
+			adf := `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"Hello"}]}]}`
+			body, _ := json.Marshal(map[string]any{
+				"id": "123", "title": "Bug page", "status": "current",
+				"version": map[string]any{"number": 4},
+				"body":    map[string]any{"atlas_doc_format": map[string]any{"value": adf, "representation": "atlas_doc_format"}},
+			})
+			w.Write(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not found"}`))
+		}
+	}))
+	return server
+}
+
+func dumpTestPage(t *testing.T) (string, string) {
+	server := mockPageServer(t)
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "bug.json")
+	client := api.NewClient(server.URL, "test@example.com", "s3cret")
+	opts := &dumpOptions{
+		file:    path,
+		secrets: []string{"s3cret", "test@example.com"},
+		now:     func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) },
+	}
+	require.NoError(t, runDumpPage("123", opts, client))
+	return path, server.URL
+}
+
+func TestRunDumpPage(t *testing.T) {
+	path, serverURL := dumpTestPage(t)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "s3cret")
+	assert.NotContains(t, string(data), strings.TrimPrefix(serverURL, "http://"))
+
+	var f fixture
+	require.NoError(t, json.Unmarshal(data, &f))
+	assert.Equal(t, fixtureFormatVersion, f.FormatVersion)
+	assert.Equal(t, "123", f.Page.ID)
+	assert.Equal(t, 4, f.Page.Version)
+	assert.Contains(t, f.Storage, redactedSite+"/wiki/x/abc")
+	assert.Contains(t, f.Storage, "token REDACTED")
+	assert.Contains(t, f.ADF, `"Hello"`)
+
+	// Storage and ADF, each with and without macros
+	require.Len(t, f.Conversions, 4)
+	byKey := make(map[string]conversion)
+	for _, c := range f.Conversions {
+		byKey[conversionKey(c)] = c
+	}
+	assert.NotContains(t, byKey["storage/false"].Markdown, "TOC")
+	assert.Contains(t, byKey["storage/true"].Markdown, "TOC")
+	assert.Contains(t, byKey["adf/false"].Markdown, "Hello")
+}
+
+func TestRunDumpPage_Stdout(t *testing.T) {
+	server := mockPageServer(t)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	client := api.NewClient(server.URL, "test@example.com", "s3cret")
+	require.NoError(t, runDumpPage("123", &dumpOptions{stdout: &stdout}, client))
+
+	var f fixture
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &f))
+	assert.Equal(t, "Bug page", f.Page.Title)
+}
+
+func TestRunConvert(t *testing.T) {
+	path, _ := dumpTestPage(t)
+
+	var stdout bytes.Buffer
+	require.NoError(t, runConvert(path, &convertOptions{noColor: true, stdout: &stdout}, nil))
+	out := stdout.String()
+	assert.Contains(t, out, `Page 123 "Bug page"`)
+	assert.Contains(t, out, "=== storage (macros hidden): unchanged")
+	assert.Contains(t, out, "=== adf (macros shown): unchanged")
+	assert.Contains(t, out, "Hello")
+}
+
+func TestRunConvert_Changed(t *testing.T) {
+	path, _ := dumpTestPage(t)
+
+	// Simulate a file recorded by a build that converted differently
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var f fixture
+	require.NoError(t, json.Unmarshal(data, &f))
+	for i := range f.Conversions {
+		f.Conversions[i].Markdown = "old output"
+	}
+	data, err = json.Marshal(f)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	var stdout bytes.Buffer
+	showMacros := true
+	opts := &convertOptions{input: "storage", output: "json", stdout: &stdout}
+	require.NoError(t, runConvert(path, opts, &showMacros))
+
+	var results []convertResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &results))
+	require.Len(t, results, 1)
+	assert.Equal(t, "storage", results[0].Input)
+	assert.True(t, results[0].ShowMacros)
+	assert.True(t, results[0].Changed)
+	assert.Equal(t, "old output", results[0].Recorded)
+}
+
+func TestRunConvert_Errors(t *testing.T) {
+	dir := t.TempDir()
+	future := filepath.Join(dir, "future.json")
+	require.NoError(t, os.WriteFile(future, []byte(`{"formatVersion": 99}`), 0o600))
+	noADF := filepath.Join(dir, "storage.json")
+	require.NoError(t, os.WriteFile(noADF, []byte(`{"formatVersion": 1, "storage": "<p>x</p>"}`), 0o600))
+
+	tests := []struct {
+		name    string
+		path    string
+		opts    *convertOptions
+		wantErr string
+	}{
+		{name: "missing file", path: filepath.Join(dir, "none.json"), opts: &convertOptions{}, wantErr: "failed to read file"},
+		{name: "unsupported version", path: future, opts: &convertOptions{}, wantErr: "unsupported file format version 99"},
+		{name: "invalid input", path: noADF, opts: &convertOptions{input: "html"}, wantErr: "invalid input"},
+		{name: "no body", path: noADF, opts: &convertOptions{input: "adf"}, wantErr: "has no adf body"},
+		{name: "invalid output", path: noADF, opts: &convertOptions{output: "xml"}, wantErr: "invalid output format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runConvert(tt.path, tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestNewRedactor(t *testing.T) {
+	r := newRedactor("https://acme.atlassian.net", []string{"tok", "", "me@acme.com"})
+	got := r.Replace("https://acme.atlassian.net/wiki acme.atlassian.net tok me@acme.com")
+	assert.Equal(t, "https://example.atlassian.net/wiki example.atlassian.net REDACTED REDACTED", got)
+}
@@ -0,0 +1,158 @@
+package debugcmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/version"
+)
+
+// redactedSite replaces the Confluence site URL in dumped bodies.
+const redactedSite = "https://example.atlassian.net"
+
+type dumpOptions struct {
+	file    string
+	secrets []string  // Values replaced in the dump, such as the API token
+	stdout  io.Writer // For testing; defaults to os.Stdout
+	now     func() time.Time
+}
+
+// NewCmdDumpPage creates the debug dump-page command.
+func NewCmdDumpPage() *cobra.Command {
+	opts := &dumpOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "dump-page <page>",
+		Short: "Save a page's bodies and conversions for a bug report",
+		Long: `Save what cfl needs to reproduce a conversion bug into one JSON file: the
+page's storage and ADF bodies, the markdown each converts to with and without
+--show-macros, conversion errors and warnings, and the page's version and the
+cfl build.
+
+Your API token and email and the site URL are replaced wherever they appear,
+and no credentials are written. The page content itself is included, so
+check the file before attaching it to a public issue.
+
+The page can be ` + pageref.Usage + `.`,
+		Example: `  # Save a page for a bug report
+  cfl debug dump-page 12345 --file bug.json
+
+  # Reproduce it
+  cfl debug convert bug.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runDumpPage(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "File to write (default: stdout)")
+
+	return cmd
+}
+
+func runDumpPage(pageRef string, opts *dumpOptions, client *api.Client) error {
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		opts.secrets = append(opts.secrets, cfg.APIToken, cfg.Email)
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pageID, err := pageref.Resolve(ctx, client, pageRef)
+	if err != nil {
+		return err
+	}
+
+	page, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: "storage"})
+	if err != nil {
+		return fmt.Errorf("failed to get page: %w", err)
+	}
+	adfPage, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: "atlas_doc_format"})
+	if err != nil {
+		return fmt.Errorf("failed to get page: %w", err)
+	}
+	// The editor is only recorded for pages edited with cfl or the cloud editor
+	editor, _ := client.GetPageEditor(ctx, pageID)
+
+	now := time.Now
+	if opts.now != nil {
+		now = opts.now
+	}
+	redact := newRedactor(client.BaseURL(), opts.secrets)
+	f := &fixture{
+		FormatVersion: fixtureFormatVersion,
+		Created:       now().UTC(),
+		CFL:           version.Get(),
+		Page: fixturePage{
+			ID:     page.ID,
+			Title:  redact.Replace(page.Title),
+			Status: page.Status,
+			Editor: editor,
+		},
+	}
+	if page.Version != nil {
+		f.Page.Version = page.Version.Number
+	}
+	if page.Body != nil && page.Body.Storage != nil {
+		f.Storage = redact.Replace(page.Body.Storage.Value)
+	}
+	if adfPage.Body != nil && adfPage.Body.AtlasDocFormat != nil {
+		f.ADF = redact.Replace(adfPage.Body.AtlasDocFormat.Value)
+	}
+	// Convert the redacted bodies, so convert reproduces the recorded output
+	f.Conversions = convertBodies(f)
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if opts.file == "" {
+		stdout := opts.stdout
+		if stdout == nil {
+			stdout = os.Stdout
+		}
+		_, err := stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(opts.file, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Saved page %s to %s\n", page.ID, opts.file)
+	return nil
+}
+
+// newRedactor returns a replacer for the site URL and secrets.
+func newRedactor(baseURL string, secrets []string) *strings.Replacer {
+	var pairs []string
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		pairs = append(pairs, u.Scheme+"://"+u.Host, redactedSite, u.Host, strings.TrimPrefix(redactedSite, "https://"))
+	}
+	for _, s := range secrets {
+		if s != "" {
+			pairs = append(pairs, s, "REDACTED")
+		}
+	}
+	return strings.NewReplacer(pairs...)
+}
@@ -0,0 +1,19 @@
+// Package generate provides commands that generate page content.
+package generate
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmdGenerate creates the generate command.
+func NewCmdGenerate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate page content",
+		Long:  `Commands for generating and maintaining pages built from other content.`,
+	}
+
+	cmd.AddCommand(NewCmdIndex())
+
+	return cmd
+}
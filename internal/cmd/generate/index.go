@@ -0,0 +1,350 @@
+package generate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// Index grouping modes.
+const (
+	groupByLabel  = "label"
+	groupByParent = "parent"
+)
+
+// Group headings for pages that have no label or parent.
+const (
+	unlabeledGroup = "Unlabeled"
+	topLevelGroup  = "Top level"
+)
+
+// indexMessagePrefix starts the version message of a generated index. The
+// content hash that follows lets unchanged indexes be skipped.
+const indexMessagePrefix = "Generated index via cfl"
+
+type indexOptions struct {
+	space   string
+	pageID  string
+	groupBy string
+	labels  []string
+	legacy  bool
+	dryRun  bool
+	output  string
+	noColor bool
+
+	legacyAuto   bool     // --legacy wasn't given, so the space decides the editor format
+	legacySpaces []string // Spaces written in legacy format by default; loaded from config
+}
+
+// indexResult describes a generated index for JSON output.
+type indexResult struct {
+	PageID  string              `json:"pageId"`
+	Updated bool                `json:"updated"`
+	Groups  map[string][]string `json:"groups"`
+}
+
+// NewCmdIndex creates the generate index command.
+func NewCmdIndex() *cobra.Command {
+	opts := &indexOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "index",
+		Short: "Generate an index page of a space's pages",
+		Long: `Write an index of a space's pages to an existing page, with links grouped
+under a heading per label or per parent page.
+
+The index is regenerated idempotently: the page is only updated when the
+generated content changes, so the command is safe to run on a schedule.
+The index page replaces its whole body, so keep it dedicated to the index.
+
+With --group-by label, a page appears under each of its labels and pages
+without labels are listed under "Unlabeled". Use --label to only include
+some labels.
+
+The index is written in the cloud editor format unless --legacy is given or
+the space is listed under legacy_spaces in the config file.`,
+		Example: `  # Index grouped by label
+  cfl generate index --space DOCS --page 12345
+
+  # Only the howto and reference labels
+  cfl generate index --space DOCS --page 12345 --label howto --label reference
+
+  # Index grouped by parent page
+  cfl generate index --space DOCS --page 12345 --group-by parent
+
+  # Preview the generated markdown
+  cfl generate index --space DOCS --page 12345 --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.legacyAuto = !cmd.Flags().Changed("legacy")
+			return runIndex(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key (default: from config)")
+	cmd.Flags().StringVar(&opts.pageID, "page", "", "Page to write the index to: ID, URL, or SPACE/Title (required)")
+	cmd.Flags().StringVar(&opts.groupBy, "group-by", groupByLabel, "Group pages by: label or parent")
+	cmd.Flags().StringSliceVar(&opts.labels, "label", nil, "Only include these labels (repeatable)")
+	cmd.Flags().BoolVar(&opts.legacy, "legacy", false, "Write the index in legacy editor format (default: cloud editor)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print the generated markdown without updating the page")
+
+	_ = cmd.MarkFlagRequired("page")
+
+	return cmd
+}
+
+func runIndex(opts *indexOptions, client *api.Client) error {
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.groupBy != groupByLabel && opts.groupBy != groupByParent {
+		return fmt.Errorf("invalid --group-by %q: must be %s or %s", opts.groupBy, groupByLabel, groupByParent)
+	}
+	if opts.groupBy != groupByLabel && len(opts.labels) > 0 {
+		return fmt.Errorf("--label can only be used with --group-by %s", groupByLabel)
+	}
+
+	spaceKey := opts.space
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if spaceKey == "" {
+			spaceKey = cfg.DefaultSpace
+		}
+		opts.legacySpaces = cfg.LegacySpaces
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, opts.pageID)
+	if err != nil {
+		return err
+	}
+	opts.pageID = pageID
+
+	if spaceKey == "" {
+		return fmt.Errorf("space is required: use --space flag or set default_space in config")
+	}
+	if opts.legacyAuto {
+		opts.legacy = config.IsLegacySpace(opts.legacySpaces, spaceKey)
+	}
+
+	ctx := context.Background()
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	pages, err := api.ListAll(client.PagesIter(ctx, space.ID, &api.ListPagesOptions{Limit: 250, Status: "current"}))
+	if err != nil {
+		return fmt.Errorf("failed to list pages: %w", err)
+	}
+
+	var groups map[string][]api.Page
+	if opts.groupBy == groupByParent {
+		groups = groupByParents(pages, opts.pageID)
+	} else {
+		groups, err = groupByLabels(ctx, client, pages, opts.pageID, opts.labels)
+		if err != nil {
+			return err
+		}
+	}
+
+	markdown := indexMarkdown(groups, client.BaseURL())
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if opts.dryRun {
+		renderer.RenderText(markdown)
+		return nil
+	}
+
+	target, err := client.GetPage(ctx, opts.pageID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get index page: %w", err)
+	}
+
+	message := indexMessage(markdown)
+	result := indexResult{PageID: target.ID, Groups: groupPageIDs(groups)}
+
+	if target.Version == nil || target.Version.Message != message {
+		body, err := indexBody(markdown, opts.legacy)
+		if err != nil {
+			return err
+		}
+		version := 1
+		if target.Version != nil {
+			version = target.Version.Number
+		}
+		_, err = client.UpdatePage(ctx, target.ID, &api.UpdatePageRequest{
+			ID:     target.ID,
+			Status: "current",
+			Title:  target.Title,
+			Body:   body,
+			Version: &api.Version{
+				Number:  version + 1,
+				Message: message,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update index page: %w", err)
+		}
+		result.Updated = true
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(result)
+	}
+	if !result.Updated {
+		renderer.Success(fmt.Sprintf("Index page %s is up to date", target.Title))
+		return nil
+	}
+	renderer.Success(fmt.Sprintf("Updated index page: %s (%d groups)", target.Title, len(groups)))
+	return nil
+}
+
+// groupByLabels groups pages under each of their labels, leaving out the
+// index page. Without a label filter, unlabeled pages get their own group.
+func groupByLabels(ctx context.Context, client *api.Client, pages []api.Page, indexID string, only []string) (map[string][]api.Page, error) {
+	include := make(map[string]bool)
+	for _, l := range only {
+		include[strings.ToLower(l)] = true
+	}
+
+	groups := make(map[string][]api.Page)
+	for _, p := range pages {
+		if p.ID == indexID {
+			continue
+		}
+		labels, err := api.ListAll(client.PageLabelsIter(ctx, p.ID, &api.ListLabelsOptions{Limit: 200, Prefix: "global"}))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list labels of %s: %w", p.ID, err)
+		}
+
+		grouped := false
+		for _, l := range labels {
+			if len(include) > 0 && !include[strings.ToLower(l.Name)] {
+				continue
+			}
+			groups[l.Name] = append(groups[l.Name], p)
+			grouped = true
+		}
+		if !grouped && len(include) == 0 {
+			groups[unlabeledGroup] = append(groups[unlabeledGroup], p)
+		}
+	}
+	return groups, nil
+}
+
+// groupByParents groups pages under their parent page's title, leaving out
+// the index page.
+func groupByParents(pages []api.Page, indexID string) map[string][]api.Page {
+	titles := make(map[string]string)
+	for _, p := range pages {
+		titles[p.ID] = p.Title
+	}
+
+	groups := make(map[string][]api.Page)
+	for _, p := range pages {
+		if p.ID == indexID {
+			continue
+		}
+		group, ok := titles[p.ParentID]
+		if !ok {
+			group = topLevelGroup
+		}
+		groups[group] = append(groups[group], p)
+	}
+	return groups
+}
+
+// indexMarkdown renders groups as markdown: a heading per group, in
+// alphabetical order, listing links to its pages by title.
+func indexMarkdown(groups map[string][]api.Page, baseURL string) string {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		// Catch-all groups go last
+		if catchAll(names[i]) != catchAll(names[j]) {
+			return catchAll(names[j])
+		}
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+
+	var sb strings.Builder
+	sb.WriteString("_This page is generated by `cfl generate index`. Manual edits will be overwritten._\n")
+	for _, name := range names {
+		pages := groups[name]
+		sort.Slice(pages, func(i, j int) bool {
+			return strings.ToLower(pages[i].Title) < strings.ToLower(pages[j].Title)
+		})
+
+		fmt.Fprintf(&sb, "\n## %s\n\n", name)
+		for _, p := range pages {
+			fmt.Fprintf(&sb, "- [%s](%s)\n", md.EscapeLinkText(p.Title), baseURL+p.Links.WebUI)
+		}
+	}
+	return sb.String()
+}
+
+// catchAll reports whether a group collects pages without a label or parent.
+func catchAll(group string) bool {
+	return group == unlabeledGroup || group == topLevelGroup
+}
+
+// indexMessage returns the version message for an index, identifying its content.
+func indexMessage(markdown string) string {
+	sum := sha256.Sum256([]byte(markdown))
+	return fmt.Sprintf("%s (%s)", indexMessagePrefix, hex.EncodeToString(sum[:])[:12])
+}
+
+// indexBody converts index markdown to a page body.
+func indexBody(markdown string, legacy bool) (*api.Body, error) {
+	if legacy {
+		storage, err := md.ToConfluenceStorage([]byte(markdown))
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert markdown: %w", err)
+		}
+		return &api.Body{Storage: &api.BodyRepresentation{Representation: "storage", Value: storage}}, nil
+	}
+
+	adf, err := md.ToADF([]byte(markdown))
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert markdown to ADF: %w", err)
+	}
+	return &api.Body{AtlasDocFormat: &api.BodyRepresentation{Representation: "atlas_doc_format", Value: adf}}, nil
+}
+
+// groupPageIDs lists the page IDs in each group.
+func groupPageIDs(groups map[string][]api.Page) map[string][]string {
+	ids := make(map[string][]string, len(groups))
+	for name, pages := range groups {
+		for _, p := range pages {
+			ids[name] = append(ids[name], p.ID)
+		}
+	}
+	return ids
+}
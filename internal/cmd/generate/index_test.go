@@ -0,0 +1,143 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// indexServer serves a space with a few labelled pages and an index page,
+// recording updates to the index page.
+type indexServer struct {
+	message string
+	updates []api.UpdatePageRequest
+}
+
+func newIndexServer(t *testing.T) (*indexServer, *api.Client) {
+	s := &indexServer{}
+	labels := map[string]string{
+		"1": `[{"id": "l1", "name": "howto"}, {"id": "l2", "name": "reference"}]`,
+		"2": `[{"id": "l1", "name": "howto"}]`,
+		"3": `[]`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "777", "key": "DOCS"}]}`))
+		case r.URL.Path == "/api/v2/spaces/777/pages":
+			w.Write([]byte(`{"results": [
+				{"id": "1", "title": "Install", "parentId": "9", "_links": {"webui": "/spaces/DOCS/pages/1"}},
+				{"id": "2", "title": "Configure", "parentId": "3", "_links": {"webui": "/spaces/DOCS/pages/2"}},
+				{"id": "3", "title": "Guide", "parentId": "9", "_links": {"webui": "/spaces/DOCS/pages/3"}},
+				{"id": "9", "title": "Index", "_links": {"webui": "/spaces/DOCS/pages/9"}}
+			]}`))
+		case strings.HasSuffix(r.URL.Path, "/labels"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v2/pages/"), "/labels")
+			fmt.Fprintf(w, `{"results": %s}`, labels[id])
+		case r.URL.Path == "/api/v2/pages/9" && r.Method == http.MethodGet:
+			fmt.Fprintf(w, `{"id": "9", "title": "Index", "version": {"number": 3, "message": %q}}`, s.message)
+		case r.URL.Path == "/api/v2/pages/9" && r.Method == http.MethodPut:
+			var req api.UpdatePageRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			s.updates = append(s.updates, req)
+			s.message = req.Version.Message
+			w.Write([]byte(`{"id": "9", "title": "Index"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return s, api.NewClient(server.URL, "test@example.com", "token")
+}
+
+func TestRunIndex_GroupByLabel(t *testing.T) {
+	s, client := newIndexServer(t)
+
+	err := runIndex(&indexOptions{space: "DOCS", pageID: "9", groupBy: groupByLabel, legacy: true, noColor: true}, client)
+	require.NoError(t, err)
+
+	require.Len(t, s.updates, 1)
+	update := s.updates[0]
+	assert.Equal(t, "Index", update.Title)
+	assert.Equal(t, 4, update.Version.Number)
+	assert.True(t, strings.HasPrefix(update.Version.Message, indexMessagePrefix))
+
+	storage := update.Body.Storage.Value
+	assert.NotContains(t, storage, "pages/9")
+	howto := strings.Index(storage, "howto")
+	reference := strings.Index(storage, "reference")
+	unlabeled := strings.Index(storage, "Unlabeled")
+	assert.True(t, howto < reference && reference < unlabeled, "groups sorted with Unlabeled last")
+	assert.Less(t, strings.Index(storage, "Configure"), strings.Index(storage, "Install"))
+}
+
+func TestRunIndex_Unchanged(t *testing.T) {
+	s, client := newIndexServer(t)
+	opts := &indexOptions{space: "DOCS", pageID: "9", groupBy: groupByLabel, legacy: true, noColor: true}
+
+	require.NoError(t, runIndex(opts, client))
+	require.NoError(t, runIndex(opts, client))
+
+	assert.Len(t, s.updates, 1)
+}
+
+func TestIndexMarkdown_GroupByParent(t *testing.T) {
+	pages := []api.Page{
+		{ID: "1", Title: "Install", ParentID: "3"},
+		{ID: "2", Title: "Guide", ParentID: "9"},
+		{ID: "3", Title: "Setup"},
+		{ID: "9", Title: "Index"},
+	}
+	pages[0].Links.WebUI = "/p/1"
+	pages[1].Links.WebUI = "/p/2"
+	pages[2].Links.WebUI = "/p/3"
+
+	got := indexMarkdown(groupByParents(pages, "9"), "https://example.com/wiki")
+
+	assert.Equal(t, "_This page is generated by `cfl generate index`. Manual edits will be overwritten._\n"+
+		"\n## Index\n\n- [Guide](https://example.com/wiki/p/2)\n"+
+		"\n## Setup\n\n- [Install](https://example.com/wiki/p/1)\n"+
+		"\n## Top level\n\n- [Setup](https://example.com/wiki/p/3)\n", got)
+}
+
+func TestRunIndex_LabelFilter(t *testing.T) {
+	s, client := newIndexServer(t)
+
+	err := runIndex(&indexOptions{space: "DOCS", pageID: "9", groupBy: groupByLabel, labels: []string{"Reference"}, legacy: true, noColor: true}, client)
+	require.NoError(t, err)
+
+	require.Len(t, s.updates, 1)
+	storage := s.updates[0].Body.Storage.Value
+	assert.Contains(t, storage, "Install")
+	assert.NotContains(t, storage, "Configure")
+	assert.NotContains(t, storage, "Unlabeled")
+}
+
+func TestRunIndex_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *indexOptions
+		wantErr string
+	}{
+		{"bad group-by", &indexOptions{pageID: "9", groupBy: "author"}, "invalid --group-by"},
+		{"label with parent", &indexOptions{pageID: "9", groupBy: groupByParent, labels: []string{"x"}}, "--label can only be used"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runIndex(tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
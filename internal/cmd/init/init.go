@@ -2,6 +2,7 @@
 package init
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,6 +11,8 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/whoami"
 	"github.com/open-cli-collective/confluence-cli/internal/config"
 )
 
@@ -19,6 +22,7 @@ func NewCmdInit() *cobra.Command {
 		url      string
 		email    string
 		noVerify bool
+		verify   bool
 	)
 
 	cmd := &cobra.Command{
@@ -29,6 +33,14 @@ func NewCmdInit() *cobra.Command {
 This command will guide you through setting up your Confluence URL,
 email, and API token. The configuration will be saved to ~/.config/cfl/config.yml.
 
+With --profile, the site is saved as a named profile (under contexts: in
+the config file) instead of replacing the top-level settings, so several
+sites can be configured side by side.
+
+With --verify, nothing is asked or saved: the existing configuration (or
+profile, with --profile) signs in and the account is shown, failing when
+the credentials don't work.
+
 To generate an API token:
   1. Go to https://id.atlassian.com/manage-profile/security/api-tokens
   2. Click "Create API token"
@@ -37,28 +49,57 @@ To generate an API token:
   cfl init
 
   # Pre-populate URL
-  cfl init --url https://mycompany.atlassian.net`,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			return runInit(url, email, noVerify)
+  cfl init --url https://mycompany.atlassian.net
+
+  # Add a second site as the "work" profile
+  cfl init --profile work
+
+  # Check the saved configuration still signs in
+  cfl init --verify`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if verify {
+				return runVerify(nil, nil)
+			}
+			profile, _ := cmd.Flags().GetString("profile")
+			return runInit(url, email, noVerify, profile)
 		},
 	}
 
 	cmd.Flags().StringVar(&url, "url", "", "Confluence URL (e.g., https://mycompany.atlassian.net)")
 	cmd.Flags().StringVar(&email, "email", "", "Your Atlassian account email")
 	cmd.Flags().BoolVar(&noVerify, "no-verify", false, "Skip connection verification")
+	cmd.Flags().BoolVar(&verify, "verify", false, "Check the existing configuration signs in, without changing it")
+
+	cmd.MarkFlagsMutuallyExclusive("verify", "no-verify")
+	cmd.MarkFlagsMutuallyExclusive("verify", "url")
+	cmd.MarkFlagsMutuallyExclusive("verify", "email")
 
 	return cmd
 }
 
-func runInit(prefillURL, prefillEmail string, noVerify bool) error {
+func runInit(prefillURL, prefillEmail string, noVerify bool, profile string) error {
+	if config.NoConfig() {
+		return fmt.Errorf("%w: set CFL_URL, CFL_EMAIL and CFL_API_TOKEN instead", config.ErrNoConfig)
+	}
+
 	configPath := config.DefaultConfigPath()
 
-	// Check if config already exists
-	if _, err := os.Stat(configPath); err == nil {
+	// Check if config (or the profile being added) already exists
+	title, description := "Configuration already exists", fmt.Sprintf("Overwrite %s?", configPath)
+	_, err := os.Stat(configPath)
+	exists := err == nil
+	if profile != "" {
+		exists = false
+		if existing, err := config.Load(configPath); err == nil {
+			_, exists = existing.Contexts[profile]
+		}
+		title, description = "Profile already exists", fmt.Sprintf("Overwrite profile %q?", profile)
+	}
+	if exists {
 		var overwrite bool
 		err := huh.NewConfirm().
-			Title("Configuration already exists").
-			Description(fmt.Sprintf("Overwrite %s?", configPath)).
+			Title(title).
+			Description(description).
 			Value(&overwrite).
 			Run()
 		if err != nil {
@@ -150,7 +191,12 @@ func runInit(prefillURL, prefillEmail string, noVerify bool) error {
 	}
 
 	// Save configuration
-	if err := cfg.Save(configPath); err != nil {
+	if profile != "" {
+		err = saveProfile(configPath, profile, cfg)
+	} else {
+		err = cfg.Save(configPath)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -162,6 +208,61 @@ func runInit(prefillURL, prefillEmail string, noVerify bool) error {
 	return nil
 }
 
+// saveProfile adds the site in cfg to the config file as the named profile,
+// keeping the rest of the file. The profile becomes the default if there
+// are no top-level settings to fall back on.
+func saveProfile(configPath, name string, cfg *config.Config) error {
+	file, err := config.Load(configPath)
+	if err != nil {
+		file = &config.Config{}
+	}
+
+	if file.Contexts == nil {
+		file.Contexts = make(map[string]config.Profile)
+	}
+	file.Contexts[name] = config.Profile{
+		URL:          cfg.URL,
+		Email:        cfg.Email,
+		APIToken:     cfg.APIToken,
+		DefaultSpace: cfg.DefaultSpace,
+	}
+	if file.URL == "" && file.CurrentContext == "" {
+		file.CurrentContext = name
+	}
+
+	return file.Save(configPath)
+}
+
+// runVerify signs in with the existing configuration and shows the account.
+func runVerify(cfg *config.Config, client *api.Client) error {
+	// Load config if not provided (allows injection for testing)
+	if cfg == nil {
+		var err error
+		cfg, err = config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+	}
+	if client == nil {
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	identity, err := whoami.Lookup(context.Background(), client)
+	if err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+
+	account := identity.DisplayName
+	if identity.Email != "" {
+		account += " <" + identity.Email + ">"
+	}
+	fmt.Printf("Signed in to %s as %s\n", identity.Site, account)
+	return nil
+}
+
 func verifyConnection(cfg *config.Config) error {
 	client := &http.Client{Timeout: 10 * time.Second}
 
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/open-cli-collective/confluence-cli/api"
 	"github.com/open-cli-collective/confluence-cli/internal/config"
 )
 
@@ -226,6 +227,29 @@ func TestConfigFilePermissions_DirectoryCreation(t *testing.T) {
 	assert.True(t, dirInfo.IsDir())
 }
 
+func TestSaveProfile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	site := &config.Config{URL: "https://work.atlassian.net/wiki", Email: "me@work.example", APIToken: "work-token"}
+
+	// The first profile in an empty config becomes the default
+	require.NoError(t, saveProfile(configPath, "work", site))
+	cfg, err := config.Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "work", cfg.CurrentContext)
+	assert.Equal(t, "work-token", cfg.Contexts["work"].APIToken)
+
+	// Top-level settings and other profiles are kept
+	cfg.URL = "https://default.atlassian.net/wiki"
+	require.NoError(t, cfg.Save(configPath))
+	require.NoError(t, saveProfile(configPath, "personal", &config.Config{URL: "https://me.atlassian.net/wiki"}))
+
+	cfg, err = config.Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "https://default.atlassian.net/wiki", cfg.URL)
+	assert.Equal(t, "work", cfg.CurrentContext)
+	assert.Len(t, cfg.Contexts, 2)
+}
+
 func TestNewCmdInit_Flags(t *testing.T) {
 	cmd := NewCmdInit()
 
@@ -246,4 +270,43 @@ func TestNewCmdInit_Flags(t *testing.T) {
 	noVerifyFlag := cmd.Flags().Lookup("no-verify")
 	require.NotNil(t, noVerifyFlag)
 	assert.Equal(t, "false", noVerifyFlag.DefValue)
+
+	verifyFlag := cmd.Flags().Lookup("verify")
+	require.NotNil(t, verifyFlag)
+	assert.Equal(t, "false", verifyFlag.DefValue)
+}
+
+func TestRunVerify(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		user    string
+		wantErr string
+	}{
+		{"signed in", http.StatusOK, `{"type": "known", "accountId": "5b10", "displayName": "Test User"}`, ""},
+		{"rejected", http.StatusUnauthorized, `{"message": "Unauthorized"}`, "verification failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/user/current" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.user))
+			}))
+			defer server.Close()
+
+			cfg := &config.Config{URL: server.URL, Email: "test@example.com", APIToken: "test-token"}
+			err := runVerify(cfg, api.NewClient(cfg.URL, cfg.Email, cfg.APIToken))
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
 }
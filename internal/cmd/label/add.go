@@ -0,0 +1,101 @@
+package label
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type addOptions struct {
+	output  string
+	noColor bool
+}
+
+// NewCmdAdd creates the label add command.
+func NewCmdAdd() *cobra.Command {
+	opts := &addOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "add <page-id> <label>...",
+		Short: "Add labels to a page",
+		Long: `Add one or more labels to a Confluence page.
+
+Labels the page already has are left unchanged. Labels cannot contain
+spaces; Confluence stores them in lowercase.`,
+		Example: `  # Add a label
+  cfl label add 12345 release-notes
+
+  # Add several labels
+  cfl label add 12345 release-notes v2 public`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runAdd(args[0], args[1:], opts, nil)
+		},
+	}
+
+	return cmd
+}
+
+func runAdd(pageID string, names []string, opts *addOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Validate before loading config so typos fail fast
+	for _, name := range names {
+		if err := api.ValidateLabel(name); err != nil {
+			return err
+		}
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
+	}
+
+	labels, err := client.AddLabels(context.Background(), pageID, names)
+	if err != nil {
+		return fmt.Errorf("failed to add labels: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(labels)
+	}
+
+	renderer.Success(fmt.Sprintf("Added %s to page %s", labelList(names), pageID))
+	return nil
+}
+
+// labelList formats label names for messages.
+func labelList(names []string) string {
+	if len(names) == 1 {
+		return "label " + names[0]
+	}
+	return "labels " + strings.Join(names, ", ")
+}
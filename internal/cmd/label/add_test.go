@@ -0,0 +1,43 @@
+package label
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunAdd(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/rest/api/content/12345/label", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"results": [{"id": "1", "name": "release", "prefix": "global"}]}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runAdd("12345", []string{"release"}, &addOptions{noColor: true}, client)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"prefix": "global", "name": "release"}]`, received)
+}
+
+func TestRunAdd_InvalidLabel(t *testing.T) {
+	client := api.NewClient("http://unused", "test@example.com", "token")
+	err := runAdd("12345", []string{"release", "bad label"}, &addOptions{}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid label")
+}
+
+func TestLabelList(t *testing.T) {
+	assert.Equal(t, "label a", labelList([]string{"a"}))
+	assert.Equal(t, "labels a, b", labelList([]string{"a", "b"}))
+}
@@ -0,0 +1,22 @@
+// Package label provides page label commands.
+package label
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmdLabel creates the label command.
+func NewCmdLabel() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "label",
+		Aliases: []string{"labels"},
+		Short:   "Manage page labels",
+		Long:    `Commands for listing, adding, and removing the labels on Confluence pages.`,
+	}
+
+	cmd.AddCommand(NewCmdList())
+	cmd.AddCommand(NewCmdAdd())
+	cmd.AddCommand(NewCmdRemove())
+
+	return cmd
+}
@@ -0,0 +1,96 @@
+package label
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type listOptions struct {
+	output  string
+	noColor bool
+}
+
+// NewCmdList creates the label list command.
+func NewCmdList() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list <page-id>",
+		Aliases: []string{"ls"},
+		Short:   "List labels on a page",
+		Long:    `List all labels on a Confluence page.`,
+		Example: `  # List labels on a page
+  cfl label list 12345
+
+  # Output as JSON
+  cfl label list 12345 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runList(args[0], opts, nil)
+		},
+	}
+
+	return cmd
+}
+
+func runList(pageID string, opts *listOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
+	}
+
+	labels, err := client.GetLabels(context.Background(), pageID)
+	if err != nil {
+		return fmt.Errorf("failed to list labels: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		if labels == nil {
+			labels = []api.Label{}
+		}
+		return renderer.RenderJSON(labels)
+	}
+
+	if len(labels) == 0 {
+		fmt.Println("No labels found.")
+		return nil
+	}
+
+	headers := []string{"NAME", "PREFIX", "ID"}
+	var rows [][]string
+	for _, l := range labels {
+		rows = append(rows, []string{l.Name, l.Prefix, l.ID})
+	}
+	renderer.RenderTable(headers, rows)
+	return nil
+}
@@ -0,0 +1,52 @@
+package label
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunList(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		output   string
+	}{
+		{"table", `{"results": [{"id": "1", "name": "release", "prefix": "global"}]}`, ""},
+		{"json", `{"results": [{"id": "1", "name": "release", "prefix": "global"}]}`, "json"},
+		{"empty", `{"results": []}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v2/pages/12345/labels", r.URL.Path)
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			err := runList("12345", &listOptions{output: tt.output, noColor: true}, client)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRunList_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Page not found"}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runList("12345", &listOptions{}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list labels")
+}
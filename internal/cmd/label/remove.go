@@ -0,0 +1,88 @@
+package label
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type removeOptions struct {
+	output  string
+	noColor bool
+}
+
+// NewCmdRemove creates the label remove command.
+func NewCmdRemove() *cobra.Command {
+	opts := &removeOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "remove <page-id> <label>...",
+		Aliases: []string{"rm"},
+		Short:   "Remove labels from a page",
+		Long:    `Remove one or more labels from a Confluence page.`,
+		Example: `  # Remove a label
+  cfl label remove 12345 draft
+
+  # Remove several labels
+  cfl label remove 12345 draft wip`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runRemove(args[0], args[1:], opts, nil)
+		},
+	}
+
+	return cmd
+}
+
+func runRemove(pageID string, names []string, opts *removeOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if err := client.RemoveLabel(context.Background(), pageID, name); err != nil {
+			return fmt.Errorf("failed to remove label %s: %w", name, err)
+		}
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]interface{}{
+			"status":  "removed",
+			"page_id": pageID,
+			"labels":  names,
+		})
+	}
+
+	renderer.Success(fmt.Sprintf("Removed %s from page %s", labelList(names), pageID))
+	return nil
+}
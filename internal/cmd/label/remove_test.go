@@ -0,0 +1,41 @@
+package label
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunRemove(t *testing.T) {
+	var removed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/rest/api/content/12345/label", r.URL.Path)
+		removed = append(removed, r.URL.Query().Get("name"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runRemove("12345", []string{"draft", "wip"}, &removeOptions{output: "json", noColor: true}, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"draft", "wip"}, removed)
+}
+
+func TestRunRemove_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Label not found"}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runRemove("12345", []string{"draft"}, &removeOptions{}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to remove label draft")
+}
@@ -0,0 +1,546 @@
+package links
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+// Kinds of links.
+const (
+	kindPage = "page"
+	kindURL  = "url"
+)
+
+var (
+	// pageLink matches a link to a page by title, capturing the reference's
+	// attributes.
+	pageLink = regexp.MustCompile(`<ac:link\b[^>]*>\s*<ri:page\b([^>]*?)/?>`)
+
+	// refAttr matches an attribute of a resource reference.
+	refAttr = regexp.MustCompile(`ri:(content-title|space-key)="([^"]*)"`)
+
+	// hrefAttr matches the target of an HTML link.
+	hrefAttr = regexp.MustCompile(`<a\b[^>]*?\bhref="([^"]+)"`)
+
+	// pageURLID matches the page ID in the path of a Confluence page URL.
+	pageURLID = regexp.MustCompile(`/pages/(\d+)(?:/|$)`)
+)
+
+type checkOptions struct {
+	space       string
+	external    bool
+	concurrency int
+	timeout     time.Duration
+	junit       string
+	output      string
+	noColor     bool
+	out         io.Writer    // injectable for testing
+	httpClient  *http.Client // injectable for testing; checks external URLs
+}
+
+// link is a link found in a page.
+type link struct {
+	PageID string `json:"page_id"`
+	Title  string `json:"title"`
+	Kind   string `json:"kind"`
+	Target string `json:"target"`
+	Error  string `json:"error,omitempty"`
+
+	space, pageTitle, targetID string // What a page link points to
+}
+
+// checkReport is the JSON output of links check.
+type checkReport struct {
+	Pages  int     `json:"pages"`
+	Links  int     `json:"links"`
+	Broken []*link `json:"broken"`
+}
+
+// NewCmdCheck creates the links check command.
+func NewCmdCheck() *cobra.Command {
+	opts := &checkOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "check [page]",
+		Short: "Find broken links in pages",
+		Long: `Check the links in a page and every page under it, or in a whole space,
+and report those that are broken. The command fails if any link is broken,
+so it can gate a documentation CI job.
+
+Links to pages, whether page links or URLs of pages on the site, are
+checked to exist. With --external, links to other sites are checked too,
+with a HEAD request (or a GET if the site doesn't allow HEAD); a link is
+broken if the request fails or the response is an error. Each URL is
+requested once, however many pages link to it, with at most --concurrency
+requests at a time.
+
+With --junit, a JUnit XML report with a test case for each page is written
+to a file, or to standard output for "-", for CI systems that show test
+results.`,
+		Example: `  # Check the links in a space
+  cfl links check --space DOCS
+
+  # Check a page tree, including links to other sites
+  cfl links check "DOCS/User Guide" --external
+
+  # Report to CI as JUnit
+  cfl links check --space DOCS --external --junit links.xml`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.out = os.Stdout
+			if len(args) > 0 && opts.space != "" {
+				return fmt.Errorf("--space is incompatible with a page")
+			}
+			var ref string
+			if len(args) > 0 {
+				ref = args[0]
+			}
+			return runCheck(ref, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key (default: from config)")
+	cmd.Flags().BoolVar(&opts.external, "external", false, "Also check links to other sites")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 8, "Maximum external requests at a time")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 10*time.Second, "Timeout for each external request")
+	cmd.Flags().StringVar(&opts.junit, "junit", "", `Write a JUnit XML report to this file ("-" for stdout)`)
+
+	return cmd
+}
+
+func runCheck(ref string, opts *checkOptions, client *api.Client) error {
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.concurrency < 1 {
+		return fmt.Errorf("invalid --concurrency: %d (must be >= 1)", opts.concurrency)
+	}
+
+	spaceKey := opts.space
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if spaceKey == "" && ref == "" {
+			spaceKey = cfg.DefaultSpace
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	if spaceKey == "" && ref == "" {
+		return fmt.Errorf("page or space is required: give a page, use --space, or set default_space in config")
+	}
+
+	ctx := context.Background()
+	pages, spaceKey, err := crawlPages(ctx, client, ref, spaceKey)
+	if err != nil {
+		return err
+	}
+
+	var found []*link
+	for i := range pages {
+		found = append(found, extractLinks(&pages[i], spaceKey, client.BaseURL())...)
+	}
+
+	var pageLinks []*link
+	for _, l := range found {
+		if l.Kind == kindPage {
+			pageLinks = append(pageLinks, l)
+		}
+	}
+	checker := &linkChecker{client: client, spaces: map[string]string{}, pages: map[string]error{}}
+	progress := view.NewProgress(len(pageLinks))
+	for _, l := range pageLinks {
+		progress.Increment(l.Target)
+		checker.checkPage(ctx, l)
+	}
+	progress.Done()
+
+	if opts.external {
+		httpClient := opts.httpClient
+		if httpClient == nil {
+			httpClient = &http.Client{Timeout: opts.timeout}
+		}
+		checkURLs(ctx, httpClient, found, opts.concurrency)
+	}
+
+	report := &checkReport{Pages: len(pages), Broken: []*link{}}
+	for _, l := range found {
+		if l.Kind == kindURL && !opts.external {
+			continue
+		}
+		report.Links++
+		if l.Error != "" {
+			report.Broken = append(report.Broken, l)
+		}
+	}
+
+	if opts.junit != "" {
+		if err := writeJUnit(opts.junit, opts.out, pages, found, opts.external); err != nil {
+			return err
+		}
+	}
+
+	if opts.junit != "-" {
+		renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+		renderer.SetWriter(opts.out)
+		if view.IsStructured(opts.output) {
+			if err := renderer.RenderJSON(report); err != nil {
+				return err
+			}
+		} else if len(report.Broken) > 0 {
+			rows := make([][]string, len(report.Broken))
+			for i, l := range report.Broken {
+				rows[i] = []string{l.PageID, view.Truncate(l.Title, 40), l.Kind, l.Target, l.Error}
+			}
+			renderer.RenderTable([]string{"ID", "TITLE", "KIND", "TARGET", "ERROR"}, rows)
+		} else {
+			renderer.Success(fmt.Sprintf("All %d links in %d pages work", report.Links, report.Pages))
+		}
+	}
+
+	if len(report.Broken) > 0 {
+		return fmt.Errorf("%d of %d links in %d pages are broken", len(report.Broken), report.Links, report.Pages)
+	}
+	return nil
+}
+
+// crawlPages returns the pages to check, with their storage format bodies:
+// the page ref and the pages under it, or the pages of a space. It also
+// returns the key of their space.
+func crawlPages(ctx context.Context, client *api.Client, ref, spaceKey string) ([]api.Page, string, error) {
+	if ref == "" {
+		space, err := client.GetSpaceByKey(ctx, spaceKey)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+		}
+		pages, err := api.ListAll(client.PagesIter(ctx, space.ID, &api.ListPagesOptions{Status: "current", BodyFormat: "storage"}))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to list pages: %w", err)
+		}
+		return pages, space.Key, nil
+	}
+
+	pageID, err := pageref.Resolve(ctx, client, ref)
+	if err != nil {
+		return nil, "", err
+	}
+	var pages []api.Page
+	queue := []string{pageID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		page, err := client.GetPage(ctx, id, &api.GetPageOptions{BodyFormat: "storage"})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get page %s: %w", id, err)
+		}
+		pages = append(pages, *page)
+
+		opts := &api.ListChildPagesOptions{Limit: 100}
+		for {
+			children, err := client.ListChildPages(ctx, id, opts)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to list children of page %s: %w", id, err)
+			}
+			for _, c := range children.Results {
+				queue = append(queue, c.ID)
+			}
+			if opts.Cursor = children.NextCursor(); opts.Cursor == "" {
+				break
+			}
+		}
+	}
+
+	if spaceKey == "" && pages[0].SpaceID != "" {
+		space, err := client.GetSpace(ctx, pages[0].SpaceID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get space: %w", err)
+		}
+		spaceKey = space.Key
+	}
+	return pages, spaceKey, nil
+}
+
+// extractLinks returns the links to pages, and to other sites, in a page
+// of the space spaceKey on the site at baseURL. Anchors, links to the site
+// that aren't to pages, and other schemes are left out.
+func extractLinks(page *api.Page, spaceKey, baseURL string) []*link {
+	var body string
+	if page.Body != nil && page.Body.Storage != nil {
+		body = page.Body.Storage.Value
+	}
+
+	var links []*link
+	for _, m := range pageLink.FindAllStringSubmatch(body, -1) {
+		l := &link{PageID: page.ID, Title: page.Title, Kind: kindPage, space: spaceKey}
+		for _, attr := range refAttr.FindAllStringSubmatch(m[1], -1) {
+			value := html.UnescapeString(attr[2])
+			if attr[1] == "content-title" {
+				l.pageTitle = value
+			} else if value != "" {
+				l.space = value
+			}
+		}
+		if l.pageTitle == "" {
+			continue
+		}
+		l.Target = l.space + "/" + l.pageTitle
+		links = append(links, l)
+	}
+
+	base, _ := url.Parse(baseURL)
+	for _, m := range hrefAttr.FindAllStringSubmatch(body, -1) {
+		href := html.UnescapeString(m[1])
+		u, err := url.Parse(href)
+		if err != nil {
+			links = append(links, &link{PageID: page.ID, Title: page.Title, Kind: kindURL, Target: href, Error: "invalid URL"})
+			continue
+		}
+		onSite := u.Host == "" || (base != nil && strings.EqualFold(u.Host, base.Host))
+		switch {
+		case onSite && (u.Host != "" || strings.HasPrefix(u.Path, "/")):
+			if id := pageURLID.FindStringSubmatch(u.Path); id != nil {
+				links = append(links, &link{PageID: page.ID, Title: page.Title, Kind: kindPage, Target: href, targetID: id[1]})
+			}
+		case u.Scheme == "http" || u.Scheme == "https":
+			links = append(links, &link{PageID: page.ID, Title: page.Title, Kind: kindURL, Target: href})
+		}
+	}
+	return links
+}
+
+// linkChecker checks that linked pages exist, remembering the answers.
+type linkChecker struct {
+	client *api.Client
+	spaces map[string]string // Space key → ID, or "" if missing
+	pages  map[string]error  // Page ID or space/title → problem
+}
+
+// checkPage sets the error of a page link whose target doesn't exist.
+func (c *linkChecker) checkPage(ctx context.Context, l *link) {
+	key := l.targetID
+	if key == "" {
+		key = l.Target
+	}
+	err, done := c.pages[key]
+	if !done {
+		err = c.findPage(ctx, l)
+		c.pages[key] = err
+	}
+	if err != nil {
+		l.Error = err.Error()
+	}
+}
+
+// findPage returns why the page a link points to can't be found, or nil.
+func (c *linkChecker) findPage(ctx context.Context, l *link) error {
+	if l.targetID != "" {
+		if _, err := c.client.GetPage(ctx, l.targetID, nil); err != nil {
+			if isNotFound(err) {
+				return fmt.Errorf("page %s not found", l.targetID)
+			}
+			return fmt.Errorf("failed to get page %s: %w", l.targetID, err)
+		}
+		return nil
+	}
+
+	spaceID, done := c.spaces[l.space]
+	if !done {
+		space, err := c.client.GetSpaceByKey(ctx, l.space)
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("failed to find space '%s': %w", l.space, err)
+		}
+		if space != nil {
+			spaceID = space.ID
+		}
+		c.spaces[l.space] = spaceID
+	}
+	if spaceID == "" {
+		return fmt.Errorf("space %s not found", l.space)
+	}
+
+	page, err := c.client.FindPageByTitle(ctx, spaceID, l.pageTitle)
+	if err != nil {
+		return fmt.Errorf("failed to find page %q: %w", l.pageTitle, err)
+	}
+	if page == nil {
+		return fmt.Errorf("no page titled %q in space %s", l.pageTitle, l.space)
+	}
+	return nil
+}
+
+// checkURLs requests each URL linked to once, with at most concurrency
+// requests at a time, and sets the error of the links that are broken.
+func checkURLs(ctx context.Context, client *http.Client, links []*link, concurrency int) {
+	byURL := make(map[string][]*link)
+	var urls []string
+	for _, l := range links {
+		if l.Kind != kindURL || l.Error != "" {
+			continue
+		}
+		if byURL[l.Target] == nil {
+			urls = append(urls, l.Target)
+		}
+		byURL[l.Target] = append(byURL[l.Target], l)
+	}
+
+	results := make([]error, len(urls))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex // Guards progress
+	progress := view.NewProgress(len(urls))
+	for i, u := range urls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = checkURL(ctx, client, u)
+			mu.Lock()
+			progress.Increment(u)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	progress.Done()
+
+	for i, u := range urls {
+		if results[i] == nil {
+			continue
+		}
+		for _, l := range byURL[u] {
+			l.Error = results[i].Error()
+		}
+	}
+}
+
+// checkURL requests a URL with HEAD, or with GET if HEAD isn't allowed,
+// and returns why it is broken, or nil.
+func checkURL(ctx context.Context, client *http.Client, target string) error {
+	status, err := requestStatus(ctx, client, http.MethodHead, target)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented || status == http.StatusForbidden) {
+		status, err = requestStatus(ctx, client, http.MethodGet, target)
+	}
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return err
+	}
+	if status >= 400 {
+		return fmt.Errorf("HTTP %d %s", status, http.StatusText(status))
+	}
+	return nil
+}
+
+// requestStatus makes a request and returns the response's status code.
+func requestStatus(ctx context.Context, client *http.Client, method, target string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "cfl-links-check")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
+
+func isNotFound(err error) bool {
+	var apiErr *api.ErrorResponse
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// JUnit XML report elements.
+type (
+	junitSuite struct {
+		XMLName  xml.Name    `xml:"testsuite"`
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Cases    []junitCase `xml:"testcase"`
+	}
+	junitCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+	}
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// writeJUnit writes a JUnit XML report with a test case for each page,
+// failing if any of its checked links are broken, to path, or to out for
+// "-".
+func writeJUnit(path string, out io.Writer, pages []api.Page, links []*link, external bool) error {
+	broken := make(map[string][]*link)
+	for _, l := range links {
+		if l.Error != "" && (l.Kind == kindPage || external) {
+			broken[l.PageID] = append(broken[l.PageID], l)
+		}
+	}
+
+	suite := junitSuite{Name: "cfl links check", Tests: len(pages)}
+	for _, p := range pages {
+		c := junitCase{Name: p.Title, ClassName: "links." + p.ID}
+		if b := broken[p.ID]; len(b) > 0 {
+			var sb strings.Builder
+			for _, l := range b {
+				fmt.Fprintf(&sb, "%s: %s\n", l.Target, l.Error)
+			}
+			c.Failure = &junitFailure{Message: fmt.Sprintf("%d broken link(s)", len(b)), Text: sb.String()}
+			suite.Failures++
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append([]byte(xml.Header), append(data, '\n')...)
+	if path == "-" {
+		_, err = out.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return nil
+}
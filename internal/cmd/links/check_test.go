@@ -0,0 +1,143 @@
+package links
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestExtractLinks(t *testing.T) {
+	page := &api.Page{ID: "1", Title: "Home", Body: &api.Body{Storage: &api.BodyRepresentation{Value: `` +
+		`<ac:link><ri:page ri:content-title="Guide &amp; FAQ"/></ac:link>` +
+		`<ac:link><ri:page ri:space-key="HR" ri:content-title="Policies"/><ac:plain-text-link-body><![CDATA[HR]]></ac:plain-text-link-body></ac:link>` +
+		`<a href="https://example.atlassian.net/wiki/spaces/DOCS/pages/42/Old">old</a>` +
+		`<a href="/wiki/spaces/DOCS/overview">overview</a>` +
+		`<a href="https://go.dev/doc?a=1&amp;b=2">Go</a>` +
+		`<a href="#top">top</a><a href="mailto:docs@example.com">mail</a>`}}}
+
+	var got [][3]string
+	for _, l := range extractLinks(page, "DOCS", "https://example.atlassian.net/wiki") {
+		got = append(got, [3]string{l.Kind, l.Target, l.targetID})
+	}
+	assert.Equal(t, [][3]string{
+		{kindPage, "DOCS/Guide & FAQ", ""},
+		{kindPage, "HR/Policies", ""},
+		{kindPage, "https://example.atlassian.net/wiki/spaces/DOCS/pages/42/Old", "42"},
+		{kindURL, "https://go.dev/doc?a=1&b=2", ""},
+	}, got)
+}
+
+// newCheckServer serves a DOCS space whose Home page links to an existing
+// page, a missing page, a page in a missing space and two external URLs.
+func newCheckServer(t *testing.T, external string) *httptest.Server {
+	t.Helper()
+	body := `<ac:link><ri:page ri:content-title="Guide"/></ac:link>` +
+		`<ac:link><ri:page ri:content-title="Gone"/></ac:link>` +
+		`<ac:link><ri:page ri:space-key="NOPE" ri:content-title="X"/></ac:link>` +
+		`<a href="` + external + `/ok">ok</a><a href="` + external + `/missing">missing</a>`
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/spaces":
+			if r.URL.Query().Get("keys") == "DOCS" {
+				w.Write([]byte(`{"results": [{"id": "100", "key": "DOCS"}]}`))
+				return
+			}
+			w.Write([]byte(`{"results": []}`))
+		case "/api/v2/spaces/100/pages":
+			switch r.URL.Query().Get("title") {
+			case "":
+				w.Write([]byte(`{"results": [{"id": "1", "title": "Home", "body": {"storage": {"value": ` + string(data) + `}}}, {"id": "2", "title": "Guide"}]}`))
+			case "Guide":
+				w.Write([]byte(`{"results": [{"id": "2", "title": "Guide"}]}`))
+			default:
+				w.Write([]byte(`{"results": []}`))
+			}
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunCheck(t *testing.T) {
+	var heads atomic.Int32
+	external := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			heads.Add(1)
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer external.Close()
+
+	server := newCheckServer(t, external.URL)
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	t.Run("pages only", func(t *testing.T) {
+		var buf bytes.Buffer
+		opts := &checkOptions{space: "DOCS", concurrency: 2, output: "json", out: &buf}
+		err := runCheck("", opts, client)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "2 of 3 links in 2 pages are broken")
+
+		var report checkReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+		require.Len(t, report.Broken, 2)
+		assert.Equal(t, "DOCS/Gone", report.Broken[0].Target)
+		assert.Equal(t, `no page titled "Gone" in space DOCS`, report.Broken[0].Error)
+		assert.Equal(t, "space NOPE not found", report.Broken[1].Error)
+		assert.Zero(t, heads.Load())
+	})
+
+	t.Run("external as junit", func(t *testing.T) {
+		var buf bytes.Buffer
+		opts := &checkOptions{space: "DOCS", external: true, concurrency: 2, junit: "-", out: &buf, httpClient: external.Client()}
+		err := runCheck("", opts, client)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "3 of 5 links in 2 pages are broken")
+		assert.Equal(t, int32(2), heads.Load())
+
+		out := buf.String()
+		assert.True(t, strings.HasPrefix(out, `<?xml version="1.0" encoding="UTF-8"?>`))
+		assert.Contains(t, out, `<testsuite name="cfl links check" tests="2" failures="1">`)
+		assert.Contains(t, out, `<failure message="3 broken link(s)">`)
+		assert.Contains(t, out, external.URL+"/missing: HTTP 404 Not Found")
+		assert.Contains(t, out, `<testcase name="Guide" classname="links.2"></testcase>`)
+	})
+}
+
+func TestCheckURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/no-head" && r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case r.URL.Path == "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	assert.NoError(t, checkURL(t.Context(), server.Client(), server.URL+"/ok"))
+	assert.NoError(t, checkURL(t.Context(), server.Client(), server.URL+"/no-head"))
+	assert.EqualError(t, checkURL(t.Context(), server.Client(), server.URL+"/error"), "HTTP 500 Internal Server Error")
+}
+
+func TestRunCheck_RequiresPages(t *testing.T) {
+	err := runCheck("", &checkOptions{concurrency: 1}, api.NewClient("https://example.atlassian.net/wiki", "a", "b"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "page or space is required")
+}
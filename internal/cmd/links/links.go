@@ -0,0 +1,19 @@
+// Package links provides commands that work with the links in pages.
+package links
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmdLinks creates the links command.
+func NewCmdLinks() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "links",
+		Short: "Work with links in pages",
+		Long:  `Commands that examine the links between pages and to other sites.`,
+	}
+
+	cmd.AddCommand(NewCmdCheck())
+
+	return cmd
+}
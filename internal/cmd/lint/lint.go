@@ -0,0 +1,206 @@
+// Package lint provides the lint command, which checks pages against a
+// content policy.
+package lint
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+// lintSearchLimit is the page size used when searching for pages to lint.
+const lintSearchLimit = 100
+
+type lintOptions struct {
+	policy  string
+	cql     string
+	output  string
+	noColor bool
+}
+
+// violation is a page breaking a policy rule.
+type violation struct {
+	PageID  string `json:"pageId"`
+	Title   string `json:"title"`
+	Rule    string `json:"rule"`
+	Problem string `json:"problem"`
+}
+
+// lintReport is the JSON output of lint.
+type lintReport struct {
+	Checked    int          `json:"checked"`
+	Failing    int          `json:"failing"`
+	Violations []*violation `json:"violations"`
+}
+
+// NewCmdLint creates the lint command.
+func NewCmdLint() *cobra.Command {
+	opts := &lintOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check pages against a content policy",
+		Long: `Check the pages matching a CQL query against the rules of a YAML policy
+and report those that don't conform. The command fails if any page breaks
+a rule, so it can gate a CI job.
+
+Each rule requires one thing of a page:
+
+  rules:
+    - name: title-heading
+      heading: 1          # A heading of this level
+    - name: owner
+      property: Owner     # A row with a value in a page properties macro
+    - name: reviewed
+      label: reviewed     # A label
+    - macro: toc          # A macro
+
+Rules without a name are named after what they check.`,
+		Example: `  # Lint a space's pages
+  cfl lint --policy policy.yml --cql 'space=DEV'
+
+  # Lint runbooks, as JSON
+  cfl lint --policy runbook.yml --cql 'space=OPS and label=runbook' -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runLint(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.policy, "policy", "", "Policy file (required)")
+	cmd.Flags().StringVar(&opts.cql, "cql", "", "CQL query selecting the pages to check (required)")
+
+	_ = cmd.MarkFlagRequired("policy")
+	_ = cmd.MarkFlagRequired("cql")
+
+	return cmd
+}
+
+func runLint(opts *lintOptions, client *api.Client) error {
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	policy, err := LoadPolicy(opts.policy)
+	if err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pages, err := searchPages(ctx, client, opts.cql)
+	if err != nil {
+		return err
+	}
+
+	report := &lintReport{Checked: len(pages), Violations: []*violation{}}
+	progress := view.NewProgress(len(pages))
+	for _, p := range pages {
+		progress.Increment(p.Title)
+
+		page, err := loadLintPage(ctx, client, p.ID)
+		if err != nil {
+			progress.Done()
+			return err
+		}
+
+		failing := false
+		for i := range policy.Rules {
+			rule := &policy.Rules[i]
+			if problem := rule.Check(page); problem != "" {
+				report.Violations = append(report.Violations, &violation{PageID: p.ID, Title: p.Title, Rule: rule.Name, Problem: problem})
+				failing = true
+			}
+		}
+		if failing {
+			report.Failing++
+		}
+	}
+	progress.Done()
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		if err := renderer.RenderJSON(report); err != nil {
+			return err
+		}
+	} else if len(report.Violations) > 0 {
+		headers := []string{"ID", "TITLE", "RULE", "PROBLEM"}
+		var rows [][]string
+		for _, v := range report.Violations {
+			rows = append(rows, []string{v.PageID, v.Title, v.Rule, v.Problem})
+		}
+		renderer.RenderTable(headers, rows)
+	}
+
+	if report.Failing > 0 {
+		return fmt.Errorf("%d of %d pages don't conform to the policy", report.Failing, report.Checked)
+	}
+	if !view.IsStructured(opts.output) {
+		renderer.Success(fmt.Sprintf("All %d pages conform to the policy", report.Checked))
+	}
+	return nil
+}
+
+// searchPages returns the pages matching a CQL query, skipping other
+// content types.
+func searchPages(ctx context.Context, client *api.Client, cql string) ([]api.SearchContent, error) {
+	var pages []api.SearchContent
+	opts := &api.SearchOptions{CQL: cql, Limit: lintSearchLimit}
+	for {
+		result, err := client.Search(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search: %w", err)
+		}
+		for _, r := range result.Results {
+			if r.Content.Type == "page" {
+				pages = append(pages, r.Content)
+			}
+		}
+		if !result.HasMore() || len(result.Results) == 0 {
+			return pages, nil
+		}
+		opts.Start += len(result.Results)
+	}
+}
+
+// loadLintPage gets the storage format body and labels of a page.
+func loadLintPage(ctx context.Context, client *api.Client, pageID string) (*lintPage, error) {
+	page, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: "storage"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page %s: %w", pageID, err)
+	}
+	labels, err := client.GetLabels(ctx, pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels of page %s: %w", pageID, err)
+	}
+
+	lp := &lintPage{}
+	if page.Body != nil && page.Body.Storage != nil {
+		lp.Storage = page.Body.Storage.Value
+	}
+	for _, l := range labels {
+		lp.Labels = append(lp.Labels, l.Name)
+	}
+	return lp, nil
+}
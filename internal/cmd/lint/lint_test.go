@@ -0,0 +1,69 @@
+package lint
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// newLintServer serves a search returning page 1, which has level 1 and 2
+// headings and the reviewed label, page 2, which only has a level 2
+// heading, and a blog post.
+func newLintServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/search":
+			assert.Equal(t, "space=DEV", r.URL.Query().Get("cql"))
+			w.Write([]byte(`{"results": [
+				{"content": {"id": "1", "type": "page", "title": "Good"}},
+				{"content": {"id": "2", "type": "page", "title": "Bad"}},
+				{"content": {"id": "3", "type": "blogpost", "title": "News"}}
+			], "start": 0, "size": 3, "totalSize": 3}`))
+		case "/api/v2/pages/1":
+			w.Write([]byte(`{"id": "1", "body": {"storage": {"value": "<h1>Good</h1><h2>More</h2>"}}}`))
+		case "/api/v2/pages/2":
+			w.Write([]byte(`{"id": "2", "body": {"storage": {"value": "<h2>Bad</h2>"}}}`))
+		case "/api/v2/pages/1/labels":
+			w.Write([]byte(`{"results": [{"name": "reviewed"}]}`))
+		case "/api/v2/pages/2/labels":
+			w.Write([]byte(`{"results": []}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunLint(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr string
+	}{
+		{"failing page", "rules:\n  - heading: 1\n  - label: reviewed\n", "1 of 2 pages don't conform to the policy"},
+		{"all conform", "rules:\n  - heading: 2\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newLintServer(t)
+			client := api.NewClient(server.URL, "test@example.com", "token")
+
+			opts := &lintOptions{policy: writePolicy(t, tt.policy), cql: "space=DEV", noColor: true}
+			err := runLint(opts, client)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package lint
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// detailsMacro matches the body of a page properties macro.
+	detailsMacro = regexp.MustCompile(`(?s)<ac:structured-macro\b[^>]*?\bac:name="details"[^>]*>(.*?)</ac:structured-macro>`)
+
+	// tableRow and tableCell match the rows of a table and their cells.
+	tableRow  = regexp.MustCompile(`(?s)<tr\b[^>]*>(.*?)</tr>`)
+	tableCell = regexp.MustCompile(`(?s)<t[hd]\b[^>]*>(.*?)</t[hd]>`)
+
+	// macroName matches the name of a macro in storage format.
+	macroName = regexp.MustCompile(`<ac:(?:structured-)?macro\b[^>]*?\bac:name="([^"]+)"`)
+
+	// markup matches XML tags.
+	markup = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Policy is a set of rules pages must conform to.
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule is a single requirement. Exactly one of its checks is set.
+type Rule struct {
+	Name     string `yaml:"name"`
+	Heading  int    `yaml:"heading,omitempty"`  // Requires a heading of this level (1-6)
+	Property string `yaml:"property,omitempty"` // Requires a page properties row with a value
+	Label    string `yaml:"label,omitempty"`    // Requires a label
+	Macro    string `yaml:"macro,omitempty"`    // Requires a macro
+}
+
+// lintPage is the content of a page that rules are checked against.
+type lintPage struct {
+	Storage string
+	Labels  []string
+}
+
+// LoadPolicy reads and validates a policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	var policy Policy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+	if len(policy.Rules) == 0 {
+		return nil, fmt.Errorf("policy %s has no rules", path)
+	}
+	for i := range policy.Rules {
+		if err := policy.Rules[i].validate(); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+	return &policy, nil
+}
+
+// validate checks that a rule has exactly one valid check and fills in a
+// default name.
+func (r *Rule) validate() error {
+	checks := 0
+	for _, set := range []bool{r.Heading != 0, r.Property != "", r.Label != "", r.Macro != ""} {
+		if set {
+			checks++
+		}
+	}
+	if checks != 1 {
+		return fmt.Errorf("exactly one of heading, property, label or macro is required")
+	}
+	if r.Heading < 0 || r.Heading > 6 {
+		return fmt.Errorf("invalid heading level %d (must be 1-6)", r.Heading)
+	}
+
+	if r.Name == "" {
+		switch {
+		case r.Heading != 0:
+			r.Name = fmt.Sprintf("heading-h%d", r.Heading)
+		case r.Property != "":
+			r.Name = "property-" + strings.ToLower(r.Property)
+		case r.Label != "":
+			r.Name = "label-" + r.Label
+		default:
+			r.Name = "macro-" + r.Macro
+		}
+	}
+	return nil
+}
+
+// Check returns why a page doesn't conform to the rule, or "" if it does.
+func (r *Rule) Check(page *lintPage) string {
+	switch {
+	case r.Heading != 0:
+		if !strings.Contains(page.Storage, fmt.Sprintf("<h%d", r.Heading)) {
+			return fmt.Sprintf("no level %d heading", r.Heading)
+		}
+	case r.Property != "":
+		value, ok := pageProperty(page.Storage, r.Property)
+		if !ok {
+			return fmt.Sprintf("no %q page property", r.Property)
+		}
+		// A mention, date or status macro counts as a value
+		if cellText(value) == "" && !strings.Contains(value, "<ac:") && !strings.Contains(value, "<ri:") {
+			return fmt.Sprintf("%q page property is empty", r.Property)
+		}
+	case r.Label != "":
+		for _, label := range page.Labels {
+			if strings.EqualFold(label, r.Label) {
+				return ""
+			}
+		}
+		return fmt.Sprintf("missing label %q", r.Label)
+	case r.Macro != "":
+		for _, m := range macroName.FindAllStringSubmatch(page.Storage, -1) {
+			if strings.EqualFold(m[1], r.Macro) {
+				return ""
+			}
+		}
+		return fmt.Sprintf("no %s macro", r.Macro)
+	}
+	return ""
+}
+
+// pageProperty returns the value cell of a row in a page's page properties
+// macros, and whether the row exists.
+func pageProperty(storage, key string) (string, bool) {
+	for _, details := range detailsMacro.FindAllStringSubmatch(storage, -1) {
+		for _, row := range tableRow.FindAllStringSubmatch(details[1], -1) {
+			cells := tableCell.FindAllStringSubmatch(row[1], -1)
+			if len(cells) < 2 || !strings.EqualFold(cellText(cells[0][1]), key) {
+				continue
+			}
+			return cells[1][1], true
+		}
+	}
+	return "", false
+}
+
+// cellText returns the text of a table cell.
+func cellText(cell string) string {
+	return strings.TrimSpace(html.UnescapeString(markup.ReplaceAllString(cell, "")))
+}
@@ -0,0 +1,88 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePolicy(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "policy.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadPolicy(t *testing.T) {
+	policy, err := LoadPolicy(writePolicy(t, `rules:
+  - name: title
+    heading: 1
+  - property: Owner
+  - label: reviewed
+  - macro: toc
+`))
+	require.NoError(t, err)
+
+	var names []string
+	for _, r := range policy.Rules {
+		names = append(names, r.Name)
+	}
+	assert.Equal(t, []string{"title", "property-owner", "label-reviewed", "macro-toc"}, names)
+}
+
+func TestLoadPolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"no rules", "rules: []\n", "has no rules"},
+		{"two checks", "rules:\n  - label: a\n    macro: toc\n", "rule 1: exactly one of"},
+		{"no check", "rules:\n  - name: empty\n", "rule 1: exactly one of"},
+		{"bad heading", "rules:\n  - heading: 7\n", "invalid heading level 7"},
+		{"bad yaml", "rules: [", "failed to parse policy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadPolicy(writePolicy(t, tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRule_Check(t *testing.T) {
+	properties := `<ac:structured-macro ac:name="details"><ac:rich-text-body><table><tbody>` +
+		`<tr><th><p>Owner</p></th><td><p><ac:link><ri:user ri:account-id="abc"/></ac:link></p></td></tr>` +
+		`<tr><th><p>Status</p></th><td><p> </p></td></tr>` +
+		`</tbody></table></ac:rich-text-body></ac:structured-macro>`
+	page := &lintPage{
+		Storage: `<h1>Intro</h1>` + properties + `<ac:structured-macro ac:name="toc"/>`,
+		Labels:  []string{"Reviewed"},
+	}
+
+	tests := []struct {
+		name string
+		rule Rule
+		want string
+	}{
+		{"heading present", Rule{Heading: 1}, ""},
+		{"heading missing", Rule{Heading: 2}, "no level 2 heading"},
+		{"property with mention", Rule{Property: "owner"}, ""},
+		{"property empty", Rule{Property: "Status"}, `"Status" page property is empty`},
+		{"property missing", Rule{Property: "Team"}, `no "Team" page property`},
+		{"label present", Rule{Label: "reviewed"}, ""},
+		{"label missing", Rule{Label: "approved"}, `missing label "approved"`},
+		{"macro present", Rule{Macro: "toc"}, ""},
+		{"macro missing", Rule{Macro: "jira"}, "no jira macro"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Check(page))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package migrate
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// pageURLPattern matches links to pages by ID, absolute or relative to
+	// the site: [https://site][/wiki]/spaces/KEY/pages/ID.
+	pageURLPattern = regexp.MustCompile(`(https?://[^/\s"'<>]+)?(/wiki)?/spaces/([^/\s"'<>?#]+)/pages/(\d+)`)
+
+	// contentIDPattern matches page references by ID in storage format.
+	contentIDPattern = regexp.MustCompile(`ri:content-id="(\d+)"`)
+
+	// spaceKeyPattern matches space references in storage format, such as
+	// the space of a page linked by title.
+	spaceKeyPattern = regexp.MustCompile(`ri:space-key="([^"]*)"`)
+)
+
+// linkRewriter rewrites the links in a storage format body that point into
+// the migrated pages so they point to their copies.
+type linkRewriter struct {
+	fromSite  string            // Source site base URL
+	toSite    string            // Target site base URL
+	fromSpace string            // Source space key
+	toSpace   string            // Target space key
+	ids       map[string]string // Target page ID by source page ID; empty until the page is created
+}
+
+// rewrite returns body with links to migrated pages rewritten, and how
+// many links are to pages that aren't created yet and were left alone.
+func (r *linkRewriter) rewrite(body string) (string, int) {
+	pending := 0
+	target := func(id string) (string, bool) {
+		newID, ok := r.ids[id]
+		if ok && newID == "" {
+			pending++
+		}
+		return newID, newID != ""
+	}
+
+	body = pageURLPattern.ReplaceAllStringFunc(body, func(link string) string {
+		m := pageURLPattern.FindStringSubmatch(link)
+		origin, wiki, id := m[1], m[2], m[4]
+		if origin != "" && !sameOrigin(origin, r.fromSite) {
+			return link
+		}
+		newID, ok := target(id)
+		if !ok {
+			return link
+		}
+		prefix := origin + wiki
+		if origin != "" {
+			prefix = r.toSite
+		}
+		return prefix + "/spaces/" + r.toSpace + "/pages/" + newID
+	})
+
+	body = contentIDPattern.ReplaceAllStringFunc(body, func(ref string) string {
+		id := contentIDPattern.FindStringSubmatch(ref)[1]
+		if newID, ok := target(id); ok {
+			return `ri:content-id="` + newID + `"`
+		}
+		return ref
+	})
+
+	if r.fromSpace != r.toSpace {
+		body = spaceKeyPattern.ReplaceAllStringFunc(body, func(ref string) string {
+			if spaceKeyPattern.FindStringSubmatch(ref)[1] == r.fromSpace {
+				return `ri:space-key="` + r.toSpace + `"`
+			}
+			return ref
+		})
+	}
+	return body, pending
+}
+
+// sameOrigin reports whether origin (scheme://host) is the origin of the
+// site URL.
+func sameOrigin(origin, site string) bool {
+	u, err := url.Parse(site)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(origin, u.Scheme+"://"+u.Host)
+}
@@ -0,0 +1,445 @@
+// Package migrate provides the migrate command, which copies pages between
+// Confluence sites.
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type migrateOptions struct {
+	from     string
+	to       string
+	space    string
+	page     string
+	toSpace  string
+	toParent string
+	state    string
+	output   string
+	noColor  bool
+	stdout   io.Writer // For testing; defaults to os.Stdout
+}
+
+// migrateResult maps a source page to its copy.
+type migrateResult struct {
+	SourceID string `json:"source_id"`
+	Title    string `json:"title"`
+	ID       string `json:"id"`
+	URL      string `json:"url,omitempty"`
+}
+
+// NewCmdMigrate creates the migrate command.
+func NewCmdMigrate() *cobra.Command {
+	opts := &migrateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Copy a space or page tree to another Confluence site",
+		Long: `Copy a whole space (--space) or a page and its descendants (--page) from
+the site of one config profile (--from) to the site of another (--to).
+
+Pages are created in the target space (--to-space, default: the source
+space key), parents before children, under --to-parent or at the top of
+the space. Each page is copied in storage format with its attachments and
+labels. Once every page exists, links between the copied pages, whether
+by URL or page ID, are rewritten to point to the new pages; links by
+title follow the new space key.
+
+Progress is recorded in a state file (default: .cfl-migrate.json in the
+current directory) after every step, so an interrupted migration is
+resumed by running the same command again: pages already copied are
+skipped. A state file belongs to one migration; use --state to run
+another.`,
+		Example: `  # Copy space DOCS from the old site to the new one
+  cfl migrate --from old --to new --space DOCS
+
+  # Copy a page tree into another space, under a parent page
+  cfl migrate --from old --to new --page 12345 --to-space ARCHIVE --to-parent 67890
+
+  # Keep the state of a second migration separately
+  cfl migrate --from old --to new --space TEAM --state team-migration.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runMigrate(opts, nil, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.from, "from", "", "Config profile of the source site (required)")
+	cmd.Flags().StringVar(&opts.to, "to", "", "Config profile of the target site (required)")
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Source space key to copy")
+	cmd.Flags().StringVar(&opts.page, "page", "", "Source page ID to copy with its descendants")
+	cmd.Flags().StringVar(&opts.toSpace, "to-space", "", "Target space key (default: the source space key)")
+	cmd.Flags().StringVar(&opts.toParent, "to-parent", "", "Target parent page ID (default: top of the space)")
+	cmd.Flags().StringVar(&opts.state, "state", defaultStateFile, "State file for resuming the migration")
+
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	cmd.MarkFlagsMutuallyExclusive("space", "page")
+	cmd.MarkFlagsOneRequired("space", "page")
+
+	return cmd
+}
+
+// profileClient creates a client for the site of a config profile.
+func profileClient(name string) (*api.Client, error) {
+	cfg, err := config.LoadProfile(config.DefaultConfigPath(), name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config for profile %q: %w", name, err)
+	}
+	return api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...), nil
+}
+
+func runMigrate(opts *migrateOptions, source, target *api.Client) error {
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if (opts.space == "") == (opts.page == "") {
+		return fmt.Errorf("exactly one of --space and --page is required")
+	}
+
+	// Create API clients if not provided (allows injection for testing)
+	var err error
+	if source == nil {
+		if source, err = profileClient(opts.from); err != nil {
+			return err
+		}
+	}
+	if target == nil {
+		if target, err = profileClient(opts.to); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	m := &migration{source: source, target: target, opts: opts}
+	if err := m.plan(ctx); err != nil {
+		return err
+	}
+	if err := m.run(ctx); err != nil {
+		return fmt.Errorf("%w (run the command again to resume)", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	results := make([]migrateResult, 0, len(m.pages))
+	for _, p := range m.pages {
+		copied := m.state.Pages[p.ID]
+		results = append(results, migrateResult{SourceID: p.ID, Title: copied.Title, ID: copied.ID, URL: copied.URL})
+	}
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(results)
+	}
+
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		rows = append(rows, []string{r.SourceID, view.Truncate(r.Title, 50), r.ID, r.URL})
+	}
+	if len(rows) > 0 {
+		renderer.RenderTable([]string{"SOURCE ID", "TITLE", "ID", "URL"}, rows)
+	}
+	renderer.Success(fmt.Sprintf("Migrated %d pages to space %s on %s", len(results), m.state.Space, target.BaseURL()))
+	return nil
+}
+
+// migration copies pages from the source site to the target site.
+type migration struct {
+	source, target *api.Client
+	opts           *migrateOptions
+
+	pages         []api.Page // Source pages, parents before children
+	roots         map[string]bool
+	targetSpaceID string
+	state         *migrateState
+	links         *linkRewriter
+}
+
+// plan lists the pages to copy, finds the target space and loads the
+// state of an earlier run.
+func (m *migration) plan(ctx context.Context) error {
+	var sourceSpace *api.Space
+	var rootID, description string
+	if m.opts.page != "" {
+		root, err := m.source.GetPage(ctx, m.opts.page, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get page %s: %w", m.opts.page, err)
+		}
+		if sourceSpace, err = m.source.GetSpace(ctx, root.SpaceID); err != nil {
+			return fmt.Errorf("failed to get space: %w", err)
+		}
+		rootID, description = root.ID, "page "+root.ID
+	} else {
+		var err error
+		if sourceSpace, err = m.source.GetSpaceByKey(ctx, m.opts.space); err != nil {
+			return fmt.Errorf("failed to find space '%s': %w", m.opts.space, err)
+		}
+		description = "space " + sourceSpace.Key
+	}
+
+	var all []api.Page
+	for p, err := range m.source.PagesIter(ctx, sourceSpace.ID, &api.ListPagesOptions{Limit: 250, Status: "current"}) {
+		if err != nil {
+			return fmt.Errorf("failed to list pages: %w", err)
+		}
+		all = append(all, p)
+	}
+	m.pages, m.roots = treeOrder(all, rootID)
+	if len(m.pages) == 0 {
+		return fmt.Errorf("no pages to migrate in %s", description)
+	}
+
+	toSpace := m.opts.toSpace
+	if toSpace == "" {
+		toSpace = sourceSpace.Key
+	}
+	targetSpace, err := m.target.GetSpaceByKey(ctx, toSpace)
+	if err != nil {
+		return fmt.Errorf("failed to find target space '%s': %w", toSpace, err)
+	}
+	m.targetSpaceID = targetSpace.ID
+
+	want := &migrateState{From: m.source.BaseURL(), To: m.target.BaseURL(), Source: description, Space: targetSpace.Key, Parent: m.opts.toParent}
+	if m.state, err = loadMigrateState(m.opts.state); err != nil {
+		return err
+	}
+	if !m.state.matches(want) {
+		return fmt.Errorf("state file %s is for a migration of %s to %s on %s; use --state to choose another",
+			m.opts.state, m.state.Source, m.state.Space, m.state.To)
+	}
+	m.state.From, m.state.To, m.state.Source, m.state.Space, m.state.Parent = want.From, want.To, want.Source, want.Space, want.Parent
+
+	m.links = &linkRewriter{
+		fromSite:  m.source.BaseURL(),
+		toSite:    m.target.BaseURL(),
+		fromSpace: sourceSpace.Key,
+		toSpace:   targetSpace.Key,
+		ids:       make(map[string]string, len(m.pages)),
+	}
+	for _, p := range m.pages {
+		if copied := m.state.Pages[p.ID]; copied != nil {
+			m.links.ids[p.ID] = copied.ID
+		} else {
+			m.links.ids[p.ID] = ""
+		}
+	}
+	return nil
+}
+
+// treeOrder returns the pages of the tree rooted at rootID, or of the
+// whole space when rootID is empty, with parents before their children
+// and siblings in position order. The roots of the returned pages are
+// marked in roots.
+func treeOrder(pages []api.Page, rootID string) ([]api.Page, map[string]bool) {
+	byID := make(map[string]bool, len(pages))
+	for _, p := range pages {
+		byID[p.ID] = true
+	}
+	children := make(map[string][]api.Page)
+	var tops []api.Page
+	for _, p := range pages {
+		switch {
+		case rootID != "" && p.ID == rootID:
+			tops = append(tops, p)
+		case rootID == "" && !byID[p.ParentID]:
+			tops = append(tops, p)
+		case p.ParentID != "":
+			children[p.ParentID] = append(children[p.ParentID], p)
+		}
+	}
+
+	byPosition := func(a, b api.Page) int {
+		return cmp.Or(cmp.Compare(a.Position, b.Position), cmp.Compare(a.Title, b.Title))
+	}
+	var ordered []api.Page
+	var walk func(siblings []api.Page)
+	walk = func(siblings []api.Page) {
+		slices.SortStableFunc(siblings, byPosition)
+		for _, p := range siblings {
+			ordered = append(ordered, p)
+			walk(children[p.ID])
+		}
+	}
+	walk(tops)
+
+	roots := make(map[string]bool, len(tops))
+	for _, p := range tops {
+		roots[p.ID] = true
+	}
+	return ordered, roots
+}
+
+// run copies the pages that the state doesn't record as copied, then
+// rewrites the links between them. The state is saved after every step.
+func (m *migration) run(ctx context.Context) error {
+	progress := view.NewProgress(len(m.pages))
+	defer progress.Done()
+
+	for _, p := range m.pages {
+		copied := m.state.Pages[p.ID]
+		if copied == nil {
+			var err error
+			if copied, err = m.createPage(ctx, p); err != nil {
+				return fmt.Errorf("failed to copy page %q (%s): %w", p.Title, p.ID, err)
+			}
+			m.state.Pages[p.ID] = copied
+			m.links.ids[p.ID] = copied.ID
+			if err := m.state.save(m.opts.state); err != nil {
+				return err
+			}
+		}
+
+		if !copied.Attachments {
+			if err := m.copyAttachments(ctx, p.ID, copied.ID); err != nil {
+				return fmt.Errorf("failed to copy attachments of page %q (%s): %w", p.Title, p.ID, err)
+			}
+			copied.Attachments = true
+			if err := m.state.save(m.opts.state); err != nil {
+				return err
+			}
+		}
+
+		if !copied.Labels {
+			if err := m.copyLabels(ctx, p.ID, copied.ID); err != nil {
+				return fmt.Errorf("failed to copy labels of page %q (%s): %w", p.Title, p.ID, err)
+			}
+			copied.Labels = true
+			if err := m.state.save(m.opts.state); err != nil {
+				return err
+			}
+		}
+		progress.Increment(p.Title)
+	}
+
+	// Pages created before the pages they link to still point to the source
+	for _, p := range m.pages {
+		copied := m.state.Pages[p.ID]
+		if copied.Linked {
+			continue
+		}
+		if err := m.relink(ctx, copied.ID); err != nil {
+			return fmt.Errorf("failed to rewrite links in page %q (%s): %w", copied.Title, copied.ID, err)
+		}
+		copied.Linked = true
+		if err := m.state.save(m.opts.state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createPage creates the copy of a source page, with the links to pages
+// already copied rewritten.
+func (m *migration) createPage(ctx context.Context, p api.Page) (*migratedPage, error) {
+	full, err := m.source.GetPage(ctx, p.ID, &api.GetPageOptions{BodyFormat: "storage"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+	var body string
+	if full.Body != nil && full.Body.Storage != nil {
+		body = full.Body.Storage.Value
+	}
+	body, pending := m.links.rewrite(body)
+
+	parentID := m.opts.toParent
+	if !m.roots[p.ID] {
+		parentID = m.state.Pages[p.ParentID].ID
+	}
+	created, err := m.target.CreatePage(ctx, &api.CreatePageRequest{
+		SpaceID:  m.targetSpaceID,
+		Status:   "current",
+		Title:    full.Title,
+		ParentID: parentID,
+		Body:     &api.Body{Storage: &api.BodyRepresentation{Representation: "storage", Value: body}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	copied := &migratedPage{ID: created.ID, Title: created.Title, Linked: pending == 0}
+	if created.Links.WebUI != "" {
+		copied.URL = m.target.BaseURL() + created.Links.WebUI
+	}
+	return copied, nil
+}
+
+// copyAttachments uploads the attachments of a source page to its copy.
+// Attachments are replaced if they exist, so an interrupted copy can be
+// repeated.
+func (m *migration) copyAttachments(ctx context.Context, sourceID, targetID string) error {
+	for att, err := range m.source.AttachmentsIter(ctx, sourceID, &api.ListAttachmentsOptions{Limit: 250}) {
+		if err != nil {
+			return err
+		}
+		content, err := m.source.DownloadAttachment(ctx, att.ID)
+		if err != nil {
+			return fmt.Errorf("failed to download %s: %w", att.Title, err)
+		}
+		_, err = m.target.CreateOrUpdateAttachment(ctx, targetID, att.Title, content, att.Comment)
+		_ = content.Close()
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %w", att.Title, err)
+		}
+	}
+	return nil
+}
+
+// copyLabels adds the labels of a source page to its copy.
+func (m *migration) copyLabels(ctx context.Context, sourceID, targetID string) error {
+	labels, err := m.source.GetLabels(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	names := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l.Name
+	}
+	_, err = m.target.AddLabels(ctx, targetID, names)
+	return err
+}
+
+// relink rewrites the links to migrated pages left in a copied page.
+func (m *migration) relink(ctx context.Context, pageID string) error {
+	page, err := m.target.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: "storage"})
+	if err != nil {
+		return err
+	}
+	if page.Body == nil || page.Body.Storage == nil {
+		return nil
+	}
+	body, _ := m.links.rewrite(page.Body.Storage.Value)
+	if body == page.Body.Storage.Value {
+		return nil
+	}
+
+	version := 1
+	if page.Version != nil {
+		version = page.Version.Number + 1
+	}
+	_, err = m.target.UpdatePage(ctx, pageID, &api.UpdatePageRequest{
+		ID:      pageID,
+		Status:  "current",
+		Title:   page.Title,
+		Body:    &api.Body{Storage: &api.BodyRepresentation{Representation: "storage", Value: body}},
+		Version: &api.Version{Number: version, Message: "Rewrote links to migrated pages", MinorEdit: true},
+	})
+	return err
+}
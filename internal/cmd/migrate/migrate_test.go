@@ -0,0 +1,290 @@
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// mockSourceServer serves space DOCS: Home (1) with children Guide (2) and
+// FAQ (3). Guide links to FAQ by URL and has an attachment; FAQ links to
+// Guide by title and is labeled faq.
+func mockSourceServer(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodies := map[string]string{
+			"1": `<p>Welcome</p>`,
+			"2": fmt.Sprintf(`<p>See <a href="%s/spaces/DOCS/pages/3/FAQ">the FAQ</a>.</p><ac:image><ri:attachment ri:filename="arch.png" /></ac:image>`, server.URL),
+			"3": `<p>Read <ac:link><ri:page ri:space-key="DOCS" ri:content-title="Guide" /></ac:link> or <a href="https://other.example.com/wiki/spaces/DOCS/pages/2">elsewhere</a>.</p>`,
+		}
+		titles := map[string]string{"1": "Home", "2": "Guide", "3": "FAQ"}
+
+		switch {
+		case r.URL.Path == "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "10", "key": "DOCS"}]}`))
+		case r.URL.Path == "/api/v2/spaces/10":
+			w.Write([]byte(`{"id": "10", "key": "DOCS"}`))
+		case r.URL.Path == "/api/v2/spaces/10/pages":
+			w.Write([]byte(`{"results": [
+				{"id": "3", "title": "FAQ", "spaceId": "10", "parentId": "1", "position": 1},
+				{"id": "2", "title": "Guide", "spaceId": "10", "parentId": "1", "position": 0},
+				{"id": "1", "title": "Home", "spaceId": "10"}
+			]}`))
+		case strings.HasPrefix(r.URL.Path, "/api/v2/pages/") && strings.HasSuffix(r.URL.Path, "/attachments"):
+			if r.URL.Path == "/api/v2/pages/2/attachments" {
+				w.Write([]byte(`{"results": [{"id": "att1", "title": "arch.png", "comment": "Architecture"}]}`))
+				return
+			}
+			w.Write([]byte(`{"results": []}`))
+		case strings.HasPrefix(r.URL.Path, "/api/v2/pages/") && strings.HasSuffix(r.URL.Path, "/labels"):
+			if r.URL.Path == "/api/v2/pages/3/labels" {
+				w.Write([]byte(`{"results": [{"id": "9", "name": "faq"}]}`))
+				return
+			}
+			w.Write([]byte(`{"results": []}`))
+		case strings.HasPrefix(r.URL.Path, "/api/v2/pages/"):
+			id := strings.TrimPrefix(r.URL.Path, "/api/v2/pages/")
+			page := api.Page{ID: id, Title: titles[id], SpaceID: "10", Body: &api.Body{Storage: &api.BodyRepresentation{Representation: "storage", Value: bodies[id]}}}
+			require.NoError(t, json.NewEncoder(w).Encode(page))
+		case r.URL.Path == "/api/v2/attachments/att1":
+			w.Write([]byte(`{"id": "att1", "title": "arch.png", "downloadLink": "/download/attachments/2/arch.png"}`))
+		case r.URL.Path == "/download/attachments/2/arch.png":
+			w.Write([]byte("PNG"))
+		default:
+			t.Errorf("unexpected source request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+// targetServer records what is created in space NEW. Creating a page
+// titled failTitle fails.
+type targetServer struct {
+	*httptest.Server
+	failTitle string
+
+	mu          sync.Mutex
+	pages       map[string]*api.Page // By ID
+	created     []string             // Titles in creation order
+	attachments map[string]string    // Content by page ID and filename
+	labels      map[string][]string  // By page ID
+}
+
+func newTargetServer(t *testing.T) *targetServer {
+	s := &targetServer{pages: map[string]*api.Page{}, attachments: map[string]string{}, labels: map[string][]string{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "20", "key": "NEW"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/pages":
+			var req api.CreatePageRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Title == s.failTitle {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"message": "bad page"}`))
+				return
+			}
+			id := fmt.Sprint(500 + len(s.pages))
+			s.pages[id] = &api.Page{ID: id, Title: req.Title, ParentID: req.ParentID, Version: &api.Version{Number: 1}, Body: req.Body,
+				Links: api.Links{WebUI: "/spaces/NEW/pages/" + id}}
+			s.created = append(s.created, req.Title)
+			require.NoError(t, json.NewEncoder(w).Encode(s.pages[id]))
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/v2/pages/"):
+			var req api.UpdatePageRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			page := s.pages[req.ID]
+			page.Body, page.Version = req.Body, req.Version
+			require.NoError(t, json.NewEncoder(w).Encode(page))
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v2/pages/"):
+			require.NoError(t, json.NewEncoder(w).Encode(s.pages[strings.TrimPrefix(r.URL.Path, "/api/v2/pages/")]))
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/child/attachment"):
+			id := strings.Split(r.URL.Path, "/")[4]
+			file, header, err := r.FormFile("file")
+			require.NoError(t, err)
+			data, _ := io.ReadAll(file)
+			s.attachments[id+"/"+header.Filename] = string(data)
+			w.Write([]byte(`{"results": [{"id": "att9", "title": "x"}]}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/label"):
+			var labels []struct {
+				Name string `json:"name"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&labels))
+			id := strings.Split(r.URL.Path, "/")[4]
+			for _, l := range labels {
+				s.labels[id] = append(s.labels[id], l.Name)
+			}
+			w.Write([]byte(`{"results": []}`))
+		default:
+			t.Errorf("unexpected target request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+// byTitle returns the created page with a title.
+func (s *targetServer) byTitle(title string) *api.Page {
+	for _, p := range s.pages {
+		if p.Title == title {
+			return p
+		}
+	}
+	return nil
+}
+
+func TestRunMigrate(t *testing.T) {
+	src := mockSourceServer(t)
+	defer src.Close()
+	dst := newTargetServer(t)
+	defer dst.Close()
+	source := api.NewClient(src.URL, "old@example.com", "token")
+	target := api.NewClient(dst.URL, "new@example.com", "token")
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	var out bytes.Buffer
+	opts := &migrateOptions{space: "DOCS", toSpace: "NEW", toParent: "99", state: statePath, output: "json", stdout: &out}
+	require.NoError(t, runMigrate(opts, source, target))
+
+	// Parents are created first, siblings in position order
+	assert.Equal(t, []string{"Home", "Guide", "FAQ"}, dst.created)
+	home, guide, faq := dst.byTitle("Home"), dst.byTitle("Guide"), dst.byTitle("FAQ")
+	assert.Equal(t, "99", home.ParentID)
+	assert.Equal(t, home.ID, guide.ParentID)
+	assert.Equal(t, home.ID, faq.ParentID)
+
+	// Guide was created before FAQ, so its link was rewritten afterwards
+	assert.Contains(t, guide.Body.Storage.Value, `href="`+dst.URL+`/spaces/NEW/pages/`+faq.ID+`/FAQ"`)
+	assert.Equal(t, 2, guide.Version.Number)
+	assert.Contains(t, faq.Body.Storage.Value, `ri:space-key="NEW" ri:content-title="Guide"`)
+	assert.Contains(t, faq.Body.Storage.Value, `https://other.example.com/wiki/spaces/DOCS/pages/2`)
+	assert.Equal(t, 1, faq.Version.Number)
+
+	assert.Equal(t, map[string]string{guide.ID + "/arch.png": "PNG"}, dst.attachments)
+	assert.Equal(t, map[string][]string{faq.ID: {"faq"}}, dst.labels)
+
+	var results []migrateResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+	require.Len(t, results, 3)
+	assert.Equal(t, migrateResult{SourceID: "3", Title: "FAQ", ID: faq.ID, URL: dst.URL + "/spaces/NEW/pages/" + faq.ID}, results[2])
+
+	// Running it again finds everything done
+	out.Reset()
+	require.NoError(t, runMigrate(opts, source, target))
+	assert.Len(t, dst.created, 3)
+	require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+	assert.Len(t, results, 3)
+
+	// The state belongs to this migration
+	err := runMigrate(&migrateOptions{space: "DOCS", state: statePath, output: "json", stdout: &out}, source, target)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is for a migration of space DOCS to NEW")
+}
+
+func TestRunMigrate_Resume(t *testing.T) {
+	src := mockSourceServer(t)
+	defer src.Close()
+	dst := newTargetServer(t)
+	defer dst.Close()
+	dst.failTitle = "FAQ"
+	source := api.NewClient(src.URL, "old@example.com", "token")
+	target := api.NewClient(dst.URL, "new@example.com", "token")
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	var out bytes.Buffer
+	opts := &migrateOptions{space: "DOCS", toSpace: "NEW", state: statePath, output: "json", stdout: &out}
+	err := runMigrate(opts, source, target)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `failed to copy page "FAQ" (3)`)
+	assert.Contains(t, err.Error(), "run the command again to resume")
+	assert.Empty(t, out.String())
+
+	state, err := loadMigrateState(statePath)
+	require.NoError(t, err)
+	require.Len(t, state.Pages, 2)
+	assert.True(t, state.Pages["2"].Attachments)
+	assert.False(t, state.Pages["2"].Linked)
+
+	// The rerun only creates the page that failed
+	dst.failTitle = ""
+	require.NoError(t, runMigrate(opts, source, target))
+	assert.Equal(t, []string{"Home", "Guide", "FAQ"}, dst.created)
+	assert.Contains(t, dst.byTitle("Guide").Body.Storage.Value, "/spaces/NEW/pages/"+dst.byTitle("FAQ").ID)
+}
+
+func TestRunMigrate_Page(t *testing.T) {
+	src := mockSourceServer(t)
+	defer src.Close()
+	dst := newTargetServer(t)
+	defer dst.Close()
+	source := api.NewClient(src.URL, "old@example.com", "token")
+	target := api.NewClient(dst.URL, "new@example.com", "token")
+
+	var out bytes.Buffer
+	opts := &migrateOptions{page: "2", toSpace: "NEW", state: filepath.Join(t.TempDir(), "state.json"), output: "json", stdout: &out}
+	require.NoError(t, runMigrate(opts, source, target))
+
+	// Only the subtree is copied, at the top of the space; the link to a
+	// page outside it is left pointing to the source
+	assert.Equal(t, []string{"Guide"}, dst.created)
+	assert.Empty(t, dst.byTitle("Guide").ParentID)
+	assert.Contains(t, dst.byTitle("Guide").Body.Storage.Value, src.URL+"/spaces/DOCS/pages/3/FAQ")
+}
+
+func TestRunMigrate_Validation(t *testing.T) {
+	err := runMigrate(&migrateOptions{}, &api.Client{}, &api.Client{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exactly one of --space and --page is required")
+
+	err = runMigrate(&migrateOptions{space: "A", page: "1"}, &api.Client{}, &api.Client{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exactly one of --space and --page is required")
+}
+
+func TestLinkRewriter(t *testing.T) {
+	r := &linkRewriter{
+		fromSite:  "https://old.atlassian.net/wiki",
+		toSite:    "https://new.atlassian.net/wiki",
+		fromSpace: "DOCS",
+		toSpace:   "NEW",
+		ids:       map[string]string{"1": "101", "2": ""},
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		want        string
+		wantPending int
+	}{
+		{"absolute URL", `<a href="https://old.atlassian.net/wiki/spaces/DOCS/pages/1/Home">`, `<a href="https://new.atlassian.net/wiki/spaces/NEW/pages/101/Home">`, 0},
+		{"relative URL", `<a href="/wiki/spaces/DOCS/pages/1">`, `<a href="/wiki/spaces/NEW/pages/101">`, 0},
+		{"other site", `<a href="https://other.example.com/wiki/spaces/DOCS/pages/1">`, `<a href="https://other.example.com/wiki/spaces/DOCS/pages/1">`, 0},
+		{"not migrated", `<a href="/wiki/spaces/DOCS/pages/7">`, `<a href="/wiki/spaces/DOCS/pages/7">`, 0},
+		{"not created yet", `<a href="/wiki/spaces/DOCS/pages/2">`, `<a href="/wiki/spaces/DOCS/pages/2">`, 1},
+		{"content ID", `<ri:page ri:content-id="1" />`, `<ri:page ri:content-id="101" />`, 0},
+		{"space key", `<ri:page ri:space-key="DOCS" ri:content-title="X" />`, `<ri:page ri:space-key="NEW" ri:content-title="X" />`, 0},
+		{"other space key", `<ri:page ri:space-key="TEAM" ri:content-title="X" />`, `<ri:page ri:space-key="TEAM" ri:content-title="X" />`, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pending := r.rewrite(tt.body)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPending, pending)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// defaultStateFile is the state file written in the current directory.
+const defaultStateFile = ".cfl-migrate.json"
+
+// migrateState records how far a migration got, so an interrupted one can
+// be resumed.
+type migrateState struct {
+	From   string                   `json:"from"`   // Source site
+	To     string                   `json:"to"`     // Target site
+	Source string                   `json:"source"` // What is migrated: "space KEY" or "page ID"
+	Space  string                   `json:"space"`  // Target space key
+	Parent string                   `json:"parent,omitempty"`
+	Pages  map[string]*migratedPage `json:"pages"` // By source page ID
+}
+
+// migratedPage is the page a source page was copied to, and which steps of
+// copying it are done.
+type migratedPage struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	URL         string `json:"url,omitempty"`
+	Attachments bool   `json:"attachments"` // Attachments uploaded
+	Labels      bool   `json:"labels"`      // Labels added
+	Linked      bool   `json:"linked"`      // Links to other migrated pages rewritten
+}
+
+// loadMigrateState reads a state file, returning an empty state if it
+// does not exist.
+func loadMigrateState(path string) (*migrateState, error) {
+	state := &migrateState{Pages: make(map[string]*migratedPage)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse migration state %s: %w", path, err)
+	}
+	if state.Pages == nil {
+		state.Pages = make(map[string]*migratedPage)
+	}
+	return state, nil
+}
+
+// matches reports whether the state is for the same migration as other,
+// or is new.
+func (s *migrateState) matches(other *migrateState) bool {
+	if s.From == "" && len(s.Pages) == 0 {
+		return true
+	}
+	return s.From == other.From && s.To == other.To && s.Source == other.Source &&
+		s.Space == other.Space && s.Parent == other.Parent
+}
+
+// save writes the state file.
+func (s *migrateState) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal migration state: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write migration state: %w", err)
+	}
+	return nil
+}
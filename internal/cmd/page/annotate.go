@@ -0,0 +1,139 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+type annotateOptions struct {
+	match      string
+	body       string
+	noMarkdown bool
+	output     string
+	noColor    bool
+}
+
+// NewCmdAnnotate creates the page annotate command.
+func NewCmdAnnotate() *cobra.Command {
+	opts := &annotateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "annotate <page-id>",
+		Short: "Add an inline comment on text in a page",
+		Long: `Add an inline comment anchored to the first occurrence of a text snippet.
+
+The snippet given by --match is looked for in the page's text, ignoring
+formatting, so it can span bold or linked words. The command fails if the
+snippet is not on the page, which makes it suitable for review bots that
+flag stale instructions.
+
+The comment body is converted from markdown unless --no-markdown is given.
+To comment on a later occurrence, use 'cfl comment add --selection --match'.`,
+		Example: `  # Flag an outdated instruction
+  cfl page annotate 12345 --match "deploy step 3" --body "this is outdated"
+
+  # Comment with raw storage format
+  cfl page annotate 12345 --match "v1 API" --body "<p>Use <code>v2</code></p>" --no-markdown`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runAnnotate(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.match, "match", "", "Page text to attach the comment to (required)")
+	cmd.Flags().StringVar(&opts.body, "body", "", "Comment text (required)")
+	cmd.Flags().BoolVar(&opts.noMarkdown, "no-markdown", false, "Disable markdown conversion (use raw XHTML)")
+
+	_ = cmd.MarkFlagRequired("match")
+	_ = cmd.MarkFlagRequired("body")
+
+	return cmd
+}
+
+func runAnnotate(pageID string, opts *annotateOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.match == "" {
+		return fmt.Errorf("--match is required")
+	}
+	if strings.TrimSpace(opts.body) == "" {
+		return fmt.Errorf("--body is required")
+	}
+
+	body := opts.body
+	if !opts.noMarkdown {
+		storage, err := md.ToConfluenceStorage([]byte(body))
+		if err != nil {
+			return fmt.Errorf("failed to convert markdown: %w", err)
+		}
+		body = storage
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	page, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: "storage"})
+	if err != nil {
+		return fmt.Errorf("failed to get page: %w", err)
+	}
+
+	var count int
+	if page.Body != nil && page.Body.Storage != nil {
+		count = md.CountStorageText(page.Body.Storage.Value, opts.match)
+	}
+	if count == 0 {
+		return fmt.Errorf("text %q not found on page %s", opts.match, pageID)
+	}
+
+	comment, err := client.CreateComment(ctx, api.CommentInline, &api.CreateCommentRequest{
+		PageID: pageID,
+		Body:   &api.BodyRepresentation{Representation: "storage", Value: body},
+		InlineCommentProperties: &api.NewInlineCommentSelection{
+			TextSelection:           opts.match,
+			TextSelectionMatchCount: count,
+			TextSelectionMatchIndex: 0,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(comment)
+	}
+
+	renderer.Success(fmt.Sprintf("Added inline comment on %q in %s (comment ID: %s)", opts.match, page.Title, comment.ID))
+	return nil
+}
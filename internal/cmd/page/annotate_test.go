@@ -0,0 +1,89 @@
+package page
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// newAnnotateServer serves a page with the given storage body and records
+// created inline comments.
+func newAnnotateServer(t *testing.T, storage string, created *[]api.CreateCommentRequest) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":    "12345",
+				"title": "Deploy Guide",
+				"body":  map[string]any{"storage": map[string]string{"value": storage}},
+			})
+		case r.Method == "POST" && r.URL.Path == "/api/v2/inline-comments":
+			var req api.CreateCommentRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			*created = append(*created, req)
+			_, _ = w.Write([]byte(`{"id": "777", "status": "current"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunAnnotate(t *testing.T) {
+	var created []api.CreateCommentRequest
+	server := newAnnotateServer(t, "<p>Run <strong>deploy step 3</strong>, then deploy step 3 again.</p>", &created)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	err := runAnnotate("12345", &annotateOptions{match: "deploy step 3", body: "this is **outdated**", noColor: true}, client)
+	require.NoError(t, err)
+
+	require.Len(t, created, 1)
+	assert.Equal(t, "12345", created[0].PageID)
+	assert.Contains(t, created[0].Body.Value, "<strong>outdated</strong>")
+	assert.Equal(t, &api.NewInlineCommentSelection{
+		TextSelection:           "deploy step 3",
+		TextSelectionMatchCount: 2,
+		TextSelectionMatchIndex: 0,
+	}, created[0].InlineCommentProperties)
+}
+
+func TestRunAnnotate_NoMarkdown(t *testing.T) {
+	var created []api.CreateCommentRequest
+	server := newAnnotateServer(t, "<p>Use the v1 API.</p>", &created)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	err := runAnnotate("12345", &annotateOptions{match: "v1 API", body: "<p>Use **v2**</p>", noMarkdown: true, output: "json", noColor: true}, client)
+	require.NoError(t, err)
+
+	require.Len(t, created, 1)
+	assert.Equal(t, "<p>Use **v2**</p>", created[0].Body.Value)
+}
+
+func TestRunAnnotate_NotFound(t *testing.T) {
+	var created []api.CreateCommentRequest
+	server := newAnnotateServer(t, "<p>Nothing relevant.</p>", &created)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	err := runAnnotate("12345", &annotateOptions{match: "deploy step 3", body: "outdated", noColor: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `text "deploy step 3" not found on page 12345`)
+	assert.Empty(t, created)
+}
+
+func TestRunAnnotate_MissingFlags(t *testing.T) {
+	err := runAnnotate("12345", &annotateOptions{body: "x"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--match is required")
+
+	err = runAnnotate("12345", &annotateOptions{match: "x", body: "  "}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--body is required")
+}
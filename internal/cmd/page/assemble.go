@@ -0,0 +1,228 @@
+package page
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+type assembleOptions struct {
+	sections   []string
+	title      string
+	toc        bool
+	legacy     bool
+	legacyAuto bool // --legacy wasn't given, so the page's space decides the editor format
+	dryRun     bool
+	output     string
+	noColor    bool
+	stdout     io.Writer // For testing; defaults to os.Stdout
+}
+
+// atxHeading matches a markdown ATX heading, capturing its hashes and text.
+var atxHeading = regexp.MustCompile(`^(#{1,6})(?:[ \t]+(.*?))?[ \t]*#*[ \t]*$`)
+
+// NewCmdAssemble creates the page assemble command.
+func NewCmdAssemble() *cobra.Command {
+	opts := &assembleOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "assemble <page>",
+		Short: "Publish a page assembled from markdown fragments",
+		Long: `Replace the content of a page with markdown fragments, each published as
+a level 2 section, for docs maintained as separate files.
+
+Each fragment's section heading is its front matter title, else its first
+level 1 heading, else its file name ("getting-started.md" becomes "Getting
+started"). The fragment's own headings move down a level to sit under it.
+Images are resolved relative to each fragment's file.
+
+With --toc, a table of contents of the sections is added at the top. Use
+--dry-run to print the assembled markdown instead of publishing it.
+
+The page may be ` + pageref.Usage + `.`,
+		Example: `  # Publish three fragments as one page
+  cfl page assemble 12345 --section intro.md --section setup.md --section faq.md
+
+  # With a table of contents
+  cfl page assemble "DEV/User Guide" --section docs/*.md --toc
+
+  # Preview the assembled markdown
+  cfl page assemble 12345 --section intro.md --section faq.md --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.legacyAuto = !cmd.Flags().Changed("legacy")
+			return runAssemble(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&opts.sections, "section", nil, "Markdown file to publish as a section (repeatable, in order)")
+	cmd.Flags().StringVarP(&opts.title, "title", "t", "", "New page title")
+	cmd.Flags().BoolVar(&opts.toc, "toc", false, "Add a table of contents of the sections")
+	cmd.Flags().BoolVar(&opts.legacy, "legacy", false, "Publish in legacy editor format (default: cloud editor)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print the assembled markdown instead of publishing")
+	_ = cmd.MarkFlagRequired("section")
+
+	return cmd
+}
+
+func runAssemble(ref string, opts *assembleOptions, client *api.Client) error {
+	if len(opts.sections) == 0 {
+		return fmt.Errorf("at least one --section is required")
+	}
+
+	content, err := assembleSections(opts.sections, opts.toc)
+	if err != nil {
+		return err
+	}
+
+	if opts.dryRun {
+		stdout := opts.stdout
+		if stdout == nil {
+			stdout = os.Stdout
+		}
+		_, err := io.WriteString(stdout, content)
+		return err
+	}
+
+	markdown := true
+	return runEdit(&editOptions{
+		pageID:     ref,
+		title:      opts.title,
+		markdown:   &markdown,
+		legacy:     opts.legacy,
+		output:     opts.output,
+		noColor:    opts.noColor,
+		stdin:      strings.NewReader(content),
+		legacyAuto: opts.legacyAuto,
+	}, client)
+}
+
+// assembleSections reads markdown fragments and joins them into one
+// document with a level 2 section per fragment.
+func assembleSections(files []string, toc bool) (string, error) {
+	var sb strings.Builder
+	if toc {
+		sb.WriteString("<!-- toc min=2 max=2 -->\n\n")
+	}
+	for i, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read section: %w", err)
+		}
+		section, err := assembleSection(file, data)
+		if err != nil {
+			return "", fmt.Errorf("section %s: %w", file, err)
+		}
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(section)
+	}
+	return sb.String(), nil
+}
+
+// assembleSection turns a fragment into a level 2 section: its title becomes
+// the section heading, its headings move down a level, and images are made
+// relative to the working directory.
+func assembleSection(file string, data []byte) (string, error) {
+	fm, body, err := md.ParseFrontMatter(data)
+	if err != nil {
+		return "", err
+	}
+	title := ""
+	if fm != nil {
+		title = fm.Title
+	}
+
+	var out []string
+	fence := ""
+	started := false
+	for _, line := range strings.Split(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if marker := fenceMarker(trimmed); marker != "" {
+			// A fence closes on a bare run of its character at least as long
+			if fence == "" {
+				fence = marker
+			} else if marker[0] == fence[0] && len(marker) >= len(fence) && marker == trimmed {
+				fence = ""
+			}
+		} else if fence == "" && !strings.HasPrefix(line, "    ") {
+			if m := atxHeading.FindStringSubmatch(trimmed); m != nil {
+				// A leading level 1 heading titles the section
+				if !started && title == "" && m[1] == "#" {
+					title = m[2]
+					started = true
+					continue
+				}
+				if len(m[1]) < 6 {
+					line = "#" + trimmed
+				}
+			}
+		}
+		if trimmed != "" {
+			started = true
+		}
+		out = append(out, line)
+	}
+
+	if title == "" {
+		title = sectionTitle(file)
+	}
+	content := rebaseImages(strings.TrimSpace(strings.Join(out, "\n")), filepath.Dir(file))
+	if content == "" {
+		return "## " + title + "\n", nil
+	}
+	return "## " + title + "\n\n" + content + "\n", nil
+}
+
+// fenceMarker returns the opening characters of a code fence line (``` or
+// ~~~ and longer), or "" if the line isn't a fence.
+func fenceMarker(line string) string {
+	for _, c := range []string{"`", "~"} {
+		n := len(line) - len(strings.TrimLeft(line, c))
+		if n >= 3 {
+			return strings.Repeat(c, n)
+		}
+	}
+	return ""
+}
+
+// sectionTitle makes a section title from a file name: "getting-started.md"
+// becomes "Getting started".
+func sectionTitle(file string) string {
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	name = strings.TrimSpace(strings.NewReplacer("-", " ", "_", " ").Replace(name))
+	if name == "" {
+		return file
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// rebaseImages rewrites relative local image references to be relative to
+// the working directory instead of dir, so fragments from different
+// directories can be published together.
+func rebaseImages(content, dir string) string {
+	if dir == "." {
+		return content
+	}
+	for _, ref := range md.LocalFileRefs([]byte(content)) {
+		if path.IsAbs(ref) || filepath.IsAbs(filepath.FromSlash(ref)) {
+			continue
+		}
+		rebased := path.Join(filepath.ToSlash(dir), ref)
+		content = strings.ReplaceAll(content, "]("+ref, "]("+rebased)
+	}
+	return content
+}
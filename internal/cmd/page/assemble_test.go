@@ -0,0 +1,131 @@
+package page
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestAssembleSection(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		want string
+	}{
+		{
+			name: "leading H1 is the title",
+			file: "intro.md",
+			data: "# Introduction\n\nWelcome.\n\n## Goals\n\nShip it.\n",
+			want: "## Introduction\n\nWelcome.\n\n### Goals\n\nShip it.\n",
+		},
+		{
+			name: "front matter title",
+			file: "setup.md",
+			data: "---\ntitle: Setting up\n---\n# Install\n\nRun it.\n",
+			want: "## Setting up\n\n## Install\n\nRun it.\n",
+		},
+		{
+			name: "file name title",
+			file: "docs/getting-started.md",
+			data: "Read this first.\n\n## Step one\n",
+			want: "## Getting started\n\nRead this first.\n\n### Step one\n",
+		},
+		{
+			name: "headings in code are left alone",
+			file: "faq.md",
+			data: "# FAQ\n\n```sh\n# not a heading\n```\n\n~~~~\n## nor this\n~~~\n~~~~\n\n###### Deepest\n",
+			want: "## FAQ\n\n```sh\n# not a heading\n```\n\n~~~~\n## nor this\n~~~\n~~~~\n\n###### Deepest\n",
+		},
+		{
+			name: "images are relative to the fragment",
+			file: filepath.Join("docs", "arch.md"),
+			data: "![Diagram](img/arch.png) ![Remote](https://example.com/x.png)\n",
+			want: "## Arch\n\n![Diagram](docs/img/arch.png) ![Remote](https://example.com/x.png)\n",
+		},
+		{
+			name: "empty fragment",
+			file: "todo.md",
+			data: "# To do\n",
+			want: "## To do\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := assembleSection(tt.file, []byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunAssemble_DryRun(t *testing.T) {
+	dir := t.TempDir()
+	intro := filepath.Join(dir, "intro.md")
+	faq := filepath.Join(dir, "faq.md")
+	require.NoError(t, os.WriteFile(intro, []byte("# Intro\n\nHello.\n"), 0644))
+	require.NoError(t, os.WriteFile(faq, []byte("Questions.\n"), 0644))
+
+	var stdout bytes.Buffer
+	err := runAssemble("12345", &assembleOptions{sections: []string{intro, faq}, toc: true, dryRun: true, stdout: &stdout}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "<!-- toc min=2 max=2 -->\n\n## Intro\n\nHello.\n\n## Faq\n\nQuestions.\n", stdout.String())
+}
+
+func TestRunAssemble_Publish(t *testing.T) {
+	dir := t.TempDir()
+	intro := filepath.Join(dir, "intro.md")
+	setup := filepath.Join(dir, "setup.md")
+	require.NoError(t, os.WriteFile(intro, []byte("# Intro\n\nHello.\n"), 0644))
+	require.NoError(t, os.WriteFile(setup, []byte("# Setup\n\n## Install\n"), 0644))
+
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345/properties":
+			w.Write([]byte(`{"results": []}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+			w.Write([]byte(`{"id": "12345", "title": "Guide", "version": {"number": 2}, "body": {"storage": {"value": "<p>Old</p>"}}}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/v2/pages/12345":
+			data, _ := io.ReadAll(r.Body)
+			json.Unmarshal(data, &received)
+			w.Write([]byte(`{"id": "12345", "title": "Guide", "version": {"number": 3}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runAssemble("12345", &assembleOptions{sections: []string{intro, setup}, toc: true, legacy: true, noColor: true}, client)
+	require.NoError(t, err)
+
+	storage := received["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"].(string)
+	assert.Contains(t, storage, "<h2")
+	assert.Contains(t, storage, "Intro</h2>")
+	assert.Contains(t, storage, "Install</h3>")
+	assert.Contains(t, storage, `href="#`, "the TOC links to the sections")
+	assert.NotContains(t, storage, "<h1")
+}
+
+func TestRunAssemble_Errors(t *testing.T) {
+	err := runAssemble("12345", &assembleOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one --section is required")
+
+	err = runAssemble("12345", &assembleOptions{sections: []string{"missing.md"}, dryRun: true}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read section")
+}
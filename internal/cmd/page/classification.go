@@ -0,0 +1,42 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+// classify returns labels with the configured default classification
+// added when none of them is a classification label. A page without one
+// is refused when there is no default.
+func classify(classification config.ClassificationConfig, labels []string) ([]string, error) {
+	if len(classification.Labels) == 0 || classification.Classified(labels) != "" {
+		return labels, nil
+	}
+	if classification.Default == "" {
+		return nil, fmt.Errorf("page must carry a classification label (%s): add one with --label or in the front matter, or set classification.default in the config",
+			strings.Join(classification.Labels, ", "))
+	}
+	return append(append([]string(nil), labels...), classification.Default), nil
+}
+
+// classifyExisting is classify for an existing page, whose current labels
+// count towards its classification.
+func classifyExisting(ctx context.Context, client *api.Client, classification config.ClassificationConfig, pageID string, labels []string) ([]string, error) {
+	if len(classification.Labels) == 0 || classification.Classified(labels) != "" {
+		return labels, nil
+	}
+	existing, err := client.GetLabels(ctx, pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	for _, l := range existing {
+		if classification.Classified([]string{l.Name}) != "" {
+			return labels, nil
+		}
+	}
+	return classify(classification, labels)
+}
@@ -0,0 +1,289 @@
+package page
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// Statuses of pages converted to the cloud editor.
+const (
+	convertConverted    = "converted"
+	convertWouldConvert = "would-convert"
+	convertSkipped      = "skipped"
+	convertFailed       = "failed"
+)
+
+type convertOptions struct {
+	space   string
+	dryRun  bool
+	force   bool
+	output  string
+	noColor bool
+	stdin   io.Reader // injectable for testing
+}
+
+// convertResult is the outcome of converting one page.
+type convertResult struct {
+	ID          string   `json:"page_id"`
+	Title       string   `json:"title"`
+	Status      string   `json:"status"`
+	Version     int      `json:"version,omitempty"`
+	Unconverted []string `json:"unconverted,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// NewCmdConvert creates the page convert command.
+func NewCmdConvert() *cobra.Command {
+	opts := &convertOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "convert [page-id]",
+		Short: "Convert legacy editor pages to the cloud editor",
+		Long: `Convert a page from the legacy editor to the cloud editor.
+
+The page's storage format is converted to ADF and published as a new
+version, which moves the page to the cloud editor. Macros with bracket
+syntax become their cloud editor equivalents (panels, expands, code blocks
+and macro extensions), as do macros cfl has no syntax for whose content is
+rich text, and page layouts become cloud editor layouts. Elements that
+can't be converted are reported: layout sections with more than three
+columns, whose cells are published one after another, and unknown macros
+with plain text bodies, which are lost from the new version but remain in
+the page history.
+
+Pages already using the cloud editor are skipped.
+
+With --space, every page in the space is converted after a single
+confirmation; --dry-run reports what would be converted, and what couldn't
+be, without changing anything. A failure
+does not stop the rest: a summary is printed at the end.`,
+		Example: `  # Convert a page
+  cfl page convert 12345
+
+  # Preview converting a space
+  cfl page convert --space DEV --dry-run
+
+  # Convert every page in a space, without confirmation
+  cfl page convert --space DEV --yes`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.stdin = os.Stdin // default to os.Stdin, can be overridden in tests
+			switch {
+			case opts.space != "" && len(args) > 0:
+				return fmt.Errorf("--space is incompatible with a page ID")
+			case opts.space == "" && len(args) == 0:
+				return fmt.Errorf("page ID or --space is required")
+			}
+			var pageID string
+			if len(args) > 0 {
+				pageID = args[0]
+			}
+			return runConvert(pageID, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Convert every page in this space")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Report what would be converted without changing anything")
+	cmd.Flags().BoolVarP(&opts.force, "yes", "y", false, "Skip the confirmation prompt for --space")
+
+	return cmd
+}
+
+func runConvert(pageID string, opts *convertOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	if opts.space != "" {
+		return runConvertSpace(ctx, opts, client)
+	}
+
+	pageID, err := pageref.Resolve(ctx, client, pageID)
+	if err != nil {
+		return err
+	}
+	page, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: "storage"})
+	if err != nil {
+		return fmt.Errorf("failed to get page: %w", err)
+	}
+
+	result := convertPage(ctx, client, page, opts.dryRun)
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if view.IsStructured(opts.output) {
+		if err := renderer.RenderJSON(result); err != nil {
+			return err
+		}
+	} else {
+		switch result.Status {
+		case convertConverted:
+			renderer.Success(fmt.Sprintf("Converted page to the cloud editor: %s (ID: %s)", result.Title, result.ID))
+			renderer.RenderKeyValue("Version", fmt.Sprint(result.Version))
+		case convertWouldConvert:
+			renderer.RenderText(fmt.Sprintf("Page would be converted to the cloud editor: %s (ID: %s) (dry run)", result.Title, result.ID))
+		case convertSkipped:
+			renderer.RenderText(fmt.Sprintf("Page already uses the cloud editor: %s (ID: %s)", result.Title, result.ID))
+		}
+		if len(result.Unconverted) > 0 {
+			renderer.Warning("Could not convert: " + strings.Join(result.Unconverted, ", "))
+		}
+	}
+	if result.Status == convertFailed {
+		return errors.New(result.Error)
+	}
+	return nil
+}
+
+// runConvertSpace converts every page in opts.space.
+func runConvertSpace(ctx context.Context, opts *convertOptions, client *api.Client) error {
+	space, err := client.GetSpaceByKey(ctx, opts.space)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", opts.space, err)
+	}
+	pages, err := api.ListAll(client.PagesIter(ctx, space.ID, &api.ListPagesOptions{BodyFormat: "storage"}))
+	if err != nil {
+		return fmt.Errorf("failed to list pages: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if len(pages) == 0 {
+		if view.IsStructured(opts.output) {
+			return renderer.RenderJSON([]convertResult{})
+		}
+		renderer.RenderText("No pages in the space.")
+		return nil
+	}
+
+	// Confirm conversion unless --yes or --dry-run is used
+	if !opts.force && !opts.dryRun {
+		fmt.Printf("About to convert %d page(s) in space %s to the cloud editor.\n", len(pages), space.Key)
+		if !confirmDelete(opts.stdin) {
+			fmt.Println("Conversion cancelled.")
+			return nil
+		}
+	}
+
+	results := make([]convertResult, 0, len(pages))
+	counts := make(map[string]int)
+	progress := view.NewProgress(len(pages))
+	for i := range pages {
+		result := convertPage(ctx, client, &pages[i], opts.dryRun)
+		results = append(results, result)
+		counts[result.Status]++
+		progress.Increment(pages[i].Title)
+	}
+	progress.Done()
+
+	if view.IsStructured(opts.output) {
+		if err := renderer.RenderJSON(results); err != nil {
+			return err
+		}
+	} else {
+		rows := make([][]string, len(results))
+		for i, r := range results {
+			rows[i] = []string{r.ID, view.Truncate(r.Title, 50), r.Status, strings.Join(r.Unconverted, ", "), r.Error}
+		}
+		renderer.RenderTable([]string{"ID", "TITLE", "STATUS", "UNCONVERTED", "ERROR"}, rows)
+		if opts.output != "plain" {
+			if opts.dryRun {
+				fmt.Printf("\n%d would be converted, %d skipped (dry run).\n", counts[convertWouldConvert], counts[convertSkipped])
+			} else {
+				fmt.Printf("\n%d converted, %d skipped, %d failed.\n", counts[convertConverted], counts[convertSkipped], counts[convertFailed])
+			}
+		}
+	}
+
+	if failed := counts[convertFailed]; failed > 0 {
+		return fmt.Errorf("failed to convert %d of %d page(s)", failed, len(results))
+	}
+	return nil
+}
+
+// convertPage converts a page fetched with its storage format body to the
+// cloud editor, or with dryRun only reports whether it would.
+func convertPage(ctx context.Context, client *api.Client, page *api.Page, dryRun bool) convertResult {
+	result := convertResult{ID: page.ID, Title: page.Title}
+	fail := func(err error) convertResult {
+		result.Status = convertFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	editor, err := pageEditor(ctx, client, page.ID)
+	if err != nil {
+		return fail(err)
+	}
+	if editor == api.EditorCloud {
+		result.Status = convertSkipped
+		return result
+	}
+
+	var storage string
+	if page.Body != nil && page.Body.Storage != nil {
+		storage = page.Body.Storage.Value
+	}
+	adf, err := md.StorageToADF(storage)
+	if err != nil {
+		return fail(fmt.Errorf("failed to convert page: %w", err))
+	}
+	result.Unconverted = md.UnconvertedStorage(storage)
+	if dryRun {
+		result.Status = convertWouldConvert
+		return result
+	}
+
+	version := 1
+	if page.Version != nil {
+		version = page.Version.Number + 1
+	}
+	updated, err := client.UpdatePage(ctx, page.ID, &api.UpdatePageRequest{
+		ID:     page.ID,
+		Status: "current",
+		Title:  page.Title,
+		Body:   newEditBody(adf, false),
+		Version: &api.Version{
+			Number:  version,
+			Message: "Converted to the cloud editor via cfl",
+		},
+	})
+	if err != nil {
+		err = checkADFSupport(ctx, client, err, false)
+		return fail(fmt.Errorf("failed to update page: %w", err))
+	}
+	result.Status = convertConverted
+	result.Version = version
+	if updated.Version != nil {
+		result.Version = updated.Version.Number
+	}
+	return result
+}
@@ -0,0 +1,185 @@
+package page
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunConvert(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+			assert.Equal(t, "storage", r.URL.Query().Get("body-format"))
+			w.Write([]byte(`{"id": "12345", "title": "Runbook", "version": {"number": 4},
+				"body": {"storage": {"value": "<ac:structured-macro ac:name=\"info\"><ac:rich-text-body><p>Careful</p></ac:rich-text-body></ac:structured-macro>"}}}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345/properties":
+			w.Write([]byte(`{"results": [{"key": "editor", "value": "v1"}]}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/v2/pages/12345":
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &received))
+			w.Write([]byte(`{"id": "12345", "title": "Runbook", "version": {"number": 5}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	require.NoError(t, runConvert("12345", &convertOptions{noColor: true}, client))
+
+	require.NotNil(t, received)
+	version := received["version"].(map[string]interface{})
+	assert.Equal(t, float64(5), version["number"])
+	body := received["body"].(map[string]interface{})
+	adf := body["atlas_doc_format"].(map[string]interface{})
+	assert.Equal(t, "atlas_doc_format", adf["representation"])
+	assert.Contains(t, adf["value"], `"type":"panel"`)
+	assert.Contains(t, adf["value"], `"text":"Careful"`)
+}
+
+func TestConvertPage(t *testing.T) {
+	tests := []struct {
+		name            string
+		editor          string
+		dryRun          bool
+		updateStatus    int
+		wantStatus      string
+		wantUpdate      bool
+		wantUnconverted []string
+	}{
+		{"legacy page", "v1", false, http.StatusOK, convertConverted, true, []string{"macro html"}},
+		{"editor not recorded", "", false, http.StatusOK, convertConverted, true, []string{"macro html"}},
+		{"cloud page is skipped", "v2", false, http.StatusOK, convertSkipped, false, nil},
+		{"dry run", "v1", true, http.StatusOK, convertWouldConvert, false, []string{"macro html"}},
+		{"update fails", "v1", false, http.StatusForbidden, convertFailed, true, []string{"macro html"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345/properties":
+					if tt.editor == "" {
+						w.Write([]byte(`{"results": []}`))
+						return
+					}
+					w.Write([]byte(`{"results": [{"key": "editor", "value": "` + tt.editor + `"}]}`))
+				case r.Method == "PUT" && r.URL.Path == "/api/v2/pages/12345":
+					updated = true
+					w.WriteHeader(tt.updateStatus)
+					w.Write([]byte(`{"id": "12345", "title": "Test", "version": {"number": 2}}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			page := &api.Page{
+				ID:      "12345",
+				Title:   "Test",
+				Version: &api.Version{Number: 1},
+				Body: &api.Body{Storage: &api.BodyRepresentation{
+					Value: `<ac:structured-macro ac:name="html"><ac:plain-text-body><![CDATA[<b>A</b>]]></ac:plain-text-body></ac:structured-macro>`,
+				}},
+			}
+
+			result := convertPage(t.Context(), client, page, tt.dryRun)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantUnconverted, result.Unconverted)
+			assert.Equal(t, tt.wantUpdate, updated)
+			if tt.wantStatus == convertFailed {
+				assert.Contains(t, result.Error, "failed to update page")
+			}
+		})
+	}
+}
+
+func TestRunConvert_Space(t *testing.T) {
+	tests := []struct {
+		name        string
+		dryRun      bool
+		force       bool
+		stdin       string
+		wantUpdates []string
+		wantErr     string
+	}{
+		{"dry run", true, false, "", nil, ""},
+		{"confirmed", false, false, "y\n", []string{"1", "3"}, "failed to convert 1 of 3 page(s)"},
+		{"cancelled", false, false, "n\n", nil, ""},
+		{"yes", false, true, "", []string{"1", "3"}, "failed to convert 1 of 3 page(s)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var updates []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/api/v2/spaces":
+					w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+				case r.URL.Path == "/api/v2/spaces/123456/pages":
+					assert.Equal(t, "storage", r.URL.Query().Get("body-format"))
+					w.Write([]byte(`{"results": [
+						{"id": "1", "title": "One", "version": {"number": 1}, "body": {"storage": {"value": "<p>One</p>"}}},
+						{"id": "2", "title": "Two", "version": {"number": 1}, "body": {"storage": {"value": "<p>Two</p>"}}},
+						{"id": "3", "title": "Three", "version": {"number": 1}, "body": {"storage": {"value": "<p>Three</p>"}}}
+					]}`))
+				case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/properties"):
+					editor := "v1"
+					if r.URL.Path == "/api/v2/pages/2/properties" {
+						editor = "v2"
+					}
+					w.Write([]byte(`{"results": [{"key": "editor", "value": "` + editor + `"}]}`))
+				case r.Method == "PUT":
+					id := strings.TrimPrefix(r.URL.Path, "/api/v2/pages/")
+					mu.Lock()
+					updates = append(updates, id)
+					mu.Unlock()
+					if id == "3" {
+						w.WriteHeader(http.StatusForbidden)
+						return
+					}
+					w.Write([]byte(`{"id": "` + id + `", "version": {"number": 2}}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			opts := &convertOptions{
+				space:   "DEV",
+				dryRun:  tt.dryRun,
+				force:   tt.force,
+				output:  "json",
+				noColor: true,
+				stdin:   strings.NewReader(tt.stdin),
+			}
+
+			err := runConvert("", opts, client)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantUpdates, updates)
+		})
+	}
+}
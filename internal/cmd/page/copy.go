@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
 	"github.com/open-cli-collective/confluence-cli/internal/config"
 	"github.com/open-cli-collective/confluence-cli/internal/view"
 )
@@ -72,7 +73,12 @@ func runCopy(pageID string, opts *copyOptions, client *api.Client) error {
 		if err := cfg.Validate(); err != nil {
 			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
 		}
-		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken)
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
 	}
 
 	// If no destination space specified, get source page's space key
@@ -110,7 +116,7 @@ func runCopy(pageID string, opts *copyOptions, client *api.Client) error {
 	// Render output
 	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
 
-	if opts.output == "json" {
+	if view.IsStructured(opts.output) {
 		return renderer.RenderJSON(newPage)
 	}
 
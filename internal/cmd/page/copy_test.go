@@ -198,7 +198,7 @@ func TestRunCopy_GetSourcePageFails(t *testing.T) {
 		noColor: true,
 	}
 
-	err := runCopy("invalid", opts, client)
+	err := runCopy("99999999", opts, client)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to get source page")
 }
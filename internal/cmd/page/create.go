@@ -12,7 +12,11 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
 	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/mermaid"
+	"github.com/open-cli-collective/confluence-cli/internal/plantuml"
+	"github.com/open-cli-collective/confluence-cli/internal/tmpl"
 	"github.com/open-cli-collective/confluence-cli/internal/view"
 	"github.com/open-cli-collective/confluence-cli/pkg/md"
 )
@@ -21,13 +25,41 @@ type createOptions struct {
 	space    string
 	title    string
 	parent   string
+	labels   []string
 	file     string
+	template string   // Template file or name of a stored template
+	fromTmpl string   // ID of a Confluence template
+	vars     []string // Template variables as key=value
 	editor   bool
 	markdown *bool // nil = auto-detect, true = force markdown, false = force storage format
 	legacy   bool  // Use legacy editor (storage format) instead of cloud editor (ADF)
+	draft    bool  // Create the page as an unpublished draft
 	output   string
 	noColor  bool
 	stdin    io.Reader // For testing; defaults to os.Stdin
+
+	inputFormat string // Format of the content: "" or auto, markdown, storage or adf
+
+	fromDir     string    // Directory of markdown files to publish as pages
+	concurrency int       // Pages created at once with --from-dir; zero for config or the default
+	stdout      io.Writer // For testing; defaults to os.Stdout
+
+	legacyAuto   bool     // --legacy wasn't given, so the space decides the editor format
+	legacySpaces []string // Spaces published in legacy format by default; loaded from config
+
+	diagramMacro string                 // Viewer macro for .drawio references
+	codeTabs     bool                   // Group tabbed code blocks into ui-tabs macros
+	glossary     bool                   // Link glossary terms to their definition pages
+	glossaryCfg  *config.GlossaryConfig // Glossary terms; loaded from config when nil
+	jira         config.JiraConfig      // Jira issue linking; loaded from config
+	plantuml     *plantuml.Renderer     // Renders plantuml fences; nil leaves them as code
+	mermaid      string                 // How mermaid fences are published: "" (code), macro or image
+	mermaidMacro string                 // Macro for --mermaid macro; loaded from config when empty
+	mermaidImg   *mermaid.Renderer      // Renders mermaid fences for --mermaid image; built from config when nil
+	templateDir  string                 // Stored templates; defaults to config.TemplateDir()
+
+	classification config.ClassificationConfig // Required classification labels; loaded from config
+	images         imageOptions                // Optimization of local images; completed from config
 }
 
 // NewCmdCreate creates the page create command.
@@ -40,17 +72,81 @@ func NewCmdCreate() *cobra.Command {
 		Long: `Create a new Confluence page.
 
 By default, pages are created using the cloud editor format (ADF).
-Use --legacy to create pages in the legacy editor format.
+Use --legacy to create pages in the legacy editor format. Spaces listed under
+legacy_spaces in the config file (or CFL_LEGACY_SPACES) default to the legacy
+editor format; --legacy=false creates a cloud editor page there.
 
 Content can be provided via:
 - --file flag to read from a file
+- --template flag to render a template with --var values
+- --from-template flag to start from a Confluence template
 - Standard input (pipe content)
 - Interactive editor (default, or with --editor flag)
 
 Content format:
-- Markdown is the default for stdin, editor, and .md files
+- Markdown is the default for the editor and .md files
+- Piped content is detected: an ADF JSON document is published as ADF, and
+  content starting with a storage format element, such as <p> or <ac:...>,
+  as storage format; anything else is treated as markdown
+- Files with .html/.xhtml extensions are treated as storage format
+- Storage format is converted for the cloud editor; ADF can't be used with --legacy
+- Use --input-format to set the format instead of detecting it
 - Use --no-markdown to provide raw Confluence format (XHTML for legacy, ADF JSON for cloud)
-- Files with .html/.xhtml extensions are treated as storage format`,
+
+Templates are Go text/template files, given as a path or as the name of a
+template stored with 'cfl template add'. {{.version}} is replaced by the
+value of --var version=...; a variable without a value is an error. The
+format follows the extension before .tmpl, as in release-notes.md.tmpl.
+
+--from-template creates the page from a Confluence page or blueprint
+template, by the ID shown by 'cfl template list --space'. Template variables
+are filled in with --var, and the template's labels are added to the page.
+
+Markdown may start with YAML front matter, such as the output of
+'cfl page view --front-matter'. Its title, space and labels are used when
+--title and --space aren't given, and its labels are added to --label.
+
+Local images (.png, .jpg, .jpeg, .gif, .webp, .bmp, .svg) and .drawio files
+referenced from markdown are uploaded as attachments and the references
+rewritten to point at them. Images are shown inline and .drawio files with
+the draw.io viewer macro (or Gliffy, with --diagram-macro gliffy).
+
+` + "```plantuml" + ` code blocks are rendered to SVG and attached when a PlantUML
+jar or server is configured (plantuml.jar / plantuml.server in the config
+file, or CFL_PLANTUML_JAR / CFL_PLANTUML_SERVER).
+
+` + "```mermaid" + ` code blocks are published as code unless --mermaid is given:
+--mermaid image renders them to SVG attachments with mermaid-cli (mmdc, or
+mermaid.mmdc / CFL_MERMAID_MMDC; mermaid.format: png for PNG), and
+--mermaid macro --legacy converts them to the macro of a Mermaid app on the
+instance (mermaid.macro in the config file, default "mermaid").
+
+Adjacent code blocks annotated with a tab name (` + "```go tab=Go" + `) are
+published as titled code blocks. With --legacy --code-tabs they are grouped
+into a ui-tabs macro, which needs a tabs macro app on the instance.
+
+With --glossary, the first occurrence of each glossary term is linked to its
+definition page. Terms come from glossary.terms (term: page ID or URL) and
+the page titles of glossary.space in the config file. Write {!term} to keep
+an occurrence unlinked.
+
+When classification.labels is set in the config file, the page must be
+labeled with one of them (from --label, front matter or the template). A
+page without one gets classification.default, or is refused when there is
+no default.
+
+With --draft, the page is created as a draft that only you can see, to be
+reviewed and published later with 'cfl page publish'.
+
+--from-dir publishes a directory of markdown files as new pages under
+--parent, laid out as by 'cfl page sync': each subdirectory becomes a page
+for the files inside it, with its index.md as content. Every file is
+converted before any page is created, then pages are created a level at a
+time with up to --concurrency at once (default: config concurrency or 4).
+Relative links between the files, such as [Setup](guide/setup.md), become
+links to their pages. --label applies to every page. A manifest maps each
+path to the page created for it; use 'cfl page sync' to keep the pages
+updated afterwards.`,
 		Example: `  # Create a page with title (opens markdown editor, cloud editor format)
   cfl page create --space DEV --title "My Page"
 
@@ -69,8 +165,29 @@ Content format:
   # Create from stdin with legacy format (XHTML)
   echo "<p>Hello</p>" | cfl page create -s DEV -t "My Page" --no-markdown --legacy
 
+  # Create from a template
+  cfl page create -s DEV -t "Release 2.3" --template release-notes.md.tmpl --var version=2.3 --var date="$(date +%F)"
+
+  # Create from a stored template
+  cfl page create -s DEV -t "Release 2.3" --template release-notes --var version=2.3
+
+  # Create from a Confluence template
+  cfl page create -s DEV -t "Retro 42" --from-template 98305 --var team=Platform
+
   # Create as child of another page
-  cfl page create -s DEV -t "Child Page" --parent 12345`,
+  cfl page create -s DEV -t "Child Page" --parent 12345
+
+  # Create with labels
+  cfl page create -s DEV -t "Release 2.0" --file notes.md --label release --label v2
+
+  # Stage a generated page for review before it goes live
+  cfl page create -s DEV -t "Weekly Report" --file report.md --draft
+
+  # Copy a page to another space, taking title and labels from front matter
+  cfl page view 12345 --front-matter | cfl page create -s OPS
+
+  # Publish a directory of markdown files and save the manifest
+  cfl page create --from-dir ./docs -s DEV --parent 12345 -o json > manifest.json`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			opts.output, _ = cmd.Flags().GetString("output")
 			opts.noColor, _ = cmd.Flags().GetBool("no-color")
@@ -84,30 +201,81 @@ Content format:
 
 			// Handle legacy flag
 			opts.legacy, _ = cmd.Flags().GetBool("legacy")
+			opts.legacyAuto = !cmd.Flags().Changed("legacy")
+			opts.images.optimizeSet = cmd.Flags().Changed("optimize")
 
 			return runCreate(opts, nil)
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key (required)")
-	cmd.Flags().StringVarP(&opts.title, "title", "t", "", "Page title (required)")
-	cmd.Flags().StringVarP(&opts.parent, "parent", "p", "", "Parent page ID")
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key (default: front matter space or config default_space)")
+	cmd.Flags().StringVarP(&opts.title, "title", "t", "", "Page title (default: front matter title)")
+	cmd.Flags().StringVarP(&opts.parent, "parent", "p", "", "Parent page (ID, URL, or SPACE/Title)")
+	cmd.Flags().StringSliceVar(&opts.labels, "label", nil, "Add a label to the page (repeatable)")
 	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Read content from file")
+	cmd.Flags().StringVar(&opts.template, "template", "", "Render content from a template file or stored template")
+	cmd.Flags().StringVar(&opts.fromTmpl, "from-template", "", "Create the page from a Confluence template (template ID)")
+	cmd.Flags().StringArrayVar(&opts.vars, "var", nil, "Set a template variable as key=value (repeatable)")
 	cmd.Flags().BoolVar(&opts.editor, "editor", false, "Open editor for content")
+	cmd.Flags().BoolVar(&opts.draft, "draft", false, "Create the page as a draft, to publish later with 'cfl page publish'")
 	cmd.Flags().Bool("no-markdown", false, "Disable markdown conversion (use raw XHTML)")
+	cmd.Flags().StringVar(&opts.inputFormat, "input-format", "", inputFormatUsage)
 	cmd.Flags().Bool("legacy", false, "Create page in legacy editor format (default: cloud editor)")
+	cmd.Flags().StringVar(&opts.diagramMacro, "diagram-macro", md.DiagramMacroDrawio, "Macro for embedded .drawio files: drawio or gliffy")
+	cmd.Flags().BoolVar(&opts.codeTabs, "code-tabs", false, "Group tabbed code blocks into ui-tabs macros (requires --legacy)")
+	cmd.Flags().BoolVar(&opts.glossary, "glossary", false, "Link first occurrences of configured glossary terms")
+	cmd.Flags().StringVar(&opts.mermaid, "mermaid", "", "Publish mermaid code blocks as a macro or rendered image: macro or image")
+	cmd.Flags().StringVar(&opts.fromDir, "from-dir", "", "Create a page for each markdown file in a directory")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 0, fmt.Sprintf("Maximum pages created at once with --from-dir (default: config concurrency or %d)", defaultFromDirConcurrency))
+	addImageFlags(cmd, &opts.images)
 
-	_ = cmd.MarkFlagRequired("title")
+	cmd.MarkFlagsMutuallyExclusive("no-markdown", "input-format")
 
 	return cmd
 }
 
 func runCreate(opts *createOptions, client *api.Client) error {
+	if opts.diagramMacro == "" {
+		opts.diagramMacro = md.DiagramMacroDrawio
+	}
+	if err := validateDiagramMacro(opts.diagramMacro); err != nil {
+		return err
+	}
+	// Without --legacy the space may still default to the legacy editor, so
+	// the options needing it are checked again once the space is known
+	if err := validateLegacyOptions(opts.codeTabs, opts.mermaid, opts.legacy || opts.legacyAuto); err != nil {
+		return err
+	}
+	if err := validateLabels(opts.labels); err != nil {
+		return err
+	}
+	if err := validateInputFormat(opts.inputFormat); err != nil {
+		return err
+	}
+	if err := opts.images.validate(); err != nil {
+		return err
+	}
+	if err := validateFromDir(opts); err != nil {
+		return err
+	}
+	if opts.template != "" && opts.file != "" {
+		return fmt.Errorf("--template and --file cannot be used together")
+	}
+	if opts.fromTmpl != "" && (opts.template != "" || opts.file != "") {
+		return fmt.Errorf("--from-template cannot be used with --template or --file")
+	}
+	if len(opts.vars) > 0 && opts.template == "" && opts.fromTmpl == "" {
+		return fmt.Errorf("--var requires --template or --from-template")
+	}
+	if _, err := tmpl.ParseVars(opts.vars); err != nil {
+		return err
+	}
+
 	// Track base URL for output (only available when loading config)
 	var baseURL string
 
-	// Determine space
-	spaceKey := opts.space
+	// Space from config, used when neither --space nor front matter names one
+	var defaultSpace string
 
 	// Create API client if not provided (allows injection for testing)
 	if client == nil {
@@ -120,18 +288,113 @@ func runCreate(opts *createOptions, client *api.Client) error {
 			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
 		}
 
-		// Use default space from config if not specified
-		if spaceKey == "" {
-			spaceKey = cfg.DefaultSpace
+		defaultSpace = cfg.DefaultSpace
+		opts.legacySpaces = cfg.LegacySpaces
+
+		if opts.plantuml == nil {
+			opts.plantuml = plantuml.New(cfg.PlantUML.Jar, cfg.PlantUML.Server, config.DiagramCacheDir("plantuml"))
+		}
+		if opts.mermaidImg == nil {
+			opts.mermaidImg = mermaid.New(cfg.Mermaid.Mmdc, cfg.Mermaid.Format, config.DiagramCacheDir("mermaid"))
+		}
+		if opts.mermaidMacro == "" {
+			opts.mermaidMacro = cfg.Mermaid.Macro
+		}
+		if opts.glossaryCfg == nil {
+			opts.glossaryCfg = &cfg.Glossary
+		}
+		opts.jira = cfg.Jira
+		opts.classification = cfg.Classification
+		opts.images = opts.images.withConfig(cfg.Images)
+		if opts.concurrency <= 0 {
+			opts.concurrency = cfg.Concurrency
 		}
 
 		baseURL = cfg.URL
-		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken)
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
 	}
 
+	if opts.parent != "" {
+		resolved, err := pageref.Resolve(context.Background(), client, opts.parent)
+		if err != nil {
+			return err
+		}
+		opts.parent = resolved
+	}
+
+	if opts.fromDir != "" {
+		spaceKey := opts.space
+		if spaceKey == "" {
+			spaceKey = defaultSpace
+		}
+		return createFromDir(context.Background(), client, opts, spaceKey)
+	}
+
+	// Get content and determine if markdown conversion is needed
+	var content, format string
+	var templateLabels []string
+	var err error
+	if opts.fromTmpl != "" {
+		content, templateLabels, err = confluenceTemplateContent(context.Background(), client, opts)
+	} else {
+		content, format, err = getContent(opts)
+	}
+	if err != nil {
+		return err
+	}
+	isMarkdown := format == formatMarkdown
+
+	// Validate content is not empty
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("page content cannot be empty")
+	}
+
+	// Front matter (as written by 'cfl page view --front-matter') supplies the
+	// title, space and labels that aren't given as flags
+	title, spaceKey := opts.title, opts.space
+	labels := append(append([]string(nil), opts.labels...), templateLabels...)
+	if isMarkdown {
+		fm, _, err := md.ParseFrontMatter([]byte(content))
+		if err != nil {
+			return err
+		}
+		if fm != nil {
+			if title == "" {
+				title = fm.Title
+			}
+			if spaceKey == "" {
+				spaceKey = fm.Space
+			}
+			labels = append(append([]string(nil), labels...), fm.Labels...)
+			if err := validateLabels(labels); err != nil {
+				return err
+			}
+		}
+	}
+	if title == "" {
+		return fmt.Errorf("title is required: use --title or a title in the front matter")
+	}
+	if spaceKey == "" {
+		spaceKey = defaultSpace
+	}
 	if spaceKey == "" {
 		return fmt.Errorf("space is required: use --space flag or set default_space in config")
 	}
+	if labels, err = classify(opts.classification, labels); err != nil {
+		return err
+	}
+
+	if opts.legacyAuto {
+		opts.legacy = config.IsLegacySpace(opts.legacySpaces, spaceKey)
+		if err := validateLegacyOptions(opts.codeTabs, opts.mermaid, opts.legacy); err != nil {
+			return err
+		}
+	}
+
+	// Storage or ADF content may need converting for the editor used
+	if content, err = editorContent(context.Background(), client, content, format, opts.legacy); err != nil {
+		return err
+	}
 
 	// Get space ID
 	space, err := client.GetSpaceByKey(context.Background(), spaceKey)
@@ -139,29 +402,52 @@ func runCreate(opts *createOptions, client *api.Client) error {
 		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
 	}
 
-	// Get content and determine if markdown conversion is needed
-	content, isMarkdown, err := getContent(opts)
+	var glossary map[string]string
+	if opts.glossary {
+		glossary, err = glossaryLinks(context.Background(), client, opts.glossaryCfg, title)
+		if err != nil {
+			return err
+		}
+	}
+
+	content = expandTOC(content, isMarkdown, title, opts.legacy)
+	content = linkGlossary(content, isMarkdown, glossary)
+	content = linkJira(content, isMarkdown, opts.legacy, opts.jira, client.BaseURL())
+	content = qualifyPageLinks(content, isMarkdown, opts.legacy, space.Key)
+
+	content, err = renderDiagrams(opts.plantuml, mermaidImages(opts.mermaid, opts.mermaidImg), content, isMarkdown)
 	if err != nil {
 		return err
 	}
 
-	// Validate content is not empty
-	if strings.TrimSpace(content) == "" {
-		return fmt.Errorf("page content cannot be empty")
+	// Find referenced local files before creating anything, so a missing
+	// file doesn't leave a half-published page behind
+	baseDir := "."
+	if opts.file != "" {
+		baseDir = filepath.Dir(opts.file)
+	}
+	files, err := findLocalFiles(content, isMarkdown, baseDir)
+	if err != nil {
+		return err
 	}
+	source := content
 
-	// Build request body based on legacy flag
-	var body *api.Body
+	// Convert content based on legacy flag
+	content, err = convertEditContent(content, isMarkdown, conversion{
+		legacy:   opts.legacy,
+		codeTabs: opts.codeTabs,
+		embeds:   plannedEmbeds(files, opts.diagramMacro),
+
+		mermaid:      opts.mermaid,
+		mermaidMacro: opts.mermaidMacro,
+	})
+	if err != nil {
+		return err
+	}
 
+	var body *api.Body
 	if opts.legacy {
 		// Legacy mode: use storage format (XHTML)
-		if isMarkdown {
-			converted, err := md.ToConfluenceStorage([]byte(content))
-			if err != nil {
-				return fmt.Errorf("failed to convert markdown: %w", err)
-			}
-			content = converted
-		}
 		body = &api.Body{
 			Storage: &api.BodyRepresentation{
 				Representation: "storage",
@@ -170,13 +456,6 @@ func runCreate(opts *createOptions, client *api.Client) error {
 		}
 	} else {
 		// Default: cloud editor using ADF
-		if isMarkdown {
-			adfContent, err := md.ToADF([]byte(content))
-			if err != nil {
-				return fmt.Errorf("failed to convert markdown to ADF: %w", err)
-			}
-			content = adfContent
-		}
 		body = &api.Body{
 			AtlasDocFormat: &api.BodyRepresentation{
 				Representation: "atlas_doc_format",
@@ -188,8 +467,8 @@ func runCreate(opts *createOptions, client *api.Client) error {
 	// Create page
 	req := &api.CreatePageRequest{
 		SpaceID: space.ID,
-		Title:   opts.title,
-		Status:  "current",
+		Title:   title,
+		Status:  pageStatus(opts.draft),
 		Body:    body,
 	}
 
@@ -199,26 +478,118 @@ func runCreate(opts *createOptions, client *api.Client) error {
 
 	page, err := client.CreatePage(context.Background(), req)
 	if err != nil {
+		err = checkADFSupport(context.Background(), client, err, opts.legacy)
 		return fmt.Errorf("failed to create page: %w", err)
 	}
 
+	if len(files) > 0 {
+		if page, err = attachLocalFiles(client, page, source, files, opts.legacy, opts.diagramMacro, opts.images); err != nil {
+			return err
+		}
+	}
+
+	if len(labels) > 0 {
+		if _, err := client.AddLabels(context.Background(), page.ID, labels); err != nil {
+			return fmt.Errorf("page %s was created but adding labels failed: %w", page.ID, err)
+		}
+	}
+
 	// Render output
 	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
 
-	if opts.output == "json" {
+	if view.IsStructured(opts.output) {
 		return renderer.RenderJSON(page)
 	}
 
-	renderer.Success(fmt.Sprintf("Created page: %s", page.Title))
+	if opts.draft {
+		renderer.Success(fmt.Sprintf("Created draft: %s (publish it with 'cfl page publish %s')", page.Title, page.ID))
+	} else {
+		renderer.Success(fmt.Sprintf("Created page: %s", page.Title))
+	}
 	renderer.RenderKeyValue("ID", page.ID)
 	renderer.RenderKeyValue("URL", baseURL+page.Links.WebUI)
 
 	return nil
 }
 
-// getContent reads content and returns (content, isMarkdown, error).
-// isMarkdown indicates whether the content should be converted from markdown.
-func getContent(opts *createOptions) (string, bool, error) {
+// validateLabels checks the names given with --label.
+func validateLabels(labels []string) error {
+	for _, label := range labels {
+		if err := api.ValidateLabel(label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attachLocalFiles uploads referenced files to a newly created page. Cloud
+// editor images reference uploaded media by ID, so the page body is
+// regenerated and updated once the IDs are known.
+func attachLocalFiles(client *api.Client, page *api.Page, source string, files []localFile, legacy bool, diagramMacro string, images imageOptions) (*api.Page, error) {
+	embeds, err := uploadLocalFiles(context.Background(), client, page.ID, files, diagramMacro, images)
+	if err != nil {
+		return nil, fmt.Errorf("page %s was created but attaching files failed: %w", page.ID, err)
+	}
+
+	if legacy || !needsMediaIDs(files) {
+		return page, nil
+	}
+
+	content, err := convertEditContent(source, true, conversion{embeds: embeds})
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := client.UpdatePage(context.Background(), page.ID, &api.UpdatePageRequest{
+		ID:     page.ID,
+		Status: pageStatus(page.Status == "draft"),
+		Title:  page.Title,
+		Body:   newEditBody(content, false),
+		Version: &api.Version{
+			Number:  nextVersion(page),
+			Message: "Embedded attachments via cfl",
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("page %s was created but embedding attachments failed: %w", page.ID, err)
+	}
+	return updated, nil
+}
+
+// confluenceTemplateContent returns the body of the Confluence template
+// opts.fromTmpl with its variables filled in, in the format of the page to
+// create, and the template's labels.
+func confluenceTemplateContent(ctx context.Context, client *api.Client, opts *createOptions) (string, []string, error) {
+	vars, err := tmpl.ParseVars(opts.vars)
+	if err != nil {
+		return "", nil, err
+	}
+	template, err := client.GetTemplate(ctx, opts.fromTmpl)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get template %s: %w", opts.fromTmpl, err)
+	}
+	content, err := template.Expand(vars)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Templates are stored in storage format, which the cloud editor can't use
+	if !opts.legacy {
+		if content, err = client.ConvertStorageToADF(ctx, content); err != nil {
+			return "", nil, fmt.Errorf("failed to convert template to the cloud editor format: %w", err)
+		}
+	}
+
+	var labels []string
+	for _, l := range template.Labels {
+		labels = append(labels, l.Name)
+	}
+	return content, labels, nil
+}
+
+// getContent reads content and returns (content, format, error), where
+// format is one of the format constants.
+func getContent(opts *createOptions) (string, string, error) {
 	// Determine if we should use markdown based on explicit flag or file extension
 	useMarkdown := func(filename string) bool {
 		// If explicitly set via flag, use that
@@ -243,33 +614,61 @@ func getContent(opts *createOptions) (string, bool, error) {
 	if opts.file != "" {
 		data, err := os.ReadFile(opts.file)
 		if err != nil {
-			return "", false, fmt.Errorf("failed to read file: %w", err)
+			return "", "", fmt.Errorf("failed to read file: %w", err)
+		}
+		content := string(data)
+		return content, contentFormat(content, opts.inputFormat, opts.markdown, useMarkdown(opts.file), false), nil
+	}
+
+	// Render a template
+	if opts.template != "" {
+		dir := opts.templateDir
+		if dir == "" {
+			dir = config.TemplateDir()
+		}
+		path, err := tmpl.Find(dir, opts.template)
+		if err != nil {
+			return "", "", err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read template: %w", err)
+		}
+		vars, err := tmpl.ParseVars(opts.vars)
+		if err != nil {
+			return "", "", err
+		}
+		content, err := tmpl.Render(path, string(data), vars)
+		if err != nil {
+			return "", "", err
 		}
-		return string(data), useMarkdown(opts.file), nil
+		return content, contentFormat(content, opts.inputFormat, opts.markdown, useMarkdown(tmpl.ContentName(path)), false), nil
 	}
 
 	// Check if stdin has data (use injected stdin for testing)
 	if opts.stdin != nil {
 		data, err := io.ReadAll(opts.stdin)
 		if err != nil {
-			return "", false, fmt.Errorf("failed to read stdin: %w", err)
+			return "", "", fmt.Errorf("failed to read stdin: %w", err)
 		}
-		return string(data), useMarkdown(""), nil
+		content := string(data)
+		return content, contentFormat(content, opts.inputFormat, opts.markdown, useMarkdown(""), true), nil
 	}
 
 	stat, _ := os.Stdin.Stat()
 	if (stat.Mode() & os.ModeCharDevice) == 0 {
 		data, err := io.ReadAll(os.Stdin)
 		if err != nil {
-			return "", false, fmt.Errorf("failed to read stdin: %w", err)
+			return "", "", fmt.Errorf("failed to read stdin: %w", err)
 		}
-		return string(data), useMarkdown(""), nil
+		content := string(data)
+		return content, contentFormat(content, opts.inputFormat, opts.markdown, useMarkdown(""), true), nil
 	}
 
 	// Open editor (markdown mode)
-	isMarkdown := useMarkdown("")
-	content, err := openEditor(isMarkdown)
-	return content, isMarkdown, err
+	format := contentFormat("", opts.inputFormat, opts.markdown, useMarkdown(""), false)
+	content, err := openEditor(format == formatMarkdown)
+	return content, format, err
 }
 
 func openEditor(isMarkdown bool) (string, error) {
@@ -1,7 +1,11 @@
 package page
 
 import (
+	"bytes"
 	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -14,6 +18,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/plantuml"
 )
 
 // mockCreateServer creates a test server that handles GetSpaceByKey and CreatePage requests
@@ -67,6 +73,28 @@ func TestRunCreate_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRunCreate_Draft(t *testing.T) {
+	var received api.CreatePageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Query().Get("keys") != "":
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v2/pages":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.Write([]byte(`{"id": "99999", "status": "draft", "title": "Report", "version": {"number": 1}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &createOptions{space: "DEV", title: "Report", stdin: strings.NewReader("# Report"), draft: true, noColor: true}
+	require.NoError(t, runCreate(opts, client))
+	assert.Equal(t, "draft", received.Status)
+}
+
 func TestRunCreate_HTMLFile_Legacy(t *testing.T) {
 	// Create temp HTML file - should be treated as storage format in legacy mode
 	tmpDir := t.TempDir()
@@ -639,3 +667,786 @@ func TestRunCreate_WhitespaceOnlyFile(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "page content cannot be empty")
 }
+
+func TestRunCreate_FrontMatterSummary(t *testing.T) {
+	tests := []struct {
+		name   string
+		legacy bool
+		field  string
+		want   []string
+	}{
+		{"legacy", true, "storage", []string{`ac:name="excerpt"`, `<p>Short summary</p>`, "<h1"}},
+		{"cloud", false, "atlas_doc_format", []string{`"extensionKey":"excerpt"`, `"text":"Short summary"`, `"type":"heading"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedBody map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+				case r.Method == "POST" && strings.Contains(r.URL.Path, "/pages"):
+					body, _ := io.ReadAll(r.Body)
+					json.Unmarshal(body, &receivedBody)
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(`{"id": "99999", "title": "Test", "version": {"number": 1}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			opts := &createOptions{
+				space:   "DEV",
+				title:   "Test Page",
+				stdin:   strings.NewReader("---\nsummary: Short summary\n---\n# Hello\n"),
+				legacy:  tt.legacy,
+				noColor: true,
+			}
+
+			err := runCreate(opts, client)
+			require.NoError(t, err)
+
+			bodyMap := receivedBody["body"].(map[string]interface{})
+			fieldMap := bodyMap[tt.field].(map[string]interface{})
+			content := fieldMap["value"].(string)
+
+			for _, want := range tt.want {
+				assert.Contains(t, content, want)
+			}
+			assert.NotContains(t, content, "summary:")
+		})
+	}
+}
+
+func TestRunCreate_EmbedsLocalFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "img"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "img", "arch.svg"), []byte("<svg/>"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "img", "screen.png"), []byte("\x89PNG"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "flow.drawio"), []byte("<mxfile/>"), 0644))
+	mdFile := filepath.Join(dir, "page.md")
+	require.NoError(t, os.WriteFile(mdFile, []byte("# Design\n\n![Arch](img/arch.svg)\n\n![Screen](./img/screen.png)\n\n![Flow](flow.drawio)\n"), 0644))
+
+	tests := []struct {
+		name        string
+		legacy      bool
+		wantUpdate  bool
+		wantContent []string
+	}{
+		{"legacy", true, false, []string{`ri:filename="arch.svg"`, `ri:filename="screen.png"`, `ac:name="drawio"`}},
+		{"cloud", false, true, []string{`"type":"mediaSingle"`, `"id":"file-arch.svg"`, `"id":"file-screen.png"`, `"collection":"contentId-99999"`, `"extensionKey":"drawio"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uploaded []string
+			var created, updated map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+				case r.Method == "POST" && strings.Contains(r.URL.Path, "/pages"):
+					body, _ := io.ReadAll(r.Body)
+					json.Unmarshal(body, &created)
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(`{"id": "99999", "title": "Design", "version": {"number": 1}}`))
+				case r.Method == "PUT" && r.URL.Path == "/rest/api/content/99999/child/attachment":
+					_, header, err := r.FormFile("file")
+					require.NoError(t, err)
+					uploaded = append(uploaded, header.Filename)
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(`{"results": [{"id": "att1", "title": "` + header.Filename + `", "extensions": {"fileId": "file-` + header.Filename + `"}}]}`))
+				case r.Method == "PUT" && r.URL.Path == "/api/v2/pages/99999":
+					body, _ := io.ReadAll(r.Body)
+					json.Unmarshal(body, &updated)
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(`{"id": "99999", "title": "Design", "version": {"number": 2}}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			opts := &createOptions{
+				space:   "DEV",
+				title:   "Design",
+				file:    mdFile,
+				legacy:  tt.legacy,
+				noColor: true,
+			}
+
+			err := runCreate(opts, client)
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, []string{"arch.svg", "screen.png", "flow.drawio"}, uploaded)
+
+			final := created
+			if tt.wantUpdate {
+				require.NotNil(t, updated)
+				assert.Equal(t, float64(2), updated["version"].(map[string]interface{})["number"])
+				final = updated
+			} else {
+				assert.Nil(t, updated)
+			}
+
+			field := "atlas_doc_format"
+			if tt.legacy {
+				field = "storage"
+			}
+			content := final["body"].(map[string]interface{})[field].(map[string]interface{})["value"].(string)
+			for _, want := range tt.wantContent {
+				assert.Contains(t, content, want)
+			}
+		})
+	}
+}
+
+func TestRunCreate_OptimizesLocalImages(t *testing.T) {
+	dir := t.TempDir()
+	img := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 400; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, (&png.Encoder{CompressionLevel: png.NoCompression}).Encode(&buf, img))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "screen.png"), buf.Bytes(), 0644))
+	mdFile := filepath.Join(dir, "page.md")
+	require.NoError(t, os.WriteFile(mdFile, []byte("![Screen](screen.png)\n"), 0644))
+
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+		case r.Method == "POST" && strings.Contains(r.URL.Path, "/pages"):
+			w.Write([]byte(`{"id": "99999", "title": "Design", "version": {"number": 1}}`))
+		case r.Method == "PUT" && r.URL.Path == "/rest/api/content/99999/child/attachment":
+			file, _, err := r.FormFile("file")
+			require.NoError(t, err)
+			uploaded, _ = io.ReadAll(file)
+			w.Write([]byte(`{"results": [{"id": "att1", "title": "screen.png"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &createOptions{
+		space:   "DEV",
+		title:   "Design",
+		file:    mdFile,
+		legacy:  true,
+		noColor: true,
+		images:  imageOptions{optimize: true, maxWidth: 100},
+	}
+	require.NoError(t, runCreate(opts, client))
+
+	cfg, err := png.DecodeConfig(bytes.NewReader(uploaded))
+	require.NoError(t, err)
+	assert.Equal(t, 100, cfg.Width)
+	assert.Equal(t, 50, cfg.Height)
+}
+
+func TestImageOptions_WithConfig(t *testing.T) {
+	cfg := config.ImagesConfig{Optimize: true, MaxWidth: 1280, Quality: 70}
+
+	assert.Equal(t, imageOptions{optimize: true, maxWidth: 800, quality: 70},
+		imageOptions{maxWidth: 800}.withConfig(cfg))
+	assert.Equal(t, imageOptions{optimizeSet: true, maxWidth: 1280, quality: 70},
+		imageOptions{optimizeSet: true}.withConfig(cfg))
+}
+
+func TestRunCreate_MissingReferencedFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces") {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+			return
+		}
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &createOptions{
+		space:   "DEV",
+		title:   "Design",
+		stdin:   strings.NewReader("![Missing](does-not-exist.svg)\n"),
+		noColor: true,
+	}
+
+	err := runCreate(opts, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does-not-exist.svg")
+}
+
+func TestRunCreate_InvalidDiagramMacro(t *testing.T) {
+	opts := &createOptions{space: "DEV", title: "T", diagramMacro: "visio"}
+	err := runCreate(opts, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid diagram macro")
+}
+
+func TestRunCreate_RendersPlantUML(t *testing.T) {
+	diagramServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("<svg>rendered</svg>"))
+	}))
+	defer diagramServer.Close()
+
+	var uploaded []string
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+		case r.Method == "POST" && strings.Contains(r.URL.Path, "/pages"):
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &created)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "99999", "title": "Flow", "version": {"number": 1}}`))
+		case r.Method == "PUT" && strings.Contains(r.URL.Path, "/child/attachment"):
+			_, header, err := r.FormFile("file")
+			require.NoError(t, err)
+			uploaded = append(uploaded, header.Filename)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"results": [{"id": "att1", "title": "` + header.Filename + `"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &createOptions{
+		space:    "DEV",
+		title:    "Flow",
+		stdin:    strings.NewReader("# Flow\n\n```plantuml\nA -> B\n```\n"),
+		legacy:   true,
+		noColor:  true,
+		plantuml: &plantuml.Renderer{Server: diagramServer.URL, CacheDir: t.TempDir()},
+	}
+
+	err := runCreate(opts, client)
+	require.NoError(t, err)
+
+	require.Len(t, uploaded, 1)
+	assert.True(t, strings.HasPrefix(uploaded[0], "plantuml-"))
+
+	content := created["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"].(string)
+	assert.Contains(t, content, `<ri:attachment ri:filename="`+uploaded[0]+`" />`)
+	assert.NotContains(t, content, "A -&gt; B")
+}
+
+func TestRunCreate_CodeTabs(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+		case r.Method == "POST" && strings.Contains(r.URL.Path, "/pages"):
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &receivedBody)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "99999", "title": "Examples", "version": {"number": 1}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &createOptions{
+		space:    "DEV",
+		title:    "Examples",
+		stdin:    strings.NewReader("```go tab=Go\ngo run .\n```\n\n```sh tab=Shell\n./run\n```\n"),
+		legacy:   true,
+		codeTabs: true,
+		noColor:  true,
+	}
+
+	require.NoError(t, runCreate(opts, client))
+
+	content := receivedBody["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"].(string)
+	assert.Contains(t, content, `ac:name="ui-tabs"`)
+	assert.Equal(t, 2, strings.Count(content, `ac:name="ui-tab"`))
+}
+
+func TestRunCreate_CodeTabsRequiresLegacy(t *testing.T) {
+	opts := &createOptions{space: "DEV", title: "T", codeTabs: true}
+	err := runCreate(opts, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--code-tabs requires --legacy")
+}
+
+func TestRunCreate_MermaidMacro(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+		case r.Method == "POST" && strings.Contains(r.URL.Path, "/pages"):
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &receivedBody)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "99999", "title": "Flow", "version": {"number": 1}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &createOptions{
+		space:        "DEV",
+		title:        "Flow",
+		stdin:        strings.NewReader("# Flow\n\n```mermaid\ngraph TD; A-->B\n```\n"),
+		legacy:       true,
+		noColor:      true,
+		mermaid:      "macro",
+		mermaidMacro: "mermaid-cloud",
+	}
+
+	require.NoError(t, runCreate(opts, client))
+
+	content := receivedBody["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"].(string)
+	assert.Contains(t, content, `<ac:structured-macro ac:name="mermaid-cloud" ac:schema-version="1"><ac:plain-text-body><![CDATA[graph TD; A-->B]]>`)
+	assert.NotContains(t, content, "language-mermaid")
+}
+
+func TestRunCreate_InvalidMermaid(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *createOptions
+		wantErr string
+	}{
+		{"unknown mode", &createOptions{space: "DEV", title: "T", mermaid: "png"}, "invalid mermaid mode"},
+		{"macro without legacy", &createOptions{space: "DEV", title: "T", mermaid: "macro"}, "--mermaid macro requires --legacy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runCreate(tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunCreate_Labels(t *testing.T) {
+	tmpDir := t.TempDir()
+	mdFile := filepath.Join(tmpDir, "content.md")
+	require.NoError(t, os.WriteFile(mdFile, []byte("# Hello"), 0644))
+
+	var labelBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v2/pages":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "99999", "title": "Test", "version": {"number": 1}}`))
+		case r.Method == "POST" && r.URL.Path == "/rest/api/content/99999/label":
+			body, _ := io.ReadAll(r.Body)
+			labelBody = string(body)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"results": []}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &createOptions{
+		space:   "DEV",
+		title:   "Test Page",
+		file:    mdFile,
+		labels:  []string{"release", "v2"},
+		noColor: true,
+	}
+
+	err := runCreate(opts, client)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"prefix": "global", "name": "release"}, {"prefix": "global", "name": "v2"}]`, labelBody)
+}
+
+func TestRunCreate_InvalidLabel(t *testing.T) {
+	client := api.NewClient("http://unused", "test@example.com", "token")
+	opts := &createOptions{
+		space:  "DEV",
+		title:  "Test Page",
+		labels: []string{"two words"},
+	}
+
+	err := runCreate(opts, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid label")
+}
+
+func TestRunCreate_FrontMatter(t *testing.T) {
+	var spaceKeys []string
+	var receivedBody map[string]interface{}
+	var labelBody []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/spaces":
+			key := r.URL.Query().Get("keys")
+			spaceKeys = append(spaceKeys, key)
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "` + key + `"}]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v2/pages":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &receivedBody)
+			w.Write([]byte(`{"id": "99999", "title": "Runbook", "version": {"number": 1}}`))
+		case r.Method == "POST" && r.URL.Path == "/rest/api/content/99999/label":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &labelBody)
+			w.Write([]byte(`{"results": []}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	content := "---\ntitle: Runbook\nid: \"12345\"\nspace: DEV\nversion: 4\nlabels: [ops]\n---\n# Steps\n"
+
+	tests := []struct {
+		name      string
+		opts      *createOptions
+		wantSpace string
+		wantTitle string
+	}{
+		{"from front matter", &createOptions{}, "DEV", "Runbook"},
+		{"flags take precedence", &createOptions{space: "OPS", title: "Copy"}, "OPS", "Copy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spaceKeys, receivedBody, labelBody = nil, nil, nil
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			tt.opts.stdin = strings.NewReader(content)
+			tt.opts.noColor = true
+
+			err := runCreate(tt.opts, client)
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.wantSpace}, spaceKeys)
+			assert.Equal(t, tt.wantTitle, receivedBody["title"])
+			require.Len(t, labelBody, 1)
+			assert.Equal(t, "ops", labelBody[0]["name"])
+		})
+	}
+}
+
+func TestRunCreate_MissingTitle(t *testing.T) {
+	client := api.NewClient("http://unused", "test@example.com", "token")
+	opts := &createOptions{
+		space:   "DEV",
+		stdin:   strings.NewReader("# Hello\n"),
+		noColor: true,
+	}
+
+	err := runCreate(opts, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "title is required")
+}
+
+func TestRunCreate_Template(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v2/pages":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &receivedBody)
+			w.Write([]byte(`{"id": "99999", "title": "Release 2.3", "version": {"number": 1}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "release-notes.md.tmpl"),
+		[]byte("# Release {{.version}}\n\nShipped on {{.date}}.\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "note.html.tmpl"),
+		[]byte("<p>Release {{.version}}</p>"), 0644))
+
+	tests := []struct {
+		name     string
+		template string
+		legacy   bool
+		want     string
+	}{
+		{"file path", filepath.Join(dir, "release-notes.md.tmpl"), true, "<h1"},
+		{"stored name", "release-notes", true, "Shipped on 2026-10-16."},
+		{"storage template", "note", true, "<p>Release 2.3</p>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receivedBody = nil
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			err := runCreate(&createOptions{
+				space:       "DEV",
+				title:       "Release 2.3",
+				template:    tt.template,
+				vars:        []string{"version=2.3", "date=2026-10-16"},
+				templateDir: dir,
+				legacy:      tt.legacy,
+				noColor:     true,
+			}, client)
+			require.NoError(t, err)
+
+			storage := receivedBody["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"].(string)
+			assert.Contains(t, storage, tt.want)
+			assert.Contains(t, storage, "2.3")
+		})
+	}
+}
+
+func TestRunCreate_TemplateErrors(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.md.tmpl"), []byte("# {{.version}}\n"), 0644))
+
+	tests := []struct {
+		name    string
+		opts    *createOptions
+		wantErr string
+	}{
+		{"with file", &createOptions{template: "notes", file: "page.md"}, "--template and --file cannot be used together"},
+		{"var without template", &createOptions{vars: []string{"a=b"}}, "--var requires --template or --from-template"},
+		{"bad var", &createOptions{template: "notes", vars: []string{"version"}}, `invalid variable "version"`},
+		{"missing var", &createOptions{template: "notes"}, "--var key=value"},
+		{"unknown template", &createOptions{template: "nope"}, "template not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.space = "DEV"
+			tt.opts.title = "Notes"
+			tt.opts.templateDir = dir
+			err := runCreate(tt.opts, api.NewClient("http://unused", "test@example.com", "token"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunCreate_FromTemplate(t *testing.T) {
+	const templateBody = `<at:declarations><at:string at:name="team" /></at:declarations><h1>Retro of <at:var at:name="team" /></h1>`
+
+	tests := []struct {
+		name   string
+		legacy bool
+		field  string
+		want   string
+	}{
+		{"legacy", true, "storage", "<h1>Retro of Platform</h1>"},
+		{"cloud", false, "atlas_doc_format", `{"type":"doc","converted":"<h1>Retro of Platform</h1>"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedBody map[string]interface{}
+			var labelBody []map[string]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/rest/api/template/98305":
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"templateId": "98305",
+						"name":       "Retrospective",
+						"labels":     []map[string]string{{"name": "retrospective", "prefix": "global"}},
+						"body":       map[string]interface{}{"storage": map[string]string{"value": templateBody}},
+					})
+				case r.Method == "POST" && r.URL.Path == "/rest/api/contentbody/convert/atlas_doc_format":
+					var req map[string]string
+					data, _ := io.ReadAll(r.Body)
+					json.Unmarshal(data, &req)
+					json.NewEncoder(w).Encode(map[string]string{
+						"representation": "atlas_doc_format",
+						"value":          `{"type":"doc","converted":"` + req["value"] + `"}`,
+					})
+				case r.Method == "GET" && r.URL.Path == "/api/v2/spaces":
+					w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+				case r.Method == "POST" && r.URL.Path == "/api/v2/pages":
+					data, _ := io.ReadAll(r.Body)
+					json.Unmarshal(data, &receivedBody)
+					w.Write([]byte(`{"id": "99999", "title": "Retro 42", "version": {"number": 1}}`))
+				case r.Method == "POST" && r.URL.Path == "/rest/api/content/99999/label":
+					data, _ := io.ReadAll(r.Body)
+					json.Unmarshal(data, &labelBody)
+					w.Write([]byte(`{"results": []}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			err := runCreate(&createOptions{
+				space:    "DEV",
+				title:    "Retro 42",
+				fromTmpl: "98305",
+				vars:     []string{"team=Platform"},
+				labels:   []string{"q3"},
+				legacy:   tt.legacy,
+				noColor:  true,
+			}, client)
+			require.NoError(t, err)
+
+			content := receivedBody["body"].(map[string]interface{})[tt.field].(map[string]interface{})["value"].(string)
+			assert.Equal(t, tt.want, content)
+			require.Len(t, labelBody, 2)
+			assert.Equal(t, "q3", labelBody[0]["name"])
+			assert.Equal(t, "retrospective", labelBody[1]["name"])
+		})
+	}
+}
+
+func TestRunCreate_FromTemplateErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"templateId": "98305", "name": "Retrospective",
+			"body": {"storage": {"value": "<p><at:var at:name=\"team\" /></p>"}}}`))
+	}))
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	err := runCreate(&createOptions{space: "DEV", title: "Retro", fromTmpl: "98305", legacy: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "template Retrospective needs values for team")
+
+	err = runCreate(&createOptions{space: "DEV", title: "Retro", fromTmpl: "98305", file: "page.md"}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--from-template cannot be used with --template or --file")
+}
+
+func TestRunCreate_LegacySpaceDefault(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "OLD"}]}`))
+		case r.Method == "POST" && strings.Contains(r.URL.Path, "/pages"):
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &receivedBody)
+			w.Write([]byte(`{"id": "99999", "title": "Tabs", "version": {"number": 1}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &createOptions{
+		space:        "OLD",
+		title:        "Tabs",
+		stdin:        strings.NewReader("```go tab=Go\nfmt.Println()\n```\n\n```python tab=Python\nprint()\n```\n"),
+		codeTabs:     true,
+		legacyAuto:   true,
+		legacySpaces: []string{"OLD"},
+		noColor:      true,
+	}
+
+	require.NoError(t, runCreate(opts, client))
+
+	body := receivedBody["body"].(map[string]interface{})
+	require.Contains(t, body, "storage")
+	assert.Contains(t, body["storage"].(map[string]interface{})["value"], `ac:name="ui-tabs"`)
+}
+
+func TestRunCreate_LegacySpaceDefault_OtherSpace(t *testing.T) {
+	opts := &createOptions{
+		space:        "DEV",
+		title:        "T",
+		stdin:        strings.NewReader("# Hello"),
+		codeTabs:     true,
+		legacyAuto:   true,
+		legacySpaces: []string{"OLD"},
+	}
+	err := runCreate(opts, api.NewClient("http://unused.invalid", "test@example.com", "token"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--code-tabs requires --legacy")
+}
+
+func TestRunCreate_PageLinksInSpace(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+		case r.Method == "POST" && strings.Contains(r.URL.Path, "/pages"):
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &receivedBody)
+			w.Write([]byte(`{"id": "99999", "title": "Links", "version": {"number": 1}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &createOptions{
+		space:   "DEV",
+		title:   "Links",
+		stdin:   strings.NewReader("See [[Release Notes]] and [[OPS:Runbook|the runbook]]."),
+		noColor: true,
+	}
+
+	require.NoError(t, runCreate(opts, client))
+
+	content := receivedBody["body"].(map[string]interface{})["atlas_doc_format"].(map[string]interface{})["value"].(string)
+	assert.Contains(t, content, `"href":"/wiki/display/DEV/Release+Notes"`)
+	assert.Contains(t, content, `"href":"/wiki/display/OPS/Runbook"`)
+}
+
+func TestRunCreate_Classification(t *testing.T) {
+	var requests []string
+	var labelBody []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v2/pages":
+			w.Write([]byte(`{"id": "99999", "title": "Plan", "version": {"number": 1}}`))
+		case r.Method == "POST" && r.URL.Path == "/rest/api/content/99999/label":
+			json.NewDecoder(r.Body).Decode(&labelBody)
+			w.Write([]byte(`{"results": []}`))
+		}
+	}))
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	classification := config.ClassificationConfig{Labels: []string{"public", "internal"}}
+
+	// Without a default, a page lacking a classification label is refused
+	opts := &createOptions{space: "DEV", title: "Plan", stdin: strings.NewReader("# Plan"), labels: []string{"draft"}, noColor: true, classification: classification}
+	err := runCreate(opts, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "page must carry a classification label (public, internal)")
+	assert.Empty(t, requests, "nothing is created")
+
+	// Front matter labels count
+	opts = &createOptions{space: "DEV", title: "Plan", stdin: strings.NewReader("---\nlabels: [public]\n---\n# Plan"), noColor: true, classification: classification}
+	require.NoError(t, runCreate(opts, client))
+	require.Len(t, labelBody, 1)
+	assert.Equal(t, "public", labelBody[0]["name"])
+
+	// The default is added to unclassified pages
+	classification.Default = "internal"
+	opts = &createOptions{space: "DEV", title: "Plan", stdin: strings.NewReader("# Plan"), labels: []string{"draft"}, noColor: true, classification: classification}
+	require.NoError(t, runCreate(opts, client))
+	require.Len(t, labelBody, 2)
+	assert.Equal(t, "draft", labelBody[0]["name"])
+	assert.Equal(t, "internal", labelBody[1]["name"])
+}
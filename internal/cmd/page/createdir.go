@@ -0,0 +1,347 @@
+package page
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// defaultFromDirConcurrency is how many pages page create --from-dir
+// creates at once.
+const defaultFromDirConcurrency = 4
+
+// dirPage is a page to create for a file or directory with --from-dir.
+type dirPage struct {
+	item    syncItem
+	source  string // Markdown prepared for publishing, before conversion
+	content string // Body converted for the editor
+	files   []localFile
+	labels  []string
+}
+
+// dirManifestEntry maps a file or directory to the page created for it.
+type dirManifestEntry struct {
+	Path  string `json:"path"`
+	Title string `json:"title"`
+	ID    string `json:"id"`
+	URL   string `json:"url,omitempty"`
+}
+
+// validateFromDir checks that --from-dir isn't combined with flags for a
+// single page's content.
+func validateFromDir(opts *createOptions) error {
+	if opts.fromDir == "" {
+		return nil
+	}
+	if opts.file != "" || opts.template != "" || opts.fromTmpl != "" || opts.title != "" || opts.editor {
+		return fmt.Errorf("--from-dir cannot be used with --file, --template, --from-template, --title or --editor")
+	}
+	if opts.markdown != nil || opts.inputFormat != "" {
+		return fmt.Errorf("--from-dir publishes markdown files and cannot be used with --no-markdown or --input-format")
+	}
+	if opts.draft {
+		return fmt.Errorf("--from-dir cannot be used with --draft, as pages can't be created under a draft")
+	}
+	return nil
+}
+
+// createFromDir publishes a directory of markdown files as new pages
+// under opts.parent, laid out as for page sync, and prints which page each
+// path became. Every file is read and converted before any page is
+// created, so a broken file doesn't leave a half-published tree.
+//
+// Titles are known once the directory is scanned, so relative links
+// between the files become page links by title and resolve whichever page
+// is created first. Pages are created a level at a time, parents before
+// their children, with up to opts.concurrency requests at once.
+func createFromDir(ctx context.Context, client *api.Client, opts *createOptions, spaceKey string) error {
+	info, err := os.Stat(opts.fromDir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", opts.fromDir)
+	}
+	if spaceKey == "" {
+		return fmt.Errorf("space is required: use --space flag or set default_space in config")
+	}
+	if opts.legacyAuto {
+		opts.legacy = config.IsLegacySpace(opts.legacySpaces, spaceKey)
+		if err := validateLegacyOptions(opts.codeTabs, opts.mermaid, opts.legacy); err != nil {
+			return err
+		}
+	}
+
+	items, err := scanSyncDir(opts.fromDir)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("no markdown files in %s", opts.fromDir)
+	}
+	titles := make(map[string]string, len(items))
+	byTitle := make(map[string]string, len(items))
+	for _, item := range items {
+		if other, ok := byTitle[strings.ToLower(item.title)]; ok {
+			return fmt.Errorf("%s and %s would both be titled %q; page titles must be unique within a space", other, item.key, item.title)
+		}
+		byTitle[strings.ToLower(item.title)] = item.key
+		titles[item.key] = item.title
+	}
+
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	var glossary map[string]string
+	if opts.glossary {
+		if glossary, err = glossaryLinks(ctx, client, opts.glossaryCfg, ""); err != nil {
+			return err
+		}
+	}
+
+	pages := make([]*dirPage, len(items))
+	for i, item := range items {
+		if pages[i], err = prepareDirPage(client, opts, titles, item, space.Key, glossary); err != nil {
+			return fmt.Errorf("failed to prepare %s: %w", item.key, err)
+		}
+	}
+
+	concurrency := opts.concurrency
+	if concurrency <= 0 {
+		concurrency = defaultFromDirConcurrency
+	}
+	created, createErr := createDirPages(ctx, client, opts, space.ID, pages, concurrency)
+
+	manifest := make([]dirManifestEntry, 0, len(created))
+	for _, p := range pages {
+		if page := created[p.item.key]; page != nil {
+			manifest = append(manifest, dirManifestEntry{Path: p.item.key, Title: page.Title, ID: page.ID, URL: client.BaseURL() + page.Links.WebUI})
+		}
+	}
+
+	if err := renderManifest(opts, manifest); err != nil {
+		return err
+	}
+	if createErr != nil && len(manifest) > 0 {
+		return fmt.Errorf("%w (%d of %d pages were created)", createErr, len(manifest), len(pages))
+	}
+	return createErr
+}
+
+// renderManifest prints which page each path became.
+func renderManifest(opts *createOptions, manifest []dirManifestEntry) error {
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(manifest)
+	}
+	if len(manifest) == 0 {
+		return nil
+	}
+
+	rows := make([][]string, 0, len(manifest))
+	for _, e := range manifest {
+		rows = append(rows, []string{e.Path, e.Title, e.ID, e.URL})
+	}
+	renderer.RenderTable([]string{"PATH", "TITLE", "ID", "URL"}, rows)
+	renderer.Success(fmt.Sprintf("Created %d pages from %s", len(manifest), opts.fromDir))
+	return nil
+}
+
+// prepareDirPage reads an item's markdown and converts it for publishing.
+// titles are the titles of all items by key, for links between them.
+// Directories without an index.md become empty pages.
+func prepareDirPage(client *api.Client, opts *createOptions, titles map[string]string, item syncItem, spaceKey string, glossary map[string]string) (*dirPage, error) {
+	labels := append(append([]string(nil), opts.labels...), item.labels...)
+	if err := validateLabels(labels); err != nil {
+		return nil, err
+	}
+	labels, err := classify(opts.classification, labels)
+	if err != nil {
+		return nil, err
+	}
+	p := &dirPage{item: item, labels: labels}
+
+	if item.file != "" {
+		data, err := os.ReadFile(item.file)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(opts.fromDir, item.file)
+		if err != nil {
+			return nil, err
+		}
+		content := string(md.LinkLocalPages(data, func(dest string) (md.PageLink, bool) {
+			key, ok := localPageKey(titles, filepath.ToSlash(rel), dest)
+			return md.PageLink{Space: spaceKey, Title: titles[key]}, ok
+		}))
+
+		content = expandTOC(content, true, item.title, opts.legacy)
+		content = linkGlossary(content, true, withoutTerm(glossary, item.title))
+		content = linkJira(content, true, opts.legacy, opts.jira, client.BaseURL())
+		content = qualifyPageLinks(content, true, opts.legacy, spaceKey)
+		if content, err = renderDiagrams(opts.plantuml, mermaidImages(opts.mermaid, opts.mermaidImg), content, true); err != nil {
+			return nil, err
+		}
+		if p.files, err = findLocalFiles(content, true, filepath.Dir(item.file)); err != nil {
+			return nil, err
+		}
+		p.source = content
+	}
+
+	p.content, err = convertEditContent(p.source, true, conversion{
+		legacy:   opts.legacy,
+		codeTabs: opts.codeTabs,
+		embeds:   plannedEmbeds(p.files, opts.diagramMacro),
+
+		mermaid:      opts.mermaid,
+		mermaidMacro: opts.mermaidMacro,
+	})
+	return p, err
+}
+
+// localPageKey returns the key of the item a relative link in the file at
+// from (a slash-separated path relative to the directory) points to:
+// another markdown file, or a subdirectory or its index.md.
+func localPageKey(titles map[string]string, from, dest string) (string, bool) {
+	dest, _, _ = strings.Cut(dest, "#")
+	dest, _, _ = strings.Cut(dest, "?")
+	if decoded, err := url.PathUnescape(dest); err == nil {
+		dest = decoded
+	}
+	dest = filepath.ToSlash(dest)
+	if dest == "" || path.IsAbs(dest) {
+		return "", false
+	}
+
+	target := path.Join(path.Dir(from), dest)
+	if target == ".." || strings.HasPrefix(target, "../") {
+		return "", false
+	}
+	if strings.EqualFold(path.Base(target), "index.md") {
+		target = path.Dir(target)
+	}
+
+	for _, key := range []string{target, target + "/"} {
+		if _, ok := titles[key]; ok {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// withoutTerm returns glossary links without the term naming the page
+// itself, which shouldn't link to itself.
+func withoutTerm(glossary map[string]string, title string) map[string]string {
+	if len(glossary) == 0 {
+		return glossary
+	}
+	links := make(map[string]string, len(glossary))
+	for term, target := range glossary {
+		if !strings.EqualFold(term, title) {
+			links[term] = target
+		}
+	}
+	return links
+}
+
+// createDirPages creates the pages a level at a time, running up to
+// concurrency creates at once, and returns the pages created by key.
+// Creating stops after the level in which a page fails.
+func createDirPages(ctx context.Context, client *api.Client, opts *createOptions, spaceID string, pages []*dirPage, concurrency int) (map[string]*api.Page, error) {
+	var levels [][]*dirPage
+	for _, p := range pages {
+		depth := strings.Count(strings.TrimSuffix(p.item.key, "/"), "/")
+		for len(levels) <= depth {
+			levels = append(levels, nil)
+		}
+		levels[depth] = append(levels[depth], p)
+	}
+
+	created := make(map[string]*api.Page, len(pages))
+	var mu sync.Mutex
+	sem := make(chan struct{}, concurrency)
+	for _, level := range levels {
+		// Parents were created in earlier levels; look them up before the
+		// workers start adding to created
+		parentIDs := make([]string, len(level))
+		for i, p := range level {
+			parentIDs[i] = opts.parent
+			if p.item.parent != "" {
+				parentIDs[i] = created[p.item.parent].ID
+			}
+		}
+
+		errs := make([]error, len(level))
+		var wg sync.WaitGroup
+		for i, p := range level {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				page, err := createDirPage(ctx, client, opts, spaceID, parentIDs[i], p)
+				if page != nil {
+					mu.Lock()
+					created[p.item.key] = page
+					mu.Unlock()
+				}
+				if err != nil {
+					errs[i] = fmt.Errorf("failed to create %s: %w", p.item.key, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if err := errors.Join(errs...); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// createDirPage creates one page, attaching its files and adding its
+// labels. The page is returned with any error after it was created.
+func createDirPage(ctx context.Context, client *api.Client, opts *createOptions, spaceID, parentID string, p *dirPage) (*api.Page, error) {
+	page, err := client.CreatePage(ctx, &api.CreatePageRequest{
+		SpaceID:  spaceID,
+		Title:    p.item.title,
+		Status:   "current",
+		ParentID: parentID,
+		Body:     newEditBody(p.content, opts.legacy),
+	})
+	if err != nil {
+		return nil, checkADFSupport(ctx, client, err, opts.legacy)
+	}
+
+	if len(p.files) > 0 {
+		updated, err := attachLocalFiles(client, page, p.source, p.files, opts.legacy, opts.diagramMacro, opts.images)
+		if err != nil {
+			return page, err
+		}
+		page = updated
+	}
+
+	if len(p.labels) > 0 {
+		if _, err := client.AddLabels(ctx, page.ID, p.labels); err != nil {
+			return page, fmt.Errorf("page %s was created but adding labels failed: %w", page.ID, err)
+		}
+	}
+	return page, nil
+}
@@ -0,0 +1,236 @@
+package page
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// fromDirServer records the pages created in space DEV. Creating a page
+// titled failTitle fails.
+type fromDirServer struct {
+	*httptest.Server
+	failTitle string
+
+	mu       sync.Mutex
+	pages    map[string]api.CreatePageRequest // By title
+	ids      map[string]string                // Page ID by title
+	labels   map[string][]string              // Labels by page ID
+	running  int
+	maxInUse int
+}
+
+func newFromDirServer(t *testing.T) *fromDirServer {
+	s := &fromDirServer{pages: map[string]api.CreatePageRequest{}, ids: map[string]string{}, labels: map[string][]string{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "100", "key": "DEV"}]}`))
+
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/pages":
+			var req api.CreatePageRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+			s.mu.Lock()
+			s.running++
+			s.maxInUse = max(s.maxInUse, s.running)
+			s.mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.running--
+			if req.Title == s.failTitle {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"message": "bad page"}`))
+				return
+			}
+			id := fmt.Sprint(1000 + len(s.pages))
+			s.pages[req.Title] = req
+			s.ids[req.Title] = id
+			fmt.Fprintf(w, `{"id": %q, "title": %q, "version": {"number": 1}, "_links": {"webui": "/pages/%s"}}`, id, req.Title, id)
+
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/label"):
+			var labels []struct {
+				Name string `json:"name"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&labels))
+			id := strings.Split(r.URL.Path, "/")[4]
+			s.mu.Lock()
+			for _, l := range labels {
+				s.labels[id] = append(s.labels[id], l.Name)
+			}
+			s.mu.Unlock()
+			w.Write([]byte(`{"results": []}`))
+
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+// writeFromDir writes a directory of markdown files for --from-dir.
+func writeFromDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestRunCreate_FromDir(t *testing.T) {
+	dir := writeFromDir(t, map[string]string{
+		"overview.md":       "---\ntitle: Overview\nlabels: [intro]\n---\nSee [the setup guide](guide/setup.md#install) and [the guide](guide/).\n",
+		"guide/index.md":    "# Guide\n\nStart with [setup](setup.md).\n",
+		"guide/setup.md":    "Back to [the overview](../overview.md), or [elsewhere](https://example.com/x.md).\n",
+		"guide/advanced.md": "Advanced.\n",
+		"api/ref.md":        "Reference.\n",
+	})
+
+	server := newFromDirServer(t)
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	var out bytes.Buffer
+	opts := &createOptions{fromDir: dir, space: "DEV", parent: "42", labels: []string{"docs"}, legacy: true, concurrency: 2, output: "json", stdout: &out}
+	require.NoError(t, runCreate(opts, client))
+
+	// Directories are created under the parent, and files under them
+	require.Len(t, server.pages, 6)
+	assert.Equal(t, "42", server.pages["Overview"].ParentID)
+	assert.Equal(t, "42", server.pages["guide"].ParentID)
+	assert.Equal(t, "42", server.pages["api"].ParentID)
+	assert.Equal(t, server.ids["guide"], server.pages["setup"].ParentID)
+	assert.Equal(t, server.ids["api"], server.pages["ref"].ParentID)
+	assert.LessOrEqual(t, server.maxInUse, 2)
+
+	// Relative links become page links; others are left alone
+	overview := server.pages["Overview"].Body.Storage.Value
+	assert.Contains(t, overview, `<ri:page ri:space-key="DEV" ri:content-title="setup" />`)
+	assert.Contains(t, overview, `<ri:page ri:space-key="DEV" ri:content-title="guide" />`)
+	setup := server.pages["setup"].Body.Storage.Value
+	assert.Contains(t, setup, `ri:content-title="Overview"`)
+	assert.Contains(t, setup, `href="https://example.com/x.md"`)
+	assert.Contains(t, server.pages["guide"].Body.Storage.Value, `ri:content-title="setup"`)
+
+	// --label applies to every page, with front matter labels added
+	assert.Equal(t, []string{"docs", "intro"}, server.labels[server.ids["Overview"]])
+	assert.Equal(t, []string{"docs"}, server.labels[server.ids["ref"]])
+
+	var manifest []dirManifestEntry
+	require.NoError(t, json.Unmarshal(out.Bytes(), &manifest))
+	require.Len(t, manifest, 6)
+	assert.Equal(t, dirManifestEntry{Path: "overview.md", Title: "Overview", ID: server.ids["Overview"], URL: server.URL + "/pages/" + server.ids["Overview"]}, manifest[5])
+	assert.Equal(t, []string{"api/", "api/ref.md", "guide/"}, []string{manifest[0].Path, manifest[1].Path, manifest[2].Path})
+}
+
+func TestRunCreate_FromDir_Failure(t *testing.T) {
+	dir := writeFromDir(t, map[string]string{
+		"a.md":     "A\n",
+		"b.md":     "B\n",
+		"sub/c.md": "C\n",
+	})
+
+	server := newFromDirServer(t)
+	server.failTitle = "b"
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	var out bytes.Buffer
+	err := runCreate(&createOptions{fromDir: dir, space: "DEV", legacy: true, output: "json", stdout: &out}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create b.md")
+	assert.Contains(t, err.Error(), "(2 of 4 pages were created)")
+
+	// The next level isn't created, and the manifest lists what was
+	assert.NotContains(t, server.pages, "c")
+	var manifest []dirManifestEntry
+	require.NoError(t, json.Unmarshal(out.Bytes(), &manifest))
+	assert.Len(t, manifest, 2)
+}
+
+func TestRunCreate_FromDir_Errors(t *testing.T) {
+	dir := writeFromDir(t, map[string]string{
+		"a.md":     "---\ntitle: Same\n---\nA\n",
+		"b.md":     "---\ntitle: same\n---\nB\n",
+		"img.md":   "![x](missing.png)\n",
+		"empty/x":  "not markdown",
+		"notes.md": "notes",
+	})
+	missingImage := writeFromDir(t, map[string]string{"img.md": "![x](missing.png)\n"})
+	noMarkdown := writeFromDir(t, map[string]string{"readme.txt": "text"})
+
+	server := newFromDirServer(t)
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	tests := []struct {
+		name    string
+		opts    *createOptions
+		wantErr string
+	}{
+		{"with file", &createOptions{fromDir: dir, file: "x.md"}, "--from-dir cannot be used with --file"},
+		{"with title", &createOptions{fromDir: dir, title: "X"}, "--from-dir cannot be used with"},
+		{"with input format", &createOptions{fromDir: dir, inputFormat: "storage"}, "cannot be used with --no-markdown or --input-format"},
+		{"with draft", &createOptions{fromDir: dir, draft: true}, "--from-dir cannot be used with --draft"},
+		{"missing dir", &createOptions{fromDir: filepath.Join(dir, "nope"), space: "DEV"}, "failed to read directory"},
+		{"no space", &createOptions{fromDir: dir}, "space is required"},
+		{"duplicate titles", &createOptions{fromDir: dir, space: "DEV"}, `a.md and b.md would both be titled "same"`},
+		{"missing image", &createOptions{fromDir: missingImage, space: "DEV"}, "failed to prepare img.md: referenced file missing.png"},
+		{"no markdown", &createOptions{fromDir: noMarkdown, space: "DEV"}, "no markdown files in"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.stdout = io.Discard
+			err := runCreate(tt.opts, client)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+	assert.Empty(t, server.pages)
+}
+
+func TestLocalPageKey(t *testing.T) {
+	titles := map[string]string{"a.md": "A", "guide/": "Guide", "guide/setup.md": "Setup", "my notes.md": "Notes"}
+	tests := []struct {
+		from, dest string
+		want       string
+	}{
+		{"a.md", "guide/setup.md", "guide/setup.md"},
+		{"a.md", "./guide/setup.md#install", "guide/setup.md"},
+		{"a.md", "guide", "guide/"},
+		{"a.md", "guide/INDEX.md", "guide/"},
+		{"a.md", "my%20notes.md", "my notes.md"},
+		{"guide/setup.md", "../a.md", "a.md"},
+		{"guide/index.md", "setup.md", "guide/setup.md"},
+		{"a.md", "../outside.md", ""},
+		{"a.md", "/abs/a.md", ""},
+		{"a.md", "missing.md", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+" "+tt.dest, func(t *testing.T) {
+			got, ok := localPageKey(titles, tt.from, tt.dest)
+			assert.Equal(t, tt.want != "", ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
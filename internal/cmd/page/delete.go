@@ -10,15 +10,23 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
 	"github.com/open-cli-collective/confluence-cli/internal/config"
 	"github.com/open-cli-collective/confluence-cli/internal/view"
 )
 
+// bulkDeleteSearchLimit is how many search results are fetched per request
+// when resolving the pages matched by --cql.
+const bulkDeleteSearchLimit = 100
+
 type deleteOptions struct {
 	force   bool
 	output  string
 	noColor bool
 	stdin   io.Reader // injectable for testing
+	cql     string
+	dryRun  bool
+	purge   bool
 }
 
 // NewCmdDelete creates the page delete command.
@@ -26,41 +34,87 @@ func NewCmdDelete() *cobra.Command {
 	opts := &deleteOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "delete <page-id>",
+		Use:   "delete [page-id]",
 		Short: "Delete a page",
-		Long:  `Delete a Confluence page by its ID.`,
+		Long: `Delete a Confluence page by its ID.
+
+Deleted pages are moved to the space's trash, where they can be restored.
+With --purge they are then removed from the trash as well, which cannot
+be undone.
+
+With --cql, every page matching the CQL query is deleted instead. The
+matches are listed and a single confirmation is asked for; --dry-run lists
+them without deleting anything. Pages are deleted one at a time and a
+failure does not stop the rest: a summary of what was deleted and what
+failed is printed at the end. Only pages are deleted; other content the
+query matches, such as blog posts or attachments, is ignored.`,
 		Example: `  # Delete a page
   cfl page delete 12345
 
   # Delete without confirmation
-  cfl page delete 12345 --force`,
-		Args: cobra.ExactArgs(1),
+  cfl page delete 12345 --force
+
+  # Preview which pages a query would delete
+  cfl page delete --cql 'space=TMP and created < now("-90d")' --dry-run
+
+  # Delete them permanently, without confirmation
+  cfl page delete --cql 'space=TMP and created < now("-90d")' --purge --yes`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.output, _ = cmd.Flags().GetString("output")
 			opts.noColor, _ = cmd.Flags().GetBool("no-color")
 			opts.stdin = os.Stdin // default to os.Stdin, can be overridden in tests
+			if opts.cql != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("--cql is incompatible with a page ID")
+				}
+				return runBulkDelete(opts, nil)
+			}
+			if opts.dryRun {
+				return fmt.Errorf("--dry-run requires --cql")
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("page ID or --cql is required")
+			}
 			return runDelete(args[0], opts, nil)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVarP(&opts.force, "yes", "y", false, "Skip confirmation prompt (alias for --force)")
+	cmd.Flags().StringVar(&opts.cql, "cql", "", "Delete every page matching this CQL query")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "List the pages --cql matches without deleting them")
+	cmd.Flags().BoolVar(&opts.purge, "purge", false, "Permanently delete instead of moving to the trash")
 
 	return cmd
 }
 
+// newDeleteClient creates an API client from the user's config.
+func newDeleteClient() (*api.Client, error) {
+	cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+	}
+
+	return api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...), nil
+}
+
 func runDelete(pageID string, opts *deleteOptions, client *api.Client) error {
 	// Create API client if not provided (allows injection for testing)
 	if client == nil {
-		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
-		if err != nil {
-			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
-		}
-
-		if err := cfg.Validate(); err != nil {
-			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		var err error
+		if client, err = newDeleteClient(); err != nil {
+			return err
 		}
+	}
 
-		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken)
+	pageID, err := pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
 	}
 
 	// Get page info first to show what we're deleting
@@ -74,34 +128,189 @@ func runDelete(pageID string, opts *deleteOptions, client *api.Client) error {
 	// Confirm deletion unless --force is used
 	if !opts.force {
 		fmt.Printf("About to delete page: %s (ID: %s)\n", page.Title, page.ID)
-		fmt.Print("Are you sure? [y/N]: ")
-
-		scanner := bufio.NewScanner(opts.stdin)
-		var confirm string
-		if scanner.Scan() {
-			confirm = scanner.Text()
-		}
-
-		if confirm != "y" && confirm != "Y" {
+		if !confirmDelete(opts.stdin) {
 			fmt.Println("Deletion cancelled.")
 			return nil
 		}
 	}
 
 	// Delete the page
-	if err := client.DeletePage(context.Background(), pageID); err != nil {
-		return fmt.Errorf("failed to delete page: %w", err)
+	status, err := deletePage(context.Background(), client, pageID, opts.purge)
+	if err != nil {
+		return err
 	}
 
-	if opts.output == "json" {
+	if view.IsStructured(opts.output) {
 		return renderer.RenderJSON(map[string]string{
-			"status":  "deleted",
+			"status":  status,
 			"page_id": pageID,
 			"title":   page.Title,
 		})
 	}
 
-	renderer.Success(fmt.Sprintf("Deleted page: %s (ID: %s)", page.Title, pageID))
+	if opts.purge {
+		renderer.Success(fmt.Sprintf("Permanently deleted page: %s (ID: %s)", page.Title, pageID))
+	} else {
+		renderer.Success(fmt.Sprintf("Deleted page: %s (ID: %s)", page.Title, pageID))
+	}
+
+	return nil
+}
+
+// bulkDeleteResult is the outcome of deleting one page matched by --cql.
+type bulkDeleteResult struct {
+	ID     string `json:"page_id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func runBulkDelete(opts *deleteOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		var err error
+		if client, err = newDeleteClient(); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	pages, err := searchPages(ctx, client, opts.cql)
+	if err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if len(pages) == 0 {
+		if view.IsStructured(opts.output) {
+			return renderer.RenderJSON([]bulkDeleteResult{})
+		}
+		renderer.RenderText("No pages match the query.")
+		return nil
+	}
+
+	if opts.dryRun {
+		if view.IsStructured(opts.output) {
+			results := make([]bulkDeleteResult, len(pages))
+			for i, p := range pages {
+				results[i] = bulkDeleteResult{ID: p.ID, Title: p.Title, Status: "would-delete"}
+			}
+			return renderer.RenderJSON(results)
+		}
+		rows := make([][]string, len(pages))
+		for i, p := range pages {
+			rows[i] = []string{p.ID, p.Title}
+		}
+		renderer.RenderTable([]string{"ID", "TITLE"}, rows)
+		if opts.output != "plain" {
+			fmt.Printf("\n%d page(s) would be deleted (dry run).\n", len(pages))
+		}
+		return nil
+	}
 
+	// Confirm deletion unless --force is used
+	if !opts.force {
+		for _, p := range pages {
+			fmt.Printf("  %s (ID: %s)\n", p.Title, p.ID)
+		}
+		action := "delete"
+		if opts.purge {
+			action = "permanently delete"
+		}
+		fmt.Printf("About to %s %d page(s).\n", action, len(pages))
+		if !confirmDelete(opts.stdin) {
+			fmt.Println("Deletion cancelled.")
+			return nil
+		}
+	}
+
+	results := make([]bulkDeleteResult, 0, len(pages))
+	failed := 0
+	progress := view.NewProgress(len(pages))
+	for _, p := range pages {
+		result := bulkDeleteResult{ID: p.ID, Title: p.Title}
+		if result.Status, err = deletePage(ctx, client, p.ID, opts.purge); err != nil {
+			result.Status = "failed"
+			result.Error = err.Error()
+			failed++
+		}
+		results = append(results, result)
+		progress.Increment(p.Title)
+	}
+	progress.Done()
+
+	if view.IsStructured(opts.output) {
+		if err := renderer.RenderJSON(results); err != nil {
+			return err
+		}
+	} else {
+		rows := make([][]string, len(results))
+		for i, r := range results {
+			rows[i] = []string{r.ID, r.Title, r.Status, r.Error}
+		}
+		renderer.RenderTable([]string{"ID", "TITLE", "STATUS", "ERROR"}, rows)
+		if opts.output != "plain" {
+			fmt.Printf("\n%d deleted, %d failed.\n", len(results)-failed, failed)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d page(s)", failed, len(results))
+	}
 	return nil
 }
+
+// searchPages returns every page matching cql, ignoring other content types.
+func searchPages(ctx context.Context, client *api.Client, cql string) ([]api.SearchContent, error) {
+	var pages []api.SearchContent
+	opts := &api.SearchOptions{CQL: cql, Limit: bulkDeleteSearchLimit}
+	for {
+		result, err := client.Search(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search: %w", err)
+		}
+		for _, r := range result.Results {
+			if r.Content.Type == "page" {
+				pages = append(pages, r.Content)
+			}
+		}
+		if !result.HasMore() || len(result.Results) == 0 {
+			return pages, nil
+		}
+		opts.Start += len(result.Results)
+	}
+}
+
+// deletePage deletes a page, purging it from the trash as well when purge is
+// set, and returns the resulting status.
+func deletePage(ctx context.Context, client *api.Client, pageID string, purge bool) (string, error) {
+	if err := client.DeletePage(ctx, pageID); err != nil {
+		return "", fmt.Errorf("failed to delete page: %w", err)
+	}
+	if !purge {
+		return "deleted", nil
+	}
+	if err := client.PurgePage(ctx, pageID); err != nil {
+		return "", fmt.Errorf("page moved to trash but failed to purge: %w", err)
+	}
+	return "purged", nil
+}
+
+// confirmDelete asks for a y/N confirmation on in.
+func confirmDelete(in io.Reader) bool {
+	fmt.Print("Are you sure? [y/N]: ")
+
+	scanner := bufio.NewScanner(in)
+	var confirm string
+	if scanner.Scan() {
+		confirm = scanner.Text()
+	}
+
+	return confirm == "y" || confirm == "Y"
+}
@@ -1,8 +1,10 @@
 package page
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -223,3 +225,162 @@ func TestRunDelete_ConfirmationInputs(t *testing.T) {
 		})
 	}
 }
+
+// bulkDeleteServer serves a CQL search over pages, two results per request,
+// and records the pages deleted and purged. Deleting a page in failIDs fails.
+type bulkDeleteServer struct {
+	pages   []api.SearchContent
+	failIDs map[string]bool
+	deleted []string
+	purged  []string
+}
+
+func (s *bulkDeleteServer) serve(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/rest/api/search":
+			assert.Equal(t, "space=TMP", r.URL.Query().Get("cql"))
+			start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+			end := min(start+2, len(s.pages))
+			var results []map[string]any
+			for _, p := range s.pages[start:end] {
+				results = append(results, map[string]any{"content": p})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"results":   results,
+				"start":     start,
+				"size":      end - start,
+				"totalSize": len(s.pages),
+			})
+		case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/api/v2/pages/"):
+			id := strings.TrimPrefix(r.URL.Path, "/api/v2/pages/")
+			if s.failIDs[id] {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			if r.URL.Query().Get("purge") == "true" {
+				s.purged = append(s.purged, id)
+			} else {
+				s.deleted = append(s.deleted, id)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newBulkDeleteServer() *bulkDeleteServer {
+	return &bulkDeleteServer{pages: []api.SearchContent{
+		{ID: "1", Type: "page", Title: "Old One"},
+		{ID: "2", Type: "blogpost", Title: "A Post"},
+		{ID: "3", Type: "page", Title: "Old Two"},
+		{ID: "4", Type: "page", Title: "Old Three"},
+	}}
+}
+
+func TestRunBulkDelete(t *testing.T) {
+	s := newBulkDeleteServer()
+	server := s.serve(t)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &deleteOptions{cql: "space=TMP", noColor: true, stdin: strings.NewReader("y\n")}
+
+	err := runBulkDelete(opts, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "3", "4"}, s.deleted)
+	assert.Empty(t, s.purged)
+}
+
+func TestRunBulkDelete_Cancelled(t *testing.T) {
+	s := newBulkDeleteServer()
+	server := s.serve(t)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &deleteOptions{cql: "space=TMP", noColor: true, stdin: strings.NewReader("n\n")}
+
+	err := runBulkDelete(opts, client)
+	require.NoError(t, err)
+	assert.Empty(t, s.deleted)
+}
+
+func TestRunBulkDelete_DryRun(t *testing.T) {
+	s := newBulkDeleteServer()
+	server := s.serve(t)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &deleteOptions{cql: "space=TMP", dryRun: true, noColor: true, stdin: strings.NewReader("")}
+
+	err := runBulkDelete(opts, client)
+	require.NoError(t, err)
+	assert.Empty(t, s.deleted)
+}
+
+func TestRunBulkDelete_Purge(t *testing.T) {
+	s := newBulkDeleteServer()
+	server := s.serve(t)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &deleteOptions{cql: "space=TMP", force: true, purge: true, noColor: true}
+
+	err := runBulkDelete(opts, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "3", "4"}, s.deleted)
+	assert.Equal(t, []string{"1", "3", "4"}, s.purged)
+}
+
+func TestRunBulkDelete_ContinuesOnFailure(t *testing.T) {
+	s := newBulkDeleteServer()
+	s.failIDs = map[string]bool{"3": true}
+	server := s.serve(t)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &deleteOptions{cql: "space=TMP", force: true, output: "json", noColor: true}
+
+	err := runBulkDelete(opts, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to delete 1 of 3 page(s)")
+	assert.Equal(t, []string{"1", "4"}, s.deleted)
+}
+
+func TestRunBulkDelete_NoMatches(t *testing.T) {
+	s := &bulkDeleteServer{}
+	server := s.serve(t)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &deleteOptions{cql: "space=TMP", noColor: true, stdin: strings.NewReader("")}
+
+	err := runBulkDelete(opts, client)
+	require.NoError(t, err)
+	assert.Empty(t, s.deleted)
+}
+
+func TestRunDelete_Purge(t *testing.T) {
+	var purged bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"id": "12345", "title": "Test Page", "version": {"number": 1}}`))
+		case "DELETE":
+			if r.URL.Query().Get("purge") == "true" {
+				purged = true
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &deleteOptions{force: true, purge: true, noColor: true}
+
+	err := runDelete("12345", opts, client)
+	require.NoError(t, err)
+	assert.True(t, purged)
+}
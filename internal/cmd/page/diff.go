@@ -0,0 +1,207 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+type diffOptions struct {
+	from    int
+	to      int // 0 for the current version
+	output  string
+	noColor bool
+}
+
+// NewCmdDiff creates the page diff command.
+func NewCmdDiff() *cobra.Command {
+	opts := &diffOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "diff <page-id>",
+		Short: "Show the changes between two versions of a page",
+		Long: `Show the changes between two versions of a page as a unified diff.
+
+Both versions are converted to markdown before they are compared, so the
+diff shows content changes rather than changes to Confluence's markup.
+Without --to, the version given by --from is compared with the current one.
+
+With --output json the result is an object with the two version numbers
+and the diff text.`,
+		Example: `  # What changed between versions 3 and 5
+  cfl page diff 12345 --from 3 --to 5
+
+  # What changed since version 3
+  cfl page diff 12345 --from 3`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runDiff(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.from, "from", 0, "Version to compare from (required)")
+	cmd.Flags().IntVar(&opts.to, "to", 0, "Version to compare to (default: current)")
+
+	_ = cmd.MarkFlagRequired("from")
+
+	return cmd
+}
+
+func runDiff(pageID string, opts *diffOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.from <= 0 {
+		return fmt.Errorf("invalid --from version: %d (must be >= 1)", opts.from)
+	}
+	if opts.to < 0 {
+		return fmt.Errorf("invalid --to version: %d (must be >= 1)", opts.to)
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	fromPage, fromMarkdown, err := versionMarkdown(ctx, client, pageID, opts.from)
+	if err != nil {
+		return err
+	}
+	toPage, toMarkdown, err := versionMarkdown(ctx, client, pageID, opts.to)
+	if err != nil {
+		return err
+	}
+
+	fromNumber, toNumber := opts.from, opts.to
+	if fromPage.Version != nil {
+		fromNumber = fromPage.Version.Number
+	}
+	if toPage.Version != nil {
+		toNumber = toPage.Version.Number
+	}
+
+	diff := md.UnifiedDiff(
+		fmt.Sprintf("%s (version %d)", fromPage.Title, fromNumber),
+		fmt.Sprintf("%s (version %d)", toPage.Title, toNumber),
+		fromMarkdown, toMarkdown)
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]any{
+			"page_id": pageID,
+			"from":    fromNumber,
+			"to":      toNumber,
+			"diff":    diff,
+		})
+	}
+
+	if diff == "" {
+		fmt.Printf("No differences between versions %d and %d.\n", fromNumber, toNumber)
+		return nil
+	}
+
+	if opts.output == "plain" {
+		fmt.Print(diff)
+		return nil
+	}
+	printDiff(diff)
+	return nil
+}
+
+// versionMarkdown gets a version of a page, or the current version when
+// version is 0, with its body converted to markdown.
+func versionMarkdown(ctx context.Context, client *api.Client, pageID string, version int) (*api.Page, string, error) {
+	page, body, dir, err := versionBody(ctx, client, pageID, version)
+	if err != nil || body == "" {
+		return page, "", err
+	}
+	var markdown string
+	if dir == md.DirectionFromADF {
+		markdown, err = md.FromADF(body)
+	} else {
+		markdown, err = md.FromConfluenceStorage(body)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to convert page to markdown: %w", err)
+	}
+	return page, markdown, nil
+}
+
+// versionBody gets a version of a page with its body in storage format,
+// or in ADF for pages authored in the cloud editor that only have that.
+// The direction says which conversion turns the body into markdown.
+func versionBody(ctx context.Context, client *api.Client, pageID string, version int) (*api.Page, string, md.Direction, error) {
+	page, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: "storage", Version: version})
+	if err != nil {
+		if version > 0 {
+			return nil, "", "", fmt.Errorf("failed to get version %d: %w", version, err)
+		}
+		return nil, "", "", fmt.Errorf("failed to get page: %w", err)
+	}
+	if page.Body != nil && page.Body.Storage != nil && page.Body.Storage.Value != "" {
+		return page, page.Body.Storage.Value, md.DirectionFromStorage, nil
+	}
+
+	adfPage, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: "atlas_doc_format", Version: version})
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to get page body in ADF: %w", err)
+	}
+	if adfPage.Body == nil || adfPage.Body.AtlasDocFormat == nil || adfPage.Body.AtlasDocFormat.Value == "" {
+		return page, "", md.DirectionFromADF, nil
+	}
+	return page, adfPage.Body.AtlasDocFormat.Value, md.DirectionFromADF, nil
+}
+
+// printDiff prints a unified diff with removed lines in red, added lines in
+// green and hunk headers in cyan.
+func printDiff(diff string) {
+	fprintDiff(os.Stdout, diff)
+}
+
+// fprintDiff prints a unified diff as printDiff does, to w.
+func fprintDiff(w io.Writer, diff string) {
+	for i, line := range strings.SplitAfter(diff, "\n") {
+		switch {
+		case i < 2: // File headers
+			_, _ = color.New(color.Bold).Fprint(w, line)
+		case strings.HasPrefix(line, "@@"):
+			_, _ = color.New(color.FgCyan).Fprint(w, line)
+		case strings.HasPrefix(line, "-"):
+			_, _ = color.New(color.FgRed).Fprint(w, line)
+		case strings.HasPrefix(line, "+"):
+			_, _ = color.New(color.FgGreen).Fprint(w, line)
+		default:
+			_, _ = fmt.Fprint(w, line)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package page
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunDiff(t *testing.T) {
+	server := newVersionServer(t, []string{
+		"<h1>Runbook</h1><p>Restart the service.</p>",
+		"<h1>Runbook</h1><p>Restart the service twice.</p>",
+		"<h1>Runbook</h1><p>Restart the service twice.</p><p>Then check logs.</p>",
+	}, nil)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	for _, opts := range []*diffOptions{
+		{from: 1, to: 2, noColor: true},
+		{from: 1, noColor: true}, // against current
+		{from: 2, to: 2, noColor: true},
+		{from: 1, to: 3, output: "json", noColor: true},
+	} {
+		require.NoError(t, runDiff("12345", opts, client))
+	}
+}
+
+func TestVersionMarkdown(t *testing.T) {
+	server := newVersionServer(t, []string{
+		"<p>first</p>",
+		"<p>second</p>",
+	}, nil)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	page, markdown, err := versionMarkdown(t.Context(), client, "12345", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Version.Number)
+	assert.Equal(t, "first", markdown)
+
+	page, markdown, err = versionMarkdown(t.Context(), client, "12345", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Version.Number)
+	assert.Equal(t, "second", markdown)
+}
+
+func TestVersionMarkdown_ADFErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		adf     func(w http.ResponseWriter)
+		wantErr string
+	}{
+		{
+			name:    "fetch fails",
+			adf:     func(w http.ResponseWriter) { w.WriteHeader(http.StatusInternalServerError) },
+			wantErr: "failed to get page body in ADF",
+		},
+		{
+			name: "conversion fails",
+			adf: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte(`{"id": "12345", "body": {"atlas_doc_format": {"value": "not json"}}}`))
+			},
+			wantErr: "failed to convert page to markdown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("body-format") == "atlas_doc_format" {
+					tt.adf(w)
+					return
+				}
+				_, _ = w.Write([]byte(`{"id": "12345", "title": "Runbook", "body": {"storage": {"value": ""}}}`))
+			}))
+			defer server.Close()
+			client := api.NewClient(server.URL, "test@example.com", "token")
+
+			_, _, err := versionMarkdown(t.Context(), client, "12345", 0)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunDiff_VersionNotFound(t *testing.T) {
+	server := newVersionServer(t, []string{"<p>a</p>"}, nil)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	err := runDiff("12345", &diffOptions{from: 9, noColor: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get version 9")
+}
+
+func TestRunDiff_InvalidVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *diffOptions
+		wantErr string
+	}{
+		{"missing from", &diffOptions{}, "invalid --from version"},
+		{"negative to", &diffOptions{from: 1, to: -1}, "invalid --to version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runDiff("12345", tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+type downloadOptions struct {
+	outputFile string
+	force      bool
+	version    int
+	output     string
+	noColor    bool
+}
+
+// NewCmdDownload creates the page download command.
+func NewCmdDownload() *cobra.Command {
+	opts := &downloadOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "download <page-id>",
+		Short: "Download a page as a markdown file",
+		Long: `Download a page to a markdown file with YAML front matter, as printed by
+'cfl page view --front-matter', so that the file can be published back with
+page edit or page create.
+
+The file is named after the page title unless --output-file is given.
+
+With --version, that version of the page is downloaded instead of the
+current one, and the front matter records it: page edit then merges the
+file with the changes made since that version.`,
+		Example: `  # Download a page
+  cfl page download 12345
+
+  # Download the version that was reviewed to a specific file
+  cfl page download 12345 --version 7 -O reviewed.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runDownload(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.outputFile, "output-file", "O", "", "Output file (default: page title with .md)")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Overwrite an existing file")
+	cmd.Flags().IntVar(&opts.version, "version", 0, "Download a specific version of the page (default: current)")
+
+	return cmd
+}
+
+func runDownload(pageID string, opts *downloadOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	if opts.version < 0 {
+		return fmt.Errorf("invalid version: %d (must be >= 1)", opts.version)
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pageID, err := pageref.Resolve(ctx, client, pageID)
+	if err != nil {
+		return err
+	}
+
+	page, content, err := Download(ctx, client, pageID, opts.version)
+	if err != nil {
+		return err
+	}
+
+	file := opts.outputFile
+	if file == "" {
+		file = Filename(page) + ".md"
+	}
+	if !opts.force {
+		if _, err := os.Stat(file); err == nil {
+			return fmt.Errorf("file already exists: %s (use --force to overwrite)", file)
+		}
+	}
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	result := &exportedPage{ID: page.ID, Title: page.Title, File: file, Bytes: int64(len(content))}
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(result)
+	}
+
+	renderer.Success(fmt.Sprintf("Downloaded: %s", file))
+	return nil
+}
+
+// Download gets a page as markdown preceded by YAML front matter, as
+// printed by 'cfl page view --front-matter', so that the file can be
+// published back with page edit or page create. version selects the
+// version to get; 0 gets the current one.
+func Download(ctx context.Context, client *api.Client, pageID string, version int) (*api.Page, string, error) {
+	page, markdown, err := versionMarkdown(ctx, client, pageID, version)
+	if err != nil {
+		return nil, "", err
+	}
+	content, err := withFrontMatter(ctx, client, page, markdown)
+	if err != nil {
+		return nil, "", err
+	}
+	return page, content, nil
+}
+
+// DownloadAll gets pages as Download does. The pages are fetched in turn
+// and converted to markdown on a pool of workers; conversion failures are
+// reported together in an *md.BatchError.
+func DownloadAll(ctx context.Context, client *api.Client, pageIDs []string) ([]*api.Page, []string, error) {
+	pages := make([]*api.Page, len(pageIDs))
+	bodies := make(map[md.Direction][]md.BatchInput)
+	indexes := make(map[md.Direction][]int)
+	for i, id := range pageIDs {
+		page, body, dir, err := versionBody(ctx, client, id, 0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to download page %s: %w", id, err)
+		}
+		pages[i] = page
+		if body != "" {
+			bodies[dir] = append(bodies[dir], md.BatchInput{Name: page.Title, Content: []byte(body)})
+			indexes[dir] = append(indexes[dir], i)
+		}
+	}
+
+	markdown := make([]string, len(pageIDs))
+	for _, dir := range []md.Direction{md.DirectionFromStorage, md.DirectionFromADF} {
+		results, err := md.ConvertBatch(ctx, bodies[dir], md.BatchOptions{Direction: dir})
+		if err != nil {
+			return nil, nil, err
+		}
+		for j, i := range indexes[dir] {
+			markdown[i] = results[j].Output
+		}
+	}
+
+	contents := make([]string, len(pageIDs))
+	for i, page := range pages {
+		content, err := withFrontMatter(ctx, client, page, markdown[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		contents[i] = content
+	}
+	return pages, contents, nil
+}
+
+// withFrontMatter prepends a page's front matter to its markdown.
+func withFrontMatter(ctx context.Context, client *api.Client, page *api.Page, markdown string) (string, error) {
+	fm, err := pageFrontMatter(ctx, client, page)
+	if err != nil {
+		return "", err
+	}
+	rendered, err := fm.Render()
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(markdown, "\n") {
+		markdown += "\n"
+	}
+	return rendered + markdown, nil
+}
@@ -0,0 +1,100 @@
+package page
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// newDownloadServer serves page 12345 in space DEV, with version N of the
+// page titled "Runbook vN" when a version is requested.
+func newDownloadServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/pages/12345/labels":
+			w.Write([]byte(`{"results": [{"name": "ops"}]}`))
+		case strings.HasPrefix(r.URL.Path, "/api/v2/spaces/"):
+			w.Write([]byte(`{"id": "9999", "key": "DEV", "name": "Development"}`))
+		case r.URL.Path == "/api/v2/pages/12345":
+			title, version := "Runbook", "9"
+			if v := r.URL.Query().Get("version"); v != "" {
+				title, version = "Runbook v"+v, v
+			}
+			w.Write([]byte(`{"id": "12345", "title": "` + title + `", "spaceId": "9999",
+				"version": {"number": ` + version + `},
+				"body": {"storage": {"value": "<p>Body ` + version + `</p>"}}}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunDownload(t *testing.T) {
+	server := newDownloadServer(t)
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runDownload("12345", &downloadOptions{noColor: true}, client)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "Runbook.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "title: Runbook\n")
+	assert.Contains(t, string(data), "version: 9\n")
+	assert.Contains(t, string(data), "Body 9")
+}
+
+func TestRunDownload_Version(t *testing.T) {
+	server := newDownloadServer(t)
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "reviewed.md")
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runDownload("12345", &downloadOptions{outputFile: file, version: 7, noColor: true}, client)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "version: 7\n")
+	assert.Contains(t, string(data), "Body 7")
+	assert.NotContains(t, string(data), "Body 9")
+}
+
+func TestRunDownload_FileExists(t *testing.T) {
+	server := newDownloadServer(t)
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "page.md")
+	require.NoError(t, os.WriteFile(file, []byte("keep"), 0644))
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runDownload("12345", &downloadOptions{outputFile: file, noColor: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "use --force to overwrite")
+
+	err = runDownload("12345", &downloadOptions{outputFile: file, force: true, noColor: true}, client)
+	require.NoError(t, err)
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Body 9")
+}
+
+func TestRunDownload_InvalidVersion(t *testing.T) {
+	err := runDownload("12345", &downloadOptions{version: -1, noColor: true}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid version")
+}
@@ -1,9 +1,12 @@
 package page
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -13,7 +16,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
 	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/mermaid"
+	"github.com/open-cli-collective/confluence-cli/internal/plantuml"
 	"github.com/open-cli-collective/confluence-cli/internal/view"
 	"github.com/open-cli-collective/confluence-cli/pkg/md"
 )
@@ -26,9 +32,33 @@ type editOptions struct {
 	markdown *bool // nil = auto-detect, true = force markdown, false = force storage format
 	legacy   bool  // Use legacy editor (storage format) instead of cloud editor (ADF)
 	parent   string
+	labels   []string
 	output   string
 	noColor  bool
 	stdin    io.Reader // For testing; defaults to os.Stdin
+	force    bool      // Overwrite changes made to the page since the edit's base version
+	merge    bool      // Merge changes made to the page since the edit's base version
+	confirm  io.Reader // Answers to the conflict prompt; nil when not interactive
+	draft    bool      // Save the content as the page's draft instead of publishing it
+
+	inputFormat       string                 // Format of the content: "" or auto, markdown, storage or adf
+	diagramMacro      string                 // Viewer macro for .drawio references
+	codeTabs          bool                   // Group tabbed code blocks into ui-tabs macros
+	glossary          bool                   // Link glossary terms to their definition pages
+	glossaryCfg       *config.GlossaryConfig // Glossary terms; loaded from config when nil
+	jira              config.JiraConfig      // Jira issue linking; loaded from config
+	plantuml          *plantuml.Renderer     // Renders plantuml fences; nil leaves them as code
+	forceEditorSwitch bool                   // Update a page in the other editor's format, converting it
+	mermaid           string                 // How mermaid fences are published: "" (code), macro or image
+	mermaidMacro      string                 // Macro for --mermaid macro; loaded from config when empty
+	mermaidImg        *mermaid.Renderer      // Renders mermaid fences for --mermaid image; built from config when nil
+	legacyAuto        bool                   // --legacy wasn't given, so the page's space decides the editor format
+	legacySpaces      []string               // Spaces published in legacy format by default; loaded from config
+	watch             bool                   // Republish the file whenever it changes
+	publishedVersion  int                    // Version written by the last update, the base of the next with --watch
+
+	classification config.ClassificationConfig // Required classification labels; loaded from config
+	images         imageOptions                // Optimization of local images; completed from config
 }
 
 // NewCmdEdit creates the page edit command.
@@ -41,7 +71,14 @@ func NewCmdEdit() *cobra.Command {
 		Long: `Edit an existing Confluence page.
 
 By default, pages are updated using the cloud editor format (ADF).
-Use --legacy to update pages in the legacy editor format.
+Use --legacy to update pages in the legacy editor format. Pages in spaces
+listed under legacy_spaces in the config file (or CFL_LEGACY_SPACES) default
+to the legacy editor format; --legacy=false overrides that.
+
+Replacing the content of a page in the other editor's format converts the
+page, which can mangle its layout, so it is refused unless
+--force-editor-switch is given. Pages that don't record their editor are
+updated as requested.
 
 Content can be provided via:
 - --file flag to read from a file
@@ -49,9 +86,64 @@ Content can be provided via:
 - Interactive editor (default, or with --editor flag)
 
 Content format:
-- Markdown is the default for stdin, editor, and .md files
+- Markdown is the default for the editor and .md files
+- Piped content is detected: an ADF JSON document is published as ADF, and
+  content starting with a storage format element, such as <p> or <ac:...>,
+  as storage format; anything else is treated as markdown
+- Files with .html/.xhtml extensions are treated as storage format
+- Storage format is converted for the cloud editor; ADF can't be used with --legacy
+- Use --input-format to set the format instead of detecting it
 - Use --no-markdown to provide raw Confluence format (XHTML for legacy, ADF JSON for cloud)
-- Files with .html/.xhtml extensions are treated as storage format`,
+
+Markdown may start with YAML front matter, such as the output of
+'cfl page view --front-matter'. Its title is used when --title isn't given,
+its labels are added, and an id naming another page is refused.
+
+Edits never silently overwrite changes made by someone else. When the
+front matter version is older than the page's, or the page changes while
+it is being updated, the changes made since are shown as a diff and the
+edit is aborted, unless --merge merges them with yours section by section
+(three-way, with the front matter version as the base) or --force
+overwrites them. In a terminal you are asked which to do instead.
+
+With --draft, the content is saved as the page's draft instead of being
+published: its existing draft is replaced, or a draft is started when it
+has none. Publish it with 'cfl page publish'. A rename, move or new labels
+can't be saved as a draft, so --draft needs new content.
+
+Local images (.png, .jpg, .jpeg, .gif, .webp, .bmp, .svg) and .drawio files
+referenced from markdown are uploaded as attachments and the references
+rewritten to point at them. Images are shown inline and .drawio files with
+the draw.io viewer macro (or Gliffy, with --diagram-macro gliffy).
+
+` + "```plantuml" + ` code blocks are rendered to SVG and attached when a PlantUML
+jar or server is configured (plantuml.jar / plantuml.server in the config
+file, or CFL_PLANTUML_JAR / CFL_PLANTUML_SERVER).
+
+` + "```mermaid" + ` code blocks are published as code unless --mermaid is given:
+--mermaid image renders them to SVG attachments with mermaid-cli (mmdc, or
+mermaid.mmdc / CFL_MERMAID_MMDC; mermaid.format: png for PNG), and
+--mermaid macro --legacy converts them to the macro of a Mermaid app on the
+instance (mermaid.macro in the config file, default "mermaid").
+
+Adjacent code blocks annotated with a tab name (` + "```go tab=Go" + `) are
+published as titled code blocks. With --legacy --code-tabs they are grouped
+into a ui-tabs macro, which needs a tabs macro app on the instance.
+
+With --glossary, the first occurrence of each glossary term is linked to its
+definition page. Terms come from glossary.terms (term: page ID or URL) and
+the page titles of glossary.space in the config file. Write {!term} to keep
+an occurrence unlinked.
+
+When classification.labels is set in the config file, the page must keep or
+be given one of them (with --label or in the front matter). A page without
+one gets classification.default, or is refused when there is no default.
+
+With --watch, the page is updated from --file and again every time the
+file is saved with new content, until Ctrl-C. Each update is based on the
+version the previous one wrote, so changes made in Confluence meanwhile
+are handled as for front matter versions. A failed update, such as one
+with overlapping changes, is reported and watching goes on.`,
 		Example: `  # Edit a page (opens editor with current content)
   cfl page edit 12345
 
@@ -71,7 +163,24 @@ Content format:
   cfl page edit 12345 --parent 67890
 
   # Move page and update title
-  cfl page edit 12345 --parent 67890 --title "New Title"`,
+  cfl page edit 12345 --parent 67890 --title "New Title"
+
+  # Add labels without changing content
+  cfl page edit 12345 --label reviewed --label v2
+
+  # Round-trip a page through a file, metadata included
+  cfl page view 12345 --front-matter > page.md
+  cfl page edit 12345 --file page.md
+
+  # Keep changes colleagues made since page.md was fetched
+  cfl page edit 12345 --file page.md --merge
+
+  # Save changes as a draft for review, then publish them
+  cfl page edit 12345 --file page.md --draft
+  cfl page publish 12345
+
+  # Republish README.md every time it is saved
+  cfl page edit 12345 --file README.md --watch`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.pageID = args[0]
@@ -87,22 +196,85 @@ Content format:
 
 			// Handle legacy flag
 			opts.legacy, _ = cmd.Flags().GetBool("legacy")
+			opts.legacyAuto = !cmd.Flags().Changed("legacy")
+			opts.images.optimizeSet = cmd.Flags().Changed("optimize")
+
+			// Conflicts are only asked about when there's someone to ask
+			if isTerminal() {
+				opts.confirm = os.Stdin
+			}
 
+			if opts.watch {
+				return runEditWatch(opts)
+			}
 			return runEdit(opts, nil)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opts.title, "title", "t", "", "New page title")
 	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Read content from file")
-	cmd.Flags().StringVarP(&opts.parent, "parent", "p", "", "Move page to new parent page ID")
+	cmd.Flags().StringVarP(&opts.parent, "parent", "p", "", "Move page under a new parent (ID, URL, or SPACE/Title)")
+	cmd.Flags().StringSliceVar(&opts.labels, "label", nil, "Add a label to the page (repeatable)")
 	cmd.Flags().BoolVar(&opts.editor, "editor", false, "Open editor for content")
 	cmd.Flags().Bool("no-markdown", false, "Disable markdown conversion (use raw XHTML)")
+	cmd.Flags().StringVar(&opts.inputFormat, "input-format", "", inputFormatUsage)
 	cmd.Flags().Bool("legacy", false, "Edit page in legacy editor format (default: cloud editor)")
+	cmd.Flags().BoolVar(&opts.forceEditorSwitch, "force-editor-switch", false, "Allow converting the page to the other editor")
+	cmd.Flags().StringVar(&opts.diagramMacro, "diagram-macro", md.DiagramMacroDrawio, "Macro for embedded .drawio files: drawio or gliffy")
+	cmd.Flags().BoolVar(&opts.codeTabs, "code-tabs", false, "Group tabbed code blocks into ui-tabs macros (requires --legacy)")
+	cmd.Flags().BoolVar(&opts.glossary, "glossary", false, "Link first occurrences of configured glossary terms")
+	cmd.Flags().StringVar(&opts.mermaid, "mermaid", "", "Publish mermaid code blocks as a macro or rendered image: macro or image")
+	cmd.Flags().BoolVar(&opts.watch, "watch", false, "Republish --file whenever it is saved, until Ctrl-C")
+	cmd.Flags().BoolVar(&opts.merge, "merge", false, "Merge changes others made since the edit's base version")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Overwrite changes others made since the edit's base version")
+	cmd.Flags().BoolVar(&opts.draft, "draft", false, "Save the content as a draft, to publish later with 'cfl page publish'")
+	addImageFlags(cmd, &opts.images)
+
+	cmd.MarkFlagsMutuallyExclusive("no-markdown", "input-format")
+	cmd.MarkFlagsMutuallyExclusive("merge", "force")
+	cmd.MarkFlagsMutuallyExclusive("watch", "editor")
 
 	return cmd
 }
 
+// runEditWatch updates the page from opts.file, then again whenever the
+// file changes.
+func runEditWatch(opts *editOptions) error {
+	if opts.file == "" {
+		return fmt.Errorf("--watch requires --file")
+	}
+	return watchUntilInterrupted(newFileWatcher(opts.file, fileSnapshot(opts.file), func() error {
+		if err := runEdit(opts, nil); err != nil {
+			return err
+		}
+		// The page was moved by the first update
+		opts.parent = ""
+		return nil
+	}))
+}
+
 func runEdit(opts *editOptions, client *api.Client) error {
+	if opts.diagramMacro == "" {
+		opts.diagramMacro = md.DiagramMacroDrawio
+	}
+	if err := validateDiagramMacro(opts.diagramMacro); err != nil {
+		return err
+	}
+	// Without --legacy the page's space may still default to the legacy
+	// editor, so the options needing it are checked again once it is known
+	if err := validateLegacyOptions(opts.codeTabs, opts.mermaid, opts.legacy || opts.legacyAuto); err != nil {
+		return err
+	}
+	if err := validateInputFormat(opts.inputFormat); err != nil {
+		return err
+	}
+	if err := validateLabels(opts.labels); err != nil {
+		return err
+	}
+	if err := opts.images.validate(); err != nil {
+		return err
+	}
+
 	// Track base URL for output (only available when loading config)
 	var baseURL string
 
@@ -117,108 +289,231 @@ func runEdit(opts *editOptions, client *api.Client) error {
 			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
 		}
 
+		if opts.plantuml == nil {
+			opts.plantuml = plantuml.New(cfg.PlantUML.Jar, cfg.PlantUML.Server, config.DiagramCacheDir("plantuml"))
+		}
+		if opts.mermaidImg == nil {
+			opts.mermaidImg = mermaid.New(cfg.Mermaid.Mmdc, cfg.Mermaid.Format, config.DiagramCacheDir("mermaid"))
+		}
+		if opts.mermaidMacro == "" {
+			opts.mermaidMacro = cfg.Mermaid.Macro
+		}
+		if opts.glossaryCfg == nil {
+			opts.glossaryCfg = &cfg.Glossary
+		}
+		opts.jira = cfg.Jira
+		opts.legacySpaces = cfg.LegacySpaces
+		opts.classification = cfg.Classification
+		opts.images = opts.images.withConfig(cfg.Images)
+
 		baseURL = cfg.URL
-		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken)
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
 	}
 
-	// Get existing page
-	existingPage, err := client.GetPage(context.Background(), opts.pageID, &api.GetPageOptions{
-		BodyFormat: "storage",
-	})
+	pageID, err := pageref.Resolve(context.Background(), client, opts.pageID)
 	if err != nil {
-		return fmt.Errorf("failed to get page: %w", err)
+		return err
+	}
+	opts.pageID = pageID
+	if opts.parent != "" {
+		parentID, err := pageref.Resolve(context.Background(), client, opts.parent)
+		if err != nil {
+			return err
+		}
+		opts.parent = parentID
 	}
 
-	// Determine new title (use existing if not specified)
-	newTitle := opts.title
-	if newTitle == "" {
-		newTitle = existingPage.Title
+	// Get existing page, or its draft when saving a draft
+	existingPage, err := getEditPage(context.Background(), client, opts.pageID, opts.draft)
+	if err != nil {
+		return err
 	}
 
-	// Get new content
-	var newContent string
+	if opts.legacyAuto {
+		if opts.legacy, err = spaceDefaultsLegacy(context.Background(), client, existingPage.SpaceID, opts.legacySpaces); err != nil {
+			return err
+		}
+		if err := validateLegacyOptions(opts.codeTabs, opts.mermaid, opts.legacy); err != nil {
+			return err
+		}
+	}
+
+	// Refuse to convert the page to the other editor before changing anything
+	replacesContent := opts.file != "" || opts.editor || opts.stdin != nil || !isTerminal() ||
+		(opts.title == "" && opts.parent == "" && len(opts.labels) == 0)
+	var switchFrom string
+	if replacesContent {
+		if switchFrom, err = editorSwitch(context.Background(), client, opts.pageID, opts.legacy); err != nil {
+			return err
+		}
+		if switchFrom != "" && !opts.forceEditorSwitch {
+			return editorSwitchError(opts.pageID, switchFrom)
+		}
+	}
+
+	// Get new content: from a file, stdin or the editor flag, or by default
+	// from the editor when there is no new title, parent move or labels
+	var rawContent, format string
 	hasNewContent := false
+	switch {
+	case opts.file != "" || opts.editor || opts.stdin != nil || !isTerminal():
+		rawContent, format, err = getEditContent(opts, existingPage)
+		hasNewContent = true
+	case opts.title == "" && opts.parent == "" && len(opts.labels) == 0:
+		rawContent, format, err = getEditContent(&editOptions{editor: true, markdown: opts.markdown, inputFormat: opts.inputFormat}, existingPage)
+		hasNewContent = true
+	}
+	if err != nil {
+		return err
+	}
+	isMarkdown := format == formatMarkdown
 
-	// Check if content is provided via file, stdin, or editor flag
-	if opts.file != "" || opts.editor || opts.stdin != nil || !isTerminal() {
-		content, isMarkdown, err := getEditContent(opts, existingPage)
-		if err != nil {
+	// Validate content is not empty
+	if hasNewContent && strings.TrimSpace(rawContent) == "" {
+		return fmt.Errorf("page content cannot be empty")
+	}
+	if opts.draft && !hasNewContent {
+		return fmt.Errorf("--draft saves new content as a draft; give it with --file, --editor or standard input")
+	}
+
+	// Storage or ADF content may need converting for the editor used
+	if hasNewContent {
+		if rawContent, err = editorContent(context.Background(), client, rawContent, format, opts.legacy); err != nil {
 			return err
 		}
+	}
 
-		// Validate content is not empty
-		if strings.TrimSpace(content) == "" {
-			return fmt.Errorf("page content cannot be empty")
+	// Front matter written by 'cfl page view --front-matter' names the page,
+	// its title and labels, and the version the content was based on
+	newTitle := opts.title
+	labels := opts.labels
+	base := existingPage
+	if hasNewContent && isMarkdown {
+		fm, _, err := md.ParseFrontMatter([]byte(rawContent))
+		if err != nil {
+			return err
 		}
+		baseVersion := opts.publishedVersion
+		if fm != nil {
+			if fm.ID != "" && fm.ID != existingPage.ID {
+				return fmt.Errorf("front matter is for page %s, not page %s", fm.ID, existingPage.ID)
+			}
+			if newTitle == "" {
+				newTitle = fm.Title
+			}
+			labels = append(append([]string(nil), labels...), fm.Labels...)
+			if err := validateLabels(labels); err != nil {
+				return err
+			}
+			baseVersion = max(baseVersion, fm.Version)
+		}
+		if baseVersion > 0 && baseVersion < existingPage.Version.Number {
+			base, err = client.GetPage(context.Background(), opts.pageID, &api.GetPageOptions{
+				BodyFormat: "storage",
+				Version:    baseVersion,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get page version %d: %w", baseVersion, err)
+			}
+		}
+	}
 
-		// Convert content based on legacy flag
-		newContent, err = convertEditContent(content, isMarkdown, opts.legacy)
+	// Determine new title (use existing if not specified)
+	if newTitle == "" {
+		newTitle = existingPage.Title
+	}
+
+	labels, err = classifyExisting(context.Background(), client, opts.classification, opts.pageID, labels)
+	if err != nil {
+		return err
+	}
+
+	var glossary map[string]string
+	if opts.glossary {
+		glossary, err = glossaryLinks(context.Background(), client, opts.glossaryCfg, newTitle)
 		if err != nil {
 			return err
 		}
-		hasNewContent = true
 	}
 
-	// If no new content and no new title and no parent move, open editor by default
-	if !hasNewContent && opts.title == "" && opts.parent == "" {
-		content, isMarkdown, err := getEditContent(&editOptions{editor: true, markdown: opts.markdown}, existingPage)
+	var newContent string
+	var newMarkdown string // retained for merging if the update hits a version conflict
+	var embeds md.EmbedOptions
+	if hasNewContent {
+		content := expandTOC(rawContent, isMarkdown, newTitle, opts.legacy)
+		content = linkGlossary(content, isMarkdown, glossary)
+		content = linkJira(content, isMarkdown, opts.legacy, opts.jira, client.BaseURL())
+		content, err = qualifyPageLinksInSpace(context.Background(), client, content, isMarkdown, opts.legacy, existingPage.SpaceID)
 		if err != nil {
 			return err
 		}
 
-		// Validate content is not empty
-		if strings.TrimSpace(content) == "" {
-			return fmt.Errorf("page content cannot be empty")
+		content, err = renderDiagrams(opts.plantuml, mermaidImages(opts.mermaid, opts.mermaidImg), content, isMarkdown)
+		if err != nil {
+			return err
 		}
 
-		newContent, err = convertEditContent(content, isMarkdown, opts.legacy)
+		embeds, err = uploadReferencedFiles(client, opts, content, isMarkdown)
 		if err != nil {
 			return err
 		}
-		hasNewContent = true
-	}
 
-	// Build update request
-	req := &api.UpdatePageRequest{
-		ID:     opts.pageID,
-		Status: "current",
-		Title:  newTitle,
-		Version: &api.Version{
-			Number:  existingPage.Version.Number + 1,
-			Message: "Updated via cfl",
-		},
+		// Convert content based on legacy flag
+		newContent, err = convertEditContent(content, isMarkdown, opts.conversion(embeds))
+		if err != nil {
+			return err
+		}
+		if isMarkdown {
+			newMarkdown = content
+		}
 	}
 
-	// Only update body if we have new content
-	if hasNewContent {
-		if opts.legacy {
+	// Only send what changed: the body is replaced only when there is new
+	// content, a rename alone goes to the title endpoint, and a move alone
+	// doesn't update the page, so stored content is never round-tripped
+	page := existingPage
+	switch {
+	case hasNewContent:
+		renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+		switch {
+		case switchFrom != "":
+			renderer.Warning(fmt.Sprintf("Converting page from the %s to the %s.", editorName(switchFrom), editorName(targetEditor(opts.legacy))))
+		case opts.legacy:
 			// Warn about potential editor switch
-			renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
 			renderer.Warning("Using --legacy flag. If this page uses the cloud editor, it may switch to the legacy editor.")
-
-			req.Body = &api.Body{
-				Storage: &api.BodyRepresentation{
-					Representation: "storage",
-					Value:          newContent,
-				},
-			}
+		}
+		req := &api.UpdatePageRequest{
+			ID:     opts.pageID,
+			Status: pageStatus(opts.draft),
+			Title:  newTitle,
+			Body:   newEditBody(newContent, opts.legacy),
+			Version: &api.Version{
+				Number:  nextVersion(existingPage),
+				Message: "Updated via cfl",
+			},
+		}
+		if base != existingPage {
+			// The content was edited from an older version, so others'
+			// changes made since would be overwritten
+			page, err = resolveEditConflict(client, opts, base, existingPage, newMarkdown, embeds, req)
 		} else {
-			req.Body = &api.Body{
-				AtlasDocFormat: &api.BodyRepresentation{
-					Representation: "atlas_doc_format",
-					Value:          newContent,
-				},
+			page, err = client.UpdatePage(context.Background(), opts.pageID, req)
+			if err != nil && isVersionConflict(err) {
+				page, err = resolveEditConflict(client, opts, existingPage, nil, newMarkdown, embeds, req)
 			}
 		}
-	} else {
-		// Keep existing body when only updating title
-		req.Body = existingPage.Body
-	}
-
-	// Update page
-	page, err := client.UpdatePage(context.Background(), opts.pageID, req)
-	if err != nil {
-		return fmt.Errorf("failed to update page: %w", err)
+		if err != nil {
+			err = checkADFSupport(context.Background(), client, err, opts.legacy)
+			return fmt.Errorf("failed to update page: %w", err)
+		}
+		if page.Version != nil {
+			opts.publishedVersion = page.Version.Number
+		}
+	case newTitle != existingPage.Title:
+		page, err = client.UpdatePageTitle(context.Background(), opts.pageID, newTitle)
+		if err != nil {
+			return fmt.Errorf("failed to update page: %w", err)
+		}
 	}
 
 	// Move page to new parent if specified
@@ -228,14 +523,24 @@ func runEdit(opts *editOptions, client *api.Client) error {
 		}
 	}
 
+	if len(labels) > 0 {
+		if _, err := client.AddLabels(context.Background(), opts.pageID, labels); err != nil {
+			return fmt.Errorf("failed to add labels: %w", err)
+		}
+	}
+
 	// Render output
 	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
 
-	if opts.output == "json" {
+	if view.IsStructured(opts.output) {
 		return renderer.RenderJSON(page)
 	}
 
-	renderer.Success(fmt.Sprintf("Updated page: %s", page.Title))
+	if opts.draft {
+		renderer.Success(fmt.Sprintf("Saved draft: %s (publish it with 'cfl page publish %s')", page.Title, page.ID))
+	} else {
+		renderer.Success(fmt.Sprintf("Updated page: %s", page.Title))
+	}
 	renderer.RenderKeyValue("ID", page.ID)
 	renderer.RenderKeyValue("Version", strconv.Itoa(page.Version.Number))
 	renderer.RenderKeyValue("URL", baseURL+page.Links.WebUI)
@@ -243,21 +548,335 @@ func runEdit(opts *editOptions, client *api.Client) error {
 	return nil
 }
 
+// getEditPage returns the page to edit with its storage body: with draft,
+// its draft, or the published page when it has no draft.
+func getEditPage(ctx context.Context, client *api.Client, pageID string, draft bool) (*api.Page, error) {
+	if draft {
+		page, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: "storage", Draft: true})
+		switch {
+		case err == nil:
+			return page, nil
+		case !isNotFound(err):
+			return nil, fmt.Errorf("failed to get draft: %w", err)
+		}
+		// Without a draft, one is started from the published page
+	}
+	page, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: "storage"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+	return page, nil
+}
+
+// spaceDefaultsLegacy reports whether the space with the given ID is one of
+// the configured legacy spaces. The space is only looked up when some are
+// configured.
+func spaceDefaultsLegacy(ctx context.Context, client *api.Client, spaceID string, legacySpaces []string) (bool, error) {
+	if len(legacySpaces) == 0 || spaceID == "" {
+		return false, nil
+	}
+	space, err := client.GetSpace(ctx, spaceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get space: %w", err)
+	}
+	return config.IsLegacySpace(legacySpaces, space.Key), nil
+}
+
+// newEditBody wraps converted content in the body representation for the editor mode.
+func newEditBody(content string, legacy bool) *api.Body {
+	if legacy {
+		return &api.Body{
+			Storage: &api.BodyRepresentation{
+				Representation: "storage",
+				Value:          content,
+			},
+		}
+	}
+	return &api.Body{
+		AtlasDocFormat: &api.BodyRepresentation{
+			Representation: "atlas_doc_format",
+			Value:          content,
+		},
+	}
+}
+
+// checkADFSupport explains a failed cloud editor write on a site that
+// doesn't support ADF bodies. Other errors are returned unchanged.
+func checkADFSupport(ctx context.Context, client *api.Client, err error, legacy bool) error {
+	var apiErr *api.ErrorResponse
+	if legacy || !errors.As(err, &apiErr) || (apiErr.StatusCode != http.StatusBadRequest && apiErr.StatusCode != http.StatusNotFound) {
+		return err
+	}
+	var nsErr *api.NotSupportedError
+	if reqErr := client.Require(ctx, api.FeatureADF); errors.As(reqErr, &nsErr) {
+		return fmt.Errorf("%w (use --legacy for the storage format)", nsErr)
+	}
+	return err
+}
+
+// editorSwitch returns the page's current editor if updating it in the
+// format chosen by legacy would convert it to the other editor, or "" if
+// not or if the page doesn't record its editor.
+func editorSwitch(ctx context.Context, client *api.Client, pageID string, legacy bool) (string, error) {
+	editor, err := pageEditor(ctx, client, pageID)
+	if err != nil {
+		return "", err
+	}
+	if editor == "" || editor == targetEditor(legacy) {
+		return "", nil
+	}
+	return editor, nil
+}
+
+// pageEditor returns the editor a page records, api.EditorCloud or
+// api.EditorLegacy, or "" if it doesn't record one.
+func pageEditor(ctx context.Context, client *api.Client, pageID string) (string, error) {
+	editor, err := client.GetPageEditor(ctx, pageID)
+	if err != nil {
+		// Sites without content properties keep every page in one editor
+		var nsErr *api.NotSupportedError
+		if isNotFound(err) || errors.As(err, &nsErr) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to detect page editor: %w", err)
+	}
+	return editor, nil
+}
+
+// targetEditor returns the editor content is published for.
+func targetEditor(legacy bool) string {
+	if legacy {
+		return api.EditorLegacy
+	}
+	return api.EditorCloud
+}
+
+func editorName(editor string) string {
+	if editor == api.EditorLegacy {
+		return "legacy editor"
+	}
+	return "cloud editor"
+}
+
+// editorSwitchError explains why updating a page in the other editor's
+// format was refused.
+func editorSwitchError(pageID, current string) error {
+	if current == api.EditorLegacy {
+		return fmt.Errorf("page %s uses the legacy editor and publishing cloud editor content would convert it; use --legacy to keep the legacy editor, or --force-editor-switch to convert it", pageID)
+	}
+	return fmt.Errorf("page %s uses the cloud editor and publishing with --legacy would convert it to the legacy editor; drop --legacy, or use --force-editor-switch to convert it", pageID)
+}
+
+// isVersionConflict reports whether an update failed because the page
+// version moved on since it was fetched.
+func isVersionConflict(err error) bool {
+	var apiErr *api.ErrorResponse
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// Ways of resolving an edit conflict.
+const (
+	conflictMerge = "merge"
+	conflictForce = "force"
+)
+
+// resolveEditConflict publishes an edit made from base when the page has
+// since changed to latest (fetched when nil): it merges or overwrites the
+// changes made since, by --merge, --force or the user's answer, or else
+// shows them and fails. ours is the edit's markdown, or empty for storage
+// or ADF content, which can't be merged.
+func resolveEditConflict(client *api.Client, opts *editOptions, base, latest *api.Page, ours string, embeds md.EmbedOptions, req *api.UpdatePageRequest) (*api.Page, error) {
+	if latest == nil || latest.Body == nil {
+		var err error
+		latest, err = client.GetPage(context.Background(), opts.pageID, &api.GetPageOptions{
+			BodyFormat: "storage",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest page version: %w", err)
+		}
+	}
+
+	baseMarkdown, err := storageMarkdown(base)
+	if err != nil {
+		return nil, err
+	}
+	theirMarkdown, err := storageMarkdown(latest)
+	if err != nil {
+		return nil, err
+	}
+
+	choice, err := conflictChoice(opts, base, latest, baseMarkdown, theirMarkdown)
+	if err != nil {
+		return nil, err
+	}
+	if choice == conflictForce {
+		req.Version.Number = latest.Version.Number + 1
+		return client.UpdatePage(context.Background(), opts.pageID, req)
+	}
+	if ours == "" {
+		return nil, fmt.Errorf("page was modified by someone else (now version %d) and only markdown edits can be merged; use --force to overwrite their changes",
+			latest.Version.Number)
+	}
+	return mergeConflictingEdit(client, opts, latest, baseMarkdown, ours, theirMarkdown, embeds, req)
+}
+
+// conflictChoice returns how to resolve a conflict: by --merge or --force,
+// or else by asking on opts.confirm after showing the changes made since
+// base. Without a way to ask, or when the answer is to abort, the changes
+// are shown and an error is returned.
+func conflictChoice(opts *editOptions, base, latest *api.Page, baseMarkdown, theirMarkdown string) (string, error) {
+	switch {
+	case opts.force:
+		return conflictForce, nil
+	case opts.merge:
+		return conflictMerge, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Page %s was changed by someone else since version %d (now version %d):\n",
+		opts.pageID, base.Version.Number, latest.Version.Number)
+	fprintDiff(os.Stderr, md.UnifiedDiff(
+		fmt.Sprintf("version %d", base.Version.Number),
+		fmt.Sprintf("version %d", latest.Version.Number),
+		baseMarkdown, theirMarkdown))
+
+	aborted := fmt.Errorf("page %s was changed by someone else since version %d (now version %d); use --merge to merge their changes, or --force to overwrite them",
+		opts.pageID, base.Version.Number, latest.Version.Number)
+	if opts.confirm == nil {
+		return "", aborted
+	}
+
+	fmt.Fprint(os.Stderr, "[m]erge their changes, [f]orce overwrite, or [a]bort? [a]: ")
+	scanner := bufio.NewScanner(opts.confirm)
+	var answer string
+	if scanner.Scan() {
+		answer = strings.ToLower(strings.TrimSpace(scanner.Text()))
+	}
+	switch answer {
+	case "m", "merge":
+		return conflictMerge, nil
+	case "f", "force":
+		return conflictForce, nil
+	}
+	return "", aborted
+}
+
+// mergeConflictingEdit publishes the section-by-section merge of the
+// markdown of the version the edit was made from, our new markdown and the
+// latest remote version. Overlapping changes are not merged and must be
+// resolved manually.
+func mergeConflictingEdit(client *api.Client, opts *editOptions, latest *api.Page, baseMarkdown, ours, theirMarkdown string, embeds md.EmbedOptions, req *api.UpdatePageRequest) (*api.Page, error) {
+	// Merge the markdown body only; front matter is ours to keep
+	_, ourBody, err := md.ParseFrontMatter([]byte(ours))
+	if err != nil {
+		return nil, err
+	}
+	frontMatter := ours[:len(ours)-len(ourBody)]
+
+	merged, err := md.MergeSections(baseMarkdown, string(ourBody), theirMarkdown)
+	if err != nil {
+		return nil, fmt.Errorf("page was modified by someone else (now version %d) and changes overlap: %w; resolve them in your copy, or use --force to overwrite their changes",
+			latest.Version.Number, err)
+	}
+
+	content, err := convertEditContent(frontMatter+merged, true, opts.conversion(embeds))
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(os.Stderr, "Page was modified concurrently (version %d); merged non-overlapping section changes.\n", latest.Version.Number)
+
+	req.Body = newEditBody(content, opts.legacy)
+	req.Version.Number = latest.Version.Number + 1
+	return client.UpdatePage(context.Background(), opts.pageID, req)
+}
+
+// uploadReferencedFiles attaches local files referenced by markdown content
+// to the page being edited and returns how to embed them. References are
+// resolved relative to the input file, or the working directory otherwise.
+func uploadReferencedFiles(client *api.Client, opts *editOptions, content string, isMarkdown bool) (md.EmbedOptions, error) {
+	baseDir := "."
+	if opts.file != "" {
+		baseDir = filepath.Dir(opts.file)
+	}
+
+	files, err := findLocalFiles(content, isMarkdown, baseDir)
+	if err != nil {
+		return md.EmbedOptions{}, err
+	}
+	return uploadLocalFiles(context.Background(), client, opts.pageID, files, opts.diagramMacro, opts.images)
+}
+
+// storageMarkdown converts a page's storage body to markdown.
+func storageMarkdown(page *api.Page) (string, error) {
+	if page.Body == nil || page.Body.Storage == nil {
+		return "", nil
+	}
+	markdown, err := md.FromConfluenceStorage(page.Body.Storage.Value)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert page to markdown: %w", err)
+	}
+	return markdown, nil
+}
+
 // isTerminal checks if stdin is a terminal
 func isTerminal() bool {
 	stat, _ := os.Stdin.Stat()
 	return (stat.Mode() & os.ModeCharDevice) != 0
 }
 
+// conversion returns how the edit's markdown is converted for publishing.
+func (opts *editOptions) conversion(embeds md.EmbedOptions) conversion {
+	return conversion{
+		legacy:       opts.legacy,
+		codeTabs:     opts.codeTabs,
+		embeds:       embeds,
+		mermaid:      opts.mermaid,
+		mermaidMacro: opts.mermaidMacro,
+	}
+}
+
+// conversion configures how markdown is converted for publishing.
+type conversion struct {
+	legacy   bool            // Convert to storage format instead of ADF
+	codeTabs bool            // Group tabbed code blocks into ui-tabs macros (storage format only)
+	embeds   md.EmbedOptions // Attachments to embed for local file references
+
+	mermaid      string // How mermaid code blocks are published (--mermaid)
+	mermaidMacro string // Macro for mermaid code blocks with --mermaid macro; empty for the default
+}
+
 // convertEditContent converts content based on markdown flag and legacy mode.
-func convertEditContent(content string, isMarkdown, legacy bool) (string, error) {
-	if legacy {
+// A front matter summary in markdown content is published as a hidden page excerpt.
+// Images referring to uploaded attachments are embedded as described by conv.embeds.
+func convertEditContent(content string, isMarkdown bool, conv conversion) (string, error) {
+	summary := ""
+	if isMarkdown {
+		fm, body, err := md.ParseFrontMatter([]byte(content))
+		if err != nil {
+			return "", err
+		}
+		if fm != nil {
+			summary = fm.Summary
+			content = string(body)
+		}
+	}
+
+	if conv.legacy {
 		// Legacy mode: convert to storage format (XHTML)
 		if isMarkdown {
-			converted, err := md.ToConfluenceStorage([]byte(content))
+			converted, err := md.ToConfluenceStorageWithEmbeds([]byte(content), conv.embeds)
 			if err != nil {
 				return "", fmt.Errorf("failed to convert markdown: %w", err)
 			}
+			if conv.codeTabs {
+				converted = md.GroupCodeTabs(converted)
+			}
+			if conv.mermaid == mermaidAsMacro {
+				converted = md.MermaidMacros(converted, conv.mermaidMacro)
+			}
+			if summary != "" {
+				converted = md.ExcerptStorage(summary) + converted
+			}
 			return converted, nil
 		}
 		return content, nil
@@ -265,17 +884,24 @@ func convertEditContent(content string, isMarkdown, legacy bool) (string, error)
 
 	// Default: cloud editor using ADF
 	if isMarkdown {
-		adfContent, err := md.ToADF([]byte(content))
+		adfContent, err := md.ToADFWithEmbeds([]byte(content), conv.embeds)
 		if err != nil {
 			return "", fmt.Errorf("failed to convert markdown to ADF: %w", err)
 		}
+		if summary != "" {
+			adfContent, err = md.PrependExcerptADF(adfContent, summary)
+			if err != nil {
+				return "", fmt.Errorf("failed to add page excerpt: %w", err)
+			}
+		}
 		return adfContent, nil
 	}
 	return content, nil
 }
 
-// getEditContent reads content for editing and returns (content, isMarkdown, error).
-func getEditContent(opts *editOptions, existingPage *api.Page) (string, bool, error) {
+// getEditContent reads content for editing and returns (content, format,
+// error), where format is one of the format constants.
+func getEditContent(opts *editOptions, existingPage *api.Page) (string, string, error) {
 	// Determine if we should use markdown based on explicit flag or file extension
 	useMarkdown := func(filename string) bool {
 		// If explicitly set via flag, use that
@@ -300,32 +926,35 @@ func getEditContent(opts *editOptions, existingPage *api.Page) (string, bool, er
 	if opts.file != "" {
 		data, err := os.ReadFile(opts.file)
 		if err != nil {
-			return "", false, fmt.Errorf("failed to read file: %w", err)
+			return "", "", fmt.Errorf("failed to read file: %w", err)
 		}
-		return string(data), useMarkdown(opts.file), nil
+		content := string(data)
+		return content, contentFormat(content, opts.inputFormat, opts.markdown, useMarkdown(opts.file), false), nil
 	}
 
 	// Check if stdin has data (use injected stdin for testing)
 	if opts.stdin != nil {
 		data, err := io.ReadAll(opts.stdin)
 		if err != nil {
-			return "", false, fmt.Errorf("failed to read stdin: %w", err)
+			return "", "", fmt.Errorf("failed to read stdin: %w", err)
 		}
-		return string(data), useMarkdown(""), nil
+		content := string(data)
+		return content, contentFormat(content, opts.inputFormat, opts.markdown, useMarkdown(""), true), nil
 	}
 
 	if !isTerminal() {
 		data, err := io.ReadAll(os.Stdin)
 		if err != nil {
-			return "", false, fmt.Errorf("failed to read stdin: %w", err)
+			return "", "", fmt.Errorf("failed to read stdin: %w", err)
 		}
-		return string(data), useMarkdown(""), nil
+		content := string(data)
+		return content, contentFormat(content, opts.inputFormat, opts.markdown, useMarkdown(""), true), nil
 	}
 
 	// Open editor with existing content
-	isMarkdown := useMarkdown("")
-	content, err := openEditorForEdit(existingPage, isMarkdown)
-	return content, isMarkdown, err
+	format := contentFormat("", opts.inputFormat, opts.markdown, useMarkdown(""), false)
+	content, err := openEditorForEdit(existingPage, format == formatMarkdown)
+	return content, format, err
 }
 
 func openEditorForEdit(existingPage *api.Page, isMarkdown bool) (string, error) {
@@ -2,6 +2,7 @@ package page
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
 )
 
 func TestRunEdit_Success(t *testing.T) {
@@ -501,6 +503,58 @@ func TestRunEdit_Stdin_ADF(t *testing.T) {
 	assert.Contains(t, content, `"type":"strong"`)
 }
 
+func TestRunEdit_Stdin_StorageDetected(t *testing.T) {
+	var receivedBody map[string]interface{}
+	converted := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"id": "12345",
+				"title": "Test",
+				"version": {"number": 1},
+				"body": {"storage": {"value": "<p>Old</p>"}},
+				"_links": {"webui": "/pages/12345"}
+			}`))
+		case r.Method == "POST" && r.URL.Path == "/rest/api/contentbody/convert/atlas_doc_format":
+			body, _ := io.ReadAll(r.Body)
+			converted = string(body)
+			w.Write([]byte(`{"representation": "atlas_doc_format", "value": "{\"type\":\"doc\",\"version\":1,\"content\":[]}"}`))
+		case r.Method == "PUT":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &receivedBody)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"id": "12345",
+				"title": "Test",
+				"version": {"number": 2},
+				"_links": {"webui": "/pages/12345"}
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &editOptions{
+		pageID:  "12345",
+		stdin:   strings.NewReader(`<p>Piped <ac:emoticon ac:name="smile"/></p>`),
+		noColor: true,
+	}
+
+	err := runEdit(opts, client)
+	require.NoError(t, err)
+
+	// Storage format is converted by Confluence, not treated as markdown
+	assert.Contains(t, converted, `"representation":"storage"`)
+	assert.Contains(t, converted, "ac:emoticon")
+	bodyMap := receivedBody["body"].(map[string]interface{})
+	adfMap := bodyMap["atlas_doc_format"].(map[string]interface{})
+	assert.Equal(t, `{"type":"doc","version":1,"content":[]}`, adfMap["value"])
+}
+
 func TestRunEdit_Stdin_Legacy(t *testing.T) {
 	var receivedBody map[string]interface{}
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1066,14 +1120,15 @@ func TestRunEdit_MoveOnly_NoEditorOpened(t *testing.T) {
 
 	err := runEdit(opts, client)
 	require.NoError(t, err)
-	assert.True(t, updateCalled, "UpdatePage should have been called")
+	assert.False(t, updateCalled, "UpdatePage should not be called for a move")
 	assert.True(t, moveCalled, "MovePage should have been called")
 }
 
 func TestRunEdit_MoveWithTitleOnly_NoEditorOpened(t *testing.T) {
 	// Test: cfl page edit 12345 --parent 67890 --title "New Title"
-	// Verifies: page is moved and title updated, body preserved, no editor opened
+	// Verifies: page is moved and renamed without resending the body, no editor opened
 	moveCalled := false
+	var titlePath string
 	var receivedBody map[string]interface{}
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch {
@@ -1087,6 +1142,7 @@ func TestRunEdit_MoveWithTitleOnly_NoEditorOpened(t *testing.T) {
 				"_links": {"webui": "/pages/12345"}
 			}`))
 		case r.Method == "PUT" && strings.Contains(r.URL.Path, "/api/v2/pages/12345"):
+			titlePath = r.URL.Path
 			body, _ := io.ReadAll(r.Body)
 			json.Unmarshal(body, &receivedBody)
 			w.WriteHeader(http.StatusOK)
@@ -1117,13 +1173,15 @@ func TestRunEdit_MoveWithTitleOnly_NoEditorOpened(t *testing.T) {
 	err := runEdit(opts, client)
 	require.NoError(t, err)
 	assert.True(t, moveCalled, "MovePage should have been called")
+	assert.Equal(t, "/api/v2/pages/12345/title", titlePath)
 	assert.Equal(t, "New Title", receivedBody["title"])
+	assert.NotContains(t, receivedBody, "body")
 }
 
 func TestRunEdit_MoveOnly_BodyPreserved(t *testing.T) {
-	// Test: move-only operation preserves original body exactly
-	// Verifies: received body in PUT request matches original page body
-	var receivedBody map[string]interface{}
+	// Test: move-only operation leaves the body untouched
+	// Verifies: no page update is sent, so stored content isn't round-tripped
+	var writes []string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case r.Method == "GET" && strings.Contains(r.URL.Path, "/api/v2/pages/12345"):
@@ -1135,19 +1193,230 @@ func TestRunEdit_MoveOnly_BodyPreserved(t *testing.T) {
 				"body": {"storage": {"representation": "storage", "value": "<p>Original content that must be preserved</p>"}},
 				"_links": {"webui": "/pages/12345"}
 			}`))
-		case r.Method == "PUT" && strings.Contains(r.URL.Path, "/api/v2/pages/12345"):
+		case r.Method == "PUT":
+			writes = append(writes, r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &editOptions{
+		pageID:  "12345",
+		parent:  "67890",
+		noColor: true,
+	}
+
+	err := runEdit(opts, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/rest/api/content/12345/move/append/67890"}, writes)
+}
+
+func TestRunEdit_RenameOnly(t *testing.T) {
+	// Test: cfl page edit 12345 --title "New Title"
+	// Verifies: only the title is sent, no editor opened
+	var writes []string
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"id": "12345",
+				"title": "Old Title",
+				"version": {"number": 3},
+				"body": {"storage": {"representation": "storage", "value": "<p>Keep this</p>"}},
+				"_links": {"webui": "/pages/12345"}
+			}`))
+		case r.Method == "PUT":
+			writes = append(writes, r.URL.Path)
 			body, _ := io.ReadAll(r.Body)
 			json.Unmarshal(body, &receivedBody)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "12345", "title": "New Title", "version": {"number": 4}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runEdit(&editOptions{pageID: "12345", title: "New Title", noColor: true}, client)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/api/v2/pages/12345/title"}, writes)
+	assert.Equal(t, map[string]interface{}{"status": "current", "title": "New Title"}, receivedBody)
+}
+
+// mockConflictServer serves a page whose version moves on between the initial
+// GET and the first PUT, which fails with 409 Conflict.
+func mockConflictServer(t *testing.T, latestStorage string, receivedBody *map[string]interface{}) *httptest.Server {
+	getCount := 0
+	putCount := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			getCount++
+			version, storage := 1, `<h1>Alpha</h1><p>A</p><h1>Beta</h1><p>B</p>`
+			if getCount > 1 {
+				version, storage = 2, latestStorage
+			}
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":      "12345",
+				"title":   "Test",
+				"version": map[string]int{"number": version},
+				"body":    map[string]interface{}{"storage": map[string]string{"value": storage}},
+			})
+		case "PUT":
+			putCount++
+			if putCount == 1 {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"message": "Version must be incremented"}`))
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, receivedBody)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "12345", "title": "Test", "version": {"number": 3}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunEdit_ConflictMergesDisjointSections(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := mockConflictServer(t, `<h1>Alpha</h1><p>A</p><h1>Beta</h1><p>B2</p>`, &receivedBody)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &editOptions{
+		pageID:  "12345",
+		stdin:   strings.NewReader("# Alpha\n\nA changed\n\n# Beta\n\nB\n"),
+		noColor: true,
+		merge:   true,
+	}
+
+	err := runEdit(opts, client)
+	require.NoError(t, err)
+
+	version := receivedBody["version"].(map[string]interface{})
+	assert.Equal(t, float64(3), version["number"])
+
+	bodyMap := receivedBody["body"].(map[string]interface{})
+	adf := bodyMap["atlas_doc_format"].(map[string]interface{})["value"].(string)
+	assert.Contains(t, adf, "A changed")
+	assert.Contains(t, adf, "B2")
+}
+
+func TestRunEdit_ConflictOverlappingSections(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := mockConflictServer(t, `<h1>Alpha</h1><p>A theirs</p><h1>Beta</h1><p>B</p>`, &receivedBody)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &editOptions{
+		pageID:  "12345",
+		stdin:   strings.NewReader("# Alpha\n\nA ours\n\n# Beta\n\nB\n"),
+		noColor: true,
+		merge:   true,
+	}
+
+	err := runEdit(opts, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "changes overlap")
+	assert.Contains(t, err.Error(), "# Alpha")
+	assert.Nil(t, receivedBody)
+}
+
+func TestRunEdit_ConflictResolution(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		opts     editOptions
+		wantErr  string
+		want     []string // In the published content
+		dontWant []string
+	}{
+		{name: "aborts by default", content: "# Alpha\n\nA ours\n\n# Beta\n\nB\n",
+			wantErr: "page 12345 was changed by someone else since version 1 (now version 2); use --merge to merge their changes, or --force to overwrite them"},
+		{name: "force overwrites", content: "# Alpha\n\nA ours\n\n# Beta\n\nB\n", opts: editOptions{force: true},
+			want: []string{"A ours"}, dontWant: []string{"B2"}},
+		{name: "prompt merges", content: "# Alpha\n\nA ours\n\n# Beta\n\nB\n", opts: editOptions{confirm: strings.NewReader("m\n")},
+			want: []string{"A ours", "B2"}},
+		{name: "prompt forces", content: "# Alpha\n\nA ours\n\n# Beta\n\nB\n", opts: editOptions{confirm: strings.NewReader("f\n")},
+			want: []string{"A ours"}, dontWant: []string{"B2"}},
+		{name: "prompt aborts", content: "# Alpha\n\nA ours\n\n# Beta\n\nB\n", opts: editOptions{confirm: strings.NewReader("\n")},
+			wantErr: "use --merge to merge their changes"},
+		{name: "storage can't merge", content: "<p>Replaced</p>", opts: editOptions{merge: true, legacy: true},
+			wantErr: "only markdown edits can be merged"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedBody map[string]interface{}
+			server := mockConflictServer(t, `<h1>Alpha</h1><p>A</p><h1>Beta</h1><p>B2</p>`, &receivedBody)
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			opts := tt.opts
+			opts.pageID = "12345"
+			opts.stdin = strings.NewReader(tt.content)
+			opts.noColor = true
+
+			err := runEdit(&opts, client)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, receivedBody, "the page isn't updated")
+				return
+			}
+			require.NoError(t, err)
+
+			version := receivedBody["version"].(map[string]interface{})
+			assert.Equal(t, float64(3), version["number"])
+			adf := receivedBody["body"].(map[string]interface{})["atlas_doc_format"].(map[string]interface{})["value"].(string)
+			for _, s := range tt.want {
+				assert.Contains(t, adf, s)
+			}
+			for _, s := range tt.dontWant {
+				assert.NotContains(t, adf, s)
+			}
+		})
+	}
+}
+
+func TestRunEdit_ADFNotSupported(t *testing.T) {
+	tmpDir := t.TempDir()
+	mdFile := filepath.Join(tmpDir, "content.md")
+	err := os.WriteFile(mdFile, []byte("# New Content"), 0644)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
 			w.WriteHeader(http.StatusOK)
 			w.Write([]byte(`{
 				"id": "12345",
-				"title": "Test Page",
-				"version": {"number": 2},
+				"title": "Test",
+				"version": {"number": 1},
+				"body": {"storage": {"value": "<p>Old</p>"}},
 				"_links": {"webui": "/pages/12345"}
 			}`))
-		case r.Method == "PUT" && strings.Contains(r.URL.Path, "/rest/api/content/12345/move/append/67890"):
+		case r.Method == "GET" && r.URL.Path == "/api/v2/spaces":
 			w.WriteHeader(http.StatusOK)
-			w.Write([]byte(`{}`))
+			w.Write([]byte(`{"results": []}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages":
+			// Capability probe: the site rejects ADF bodies
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message": "Invalid body-format"}`))
+		case r.Method == "PUT":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message": "Invalid representation"}`))
 		default:
 			w.WriteHeader(http.StatusNotFound)
 		}
@@ -1157,15 +1426,465 @@ func TestRunEdit_MoveOnly_BodyPreserved(t *testing.T) {
 	client := api.NewClient(server.URL, "test@example.com", "token")
 	opts := &editOptions{
 		pageID:  "12345",
-		parent:  "67890",
+		file:    mdFile,
+		noColor: true,
+	}
+
+	err = runEdit(opts, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported on this site")
+	assert.Contains(t, err.Error(), "--legacy")
+
+	// Legacy edits don't probe and keep the API error
+	opts.legacy = true
+	err = runEdit(opts, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid representation")
+}
+
+func TestRunEdit_LabelsOnly(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"id": "12345",
+				"title": "Test",
+				"version": {"number": 3},
+				"body": {"storage": {"value": "<p>Old</p>"}},
+				"_links": {"webui": "/pages/12345"}
+			}`))
+		case r.Method == "POST" && r.URL.Path == "/rest/api/content/12345/label":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"results": [{"id": "1", "name": "reviewed", "prefix": "global"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &editOptions{
+		pageID:  "12345",
+		labels:  []string{"reviewed"},
 		noColor: true,
 	}
 
 	err := runEdit(opts, client)
 	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /api/v2/pages/12345",
+		"POST /rest/api/content/12345/label",
+	}, requests, "the page itself isn't updated")
+}
 
-	// Verify body was preserved from original page
-	bodyMap := receivedBody["body"].(map[string]interface{})
-	storageMap := bodyMap["storage"].(map[string]interface{})
-	assert.Equal(t, "<p>Original content that must be preserved</p>", storageMap["value"])
+func TestRunEdit_EditorSwitch(t *testing.T) {
+	tests := []struct {
+		name       string
+		editor     string
+		legacy     bool
+		force      bool
+		wantUpdate bool
+		wantErr    string
+	}{
+		{"cloud page with legacy content", api.EditorCloud, true, false, false, "uses the cloud editor"},
+		{"legacy page with cloud content", api.EditorLegacy, false, false, false, "uses the legacy editor"},
+		{"forced switch", api.EditorCloud, true, true, true, ""},
+		{"same editor", api.EditorLegacy, true, false, true, ""},
+		{"editor not recorded", "", false, false, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+					w.Write([]byte(`{"id": "12345", "title": "Test", "version": {"number": 1}, "body": {"storage": {"value": "<p>Old</p>"}}}`))
+				case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345/properties":
+					if tt.editor == "" {
+						w.Write([]byte(`{"results": []}`))
+						return
+					}
+					w.Write([]byte(`{"results": [{"key": "editor", "value": "` + tt.editor + `"}]}`))
+				case r.Method == "PUT" && r.URL.Path == "/api/v2/pages/12345":
+					updated = true
+					w.Write([]byte(`{"id": "12345", "title": "Test", "version": {"number": 2}}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			opts := &editOptions{
+				pageID:            "12345",
+				legacy:            tt.legacy,
+				forceEditorSwitch: tt.force,
+				stdin:             strings.NewReader("# New\n"),
+				noColor:           true,
+			}
+
+			err := runEdit(opts, client)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Contains(t, err.Error(), "--force-editor-switch")
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantUpdate, updated)
+		})
+	}
+}
+
+func TestRunEdit_FrontMatter(t *testing.T) {
+	var receivedBody map[string]interface{}
+	var labelBody []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+			w.Write([]byte(`{"id": "12345", "title": "Test", "version": {"number": 2},
+				"body": {"storage": {"value": "<p>Old</p>"}}}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/v2/pages/12345":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &receivedBody)
+			w.Write([]byte(`{"id": "12345", "title": "Runbook", "version": {"number": 3}}`))
+		case r.Method == "POST" && r.URL.Path == "/rest/api/content/12345/label":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &labelBody)
+			w.Write([]byte(`{"results": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &editOptions{
+		pageID:  "12345",
+		stdin:   strings.NewReader("---\ntitle: Runbook\nid: \"12345\"\nversion: 2\nlabels: [ops]\n---\n# Steps\n"),
+		labels:  []string{"reviewed"},
+		noColor: true,
+	}
+
+	err := runEdit(opts, client)
+	require.NoError(t, err)
+	assert.Equal(t, "Runbook", receivedBody["title"])
+	adf := receivedBody["body"].(map[string]interface{})["atlas_doc_format"].(map[string]interface{})["value"].(string)
+	assert.NotContains(t, adf, "labels")
+	require.Len(t, labelBody, 2)
+	assert.Equal(t, "reviewed", labelBody[0]["name"])
+	assert.Equal(t, "ops", labelBody[1]["name"])
+}
+
+func TestRunEdit_FrontMatterWrongPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+			w.Write([]byte(`{"id": "12345", "title": "Test", "version": {"number": 2},
+				"body": {"storage": {"value": "<p>Old</p>"}}}`))
+		case r.Method == "PUT":
+			t.Error("page must not be updated")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &editOptions{
+		pageID:  "12345",
+		stdin:   strings.NewReader("---\nid: \"67890\"\n---\n# Steps\n"),
+		noColor: true,
+	}
+
+	err := runEdit(opts, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "front matter is for page 67890")
+}
+
+func TestRunEdit_FrontMatterStaleVersion(t *testing.T) {
+	var versions []string
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+			versions = append(versions, r.URL.Query().Get("version"))
+			version, storage := 2, `<h1>Alpha</h1><p>A</p><h1>Beta</h1><p>B2</p>`
+			if r.URL.Query().Get("version") == "1" {
+				version, storage = 1, `<h1>Alpha</h1><p>A</p><h1>Beta</h1><p>B</p>`
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":      "12345",
+				"title":   "Test",
+				"version": map[string]int{"number": version},
+				"body":    map[string]interface{}{"storage": map[string]string{"value": storage}},
+			})
+		case r.Method == "PUT":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &receivedBody)
+			w.Write([]byte(`{"id": "12345", "title": "Test", "version": {"number": 3}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &editOptions{
+		pageID:  "12345",
+		stdin:   strings.NewReader("---\nversion: 1\n---\n# Alpha\n\nA changed\n\n# Beta\n\nB\n"),
+		noColor: true,
+		merge:   true,
+	}
+
+	err := runEdit(opts, client)
+	require.NoError(t, err)
+	assert.Contains(t, versions, "1", "the edit's base version is fetched")
+
+	version := receivedBody["version"].(map[string]interface{})
+	assert.Equal(t, float64(3), version["number"])
+	adf := receivedBody["body"].(map[string]interface{})["atlas_doc_format"].(map[string]interface{})["value"].(string)
+	assert.Contains(t, adf, "A changed")
+	assert.Contains(t, adf, "B2", "changes made since the base version are kept")
+}
+
+func TestRunEdit_PublishedVersion(t *testing.T) {
+	var versions []string
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+			versions = append(versions, r.URL.Query().Get("version"))
+			version, storage := 2, `<h1>Alpha</h1><p>A</p><h1>Beta</h1><p>B2</p>`
+			if r.URL.Query().Get("version") == "1" {
+				version, storage = 1, `<h1>Alpha</h1><p>A</p><h1>Beta</h1><p>B</p>`
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":      "12345",
+				"title":   "Test",
+				"version": map[string]int{"number": version},
+				"body":    map[string]interface{}{"storage": map[string]string{"value": storage}},
+			})
+		case r.Method == "PUT":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &receivedBody)
+			w.Write([]byte(`{"id": "12345", "title": "Test", "version": {"number": 3}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// As with --watch after publishing version 1: the page was edited in
+	// Confluence since, so the edit is merged rather than overwriting it
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &editOptions{
+		pageID:           "12345",
+		stdin:            strings.NewReader("# Alpha\n\nA changed\n\n# Beta\n\nB\n"),
+		publishedVersion: 1,
+		merge:            true,
+		noColor:          true,
+	}
+
+	err := runEdit(opts, client)
+	require.NoError(t, err)
+	assert.Contains(t, versions, "1", "the last published version is fetched")
+	adf := receivedBody["body"].(map[string]interface{})["atlas_doc_format"].(map[string]interface{})["value"].(string)
+	assert.Contains(t, adf, "A changed")
+	assert.Contains(t, adf, "B2")
+	assert.Equal(t, 3, opts.publishedVersion)
+}
+
+func TestRunEdit_Draft(t *testing.T) {
+	const published = `{"id": "12345", "status": "current", "title": "Report", "version": {"number": 4},
+		"body": {"storage": {"value": "<p>Old</p>"}}}`
+
+	tests := []struct {
+		name        string
+		draft       string // Served for get-draft; empty for none
+		stdin       string
+		title       string
+		wantVersion int
+		wantErr     string
+	}{
+		{
+			name:        "starts a draft",
+			stdin:       "# New",
+			wantVersion: 5,
+		},
+		{
+			name:        "replaces the draft",
+			draft:       `{"id": "12345", "status": "draft", "title": "Report", "version": {"number": 5}, "body": {"storage": {"value": "<p>Draft</p>"}}}`,
+			stdin:       "# Newer",
+			wantVersion: 5,
+		},
+		{
+			name:    "rename only",
+			title:   "Renamed",
+			wantErr: "--draft saves new content as a draft",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated *api.UpdatePageRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+					page := published
+					if r.URL.Query().Get("get-draft") == "true" {
+						page = tt.draft
+					}
+					if page == "" {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					w.Write([]byte(page))
+				case r.Method == "PUT" && r.URL.Path == "/api/v2/pages/12345":
+					updated = &api.UpdatePageRequest{}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(updated))
+					w.Write([]byte(`{"id": "12345", "status": "draft", "title": "Report", "version": {"number": 5}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			opts := &editOptions{pageID: "12345", title: tt.title, draft: true, legacy: true, noColor: true}
+			if tt.stdin != "" {
+				opts.stdin = strings.NewReader(tt.stdin)
+			}
+			err := runEdit(opts, client)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, updated)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, updated)
+			assert.Equal(t, "draft", updated.Status)
+			assert.Equal(t, tt.wantVersion, updated.Version.Number)
+		})
+	}
+}
+
+func TestRunEdit_LegacySpaceDefault(t *testing.T) {
+	tests := []struct {
+		name       string
+		legacyAuto bool
+		wantLegacy bool
+	}{
+		{"space default applies without --legacy", true, true},
+		{"explicit --legacy=false wins", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedBody map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/spaces/777"):
+					w.Write([]byte(`{"id": "777", "key": "OLD"}`))
+				case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/pages/12345"):
+					w.Write([]byte(`{"id": "12345", "title": "Page", "spaceId": "777", "version": {"number": 2}}`))
+				case r.Method == "PUT" && strings.Contains(r.URL.Path, "/pages/12345"):
+					body, _ := io.ReadAll(r.Body)
+					json.Unmarshal(body, &receivedBody)
+					w.Write([]byte(`{"id": "12345", "title": "Page", "version": {"number": 3}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			opts := &editOptions{
+				pageID:       "12345",
+				stdin:        strings.NewReader("# Hello"),
+				legacyAuto:   tt.legacyAuto,
+				legacySpaces: []string{"old"},
+				noColor:      true,
+			}
+			require.NoError(t, runEdit(opts, client))
+
+			body := receivedBody["body"].(map[string]interface{})
+			if tt.wantLegacy {
+				assert.Contains(t, body, "storage")
+			} else {
+				assert.Contains(t, body, "atlas_doc_format")
+			}
+		})
+	}
+}
+
+func TestRunEdit_Classification(t *testing.T) {
+	classification := config.ClassificationConfig{Labels: []string{"public", "internal", "confidential"}}
+	tests := []struct {
+		name       string
+		existing   string
+		labels     []string
+		dflt       string
+		wantLabels []string
+		wantErr    string
+	}{
+		{"page already classified", "Internal", nil, "", nil, ""},
+		{"classification added", "", []string{"confidential"}, "", []string{"confidential"}, ""},
+		{"default added", "", []string{"ops"}, "internal", []string{"ops", "internal"}, ""},
+		{"refused without default", "", []string{"ops"}, "", nil, "page must carry a classification label (public, internal, confidential)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var added []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+					w.Write([]byte(`{"id": "12345", "title": "Test", "version": {"number": 3}}`))
+				case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345/labels":
+					if tt.existing == "" {
+						w.Write([]byte(`{"results": []}`))
+						return
+					}
+					fmt.Fprintf(w, `{"results": [{"id": "1", "name": %q}]}`, tt.existing)
+				case r.Method == "POST" && r.URL.Path == "/rest/api/content/12345/label":
+					var body []map[string]string
+					json.NewDecoder(r.Body).Decode(&body)
+					for _, l := range body {
+						added = append(added, l["name"])
+					}
+					w.Write([]byte(`{"results": []}`))
+				case r.Method == "PUT" && r.URL.Path == "/api/v2/pages/12345/title":
+					w.Write([]byte(`{"id": "12345", "title": "Renamed", "version": {"number": 4}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			opts := &editOptions{
+				pageID:         "12345",
+				title:          "Renamed",
+				labels:         tt.labels,
+				noColor:        true,
+				classification: classification,
+			}
+			opts.classification.Default = tt.dflt
+
+			err := runEdit(opts, api.NewClient(server.URL, "test@example.com", "token"))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLabels, added)
+		})
+	}
 }
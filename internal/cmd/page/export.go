@@ -0,0 +1,401 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/backup"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+// exportExtensions maps export formats to file extensions.
+var exportExtensions = map[string]string{
+	"pdf":  ".pdf",
+	"doc":  ".doc",
+	"html": ".html",
+}
+
+type exportOptions struct {
+	format     string
+	outputFile string
+	recursive  bool
+	force      bool
+	version    int
+	space      string
+	backupPath string // Export registry; empty to skip recording
+	output     string
+	noColor    bool
+}
+
+// exportedPage describes an exported page file.
+type exportedPage struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	File  string `json:"file"`
+	Bytes int64  `json:"bytes"`
+}
+
+// NewCmdExport creates the page export command.
+func NewCmdExport() *cobra.Command {
+	opts := &exportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export [page-id]",
+		Short: "Export a page as PDF, Word or HTML",
+		Long: `Export a page to a local file using Confluence's exporters.
+
+Formats:
+  pdf   PDF document (runs as an export task, which can take a while)
+  doc   Word document
+  html  Standalone HTML page of the rendered content
+
+The file is named after the page title unless --output-file is given.
+
+With --recursive, the page and all its descendants are exported into a
+directory (--output-file, default: the page title) that mirrors the page
+tree: each page's children are exported into a directory named after it.
+
+With --version, that version of the page is exported instead of the
+current one. Confluence's PDF and Word exporters only render the current
+version, so --version is limited to --format html and a single page; use
+'cfl page download --version' for an older version as markdown.
+
+With --space, every page tree in the space is exported into a directory
+(--output-file, default: the space key). The export is recorded as a
+backup of the space, which 'cfl space delete' requires before deleting it.`,
+		Example: `  # Export a page as PDF
+  cfl page export 12345 --format pdf
+
+  # Export as Word to a specific file
+  cfl page export 12345 --format doc -O handbook.doc
+
+  # Export a page tree as HTML
+  cfl page export 12345 --format html --recursive -O site
+
+  # Export the version that was reviewed
+  cfl page export 12345 --format html --version 7
+
+  # Back up a whole space before deleting it
+  cfl page export --space OLDDOCS --format html`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.backupPath = backup.DefaultPath()
+			var pageID string
+			if len(args) > 0 {
+				pageID = args[0]
+			}
+			return runExport(pageID, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.format, "format", "f", "pdf", "Export format: pdf, doc, html")
+	cmd.Flags().StringVarP(&opts.outputFile, "output-file", "O", "", "Output file, or directory with --recursive (default: page title)")
+	cmd.Flags().BoolVarP(&opts.recursive, "recursive", "r", false, "Export all descendant pages into a directory")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Overwrite existing files")
+	cmd.Flags().IntVar(&opts.version, "version", 0, "Export a specific version of the page (html only; default: current)")
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Export every page in a space instead of a single page tree")
+
+	return cmd
+}
+
+func runExport(pageID string, opts *exportOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	if _, ok := exportExtensions[opts.format]; !ok {
+		return fmt.Errorf("invalid format %q: must be pdf, doc or html", opts.format)
+	}
+
+	switch {
+	case pageID != "" && opts.space != "":
+		return fmt.Errorf("a page ID and --space are mutually exclusive")
+	case pageID == "" && opts.space == "":
+		return fmt.Errorf("a page ID or --space is required")
+	}
+
+	if opts.version < 0 {
+		return fmt.Errorf("invalid version: %d (must be >= 1)", opts.version)
+	}
+	if opts.version > 0 {
+		if opts.format != "html" {
+			return fmt.Errorf("--version is only supported with --format html: Confluence's PDF and Word exporters only render the current version")
+		}
+		if opts.recursive || opts.space != "" {
+			return fmt.Errorf("--version is incompatible with --recursive and --space")
+		}
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	if pageID != "" {
+		resolved, err := pageref.Resolve(context.Background(), client, pageID)
+		if err != nil {
+			return err
+		}
+		pageID = resolved
+	}
+
+	ctx := context.Background()
+	if opts.space != "" {
+		return runExportSpace(ctx, opts, client)
+	}
+
+	page, err := getExportPage(ctx, client, pageID, opts.format, opts.version)
+	if err != nil {
+		return err
+	}
+
+	var exported []*exportedPage
+	if opts.recursive {
+		dir := opts.outputFile
+		if dir == "" {
+			dir = Filename(page)
+		}
+		if err := exportTree(ctx, client, page, dir, Filename(page), opts, &exported); err != nil {
+			return err
+		}
+	} else {
+		file := opts.outputFile
+		if file == "" {
+			file = Filename(page) + exportExtensions[opts.format]
+		}
+		result, err := exportPage(ctx, client, page, file, opts)
+		if err != nil {
+			return err
+		}
+		exported = append(exported, result)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(exported)
+	}
+
+	if !opts.recursive {
+		renderer.Success(fmt.Sprintf("Exported: %s", exported[0].File))
+		return nil
+	}
+
+	renderExportTable(renderer, exported)
+	return nil
+}
+
+// runExportSpace exports every page tree in a space and records the
+// export in the backup registry.
+func runExportSpace(ctx context.Context, opts *exportOptions, client *api.Client) error {
+	space, err := client.GetSpaceByKey(ctx, opts.space)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", opts.space, err)
+	}
+	roots, err := treeRoots(ctx, client, "", space.Key)
+	if err != nil {
+		return err
+	}
+
+	dir := opts.outputFile
+	if dir == "" {
+		dir = space.Key
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	var exported []*exportedPage
+	used := make(map[string]bool)
+	for _, root := range roots {
+		page, err := getExportPage(ctx, client, root.ID, opts.format, 0)
+		if err != nil {
+			return err
+		}
+		name := Filename(page)
+		if used[strings.ToLower(name)] {
+			name += " (" + page.ID + ")"
+		}
+		used[strings.ToLower(name)] = true
+
+		if err := exportTree(ctx, client, page, dir, name, opts, &exported); err != nil {
+			return err
+		}
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	// A failure to record the backup doesn't undo the export
+	if opts.backupPath != "" {
+		absDir, err := filepath.Abs(dir)
+		if err == nil {
+			err = backup.Add(opts.backupPath, backup.Record{
+				Site:     client.BaseURL(),
+				SpaceKey: space.Key,
+				Dir:      absDir,
+				Format:   opts.format,
+				Pages:    len(exported),
+				Time:     time.Now(),
+			})
+		}
+		if err != nil {
+			renderer.Warning(fmt.Sprintf("Export not recorded as a backup: %v", err))
+		}
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(exported)
+	}
+
+	renderExportTable(renderer, exported)
+	return nil
+}
+
+// renderExportTable lists exported pages followed by a total.
+func renderExportTable(renderer *view.Renderer, exported []*exportedPage) {
+	headers := []string{"ID", "TITLE", "FILE", "SIZE"}
+	var rows [][]string
+	for _, e := range exported {
+		rows = append(rows, []string{e.ID, e.Title, e.File, view.FormatFileSize(e.Bytes)})
+	}
+	renderer.RenderTable(headers, rows)
+	renderer.Success(fmt.Sprintf("Exported %d pages", len(exported)))
+}
+
+// exportTree exports a page into dir as name and its children into a
+// subdirectory of the same name, appending results in depth-first order.
+func exportTree(ctx context.Context, client *api.Client, page *api.Page, dir, name string, opts *exportOptions, exported *[]*exportedPage) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	result, err := exportPage(ctx, client, page, filepath.Join(dir, name+exportExtensions[opts.format]), opts)
+	if err != nil {
+		return err
+	}
+	*exported = append(*exported, result)
+
+	children, err := listAllChildPages(ctx, client, page.ID)
+	if err != nil {
+		return err
+	}
+	// Siblings with the same file name are told apart by ID
+	used := make(map[string]bool)
+	for _, child := range children {
+		childName := Filename(&child)
+		if used[strings.ToLower(childName)] {
+			childName += " (" + child.ID + ")"
+		}
+		used[strings.ToLower(childName)] = true
+
+		childPage, err := getExportPage(ctx, client, child.ID, opts.format, 0)
+		if err != nil {
+			return err
+		}
+		if err := exportTree(ctx, client, childPage, filepath.Join(dir, name), childName, opts, exported); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getExportPage gets a page with the body needed to export it, at the
+// given version or the current one when version is 0.
+func getExportPage(ctx context.Context, client *api.Client, pageID, format string, version int) (*api.Page, error) {
+	apiOpts := &api.GetPageOptions{Version: version}
+	if format == "html" {
+		apiOpts.BodyFormat = "export_view"
+	}
+	page, err := client.GetPage(ctx, pageID, apiOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page %s: %w", pageID, err)
+	}
+	return page, nil
+}
+
+// exportPage exports a single page to file.
+func exportPage(ctx context.Context, client *api.Client, page *api.Page, file string, opts *exportOptions) (*exportedPage, error) {
+	if !opts.force {
+		if _, err := os.Stat(file); err == nil {
+			return nil, fmt.Errorf("file already exists: %s (use --force to overwrite)", file)
+		}
+	}
+
+	var content io.ReadCloser
+	var err error
+	switch opts.format {
+	case "pdf":
+		content, err = client.ExportPagePDF(ctx, page.ID)
+	case "doc":
+		content, err = client.ExportPageWord(ctx, page.ID)
+	case "html":
+		content = io.NopCloser(strings.NewReader(exportHTML(page)))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to export %s: %w", page.Title, err)
+	}
+	defer func() { _ = content.Close() }()
+
+	out, err := os.Create(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer func() { _ = out.Close() }()
+
+	n, err := io.Copy(out, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", file, err)
+	}
+
+	return &exportedPage{ID: page.ID, Title: page.Title, File: file, Bytes: n}, nil
+}
+
+// exportHTML wraps a page's rendered content in a standalone HTML document.
+func exportHTML(page *api.Page) string {
+	var body string
+	if page.Body != nil && page.Body.ExportView != nil {
+		body = page.Body.ExportView.Value
+	}
+	title := html.EscapeString(page.Title)
+	return "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>" + title + "</title>\n</head>\n<body>\n<h1>" +
+		title + "</h1>\n" + body + "\n</body>\n</html>\n"
+}
+
+// Filename returns a file name for a page based on its title,
+// falling back to its ID if the title has no usable characters.
+func Filename(page *api.Page) string {
+	name := strings.Map(func(r rune) rune {
+		if r < ' ' || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, page.Title)
+	name = strings.Trim(name, " .")
+	if name == "" {
+		return page.ID
+	}
+	return name
+}
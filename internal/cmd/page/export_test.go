@@ -0,0 +1,215 @@
+package page
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/backup"
+)
+
+// newExportServer serves a page tree: 1 "Handbook" with children 2 "Setup"
+// and 3 "Setup", and 2 with child 4 "Tips/Tricks". Space DOCS has the root
+// pages 1 and 5 "Archive".
+func newExportServer(t *testing.T) *httptest.Server {
+	pages := map[string]string{
+		"1": "Handbook",
+		"2": "Setup",
+		"3": "Setup",
+		"4": "Tips/Tricks",
+		"5": "Archive",
+	}
+	children := map[string]string{
+		"1": `[{"id": "2", "title": "Setup"}, {"id": "3", "title": "Setup"}]`,
+		"2": `[{"id": "4", "title": "Tips/Tricks"}]`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "100", "key": "DOCS", "name": "Docs"}]}`))
+		case r.URL.Path == "/api/v2/spaces/100/pages":
+			w.Write([]byte(`{"results": [{"id": "1", "title": "Handbook"}, {"id": "5", "title": "Archive"}]}`))
+		case r.URL.Path == "/exportword":
+			w.Write([]byte("DOC " + r.URL.Query().Get("pageId")))
+		case filepath.Base(r.URL.Path) == "children":
+			id := filepath.Base(filepath.Dir(r.URL.Path))
+			list := children[id]
+			if list == "" {
+				list = "[]"
+			}
+			w.Write([]byte(`{"results": ` + list + `}`))
+		default:
+			id := filepath.Base(r.URL.Path)
+			title, ok := pages[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if v := r.URL.Query().Get("version"); v != "" {
+				title += " v" + v
+			}
+			w.Write([]byte(`{"id": "` + id + `", "title": "` + title + `", "body": {"export_view": {"value": "<p>Body ` + id + `</p>"}}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunExport_Word(t *testing.T) {
+	server := newExportServer(t)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	file := filepath.Join(t.TempDir(), "out.doc")
+
+	err := runExport("1", &exportOptions{format: "doc", outputFile: file, noColor: true}, client)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "DOC 1", string(data))
+
+	// Existing files are kept without --force
+	err = runExport("1", &exportOptions{format: "doc", outputFile: file, noColor: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file already exists")
+}
+
+func TestRunExport_HTML(t *testing.T) {
+	server := newExportServer(t)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	file := filepath.Join(t.TempDir(), "out.html")
+
+	err := runExport("1", &exportOptions{format: "html", outputFile: file, noColor: true}, client)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<title>Handbook</title>")
+	assert.Contains(t, string(data), "<p>Body 1</p>")
+}
+
+func TestRunExport_Recursive(t *testing.T) {
+	server := newExportServer(t)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	dir := filepath.Join(t.TempDir(), "site")
+
+	err := runExport("1", &exportOptions{format: "html", outputFile: dir, recursive: true, noColor: true}, client)
+	require.NoError(t, err)
+
+	for _, name := range []string{
+		"Handbook.html",
+		"Handbook/Setup.html",
+		"Handbook/Setup (3).html",
+		"Handbook/Setup/Tips_Tricks.html",
+	} {
+		assert.FileExists(t, filepath.Join(dir, filepath.FromSlash(name)))
+	}
+}
+
+func TestRunExport_Space(t *testing.T) {
+	server := newExportServer(t)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	dir := filepath.Join(t.TempDir(), "docs")
+	registry := filepath.Join(t.TempDir(), "backups.json")
+
+	opts := &exportOptions{format: "html", outputFile: dir, space: "DOCS", backupPath: registry, noColor: true}
+	err := runExport("", opts, client)
+	require.NoError(t, err)
+
+	for _, name := range []string{
+		"Handbook.html",
+		"Handbook/Setup/Tips_Tricks.html",
+		"Archive.html",
+	} {
+		assert.FileExists(t, filepath.Join(dir, filepath.FromSlash(name)))
+	}
+
+	rec, err := backup.Latest(registry, client.BaseURL(), "DOCS")
+	require.NoError(t, err)
+	require.NotNil(t, rec)
+	assert.Equal(t, 5, rec.Pages)
+	assert.Equal(t, "html", rec.Format)
+}
+
+func TestRunExport_Space_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		pageID  string
+		opts    *exportOptions
+		wantErr string
+	}{
+		{"page and space", "1", &exportOptions{format: "html", space: "DOCS"}, "mutually exclusive"},
+		{"neither", "", &exportOptions{format: "html"}, "a page ID or --space is required"},
+		{"version", "", &exportOptions{format: "html", space: "DOCS", version: 2}, "incompatible with --recursive and --space"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runExport(tt.pageID, tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunExport_Version(t *testing.T) {
+	server := newExportServer(t)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	file := filepath.Join(t.TempDir(), "out.html")
+
+	err := runExport("1", &exportOptions{format: "html", outputFile: file, version: 3, noColor: true}, client)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<title>Handbook v3</title>")
+}
+
+func TestRunExport_Version_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *exportOptions
+		wantErr string
+	}{
+		{"negative", &exportOptions{format: "html", version: -1}, "invalid version"},
+		{"pdf", &exportOptions{format: "pdf", version: 2}, "only supported with --format html"},
+		{"recursive", &exportOptions{format: "html", version: 2, recursive: true}, "incompatible with --recursive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runExport("1", tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunExport_InvalidFormat(t *testing.T) {
+	err := runExport("1", &exportOptions{format: "epub"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid format")
+}
+
+func TestExportFilename(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{"Plain Title", "Plain Title"},
+		{`a/b\c:d*e?f"g<h>i|j`, "a_b_c_d_e_f_g_h_i_j"},
+		{" ..Dots.. ", "Dots"},
+		{"...", "42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Filename(&api.Page{ID: "42", Title: tt.title}))
+		})
+	}
+}
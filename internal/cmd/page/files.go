@@ -0,0 +1,247 @@
+package page
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/imageopt"
+	"github.com/open-cli-collective/confluence-cli/internal/mermaid"
+	"github.com/open-cli-collective/confluence-cli/internal/plantuml"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// localFile is a file referenced from markdown that is published as an attachment.
+type localFile struct {
+	ref      string // Destination as written in the markdown
+	path     string // Path on disk
+	filename string // Attachment filename
+}
+
+// imageOptions control how local images are shrunk before they are
+// attached, as set by --optimize, --max-width, --max-height and --quality.
+type imageOptions struct {
+	optimize    bool
+	optimizeSet bool // --optimize was given, so images.optimize is ignored
+	maxWidth    int  // Zero for config or the default
+	maxHeight   int  // Zero for config or the default
+	quality     int  // Zero for config or the default
+}
+
+// addImageFlags adds the image optimization flags to a command.
+func addImageFlags(cmd *cobra.Command, opts *imageOptions) {
+	cmd.Flags().BoolVar(&opts.optimize, "optimize", false, "Downscale and recompress local JPEG/PNG images before attaching them (default: config images.optimize)")
+	cmd.Flags().IntVar(&opts.maxWidth, "max-width", 0, fmt.Sprintf("Maximum image width in pixels with --optimize (default: config images.max_width or %d)", imageopt.DefaultMaxWidth))
+	cmd.Flags().IntVar(&opts.maxHeight, "max-height", 0, fmt.Sprintf("Maximum image height in pixels with --optimize (default: config images.max_height or %d)", imageopt.DefaultMaxHeight))
+	cmd.Flags().IntVar(&opts.quality, "quality", 0, fmt.Sprintf("JPEG quality 1-100 with --optimize (default: config images.quality or %d)", imageopt.DefaultQuality))
+}
+
+// validate checks the image optimization flags.
+func (o imageOptions) validate() error {
+	if o.maxWidth < 0 || o.maxHeight < 0 {
+		return fmt.Errorf("--max-width and --max-height must not be negative")
+	}
+	if o.quality < 0 || o.quality > 100 {
+		return fmt.Errorf("--quality must be between 1 and 100")
+	}
+	return nil
+}
+
+// withConfig returns the options with the settings no flag gave taken
+// from the images config.
+func (o imageOptions) withConfig(cfg config.ImagesConfig) imageOptions {
+	if !o.optimizeSet {
+		o.optimize = cfg.Optimize
+	}
+	if o.maxWidth == 0 {
+		o.maxWidth = cfg.MaxWidth
+	}
+	if o.maxHeight == 0 {
+		o.maxHeight = cfg.MaxHeight
+	}
+	if o.quality == 0 {
+		o.quality = cfg.Quality
+	}
+	return o
+}
+
+// Ways of publishing mermaid code blocks, chosen with --mermaid.
+const (
+	mermaidAsCode  = ""      // Leave them as code blocks
+	mermaidAsMacro = "macro" // Convert them to a Mermaid macro (storage format only)
+	mermaidAsImage = "image" // Render them to image attachments
+)
+
+// validateMermaid checks the --mermaid flag value.
+func validateMermaid(mode string, legacy bool) error {
+	switch mode {
+	case mermaidAsCode, mermaidAsImage:
+		return nil
+	case mermaidAsMacro:
+		if !legacy {
+			return fmt.Errorf("--mermaid macro requires --legacy")
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid mermaid mode %q: must be %s or %s", mode, mermaidAsMacro, mermaidAsImage)
+}
+
+// validateLegacyOptions checks --code-tabs and --mermaid, which have modes
+// that only work in legacy editor format.
+func validateLegacyOptions(codeTabs bool, mermaidMode string, legacy bool) error {
+	if codeTabs && !legacy {
+		return fmt.Errorf("--code-tabs requires --legacy")
+	}
+	return validateMermaid(mermaidMode, legacy)
+}
+
+// mermaidImages returns the renderer for mermaid fences, or nil unless
+// they are published as images.
+func mermaidImages(mode string, renderer *mermaid.Renderer) *mermaid.Renderer {
+	if mode != mermaidAsImage {
+		return nil
+	}
+	return renderer
+}
+
+// renderDiagrams replaces diagram code fences in markdown content with
+// references to rendered images, which are then attached like local files.
+// Fences without a renderer are left as code.
+func renderDiagrams(renderer *plantuml.Renderer, mermaidRenderer *mermaid.Renderer, content string, isMarkdown bool) (string, error) {
+	if !isMarkdown {
+		return content, nil
+	}
+	rendered, err := renderer.RenderMarkdown(context.Background(), []byte(content))
+	if err != nil {
+		return "", err
+	}
+	rendered, err = mermaidRenderer.RenderMarkdown(context.Background(), rendered)
+	if err != nil {
+		return "", err
+	}
+	return string(rendered), nil
+}
+
+// findLocalFiles returns the local files referenced by markdown content,
+// resolving relative references against baseDir. Every file must exist and
+// filenames must be unique, since attachments are matched by name.
+func findLocalFiles(content string, isMarkdown bool, baseDir string) ([]localFile, error) {
+	if !isMarkdown {
+		return nil, nil
+	}
+
+	var files []localFile
+	byName := make(map[string]string)
+	for _, ref := range md.LocalFileRefs([]byte(content)) {
+		p := ref
+		if decoded, err := url.PathUnescape(ref); err == nil {
+			p = decoded
+		}
+		p = filepath.FromSlash(p)
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(baseDir, p)
+		}
+
+		if _, err := os.Stat(p); err != nil {
+			return nil, fmt.Errorf("referenced file %s: %w", ref, err)
+		}
+
+		name := filepath.Base(p)
+		if other, ok := byName[name]; ok && other != p {
+			return nil, fmt.Errorf("referenced files %s and %s would both be attached as %s", other, p, name)
+		}
+		byName[name] = p
+
+		files = append(files, localFile{ref: ref, path: p, filename: name})
+	}
+	return files, nil
+}
+
+// plannedEmbeds returns embed options that reference files by attachment
+// filename only, for converting content before the files are uploaded.
+func plannedEmbeds(files []localFile, diagramMacro string) md.EmbedOptions {
+	embeds := md.EmbedOptions{DiagramMacro: diagramMacro}
+	if len(files) > 0 {
+		embeds.Attachments = make(map[string]md.EmbeddedFile, len(files))
+		for _, f := range files {
+			embeds.Attachments[f.ref] = md.EmbeddedFile{Filename: f.filename}
+		}
+	}
+	return embeds
+}
+
+// uploadLocalFiles attaches each file to the page, replacing earlier
+// versions with the same name, and returns embed options referencing the
+// uploaded attachments. Images are optimized first if images says so.
+func uploadLocalFiles(ctx context.Context, client *api.Client, pageID string, files []localFile, diagramMacro string, images imageOptions) (md.EmbedOptions, error) {
+	embeds := plannedEmbeds(files, diagramMacro)
+	for _, f := range files {
+		att, err := uploadLocalFile(ctx, client, pageID, f, images)
+		if err != nil {
+			return embeds, err
+		}
+		embeds.Attachments[f.ref] = md.EmbeddedFile{
+			Filename:   f.filename,
+			FileID:     att.FileID,
+			Collection: "contentId-" + pageID,
+		}
+	}
+	return embeds, nil
+}
+
+func uploadLocalFile(ctx context.Context, client *api.Client, pageID string, f localFile, images imageOptions) (*api.Attachment, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", f.path, err)
+	}
+	if images.optimize {
+		optimized, err := imageopt.Optimize(f.filename, data, imageopt.Options{
+			MaxWidth:  images.maxWidth,
+			MaxHeight: images.maxHeight,
+			Quality:   images.quality,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to optimize %s: %w", f.path, err)
+		}
+		data = optimized.Data
+	}
+
+	att, err := client.CreateOrUpdateAttachment(ctx, pageID, f.filename, bytes.NewReader(data), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload %s: %w", f.path, err)
+	}
+	return att, nil
+}
+
+// needsMediaIDs reports whether cloud editor content must be regenerated
+// once files are uploaded: ADF image nodes reference media file IDs, which
+// only exist after upload. Diagram macros reference files by name.
+func needsMediaIDs(files []localFile) bool {
+	for _, f := range files {
+		if !isDiagramFile(f.filename) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDiagramFile(filename string) bool {
+	return strings.EqualFold(filepath.Ext(filename), ".drawio")
+}
+
+// validateDiagramMacro checks the --diagram-macro flag value.
+func validateDiagramMacro(macro string) error {
+	switch macro {
+	case md.DiagramMacroDrawio, md.DiagramMacroGliffy:
+		return nil
+	}
+	return fmt.Errorf("invalid diagram macro %q: must be %s or %s", macro, md.DiagramMacroDrawio, md.DiagramMacroGliffy)
+}
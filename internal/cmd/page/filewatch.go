@@ -0,0 +1,132 @@
+package page
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+const (
+	// watchInterval is how often --watch checks the watched files.
+	watchInterval = 250 * time.Millisecond
+
+	// watchDebounce is how long the files must stay unchanged after a
+	// change before --watch republishes, so an editor's burst of writes
+	// publishes once.
+	watchDebounce = 500 * time.Millisecond
+)
+
+// fileWatcher publishes, then republishes whenever the watched files change.
+// Files are polled rather than watched with OS notifications, which also
+// catches editors that save by replacing the file.
+type fileWatcher struct {
+	name     string                   // What is watched, for messages
+	snapshot func() map[string]string // State of each watched file by path
+	publish  func() error
+	interval time.Duration
+	debounce time.Duration
+	stderr   io.Writer // For testing; defaults to os.Stderr
+}
+
+// newFileWatcher creates a watcher with the default timing.
+func newFileWatcher(name string, snapshot func() map[string]string, publish func() error) *fileWatcher {
+	return &fileWatcher{name: name, snapshot: snapshot, publish: publish, interval: watchInterval, debounce: watchDebounce}
+}
+
+// watchUntilInterrupted runs w until Ctrl-C.
+func watchUntilInterrupted(w *fileWatcher) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return w.run(ctx)
+}
+
+// run publishes, then republishes after each change until ctx is done.
+// Only the first publish failing is an error; later failures, such as a
+// syntax error saved by mistake, are reported and watching goes on.
+func (w *fileWatcher) run(ctx context.Context) error {
+	out := w.stderr
+	if out == nil {
+		out = os.Stderr
+	}
+	renderer := view.NewRenderer(view.FormatTable, false)
+	renderer.SetWriter(out)
+
+	last := w.snapshot()
+	if err := w.publish(); err != nil {
+		return err
+	}
+	renderer.Success(fmt.Sprintf("Watching %s for changes (Ctrl-C to stop)", w.name))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	var changedAt time.Time // Zero when no change is waiting to be published
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if current := w.snapshot(); !maps.Equal(current, last) {
+				last, changedAt = current, now
+				continue
+			}
+			if changedAt.IsZero() || now.Sub(changedAt) < w.debounce {
+				continue
+			}
+			changedAt = time.Time{}
+			if err := w.publish(); err != nil {
+				renderer.Warning(fmt.Sprintf("Publishing failed: %v", err))
+			}
+		}
+	}
+}
+
+// fileSnapshot returns the content hash of a file, so saving it unchanged
+// doesn't republish. A missing file has no entry.
+func fileSnapshot(path string) func() map[string]string {
+	return func() map[string]string {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return map[string]string{}
+		}
+		sum := sha256.Sum256(data)
+		return map[string]string{path: hex.EncodeToString(sum[:])}
+	}
+}
+
+// dirSnapshot returns the size and modification time of every file under
+// a directory, skipping hidden files and directories as page sync does.
+func dirSnapshot(dir string) func() map[string]string {
+	return func() map[string]string {
+		files := make(map[string]string)
+		_ = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if p != dir && strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				files[p] = fmt.Sprintf("%d %d", info.Size(), info.ModTime().UnixNano())
+			}
+			return nil
+		})
+		return files
+	}
+}
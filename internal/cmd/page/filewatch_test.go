@@ -0,0 +1,143 @@
+package page
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFiles is a watched file set whose state tests change.
+type fakeFiles struct {
+	mu        sync.Mutex
+	state     string
+	published int
+	fail      error // Returned by publishes after the first
+}
+
+func (f *fakeFiles) set(state string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.state = state
+}
+
+func (f *fakeFiles) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.published
+}
+
+func (f *fakeFiles) watcher(stderr *bytes.Buffer) *fileWatcher {
+	w := newFileWatcher("docs", func() map[string]string {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return map[string]string{"a.md": f.state}
+	}, func() error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.published++
+		if f.published > 1 {
+			return f.fail
+		}
+		return nil
+	})
+	w.interval, w.debounce, w.stderr = 2*time.Millisecond, 30*time.Millisecond, stderr
+	return w
+}
+
+func TestFileWatcher_Debounce(t *testing.T) {
+	files := &fakeFiles{state: "v1"}
+	var stderr bytes.Buffer
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- files.watcher(&stderr).run(ctx) }()
+
+	// Published once on start, and not again while nothing changes
+	require.Eventually(t, func() bool { return files.count() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, files.count())
+
+	// A burst of changes is published once, after it settles
+	for _, state := range []string{"v2", "v3", "v4"} {
+		files.set(state)
+		time.Sleep(5 * time.Millisecond)
+	}
+	require.Eventually(t, func() bool { return files.count() == 2 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 2, files.count())
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Contains(t, stderr.String(), "Watching docs for changes")
+}
+
+func TestFileWatcher_Errors(t *testing.T) {
+	// The first publish failing stops watching
+	files := &fakeFiles{state: "v1"}
+	w := files.watcher(nil)
+	w.publish = func() error { return errors.New("page not found") }
+	err := w.run(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "page not found", err.Error())
+
+	// Later failures are reported and watching goes on
+	files = &fakeFiles{state: "v1", fail: errors.New("bad markdown")}
+	var stderr bytes.Buffer
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- files.watcher(&stderr).run(ctx) }()
+
+	require.Eventually(t, func() bool { return files.count() == 1 }, time.Second, time.Millisecond)
+	files.set("v2")
+	require.Eventually(t, func() bool { return files.count() == 2 }, time.Second, time.Millisecond)
+	files.set("v3")
+	require.Eventually(t, func() bool { return files.count() == 3 }, time.Second, time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Contains(t, stderr.String(), "Publishing failed: bad markdown")
+}
+
+func TestFileSnapshot(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "page.md")
+	snapshot := fileSnapshot(file)
+	assert.Empty(t, snapshot())
+
+	require.NoError(t, os.WriteFile(file, []byte("# One\n"), 0644))
+	first := snapshot()
+	assert.Len(t, first, 1)
+
+	// Saving the same content isn't a change
+	require.NoError(t, os.WriteFile(file, []byte("# One\n"), 0644))
+	assert.Equal(t, first, snapshot())
+
+	require.NoError(t, os.WriteFile(file, []byte("# Two\n"), 0644))
+	assert.NotEqual(t, first, snapshot())
+}
+
+func TestDirSnapshot(t *testing.T) {
+	dir := writeFromDir(t, map[string]string{
+		"a.md":             "A",
+		"guide/setup.md":   "Setup",
+		"guide/img.png":    "PNG",
+		".cfl-sync.json":   "{}",
+		".git/config":      "x",
+		"guide/.notes.swp": "x",
+	})
+
+	files := dirSnapshot(dir)()
+	var names []string
+	for p := range files {
+		rel, err := filepath.Rel(dir, p)
+		require.NoError(t, err)
+		names = append(names, filepath.ToSlash(rel))
+	}
+	assert.ElementsMatch(t, []string{"a.md", "guide/setup.md", "guide/img.png"}, names)
+}
@@ -0,0 +1,91 @@
+package page
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// Input formats of page content.
+const (
+	formatMarkdown = "markdown"
+	formatStorage  = "storage" // Confluence storage format (XHTML)
+	formatADF      = "adf"     // Atlassian Document Format JSON
+
+	// formatNative is raw content in the format of the editor published
+	// to, as given with --no-markdown.
+	formatNative = ""
+)
+
+// inputFormatUsage describes --input-format for flag help.
+const inputFormatUsage = "Format of the content: auto, markdown, storage or adf (default: auto-detect piped content)"
+
+// storageRoot matches content that starts with a storage format element.
+var storageRoot = regexp.MustCompile(`^<(?:ac:|ri:|(?:p|h[1-6]|table|ul|ol|div|pre|blockquote)[\s/>])`)
+
+// detectFormat guesses the format of piped content: ADF if it is a JSON
+// document, storage format if it starts with a Confluence or block-level
+// XHTML element, and markdown otherwise.
+func detectFormat(content string) string {
+	s := strings.TrimSpace(strings.TrimPrefix(content, "\ufeff"))
+	if strings.HasPrefix(s, "{") {
+		var doc struct {
+			Type string `json:"type"`
+		}
+		if json.Unmarshal([]byte(s), &doc) == nil && doc.Type == "doc" {
+			return formatADF
+		}
+	}
+	if storageRoot.MatchString(s) {
+		return formatStorage
+	}
+	return formatMarkdown
+}
+
+// validateInputFormat checks the value of --input-format.
+func validateInputFormat(format string) error {
+	switch format {
+	case "", "auto", formatMarkdown, formatStorage, formatADF:
+		return nil
+	}
+	return fmt.Errorf("invalid input format %q: must be auto, markdown, storage or adf", format)
+}
+
+// contentFormat returns the format of content read from a file or the
+// editor (stdin is false) or piped in. inputFormat is the value of
+// --input-format and markdown that of --no-markdown, inverted; isMarkdown
+// is the choice made from them and the file extension.
+func contentFormat(content, inputFormat string, markdown *bool, isMarkdown, stdin bool) string {
+	switch {
+	case inputFormat != "" && inputFormat != "auto":
+		return inputFormat
+	case markdown != nil && !*markdown:
+		return formatNative
+	case markdown == nil && stdin:
+		return detectFormat(content)
+	case isMarkdown:
+		return formatMarkdown
+	}
+	// .html and .xhtml files
+	return formatStorage
+}
+
+// editorContent returns raw content in the format of the editor published
+// to, converting storage format for the cloud editor.
+func editorContent(ctx context.Context, client *api.Client, content, format string, legacy bool) (string, error) {
+	switch {
+	case format == formatStorage && !legacy:
+		converted, err := client.ConvertStorageToADF(ctx, content)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert storage format to the cloud editor format: %w", err)
+		}
+		return converted, nil
+	case format == formatADF && legacy:
+		return "", fmt.Errorf("ADF content can't be published with --legacy; drop --legacy to use the cloud editor")
+	}
+	return content, nil
+}
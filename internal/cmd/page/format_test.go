@@ -0,0 +1,161 @@
+package page
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"markdown heading", "# Title\n\nText", formatMarkdown},
+		{"markdown with inline html", "Some <b>bold</b> text", formatMarkdown},
+		{"front matter", "---\ntitle: x\n---\n<p>x</p>", formatMarkdown},
+		{"paragraph", "<p>Hello</p>", formatStorage},
+		{"paragraph with attributes", "\n  <p class=\"x\">Hello</p>", formatStorage},
+		{"macro", `<ac:structured-macro ac:name="toc"/>`, formatStorage},
+		{"heading", "<h2>Title</h2><p>x</p>", formatStorage},
+		{"table", "<table><tbody></tbody></table>", formatStorage},
+		{"byte order mark", "\ufeff<p>Hello</p>", formatStorage},
+		{"not a block element", "<pre-release> notes", formatMarkdown},
+		{"adf", `{"type": "doc", "version": 1, "content": []}`, formatADF},
+		{"other json", `{"type": "paragraph"}`, formatMarkdown},
+		{"invalid json", `{"type": "doc"`, formatMarkdown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, detectFormat(tt.content))
+		})
+	}
+}
+
+func TestContentFormat(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name        string
+		inputFormat string
+		markdown    *bool
+		isMarkdown  bool
+		stdin       bool
+		want        string
+	}{
+		{"piped storage detected", "", nil, true, true, formatStorage},
+		{"auto detects", "auto", nil, true, true, formatStorage},
+		{"override", "markdown", nil, true, true, formatMarkdown},
+		{"override file", "adf", nil, true, false, formatADF},
+		{"no-markdown", "", &no, false, true, formatNative},
+		{"forced markdown", "", &yes, true, true, formatMarkdown},
+		{"md file", "", nil, true, false, formatMarkdown},
+		{"html file", "", nil, false, false, formatStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := contentFormat("<p>x</p>", tt.inputFormat, tt.markdown, tt.isMarkdown, tt.stdin)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateInputFormat(t *testing.T) {
+	for _, f := range []string{"", "auto", "markdown", "storage", "adf"} {
+		assert.NoError(t, validateInputFormat(f))
+	}
+	err := validateInputFormat("html")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid input format")
+}
+
+// newDetectServer returns a server for creating a page that converts
+// storage format to ADF, recording the created page's body.
+func newDetectServer(t *testing.T, created *map[string]interface{}, converted *bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV", "name": "Dev"}]}`))
+		case r.URL.Path == "/rest/api/contentbody/convert/atlas_doc_format":
+			*converted = true
+			w.Write([]byte(`{"representation": "atlas_doc_format", "value": "{\"type\":\"doc\",\"version\":1,\"content\":[]}"}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v2/pages":
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, created))
+			w.Write([]byte(`{"id": "99999", "title": "Piped", "version": {"number": 1}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunCreate_DetectsPipedFormat(t *testing.T) {
+	tests := []struct {
+		name          string
+		stdin         string
+		inputFormat   string
+		legacy        bool
+		wantConverted bool
+		wantBody      string // Representation published
+	}{
+		{"storage for cloud editor", "<p>Hello</p>", "", false, true, "atlas_doc_format"},
+		{"storage for legacy editor", "<p>Hello</p>", "", true, false, "storage"},
+		{"adf", `{"type":"doc","version":1,"content":[]}`, "", false, false, "atlas_doc_format"},
+		{"markdown", "# Hello", "", true, false, "storage"},
+		{"forced markdown", "<p>Hello</p>", "markdown", true, false, "storage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created map[string]interface{}
+			converted := false
+			server := newDetectServer(t, &created, &converted)
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			opts := &createOptions{
+				space:       "DEV",
+				title:       "Piped",
+				legacy:      tt.legacy,
+				inputFormat: tt.inputFormat,
+				stdin:       strings.NewReader(tt.stdin),
+				noColor:     true,
+			}
+			require.NoError(t, runCreate(opts, client))
+			assert.Equal(t, tt.wantConverted, converted)
+
+			body := created["body"].(map[string]interface{})
+			assert.Contains(t, body, tt.wantBody)
+		})
+	}
+}
+
+func TestRunCreate_ADFWithLegacy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &createOptions{
+		space:   "DEV",
+		title:   "Piped",
+		legacy:  true,
+		stdin:   strings.NewReader(`{"type":"doc","version":1,"content":[]}`),
+		noColor: true,
+	}
+	err := runCreate(opts, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "can't be published with --legacy")
+}
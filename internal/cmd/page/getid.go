@@ -0,0 +1,90 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type getIDOptions struct {
+	output  string
+	noColor bool
+	stdout  io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdGetID creates the page get-id command.
+func NewCmdGetID() *cobra.Command {
+	opts := &getIDOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "get-id <page>",
+		Short: "Print the ID of a page",
+		Long: `Resolve a page reference to its page ID and print it.
+
+The page may be ` + pageref.Usage + `. Every command that takes a page ID
+accepts the same references, so get-id is mainly useful in scripts that
+need the numeric ID itself.`,
+		Example: `  # ID of a page by URL
+  cfl page get-id https://example.atlassian.net/wiki/spaces/DEV/pages/12345/Runbook
+
+  # ID of a page by space and title
+  cfl page get-id "DEV/Release Runbook"
+
+  # Output as JSON
+  cfl page get-id "DEV/Release Runbook" -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runGetID(args[0], opts, nil)
+		},
+	}
+
+	return cmd
+}
+
+func runGetID(ref string, opts *getIDOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, ref)
+	if err != nil {
+		return err
+	}
+
+	if view.IsStructured(opts.output) {
+		renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+		return renderer.RenderJSON(map[string]string{"id": pageID})
+	}
+
+	stdout := opts.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	_, err = fmt.Fprintln(stdout, pageID)
+	return err
+}
@@ -0,0 +1,65 @@
+package page
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunGetID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "100", "key": "DEV"}]}`))
+		case "/api/v2/spaces/100/pages":
+			assert.Equal(t, "Release Notes", r.URL.Query().Get("title"))
+			w.Write([]byte(`{"results": [{"id": "42", "title": "Release Notes"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{"id", "12345", "12345\n"},
+		{"url", server.URL + "/spaces/DEV/pages/777/Title", "777\n"},
+		{"space and title", "DEV/Release Notes", "42\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			err := runGetID(tt.ref, &getIDOptions{stdout: &out}, client)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestRunGetID_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "100", "key": "DEV"}]}`))
+		default:
+			w.Write([]byte(`{"results": []}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runGetID("DEV/Missing", &getIDOptions{}, client)
+	require.Error(t, err)
+}
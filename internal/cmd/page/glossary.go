@@ -0,0 +1,83 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// glossaryLinks returns the definition URL for each configured glossary
+// term. Terms map to a page ID or URL; every page in the glossary space
+// adds a term for its title. Explicit terms take precedence. The term for
+// the page being published (by title) is left out so it doesn't link to itself.
+func glossaryLinks(ctx context.Context, client *api.Client, glossary *config.GlossaryConfig, title string) (map[string]string, error) {
+	if glossary == nil || (len(glossary.Terms) == 0 && glossary.Space == "") {
+		return nil, fmt.Errorf("--glossary requires glossary.terms or glossary.space in config")
+	}
+
+	links := make(map[string]string)
+	if glossary.Space != "" {
+		space, err := client.GetSpaceByKey(ctx, glossary.Space)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find glossary space '%s': %w", glossary.Space, err)
+		}
+		opts := &api.ListPagesOptions{Limit: 250, Status: "current"}
+		for {
+			result, err := client.ListPages(ctx, space.ID, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list glossary pages: %w", err)
+			}
+			for _, p := range result.Results {
+				links[p.Title] = client.BaseURL() + p.Links.WebUI
+			}
+			if opts.Cursor = result.NextCursor(); opts.Cursor == "" {
+				break
+			}
+		}
+	}
+
+	for term, target := range glossary.Terms {
+		links[term] = glossaryURL(client, target)
+	}
+
+	for term := range links {
+		if strings.EqualFold(term, title) {
+			delete(links, term)
+		}
+	}
+	return links, nil
+}
+
+// glossaryURL resolves a configured glossary target: a page ID or a URL.
+func glossaryURL(client *api.Client, target string) string {
+	if isPageID(target) {
+		return client.BaseURL() + "/pages/viewpage.action?pageId=" + target
+	}
+	return target
+}
+
+// isPageID reports whether s is a numeric page ID.
+func isPageID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// linkGlossary links the first occurrence of each glossary term in
+// markdown content. Storage format content is returned unchanged.
+func linkGlossary(content string, isMarkdown bool, links map[string]string) string {
+	if !isMarkdown || len(links) == 0 {
+		return content
+	}
+	return string(md.LinkGlossaryTerms([]byte(content), links))
+}
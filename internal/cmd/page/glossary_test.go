@@ -0,0 +1,87 @@
+package page
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+func TestGlossaryLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "777", "key": "GLOSS"}]}`))
+		case r.URL.Path == "/api/v2/spaces/777/pages" && r.URL.Query().Get("cursor") == "":
+			w.Write([]byte(`{"results": [{"id": "1", "title": "Widget", "_links": {"webui": "/spaces/GLOSS/pages/1/Widget"}}],
+				"_links": {"next": "/api/v2/spaces/777/pages?cursor=abc"}}`))
+		case r.URL.Path == "/api/v2/spaces/777/pages":
+			w.Write([]byte(`{"results": [{"id": "2", "title": "Gadget", "_links": {"webui": "/spaces/GLOSS/pages/2/Gadget"}},
+				{"id": "3", "title": "Release Notes", "_links": {"webui": "/spaces/GLOSS/pages/3"}}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	links, err := glossaryLinks(context.Background(), client, &config.GlossaryConfig{
+		Space: "GLOSS",
+		Terms: map[string]string{"API": "12345", "Gadget": "https://example.com/gadget"},
+	}, "release notes")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"Widget": server.URL + "/spaces/GLOSS/pages/1/Widget",
+		"Gadget": "https://example.com/gadget",
+		"API":    server.URL + "/pages/viewpage.action?pageId=12345",
+	}, links)
+}
+
+func TestGlossaryLinks_NotConfigured(t *testing.T) {
+	_, err := glossaryLinks(context.Background(), nil, &config.GlossaryConfig{}, "Title")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "glossary.terms or glossary.space")
+}
+
+func TestRunCreate_LinksGlossaryTerms(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+		case r.Method == "POST" && strings.Contains(r.URL.Path, "/pages"):
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &receivedBody)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "99999", "title": "Intro", "version": {"number": 1}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &createOptions{
+		space:       "DEV",
+		title:       "Intro",
+		stdin:       strings.NewReader("Call the API. The API again, and the {!SDK}.\n"),
+		legacy:      true,
+		noColor:     true,
+		glossary:    true,
+		glossaryCfg: &config.GlossaryConfig{Terms: map[string]string{"API": "42", "SDK": "43"}},
+	}
+
+	require.NoError(t, runCreate(opts, client))
+
+	content := receivedBody["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"].(string)
+	assert.Equal(t, 1, strings.Count(content, "<a href"))
+	assert.Contains(t, content, `<a href="`+server.URL+`/pages/viewpage.action?pageId=42">API</a>`)
+	assert.Contains(t, content, "the SDK.")
+}
@@ -0,0 +1,136 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type historyOptions struct {
+	limit   int
+	output  string
+	noColor bool
+}
+
+// NewCmdHistory creates the page history command.
+func NewCmdHistory() *cobra.Command {
+	opts := &historyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "history <page-id>",
+		Short: "List the versions of a page",
+		Long: `List the versions of a page, newest first.
+
+To read an old version use 'cfl page view --version', to compare two
+versions use 'cfl page diff', and to make an old version current again
+use 'cfl page restore'.`,
+		Example: `  # List recent versions
+  cfl page history 12345
+
+  # List the whole history
+  cfl page history 12345 --limit 0
+
+  # View version 3
+  cfl page view 12345 --version 3`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runHistory(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.limit, "limit", "l", 25, "Maximum number of versions to return (0 for all)")
+
+	return cmd
+}
+
+func runHistory(pageID string, opts *historyOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.limit < 0 {
+		return fmt.Errorf("invalid limit: %d (must be >= 0)", opts.limit)
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
+	}
+
+	// Page through versions until the limit is reached
+	var versions []api.Version
+	hasMore := false
+	apiOpts := &api.ListVersionsOptions{Limit: 50}
+	for {
+		if opts.limit > 0 {
+			apiOpts.Limit = min(50, opts.limit-len(versions))
+		}
+		result, err := client.ListPageVersions(context.Background(), pageID, apiOpts)
+		if err != nil {
+			return fmt.Errorf("failed to list versions: %w", err)
+		}
+		versions = append(versions, result.Results...)
+
+		apiOpts.Cursor = result.NextCursor()
+		if opts.limit > 0 && len(versions) >= opts.limit {
+			hasMore = apiOpts.Cursor != ""
+			break
+		}
+		if apiOpts.Cursor == "" {
+			break
+		}
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if len(versions) == 0 && !view.IsStructured(opts.output) {
+		fmt.Println("No versions found.")
+		return nil
+	}
+
+	headers := []string{"VERSION", "CREATED", "AUTHOR", "MINOR", "MESSAGE"}
+	var rows [][]string
+	for _, v := range versions {
+		created := ""
+		if !v.CreatedAt.IsZero() {
+			created = v.CreatedAt.Format("2006-01-02 15:04")
+		}
+		minor := ""
+		if v.MinorEdit {
+			minor = "yes"
+		}
+		rows = append(rows, []string{strconv.Itoa(v.Number), created, v.AuthorID, minor, v.Message})
+	}
+
+	renderer.RenderList(headers, rows, hasMore)
+
+	if hasMore && !view.IsStructured(opts.output) {
+		fmt.Fprintf(os.Stderr, "\n(showing latest %d versions, use --limit to see more)\n", len(versions))
+	}
+
+	return nil
+}
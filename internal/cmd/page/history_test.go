@@ -0,0 +1,113 @@
+package page
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// newVersionServer serves page 12345 with the given storage bodies, one per
+// version starting at version 1; the last is current. Restores are recorded
+// in restored.
+func newVersionServer(t *testing.T, bodies []string, restored *[]int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/pages/12345/versions":
+			// Two versions per request, newest first
+			start, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+			var results []api.Version
+			for n := len(bodies) - start; n >= 1 && len(results) < 2; n-- {
+				results = append(results, api.Version{Number: n, Message: fmt.Sprintf("edit %d", n)})
+			}
+			resp := map[string]any{"results": results, "_links": map[string]string{}}
+			if next := start + len(results); next < len(bodies) {
+				resp["_links"] = map[string]string{"next": "/api/v2/pages/12345/versions?cursor=" + strconv.Itoa(next)}
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		case r.URL.Path == "/api/v2/pages/12345":
+			n := len(bodies)
+			if v := r.URL.Query().Get("version"); v != "" {
+				n, _ = strconv.Atoi(v)
+			}
+			if n < 1 || n > len(bodies) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":      "12345",
+				"title":   "Runbook",
+				"version": map[string]int{"number": n},
+				"body":    map[string]any{"storage": map[string]string{"value": bodies[n-1]}},
+			})
+		case r.URL.Path == "/rest/api/content/12345/version" && r.Method == "POST":
+			var req struct {
+				Params struct {
+					VersionNumber int `json:"versionNumber"`
+				} `json:"params"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			*restored = append(*restored, req.Params.VersionNumber)
+			_, _ = w.Write([]byte(`{"number": ` + strconv.Itoa(len(bodies)+1) + `}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunHistory(t *testing.T) {
+	server := newVersionServer(t, []string{"<p>a</p>", "<p>b</p>", "<p>c</p>"}, nil)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	err := runHistory("12345", &historyOptions{limit: 25, noColor: true}, client)
+	require.NoError(t, err)
+}
+
+func TestRunHistory_Limit(t *testing.T) {
+	tests := []struct {
+		name       string
+		limit      int
+		wantLimits []string // limit param of each request
+	}{
+		{"stops at limit", 3, []string{"3", "1"}},
+		{"all versions", 0, []string{"50", "50", "50"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var limits []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				limits = append(limits, r.URL.Query().Get("limit"))
+				// Two versions per request and five in all
+				page := len(limits)
+				next := ""
+				if page < 3 {
+					next = `"next": "/api/v2/pages/12345/versions?cursor=` + strconv.Itoa(page) + `"`
+				}
+				_, _ = w.Write([]byte(`{"results": [{"number": 1}, {"number": 2}], "_links": {` + next + `}}`))
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			err := runHistory("12345", &historyOptions{limit: tt.limit, noColor: true}, client)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLimits, limits)
+		})
+	}
+}
+
+func TestRunHistory_InvalidLimit(t *testing.T) {
+	err := runHistory("12345", &historyOptions{limit: -1}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid limit")
+}
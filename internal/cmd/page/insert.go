@@ -0,0 +1,254 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// insertAttempts is how many times an insert is tried when the page keeps
+// changing under it.
+const insertAttempts = 3
+
+type insertOptions struct {
+	file         string
+	afterHeading string
+	prepend      bool
+	output       string
+	noColor      bool
+	stdin        io.Reader // For testing; defaults to os.Stdin
+}
+
+// NewCmdAppend creates the page append command.
+func NewCmdAppend() *cobra.Command {
+	return newCmdInsert(false)
+}
+
+// NewCmdPrepend creates the page prepend command.
+func NewCmdPrepend() *cobra.Command {
+	return newCmdInsert(true)
+}
+
+// newCmdInsert creates the page append command, or with prepend the page
+// prepend command.
+func newCmdInsert(prepend bool) *cobra.Command {
+	opts := &insertOptions{prepend: prepend}
+
+	verb, name, where, sectionWhere := "append", "Append", "end", "end of the section under it"
+	if prepend {
+		verb, name, where, sectionWhere = "prepend", "Prepend", "start", "start of its section, right after it"
+	}
+
+	cmd := &cobra.Command{
+		Use:   verb + " <page-id>",
+		Short: name + " markdown content to a page",
+		Long: `Add markdown content at the ` + where + ` of a page, leaving the rest of the
+page as it is.
+
+The page is fetched in its own editor's format, the content converted and
+inserted, and the page republished, so log-style pages can be added to
+without replacing their content. The content is read from --file, or from
+standard input.
+
+With --after-heading, the content goes at the ` + sectionWhere + `.
+A section runs to the next heading of the same or a higher level. Headings
+are matched by their text, ignoring case, at the top level of the page or
+in the columns of its layouts.
+
+If the page changes while the content is being inserted, the insert is
+retried on the latest version.`,
+		Example: `  # ` + name + ` notes to a page
+  cfl page ` + verb + ` 12345 --file notes.md
+
+  # ` + name + ` an entry to the Changelog section
+  echo "- Fixed login" | cfl page ` + verb + ` 12345 --after-heading "Changelog"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runInsert(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Read content from file")
+	cmd.Flags().StringVar(&opts.afterHeading, "after-heading", "", "Insert into the section under this heading")
+
+	return cmd
+}
+
+func runInsert(ref string, opts *insertOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	content, err := readInsertContent(opts)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("content cannot be empty")
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pageID, err := pageref.Resolve(ctx, client, ref)
+	if err != nil {
+		return err
+	}
+
+	// Insert in the page's own format so its editor doesn't change
+	editor, err := pageEditor(ctx, client, pageID)
+	if err != nil {
+		return err
+	}
+	legacy := editor == api.EditorLegacy
+	var converted string
+	if legacy {
+		converted, err = md.ToConfluenceStorage([]byte(content))
+	} else {
+		converted, err = md.ToADF([]byte(content))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to convert markdown: %w", err)
+	}
+
+	var page *api.Page
+	for attempt := 1; ; attempt++ {
+		page, err = insertIntoPage(ctx, client, pageID, converted, opts, legacy)
+		if err == nil || !isVersionConflict(err) || attempt == insertAttempts {
+			break
+		}
+	}
+	if isVersionConflict(err) {
+		return fmt.Errorf("failed to update page: it changed on every attempt: %w", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(page)
+	}
+	action := "Appended to"
+	if opts.prepend {
+		action = "Prepended to"
+	}
+	renderer.Success(fmt.Sprintf("%s page: %s", action, page.Title))
+	renderer.RenderKeyValue("ID", page.ID)
+	if page.Version != nil {
+		renderer.RenderKeyValue("Version", strconv.Itoa(page.Version.Number))
+	}
+	return nil
+}
+
+// insertIntoPage inserts converted content into the latest version of a
+// page and publishes it. A version conflict is returned as it is, so the
+// insert can be retried.
+func insertIntoPage(ctx context.Context, client *api.Client, pageID, converted string, opts *insertOptions, legacy bool) (*api.Page, error) {
+	format := "atlas_doc_format"
+	if legacy {
+		format = "storage"
+	}
+	page, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: format})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+
+	var body string
+	if page.Body != nil {
+		rep := page.Body.AtlasDocFormat
+		if legacy {
+			rep = page.Body.Storage
+		}
+		if rep != nil {
+			body = rep.Value
+		}
+	}
+
+	var updated string
+	if legacy {
+		updated, err = md.InsertStorage(body, converted, opts.afterHeading, opts.prepend)
+	} else {
+		updated, err = md.InsertADF(body, converted, opts.afterHeading, opts.prepend)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("page %s: %w", pageID, err)
+	}
+
+	version := 1
+	if page.Version != nil {
+		version = page.Version.Number
+	}
+	message := "Appended via cfl"
+	if opts.prepend {
+		message = "Prepended via cfl"
+	}
+	result, err := client.UpdatePage(ctx, pageID, &api.UpdatePageRequest{
+		ID:     pageID,
+		Status: "current",
+		Title:  page.Title,
+		Body:   newEditBody(updated, legacy),
+		Version: &api.Version{
+			Number:  version + 1,
+			Message: message,
+		},
+	})
+	if err != nil {
+		if isVersionConflict(err) {
+			return nil, err
+		}
+		err = checkADFSupport(ctx, client, err, legacy)
+		return nil, fmt.Errorf("failed to update page: %w", err)
+	}
+	return result, nil
+}
+
+// readInsertContent reads the content to insert from the file or stdin.
+func readInsertContent(opts *insertOptions) (string, error) {
+	if opts.file != "" {
+		data, err := os.ReadFile(opts.file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read file: %w", err)
+		}
+		return string(data), nil
+	}
+
+	stdin := opts.stdin
+	if stdin == nil {
+		if isTerminal() {
+			return "", fmt.Errorf("no content: use --file or pipe content to standard input")
+		}
+		stdin = os.Stdin
+	}
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read stdin: %w", err)
+	}
+	return string(data), nil
+}
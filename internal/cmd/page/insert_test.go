@@ -0,0 +1,156 @@
+package page
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunInsert(t *testing.T) {
+	storage := `<h2>Changelog</h2><p>Old</p><h2>Other</h2>`
+	adf := `{"type":"doc","version":1,"content":[{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Changelog"}]},{"type":"paragraph","content":[{"type":"text","text":"Old"}]}]}`
+
+	tests := []struct {
+		name         string
+		editor       string
+		afterHeading string
+		prepend      bool
+		wantBody     string
+		wantContains []string
+		wantErr      string
+	}{
+		{
+			name:         "legacy page section",
+			editor:       "v1",
+			afterHeading: "Changelog",
+			wantBody:     "storage",
+			wantContains: []string{"<h2>Changelog</h2><p>Old</p><p>New entry</p>\n<h2>Other</h2>"},
+		},
+		{
+			name:         "legacy page prepend",
+			editor:       "v1",
+			prepend:      true,
+			wantBody:     "storage",
+			wantContains: []string{"<p>New entry</p>\n<h2>Changelog</h2>"},
+		},
+		{
+			name:         "cloud page append",
+			editor:       "v2",
+			wantBody:     "atlas_doc_format",
+			wantContains: []string{`"text":"Old"}]},{"type":"paragraph","content":[{"type":"text","text":"New entry"}]}`},
+		},
+		{
+			name:         "heading not found",
+			editor:       "v1",
+			afterHeading: "Missing",
+			wantErr:      `heading "Missing" not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345/properties":
+					w.Write([]byte(`{"results": [{"key": "editor", "value": "` + tt.editor + `"}]}`))
+				case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+					format := r.URL.Query().Get("body-format")
+					body := `"storage": {"value": ` + jsonString(t, storage) + `}`
+					if format == "atlas_doc_format" {
+						body = `"atlas_doc_format": {"value": ` + jsonString(t, adf) + `}`
+					}
+					w.Write([]byte(`{"id": "12345", "title": "Log", "version": {"number": 3}, "body": {` + body + `}}`))
+				case r.Method == "PUT" && r.URL.Path == "/api/v2/pages/12345":
+					body, _ := io.ReadAll(r.Body)
+					require.NoError(t, json.Unmarshal(body, &received))
+					w.Write([]byte(`{"id": "12345", "title": "Log", "version": {"number": 4}}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			opts := &insertOptions{
+				afterHeading: tt.afterHeading,
+				prepend:      tt.prepend,
+				noColor:      true,
+				stdin:        strings.NewReader("New entry\n"),
+			}
+
+			err := runInsert("12345", opts, client)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, received)
+				return
+			}
+			require.NoError(t, err)
+
+			require.NotNil(t, received)
+			assert.Equal(t, float64(4), received["version"].(map[string]interface{})["number"])
+			body := received["body"].(map[string]interface{})
+			rep := body[tt.wantBody].(map[string]interface{})
+			for _, want := range tt.wantContains {
+				assert.Contains(t, rep["value"], want)
+			}
+		})
+	}
+}
+
+func TestRunInsert_RetriesConflict(t *testing.T) {
+	gets, puts := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/pages/12345/properties":
+			w.Write([]byte(`{"results": [{"key": "editor", "value": "v1"}]}`))
+		case r.Method == "GET":
+			gets++
+			w.Write([]byte(`{"id": "12345", "title": "Log", "version": {"number": ` + strconv.Itoa(gets) + `}, "body": {"storage": {"value": "<p>Old</p>"}}}`))
+		case r.Method == "PUT":
+			puts++
+			if puts == 1 {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"message": "Version conflict"}`))
+				return
+			}
+			var req api.UpdatePageRequest
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &req))
+			assert.Equal(t, 3, req.Version.Number)
+			w.Write([]byte(`{"id": "12345", "title": "Log", "version": {"number": 3}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &insertOptions{noColor: true, stdin: strings.NewReader("New")}
+	require.NoError(t, runInsert("12345", opts, client))
+	assert.Equal(t, 2, gets)
+	assert.Equal(t, 2, puts)
+}
+
+func TestRunInsert_EmptyContent(t *testing.T) {
+	err := runInsert("12345", &insertOptions{stdin: strings.NewReader("  \n")}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "content cannot be empty")
+}
+
+// jsonString returns s as a JSON string literal.
+func jsonString(t *testing.T, s string) string {
+	t.Helper()
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	return string(data)
+}
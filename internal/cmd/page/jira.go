@@ -0,0 +1,27 @@
+package page
+
+import (
+	"strings"
+
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// linkJira links the Jira issues in markdown content: as Jira macros in
+// content published to the legacy editor, and as smart links to the Jira
+// site in content published to the cloud editor. The Jira site defaults to
+// the Confluence site at siteURL. Storage format content is returned
+// unchanged.
+func linkJira(content string, isMarkdown, legacy bool, cfg config.JiraConfig, siteURL string) string {
+	if !isMarkdown {
+		return content
+	}
+	opts := md.JiraOptions{Projects: cfg.Projects, Server: cfg.Server, ServerID: cfg.ServerID}
+	if !legacy {
+		opts.URL = cfg.URL
+		if opts.URL == "" {
+			opts.URL = strings.TrimSuffix(strings.TrimSuffix(siteURL, "/"), "/wiki")
+		}
+	}
+	return string(md.LinkJiraIssues([]byte(content), opts))
+}
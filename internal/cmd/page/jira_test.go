@@ -0,0 +1,74 @@
+package page
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+func TestLinkJira(t *testing.T) {
+	cfg := config.JiraConfig{Projects: []string{"PROJ"}, ServerID: "abc"}
+
+	tests := []struct {
+		name       string
+		content    string
+		isMarkdown bool
+		legacy     bool
+		cfg        config.JiraConfig
+		expected   string
+	}{
+		{"legacy macros", "See PROJ-1.", true, true, cfg, "See [JIRA key=PROJ-1 serverId=abc]."},
+		{"cloud links to site", "See PROJ-1.", true, false, cfg, "See <https://example.atlassian.net/browse/PROJ-1>."},
+		{"cloud links to Jira URL", "See [JIRA:PROJ-1].", true, false, config.JiraConfig{URL: "https://jira.example.com"}, "See <https://jira.example.com/browse/PROJ-1>."},
+		{"storage unchanged", "<p>PROJ-1</p>", false, false, cfg, "<p>PROJ-1</p>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := linkJira(tt.content, tt.isMarkdown, tt.legacy, tt.cfg, "https://example.atlassian.net/wiki")
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestRunCreate_LinksJiraIssues(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+		case r.Method == "POST" && strings.Contains(r.URL.Path, "/pages"):
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &receivedBody)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "99999", "title": "Notes", "version": {"number": 1}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &createOptions{
+		space:   "DEV",
+		title:   "Notes",
+		stdin:   strings.NewReader("Fixes PROJ-12 and [JIRA:OPS-3].\n"),
+		legacy:  true,
+		noColor: true,
+		jira:    config.JiraConfig{Projects: []string{"PROJ"}},
+	}
+
+	require.NoError(t, runCreate(opts, client))
+
+	content := receivedBody["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"].(string)
+	assert.Contains(t, content, `<ac:structured-macro ac:name="jira" ac:schema-version="1"><ac:parameter ac:name="key">PROJ-12</ac:parameter></ac:structured-macro>`)
+	assert.Contains(t, content, `<ac:parameter ac:name="key">OPS-3</ac:parameter>`)
+}
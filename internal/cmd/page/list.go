@@ -15,6 +15,7 @@ import (
 type listOptions struct {
 	space   string
 	limit   int
+	all     bool
 	status  string
 	output  string
 	noColor bool
@@ -35,6 +36,9 @@ func NewCmdList() *cobra.Command {
   # List with limit
   cfl page list -s DEV -l 50
 
+  # List every page in the space
+  cfl page list -s DEV --all
+
   # Output as JSON
   cfl page list -s DEV -o json`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -46,11 +50,15 @@ func NewCmdList() *cobra.Command {
 
 	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key or ID (required)")
 	cmd.Flags().IntVarP(&opts.limit, "limit", "l", 25, "Maximum number of pages to return")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Return all pages, following pagination (ignores --limit)")
 	cmd.Flags().StringVar(&opts.status, "status", "current", "Page status: current, archived, trashed")
 
 	return cmd
 }
 
+// listAllBatchSize is the number of pages requested at a time with --all.
+const listAllBatchSize = 250
+
 // validStatuses are the page statuses accepted by the Confluence API.
 var validStatuses = map[string]bool{
 	"current":  true,
@@ -79,8 +87,8 @@ func runList(opts *listOptions, client *api.Client) error {
 	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
 
 	// Handle limit 0 - return empty list
-	if opts.limit == 0 {
-		if opts.output == "json" {
+	if opts.limit == 0 && !opts.all {
+		if view.IsStructured(opts.output) {
 			return renderer.RenderJSON([]interface{}{})
 		}
 		renderer.RenderText("No pages found.")
@@ -106,7 +114,7 @@ func runList(opts *listOptions, client *api.Client) error {
 			spaceKey = cfg.DefaultSpace
 		}
 
-		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken)
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
 	}
 
 	if spaceKey == "" {
@@ -125,12 +133,26 @@ func runList(opts *listOptions, client *api.Client) error {
 		Status: opts.status,
 	}
 
-	result, err := client.ListPages(context.Background(), space.ID, apiOpts)
-	if err != nil {
-		return fmt.Errorf("failed to list pages: %w", err)
+	var pages []api.Page
+	hasMore := false
+	if opts.all {
+		apiOpts.Limit = listAllBatchSize
+		pages, err = api.ListAll(client.PagesIter(context.Background(), space.ID, apiOpts, api.WithPrefetch()))
+		if err != nil {
+			return fmt.Errorf("failed to list pages: %w", err)
+		}
+	} else {
+		result, err := client.ListPages(context.Background(), space.ID, apiOpts)
+		if err != nil {
+			return fmt.Errorf("failed to list pages: %w", err)
+		}
+		pages, hasMore = result.Results, result.HasMore()
 	}
 
-	if len(result.Results) == 0 {
+	if len(pages) == 0 {
+		if view.IsStructured(opts.output) {
+			return renderer.RenderJSON([]interface{}{})
+		}
 		renderer.RenderText(fmt.Sprintf("No pages found in space %s.", spaceKey))
 		return nil
 	}
@@ -138,7 +160,7 @@ func runList(opts *listOptions, client *api.Client) error {
 	headers := []string{"ID", "TITLE", "STATUS", "VERSION"}
 	var rows [][]string
 
-	for _, page := range result.Results {
+	for _, page := range pages {
 		version := ""
 		if page.Version != nil {
 			version = fmt.Sprintf("v%d", page.Version.Number)
@@ -151,10 +173,10 @@ func runList(opts *listOptions, client *api.Client) error {
 		})
 	}
 
-	renderer.RenderList(headers, rows, result.HasMore())
+	renderer.RenderList(headers, rows, hasMore)
 
-	if result.HasMore() && opts.output != "json" {
-		fmt.Fprintf(os.Stderr, "\n(showing first %d results, use --limit to see more)\n", len(result.Results))
+	if hasMore && !view.IsStructured(opts.output) {
+		fmt.Fprintf(os.Stderr, "\n(showing first %d results, use --limit or --all to see more)\n", len(pages))
 	}
 
 	return nil
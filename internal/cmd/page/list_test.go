@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -301,3 +302,42 @@ func TestRunList_PageList_TrashedStatus(t *testing.T) {
 	err := runList(opts, client)
 	require.NoError(t, err)
 }
+
+func TestRunList_PageList_All(t *testing.T) {
+	var mu sync.Mutex
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+		case r.URL.Path == "/api/v2/spaces/123456/pages":
+			assert.Equal(t, "250", r.URL.Query().Get("limit"))
+			mu.Lock()
+			cursors = append(cursors, r.URL.Query().Get("cursor"))
+			mu.Unlock()
+			if r.URL.Query().Get("cursor") == "" {
+				w.Write([]byte(`{"results": [{"id": "1", "title": "One"}],
+					"_links": {"next": "/api/v2/spaces/123456/pages?cursor=next"}}`))
+				return
+			}
+			w.Write([]byte(`{"results": [{"id": "2", "title": "Two"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &listOptions{
+		space:   "DEV",
+		limit:   0,
+		all:     true,
+		status:  "current",
+		noColor: true,
+	}
+
+	err := runList(opts, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "next"}, cursors)
+}
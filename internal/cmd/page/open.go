@@ -0,0 +1,115 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/browser"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type openOptions struct {
+	print   bool
+	output  string
+	noColor bool
+	stdout  io.Writer          // For testing; defaults to os.Stdout
+	open    func(string) error // For testing; defaults to browser.Open
+}
+
+// NewCmdOpen creates the page open command.
+func NewCmdOpen() *cobra.Command {
+	opts := &openOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "open <page>",
+		Short: "Open a page in the browser",
+		Long: `Open a page in the default browser.
+
+The page may be ` + pageref.Usage + `. With --print, the page's URL is
+printed instead of opened, for terminals without a browser.`,
+		Example: `  # Open a page
+  cfl page open 12345
+
+  # Open a page by space and title
+  cfl page open "DEV/Release Runbook"
+
+  # Print the URL instead
+  cfl page open "DEV/Release Runbook" --print`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runOpen(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.print, "print", false, "Print the URL instead of opening it")
+
+	return cmd
+}
+
+func runOpen(ref string, opts *openOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pageID, err := pageref.Resolve(ctx, client, ref)
+	if err != nil {
+		return err
+	}
+	page, err := client.GetPage(ctx, pageID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get page: %w", err)
+	}
+
+	url := client.BaseURL() + page.Links.WebUI
+	if page.Links.WebUI == "" {
+		url = client.BaseURL() + "/pages/viewpage.action?pageId=" + page.ID
+	}
+
+	if view.IsStructured(opts.output) {
+		renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+		return renderer.RenderJSON(map[string]string{"id": page.ID, "title": page.Title, "url": url})
+	}
+
+	if opts.print {
+		stdout := opts.stdout
+		if stdout == nil {
+			stdout = os.Stdout
+		}
+		_, err = fmt.Fprintln(stdout, url)
+		return err
+	}
+
+	open := opts.open
+	if open == nil {
+		open = browser.Open
+	}
+	if err := open(url); err != nil {
+		return fmt.Errorf("failed to open %s: %w", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package page
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "100", "key": "DEV"}]}`))
+		case "/api/v2/spaces/100/pages":
+			w.Write([]byte(`{"results": [{"id": "42", "title": "Runbook"}]}`))
+		case "/api/v2/pages/42":
+			w.Write([]byte(`{"id": "42", "title": "Runbook", "_links": {"webui": "/spaces/DEV/pages/42/Runbook"}}`))
+		case "/api/v2/pages/7":
+			w.Write([]byte(`{"id": "7", "title": "No link"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	t.Run("opens", func(t *testing.T) {
+		var opened []string
+		opts := &openOptions{open: func(url string) error {
+			opened = append(opened, url)
+			return nil
+		}}
+		require.NoError(t, runOpen("DEV/Runbook", opts, client))
+		assert.Equal(t, []string{server.URL + "/spaces/DEV/pages/42/Runbook"}, opened)
+	})
+
+	t.Run("print", func(t *testing.T) {
+		var out bytes.Buffer
+		opts := &openOptions{print: true, stdout: &out, open: func(string) error {
+			t.Error("browser opened with --print")
+			return nil
+		}}
+		require.NoError(t, runOpen("7", opts, client))
+		assert.Equal(t, server.URL+"/pages/viewpage.action?pageId=7\n", out.String())
+	})
+
+	t.Run("opener fails", func(t *testing.T) {
+		opts := &openOptions{open: func(string) error { return errors.New("no browser") }}
+		err := runOpen("42", opts, client)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no browser")
+	})
+}
@@ -0,0 +1,307 @@
+package page
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+// Ordering modes.
+const (
+	orderByTitle  = "title"
+	orderByManual = "manual"
+)
+
+type orderOptions struct {
+	by      string
+	file    string
+	dryRun  bool
+	output  string
+	noColor bool
+}
+
+// pageMove is a single move made to reorder children.
+type pageMove struct {
+	ID       string `json:"id"`
+	Position string `json:"position"`
+	TargetID string `json:"targetId"`
+}
+
+// orderResult describes a reordering for JSON output.
+type orderResult struct {
+	ParentID string     `json:"parentId"`
+	Order    []api.Page `json:"order"`
+	Moves    []pageMove `json:"moves"`
+	DryRun   bool       `json:"dryRun,omitempty"`
+}
+
+// NewCmdOrder creates the page order command.
+func NewCmdOrder() *cobra.Command {
+	opts := &orderOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "order <parent-id>",
+		Short: "Reorder the children of a page",
+		Long: `Reorder the child pages of a parent, as shown in the page tree.
+
+With --by title, children are sorted alphabetically by title.
+
+With --by manual, children follow the order in --file: one page per line,
+given by ID or title. Blank lines and lines starting with # are ignored.
+Children not listed keep their relative order after the listed ones.
+
+Only the moves needed to reach the new order are made, so running the
+command again is a no-op.`,
+		Example: `  # Sort children alphabetically
+  cfl page order 12345 --by title
+
+  # Match a declared order
+  cfl page order 12345 --by manual --file order.txt
+
+  # Show the moves without making them
+  cfl page order 12345 --by title --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runOrder(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.by, "by", orderByTitle, "Order by: title or manual")
+	cmd.Flags().StringVar(&opts.file, "file", "", "File listing the child order (with --by manual)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show the moves without making them")
+
+	return cmd
+}
+
+func runOrder(parentID string, opts *orderOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	var entries []string
+	switch opts.by {
+	case orderByTitle:
+		if opts.file != "" {
+			return fmt.Errorf("--file can only be used with --by %s", orderByManual)
+		}
+	case orderByManual:
+		if opts.file == "" {
+			return fmt.Errorf("--by %s requires --file", orderByManual)
+		}
+		var err error
+		if entries, err = readOrderFile(opts.file); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid --by %q: must be %s or %s", opts.by, orderByTitle, orderByManual)
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	parentID, err := pageref.Resolve(context.Background(), client, parentID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	children, err := listAllChildPages(ctx, client, parentID)
+	if err != nil {
+		return err
+	}
+
+	var desired []api.Page
+	if opts.by == orderByManual {
+		desired, err = manualOrder(children, entries)
+		if err != nil {
+			return err
+		}
+	} else {
+		desired = titleOrder(children)
+	}
+
+	moves := planMoves(children, desired)
+	if !opts.dryRun {
+		for _, m := range moves {
+			if err := client.MovePageTo(ctx, m.ID, m.Position, m.TargetID); err != nil {
+				return fmt.Errorf("failed to move page %s: %w", m.ID, err)
+			}
+		}
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(orderResult{ParentID: parentID, Order: desired, Moves: moves, DryRun: opts.dryRun})
+	}
+
+	headers := []string{"POSITION", "ID", "TITLE"}
+	var rows [][]string
+	for i, p := range desired {
+		rows = append(rows, []string{strconv.Itoa(i + 1), p.ID, p.Title})
+	}
+	renderer.RenderTable(headers, rows)
+
+	switch {
+	case len(moves) == 0:
+		renderer.Success("Children are already in order")
+	case opts.dryRun:
+		renderer.Warning(fmt.Sprintf("Dry run: %d moves needed", len(moves)))
+	default:
+		renderer.Success(fmt.Sprintf("Reordered %d children (%d moves)", len(desired), len(moves)))
+	}
+	return nil
+}
+
+// readOrderFile reads the entries of an order file.
+func readOrderFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read order file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read order file: %w", err)
+	}
+	return entries, nil
+}
+
+// titleOrder sorts children by title, ignoring case.
+func titleOrder(children []api.Page) []api.Page {
+	desired := append([]api.Page{}, children...)
+	sort.SliceStable(desired, func(i, j int) bool {
+		return strings.ToLower(desired[i].Title) < strings.ToLower(desired[j].Title)
+	})
+	return desired
+}
+
+// manualOrder orders children by entries, each a page ID or title.
+// Children not listed follow in their current order.
+func manualOrder(children []api.Page, entries []string) ([]api.Page, error) {
+	listed := make(map[string]bool)
+	var desired []api.Page
+	for _, entry := range entries {
+		p, ok := matchChild(children, entry)
+		if !ok {
+			return nil, fmt.Errorf("order file: no child page matches %q", entry)
+		}
+		if listed[p.ID] {
+			return nil, fmt.Errorf("order file: %q is listed more than once", p.Title)
+		}
+		listed[p.ID] = true
+		desired = append(desired, p)
+	}
+	for _, p := range children {
+		if !listed[p.ID] {
+			desired = append(desired, p)
+		}
+	}
+	return desired, nil
+}
+
+// matchChild finds the child with an ID or title matching entry, preferring
+// exact title matches over case-insensitive ones.
+func matchChild(children []api.Page, entry string) (api.Page, bool) {
+	for _, p := range children {
+		if p.ID == entry || p.Title == entry {
+			return p, true
+		}
+	}
+	for _, p := range children {
+		if strings.EqualFold(p.Title, entry) {
+			return p, true
+		}
+	}
+	return api.Page{}, false
+}
+
+// planMoves returns the moves that turn the current order into the desired
+// one: the first page is moved before the current first page if needed,
+// then each page is moved after its predecessor unless it already follows it.
+func planMoves(current, desired []api.Page) []pageMove {
+	order := make([]string, len(current))
+	for i, p := range current {
+		order[i] = p.ID
+	}
+
+	var moves []pageMove
+	move := func(id, position, target string) {
+		moves = append(moves, pageMove{ID: id, Position: position, TargetID: target})
+		order = movedOrder(order, id, position, target)
+	}
+
+	for i, p := range desired {
+		if i == 0 {
+			if len(order) > 0 && order[0] != p.ID {
+				move(p.ID, api.MoveBefore, order[0])
+			}
+			continue
+		}
+		prev := desired[i-1].ID
+		if indexOf(order, p.ID) != indexOf(order, prev)+1 {
+			move(p.ID, api.MoveAfter, prev)
+		}
+	}
+	return moves
+}
+
+// movedOrder returns order with id moved before or after target.
+func movedOrder(order []string, id, position, target string) []string {
+	var out []string
+	for _, o := range order {
+		if o != id {
+			out = append(out, o)
+		}
+	}
+	at := indexOf(out, target)
+	if position == api.MoveAfter {
+		at++
+	}
+	out = append(out[:at], append([]string{id}, out[at:]...)...)
+	return out
+}
+
+// indexOf returns the index of s in list, or -1.
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,168 @@
+package page
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func pagesWithIDs(ids ...string) []api.Page {
+	var pages []api.Page
+	for _, id := range ids {
+		pages = append(pages, api.Page{ID: id, Title: "Page " + id})
+	}
+	return pages
+}
+
+func TestPlanMoves(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  []string
+		desired  []string
+		expected []pageMove
+	}{
+		{
+			name:    "already ordered",
+			current: []string{"a", "b", "c"},
+			desired: []string{"a", "b", "c"},
+		},
+		{
+			name:     "new first page",
+			current:  []string{"b", "c", "a"},
+			desired:  []string{"a", "b", "c"},
+			expected: []pageMove{{ID: "a", Position: api.MoveBefore, TargetID: "b"}},
+		},
+		{
+			name:    "reversed",
+			current: []string{"c", "b", "a"},
+			desired: []string{"a", "b", "c"},
+			expected: []pageMove{
+				{ID: "a", Position: api.MoveBefore, TargetID: "c"},
+				{ID: "b", Position: api.MoveAfter, TargetID: "a"},
+			},
+		},
+		{
+			name:     "one out of place",
+			current:  []string{"a", "c", "b", "d"},
+			desired:  []string{"a", "b", "c", "d"},
+			expected: []pageMove{{ID: "b", Position: api.MoveAfter, TargetID: "a"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planMoves(pagesWithIDs(tt.current...), pagesWithIDs(tt.desired...))
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestManualOrder(t *testing.T) {
+	children := []api.Page{
+		{ID: "1", Title: "Intro"},
+		{ID: "2", Title: "Setup"},
+		{ID: "3", Title: "FAQ"},
+	}
+
+	got, err := manualOrder(children, []string{"faq", "2"})
+	require.NoError(t, err)
+	assert.Equal(t, []api.Page{children[2], children[1], children[0]}, got)
+
+	_, err = manualOrder(children, []string{"Missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no child page matches "Missing"`)
+
+	_, err = manualOrder(children, []string{"FAQ", "3"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "listed more than once")
+}
+
+// newOrderServer serves the children of page 100 and applies moves to them.
+func newOrderServer(t *testing.T, titles ...string) (*httptest.Server, *[]string) {
+	var order []string
+	byID := make(map[string]string)
+	for i, title := range titles {
+		id := string(rune('1' + i))
+		order = append(order, id)
+		byID[id] = title
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/pages/100/children":
+			assert.Equal(t, "child-position", r.URL.Query().Get("sort"))
+			var results []map[string]string
+			for _, id := range order {
+				results = append(results, map[string]string{"id": id, "title": byID[id]})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/rest/api/content/"):
+			parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/rest/api/content/"), "/")
+			order = movedOrder(order, parts[0], parts[2], parts[3])
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &order
+}
+
+func TestRunOrder_ByTitle(t *testing.T) {
+	server, order := newOrderServer(t, "Zeta", "alpha", "Mid")
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	require.NoError(t, runOrder("100", &orderOptions{by: orderByTitle, noColor: true}, client))
+	assert.Equal(t, []string{"2", "3", "1"}, *order)
+
+	// A second run makes no moves
+	require.NoError(t, runOrder("100", &orderOptions{by: orderByTitle, noColor: true}, client))
+	assert.Equal(t, []string{"2", "3", "1"}, *order)
+}
+
+func TestRunOrder_Manual(t *testing.T) {
+	server, order := newOrderServer(t, "Intro", "Setup", "FAQ")
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	file := filepath.Join(t.TempDir(), "order.txt")
+	require.NoError(t, os.WriteFile(file, []byte("# nav order\nFAQ\n\nIntro\n"), 0644))
+
+	require.NoError(t, runOrder("100", &orderOptions{by: orderByManual, file: file, noColor: true}, client))
+	assert.Equal(t, []string{"3", "1", "2"}, *order)
+}
+
+func TestRunOrder_DryRun(t *testing.T) {
+	server, order := newOrderServer(t, "B", "A")
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	require.NoError(t, runOrder("100", &orderOptions{by: orderByTitle, dryRun: true, noColor: true}, client))
+	assert.Equal(t, []string{"1", "2"}, *order)
+}
+
+func TestRunOrder_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *orderOptions
+		wantErr string
+	}{
+		{"bad mode", &orderOptions{by: "date"}, "invalid --by"},
+		{"manual without file", &orderOptions{by: orderByManual}, "requires --file"},
+		{"file with title", &orderOptions{by: orderByTitle, file: "order.txt"}, "--file can only be used"},
+		{"missing file", &orderOptions{by: orderByManual, file: "/nonexistent/order.txt"}, "failed to read order file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runOrder("100", tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -11,15 +11,42 @@ func NewCmdPage() *cobra.Command {
 		Use:     "page",
 		Aliases: []string{"pages"},
 		Short:   "Manage Confluence pages",
-		Long:    `Commands for creating, viewing, editing, and listing Confluence pages.`,
+		Long: `Commands for creating, viewing, editing, and listing Confluence pages.
+
+Wherever a command takes a page ID it also accepts a page URL (including
+short links) or SPACE/Title, for example "DEV/Release Notes".`,
 	}
 
 	cmd.AddCommand(NewCmdList())
 	cmd.AddCommand(NewCmdView())
+	cmd.AddCommand(NewCmdOpen())
 	cmd.AddCommand(NewCmdCreate())
 	cmd.AddCommand(NewCmdEdit())
+	cmd.AddCommand(NewCmdPublish())
 	cmd.AddCommand(NewCmdDelete())
 	cmd.AddCommand(NewCmdCopy())
+	cmd.AddCommand(NewCmdSize())
+	cmd.AddCommand(NewCmdTOC())
+	cmd.AddCommand(NewCmdSync())
+	cmd.AddCommand(NewCmdExport())
+	cmd.AddCommand(NewCmdDownload())
+	cmd.AddCommand(NewCmdOrder())
+	cmd.AddCommand(NewCmdTree())
+	cmd.AddCommand(NewCmdHistory())
+	cmd.AddCommand(NewCmdDiff())
+	cmd.AddCommand(NewCmdRestore())
+	cmd.AddCommand(NewCmdAnnotate())
+	cmd.AddCommand(NewCmdGetID())
+	cmd.AddCommand(NewCmdProps())
+	cmd.AddCommand(NewCmdAssemble())
+	cmd.AddCommand(NewCmdSplit())
+	cmd.AddCommand(NewCmdWatch())
+	cmd.AddCommand(NewCmdUnwatch())
+	cmd.AddCommand(NewCmdWatchers())
+	cmd.AddCommand(NewCmdRestrictions())
+	cmd.AddCommand(NewCmdConvert())
+	cmd.AddCommand(NewCmdAppend())
+	cmd.AddCommand(NewCmdPrepend())
 
 	return cmd
 }
@@ -0,0 +1,34 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// qualifyPageLinks names spaceKey in [[Title]] links in markdown content
+// published to the cloud editor, which links to pages by URL and so needs
+// the space. Storage format links to pages in the same space by title
+// alone, so legacy content is returned unchanged.
+func qualifyPageLinks(content string, isMarkdown, legacy bool, spaceKey string) string {
+	if !isMarkdown || legacy {
+		return content
+	}
+	return string(md.QualifyPageLinks([]byte(content), spaceKey))
+}
+
+// qualifyPageLinksInSpace is qualifyPageLinks for a page known by space
+// ID. The space is only looked up when the content has [[...]] links.
+func qualifyPageLinksInSpace(ctx context.Context, client *api.Client, content string, isMarkdown, legacy bool, spaceID string) (string, error) {
+	if !isMarkdown || legacy || spaceID == "" || !strings.Contains(content, "[[") {
+		return content, nil
+	}
+	space, err := client.GetSpace(ctx, spaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get space: %w", err)
+	}
+	return qualifyPageLinks(content, isMarkdown, legacy, space.Key), nil
+}
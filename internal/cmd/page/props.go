@@ -0,0 +1,306 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+type propsOptions struct {
+	table   string
+	output  string
+	noColor bool
+	stdout  io.Writer // For testing; defaults to os.Stdout
+}
+
+// propsSetResult is the JSON output of props set.
+type propsSetResult struct {
+	PageID  string `json:"pageId"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Updated bool   `json:"updated"`
+}
+
+// NewCmdProps creates the page props command.
+func NewCmdProps() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "props",
+		Aliases: []string{"properties"},
+		Short:   "Read and edit page properties tables",
+		Long: `Read and edit the key/value rows of a page's properties table (the Page
+Properties, or details, macro).
+
+Only the table is rewritten; the rest of the page is left as it is, in the
+page's own editor format. Keys are matched case-insensitively. When a page
+has several properties tables, --table selects one by its id.`,
+	}
+
+	cmd.AddCommand(NewCmdPropsGet())
+	cmd.AddCommand(NewCmdPropsSet())
+
+	return cmd
+}
+
+// NewCmdPropsGet creates the page props get command.
+func NewCmdPropsGet() *cobra.Command {
+	opts := &propsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "get <page> [key]",
+		Short: "Show the rows of a page properties table",
+		Long: `Show the rows of a page properties table, or the value of one row.
+
+The page may be ` + pageref.Usage + `.`,
+		Example: `  # Show all properties
+  cfl page props get 12345
+
+  # Print one value, for scripts
+  cfl page props get 12345 Status
+
+  # Properties of the table with id "release"
+  cfl page props get 12345 --table release -o json`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			key := ""
+			if len(args) > 1 {
+				key = args[1]
+			}
+			return runPropsGet(args[0], key, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.table, "table", "", "Id of the properties table (default: the first)")
+
+	return cmd
+}
+
+// NewCmdPropsSet creates the page props set command.
+func NewCmdPropsSet() *cobra.Command {
+	opts := &propsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "set <page> <key> <value>",
+		Short: "Set a row of a page properties table",
+		Long: `Set the value of a row in a page properties table, adding the row when the
+table has none with that key. The page isn't updated when the row already
+has the value.
+
+The page may be ` + pageref.Usage + `.`,
+		Example: `  # Update the status of a page
+  cfl page props set 12345 Status "In Progress"
+
+  # Set a row in the table with id "release"
+  cfl page props set "DEV/Release 2.0" Owner "Ann Lee" --table release`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runPropsSet(args[0], args[1], args[2], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.table, "table", "", "Id of the properties table (default: the first)")
+
+	return cmd
+}
+
+func runPropsGet(ref, key string, opts *propsOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, ref)
+	if err != nil {
+		return err
+	}
+
+	// Storage format is available for pages of either editor
+	page, err := client.GetPage(context.Background(), pageID, &api.GetPageOptions{
+		BodyFormat: "storage",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get page: %w", err)
+	}
+	storage := ""
+	if page.Body != nil && page.Body.Storage != nil {
+		storage = page.Body.Storage.Value
+	}
+	props, err := md.StorageProperties(storage, opts.table)
+	if err != nil {
+		return fmt.Errorf("page %s: %w", pageID, err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	stdout := opts.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	renderer.SetWriter(stdout)
+
+	if key != "" {
+		prop, ok := findProperty(props, key)
+		if !ok {
+			return fmt.Errorf("page %s has no property %q", pageID, key)
+		}
+		if view.IsStructured(opts.output) {
+			return renderer.RenderJSON(prop)
+		}
+		_, err = fmt.Fprintln(stdout, prop.Value)
+		return err
+	}
+
+	if view.IsStructured(opts.output) {
+		if props == nil {
+			props = []md.Property{}
+		}
+		return renderer.RenderJSON(props)
+	}
+	rows := make([][]string, 0, len(props))
+	for _, prop := range props {
+		rows = append(rows, []string{prop.Key, prop.Value})
+	}
+	renderer.RenderTable([]string{"KEY", "VALUE"}, rows)
+	return nil
+}
+
+func runPropsSet(ref, key, value string, opts *propsOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("property key cannot be empty")
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pageID, err := pageref.Resolve(ctx, client, ref)
+	if err != nil {
+		return err
+	}
+
+	// Rewrite the table in the page's own format so the editor doesn't change
+	editor, err := pageEditor(ctx, client, pageID)
+	if err != nil {
+		return err
+	}
+	legacy := editor == api.EditorLegacy
+	format := "atlas_doc_format"
+	if legacy {
+		format = "storage"
+	}
+	page, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: format})
+	if err != nil {
+		return fmt.Errorf("failed to get page: %w", err)
+	}
+
+	var content string
+	if body := page.Body; body != nil {
+		rep := body.AtlasDocFormat
+		if legacy {
+			rep = body.Storage
+		}
+		if rep != nil {
+			content = rep.Value
+		}
+	}
+
+	properties, setProperty := md.ADFProperties, md.SetADFProperty
+	if legacy {
+		properties, setProperty = md.StorageProperties, md.SetStorageProperty
+	}
+	props, err := properties(content, opts.table)
+	if err != nil {
+		return fmt.Errorf("page %s: %w", pageID, err)
+	}
+
+	result := propsSetResult{PageID: pageID, Key: key, Value: value}
+	if prop, ok := findProperty(props, key); !ok || prop.Value != value {
+		updated, err := setProperty(content, opts.table, key, value)
+		if err != nil {
+			return fmt.Errorf("page %s: %w", pageID, err)
+		}
+		version := 1
+		if page.Version != nil {
+			version = page.Version.Number
+		}
+		_, err = client.UpdatePage(ctx, pageID, &api.UpdatePageRequest{
+			ID:     pageID,
+			Status: "current",
+			Title:  page.Title,
+			Body:   newEditBody(updated, legacy),
+			Version: &api.Version{
+				Number:  version + 1,
+				Message: "Updated via cfl",
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update page: %w", err)
+		}
+		result.Updated = true
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(result)
+	}
+	if !result.Updated {
+		renderer.Success(fmt.Sprintf("%s is already %q on page %s", key, value, page.Title))
+		return nil
+	}
+	renderer.Success(fmt.Sprintf("Set %s to %q on page %s", key, value, page.Title))
+	return nil
+}
+
+// findProperty returns the row with the given key, matched case-insensitively.
+func findProperty(props []md.Property, key string) (md.Property, bool) {
+	for _, prop := range props {
+		if strings.EqualFold(prop.Key, key) {
+			return prop, true
+		}
+	}
+	return md.Property{}, false
+}
@@ -0,0 +1,143 @@
+package page
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+const testPropsStorage = `<p>Intro</p><ac:structured-macro ac:name="details"><ac:rich-text-body>` +
+	`<table><tbody><tr><th><p>Owner</p></th><td><p>Ann</p></td></tr><tr><th><p>Status</p></th><td><p>Draft</p></td></tr></tbody></table>` +
+	`</ac:rich-text-body></ac:structured-macro>`
+
+const testPropsADF = `{"type":"doc","version":1,"content":[{"type":"bodiedExtension",` +
+	`"attrs":{"extensionType":"com.atlassian.confluence.macro.core","extensionKey":"details"},` +
+	`"content":[{"type":"table","content":[{"type":"tableRow","content":[` +
+	`{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"Status"}]}]},` +
+	`{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"Draft"}]}]}]}]}]}]}`
+
+// mockPropsServer serves page 12345 recorded as edited with editor, and
+// records the body of an update.
+func mockPropsServer(t *testing.T, editor string, received *map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345/properties":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"results": []map[string]interface{}{{"id": "1", "key": "editor", "value": editor}},
+			})
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+			body := map[string]interface{}{"storage": map[string]string{"value": testPropsStorage}}
+			if r.URL.Query().Get("body-format") == "atlas_doc_format" {
+				body = map[string]interface{}{"atlas_doc_format": map[string]string{"value": testPropsADF}}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":      "12345",
+				"title":   "Release",
+				"version": map[string]int{"number": 4},
+				"body":    body,
+			})
+		case r.Method == "PUT" && r.URL.Path == "/api/v2/pages/12345":
+			data, _ := io.ReadAll(r.Body)
+			json.Unmarshal(data, received)
+			w.Write([]byte(`{"id": "12345", "title": "Release", "version": {"number": 5}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunPropsGet(t *testing.T) {
+	server := mockPropsServer(t, api.EditorLegacy, nil)
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	tests := []struct {
+		name   string
+		key    string
+		output string
+		want   string
+	}{
+		{"all rows", "", "", "Owner"},
+		{"one value", "status", "", "Draft\n"},
+		{"json", "", "json", `"key": "Status"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := runPropsGet("12345", tt.key, &propsOptions{output: tt.output, noColor: true, stdout: &stdout}, client)
+			require.NoError(t, err)
+			if tt.key != "" {
+				assert.Equal(t, tt.want, stdout.String())
+			} else {
+				assert.Contains(t, stdout.String(), tt.want)
+			}
+		})
+	}
+
+	err := runPropsGet("12345", "Reviewer", &propsOptions{noColor: true, stdout: &bytes.Buffer{}}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `page 12345 has no property "Reviewer"`)
+}
+
+func TestRunPropsSet_Legacy(t *testing.T) {
+	var received map[string]interface{}
+	server := mockPropsServer(t, api.EditorLegacy, &received)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runPropsSet("12345", "Status", "In Progress", &propsOptions{noColor: true, stdout: &bytes.Buffer{}}, client)
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(5), received["version"].(map[string]interface{})["number"])
+	storage := received["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"].(string)
+	assert.Contains(t, storage, `<p>Intro</p>`)
+	assert.Contains(t, storage, `<th><p>Owner</p></th><td><p>Ann</p></td>`)
+	assert.Contains(t, storage, `<th><p>Status</p></th><td><p>In Progress</p></td>`)
+}
+
+func TestRunPropsSet_Cloud(t *testing.T) {
+	var received map[string]interface{}
+	server := mockPropsServer(t, api.EditorCloud, &received)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runPropsSet("12345", "Owner", "Bo", &propsOptions{noColor: true, stdout: &bytes.Buffer{}}, client)
+	require.NoError(t, err)
+
+	body := received["body"].(map[string]interface{})
+	assert.NotContains(t, body, "storage")
+	adf := body["atlas_doc_format"].(map[string]interface{})["value"].(string)
+	assert.Contains(t, adf, `"text":"Draft"`)
+	assert.Contains(t, adf, `"text":"Owner"`)
+	assert.Contains(t, adf, `"text":"Bo"`)
+}
+
+func TestRunPropsSet_Unchanged(t *testing.T) {
+	var received map[string]interface{}
+	server := mockPropsServer(t, api.EditorLegacy, &received)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	var stdout bytes.Buffer
+	err := runPropsSet("12345", "status", "Draft", &propsOptions{output: "json", stdout: &stdout}, client)
+	require.NoError(t, err)
+
+	assert.Nil(t, received, "the page isn't updated")
+	assert.Contains(t, stdout.String(), `"updated": false`)
+}
+
+func TestRunPropsSet_EmptyKey(t *testing.T) {
+	err := runPropsSet("12345", " ", "x", &propsOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "property key cannot be empty")
+}
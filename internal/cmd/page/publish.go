@@ -0,0 +1,207 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type publishOptions struct {
+	message string
+	output  string
+	noColor bool
+	stdout  io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdPublish creates the page publish command.
+func NewCmdPublish() *cobra.Command {
+	opts := &publishOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "publish <page-id>",
+		Short: "Publish a page's draft",
+		Long: `Publish the draft of a page, such as one saved with 'cfl page create --draft'
+or 'cfl page edit --draft', making its title and content the current version.
+
+A draft of a published page becomes a new version of it; a page that was
+never published becomes its first version.`,
+		Example: `  # Publish a reviewed draft
+  cfl page publish 12345
+
+  # Publish with a version message
+  cfl page publish 12345 -m "Reviewed by docs team"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runPublish(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.message, "message", "m", "", "Version message (default: \"Published via cfl\")")
+
+	return cmd
+}
+
+func runPublish(pageID string, opts *publishOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Track base URL for output (only available when loading config)
+	var baseURL string
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		baseURL = cfg.URL
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pageID, err := pageref.Resolve(ctx, client, pageID)
+	if err != nil {
+		return err
+	}
+
+	// The draft is published in its page's editor format, so publishing
+	// doesn't convert the page
+	editor, err := pageEditor(ctx, client, pageID)
+	if err != nil {
+		return err
+	}
+	legacy := editor == api.EditorLegacy
+	bodyFormat := "atlas_doc_format"
+	if legacy {
+		bodyFormat = "storage"
+	}
+
+	draft, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: bodyFormat, Draft: true})
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("page %s has no draft to publish", pageID)
+		}
+		return fmt.Errorf("failed to get draft: %w", err)
+	}
+	if draft.Status != "draft" {
+		return fmt.Errorf("page %s has no draft to publish", pageID)
+	}
+	content, ok := draftContent(draft, legacy)
+	if !ok {
+		return fmt.Errorf("draft of page %s has no content", pageID)
+	}
+
+	version, err := publishVersion(ctx, client, pageID)
+	if err != nil {
+		return err
+	}
+
+	message := opts.message
+	if message == "" {
+		message = "Published via cfl"
+	}
+
+	page, err := client.UpdatePage(ctx, pageID, &api.UpdatePageRequest{
+		ID:     pageID,
+		Status: "current",
+		Title:  draft.Title,
+		Body:   newEditBody(content, legacy),
+		Version: &api.Version{
+			Number:  version,
+			Message: message,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish page: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(page)
+	}
+
+	renderer.Success(fmt.Sprintf("Published page: %s", page.Title))
+	renderer.RenderKeyValue("ID", page.ID)
+	if page.Version != nil {
+		renderer.RenderKeyValue("Version", strconv.Itoa(page.Version.Number))
+	}
+	renderer.RenderKeyValue("URL", baseURL+page.Links.WebUI)
+
+	return nil
+}
+
+// draftContent returns the body of a draft in the format for the editor.
+func draftContent(draft *api.Page, legacy bool) (string, bool) {
+	if draft.Body == nil {
+		return "", false
+	}
+	body := draft.Body.AtlasDocFormat
+	if legacy {
+		body = draft.Body.Storage
+	}
+	if body == nil || body.Value == "" {
+		return "", false
+	}
+	return body.Value, true
+}
+
+// publishVersion returns the version number publishing a page's draft
+// creates: the one after its published version, or 1 for a page that was
+// never published.
+func publishVersion(ctx context.Context, client *api.Client, pageID string) (int, error) {
+	published, err := client.GetPage(ctx, pageID, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return 1, nil
+		}
+		return 0, fmt.Errorf("failed to get page: %w", err)
+	}
+	if published.Status == "draft" || published.Version == nil {
+		return 1, nil
+	}
+	return published.Version.Number + 1, nil
+}
+
+// pageStatus returns the status to save a page with: draft when it is to
+// be published later, or else current.
+func pageStatus(draft bool) string {
+	if draft {
+		return "draft"
+	}
+	return "current"
+}
+
+// nextVersion returns the version number to update a page with. Drafts
+// aren't versioned until they're published, so saving a draft again keeps
+// its number.
+func nextVersion(page *api.Page) int {
+	version := 1
+	if page.Version != nil {
+		version = page.Version.Number
+	}
+	if page.Status == "draft" {
+		return version
+	}
+	return version + 1
+}
@@ -0,0 +1,110 @@
+package page
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// newPublishServer serves page 12345 with the given draft and published
+// page JSON, either of which may be empty for none, and records the update.
+func newPublishServer(t *testing.T, draft, published string, updated *api.UpdatePageRequest) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/pages/12345":
+			page := published
+			if r.URL.Query().Get("get-draft") == "true" {
+				page = draft
+			}
+			if page == "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(page))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v2/pages/12345":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(updated))
+			_, _ = w.Write([]byte(`{"id": "12345", "status": "current", "title": "Report", "version": {"number": ` +
+				strconv.Itoa(updated.Version.Number) + `}, "_links": {"webui": "/pages/12345"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunPublish(t *testing.T) {
+	const draft = `{"id": "12345", "status": "draft", "title": "Report", "version": {"number": 1},
+		"body": {"atlas_doc_format": {"value": "{\"type\":\"doc\"}"}}}`
+
+	tests := []struct {
+		name        string
+		published   string
+		wantVersion int
+	}{
+		{"draft of published page", `{"id": "12345", "status": "current", "version": {"number": 4}}`, 5},
+		{"never published", `{"id": "12345", "status": "draft", "version": {"number": 1}}`, 1},
+		{"published page not found", "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated api.UpdatePageRequest
+			server := newPublishServer(t, draft, tt.published, &updated)
+			client := api.NewClient(server.URL, "test@example.com", "token")
+
+			var out bytes.Buffer
+			err := runPublish("12345", &publishOptions{noColor: true, stdout: &out}, client)
+			require.NoError(t, err)
+
+			assert.Equal(t, "current", updated.Status)
+			assert.Equal(t, "Report", updated.Title)
+			assert.Equal(t, tt.wantVersion, updated.Version.Number)
+			assert.Equal(t, "Published via cfl", updated.Version.Message)
+			require.NotNil(t, updated.Body.AtlasDocFormat)
+			assert.Equal(t, `{"type":"doc"}`, updated.Body.AtlasDocFormat.Value)
+			assert.Contains(t, out.String(), "Published page: Report")
+		})
+	}
+}
+
+func TestRunPublish_NoDraft(t *testing.T) {
+	const current = `{"id": "12345", "status": "current", "version": {"number": 4},
+		"body": {"atlas_doc_format": {"value": "{}"}}}`
+
+	tests := []struct {
+		name  string
+		draft string
+	}{
+		{"draft not found", ""},
+		{"published page returned", current},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated api.UpdatePageRequest
+			server := newPublishServer(t, tt.draft, current, &updated)
+			client := api.NewClient(server.URL, "test@example.com", "token")
+
+			err := runPublish("12345", &publishOptions{noColor: true}, client)
+			require.Error(t, err)
+			assert.Equal(t, "page 12345 has no draft to publish", err.Error())
+			assert.Empty(t, updated.Status)
+		})
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	assert.Equal(t, 5, nextVersion(&api.Page{Status: "current", Version: &api.Version{Number: 4}}))
+	assert.Equal(t, 5, nextVersion(&api.Page{Status: "draft", Version: &api.Version{Number: 5}}))
+	assert.Equal(t, 2, nextVersion(&api.Page{}))
+}
@@ -0,0 +1,398 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// Statuses of pages in a find-and-replace.
+const (
+	replaceReplaced     = "replaced"
+	replaceWouldReplace = "would-replace"
+	replaceFailed       = "failed"
+)
+
+type replaceOptions struct {
+	cql        string
+	space      string
+	regex      bool
+	ignoreCase bool
+	dryRun     bool
+	force      bool
+	output     string
+	noColor    bool
+	stdin      io.Reader // injectable for testing
+}
+
+// replaceResult is the outcome of replacing text in one page.
+type replaceResult struct {
+	ID      string `json:"page_id"`
+	Title   string `json:"title"`
+	Matches int    `json:"matches"`
+	Status  string `json:"status"`
+	Version int    `json:"version,omitempty"`
+	Diff    string `json:"diff,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// replaceReport is the JSON output of replace.
+type replaceReport struct {
+	Scanned int             `json:"scanned"`
+	Matches int             `json:"matches"`
+	Pages   []replaceResult `json:"pages"`
+}
+
+// pageReplacement is a page body with its matches replaced, ready to
+// publish.
+type pageReplacement struct {
+	page    *api.Page
+	legacy  bool
+	before  string
+	after   string
+	matches int
+}
+
+// NewCmdReplace creates the replace command.
+func NewCmdReplace() *cobra.Command {
+	opts := &replaceOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "replace <find> <replacement>",
+		Short: "Find and replace text across pages",
+		Long: `Replace text in every page matching a CQL query, or in a whole space.
+
+Only the text of pages is changed: tags, link targets, macro parameters and
+code blocks are left as they are, so renaming a product doesn't break the
+links and macros that mention it. Each page is changed in its own editor's
+format and published as a new version. Text is matched within one run of
+formatting, so a match can't start in bold text and end outside it.
+
+The text to find is literal unless --regex is given, in which case the
+replacement can refer to submatches as $1 or ${name}.
+
+Matching pages are listed and a single confirmation is asked for; --dry-run
+shows the changes to each page as a diff without publishing anything. A
+failure does not stop the rest: a summary is printed at the end.`,
+		Example: `  # Preview renaming a product across a space
+  cfl replace "Acme Widget" "Acme Gizmo" --space DOCS --dry-run
+
+  # Rename it without confirmation
+  cfl replace "Acme Widget" "Acme Gizmo" --space DOCS --yes
+
+  # Fix links to an old host in runbooks, using a regex
+  cfl replace 'old\.example\.com/(\w+)' 'new.example.com/$1' --regex --cql 'space=OPS and label=runbook'`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.stdin = os.Stdin // default to os.Stdin, can be overridden in tests
+			return runReplace(args[0], args[1], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.cql, "cql", "", "CQL query selecting the pages to change")
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Change every page in this space")
+	cmd.Flags().BoolVar(&opts.regex, "regex", false, "Treat find as a regular expression")
+	cmd.Flags().BoolVarP(&opts.ignoreCase, "ignore-case", "i", false, "Match without regard to case")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show the changes as diffs without publishing")
+	cmd.Flags().BoolVarP(&opts.force, "yes", "y", false, "Skip the confirmation prompt")
+
+	cmd.MarkFlagsMutuallyExclusive("cql", "space")
+
+	return cmd
+}
+
+func runReplace(find, replacement string, opts *replaceOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	if opts.cql == "" && opts.space == "" {
+		return fmt.Errorf("--cql or --space is required")
+	}
+	re, repl, err := replacePattern(find, replacement, opts)
+	if err != nil {
+		return err
+	}
+
+	cql := opts.cql
+	if cql == "" {
+		cql = fmt.Sprintf("space = %q and type = page", opts.space)
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pages, err := searchPages(ctx, client, cql)
+	if err != nil {
+		return err
+	}
+
+	// Find the matches in every page before changing any of them
+	report := &replaceReport{Scanned: len(pages), Pages: []replaceResult{}}
+	var pending []*pageReplacement
+	var pendingResults []int
+	progress := view.NewProgress(len(pages))
+	for _, p := range pages {
+		progress.Increment(p.Title)
+		r, err := findReplacement(ctx, client, p.ID, re, repl)
+		if err != nil {
+			report.Pages = append(report.Pages, replaceResult{ID: p.ID, Title: p.Title, Status: replaceFailed, Error: err.Error()})
+			continue
+		}
+		if r.matches == 0 {
+			continue
+		}
+		report.Matches += r.matches
+		result := replaceResult{ID: p.ID, Title: r.page.Title, Matches: r.matches, Status: replaceWouldReplace}
+		if opts.dryRun {
+			if result.Diff, err = r.diff(); err != nil {
+				result.Status, result.Error = replaceFailed, err.Error()
+			}
+		}
+		pendingResults = append(pendingResults, len(report.Pages))
+		report.Pages = append(report.Pages, result)
+		pending = append(pending, r)
+	}
+	progress.Done()
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if len(pending) == 0 && !view.IsStructured(opts.output) {
+		renderer.RenderText(fmt.Sprintf("No matches in %d page(s).", report.Scanned))
+		return replaceFailures(report, renderer)
+	}
+
+	if opts.dryRun {
+		if view.IsStructured(opts.output) {
+			if err := renderer.RenderJSON(report); err != nil {
+				return err
+			}
+			return replaceFailures(report, nil)
+		}
+		for _, r := range report.Pages {
+			if r.Diff == "" {
+				continue
+			}
+			if opts.output == "plain" {
+				fmt.Print(r.Diff)
+			} else {
+				printDiff(r.Diff)
+			}
+		}
+		if opts.output != "plain" {
+			fmt.Printf("\n%d match(es) in %d page(s) would be replaced (dry run).\n", report.Matches, len(pending))
+		}
+		return replaceFailures(report, renderer)
+	}
+
+	// Confirm the replacement unless --yes is used
+	if !opts.force && len(pending) > 0 {
+		for _, r := range pending {
+			fmt.Printf("  %s (ID: %s): %d match(es)\n", r.page.Title, r.page.ID, r.matches)
+		}
+		fmt.Printf("About to replace %d match(es) in %d page(s).\n", report.Matches, len(pending))
+		if !confirmDelete(opts.stdin) {
+			fmt.Println("Replacement cancelled.")
+			return nil
+		}
+	}
+
+	message := fmt.Sprintf("Replaced %q with %q via cfl", find, replacement)
+	progress = view.NewProgress(len(pending))
+	for i, r := range pending {
+		result := &report.Pages[pendingResults[i]]
+		progress.Increment(result.Title)
+		page, err := publishReplacement(ctx, client, r, re, repl, message)
+		if err != nil {
+			result.Status, result.Error = replaceFailed, err.Error()
+			continue
+		}
+		result.Status = replaceReplaced
+		if page.Version != nil {
+			result.Version = page.Version.Number
+		}
+	}
+	progress.Done()
+
+	if view.IsStructured(opts.output) {
+		if err := renderer.RenderJSON(report); err != nil {
+			return err
+		}
+		return replaceFailures(report, nil)
+	}
+
+	rows := make([][]string, len(report.Pages))
+	counts := make(map[string]int)
+	for i, r := range report.Pages {
+		rows[i] = []string{r.ID, view.Truncate(r.Title, 50), strconv.Itoa(r.Matches), r.Status, r.Error}
+		counts[r.Status]++
+	}
+	renderer.RenderTable([]string{"ID", "TITLE", "MATCHES", "STATUS", "ERROR"}, rows)
+	if opts.output != "plain" {
+		fmt.Printf("\n%d page(s) changed, %d failed.\n", counts[replaceReplaced], counts[replaceFailed])
+	}
+	return replaceFailures(report, nil)
+}
+
+// replacePattern returns the regexp matching find and the replacement to
+// expand for each match.
+func replacePattern(find, replacement string, opts *replaceOptions) (*regexp.Regexp, string, error) {
+	if find == "" {
+		return nil, "", fmt.Errorf("text to find cannot be empty")
+	}
+	pattern, repl := find, replacement
+	if !opts.regex {
+		pattern = regexp.QuoteMeta(find)
+		repl = strings.ReplaceAll(replacement, "$", "$$")
+	}
+	if opts.ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid regex: %w", err)
+	}
+	if re.MatchString("") {
+		return nil, "", fmt.Errorf("regex %q matches empty text", find)
+	}
+	return re, repl, nil
+}
+
+// findReplacement gets a page in its own editor's format and replaces the
+// matches of re in its text.
+func findReplacement(ctx context.Context, client *api.Client, pageID string, re *regexp.Regexp, repl string) (*pageReplacement, error) {
+	editor, err := pageEditor(ctx, client, pageID)
+	if err != nil {
+		return nil, err
+	}
+	r := &pageReplacement{legacy: editor == api.EditorLegacy}
+
+	format := "atlas_doc_format"
+	if r.legacy {
+		format = "storage"
+	}
+	r.page, err = client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: format})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+	if r.page.Body != nil {
+		rep := r.page.Body.AtlasDocFormat
+		if r.legacy {
+			rep = r.page.Body.Storage
+		}
+		if rep != nil {
+			r.before = rep.Value
+		}
+	}
+	if strings.TrimSpace(r.before) == "" {
+		return r, nil
+	}
+
+	if r.legacy {
+		r.after, r.matches = md.ReplaceStorageText(r.before, re, repl)
+		return r, nil
+	}
+	r.after, r.matches, err = md.ReplaceADFText(r.before, re, repl)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// diff returns the replacement's changes to the page, as a diff of its
+// markdown.
+func (r *pageReplacement) diff() (string, error) {
+	convert := md.FromADF
+	if r.legacy {
+		convert = md.FromConfluenceStorage
+	}
+	before, err := convert(r.before)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert page to markdown: %w", err)
+	}
+	after, err := convert(r.after)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert page to markdown: %w", err)
+	}
+	return md.UnifiedDiff(r.page.Title, r.page.Title+" (replaced)", before, after), nil
+}
+
+// publishReplacement publishes a replacement as a new version of its page.
+// If the page changed since it was read, the replacement is made again on
+// the latest version.
+func publishReplacement(ctx context.Context, client *api.Client, r *pageReplacement, re *regexp.Regexp, repl, message string) (*api.Page, error) {
+	for attempt := 1; ; attempt++ {
+		version := 1
+		if r.page.Version != nil {
+			version = r.page.Version.Number + 1
+		}
+		page, err := client.UpdatePage(ctx, r.page.ID, &api.UpdatePageRequest{
+			ID:     r.page.ID,
+			Status: "current",
+			Title:  r.page.Title,
+			Body:   newEditBody(r.after, r.legacy),
+			Version: &api.Version{
+				Number:  version,
+				Message: message,
+			},
+		})
+		if err == nil {
+			return page, nil
+		}
+		if !isVersionConflict(err) || attempt == insertAttempts {
+			err = checkADFSupport(ctx, client, err, r.legacy)
+			return nil, fmt.Errorf("failed to update page: %w", err)
+		}
+
+		if r, err = findReplacement(ctx, client, r.page.ID, re, repl); err != nil {
+			return nil, err
+		}
+		if r.matches == 0 {
+			return r.page, nil
+		}
+	}
+}
+
+// replaceFailures returns an error if replacing failed for any page,
+// first warning of each failure with renderer if it isn't nil.
+func replaceFailures(report *replaceReport, renderer *view.Renderer) error {
+	failed := 0
+	for _, r := range report.Pages {
+		if r.Status == replaceFailed {
+			failed++
+			if renderer != nil {
+				renderer.Warning(fmt.Sprintf("%s (ID: %s): %s", r.Title, r.ID, r.Error))
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to replace text in %d page(s)", failed)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package page
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// newReplaceServer serves a search finding a legacy page (1) and a cloud
+// editor page (2) mentioning Acme, and a page without it (3), recording the
+// bodies of page updates by page ID.
+func newReplaceServer(t *testing.T, updates map[string]map[string]interface{}) *httptest.Server {
+	t.Helper()
+	storage := `<p>Acme docs</p><ac:structured-macro ac:name="info"><ac:parameter ac:name="title">Acme</ac:parameter></ac:structured-macro>`
+	adf := `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"About Acme"}]}]}`
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/search":
+			assert.Equal(t, `space = "DOCS" and type = page`, r.URL.Query().Get("cql"))
+			w.Write([]byte(`{"results": [
+				{"content": {"id": "1", "type": "page", "title": "Legacy"}},
+				{"content": {"id": "2", "type": "page", "title": "Cloud"}},
+				{"content": {"id": "3", "type": "page", "title": "Other"}}
+			], "start": 0, "size": 3, "totalSize": 3}`))
+		case strings.HasSuffix(r.URL.Path, "/properties"):
+			editor := "v2"
+			if r.URL.Path == "/api/v2/pages/1/properties" {
+				editor = "v1"
+			}
+			w.Write([]byte(`{"results": [{"key": "editor", "value": "` + editor + `"}]}`))
+		case r.Method == "GET":
+			id := strings.TrimPrefix(r.URL.Path, "/api/v2/pages/")
+			body := `"atlas_doc_format": {"value": ` + jsonString(t, adf) + `}`
+			switch id {
+			case "1":
+				assert.Equal(t, "storage", r.URL.Query().Get("body-format"))
+				body = `"storage": {"value": ` + jsonString(t, storage) + `}`
+			case "3":
+				body = `"atlas_doc_format": {"value": ` + jsonString(t, `{"type":"doc","version":1,"content":[]}`) + `}`
+			}
+			w.Write([]byte(`{"id": "` + id + `", "title": "Page ` + id + `", "version": {"number": 2}, "body": {` + body + `}}`))
+		case r.Method == "PUT":
+			id := strings.TrimPrefix(r.URL.Path, "/api/v2/pages/")
+			var req map[string]interface{}
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &req))
+			updates[id] = req
+			w.Write([]byte(`{"id": "` + id + `", "title": "Page ` + id + `", "version": {"number": 3}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunReplace(t *testing.T) {
+	updates := map[string]map[string]interface{}{}
+	server := newReplaceServer(t, updates)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &replaceOptions{space: "DOCS", force: true, noColor: true}
+	require.NoError(t, runReplace("Acme", "Globex $1", opts, client))
+
+	require.Len(t, updates, 2)
+
+	legacy := updates["1"]
+	assert.Equal(t, float64(3), legacy["version"].(map[string]interface{})["number"])
+	assert.Equal(t, `Replaced "Acme" with "Globex $1" via cfl`, legacy["version"].(map[string]interface{})["message"])
+	storage := legacy["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"]
+	assert.Equal(t, `<p>Globex $1 docs</p><ac:structured-macro ac:name="info"><ac:parameter ac:name="title">Acme</ac:parameter></ac:structured-macro>`, storage)
+
+	cloud := updates["2"]
+	adf := cloud["body"].(map[string]interface{})["atlas_doc_format"].(map[string]interface{})["value"]
+	assert.Contains(t, adf, `"text":"About Globex $1"`)
+}
+
+func TestRunReplace_DryRun(t *testing.T) {
+	updates := map[string]map[string]interface{}{}
+	server := newReplaceServer(t, updates)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &replaceOptions{space: "DOCS", dryRun: true, noColor: true}
+	require.NoError(t, runReplace("acme", "Globex", opts, client))
+	assert.Empty(t, updates)
+
+	opts.ignoreCase = true
+	require.NoError(t, runReplace("acme", "Globex", opts, client))
+	assert.Empty(t, updates)
+}
+
+func TestRunReplace_Cancelled(t *testing.T) {
+	updates := map[string]map[string]interface{}{}
+	server := newReplaceServer(t, updates)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &replaceOptions{space: "DOCS", noColor: true, stdin: strings.NewReader("n\n")}
+	require.NoError(t, runReplace("Acme", "Globex", opts, client))
+	assert.Empty(t, updates)
+}
+
+func TestReplacePattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		find     string
+		repl     string
+		opts     replaceOptions
+		input    string
+		expected string
+		wantErr  string
+	}{
+		{"literal", "a.b", "$x", replaceOptions{}, "a.b axb", "$x axb", ""},
+		{"regex", `v(\d)`, "version $1", replaceOptions{regex: true}, "v1", "version 1", ""},
+		{"ignore case", "acme", "Globex", replaceOptions{ignoreCase: true}, "ACME", "Globex", ""},
+		{"empty", "", "x", replaceOptions{}, "", "", "cannot be empty"},
+		{"invalid regex", "(", "x", replaceOptions{regex: true}, "", "", "invalid regex"},
+		{"matches empty text", "a*", "x", replaceOptions{regex: true}, "", "", "matches empty text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, repl, err := replacePattern(tt.find, tt.repl, &tt.opts)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, re.ReplaceAllString(tt.input, repl))
+		})
+	}
+}
+
+func TestRunReplace_RequiresPages(t *testing.T) {
+	err := runReplace("a", "b", &replaceOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--cql or --space is required")
+}
@@ -0,0 +1,117 @@
+package page
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type restoreOptions struct {
+	version int
+	message string
+	output  string
+	noColor bool
+}
+
+// NewCmdRestore creates the page restore command.
+func NewCmdRestore() *cobra.Command {
+	opts := &restoreOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "restore <page-id>",
+		Short: "Restore an old version of a page",
+		Long: `Make an old version of a page current again.
+
+The old version's title and content are published as a new version, so
+no history is lost: the versions in between stay in the page history and
+the restore itself can be undone the same way.`,
+		Example: `  # Restore version 3
+  cfl page restore 12345 --version 3
+
+  # Restore with a version message
+  cfl page restore 12345 --version 3 -m "Revert accidental edit"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runRestore(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.version, "version", 0, "Version to restore (required)")
+	cmd.Flags().StringVarP(&opts.message, "message", "m", "", "Version message (default: \"Restored version N\")")
+
+	_ = cmd.MarkFlagRequired("version")
+
+	return cmd
+}
+
+func runRestore(pageID string, opts *restoreOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.version <= 0 {
+		return fmt.Errorf("invalid version: %d (must be >= 1)", opts.version)
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	page, err := client.GetPage(ctx, pageID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get page: %w", err)
+	}
+	if page.Version != nil && opts.version >= page.Version.Number {
+		if opts.version == page.Version.Number {
+			return fmt.Errorf("version %d is already the current version", opts.version)
+		}
+		return fmt.Errorf("version %d does not exist (current version is %d)", opts.version, page.Version.Number)
+	}
+
+	message := opts.message
+	if message == "" {
+		message = fmt.Sprintf("Restored version %d", opts.version)
+	}
+
+	version, err := client.RestorePageVersion(ctx, pageID, opts.version, message)
+	if err != nil {
+		return fmt.Errorf("failed to restore version %d: %w", opts.version, err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]any{
+			"page_id":  pageID,
+			"restored": opts.version,
+			"version":  version.Number,
+		})
+	}
+
+	renderer.Success(fmt.Sprintf("Restored version %d of %s (ID: %s) as version %d", opts.version, page.Title, pageID, version.Number))
+	return nil
+}
@@ -0,0 +1,45 @@
+package page
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunRestore(t *testing.T) {
+	var restored []int
+	server := newVersionServer(t, []string{"<p>a</p>", "<p>b</p>", "<p>c</p>"}, &restored)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	err := runRestore("12345", &restoreOptions{version: 1, noColor: true}, client)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, restored)
+}
+
+func TestRunRestore_InvalidVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+		wantErr string
+	}{
+		{"zero", 0, "invalid version"},
+		{"current", 3, "already the current version"},
+		{"future", 7, "does not exist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var restored []int
+			server := newVersionServer(t, []string{"<p>a</p>", "<p>b</p>", "<p>c</p>"}, &restored)
+			client := api.NewClient(server.URL, "test@example.com", "token")
+
+			err := runRestore("12345", &restoreOptions{version: tt.version, noColor: true}, client)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Empty(t, restored)
+		})
+	}
+}
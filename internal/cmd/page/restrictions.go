@@ -0,0 +1,293 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/principal"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type restrictionsOptions struct {
+	user       string
+	group      string
+	operations []string
+	output     string
+	noColor    bool
+	stdout     io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdRestrictions creates the page restrictions command.
+func NewCmdRestrictions() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "restrictions",
+		Aliases: []string{"restriction"},
+		Short:   "Manage page restrictions",
+		Long: `Commands for listing, adding, and removing the restrictions on who can view
+(read) or edit (update) a page.
+
+An operation without restrictions is open to everyone with permission on the
+space; once restricted, only the listed users and groups can perform it.
+Users are given by account ID, or "me"; groups by name.`,
+	}
+
+	cmd.AddCommand(NewCmdRestrictionsList())
+	cmd.AddCommand(NewCmdRestrictionsAdd())
+	cmd.AddCommand(NewCmdRestrictionsRemove())
+
+	return cmd
+}
+
+// NewCmdRestrictionsList creates the page restrictions list command.
+func NewCmdRestrictionsList() *cobra.Command {
+	opts := &restrictionsOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list <page>",
+		Aliases: []string{"ls"},
+		Short:   "List the restrictions on a page",
+		Long: `List the users and groups each operation on a page is restricted to,
+optionally only those naming a user or group.
+
+The page can be ` + pageref.Usage + `.`,
+		Example: `  # Who can view and edit a page
+  cfl page restrictions list 12345
+
+  # As JSON for an audit
+  cfl page restrictions list 12345 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runRestrictionsList(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.user, "user", "", principal.UserUsage)
+	cmd.Flags().StringVar(&opts.group, "group", "", principal.GroupUsage)
+	cmd.MarkFlagsMutuallyExclusive("user", "group")
+
+	return cmd
+}
+
+// NewCmdRestrictionsAdd creates the page restrictions add command.
+func NewCmdRestrictionsAdd() *cobra.Command {
+	return newRestrictionsChangeCmd(false)
+}
+
+// NewCmdRestrictionsRemove creates the page restrictions remove command.
+func NewCmdRestrictionsRemove() *cobra.Command {
+	return newRestrictionsChangeCmd(true)
+}
+
+func newRestrictionsChangeCmd(remove bool) *cobra.Command {
+	opts := &restrictionsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "add <page>",
+		Short: "Restrict a page to a user or group",
+		Long: `Add a user or group to those who can view (read) or edit (update) a page.
+Restricting an unrestricted operation makes it unavailable to everyone not
+listed, so include yourself.
+
+The page can be ` + pageref.Usage + `.`,
+		Example: `  # Only the security team can view the page
+  cfl page restrictions add 12345 --operation read --group security
+  cfl page restrictions add 12345 --operation read --user me
+
+  # Let a user edit it
+  cfl page restrictions add 12345 --operation update --user 5b10ac8d82e05b22cc7d4ef5`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runRestrictionsChange(args[0], remove, opts, nil)
+		},
+	}
+	if remove {
+		cmd.Use = "remove <page>"
+		cmd.Aliases = []string{"rm"}
+		cmd.Short = "Remove a user or group from a page's restrictions"
+		cmd.Long = `Remove a user or group from those who can view (read) or edit (update) a
+page. Removing the last user or group lifts the restriction.
+
+The page can be ` + pageref.Usage + `.`
+		cmd.Example = `  # Stop a group editing the page
+  cfl page restrictions remove 12345 --operation update --group contractors`
+	}
+
+	cmd.Flags().StringVar(&opts.user, "user", "", principal.UserUsage)
+	cmd.Flags().StringVar(&opts.group, "group", "", principal.GroupUsage)
+	cmd.Flags().StringSliceVar(&opts.operations, "operation", nil, "Operation: read or update (repeatable)")
+	cmd.MarkFlagsMutuallyExclusive("user", "group")
+	_ = cmd.MarkFlagRequired("operation")
+
+	return cmd
+}
+
+func runRestrictionsList(pageRef string, opts *restrictionsOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pageID, err := pageref.Resolve(ctx, client, pageRef)
+	if err != nil {
+		return err
+	}
+	selected, err := principal.Resolve(ctx, client, opts.user, opts.group)
+	if err != nil {
+		return err
+	}
+
+	restrictions, err := client.GetPageRestrictions(ctx, pageID)
+	if err != nil {
+		return fmt.Errorf("failed to get restrictions: %w", err)
+	}
+	if selected != nil {
+		restrictions = filterRestrictions(restrictions, *selected)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(restrictions)
+	}
+
+	headers := []string{"OPERATION", "TYPE", "NAME", "ID"}
+	var rows [][]string
+	for _, r := range restrictions {
+		if len(r.Users) == 0 && len(r.Groups) == 0 && selected == nil {
+			rows = append(rows, []string{r.Operation, "-", "(not restricted)", ""})
+		}
+		for _, u := range r.Users {
+			rows = append(rows, []string{r.Operation, api.PrincipalUser, u.DisplayName, u.AccountID})
+		}
+		for _, g := range r.Groups {
+			rows = append(rows, []string{r.Operation, api.PrincipalGroup, g.Name, g.ID})
+		}
+	}
+	if len(rows) == 0 {
+		renderer.RenderText("No restrictions found.")
+		return nil
+	}
+	renderer.RenderTable(headers, rows)
+	return nil
+}
+
+func runRestrictionsChange(pageRef string, remove bool, opts *restrictionsOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if len(opts.operations) == 0 {
+		return fmt.Errorf("--operation is required")
+	}
+	for _, op := range opts.operations {
+		if op != api.RestrictionRead && op != api.RestrictionUpdate {
+			return fmt.Errorf("invalid operation %q: must be read or update", op)
+		}
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pageID, err := pageref.Resolve(ctx, client, pageRef)
+	if err != nil {
+		return err
+	}
+	selected, err := principal.Require(ctx, client, opts.user, opts.group)
+	if err != nil {
+		return err
+	}
+
+	change := client.AddPageRestriction
+	if remove {
+		change = client.RemovePageRestriction
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	for _, op := range opts.operations {
+		if err := change(ctx, pageID, op, selected); err != nil {
+			return fmt.Errorf("failed to update %s restriction: %w", op, err)
+		}
+		if !view.IsStructured(opts.output) {
+			msg := fmt.Sprintf("Restricted %s on page %s to %s %s", op, pageID, selected.Type, selected.ID)
+			if remove {
+				msg = fmt.Sprintf("Removed %s %s from the %s restriction on page %s", selected.Type, selected.ID, op, pageID)
+			}
+			renderer.Success(msg)
+		}
+	}
+
+	if view.IsStructured(opts.output) {
+		restrictions, err := client.GetPageRestrictions(ctx, pageID)
+		if err != nil {
+			return fmt.Errorf("failed to get restrictions: %w", err)
+		}
+		return renderer.RenderJSON(restrictions)
+	}
+	return nil
+}
+
+// filterRestrictions returns the restrictions naming a user or group.
+func filterRestrictions(restrictions []api.ContentRestriction, p api.Principal) []api.ContentRestriction {
+	filtered := make([]api.ContentRestriction, 0, len(restrictions))
+	for _, r := range restrictions {
+		match := api.ContentRestriction{Operation: r.Operation, Users: []api.User{}, Groups: []api.Group{}}
+		for _, u := range r.Users {
+			if p.Type == api.PrincipalUser && u.AccountID == p.ID {
+				match.Users = append(match.Users, u)
+			}
+		}
+		for _, g := range r.Groups {
+			if p.Type == api.PrincipalGroup && g.ID == p.ID {
+				match.Groups = append(match.Groups, g)
+			}
+		}
+		if len(match.Users) > 0 || len(match.Groups) > 0 {
+			filtered = append(filtered, match)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,105 @@
+package page
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+const testRestrictions = `{"results": [
+	{"operation": "read", "restrictions": {"user": {"results": []}, "group": {"results": []}}},
+	{"operation": "update", "restrictions": {
+		"user": {"results": [{"type": "known", "accountId": "abc", "displayName": "Ada"}]},
+		"group": {"results": [{"type": "group", "name": "editors", "id": "g-2"}]}}}
+]}`
+
+func newRestrictionsServer(t *testing.T, writes *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/rest/api/content/123/restriction":
+			w.Write([]byte(testRestrictions))
+		case r.URL.Path == "/rest/api/group/by-name":
+			w.Write([]byte(`{"type": "group", "name": "editors", "id": "g-2"}`))
+		case r.URL.Path == "/rest/api/user/current":
+			w.Write([]byte(`{"type": "known", "accountId": "me-1"}`))
+		case r.Method == "PUT" || r.Method == "DELETE":
+			*writes = append(*writes, r.Method+" "+r.URL.Path+" "+r.URL.RawQuery)
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunRestrictionsList(t *testing.T) {
+	server := newRestrictionsServer(t, nil)
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	var stdout bytes.Buffer
+	require.NoError(t, runRestrictionsList("123", &restrictionsOptions{noColor: true, stdout: &stdout}, client))
+	out := stdout.String()
+	assert.Contains(t, out, "(not restricted)")
+	assert.Contains(t, out, "Ada")
+	assert.Contains(t, out, "editors")
+
+	stdout.Reset()
+	opts := &restrictionsOptions{group: "editors", output: "json", stdout: &stdout}
+	require.NoError(t, runRestrictionsList("123", opts, client))
+	var restrictions []api.ContentRestriction
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &restrictions))
+	require.Len(t, restrictions, 1)
+	assert.Equal(t, "update", restrictions[0].Operation)
+	assert.Empty(t, restrictions[0].Users)
+	assert.Equal(t, "g-2", restrictions[0].Groups[0].ID)
+}
+
+func TestRunRestrictionsChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		remove bool
+		opts   *restrictionsOptions
+		want   []string
+	}{
+		{"add me to read and update", false, &restrictionsOptions{user: "me", operations: []string{"read", "update"}}, []string{
+			"PUT /rest/api/content/123/restriction/byOperation/read/user accountId=me-1",
+			"PUT /rest/api/content/123/restriction/byOperation/update/user accountId=me-1",
+		}},
+		{"remove group", true, &restrictionsOptions{group: "editors", operations: []string{"update"}}, []string{
+			"DELETE /rest/api/content/123/restriction/byOperation/update/byGroupId/g-2 ",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writes []string
+			server := newRestrictionsServer(t, &writes)
+			defer server.Close()
+			client := api.NewClient(server.URL, "test@example.com", "token")
+
+			tt.opts.noColor, tt.opts.stdout = true, &bytes.Buffer{}
+			require.NoError(t, runRestrictionsChange("123", tt.remove, tt.opts, client))
+			assert.Equal(t, tt.want, writes)
+		})
+	}
+}
+
+func TestRunRestrictionsChange_Validation(t *testing.T) {
+	client := api.NewClient("http://unused", "test@example.com", "token")
+
+	err := runRestrictionsChange("123", false, &restrictionsOptions{user: "abc"}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--operation is required")
+
+	err = runRestrictionsChange("123", false, &restrictionsOptions{user: "abc", operations: []string{"delete"}}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid operation "delete"`)
+}
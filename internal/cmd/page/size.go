@@ -0,0 +1,229 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type sizeOptions struct {
+	recursive bool
+	output    string
+	noColor   bool
+}
+
+// pageSize holds storage figures for a single page and its subtree.
+type pageSize struct {
+	ID              string `json:"id"`
+	Title           string `json:"title"`
+	Depth           int    `json:"depth"`
+	BodyBytes       int64  `json:"bodyBytes"`
+	Attachments     int    `json:"attachments"`
+	AttachmentBytes int64  `json:"attachmentBytes"`
+	Versions        int    `json:"versions"`
+	HistoryBytes    int64  `json:"historyBytesEstimate"`
+	TotalBytes      int64  `json:"totalBytes"`
+	SubtreeBytes    int64  `json:"subtreeBytes"`
+}
+
+// NewCmdSize creates the page size command.
+func NewCmdSize() *cobra.Command {
+	opts := &sizeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "size <page-id>",
+		Short: "Estimate storage used by a page",
+		Long: `Report the storage used by a page: body size, attachment size, and an
+estimate of the storage held by previous versions.
+
+Version history is estimated by assuming every previous version of the body
+and of each attachment was the same size as the current one.
+
+With --recursive, all descendant pages are included and each page reports
+the total for its subtree.`,
+		Example: `  # Size of a single page
+  cfl page size 12345
+
+  # Size of a page and all its descendants
+  cfl page size 12345 --recursive
+
+  # Output as JSON
+  cfl page size 12345 -r -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runSize(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.recursive, "recursive", "r", false, "Include all descendant pages")
+
+	return cmd
+}
+
+func runSize(pageID string, opts *sizeOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
+	}
+
+	var sizes []*pageSize
+	if _, err := collectPageSizes(context.Background(), client, pageID, 0, opts.recursive, &sizes); err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(sizes)
+	}
+
+	headers := []string{"ID", "TITLE", "BODY", "ATTACHMENTS", "HISTORY (EST.)", "TOTAL"}
+	if opts.recursive {
+		headers = append(headers, "SUBTREE")
+	}
+
+	var rows [][]string
+	for _, s := range sizes {
+		row := []string{
+			s.ID,
+			strings.Repeat("  ", s.Depth) + view.Truncate(s.Title, 50),
+			view.FormatFileSize(s.BodyBytes),
+			fmt.Sprintf("%s (%d)", view.FormatFileSize(s.AttachmentBytes), s.Attachments),
+			fmt.Sprintf("%s (%d versions)", view.FormatFileSize(s.HistoryBytes), s.Versions),
+			view.FormatFileSize(s.TotalBytes),
+		}
+		if opts.recursive {
+			row = append(row, view.FormatFileSize(s.SubtreeBytes))
+		}
+		rows = append(rows, row)
+	}
+
+	renderer.RenderTable(headers, rows)
+
+	if opts.recursive && opts.output != "plain" {
+		fmt.Println()
+		renderer.RenderKeyValue("Pages", strconv.Itoa(len(sizes)))
+		renderer.RenderKeyValue("Total", view.FormatFileSize(sizes[0].SubtreeBytes))
+	}
+
+	return nil
+}
+
+// collectPageSizes measures a page (and optionally its descendants), appending
+// results to sizes in depth-first order. Returns the page's entry.
+func collectPageSizes(ctx context.Context, client *api.Client, pageID string, depth int, recursive bool, sizes *[]*pageSize) (*pageSize, error) {
+	page, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: "storage"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page %s: %w", pageID, err)
+	}
+
+	size := &pageSize{
+		ID:       page.ID,
+		Title:    page.Title,
+		Depth:    depth,
+		Versions: 1,
+	}
+	if page.Body != nil && page.Body.Storage != nil {
+		size.BodyBytes = int64(len(page.Body.Storage.Value))
+	}
+	if page.Version != nil && page.Version.Number > 1 {
+		size.Versions = page.Version.Number
+	}
+	size.HistoryBytes = size.BodyBytes * int64(size.Versions-1)
+
+	attachments, err := listAllAttachments(ctx, client, pageID)
+	if err != nil {
+		return nil, err
+	}
+	for _, att := range attachments {
+		size.Attachments++
+		size.AttachmentBytes += att.FileSize
+		if att.Version != nil && att.Version.Number > 1 {
+			size.HistoryBytes += att.FileSize * int64(att.Version.Number-1)
+		}
+	}
+
+	size.TotalBytes = size.BodyBytes + size.AttachmentBytes + size.HistoryBytes
+	size.SubtreeBytes = size.TotalBytes
+	*sizes = append(*sizes, size)
+
+	if !recursive {
+		return size, nil
+	}
+
+	children, err := listAllChildPages(ctx, client, pageID)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		childSize, err := collectPageSizes(ctx, client, child.ID, depth+1, recursive, sizes)
+		if err != nil {
+			return nil, err
+		}
+		size.SubtreeBytes += childSize.SubtreeBytes
+	}
+
+	return size, nil
+}
+
+// listAllChildPages returns every direct child of a page in position order,
+// following pagination.
+func listAllChildPages(ctx context.Context, client *api.Client, pageID string) ([]api.Page, error) {
+	var pages []api.Page
+	opts := &api.ListChildPagesOptions{Limit: 250, Sort: "child-position"}
+	for {
+		result, err := client.ListChildPages(ctx, pageID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list child pages of %s: %w", pageID, err)
+		}
+		pages = append(pages, result.Results...)
+		if opts.Cursor = result.NextCursor(); opts.Cursor == "" {
+			return pages, nil
+		}
+	}
+}
+
+// listAllAttachments returns every attachment on a page, following pagination.
+func listAllAttachments(ctx context.Context, client *api.Client, pageID string) ([]api.Attachment, error) {
+	var attachments []api.Attachment
+	opts := &api.ListAttachmentsOptions{Limit: 250}
+	for {
+		result, err := client.ListAttachments(ctx, pageID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list attachments of %s: %w", pageID, err)
+		}
+		attachments = append(attachments, result.Results...)
+		if opts.Cursor = result.NextCursor(); opts.Cursor == "" {
+			return attachments, nil
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package page
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// mockSizeServer serves a parent page (3 versions, one attachment) with a single child page.
+func mockSizeServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/pages/100":
+			w.Write([]byte(`{"id": "100", "title": "Parent", "version": {"number": 3}, "body": {"storage": {"value": "0123456789"}}}`))
+		case r.URL.Path == "/api/v2/pages/200":
+			w.Write([]byte(`{"id": "200", "title": "Child", "version": {"number": 1}, "body": {"storage": {"value": "01234"}}}`))
+		case r.URL.Path == "/api/v2/pages/100/attachments":
+			w.Write([]byte(`{"results": [{"id": "att1", "title": "a.png", "fileSize": 1000, "version": {"number": 2}}]}`))
+		case r.URL.Path == "/api/v2/pages/200/attachments":
+			w.Write([]byte(`{"results": []}`))
+		case r.URL.Path == "/api/v2/pages/100/children":
+			w.Write([]byte(`{"results": [{"id": "200", "title": "Child"}]}`))
+		case r.URL.Path == "/api/v2/pages/200/children":
+			w.Write([]byte(`{"results": []}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestCollectPageSizes_Single(t *testing.T) {
+	server := mockSizeServer(t)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	var sizes []*pageSize
+	size, err := collectPageSizes(context.Background(), client, "100", 0, false, &sizes)
+	require.NoError(t, err)
+
+	require.Len(t, sizes, 1)
+	assert.Equal(t, int64(10), size.BodyBytes)
+	assert.Equal(t, int64(1000), size.AttachmentBytes)
+	assert.Equal(t, 1, size.Attachments)
+	assert.Equal(t, 3, size.Versions)
+	// 2 previous body versions (20) + 1 previous attachment version (1000)
+	assert.Equal(t, int64(1020), size.HistoryBytes)
+	assert.Equal(t, int64(2030), size.TotalBytes)
+	assert.Equal(t, size.TotalBytes, size.SubtreeBytes)
+}
+
+func TestCollectPageSizes_Recursive(t *testing.T) {
+	server := mockSizeServer(t)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	var sizes []*pageSize
+	size, err := collectPageSizes(context.Background(), client, "100", 0, true, &sizes)
+	require.NoError(t, err)
+
+	require.Len(t, sizes, 2)
+	assert.Equal(t, "Child", sizes[1].Title)
+	assert.Equal(t, 1, sizes[1].Depth)
+	assert.Equal(t, int64(5), sizes[1].TotalBytes)
+	assert.Equal(t, int64(2035), size.SubtreeBytes)
+}
+
+func TestRunSize_InvalidOutputFormat(t *testing.T) {
+	opts := &sizeOptions{output: "xml", noColor: true}
+	err := runSize("100", opts, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid output format")
+}
+
+func TestRunSize_PageNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Page not found"}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runSize("999", &sizeOptions{noColor: true}, client)
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "failed to get page 999"))
+}
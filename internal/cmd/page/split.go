@@ -0,0 +1,419 @@
+package page
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+type splitOptions struct {
+	by            string
+	childrenMacro bool
+	dryRun        bool
+	output        string
+	noColor       bool
+	stdout        io.Writer // For testing; defaults to os.Stdout
+}
+
+// splitResult is the JSON output of page split.
+type splitResult struct {
+	PageID   string       `json:"pageId"`
+	DryRun   bool         `json:"dryRun,omitempty"`
+	Children []splitChild `json:"children"`
+}
+
+// splitChild is a child page made from a section.
+type splitChild struct {
+	ID          string   `json:"id,omitempty"`
+	Title       string   `json:"title"`
+	Attachments []string `json:"attachments,omitempty"`
+	URL         string   `json:"url,omitempty"`
+}
+
+// headingLevelPattern matches a heading level for --by, capturing its number.
+var headingLevelPattern = regexp.MustCompile(`^[hH]([1-6])$`)
+
+// NewCmdSplit creates the page split command.
+func NewCmdSplit() *cobra.Command {
+	opts := &splitOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "split <page>",
+		Short: "Split a page into child pages by heading",
+		Long: `Split a large page into child pages, one per section. Each top-level heading
+of the --by level starts a section; the section becomes a child page titled
+with the heading, and is replaced on the page by a link to the child. Use
+--children-macro to list the children with the Children Display macro
+instead of links. Content before the first heading stays on the page.
+
+Attachments a section shows or links to are copied to its child page. They
+are left on the original page, which keeps its history.
+
+The page is split in its own editor format. Headings inside layouts, tables
+or macros don't start sections. Nothing is changed if a child page title is
+already taken in the space; use --dry-run to see the sections first.
+
+The page may be ` + pageref.Usage + `.`,
+		Example: `  # Make a child page of each level 2 section
+  cfl page split 12345 --by h2
+
+  # Preview the child pages
+  cfl page split "DEV/User Guide" --dry-run
+
+  # List the children with a macro instead of links
+  cfl page split 12345 --by h1 --children-macro`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runSplit(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.by, "by", "h2", "Heading level that starts a section: h1 to h6")
+	cmd.Flags().BoolVar(&opts.childrenMacro, "children-macro", false, "Replace the sections with a Children Display macro instead of links")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show the child pages that would be created without changing anything")
+
+	return cmd
+}
+
+func runSplit(ref string, opts *splitOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.by == "" {
+		opts.by = "h2"
+	}
+	m := headingLevelPattern.FindStringSubmatch(opts.by)
+	if m == nil {
+		return fmt.Errorf("invalid --by %q: use a heading level, h1 to h6", opts.by)
+	}
+	level, _ := strconv.Atoi(m[1])
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pageID, err := pageref.Resolve(ctx, client, ref)
+	if err != nil {
+		return err
+	}
+
+	// Split the page in its own format so its editor doesn't change
+	editor, err := pageEditor(ctx, client, pageID)
+	if err != nil {
+		return err
+	}
+	legacy := editor == api.EditorLegacy
+	format := "atlas_doc_format"
+	if legacy {
+		format = "storage"
+	}
+	page, err := client.GetPage(ctx, pageID, &api.GetPageOptions{BodyFormat: format})
+	if err != nil {
+		return fmt.Errorf("failed to get page: %w", err)
+	}
+
+	var content string
+	if body := page.Body; body != nil {
+		rep := body.AtlasDocFormat
+		if legacy {
+			rep = body.Storage
+		}
+		if rep != nil {
+			content = rep.Value
+		}
+	}
+
+	var head string
+	var sections []md.PageSection
+	if legacy {
+		head, sections = md.SplitStorage(content, level)
+	} else if head, sections, err = md.SplitADF(content, level); err != nil {
+		return fmt.Errorf("page %s: %w", pageID, err)
+	}
+	if len(sections) == 0 {
+		return fmt.Errorf("page %s has no %s headings to split at", pageID, strings.ToLower(opts.by))
+	}
+
+	// Check every title before creating anything
+	seen := map[string]bool{}
+	for _, section := range sections {
+		if strings.TrimSpace(section.Title) == "" {
+			return fmt.Errorf("page %s has an empty %s heading; give it a title to split the page", pageID, strings.ToLower(opts.by))
+		}
+		if seen[section.Title] {
+			return fmt.Errorf("page %s has more than one section titled %q", pageID, section.Title)
+		}
+		seen[section.Title] = true
+		existing, err := client.FindPageByTitle(ctx, page.SpaceID, section.Title)
+		if err != nil {
+			return fmt.Errorf("failed to check title %q: %w", section.Title, err)
+		}
+		if existing != nil {
+			return fmt.Errorf("a page titled %q already exists in the space (page %s)", section.Title, existing.ID)
+		}
+	}
+
+	attachments, err := listAllAttachments(ctx, client, pageID)
+	if err != nil {
+		return err
+	}
+
+	result := splitResult{PageID: pageID, DryRun: opts.dryRun, Children: []splitChild{}}
+	refs := make([][]api.Attachment, len(sections))
+	for i, section := range sections {
+		if refs[i], err = sectionAttachments(section.Content, attachments, legacy); err != nil {
+			return fmt.Errorf("section %q: %w", section.Title, err)
+		}
+		child := splitChild{Title: section.Title}
+		for _, att := range refs[i] {
+			child.Attachments = append(child.Attachments, att.Title)
+		}
+		result.Children = append(result.Children, child)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	if opts.dryRun {
+		if view.IsStructured(opts.output) {
+			return renderer.RenderJSON(result)
+		}
+		rows := make([][]string, 0, len(result.Children))
+		for _, child := range result.Children {
+			rows = append(rows, []string{child.Title, strings.Join(child.Attachments, ", ")})
+		}
+		renderer.RenderTable([]string{"CHILD PAGE", "ATTACHMENTS"}, rows)
+		return nil
+	}
+
+	var children []*api.Page
+	for i, section := range sections {
+		child, err := createSectionPage(ctx, client, page, section, refs[i], legacy)
+		if err != nil {
+			return fmt.Errorf("section %q: %w", section.Title, err)
+		}
+		children = append(children, child)
+		result.Children[i].ID = child.ID
+		result.Children[i].URL = client.BaseURL() + child.Links.WebUI
+	}
+
+	// The sections are replaced last, so a failure leaves the page whole
+	var updated string
+	if legacy {
+		updated = head + storageChildList(children, opts.childrenMacro)
+	} else if updated, err = adfWithChildList(head, children, client.BaseURL(), opts.childrenMacro); err != nil {
+		return err
+	}
+	version := 1
+	if page.Version != nil {
+		version = page.Version.Number
+	}
+	_, err = client.UpdatePage(ctx, pageID, &api.UpdatePageRequest{
+		ID:     pageID,
+		Status: "current",
+		Title:  page.Title,
+		Body:   newEditBody(updated, legacy),
+		Version: &api.Version{
+			Number:  version + 1,
+			Message: "Split into child pages via cfl",
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update page (child pages were created): %w", err)
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(result)
+	}
+	renderer.Success(fmt.Sprintf("Split %s into %d child pages", page.Title, len(children)))
+	rows := make([][]string, 0, len(result.Children))
+	for _, child := range result.Children {
+		rows = append(rows, []string{child.ID, child.Title, strconv.Itoa(len(child.Attachments))})
+	}
+	renderer.RenderTable([]string{"ID", "TITLE", "ATTACHMENTS"}, rows)
+	return nil
+}
+
+// sectionAttachments returns the page attachments a section references: by
+// filename in storage format, by file ID in ADF.
+func sectionAttachments(content string, attachments []api.Attachment, legacy bool) ([]api.Attachment, error) {
+	var refs []string
+	var match func(api.Attachment) string
+	if legacy {
+		refs = md.StorageAttachmentRefs(content)
+		match = func(a api.Attachment) string { return a.Title }
+	} else {
+		var err error
+		if refs, err = md.ADFMediaIDs(content); err != nil {
+			return nil, err
+		}
+		match = func(a api.Attachment) string { return a.FileID }
+	}
+
+	var found []api.Attachment
+	for _, ref := range refs {
+		for _, att := range attachments {
+			if match(att) == ref {
+				found = append(found, att)
+				break
+			}
+		}
+	}
+	return found, nil
+}
+
+// createSectionPage creates a child page of page from a section and copies
+// the attachments it references. ADF media are then pointed at the copies,
+// as they refer to files rather than filenames.
+func createSectionPage(ctx context.Context, client *api.Client, page *api.Page, section md.PageSection, attachments []api.Attachment, legacy bool) (*api.Page, error) {
+	child, err := client.CreatePage(ctx, &api.CreatePageRequest{
+		SpaceID:  page.SpaceID,
+		Status:   "current",
+		Title:    section.Title,
+		ParentID: page.ID,
+		Body:     newEditBody(section.Content, legacy),
+	})
+	if err != nil {
+		err = checkADFSupport(ctx, client, err, legacy)
+		return nil, fmt.Errorf("failed to create child page: %w", err)
+	}
+
+	fileIDs := map[string]string{}
+	for _, att := range attachments {
+		copied, err := copyAttachment(ctx, client, att, child.ID)
+		if err != nil {
+			return nil, err
+		}
+		if att.FileID != "" && copied.FileID != "" {
+			fileIDs[att.FileID] = copied.FileID
+		}
+	}
+	if legacy || len(fileIDs) == 0 {
+		return child, nil
+	}
+
+	content, err := md.RelinkADFMedia(section.Content, fileIDs, "contentId-"+child.ID)
+	if err != nil {
+		return nil, err
+	}
+	version := 1
+	if child.Version != nil {
+		version = child.Version.Number
+	}
+	updated, err := client.UpdatePage(ctx, child.ID, &api.UpdatePageRequest{
+		ID:     child.ID,
+		Status: "current",
+		Title:  child.Title,
+		Body:   newEditBody(content, legacy),
+		Version: &api.Version{
+			Number:  version + 1,
+			Message: "Linked attachments via cfl",
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to link attachments on child page %s: %w", child.ID, err)
+	}
+	child.Version = updated.Version
+	return child, nil
+}
+
+// copyAttachment copies an attachment to another page.
+func copyAttachment(ctx context.Context, client *api.Client, att api.Attachment, pageID string) (*api.Attachment, error) {
+	rc, err := client.DownloadAttachment(ctx, att.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download attachment %s: %w", att.Title, err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	copied, err := client.UploadAttachment(ctx, pageID, att.Title, rc, att.Comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy attachment %s: %w", att.Title, err)
+	}
+	return copied, nil
+}
+
+// storageChildList returns storage format that lists the child pages, as
+// links or a Children Display macro.
+func storageChildList(children []*api.Page, macro bool) string {
+	if macro {
+		return `<ac:structured-macro ac:name="children" ac:schema-version="2" />`
+	}
+	var sb strings.Builder
+	sb.WriteString("<ul>")
+	for _, child := range children {
+		fmt.Fprintf(&sb, `<li><ac:link><ri:page ri:content-title="%s" /></ac:link></li>`, html.EscapeString(child.Title))
+	}
+	sb.WriteString("</ul>")
+	return sb.String()
+}
+
+// adfWithChildList appends a list of the child pages to an ADF document, as
+// links or a Children Display macro.
+func adfWithChildList(adf string, children []*api.Page, baseURL string, macro bool) (string, error) {
+	var doc md.ADFDocument
+	if err := json.Unmarshal([]byte(adf), &doc); err != nil {
+		return "", fmt.Errorf("failed to parse ADF document: %w", err)
+	}
+
+	if macro {
+		doc.Content = append(doc.Content, &md.ADFNode{
+			Type: "extension",
+			Attrs: map[string]interface{}{
+				"extensionType": "com.atlassian.confluence.macro.core",
+				"extensionKey":  "children",
+				"parameters":    map[string]interface{}{"macroParams": map[string]interface{}{}},
+			},
+		})
+	} else {
+		list := &md.ADFNode{Type: "bulletList"}
+		for _, child := range children {
+			list.Content = append(list.Content, &md.ADFNode{
+				Type: "listItem",
+				Content: []*md.ADFNode{{
+					Type: "paragraph",
+					Content: []*md.ADFNode{{
+						Type:  "text",
+						Text:  child.Title,
+						Marks: []*md.ADFMark{{Type: "link", Attrs: map[string]interface{}{"href": baseURL + child.Links.WebUI}}},
+					}},
+				}},
+			})
+		}
+		doc.Content = append(doc.Content, list)
+	}
+
+	result, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
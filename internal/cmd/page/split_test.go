@@ -0,0 +1,245 @@
+package page
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+const testSplitStorage = `<p>Intro</p>` +
+	`<h2>Setup</h2><p>Install it.</p><ac:image><ri:attachment ri:filename="setup.png" /></ac:image>` +
+	`<h2>FAQ</h2><p>None yet.</p>`
+
+const testSplitADF = `{"type":"doc","version":1,"content":[` +
+	`{"type":"paragraph","content":[{"type":"text","text":"Intro"}]},` +
+	`{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Setup"}]},` +
+	`{"type":"mediaSingle","content":[{"type":"media","attrs":{"type":"file","id":"file-1","collection":"contentId-12345"}}]},` +
+	`{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"FAQ"}]},` +
+	`{"type":"paragraph","content":[{"type":"text","text":"None yet."}]}]}`
+
+// splitServer is a mock of page 12345 in space S1, with one attachment,
+// that records the pages created and updated.
+type splitServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	existing string                       // A title already taken in the space
+	created  []map[string]interface{}     // Create requests
+	updates  map[string][]json.RawMessage // Update requests by page ID
+	uploads  []string                     // "pageID/filename" of uploads
+}
+
+func newSplitServer(t *testing.T, editor string) *splitServer {
+	s := &splitServer{updates: map[string][]json.RawMessage{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345/properties":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"results": []map[string]interface{}{{"id": "1", "key": "editor", "value": editor}},
+			})
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345":
+			body := map[string]interface{}{"storage": map[string]string{"value": testSplitStorage}}
+			if r.URL.Query().Get("body-format") == "atlas_doc_format" {
+				body = map[string]interface{}{"atlas_doc_format": map[string]string{"value": testSplitADF}}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":      "12345",
+				"title":   "Guide",
+				"spaceId": "S1",
+				"version": map[string]int{"number": 3},
+				"body":    body,
+			})
+		case r.Method == "GET" && r.URL.Path == "/api/v2/spaces/S1/pages":
+			results := []map[string]string{}
+			if title := r.URL.Query().Get("title"); title == s.existing {
+				results = append(results, map[string]string{"id": "999", "title": title})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+		case r.Method == "GET" && r.URL.Path == "/api/v2/pages/12345/attachments":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"results": []map[string]string{
+					{"id": "att1", "title": "setup.png", "fileId": "file-1"},
+					{"id": "att2", "title": "unused.png", "fileId": "file-2"},
+				},
+			})
+		case r.Method == "GET" && r.URL.Path == "/api/v2/attachments/att1":
+			w.Write([]byte(`{"id": "att1", "title": "setup.png", "downloadLink": "/download/attachments/12345/setup.png"}`))
+		case r.Method == "GET" && r.URL.Path == "/download/attachments/12345/setup.png":
+			w.Write([]byte("png"))
+		case r.Method == "POST" && r.URL.Path == "/api/v2/pages":
+			var req map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&req)
+			s.created = append(s.created, req)
+			id := "c" + string(rune('0'+len(s.created)))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":      id,
+				"title":   req["title"],
+				"version": map[string]int{"number": 1},
+				"_links":  map[string]string{"webui": "/pages/" + id},
+			})
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/child/attachment"):
+			r.ParseMultipartForm(1 << 20)
+			pageID := strings.Split(r.URL.Path, "/")[4]
+			_, header, _ := r.FormFile("file")
+			s.uploads = append(s.uploads, pageID+"/"+header.Filename)
+			w.Write([]byte(`{"results": [{"id": "att9", "title": "setup.png", "extensions": {"fileId": "file-new"}}]}`))
+		case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/api/v2/pages/"):
+			id := strings.TrimPrefix(r.URL.Path, "/api/v2/pages/")
+			data, _ := io.ReadAll(r.Body)
+			s.updates[id] = append(s.updates[id], data)
+			w.Write([]byte(`{"id": "` + id + `", "title": "t", "version": {"number": 2}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+// updatedBody returns the body value of the nth update of a page.
+func (s *splitServer) updatedBody(t *testing.T, pageID string, n int, legacy bool) string {
+	require.Greater(t, len(s.updates[pageID]), n)
+	var req api.UpdatePageRequest
+	require.NoError(t, json.Unmarshal(s.updates[pageID][n], &req))
+	if legacy {
+		return req.Body.Storage.Value
+	}
+	return req.Body.AtlasDocFormat.Value
+}
+
+func TestRunSplit_Legacy(t *testing.T) {
+	server := newSplitServer(t, api.EditorLegacy)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	var stdout bytes.Buffer
+	err := runSplit("12345", &splitOptions{by: "h2", output: "json", stdout: &stdout}, client)
+	require.NoError(t, err)
+
+	require.Len(t, server.created, 2)
+	assert.Equal(t, "Setup", server.created[0]["title"])
+	assert.Equal(t, "12345", server.created[0]["parentId"])
+	assert.Equal(t, "S1", server.created[0]["spaceId"])
+	body := server.created[0]["body"].(map[string]interface{})["storage"].(map[string]interface{})
+	assert.Equal(t, `<p>Install it.</p><ac:image><ri:attachment ri:filename="setup.png" /></ac:image>`, body["value"])
+	assert.Equal(t, "FAQ", server.created[1]["title"])
+
+	// Attachments are copied to the section that shows them; storage refers
+	// to them by filename, so the child isn't updated
+	assert.Equal(t, []string{"c1/setup.png"}, server.uploads)
+	assert.Empty(t, server.updates["c1"])
+
+	assert.Equal(t, `<p>Intro</p><ul>`+
+		`<li><ac:link><ri:page ri:content-title="Setup" /></ac:link></li>`+
+		`<li><ac:link><ri:page ri:content-title="FAQ" /></ac:link></li></ul>`,
+		server.updatedBody(t, "12345", 0, true))
+
+	var result splitResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	assert.Equal(t, "12345", result.PageID)
+	require.Len(t, result.Children, 2)
+	assert.Equal(t, splitChild{ID: "c1", Title: "Setup", Attachments: []string{"setup.png"}, URL: server.URL + "/pages/c1"}, result.Children[0])
+}
+
+func TestRunSplit_ADF(t *testing.T) {
+	server := newSplitServer(t, api.EditorCloud)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runSplit("12345", &splitOptions{by: "H2", stdout: io.Discard}, client)
+	require.NoError(t, err)
+
+	require.Len(t, server.created, 2)
+	assert.Equal(t, []string{"c1/setup.png"}, server.uploads)
+
+	// The child's media are pointed at the copied file
+	child := server.updatedBody(t, "c1", 0, false)
+	assert.Contains(t, child, `"collection":"contentId-c1","id":"file-new"`)
+
+	parent := server.updatedBody(t, "12345", 0, false)
+	assert.JSONEq(t, `{"type":"doc","version":1,"content":[`+
+		`{"type":"paragraph","content":[{"type":"text","text":"Intro"}]},`+
+		`{"type":"bulletList","content":[`+
+		`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"Setup","marks":[{"type":"link","attrs":{"href":"`+server.URL+`/pages/c1"}}]}]}]},`+
+		`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"FAQ","marks":[{"type":"link","attrs":{"href":"`+server.URL+`/pages/c2"}}]}]}]}]}]}`,
+		parent)
+}
+
+func TestRunSplit_ChildrenMacro(t *testing.T) {
+	server := newSplitServer(t, api.EditorLegacy)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runSplit("12345", &splitOptions{childrenMacro: true, stdout: io.Discard}, client)
+	require.NoError(t, err)
+
+	assert.Equal(t, `<p>Intro</p><ac:structured-macro ac:name="children" ac:schema-version="2" />`,
+		server.updatedBody(t, "12345", 0, true))
+}
+
+func TestRunSplit_DryRun(t *testing.T) {
+	server := newSplitServer(t, api.EditorLegacy)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	var stdout bytes.Buffer
+	err := runSplit("12345", &splitOptions{dryRun: true, stdout: &stdout}, client)
+	require.NoError(t, err)
+
+	assert.Empty(t, server.created)
+	assert.Empty(t, server.uploads)
+	assert.Empty(t, server.updates)
+	assert.Contains(t, stdout.String(), "Setup")
+	assert.Contains(t, stdout.String(), "setup.png")
+	assert.Contains(t, stdout.String(), "FAQ")
+}
+
+func TestRunSplit_TitleTaken(t *testing.T) {
+	server := newSplitServer(t, api.EditorLegacy)
+	server.existing = "FAQ"
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runSplit("12345", &splitOptions{stdout: io.Discard}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `a page titled "FAQ" already exists`)
+	assert.Empty(t, server.created)
+	assert.Empty(t, server.updates)
+}
+
+func TestRunSplit_Errors(t *testing.T) {
+	server := newSplitServer(t, api.EditorLegacy)
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	tests := []struct {
+		name    string
+		opts    *splitOptions
+		wantErr string
+	}{
+		{name: "invalid level", opts: &splitOptions{by: "h7"}, wantErr: `invalid --by "h7"`},
+		{name: "not a heading", opts: &splitOptions{by: "section"}, wantErr: `invalid --by "section"`},
+		{name: "no headings", opts: &splitOptions{by: "h4"}, wantErr: "page 12345 has no h4 headings to split at"},
+		{name: "invalid output", opts: &splitOptions{output: "xml"}, wantErr: "invalid output format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runSplit("12345", tt.opts, client)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+	assert.Empty(t, server.created)
+}
@@ -0,0 +1,898 @@
+package page
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/mermaid"
+	"github.com/open-cli-collective/confluence-cli/internal/plantuml"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// Sync actions reported for each path.
+const (
+	syncCreate    = "create"
+	syncUpdate    = "update"
+	syncUnchanged = "unchanged"
+	syncConflict  = "conflict"
+	syncEditor    = "editor-mismatch"
+	syncDelete    = "delete"
+	syncArchive   = "archive"
+	syncOrphaned  = "orphaned"
+	syncSkipped   = "skipped" // Under a page that was skipped
+)
+
+type syncOptions struct {
+	dir       string
+	space     string
+	parent    string
+	stateFile string
+	dryRun    bool
+	delete    bool // Delete pages whose files were removed
+	prune     bool // Archive pages whose files were removed
+	force     bool // Skip the deletion or archive confirmation prompt
+	overwrite bool // Overwrite pages changed in Confluence since the last sync
+	watch     bool // Sync again whenever a file changes
+	legacy    bool
+	output    string
+	noColor   bool
+	stdin     io.Reader // For testing; defaults to os.Stdin
+
+	diagramMacro      string             // Viewer macro for .drawio references
+	plantuml          *plantuml.Renderer // Renders plantuml fences; nil leaves them as code
+	forceEditorSwitch bool               // Update pages in the other editor's format, converting them
+	mermaid           string             // How mermaid fences are published: "" (code), macro or image
+	mermaidMacro      string             // Macro for --mermaid macro; loaded from config when empty
+	mermaidImg        *mermaid.Renderer  // Renders mermaid fences for --mermaid image; built from config when nil
+	legacyAuto        bool               // --legacy wasn't given, so the space decides the editor format
+	legacySpaces      []string           // Spaces published in legacy format by default; loaded from config
+	jira              config.JiraConfig  // Jira issue linking; loaded from config
+
+	classification config.ClassificationConfig // Required classification labels; loaded from config
+	images         imageOptions                // Optimization of local images; completed from config
+}
+
+// syncItem is a page to publish for a file or directory.
+type syncItem struct {
+	key    string // Slash-separated path relative to the directory; directories end in "/"
+	parent string // Key of the parent directory item, "" at the top level
+	file   string // Markdown file with the page content; "" for a directory without index.md
+	title  string
+	labels []string // Labels from the file's front matter
+}
+
+// syncResult is the outcome for one synced path.
+type syncResult struct {
+	Action string `json:"action"`
+	Path   string `json:"path"`
+	Title  string `json:"title"`
+	ID     string `json:"id,omitempty"`
+}
+
+// NewCmdSync creates the page sync command.
+func NewCmdSync() *cobra.Command {
+	opts := &syncOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "sync <directory>",
+		Short: "Publish a directory of markdown files as pages",
+		Long: `Mirror a directory of markdown files into a Confluence space.
+
+Each .md file becomes a page and each subdirectory becomes a page whose
+children are the files inside it. A subdirectory's index.md provides its
+page content; without one the page is left empty. Pages are titled by the
+title in the file's front matter, or else by the file or directory name,
+and get the labels listed there.
+
+Page IDs and content hashes are kept in a state file (.cfl-sync.json in the
+directory by default), so later runs only push files that changed and need
+no --space or --parent. Hidden files and directories are skipped.
+
+Pages edited in Confluence since the last sync are reported as conflicts and
+left alone unless --overwrite is given. So is a page that isn't in the
+state but already has a file's title under the same parent, as sync didn't
+create it; with --overwrite it is taken over and updated.
+
+Pages using a different editor than the one content is published for
+(cloud by default, legacy with --legacy or for spaces listed under
+legacy_spaces in the config file) are skipped, since updating them
+would convert them; --force-editor-switch converts them anyway.
+
+Mermaid code blocks are published as code unless --mermaid is given, as for
+'cfl page create'. When the config file lists classification labels, every
+page published must carry one, as for 'cfl page create'.
+
+Pages published from files that were since removed are reported as
+orphaned. --prune moves them to the space archive, where they can be
+restored, and --delete deletes them; both ask for confirmation (skip with
+--force). Combine either with --dry-run to list the pages it would remove.
+
+With --watch, the directory is synced and then synced again every time a
+file in it changes, until Ctrl-C, so only the pages of saved files are
+updated. A failed sync is reported and watching goes on. --watch can't be
+combined with --delete or --prune.
+
+'cfl sync state show|repair|migrate' inspects the state file and repairs it
+when pages were deleted or copied in Confluence. The command is also
+available as 'cfl sync'.`,
+		Example: `  # Preview what would change
+  cfl page sync ./docs --space DOCS --dry-run
+
+  # Publish under a parent page
+  cfl page sync ./docs --space DOCS --parent 12345
+
+  # Later runs reuse the space and parent from the state file
+  cfl page sync ./docs
+
+  # List the pages that would be archived for removed files
+  cfl page sync ./docs --prune --dry-run
+
+  # Archive pages for removed files
+  cfl page sync ./docs --prune
+
+  # Delete them instead
+  cfl page sync ./docs --delete
+
+  # Keep publishing while editing
+  cfl page sync ./docs --watch`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.dir = args[0]
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.stdin = os.Stdin
+			opts.legacyAuto = !cmd.Flags().Changed("legacy")
+			opts.images.optimizeSet = cmd.Flags().Changed("optimize")
+			if opts.watch {
+				return runSyncWatch(opts)
+			}
+			return runSync(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key (default: from state file or config)")
+	cmd.Flags().StringVarP(&opts.parent, "parent", "p", "", "Parent page for top-level pages (ID, URL, or SPACE/Title)")
+	cmd.Flags().StringVar(&opts.stateFile, "state", "", "State file path (default: <directory>/"+defaultSyncStateFile+")")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would change without publishing")
+	cmd.Flags().BoolVar(&opts.delete, "delete", false, "Delete pages whose files were removed")
+	cmd.Flags().BoolVar(&opts.prune, "prune", false, "Archive pages whose files were removed")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip the deletion or archive confirmation prompt")
+	cmd.Flags().BoolVar(&opts.overwrite, "overwrite", false, "Overwrite pages edited in Confluence since the last sync")
+	cmd.Flags().BoolVar(&opts.watch, "watch", false, "Sync again whenever a file changes, until Ctrl-C")
+	cmd.Flags().BoolVar(&opts.legacy, "legacy", false, "Publish in legacy editor format (default: cloud editor)")
+	cmd.Flags().BoolVar(&opts.forceEditorSwitch, "force-editor-switch", false, "Allow converting pages to the other editor")
+	cmd.Flags().StringVar(&opts.diagramMacro, "diagram-macro", md.DiagramMacroDrawio, "Macro for embedded .drawio files: drawio or gliffy")
+	cmd.Flags().StringVar(&opts.mermaid, "mermaid", "", "Publish mermaid code blocks as a macro or rendered image: macro or image")
+	addImageFlags(cmd, &opts.images)
+
+	cmd.AddCommand(NewCmdSyncState())
+
+	return cmd
+}
+
+// runSyncWatch syncs the directory, then again whenever a file in it
+// changes.
+func runSyncWatch(opts *syncOptions) error {
+	if opts.delete || opts.prune {
+		return fmt.Errorf("--watch is incompatible with --delete and --prune")
+	}
+	return watchUntilInterrupted(newFileWatcher(opts.dir, dirSnapshot(opts.dir), func() error {
+		return runSync(opts, nil)
+	}))
+}
+
+func runSync(opts *syncOptions, client *api.Client) error {
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.delete && opts.prune {
+		return fmt.Errorf("--delete is incompatible with --prune")
+	}
+	if opts.diagramMacro == "" {
+		opts.diagramMacro = md.DiagramMacroDrawio
+	}
+	if err := validateDiagramMacro(opts.diagramMacro); err != nil {
+		return err
+	}
+	if err := validateMermaid(opts.mermaid, opts.legacy || opts.legacyAuto); err != nil {
+		return err
+	}
+	if err := opts.images.validate(); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(opts.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", opts.dir)
+	}
+
+	statePath := opts.stateFile
+	if statePath == "" {
+		statePath = filepath.Join(opts.dir, defaultSyncStateFile)
+	}
+	state, err := loadSyncState(statePath)
+	if err != nil {
+		return err
+	}
+
+	spaceKey := opts.space
+	if spaceKey == "" {
+		spaceKey = state.Space
+	}
+	parentID := opts.parent
+	if parentID == "" {
+		parentID = state.Parent
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if spaceKey == "" {
+			spaceKey = cfg.DefaultSpace
+		}
+
+		if opts.plantuml == nil {
+			opts.plantuml = plantuml.New(cfg.PlantUML.Jar, cfg.PlantUML.Server, config.DiagramCacheDir("plantuml"))
+		}
+		if opts.mermaidImg == nil {
+			opts.mermaidImg = mermaid.New(cfg.Mermaid.Mmdc, cfg.Mermaid.Format, config.DiagramCacheDir("mermaid"))
+		}
+		if opts.mermaidMacro == "" {
+			opts.mermaidMacro = cfg.Mermaid.Macro
+		}
+		opts.legacySpaces = cfg.LegacySpaces
+		opts.jira = cfg.Jira
+		opts.classification = cfg.Classification
+		opts.images = opts.images.withConfig(cfg.Images)
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	if parentID != "" {
+		resolved, err := pageref.Resolve(context.Background(), client, parentID)
+		if err != nil {
+			return err
+		}
+		parentID = resolved
+	}
+
+	if spaceKey == "" {
+		return fmt.Errorf("space is required: use --space flag or set default_space in config")
+	}
+	if state.Space != "" && !strings.EqualFold(state.Space, spaceKey) {
+		return fmt.Errorf("%s was synced to space %s; use a different --state file to sync to %s", opts.dir, state.Space, spaceKey)
+	}
+	if state.Parent != "" && parentID != state.Parent {
+		return fmt.Errorf("%s was synced under parent page %s; use a different --state file to sync under %s", opts.dir, state.Parent, parentID)
+	}
+	if opts.legacyAuto {
+		opts.legacy = config.IsLegacySpace(opts.legacySpaces, spaceKey)
+		if err := validateMermaid(opts.mermaid, opts.legacy); err != nil {
+			return err
+		}
+	}
+
+	items, err := scanSyncDir(opts.dir)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	state.Space = space.Key
+	if state.Space == "" {
+		state.Space = spaceKey
+	}
+	state.Parent = parentID
+
+	s := &syncer{ctx: ctx, client: client, opts: opts, state: state, spaceID: space.ID, parentID: parentID}
+	results, syncErr := s.sync(items)
+
+	if !opts.dryRun {
+		if err := state.save(statePath); err != nil {
+			return err
+		}
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if err := renderSyncResults(renderer, opts, results); err != nil {
+		return err
+	}
+	return syncErr
+}
+
+// syncer publishes sync items and records the outcome in the state.
+type syncer struct {
+	ctx      context.Context
+	client   *api.Client
+	opts     *syncOptions
+	state    *syncState
+	spaceID  string
+	parentID string
+	created  map[string]bool // IDs of the pages this run created
+	skipped  map[string]bool // Items with no page to publish their children under
+}
+
+// preparedItem is an item's content, ready to publish.
+type preparedItem struct {
+	content   string
+	files     []localFile
+	hash      string
+	converted string // Content converted for publishing, when ready is set
+	ready     bool
+}
+
+// sync publishes every item, then handles pages whose files were removed.
+// Results gathered before an error are returned with it.
+func (s *syncer) sync(items []syncItem) ([]syncResult, error) {
+	prepared, err := s.prepareAll(items)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []syncResult
+	seen := make(map[string]bool)
+	for i, item := range items {
+		seen[item.key] = true
+		result, err := s.syncItem(item, prepared[i])
+		if err != nil {
+			return results, fmt.Errorf("failed to sync %s: %w", item.key, err)
+		}
+		results = append(results, result)
+	}
+
+	removed, err := s.removed(seen)
+	results = append(results, removed...)
+	return results, err
+}
+
+// prepareAll prepares every item, then converts the changed ones that
+// reference no local files on a pool of workers. The others are converted
+// once their files are uploaded, since the content embeds the attachments.
+func (s *syncer) prepareAll(items []syncItem) ([]preparedItem, error) {
+	prepared := make([]preparedItem, len(items))
+	var inputs []md.BatchInput
+	var pending []int
+	for i, item := range items {
+		content, files, hash, err := s.prepare(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sync %s: %w", item.key, err)
+		}
+		prepared[i] = preparedItem{content: content, files: files, hash: hash}
+
+		synced := s.state.Pages[item.key]
+		if len(files) == 0 && (synced == nil || synced.Hash != hash || synced.Title != item.title) {
+			inputs = append(inputs, md.BatchInput{Name: item.key, Content: []byte(content)})
+			pending = append(pending, i)
+		}
+	}
+
+	results, err := md.ConvertBatch(s.ctx, inputs, md.BatchOptions{
+		Convert: func(content []byte) (string, error) {
+			return convertEditContent(string(content), true, s.conversion(md.EmbedOptions{}))
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range pending {
+		prepared[i].converted = results[j].Output
+		prepared[i].ready = true
+	}
+	return prepared, nil
+}
+
+// conversion returns how sync converts content that embeds the given files.
+func (s *syncer) conversion(embeds md.EmbedOptions) conversion {
+	return conversion{legacy: s.opts.legacy, embeds: embeds, mermaid: s.opts.mermaid, mermaidMacro: s.opts.mermaidMacro}
+}
+
+// syncItem creates or updates the page for one item.
+func (s *syncer) syncItem(item syncItem, p preparedItem) (syncResult, error) {
+	result := syncResult{Path: item.key, Title: item.title}
+	if item.parent != "" && s.skipped[item.parent] {
+		s.skip(item.key)
+		result.Action = syncSkipped
+		return result, nil
+	}
+
+	var err error
+	synced := s.state.Pages[item.key]
+	if synced == nil {
+		// A page this run created without recording it is updated rather
+		// than created again; any other page with the title is someone
+		// else's, and is only replaced with --overwrite
+		if synced, err = s.findUnrecorded(item); err != nil {
+			return result, err
+		}
+		if synced != nil && !s.created[synced.ID] && !s.opts.overwrite {
+			s.skip(item.key)
+			result.ID = synced.ID
+			result.Action = syncConflict
+			return result, nil
+		}
+		if synced != nil {
+			s.state.Pages[item.key] = synced
+		}
+	}
+	if synced == nil {
+		result.Action = syncCreate
+		labels, err := classify(s.opts.classification, item.labels)
+		if err != nil {
+			return result, err
+		}
+		if s.opts.dryRun {
+			return result, nil
+		}
+		page, err := s.create(item, p)
+		if err != nil {
+			return result, err
+		}
+		result.ID = page.ID
+		s.state.Pages[item.key] = &syncedPage{ID: page.ID, Title: item.title, Hash: p.hash, Version: pageVersion(page)}
+		return result, s.addLabels(page.ID, labels)
+	}
+
+	result.ID = synced.ID
+	if synced.Hash == p.hash && synced.Title == item.title {
+		result.Action = syncUnchanged
+		return result, nil
+	}
+
+	current, err := s.client.GetPage(s.ctx, synced.ID, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get page %s: %w", synced.ID, err)
+	}
+	if pageVersion(current) > synced.Version && !s.opts.overwrite {
+		result.Action = syncConflict
+		return result, nil
+	}
+	if switchFrom, err := editorSwitch(s.ctx, s.client, synced.ID, s.opts.legacy); err != nil {
+		return result, err
+	} else if switchFrom != "" && !s.opts.forceEditorSwitch {
+		result.Action = syncEditor
+		return result, nil
+	}
+
+	labels, err := classifyExisting(s.ctx, s.client, s.opts.classification, synced.ID, item.labels)
+	if err != nil {
+		return result, err
+	}
+
+	result.Action = syncUpdate
+	if s.opts.dryRun {
+		return result, nil
+	}
+
+	converted := p.converted
+	if !p.ready {
+		embeds, err := uploadLocalFiles(s.ctx, s.client, synced.ID, p.files, s.opts.diagramMacro, s.opts.images)
+		if err != nil {
+			return result, err
+		}
+		if converted, err = convertEditContent(p.content, true, s.conversion(embeds)); err != nil {
+			return result, err
+		}
+	}
+	page, err := s.client.UpdatePage(s.ctx, synced.ID, &api.UpdatePageRequest{
+		ID:     synced.ID,
+		Status: "current",
+		Title:  item.title,
+		Body:   newEditBody(converted, s.opts.legacy),
+		Version: &api.Version{
+			Number:  pageVersion(current) + 1,
+			Message: "Synced via cfl",
+		},
+	})
+	if err != nil {
+		err = checkADFSupport(s.ctx, s.client, err, s.opts.legacy)
+		return result, fmt.Errorf("failed to update page: %w", err)
+	}
+
+	*synced = syncedPage{ID: synced.ID, Title: item.title, Hash: p.hash, Version: pageVersion(page)}
+	return result, s.addLabels(synced.ID, labels)
+}
+
+// skip records that an item has no page its children can be published
+// under, so they are skipped too.
+func (s *syncer) skip(key string) {
+	if s.skipped == nil {
+		s.skipped = make(map[string]bool)
+	}
+	s.skipped[key] = true
+}
+
+// addLabels adds an item's labels to its page.
+func (s *syncer) addLabels(pageID string, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	if _, err := s.client.AddLabels(s.ctx, pageID, labels); err != nil {
+		return fmt.Errorf("failed to add labels to page %s: %w", pageID, err)
+	}
+	return nil
+}
+
+// itemParentID returns the ID of the page an item is published under, or
+// "" for the top of the space or a parent not yet created.
+func (s *syncer) itemParentID(item syncItem) string {
+	if item.parent != "" {
+		if parent := s.state.Pages[item.parent]; parent != nil {
+			return parent.ID
+		}
+		return ""
+	}
+	return s.parentID
+}
+
+// findUnrecorded looks for a page with the item's title under its parent
+// that isn't in the state: one this run created but failed to record, or
+// a page someone else wrote. Titles are unique within a space, so creating
+// the item would fail anyway.
+func (s *syncer) findUnrecorded(item syncItem) (*syncedPage, error) {
+	parentID := s.itemParentID(item)
+	if item.parent != "" && parentID == "" {
+		// The parent directory hasn't been created yet (dry run)
+		return nil, nil
+	}
+
+	page, err := s.client.FindPageByTitle(s.ctx, s.spaceID, item.title)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up page %q: %w", item.title, err)
+	}
+	if page == nil || (parentID != "" && page.ParentID != parentID) {
+		return nil, nil
+	}
+	return &syncedPage{ID: page.ID, Title: page.Title, Version: pageVersion(page)}, nil
+}
+
+// create publishes a new page under the item's parent.
+func (s *syncer) create(item syncItem, p preparedItem) (*api.Page, error) {
+	parentID := s.itemParentID(item)
+
+	converted := p.converted
+	if !p.ready {
+		var err error
+		converted, err = convertEditContent(p.content, true, s.conversion(plannedEmbeds(p.files, s.opts.diagramMacro)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	page, err := s.client.CreatePage(s.ctx, &api.CreatePageRequest{
+		SpaceID:  s.spaceID,
+		Title:    item.title,
+		Status:   "current",
+		ParentID: parentID,
+		Body:     newEditBody(converted, s.opts.legacy),
+	})
+	if err != nil {
+		err = checkADFSupport(s.ctx, s.client, err, s.opts.legacy)
+		return nil, fmt.Errorf("failed to create page: %w", err)
+	}
+	if s.created == nil {
+		s.created = make(map[string]bool)
+	}
+	s.created[page.ID] = true
+
+	if len(p.files) > 0 {
+		return attachLocalFiles(s.client, page, p.content, p.files, s.opts.legacy, s.opts.diagramMacro, s.opts.images)
+	}
+	return page, nil
+}
+
+// prepare reads an item's markdown and renders it for publishing. The hash
+// covers the content and every referenced file, so changing an image
+// republishes the page.
+func (s *syncer) prepare(item syncItem) (content string, files []localFile, hash string, err error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00", item.title)
+	if s.opts.mermaid == mermaidAsMacro {
+		// The markdown is unchanged but publishes differently, so switching republishes
+		fmt.Fprintf(h, "mermaid=%s:%s\x00", s.opts.mermaid, s.opts.mermaidMacro)
+	}
+
+	if item.file == "" {
+		return "", nil, hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	data, err := os.ReadFile(item.file)
+	if err != nil {
+		return "", nil, "", err
+	}
+	content = expandTOC(string(data), true, item.title, s.opts.legacy)
+	content = linkJira(content, true, s.opts.legacy, s.opts.jira, s.client.BaseURL())
+	content = qualifyPageLinks(content, true, s.opts.legacy, s.state.Space)
+	content, err = renderDiagrams(s.opts.plantuml, mermaidImages(s.opts.mermaid, s.opts.mermaidImg), content, true)
+	if err != nil {
+		return "", nil, "", err
+	}
+	files, err = findLocalFiles(content, true, filepath.Dir(item.file))
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	h.Write([]byte(content))
+	for _, f := range files {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			return "", nil, "", err
+		}
+		fmt.Fprintf(h, "\x00%s\x00", f.filename)
+		h.Write(data)
+	}
+	return content, files, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// removed handles synced pages whose files no longer exist. They are
+// deleted with --delete or archived with --prune, children before parents,
+// and reported as orphaned otherwise.
+func (s *syncer) removed(seen map[string]bool) ([]syncResult, error) {
+	var keys []string
+	for key := range s.state.Pages {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+
+	action := syncOrphaned
+	switch {
+	case s.opts.delete:
+		action = syncDelete
+	case s.opts.prune:
+		action = syncArchive
+	}
+	if action != syncOrphaned && len(keys) > 0 && !s.opts.dryRun && !s.opts.force && !s.confirmRemove(action, keys) {
+		action = syncOrphaned
+	}
+
+	var results []syncResult
+	for _, key := range keys {
+		p := s.state.Pages[key]
+		results = append(results, syncResult{Action: action, Path: key, Title: p.Title, ID: p.ID})
+		if action == syncOrphaned || s.opts.dryRun {
+			continue
+		}
+
+		var err error
+		if action == syncDelete {
+			err = s.client.DeletePage(s.ctx, p.ID)
+		} else {
+			err = s.archive(p.ID)
+		}
+		// A page already gone from Confluence needs no cleanup
+		if err != nil && !isNotFound(err) {
+			return results, fmt.Errorf("failed to %s page %s (%s): %w", action, p.ID, key, err)
+		}
+		delete(s.state.Pages, key)
+	}
+	return results, nil
+}
+
+// archive archives a page, waiting for the archive task so a failure is
+// reported before the page is dropped from the sync state.
+func (s *syncer) archive(pageID string) error {
+	taskID, err := s.client.ArchivePages(s.ctx, pageID)
+	if err != nil || taskID == "" {
+		return err
+	}
+	_, err = s.client.WaitForTask(s.ctx, taskID, nil)
+	return err
+}
+
+// confirmRemove lists the pages to delete or archive and asks for
+// confirmation.
+func (s *syncer) confirmRemove(action string, keys []string) bool {
+	fmt.Printf("About to %s %d page(s) whose files were removed:\n", action, len(keys))
+	for _, key := range keys {
+		p := s.state.Pages[key]
+		fmt.Printf("  %s (ID: %s) from %s\n", p.Title, p.ID, key)
+	}
+	fmt.Print("Are you sure? [y/N]: ")
+
+	stdin := s.opts.stdin
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+	scanner := bufio.NewScanner(stdin)
+	var confirm string
+	if scanner.Scan() {
+		confirm = scanner.Text()
+	}
+	return strings.EqualFold(strings.TrimSpace(confirm), "y")
+}
+
+// scanSyncDir lists the pages to publish for a directory, parents before
+// their children. Hidden files and directories are skipped, as are
+// directories without markdown files.
+func scanSyncDir(dir string) ([]syncItem, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(p), ".md") {
+			rel, err := filepath.Rel(dir, p)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan directory: %w", err)
+	}
+
+	items := make(map[string]*syncItem)
+	for _, rel := range files {
+		parent := path.Dir(rel)
+		if parent == "." {
+			parent = ""
+		} else {
+			parent += "/"
+		}
+
+		// A subdirectory's index.md is the directory page's content
+		if parent != "" && strings.EqualFold(path.Base(rel), "index.md") {
+			addSyncDirs(items, parent)
+			items[parent].file = filepath.Join(dir, filepath.FromSlash(rel))
+			continue
+		}
+
+		if parent != "" {
+			addSyncDirs(items, parent)
+		}
+		items[rel] = &syncItem{
+			key:    rel,
+			parent: parent,
+			file:   filepath.Join(dir, filepath.FromSlash(rel)),
+			title:  strings.TrimSuffix(path.Base(rel), path.Ext(rel)),
+		}
+	}
+
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]syncItem, 0, len(keys))
+	for _, key := range keys {
+		item := items[key]
+		if item.file != "" {
+			data, err := os.ReadFile(item.file)
+			if err != nil {
+				return nil, err
+			}
+			fm, _, err := md.ParseFrontMatter(data)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			if fm != nil && fm.Title != "" {
+				item.title = fm.Title
+			}
+			if fm != nil {
+				item.labels = fm.Labels
+			}
+		}
+		result = append(result, *item)
+	}
+	return result, nil
+}
+
+// addSyncDirs adds items for a directory key and its ancestors.
+func addSyncDirs(items map[string]*syncItem, key string) {
+	for key != "" {
+		if _, ok := items[key]; ok {
+			return
+		}
+		parent := path.Dir(strings.TrimSuffix(key, "/"))
+		if parent == "." {
+			parent = ""
+		} else {
+			parent += "/"
+		}
+		items[key] = &syncItem{key: key, parent: parent, title: path.Base(key)}
+		key = parent
+	}
+}
+
+// renderSyncResults prints the outcome of a sync.
+func renderSyncResults(renderer *view.Renderer, opts *syncOptions, results []syncResult) error {
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(results)
+	}
+
+	counts := make(map[string]int)
+	var rows [][]string
+	for _, r := range results {
+		counts[r.Action]++
+		if r.Action == syncUnchanged {
+			continue
+		}
+		rows = append(rows, []string{r.Action, r.Path, r.Title, r.ID})
+	}
+	if len(rows) > 0 {
+		renderer.RenderTable([]string{"ACTION", "PATH", "TITLE", "ID"}, rows)
+	}
+
+	if counts[syncConflict] > 0 {
+		renderer.Warning(fmt.Sprintf("%d page(s) were edited in Confluence since the last sync, or have the title of a page sync didn't create, and were skipped; use --overwrite to replace them", counts[syncConflict]))
+	}
+	if counts[syncSkipped] > 0 {
+		renderer.Warning(fmt.Sprintf("%d page(s) under a skipped page were skipped too", counts[syncSkipped]))
+	}
+	if counts[syncEditor] > 0 {
+		renderer.Warning(fmt.Sprintf("%d page(s) use the other editor and were skipped, since publishing would convert them; use --force-editor-switch to convert them", counts[syncEditor]))
+	}
+	if counts[syncOrphaned] > 0 {
+		renderer.Warning(fmt.Sprintf("%d page(s) no longer have a file; use --prune to archive or --delete to delete them", counts[syncOrphaned]))
+	}
+
+	summary := fmt.Sprintf("%d created, %d updated, %d unchanged, %d deleted",
+		counts[syncCreate], counts[syncUpdate], counts[syncUnchanged], counts[syncDelete])
+	if counts[syncArchive] > 0 {
+		summary += fmt.Sprintf(", %d archived", counts[syncArchive])
+	}
+	if opts.dryRun {
+		renderer.RenderText("Dry run: " + summary)
+		return nil
+	}
+	renderer.Success("Synced " + opts.dir + ": " + summary)
+	return nil
+}
+
+// pageVersion returns a page's version number, or 0 if unknown.
+func pageVersion(page *api.Page) int {
+	if page == nil || page.Version == nil {
+		return 0
+	}
+	return page.Version.Number
+}
+
+// isNotFound reports whether a request failed because the content does not exist.
+func isNotFound(err error) bool {
+	var apiErr *api.ErrorResponse
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
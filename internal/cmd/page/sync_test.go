@@ -0,0 +1,435 @@
+package page
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+// fakeSyncPage is a page held by fakeConfluence.
+type fakeSyncPage struct {
+	ID       string
+	Title    string
+	ParentID string
+	Version  int
+	Body     string
+}
+
+// fakeConfluence is an in-memory Confluence space for sync tests.
+type fakeConfluence struct {
+	mu      sync.Mutex
+	pages   map[string]*fakeSyncPage
+	editors map[string]string   // Page ID to the editor content property
+	labels  map[string][]string // Page ID to its labels
+	nextID  int
+	writes  []string // "METHOD title" for each write request
+}
+
+func newFakeConfluence(t *testing.T) (*fakeConfluence, *api.Client) {
+	f := &fakeConfluence{pages: make(map[string]*fakeSyncPage), labels: make(map[string][]string), nextID: 100}
+	server := httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(server.Close)
+	return f, api.NewClient(server.URL, "test@example.com", "token")
+}
+
+func (f *fakeConfluence) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/v2/pages/")
+	switch {
+	case r.URL.Path == "/api/v2/spaces":
+		w.Write([]byte(`{"results": [{"id": "777", "key": "DOCS"}]}`))
+	case r.URL.Path == "/api/v2/spaces/777/pages":
+		var results []string
+		for _, p := range f.pages {
+			if p.Title == r.URL.Query().Get("title") {
+				results = append(results, fmt.Sprintf(`{"id": %q, "title": %q, "parentId": %q, "version": {"number": %d}}`, p.ID, p.Title, p.ParentID, p.Version))
+			}
+		}
+		fmt.Fprintf(w, `{"results": [%s]}`, strings.Join(results, ","))
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/pages":
+		var req api.CreatePageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		f.nextID++
+		p := &fakeSyncPage{ID: fmt.Sprint(f.nextID), Title: req.Title, ParentID: req.ParentID, Version: 1, Body: requestBody(req.Body)}
+		f.pages[p.ID] = p
+		f.writes = append(f.writes, "POST "+p.Title)
+		f.writePage(w, p)
+	case r.Method == http.MethodGet && strings.HasSuffix(id, "/properties"):
+		if editor := f.editors[strings.TrimSuffix(id, "/properties")]; editor != "" {
+			fmt.Fprintf(w, `{"results": [{"key": "editor", "value": %q}]}`, editor)
+			return
+		}
+		w.Write([]byte(`{"results": []}`))
+	case r.Method == http.MethodGet && strings.HasSuffix(id, "/labels"):
+		var results []string
+		for _, l := range f.labels[strings.TrimSuffix(id, "/labels")] {
+			results = append(results, fmt.Sprintf(`{"name": %q}`, l))
+		}
+		fmt.Fprintf(w, `{"results": [%s]}`, strings.Join(results, ","))
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/rest/api/content/") && strings.HasSuffix(r.URL.Path, "/label"):
+		pageID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/rest/api/content/"), "/label")
+		var req []map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, l := range req {
+			f.labels[pageID] = append(f.labels[pageID], l["name"])
+		}
+		w.Write([]byte(`{"results": []}`))
+	case r.Method == http.MethodGet && f.pages[id] != nil:
+		f.writePage(w, f.pages[id])
+	case r.Method == http.MethodPut && f.pages[id] != nil:
+		var req api.UpdatePageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		p := f.pages[id]
+		p.Title, p.Version, p.Body = req.Title, req.Version.Number, requestBody(req.Body)
+		f.writes = append(f.writes, "PUT "+p.Title)
+		f.writePage(w, p)
+	case r.Method == http.MethodPost && r.URL.Path == "/rest/api/content/archive":
+		var req struct {
+			Pages []struct {
+				ID string `json:"id"`
+			} `json:"pages"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, p := range req.Pages {
+			if f.pages[p.ID] == nil {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"statusCode": 404, "message": "not found"}`))
+				return
+			}
+			f.writes = append(f.writes, "ARCHIVE "+f.pages[p.ID].Title)
+			delete(f.pages, p.ID)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"id": "task-1"}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/rest/api/longtask/task-1":
+		w.Write([]byte(`{"id": "task-1", "percentageComplete": 100, "finished": true, "successful": true}`))
+	case r.Method == http.MethodDelete && f.pages[id] != nil:
+		f.writes = append(f.writes, "DELETE "+f.pages[id].Title)
+		delete(f.pages, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"statusCode": 404, "message": "not found"}`))
+	}
+}
+
+func (f *fakeConfluence) writePage(w http.ResponseWriter, p *fakeSyncPage) {
+	fmt.Fprintf(w, `{"id": %q, "title": %q, "parentId": %q, "version": {"number": %d}}`, p.ID, p.Title, p.ParentID, p.Version)
+}
+
+func (f *fakeConfluence) byTitle(title string) *fakeSyncPage {
+	for _, p := range f.pages {
+		if p.Title == title {
+			return p
+		}
+	}
+	return nil
+}
+
+func (f *fakeConfluence) takeWrites() []string {
+	writes := f.writes
+	f.writes = nil
+	return writes
+}
+
+func requestBody(body *api.Body) string {
+	switch {
+	case body == nil:
+		return ""
+	case body.Storage != nil:
+		return body.Storage.Value
+	case body.AtlasDocFormat != nil:
+		return body.AtlasDocFormat.Value
+	}
+	return ""
+}
+
+func writeSyncFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+}
+
+func TestScanSyncDir(t *testing.T) {
+	dir := t.TempDir()
+	writeSyncFiles(t, dir, map[string]string{
+		"intro.md":                 "# Intro\n",
+		"guide/index.md":           "---\ntitle: User Guide\n---\nGuide home\n",
+		"guide/install.md":         "Install\n",
+		"guide/advanced/tuning.md": "Tuning\n",
+		"empty/notes.txt":          "not markdown",
+		".hidden/secret.md":        "skipped",
+	})
+
+	items, err := scanSyncDir(dir)
+	require.NoError(t, err)
+
+	var got []string
+	for _, item := range items {
+		got = append(got, fmt.Sprintf("%s|%s|%s|%t", item.key, item.parent, item.title, item.file != ""))
+	}
+	assert.Equal(t, []string{
+		"guide/||User Guide|true",
+		"guide/advanced/|guide/|advanced|false",
+		"guide/advanced/tuning.md|guide/advanced/|tuning|true",
+		"guide/install.md|guide/|install|true",
+		"intro.md||intro|true",
+	}, got)
+}
+
+func TestRunSync(t *testing.T) {
+	fake, client := newFakeConfluence(t)
+	dir := t.TempDir()
+	writeSyncFiles(t, dir, map[string]string{
+		"intro.md":         "# Welcome\n",
+		"guide/index.md":   "Guide home\n",
+		"guide/install.md": "Install steps\n",
+	})
+	opts := func() *syncOptions {
+		return &syncOptions{dir: dir, noColor: true, legacy: true}
+	}
+
+	// First run creates every page, directories before their children
+	first := opts()
+	first.space = "DOCS"
+	first.parent = "42"
+	require.NoError(t, runSync(first, client))
+	assert.Equal(t, []string{"POST guide", "POST install", "POST intro"}, fake.takeWrites())
+
+	guide := fake.byTitle("guide")
+	require.NotNil(t, guide)
+	assert.Equal(t, "42", guide.ParentID)
+	assert.Equal(t, guide.ID, fake.byTitle("install").ParentID)
+	assert.Contains(t, fake.byTitle("intro").Body, "<h1")
+
+	state, err := loadSyncState(filepath.Join(dir, defaultSyncStateFile))
+	require.NoError(t, err)
+	assert.Equal(t, "DOCS", state.Space)
+	assert.Equal(t, "42", state.Parent)
+	assert.Len(t, state.Pages, 3)
+
+	// Unchanged files are not pushed; space and parent come from the state file
+	require.NoError(t, runSync(opts(), client))
+	assert.Empty(t, fake.takeWrites())
+
+	// Changed files are updated
+	writeSyncFiles(t, dir, map[string]string{"guide/install.md": "New install steps\n"})
+	require.NoError(t, runSync(opts(), client))
+	assert.Equal(t, []string{"PUT install"}, fake.takeWrites())
+	assert.Equal(t, 2, fake.byTitle("install").Version)
+	assert.Contains(t, fake.byTitle("install").Body, "New install steps")
+}
+
+func TestRunSync_Conflict(t *testing.T) {
+	fake, client := newFakeConfluence(t)
+	dir := t.TempDir()
+	writeSyncFiles(t, dir, map[string]string{"page.md": "v1\n"})
+
+	require.NoError(t, runSync(&syncOptions{dir: dir, space: "DOCS", noColor: true, legacy: true}, client))
+	fake.takeWrites()
+
+	// Someone edits the page in Confluence, then the file changes locally
+	fake.byTitle("page").Version = 5
+	writeSyncFiles(t, dir, map[string]string{"page.md": "v2\n"})
+
+	require.NoError(t, runSync(&syncOptions{dir: dir, noColor: true, legacy: true}, client))
+	assert.Empty(t, fake.takeWrites())
+
+	require.NoError(t, runSync(&syncOptions{dir: dir, noColor: true, legacy: true, overwrite: true}, client))
+	assert.Equal(t, []string{"PUT page"}, fake.takeWrites())
+	assert.Equal(t, 6, fake.byTitle("page").Version)
+}
+
+func TestRunSync_EditorMismatch(t *testing.T) {
+	fake, client := newFakeConfluence(t)
+	fake.editors = make(map[string]string)
+	dir := t.TempDir()
+	writeSyncFiles(t, dir, map[string]string{"page.md": "v1\n"})
+
+	require.NoError(t, runSync(&syncOptions{dir: dir, space: "DOCS", noColor: true}, client))
+	fake.takeWrites()
+
+	// The page was converted to the legacy editor in Confluence
+	fake.editors[fake.byTitle("page").ID] = api.EditorLegacy
+	writeSyncFiles(t, dir, map[string]string{"page.md": "v2\n"})
+
+	require.NoError(t, runSync(&syncOptions{dir: dir, noColor: true}, client))
+	assert.Empty(t, fake.takeWrites())
+
+	// Publishing in the page's own editor format is fine
+	require.NoError(t, runSync(&syncOptions{dir: dir, noColor: true, legacy: true}, client))
+	assert.Equal(t, []string{"PUT page"}, fake.takeWrites())
+
+	writeSyncFiles(t, dir, map[string]string{"page.md": "v3\n"})
+	require.NoError(t, runSync(&syncOptions{dir: dir, noColor: true, forceEditorSwitch: true}, client))
+	assert.Equal(t, []string{"PUT page"}, fake.takeWrites())
+}
+
+func TestRunSync_RemovedFiles(t *testing.T) {
+	fake, client := newFakeConfluence(t)
+	dir := t.TempDir()
+	writeSyncFiles(t, dir, map[string]string{"keep.md": "keep\n", "old/gone.md": "gone\n"})
+
+	require.NoError(t, runSync(&syncOptions{dir: dir, space: "DOCS", noColor: true, legacy: true}, client))
+	fake.takeWrites()
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "old")))
+
+	// Without --delete pages are kept
+	require.NoError(t, runSync(&syncOptions{dir: dir, noColor: true, legacy: true}, client))
+	assert.Empty(t, fake.takeWrites())
+
+	// Declining the confirmation keeps them too
+	require.NoError(t, runSync(&syncOptions{dir: dir, noColor: true, legacy: true, delete: true, stdin: strings.NewReader("n\n")}, client))
+	assert.Empty(t, fake.takeWrites())
+
+	// Children are deleted before their parents
+	require.NoError(t, runSync(&syncOptions{dir: dir, noColor: true, legacy: true, delete: true, stdin: strings.NewReader("y\n")}, client))
+	assert.Equal(t, []string{"DELETE gone", "DELETE old"}, fake.takeWrites())
+
+	state, err := loadSyncState(filepath.Join(dir, defaultSyncStateFile))
+	require.NoError(t, err)
+	assert.Len(t, state.Pages, 1)
+	assert.Contains(t, state.Pages, "keep.md")
+}
+
+func TestRunSync_Prune(t *testing.T) {
+	fake, client := newFakeConfluence(t)
+	dir := t.TempDir()
+	writeSyncFiles(t, dir, map[string]string{"keep.md": "keep\n", "old/gone.md": "gone\n"})
+
+	require.NoError(t, runSync(&syncOptions{dir: dir, space: "DOCS", noColor: true, legacy: true}, client))
+	fake.takeWrites()
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "old")))
+	statePath := filepath.Join(dir, defaultSyncStateFile)
+
+	// A dry run lists the pages without asking or archiving
+	require.NoError(t, runSync(&syncOptions{dir: dir, noColor: true, legacy: true, prune: true, dryRun: true}, client))
+	assert.Empty(t, fake.takeWrites())
+	state, err := loadSyncState(statePath)
+	require.NoError(t, err)
+	assert.Len(t, state.Pages, 3)
+
+	// A page already removed in Confluence is dropped from the state
+	delete(fake.pages, state.Pages["old/gone.md"].ID)
+
+	require.NoError(t, runSync(&syncOptions{dir: dir, noColor: true, legacy: true, prune: true, force: true}, client))
+	assert.Equal(t, []string{"ARCHIVE old"}, fake.takeWrites())
+
+	state, err = loadSyncState(statePath)
+	require.NoError(t, err)
+	assert.Len(t, state.Pages, 1)
+	assert.Contains(t, state.Pages, "keep.md")
+}
+
+func TestRunSync_PruneWithDelete(t *testing.T) {
+	err := runSync(&syncOptions{dir: t.TempDir(), prune: true, delete: true}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "incompatible")
+}
+
+func TestRunSync_DryRun(t *testing.T) {
+	fake, client := newFakeConfluence(t)
+	dir := t.TempDir()
+	writeSyncFiles(t, dir, map[string]string{"a.md": "A\n", "sub/b.md": "B\n"})
+
+	require.NoError(t, runSync(&syncOptions{dir: dir, space: "DOCS", noColor: true, dryRun: true}, client))
+
+	assert.Empty(t, fake.takeWrites())
+	_, err := os.Stat(filepath.Join(dir, defaultSyncStateFile))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRunSync_StateSpaceMismatch(t *testing.T) {
+	_, client := newFakeConfluence(t)
+	dir := t.TempDir()
+	writeSyncFiles(t, dir, map[string]string{"a.md": "A\n"})
+	state := &syncState{Space: "OTHER", Pages: map[string]*syncedPage{}}
+	require.NoError(t, state.save(filepath.Join(dir, defaultSyncStateFile)))
+
+	err := runSync(&syncOptions{dir: dir, space: "DOCS", noColor: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "was synced to space OTHER")
+}
+
+func TestRunSync_NotADirectory(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "page.md")
+	require.NoError(t, os.WriteFile(file, []byte("x"), 0644))
+
+	err := runSync(&syncOptions{dir: file, space: "DOCS"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not a directory")
+}
+
+func TestRunSync_UnrecordedPages(t *testing.T) {
+	fake, client := newFakeConfluence(t)
+	dir := t.TempDir()
+	writeSyncFiles(t, dir, map[string]string{"guide/index.md": "Guide\n", "guide/install.md": "Install\n"})
+
+	require.NoError(t, runSync(&syncOptions{dir: dir, space: "DOCS", parent: "42", noColor: true, legacy: true}, client))
+	fake.takeWrites()
+
+	// Pages sync didn't record, such as ones written by hand, are
+	// conflicts rather than being taken over
+	require.NoError(t, os.Remove(filepath.Join(dir, defaultSyncStateFile)))
+
+	require.NoError(t, runSync(&syncOptions{dir: dir, space: "DOCS", parent: "42", noColor: true, legacy: true}, client))
+	assert.Empty(t, fake.takeWrites())
+
+	// --overwrite takes them over
+	require.NoError(t, runSync(&syncOptions{dir: dir, space: "DOCS", parent: "42", noColor: true, legacy: true, overwrite: true}, client))
+	assert.Equal(t, []string{"PUT guide", "PUT install"}, fake.takeWrites())
+	assert.Len(t, fake.pages, 2)
+
+	state, err := loadSyncState(filepath.Join(dir, defaultSyncStateFile))
+	require.NoError(t, err)
+	assert.Equal(t, fake.byTitle("install").ID, state.Pages["guide/install.md"].ID)
+
+	// A same-titled page under another parent is not taken over
+	require.NoError(t, os.Remove(filepath.Join(dir, defaultSyncStateFile)))
+	fake.byTitle("guide").ParentID = "99"
+	err = runSync(&syncOptions{dir: dir, space: "DOCS", parent: "42", noColor: true, legacy: true}, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST guide", "POST install"}, fake.takeWrites())
+}
+
+func TestRunSync_Classification(t *testing.T) {
+	fake, client := newFakeConfluence(t)
+	dir := t.TempDir()
+	writeSyncFiles(t, dir, map[string]string{
+		"public.md": "---\nlabels: [public, howto]\n---\nPublic\n",
+		"notes.md":  "Notes\n",
+	})
+	classification := config.ClassificationConfig{Labels: []string{"public", "internal"}}
+
+	// A file without a classification label stops the sync
+	err := runSync(&syncOptions{dir: dir, space: "DOCS", noColor: true, legacy: true, classification: classification}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to sync notes.md: page must carry a classification label")
+	assert.Empty(t, fake.takeWrites())
+
+	classification.Default = "internal"
+	require.NoError(t, runSync(&syncOptions{dir: dir, space: "DOCS", noColor: true, legacy: true, classification: classification}, client))
+	assert.Equal(t, []string{"internal"}, fake.labels[fake.byTitle("notes").ID])
+	assert.Equal(t, []string{"public", "howto"}, fake.labels[fake.byTitle("public").ID])
+
+	// Updating a classified page adds no labels
+	writeSyncFiles(t, dir, map[string]string{"notes.md": "More notes\n"})
+	require.NoError(t, runSync(&syncOptions{dir: dir, noColor: true, legacy: true, classification: classification}, client))
+	assert.Equal(t, []string{"internal"}, fake.labels[fake.byTitle("notes").ID])
+}
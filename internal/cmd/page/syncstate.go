@@ -0,0 +1,60 @@
+package page
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// defaultSyncStateFile is the state file name inside a synced directory.
+const defaultSyncStateFile = ".cfl-sync.json"
+
+// syncState records which page each synced path was published to.
+type syncState struct {
+	Space  string                 `json:"space"`
+	Parent string                 `json:"parent,omitempty"`
+	Pages  map[string]*syncedPage `json:"pages"`
+}
+
+// syncedPage is the last published state of one synced path.
+type syncedPage struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Hash    string `json:"hash"`    // Hash of the published title, content and referenced files
+	Version int    `json:"version"` // Page version written by the last sync
+}
+
+// loadSyncState reads a state file, returning an empty state if it does not exist.
+func loadSyncState(path string) (*syncState, error) {
+	state := &syncState{Pages: make(map[string]*syncedPage)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state %s: %w", path, err)
+	}
+	if state.Pages == nil {
+		state.Pages = make(map[string]*syncedPage)
+	}
+	return state, nil
+}
+
+// save writes the state file.
+func (s *syncState) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync state: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	return nil
+}
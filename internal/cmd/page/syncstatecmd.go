@@ -0,0 +1,516 @@
+package page
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+// How a sync state entry differs from Confluence and the synced directory.
+const (
+	driftOK        = "ok"
+	driftEdited    = "edited"     // Edited in Confluence since the last sync
+	driftRenamed   = "renamed"    // Retitled in Confluence since the last sync
+	driftDeleted   = "deleted"    // The page no longer exists
+	driftTrashed   = "trashed"    // The page is in the trash
+	driftArchived  = "archived"   // The page was archived
+	driftIDChanged = "id-changed" // The page is gone, but one with its title exists, as after a copy
+	driftOrphaned  = "orphaned"   // The file was removed
+	driftUntracked = "untracked"  // A file not yet published
+)
+
+type syncStateOptions struct {
+	dir       string
+	stateFile string
+	yes       bool   // Apply repairs without asking
+	dryRun    bool   // Show what would change without writing the state
+	space     string // Space the pages were copied to, for migrate
+	parent    string // Parent page in that space, for migrate
+	output    string
+	noColor   bool
+	stdin     io.Reader // For testing; defaults to os.Stdin
+}
+
+// stateEntry is a sync state entry checked against Confluence.
+type stateEntry struct {
+	Path    string `json:"path"`
+	ID      string `json:"id,omitempty"`
+	Title   string `json:"title"`
+	Version int    `json:"version,omitempty"`
+	Status  string `json:"status"`
+	Detail  string `json:"detail,omitempty"`
+
+	page *api.Page // The page now published for the entry, if found
+}
+
+// NewCmdSyncState creates the sync state command group.
+func NewCmdSyncState() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Inspect and repair sync state",
+		Long: `Inspect and repair the state file 'cfl page sync' keeps for a directory.
+
+The state maps each synced file to the page it was published to. When pages
+are deleted, trashed or copied in Confluence the mapping breaks, and without
+repair the next sync fails or publishes everything again.
+
+Repair and migrate save the previous state next to the state file with a
+.bak suffix before writing it.`,
+	}
+
+	cmd.AddCommand(newCmdSyncStateShow())
+	cmd.AddCommand(newCmdSyncStateRepair())
+	cmd.AddCommand(newCmdSyncStateMigrate())
+
+	return cmd
+}
+
+func newCmdSyncStateShow() *cobra.Command {
+	opts := &syncStateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "show <directory>",
+		Short: "Show sync state and drift from Confluence",
+		Long: `Show the page each file of a synced directory maps to, checked against
+Confluence. Each entry is reported as:
+
+  ok          Unchanged since the last sync
+  edited      Edited in Confluence; the next sync reports a conflict
+  renamed     Retitled in Confluence; the next sync reports a conflict
+  deleted     The page no longer exists
+  trashed     The page is in the trash
+  archived    The page was archived
+  id-changed  The page is gone but one with its title exists in the space,
+              as after a copy; 'state repair' maps the file to it
+  orphaned    The file was removed; sync --prune or --delete cleans it up
+  untracked   The file hasn't been published yet`,
+		Example: `  # Check a synced directory
+  cfl sync state show ./docs
+
+  # With a state file kept elsewhere
+  cfl sync state show ./docs --state ~/sync/docs.json -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.dir = args[0]
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runSyncStateShow(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.stateFile, "state", "", "State file path (default: <directory>/"+defaultSyncStateFile+")")
+
+	return cmd
+}
+
+func newCmdSyncStateRepair() *cobra.Command {
+	opts := &syncStateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "repair <directory>",
+		Short: "Repair broken sync state mappings",
+		Long: `Repair sync state entries whose pages are gone from Confluence.
+
+Files whose page was replaced by one with the same title (id-changed) are
+mapped to that page. Files whose page was deleted, trashed or archived are
+forgotten, so the next sync publishes them as new pages. Each repair is
+confirmed unless --yes is given.
+
+Pages edited or renamed in Confluence are left alone; sync reports them as
+conflicts, and --overwrite replaces them.`,
+		Example: `  # Repair interactively
+  cfl sync state repair ./docs
+
+  # Preview the repairs
+  cfl sync state repair ./docs --dry-run
+
+  # Apply every repair
+  cfl sync state repair ./docs --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.dir = args[0]
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.stdin = os.Stdin
+			return runSyncStateRepair(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.stateFile, "state", "", "State file path (default: <directory>/"+defaultSyncStateFile+")")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Apply every repair without asking")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show the repairs without changing the state")
+
+	return cmd
+}
+
+func newCmdSyncStateMigrate() *cobra.Command {
+	opts := &syncStateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "migrate <directory>",
+		Short: "Point sync state at a copy of the pages in another space",
+		Long: `Map a synced directory to the copies of its pages in another space, such
+as after copying or importing the space, so later syncs update the copies.
+
+Pages are matched by title. Files without a copy are forgotten and published
+as new pages by the next sync. The parent page is given with --parent, or
+else matched by the title of the current parent.`,
+		Example: `  # Sync ./docs to the copy of its pages in DOCS2
+  cfl sync state migrate ./docs --space DOCS2
+
+  # Preview the mapping
+  cfl sync state migrate ./docs --space DOCS2 --parent 98765 --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.dir = args[0]
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runSyncStateMigrate(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.stateFile, "state", "", "State file path (default: <directory>/"+defaultSyncStateFile+")")
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key the pages were copied to")
+	cmd.Flags().StringVarP(&opts.parent, "parent", "p", "", "Parent page in that space (ID, URL, or SPACE/Title)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show the mapping without changing the state")
+	_ = cmd.MarkFlagRequired("space")
+
+	return cmd
+}
+
+// load reads the state of the synced directory and creates the API client
+// if none is given.
+func (opts *syncStateOptions) load(client *api.Client) (*syncState, string, *api.Client, error) {
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return nil, "", nil, err
+	}
+
+	statePath := opts.stateFile
+	if statePath == "" {
+		statePath = filepath.Join(opts.dir, defaultSyncStateFile)
+	}
+	if _, err := os.Stat(statePath); err != nil {
+		return nil, "", nil, fmt.Errorf("no sync state for %s: %w", opts.dir, err)
+	}
+	state, err := loadSyncState(statePath)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return nil, "", nil, fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+	return state, statePath, client, nil
+}
+
+func runSyncStateShow(opts *syncStateOptions, client *api.Client) error {
+	state, _, client, err := opts.load(client)
+	if err != nil {
+		return err
+	}
+
+	entries, err := checkSyncState(context.Background(), client, state, opts.dir)
+	if err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]interface{}{
+			"space":   state.Space,
+			"parent":  state.Parent,
+			"entries": entries,
+		})
+	}
+
+	renderer.RenderKeyValue("Space", state.Space)
+	if state.Parent != "" {
+		renderer.RenderKeyValue("Parent", state.Parent)
+	}
+	renderStateEntries(renderer, entries)
+
+	drifted := 0
+	for _, e := range entries {
+		if e.Status != driftOK {
+			drifted++
+		}
+	}
+	if drifted > 0 {
+		renderer.Warning(fmt.Sprintf("%d of %d entries differ from Confluence or the directory", drifted, len(entries)))
+	}
+	return nil
+}
+
+func runSyncStateRepair(opts *syncStateOptions, client *api.Client) error {
+	state, statePath, client, err := opts.load(client)
+	if err != nil {
+		return err
+	}
+
+	entries, err := checkSyncState(context.Background(), client, state, opts.dir)
+	if err != nil {
+		return err
+	}
+
+	stdin := opts.stdin
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+	scanner := bufio.NewScanner(stdin)
+
+	var repaired []stateEntry
+	for _, e := range entries {
+		var question string
+		switch e.Status {
+		case driftIDChanged:
+			question = fmt.Sprintf("%s: page %s is gone; map it to %q (ID: %s)?", e.Path, e.ID, e.page.Title, e.page.ID)
+		case driftDeleted, driftTrashed, driftArchived:
+			question = fmt.Sprintf("%s: page %s is %s; forget it so the next sync publishes it again?", e.Path, e.ID, e.Status)
+		default:
+			continue
+		}
+		if !opts.yes && !opts.dryRun && !confirmRepair(scanner, question) {
+			continue
+		}
+
+		if e.Status == driftIDChanged {
+			state.Pages[e.Path].ID = e.page.ID
+			state.Pages[e.Path].Version = pageVersion(e.page)
+			e.Detail = "mapped to " + e.page.ID
+		} else {
+			delete(state.Pages, e.Path)
+			e.Detail = "forgotten"
+		}
+		repaired = append(repaired, e)
+	}
+
+	return saveRepairedState(opts, state, statePath, repaired, "Repaired")
+}
+
+func runSyncStateMigrate(opts *syncStateOptions, client *api.Client) error {
+	if opts.space == "" {
+		return fmt.Errorf("--space is required")
+	}
+	state, statePath, client, err := opts.load(client)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	space, err := client.GetSpaceByKey(ctx, opts.space)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", opts.space, err)
+	}
+
+	parentID := opts.parent
+	switch {
+	case parentID != "":
+		if parentID, err = pageref.Resolve(ctx, client, parentID); err != nil {
+			return err
+		}
+	case state.Parent != "":
+		if parentID, err = copiedParent(ctx, client, state.Parent, space.ID); err != nil {
+			return err
+		}
+	}
+
+	keys := make([]string, 0, len(state.Pages))
+	for key := range state.Pages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var migrated []stateEntry
+	for _, key := range keys {
+		p := state.Pages[key]
+		e := stateEntry{Path: key, ID: p.ID, Title: p.Title, Version: p.Version}
+		copied, err := client.FindPageByTitle(ctx, space.ID, p.Title)
+		if err != nil {
+			return fmt.Errorf("failed to look up page %q: %w", p.Title, err)
+		}
+		if copied == nil {
+			delete(state.Pages, key)
+			e.Status, e.Detail = driftDeleted, "no copy; forgotten"
+		} else {
+			p.ID, p.Version = copied.ID, pageVersion(copied)
+			e.Status, e.Detail = driftIDChanged, "mapped to "+copied.ID
+		}
+		migrated = append(migrated, e)
+	}
+
+	state.Space = space.Key
+	if state.Space == "" {
+		state.Space = opts.space
+	}
+	state.Parent = parentID
+	return saveRepairedState(opts, state, statePath, migrated, "Migrated")
+}
+
+// copiedParent finds the copy of the parent page in the space with spaceID,
+// by the parent's title.
+func copiedParent(ctx context.Context, client *api.Client, parentID, spaceID string) (string, error) {
+	parent, err := client.GetPage(ctx, parentID, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get parent page %s: %w (use --parent)", parentID, err)
+	}
+	copied, err := client.FindPageByTitle(ctx, spaceID, parent.Title)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up page %q: %w", parent.Title, err)
+	}
+	if copied == nil {
+		return "", fmt.Errorf("no copy of parent page %q in the space: use --parent", parent.Title)
+	}
+	return copied.ID, nil
+}
+
+// saveRepairedState backs up the state file and writes the changed state,
+// then reports the changed entries. Nothing is written in a dry run.
+func saveRepairedState(opts *syncStateOptions, state *syncState, statePath string, changed []stateEntry, verb string) error {
+	if !opts.dryRun && len(changed) > 0 {
+		data, err := os.ReadFile(statePath)
+		if err != nil {
+			return fmt.Errorf("failed to read sync state: %w", err)
+		}
+		if err := os.WriteFile(statePath+".bak", data, 0644); err != nil {
+			return fmt.Errorf("failed to back up sync state: %w", err)
+		}
+		if err := state.save(statePath); err != nil {
+			return err
+		}
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if view.IsStructured(opts.output) {
+		if changed == nil {
+			changed = []stateEntry{}
+		}
+		return renderer.RenderJSON(changed)
+	}
+	if len(changed) == 0 {
+		renderer.RenderText("Nothing to repair.")
+		return nil
+	}
+	renderStateEntries(renderer, changed)
+	if opts.dryRun {
+		renderer.RenderText(fmt.Sprintf("Dry run: %d entries would change", len(changed)))
+		return nil
+	}
+	renderer.Success(fmt.Sprintf("%s %d entries; previous state saved to %s", verb, len(changed), statePath+".bak"))
+	return nil
+}
+
+// confirmRepair asks a yes/no question on stdin.
+func confirmRepair(scanner *bufio.Scanner, question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+	var answer string
+	if scanner.Scan() {
+		answer = scanner.Text()
+	}
+	return strings.EqualFold(strings.TrimSpace(answer), "y")
+}
+
+// renderStateEntries prints state entries as a table.
+func renderStateEntries(renderer *view.Renderer, entries []stateEntry) {
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		version := ""
+		if e.Version > 0 {
+			version = strconv.Itoa(e.Version)
+		}
+		rows = append(rows, []string{e.Status, e.Path, e.Title, e.ID, version, e.Detail})
+	}
+	renderer.RenderTable([]string{"STATUS", "PATH", "TITLE", "ID", "VERSION", "DETAIL"}, rows)
+}
+
+// checkSyncState compares each state entry with its page in Confluence and
+// the files in dir, and lists files that aren't in the state.
+func checkSyncState(ctx context.Context, client *api.Client, state *syncState, dir string) ([]stateEntry, error) {
+	items, err := scanSyncDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]bool, len(items))
+	for _, item := range items {
+		files[item.key] = true
+	}
+
+	var spaceID string
+	if state.Space != "" {
+		space, err := client.GetSpaceByKey(ctx, state.Space)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space '%s': %w", state.Space, err)
+		}
+		spaceID = space.ID
+	}
+
+	keys := make([]string, 0, len(state.Pages))
+	for key := range state.Pages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var entries []stateEntry
+	for _, key := range keys {
+		p := state.Pages[key]
+		e := stateEntry{Path: key, ID: p.ID, Title: p.Title, Version: p.Version, Status: driftOK}
+
+		page, err := client.GetPage(ctx, p.ID, nil)
+		switch {
+		case isNotFound(err):
+			e.Status = driftDeleted
+			if spaceID != "" {
+				replacement, err := client.FindPageByTitle(ctx, spaceID, p.Title)
+				if err != nil {
+					return nil, fmt.Errorf("failed to look up page %q: %w", p.Title, err)
+				}
+				if replacement != nil {
+					e.Status, e.Detail, e.page = driftIDChanged, "now "+replacement.ID, replacement
+				}
+			}
+		case err != nil:
+			return nil, fmt.Errorf("failed to get page %s: %w", p.ID, err)
+		case page.Status == "trashed":
+			e.Status = driftTrashed
+		case page.Status == "archived":
+			e.Status = driftArchived
+		case page.Title != p.Title:
+			e.Status, e.Detail = driftRenamed, "now "+strconv.Quote(page.Title)
+		case pageVersion(page) > p.Version:
+			e.Status, e.Detail = driftEdited, fmt.Sprintf("now version %d", pageVersion(page))
+		}
+		if e.Status == driftOK && !files[key] {
+			e.Status = driftOrphaned
+		}
+		entries = append(entries, e)
+	}
+
+	for _, item := range items {
+		if state.Pages[item.key] == nil {
+			entries = append(entries, stateEntry{Path: item.key, Title: item.title, Status: driftUntracked})
+		}
+	}
+	return entries, nil
+}
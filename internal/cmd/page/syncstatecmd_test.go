@@ -0,0 +1,136 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// copyPage replaces a fake page with a copy under a new ID, as when a
+// space is copied.
+func (f *fakeConfluence) copyPage(title, parentID string) *fakeSyncPage {
+	old := f.byTitle(title)
+	delete(f.pages, old.ID)
+	f.nextID++
+	p := &fakeSyncPage{ID: fmt.Sprint(f.nextID), Title: old.Title, ParentID: parentID, Version: 1, Body: old.Body}
+	f.pages[p.ID] = p
+	return p
+}
+
+func TestCheckSyncState(t *testing.T) {
+	fake, client := newFakeConfluence(t)
+	dir := t.TempDir()
+	writeSyncFiles(t, dir, map[string]string{
+		"intro.md":         "# Welcome\n",
+		"faq.md":           "FAQ\n",
+		"guide/index.md":   "Guide home\n",
+		"guide/install.md": "Install steps\n",
+	})
+	require.NoError(t, runSync(&syncOptions{dir: dir, space: "DOCS", noColor: true, legacy: true}, client))
+	fake.takeWrites()
+
+	copied := fake.copyPage("intro", "")
+	delete(fake.pages, fake.byTitle("install").ID)
+	fake.byTitle("guide").Version = 3
+	fake.byTitle("faq").Title = "Questions"
+	writeSyncFiles(t, dir, map[string]string{"news.md": "News\n"})
+
+	state, err := loadSyncState(filepath.Join(dir, defaultSyncStateFile))
+	require.NoError(t, err)
+	// A file removed since the sync
+	state.Pages["old.md"] = &syncedPage{ID: fake.byTitle("Questions").ID, Title: "Questions", Version: 1}
+	state.Pages["faq.md"].Title = "faq"
+
+	entries, err := checkSyncState(context.Background(), client, state, dir)
+	require.NoError(t, err)
+
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Status+" "+e.Path+" "+e.Detail)
+	}
+	assert.Equal(t, []string{
+		`renamed faq.md now "Questions"`,
+		"edited guide/ now version 3",
+		"deleted guide/install.md ",
+		"id-changed intro.md now " + copied.ID,
+		"orphaned old.md ",
+		"untracked news.md ",
+	}, got)
+}
+
+func TestRunSyncStateRepair(t *testing.T) {
+	fake, client := newFakeConfluence(t)
+	dir := t.TempDir()
+	writeSyncFiles(t, dir, map[string]string{
+		"intro.md":         "# Welcome\n",
+		"guide/index.md":   "Guide home\n",
+		"guide/install.md": "Install steps\n",
+	})
+	require.NoError(t, runSync(&syncOptions{dir: dir, space: "DOCS", noColor: true, legacy: true}, client))
+	fake.takeWrites()
+
+	copied := fake.copyPage("intro", "")
+	delete(fake.pages, fake.byTitle("install").ID)
+	statePath := filepath.Join(dir, defaultSyncStateFile)
+	before, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+
+	// A dry run changes nothing
+	require.NoError(t, runSyncStateRepair(&syncStateOptions{dir: dir, dryRun: true, noColor: true}, client))
+	after, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	// Forgetting install.md is declined; intro.md is mapped to the copy
+	opts := &syncStateOptions{dir: dir, noColor: true, stdin: strings.NewReader("n\ny\n")}
+	require.NoError(t, runSyncStateRepair(opts, client))
+
+	state, err := loadSyncState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, copied.ID, state.Pages["intro.md"].ID)
+	assert.NotNil(t, state.Pages["guide/install.md"])
+
+	backup, err := os.ReadFile(statePath + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, before, backup)
+
+	// The next sync recreates the forgotten page and leaves the copy alone
+	require.NoError(t, runSyncStateRepair(&syncStateOptions{dir: dir, yes: true, noColor: true}, client))
+	require.NoError(t, runSync(&syncOptions{dir: dir, noColor: true, legacy: true}, client))
+	assert.Equal(t, []string{"POST install"}, fake.takeWrites())
+}
+
+func TestRunSyncStateMigrate(t *testing.T) {
+	fake, client := newFakeConfluence(t)
+	dir := t.TempDir()
+	writeSyncFiles(t, dir, map[string]string{
+		"intro.md":       "# Welcome\n",
+		"guide/index.md": "Guide home\n",
+	})
+	require.NoError(t, runSync(&syncOptions{dir: dir, space: "DOCS", parent: "42", noColor: true, legacy: true}, client))
+	fake.takeWrites()
+
+	guide := fake.copyPage("guide", "900")
+	delete(fake.pages, fake.byTitle("intro").ID)
+
+	opts := &syncStateOptions{dir: dir, space: "DOCS", parent: "900", noColor: true}
+	require.NoError(t, runSyncStateMigrate(opts, client))
+
+	state, err := loadSyncState(filepath.Join(dir, defaultSyncStateFile))
+	require.NoError(t, err)
+	assert.Equal(t, "900", state.Parent)
+	assert.Equal(t, guide.ID, state.Pages["guide/"].ID)
+	assert.Nil(t, state.Pages["intro.md"], "pages without a copy are forgotten")
+}
+
+func TestRunSyncStateShow_NoState(t *testing.T) {
+	err := runSyncStateShow(&syncStateOptions{dir: t.TempDir()}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no sync state for")
+}
@@ -0,0 +1,123 @@
+package page
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+type tocOptions struct {
+	file     string
+	title    string
+	legacy   bool
+	minLevel int
+	maxLevel int
+	stdin    io.Reader // For testing; defaults to os.Stdin
+	stdout   io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdTOC creates the page toc command.
+func NewCmdTOC() *cobra.Command {
+	opts := &tocOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "toc [file]",
+		Short: "Generate a static table of contents for markdown",
+		Long: `Generate a markdown table of contents linking to each heading.
+
+Each <!-- toc --> marker in the document is replaced with the table of
+contents and the whole document is printed. Without a marker, only the
+table of contents is printed. A marker can set its own heading range:
+<!-- toc min=2 max=4 -->.
+
+'cfl page create' and 'cfl page edit' expand markers automatically when
+publishing, so a static TOC can be used where the TOC macro is not allowed.
+
+Links use the cloud editor's heading anchors. Use --legacy with --title for
+pages in the legacy editor, whose anchors include the page title.`,
+		Example: `  # Preview the document with its TOC expanded
+  cfl page toc docs/guide.md
+
+  # Print a TOC of level 2 and 3 headings from stdin
+  cat guide.md | cfl page toc --min 2 --max 3
+
+  # Anchors for a legacy editor page
+  cfl page toc guide.md --legacy --title "User Guide"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.file = args[0]
+			}
+			return runTOC(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.title, "title", "t", "", "Page title (for legacy editor anchors)")
+	cmd.Flags().BoolVar(&opts.legacy, "legacy", false, "Use legacy editor heading anchors")
+	cmd.Flags().IntVar(&opts.minLevel, "min", 1, "Shallowest heading level to include")
+	cmd.Flags().IntVar(&opts.maxLevel, "max", 3, "Deepest heading level to include")
+
+	return cmd
+}
+
+func runTOC(opts *tocOptions) error {
+	if opts.legacy && opts.title == "" {
+		return fmt.Errorf("--title is required with --legacy")
+	}
+	if opts.minLevel > opts.maxLevel {
+		return fmt.Errorf("--min (%d) cannot be greater than --max (%d)", opts.minLevel, opts.maxLevel)
+	}
+
+	var data []byte
+	var err error
+	if opts.file != "" {
+		data, err = os.ReadFile(opts.file)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+	} else {
+		stdin := opts.stdin
+		if stdin == nil {
+			stdin = os.Stdin
+		}
+		data, err = io.ReadAll(stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+	}
+
+	stdout := opts.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+
+	tocOpts := md.TOCOptions{MinLevel: opts.minLevel, MaxLevel: opts.maxLevel}
+	if opts.legacy {
+		tocOpts.Anchor = md.LegacyAnchor(opts.title)
+	}
+
+	expanded := md.InsertTOC(data, tocOpts)
+	if string(expanded) == string(data) {
+		_, err = io.WriteString(stdout, md.GenerateTOC(md.Headings(data), tocOpts))
+	} else {
+		_, err = stdout.Write(expanded)
+	}
+	return err
+}
+
+// expandTOC replaces TOC markers in markdown content being published, using
+// the heading anchors of the target editor.
+func expandTOC(content string, isMarkdown bool, title string, legacy bool) string {
+	if !isMarkdown {
+		return content
+	}
+	opts := md.TOCOptions{}
+	if legacy {
+		opts.Anchor = md.LegacyAnchor(title)
+	}
+	return string(md.InsertTOC([]byte(content), opts))
+}
@@ -0,0 +1,104 @@
+package page
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunTOC_ExpandsMarker(t *testing.T) {
+	var out bytes.Buffer
+	opts := &tocOptions{
+		stdin:    strings.NewReader("# Guide\n\n<!-- toc -->\n\n## Setup\n"),
+		stdout:   &out,
+		minLevel: 1,
+		maxLevel: 3,
+	}
+
+	require.NoError(t, runTOC(opts))
+	assert.Equal(t, "# Guide\n\n- [Guide](#Guide)\n  - [Setup](#Setup)\n\n## Setup\n", out.String())
+}
+
+func TestRunTOC_NoMarkerPrintsTOC(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "guide.md")
+	require.NoError(t, os.WriteFile(file, []byte("# Guide\n\n## Setup\n"), 0644))
+
+	var out bytes.Buffer
+	opts := &tocOptions{
+		file:     file,
+		stdout:   &out,
+		legacy:   true,
+		title:    "User Guide",
+		minLevel: 2,
+		maxLevel: 3,
+	}
+
+	require.NoError(t, runTOC(opts))
+	assert.Equal(t, "- [Setup](#UserGuide-Setup)\n", out.String())
+}
+
+func TestRunTOC_SkipsFrontMatter(t *testing.T) {
+	var out bytes.Buffer
+	opts := &tocOptions{
+		stdin:    strings.NewReader("---\ntitle: Guide\n---\n\n## Setup\n"),
+		stdout:   &out,
+		minLevel: 1,
+		maxLevel: 3,
+	}
+
+	require.NoError(t, runTOC(opts))
+	assert.Equal(t, "- [Setup](#Setup)\n", out.String())
+}
+
+func TestRunTOC_Validation(t *testing.T) {
+	err := runTOC(&tocOptions{legacy: true, minLevel: 1, maxLevel: 3})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--title")
+
+	err = runTOC(&tocOptions{minLevel: 4, maxLevel: 2})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--min")
+}
+
+func TestRunCreate_ExpandsTOCMarker(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/spaces"):
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"results": [{"id": "123456", "key": "DEV"}]}`))
+		case r.Method == "POST" && strings.Contains(r.URL.Path, "/pages"):
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &receivedBody)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "99999", "title": "User Guide", "version": {"number": 1}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &createOptions{
+		space:   "DEV",
+		title:   "User Guide",
+		stdin:   strings.NewReader("<!-- toc -->\n\n## Setup\n"),
+		legacy:  true,
+		noColor: true,
+	}
+
+	require.NoError(t, runCreate(opts, client))
+
+	content := receivedBody["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"].(string)
+	assert.Contains(t, content, `<a href="#UserGuide-Setup">Setup</a>`)
+	assert.NotContains(t, content, "toc")
+}
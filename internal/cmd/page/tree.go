@@ -0,0 +1,283 @@
+package page
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+// defaultTreeConcurrency is how many child listings page tree runs at once.
+const defaultTreeConcurrency = 8
+
+type treeOptions struct {
+	space       string
+	depth       int // Levels below the roots to include; 0 for all
+	concurrency int
+	output      string
+	noColor     bool
+}
+
+// treeNode is a page and its descendants.
+type treeNode struct {
+	ID       string      `json:"id"`
+	Title    string      `json:"title"`
+	Children []*treeNode `json:"children,omitempty"`
+}
+
+// NewCmdTree creates the page tree command.
+func NewCmdTree() *cobra.Command {
+	opts := &treeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "tree [page-id]",
+		Short: "Show the page hierarchy",
+		Long: `Show a page and all of its descendants as a tree.
+
+Without a page ID, the tree of every page in the space given by --space
+(or default_space in config) is shown, starting from its top-level pages.
+Children are listed in their order in the page tree.
+
+With --output json the result is an array of root pages, each with its
+children nested under "children". With --output plain each page is printed
+on its own line as its depth, ID and title, separated by tabs.`,
+		Example: `  # Tree under a page
+  cfl page tree 12345
+
+  # Whole space, two levels deep
+  cfl page tree --space DOCS --depth 2
+
+  # Nested JSON for scripting
+  cfl page tree 12345 -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			var pageID string
+			if len(args) > 0 {
+				pageID = args[0]
+			}
+			return runTree(pageID, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key to show the whole tree of")
+	cmd.Flags().IntVarP(&opts.depth, "depth", "d", 0, "Levels of children to include (default: all)")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 0, fmt.Sprintf("Maximum concurrent requests (default: config concurrency or %d)", defaultTreeConcurrency))
+
+	return cmd
+}
+
+func runTree(pageID string, opts *treeOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if pageID != "" && opts.space != "" {
+		return fmt.Errorf("--space is incompatible with a page ID")
+	}
+	if opts.depth < 0 {
+		return fmt.Errorf("invalid depth: %d (must be >= 0)", opts.depth)
+	}
+	spaceKey := opts.space
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		// Use default space from config if not specified
+		if pageID == "" && spaceKey == "" {
+			spaceKey = cfg.DefaultSpace
+		}
+		if opts.concurrency <= 0 {
+			opts.concurrency = cfg.Concurrency
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	if pageID != "" {
+		resolved, err := pageref.Resolve(context.Background(), client, pageID)
+		if err != nil {
+			return err
+		}
+		pageID = resolved
+	}
+
+	if opts.concurrency <= 0 {
+		opts.concurrency = defaultTreeConcurrency
+	}
+
+	ctx := context.Background()
+	roots, err := treeRoots(ctx, client, pageID, spaceKey)
+	if err != nil {
+		return err
+	}
+
+	walker := &treeWalker{client: client, maxDepth: opts.depth, sem: make(chan struct{}, opts.concurrency)}
+	if err := walker.walkAll(ctx, roots, 0); err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	switch {
+	case view.IsStructured(opts.output):
+		return renderer.RenderJSON(roots)
+	case opts.output == "plain":
+		renderer.RenderTable([]string{"DEPTH", "ID", "TITLE"}, treeRows(roots, 0, nil))
+	default:
+		if len(roots) == 0 {
+			renderer.RenderText("No pages found.")
+			return nil
+		}
+		renderer.RenderText(strings.Join(treeLines(roots), "\n"))
+	}
+	return nil
+}
+
+// treeRoots returns the page a tree starts from, or the top-level pages of
+// the space when no page is given.
+func treeRoots(ctx context.Context, client *api.Client, pageID, spaceKey string) ([]*treeNode, error) {
+	if pageID != "" {
+		page, err := client.GetPage(ctx, pageID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get page: %w", err)
+		}
+		return []*treeNode{{ID: page.ID, Title: page.Title}}, nil
+	}
+
+	if spaceKey == "" {
+		return nil, fmt.Errorf("page ID or space is required: use --space flag or set default_space in config")
+	}
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	var roots []*treeNode
+	opts := &api.ListPagesOptions{Limit: 250, Status: "current", Depth: "root"}
+	for {
+		result, err := client.ListPages(ctx, space.ID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pages: %w", err)
+		}
+		for _, p := range result.Results {
+			roots = append(roots, &treeNode{ID: p.ID, Title: p.Title})
+		}
+		if opts.Cursor = result.NextCursor(); opts.Cursor == "" {
+			return roots, nil
+		}
+	}
+}
+
+// treeWalker fills in the descendants of tree nodes, listing the children
+// of sibling pages concurrently.
+type treeWalker struct {
+	client   *api.Client
+	maxDepth int           // Levels below the roots to include; 0 for all
+	sem      chan struct{} // Bounds the number of requests in flight
+}
+
+// walk lists the children of node, which is depth levels below the roots,
+// and walks them in turn.
+func (w *treeWalker) walk(ctx context.Context, node *treeNode, depth int) error {
+	if w.maxDepth > 0 && depth >= w.maxDepth {
+		return nil
+	}
+
+	select {
+	case w.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	children, err := listAllChildPages(ctx, w.client, node.ID)
+	<-w.sem
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		node.Children = append(node.Children, &treeNode{ID: child.ID, Title: child.Title})
+	}
+	return w.walkAll(ctx, node.Children, depth+1)
+}
+
+// walkAll walks nodes concurrently and returns the first error. Other walks
+// are cancelled once one fails.
+func (w *treeWalker) walkAll(ctx context.Context, nodes []*treeNode, depth int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if errs[i] = w.walk(ctx, node, depth); errs[i] != nil {
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Report the failure that caused the cancellation, not the ones it caused
+	var first error
+	for _, err := range errs {
+		if err != nil && (first == nil || errors.Is(first, context.Canceled)) {
+			first = err
+		}
+	}
+	return first
+}
+
+// treeLines draws trees with box-drawing connectors, one line per page.
+func treeLines(roots []*treeNode) []string {
+	var lines []string
+	var draw func(nodes []*treeNode, prefix string)
+	draw = func(nodes []*treeNode, prefix string) {
+		for i, node := range nodes {
+			connector, indent := "├── ", "│   "
+			if i == len(nodes)-1 {
+				connector, indent = "└── ", "    "
+			}
+			lines = append(lines, prefix+connector+treeLabel(node))
+			draw(node.Children, prefix+indent)
+		}
+	}
+	for _, root := range roots {
+		lines = append(lines, treeLabel(root))
+		draw(root.Children, "")
+	}
+	return lines
+}
+
+func treeLabel(node *treeNode) string {
+	return fmt.Sprintf("%s (%s)", node.Title, node.ID)
+}
+
+// treeRows flattens trees into depth, ID and title rows in depth-first order.
+func treeRows(nodes []*treeNode, depth int, rows [][]string) [][]string {
+	for _, node := range nodes {
+		rows = append(rows, []string{strconv.Itoa(depth), node.ID, node.Title})
+		rows = treeRows(node.Children, depth+1, rows)
+	}
+	return rows
+}
@@ -0,0 +1,134 @@
+package page
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// mockTreeServer serves space DOCS with top-level pages 1 and 5. Page 1 has
+// children 2 and 3, listed over two result pages, and page 2 has child 4.
+func mockTreeServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "777", "key": "DOCS"}]}`))
+		case r.URL.Path == "/api/v2/spaces/777/pages":
+			assert.Equal(t, "root", r.URL.Query().Get("depth"))
+			w.Write([]byte(`{"results": [{"id": "1", "title": "Home"}, {"id": "5", "title": "Archive"}]}`))
+		case r.URL.Path == "/api/v2/pages/1":
+			w.Write([]byte(`{"id": "1", "title": "Home"}`))
+		case r.URL.Path == "/api/v2/pages/1/children" && r.URL.Query().Get("cursor") == "":
+			w.Write([]byte(`{"results": [{"id": "2", "title": "Guide"}], "_links": {"next": "/api/v2/pages/1/children?cursor=next"}}`))
+		case r.URL.Path == "/api/v2/pages/1/children":
+			w.Write([]byte(`{"results": [{"id": "3", "title": "FAQ"}]}`))
+		case r.URL.Path == "/api/v2/pages/2/children":
+			w.Write([]byte(`{"results": [{"id": "4", "title": "Install"}]}`))
+		case r.URL.Path == "/api/v2/pages/3/children", r.URL.Path == "/api/v2/pages/4/children", r.URL.Path == "/api/v2/pages/5/children":
+			w.Write([]byte(`{"results": []}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func buildTree(t *testing.T, client *api.Client, pageID, spaceKey string, depth int) []*treeNode {
+	ctx := context.Background()
+	roots, err := treeRoots(ctx, client, pageID, spaceKey)
+	require.NoError(t, err)
+	walker := &treeWalker{client: client, maxDepth: depth, sem: make(chan struct{}, 2)}
+	require.NoError(t, walker.walkAll(ctx, roots, 0))
+	return roots
+}
+
+func TestPageTree_FromPage(t *testing.T) {
+	server := mockTreeServer(t)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	roots := buildTree(t, client, "1", "", 0)
+
+	assert.Equal(t, []string{
+		"Home (1)",
+		"├── Guide (2)",
+		"│   └── Install (4)",
+		"└── FAQ (3)",
+	}, treeLines(roots))
+}
+
+func TestPageTree_Space(t *testing.T) {
+	server := mockTreeServer(t)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	roots := buildTree(t, client, "", "DOCS", 0)
+
+	assert.Equal(t, [][]string{
+		{"0", "1", "Home"},
+		{"1", "2", "Guide"},
+		{"2", "4", "Install"},
+		{"1", "3", "FAQ"},
+		{"0", "5", "Archive"},
+	}, treeRows(roots, 0, nil))
+}
+
+func TestPageTree_Depth(t *testing.T) {
+	server := mockTreeServer(t)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	roots := buildTree(t, client, "1", "", 1)
+
+	require.Len(t, roots[0].Children, 2)
+	assert.Nil(t, roots[0].Children[0].Children)
+}
+
+func TestPageTree_ChildListingFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/pages/1":
+			w.Write([]byte(`{"id": "1", "title": "Home"}`))
+		case "/api/v2/pages/1/children":
+			w.Write([]byte(`{"results": [{"id": "2", "title": "Guide"}, {"id": "3", "title": "FAQ"}]}`))
+		case "/api/v2/pages/2/children":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message": "forbidden"}`))
+		default:
+			w.Write([]byte(`{"results": []}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runTree("1", &treeOptions{noColor: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list child pages of 2")
+}
+
+func TestRunTree_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		pageID  string
+		opts    *treeOptions
+		wantErr string
+	}{
+		{"page and space", "1", &treeOptions{space: "DOCS"}, "incompatible"},
+		{"negative depth", "1", &treeOptions{depth: -1}, "invalid depth"},
+		{"invalid output", "1", &treeOptions{output: "xml"}, "invalid output format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runTree(tt.pageID, tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -2,25 +2,33 @@ package page
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os/exec"
-	"runtime"
 
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/browser"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
 	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/pagecache"
 	"github.com/open-cli-collective/confluence-cli/internal/view"
 	"github.com/open-cli-collective/confluence-cli/pkg/md"
 )
 
 type viewOptions struct {
 	raw         bool
+	render      bool
 	web         bool
 	showMacros  bool
 	contentOnly bool
+	frontMatter bool
+	version     int
+	offline     bool
 	output      string
 	noColor     bool
+
+	cache *pagecache.Cache // Caches fetched pages; set from config unless injected
 }
 
 // NewCmdView creates the page view command.
@@ -30,18 +38,47 @@ func NewCmdView() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "view <page-id>",
 		Short: "View a page",
-		Long:  `View a Confluence page content.`,
+		Long: `View a Confluence page content.
+
+With --front-matter, the page's title, ID, space, version and labels are
+printed as YAML front matter instead of the metadata headers. page edit and
+page create read them back: edit refuses content for another page, applies
+the title and labels, and merges with changes made since the recorded
+version; create uses the title, space and labels.
+
+With --render, the content is styled for reading in the terminal: headings,
+emphasis, links, lists and tables are laid out, code blocks are syntax
+highlighted and text is wrapped to the terminal width. Colors are left out
+with --no-color or when the output isn't a terminal.
+
+With page_cache: true in the config (or CFL_PAGE_CACHE=1), every page
+viewed is also saved in the page cache, by ID and version. --offline shows
+a page from the cache without connecting, by ID, URL or SPACE/Title; 'cfl
+cache sync' fills the cache with a whole space.`,
 		Example: `  # View a page
   cfl page view 12345
 
-  # View raw storage format
+  # Read a cached page without a connection
+  cfl page view "DEV/Release Runbook" --offline
+
+  # Read a page with styled headings and highlighted code
+  cfl page view 12345 --render
+
+  # View raw storage format (or ADF for cloud editor pages)
   cfl page view 12345 --raw
 
   # Open in browser
   cfl page view 12345 --web
 
+  # View the version that was reviewed, not the current one
+  cfl page view 12345 --version 7
+
   # Output content only (for piping to edit)
-  cfl page view 12345 --show-macros --content-only | cfl page edit 12345 --legacy`,
+  cfl page view 12345 --show-macros --content-only | cfl page edit 12345 --legacy
+
+  # Round-trip a page through a file, keeping its title, labels and version
+  cfl page view 12345 --front-matter > page.md
+  cfl page edit 12345 --file page.md`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.output, _ = cmd.Flags().GetString("output")
@@ -50,10 +87,14 @@ func NewCmdView() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().BoolVar(&opts.raw, "raw", false, "Show raw Confluence storage format")
+	cmd.Flags().BoolVar(&opts.raw, "raw", false, "Show raw Confluence storage format (ADF JSON for cloud editor pages)")
+	cmd.Flags().BoolVar(&opts.render, "render", false, "Render the content for reading in the terminal")
 	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open in browser instead of displaying")
-	cmd.Flags().BoolVar(&opts.showMacros, "show-macros", false, "Show Confluence macro placeholders (e.g., [TOC]) instead of stripping them")
+	cmd.Flags().BoolVar(&opts.showMacros, "show-macros", false, "Show Confluence macro placeholders (e.g., [TOC]) instead of stripping them; macros without one are kept as [MACRO name=...]")
 	cmd.Flags().BoolVar(&opts.contentOnly, "content-only", false, "Output only page content (no metadata headers)")
+	cmd.Flags().BoolVar(&opts.frontMatter, "front-matter", false, "Output page metadata as YAML front matter before the content")
+	cmd.Flags().IntVar(&opts.version, "version", 0, "View a specific version of the page (default: current)")
+	cmd.Flags().BoolVar(&opts.offline, "offline", false, "Show the page from the local page cache without connecting")
 
 	return cmd
 }
@@ -69,7 +110,7 @@ func runView(pageID string, opts *viewOptions, client *api.Client) error {
 
 	// Validate flag combinations
 	if opts.contentOnly {
-		if opts.output == "json" {
+		if view.IsStructured(opts.output) {
 			return fmt.Errorf("--content-only is incompatible with --output json")
 		}
 		if opts.web {
@@ -77,6 +118,47 @@ func runView(pageID string, opts *viewOptions, client *api.Client) error {
 		}
 	}
 
+	if opts.frontMatter {
+		if view.IsStructured(opts.output) {
+			return fmt.Errorf("--front-matter is incompatible with --output json")
+		}
+		if opts.web {
+			return fmt.Errorf("--front-matter is incompatible with --web")
+		}
+		if opts.raw {
+			return fmt.Errorf("--front-matter is incompatible with --raw")
+		}
+	}
+
+	if opts.render {
+		if view.IsStructured(opts.output) {
+			return fmt.Errorf("--render is incompatible with --output json")
+		}
+		if opts.raw {
+			return fmt.Errorf("--render is incompatible with --raw")
+		}
+		if opts.frontMatter {
+			return fmt.Errorf("--render is incompatible with --front-matter")
+		}
+	}
+
+	if opts.version < 0 {
+		return fmt.Errorf("invalid version: %d (must be >= 1)", opts.version)
+	}
+	if opts.version > 0 && opts.web {
+		return fmt.Errorf("--version is incompatible with --web")
+	}
+
+	if opts.offline {
+		if opts.web {
+			return fmt.Errorf("--offline is incompatible with --web")
+		}
+		if opts.frontMatter {
+			return fmt.Errorf("--offline is incompatible with --front-matter")
+		}
+		return runViewOffline(pageID, opts)
+	}
+
 	// Create API client if not provided (allows injection for testing)
 	if client == nil {
 		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
@@ -89,12 +171,21 @@ func runView(pageID string, opts *viewOptions, client *api.Client) error {
 		}
 
 		baseURL = cfg.URL
-		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken)
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+		if cfg.PageCache && opts.cache == nil {
+			opts.cache = pagecache.New(pagecache.DefaultDir(), cfg.URL)
+		}
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
 	}
 
 	// Get page with body
 	apiOpts := &api.GetPageOptions{
 		BodyFormat: "storage",
+		Version:    opts.version,
 	}
 
 	page, err := client.GetPage(context.Background(), pageID, apiOpts)
@@ -105,39 +196,110 @@ func runView(pageID string, opts *viewOptions, client *api.Client) error {
 	// Open in browser if requested
 	if opts.web {
 		url := baseURL + page.Links.WebUI
-		return openBrowser(url)
+		return browser.Open(url)
+	}
+
+	// The space key is shown when we can read the space, and the ID otherwise
+	var spaceKey string
+	needsSpace := view.IsStructured(opts.output) || opts.cache != nil || !opts.contentOnly && !opts.frontMatter
+	if page.SpaceID != "" && needsSpace {
+		if space, err := client.GetSpace(context.Background(), page.SpaceID); err == nil {
+			spaceKey = space.Key
+		}
+	}
+
+	var fm *md.FrontMatter
+	if opts.frontMatter && !view.IsStructured(opts.output) {
+		if fm, err = pageFrontMatter(context.Background(), client, page); err != nil {
+			return err
+		}
+	}
+
+	// Pages authored in the cloud editor may only have an ADF body
+	if !view.IsStructured(opts.output) && (page.Body == nil || page.Body.Storage == nil || page.Body.Storage.Value == "") {
+		adfPage, err := client.GetPage(context.Background(), pageID, &api.GetPageOptions{BodyFormat: "atlas_doc_format", Version: opts.version})
+		if err == nil && adfPage.Body != nil && adfPage.Body.AtlasDocFormat != nil && adfPage.Body.AtlasDocFormat.Value != "" {
+			page.Body = adfPage.Body
+		}
+	}
+
+	if opts.cache != nil && page.Body != nil && page.Version != nil {
+		// The cache is for reading offline; failing to write it isn't an error
+		_ = opts.cache.Put(page, spaceKey)
+	}
+
+	return printPage(page, spaceKey, fm, opts)
+}
+
+// runViewOffline shows a page from the page cache, without requests.
+func runViewOffline(ref string, opts *viewOptions) error {
+	cache := opts.cache
+	if cache == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+		if cfg.URL == "" {
+			return fmt.Errorf("invalid config: url is required (run 'cfl init' to configure)")
+		}
+		cache = pagecache.New(pagecache.DefaultDir(), cfg.URL)
+	}
+
+	id, spaceKey, title, err := pageref.Parse(ref, "")
+	if err != nil {
+		return err
+	}
+	var entry *pagecache.Entry
+	if id != "" {
+		entry, err = cache.Get(id, opts.version)
+	} else {
+		if opts.version > 0 {
+			return fmt.Errorf("--version with --offline needs a page ID")
+		}
+		entry, err = cache.Find(spaceKey, title)
+	}
+	if errors.Is(err, pagecache.ErrNotCached) {
+		return fmt.Errorf("%w (cache it with 'cfl cache sync', or set page_cache: true and view it online)", err)
+	}
+	if err != nil {
+		return err
 	}
 
-	// Render output
+	return printPage(entry.Page, entry.SpaceKey, nil, opts)
+}
+
+// printPage shows a page: as JSON, or as its metadata (as front matter when
+// fm is set) followed by its content.
+func printPage(page *api.Page, spaceKey string, fm *md.FrontMatter, opts *viewOptions) error {
 	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
 
-	if opts.output == "json" {
-		// Enrich JSON output with spaceKey if we can resolve it
-		if page.SpaceID != "" {
-			space, err := client.GetSpace(context.Background(), page.SpaceID)
-			if err == nil {
-				// Create enriched response with spaceKey
-				type enrichedPage struct {
-					*api.Page
-					SpaceKey string `json:"spaceKey"`
-				}
-				return renderer.RenderJSON(enrichedPage{Page: page, SpaceKey: space.Key})
+	if view.IsStructured(opts.output) {
+		// Enrich JSON output with spaceKey if we could resolve it
+		if spaceKey != "" {
+			type enrichedPage struct {
+				*api.Page
+				SpaceKey string `json:"spaceKey"`
 			}
+			return renderer.RenderJSON(enrichedPage{Page: page, SpaceKey: spaceKey})
 		}
 		return renderer.RenderJSON(page)
 	}
 
-	// Show page info (unless content-only mode)
-	if !opts.contentOnly {
+	// Show page info as front matter, or as headers unless in content-only mode
+	switch {
+	case fm != nil:
+		rendered, err := fm.Render()
+		if err != nil {
+			return err
+		}
+		fmt.Print(rendered)
+	case !opts.contentOnly:
 		renderer.RenderKeyValue("Title", page.Title)
 		renderer.RenderKeyValue("ID", page.ID)
-		if page.SpaceID != "" {
-			space, err := client.GetSpace(context.Background(), page.SpaceID)
-			if err == nil {
-				renderer.RenderKeyValue("Space", fmt.Sprintf("%s (ID: %s)", space.Key, page.SpaceID))
-			} else {
-				renderer.RenderKeyValue("Space ID", page.SpaceID)
-			}
+		if spaceKey != "" {
+			renderer.RenderKeyValue("Space", fmt.Sprintf("%s (ID: %s)", spaceKey, page.SpaceID))
+		} else if page.SpaceID != "" {
+			renderer.RenderKeyValue("Space ID", page.SpaceID)
 		}
 		if page.Version != nil {
 			renderer.RenderKeyValue("Version", fmt.Sprintf("%d", page.Version.Number))
@@ -146,15 +308,22 @@ func runView(pageID string, opts *viewOptions, client *api.Client) error {
 	}
 
 	// Show content
-	if page.Body != nil && page.Body.Storage != nil {
+	convertOpts := md.ConvertOptions{
+		ShowMacros: opts.showMacros,
+	}
+	printMarkdown := func(markdown string) {
+		if opts.render {
+			markdown = view.RenderMarkdown(markdown, view.TerminalWidth())
+		}
+		fmt.Println(markdown)
+	}
+	switch {
+	case page.Body != nil && page.Body.Storage != nil && page.Body.Storage.Value != "":
 		content := page.Body.Storage.Value
 		if opts.raw {
 			fmt.Println(content)
 		} else {
 			// Convert storage format (HTML) to markdown
-			convertOpts := md.ConvertOptions{
-				ShowMacros: opts.showMacros,
-			}
 			markdown, err := md.FromConfluenceStorageWithOptions(content, convertOpts)
 			if err != nil {
 				// Fall back to raw content if conversion fails
@@ -162,29 +331,55 @@ func runView(pageID string, opts *viewOptions, client *api.Client) error {
 				fmt.Println()
 				fmt.Println(content)
 			} else {
-				fmt.Println(markdown)
+				printMarkdown(markdown)
 			}
 		}
-	} else {
+	case page.Body != nil && page.Body.AtlasDocFormat != nil:
+		content := page.Body.AtlasDocFormat.Value
+		if opts.raw {
+			fmt.Println(content)
+		} else {
+			// Convert ADF (JSON) to markdown
+			markdown, err := md.FromADFWithOptions(content, convertOpts)
+			if err != nil {
+				// Fall back to raw content if conversion fails
+				fmt.Println("(Failed to convert to markdown, showing raw ADF)")
+				fmt.Println()
+				fmt.Println(content)
+			} else {
+				printMarkdown(markdown)
+			}
+		}
+	case page.Body != nil && page.Body.Storage != nil:
+		fmt.Println()
+	default:
 		fmt.Println("(No content)")
 	}
 
 	return nil
 }
 
-func openBrowser(url string) error {
-	var cmd *exec.Cmd
-
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
-	default:
-		return fmt.Errorf("unsupported platform")
+// pageFrontMatter returns the front matter describing a page, for
+// publishing its content back with page edit or page create.
+func pageFrontMatter(ctx context.Context, client *api.Client, page *api.Page) (*md.FrontMatter, error) {
+	fm := &md.FrontMatter{Title: page.Title, ID: page.ID}
+	if page.Version != nil {
+		fm.Version = page.Version.Number
+	}
+	if page.SpaceID != "" {
+		space, err := client.GetSpace(ctx, page.SpaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get space: %w", err)
+		}
+		fm.Space = space.Key
 	}
 
-	return cmd.Start()
+	labels, err := client.GetLabels(ctx, page.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	for _, label := range labels {
+		fm.Labels = append(fm.Labels, label.Name)
+	}
+	return fm, nil
 }
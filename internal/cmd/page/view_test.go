@@ -1,6 +1,8 @@
 package page
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/pagecache"
 )
 
 func TestRunView_Success(t *testing.T) {
@@ -292,3 +295,200 @@ func TestRunView_ContentOnly_EmptyBody(t *testing.T) {
 	require.NoError(t, err)
 	// Output should be "(No content)" without metadata headers
 }
+
+func TestRunView_ADFBody(t *testing.T) {
+	var formats []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("body-format")
+		formats = append(formats, format)
+
+		body := `{"storage": {"representation": "storage", "value": ""}}`
+		if format == "atlas_doc_format" {
+			body = `{"atlas_doc_format": {"representation": "atlas_doc_format", "value": "{\"type\":\"doc\",\"version\":1,\"content\":[]}"}}`
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "12345", "title": "Cloud Page", "version": {"number": 1}, "body": ` + body + `}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &viewOptions{
+		contentOnly: true,
+		noColor:     true,
+	}
+
+	err := runView("12345", opts, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"storage", "atlas_doc_format"}, formats)
+}
+
+func TestRunView_Version(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/spaces/") {
+			w.Write([]byte(`{"id": "9999", "key": "DEV", "name": "Development"}`))
+			return
+		}
+
+		assert.Equal(t, "7", r.URL.Query().Get("version"))
+		w.Write([]byte(`{
+			"id": "12345",
+			"title": "Test Page",
+			"spaceId": "9999",
+			"version": {"number": 7},
+			"body": {"storage": {"value": "<p>Reviewed</p>"}}
+		}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &viewOptions{
+		version: 7,
+		noColor: true,
+	}
+
+	err := runView("12345", opts, client)
+	require.NoError(t, err)
+}
+
+func TestRunView_Version_Invalid(t *testing.T) {
+	client := api.NewClient("http://unused", "test@example.com", "token")
+
+	err := runView("12345", &viewOptions{version: -1, noColor: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid version")
+
+	err = runView("12345", &viewOptions{version: 2, web: true, noColor: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--version is incompatible with --web")
+}
+
+// mockFrontMatterServer serves page 12345 (version 3, space DEV) with two labels.
+func mockFrontMatterServer(t *testing.T, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v2/pages/12345":
+			w.Write([]byte(`{"id": "12345", "title": "Runbook", "spaceId": "100", "version": {"number": 3},
+				"body": {"storage": {"value": "<p>Hello</p>"}}}`))
+		case "/api/v2/spaces/100":
+			w.Write([]byte(`{"id": "100", "key": "DEV"}`))
+		case "/api/v2/pages/12345/labels":
+			w.Write([]byte(`{"results": [{"id": "1", "name": "ops"}, {"id": "2", "name": "runbook"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestPageFrontMatter(t *testing.T) {
+	var requests []string
+	server := mockFrontMatterServer(t, &requests)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	page, err := client.GetPage(context.Background(), "12345", nil)
+	require.NoError(t, err)
+
+	fm, err := pageFrontMatter(context.Background(), client, page)
+	require.NoError(t, err)
+	assert.Equal(t, "Runbook", fm.Title)
+	assert.Equal(t, "12345", fm.ID)
+	assert.Equal(t, "DEV", fm.Space)
+	assert.Equal(t, 3, fm.Version)
+	assert.Equal(t, []string{"ops", "runbook"}, fm.Labels)
+}
+
+func TestRunView_FrontMatter(t *testing.T) {
+	var requests []string
+	server := mockFrontMatterServer(t, &requests)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runView("12345", &viewOptions{frontMatter: true, noColor: true}, client)
+	require.NoError(t, err)
+	assert.Contains(t, requests, "/api/v2/pages/12345/labels")
+}
+
+func TestRunView_FrontMatter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *viewOptions
+		wantErr string
+	}{
+		{"json", &viewOptions{frontMatter: true, output: "json"}, "--front-matter is incompatible with --output json"},
+		{"web", &viewOptions{frontMatter: true, web: true}, "--front-matter is incompatible with --web"},
+		{"raw", &viewOptions{frontMatter: true, raw: true}, "--front-matter is incompatible with --raw"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runView("12345", tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunView_Render_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *viewOptions
+		wantErr string
+	}{
+		{"json", &viewOptions{render: true, output: "json"}, "--render is incompatible with --output json"},
+		{"raw", &viewOptions{render: true, raw: true}, "--render is incompatible with --raw"},
+		{"front matter", &viewOptions{render: true, frontMatter: true}, "--render is incompatible with --front-matter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runView("12345", tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunView_CacheAndOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/spaces/") {
+			w.Write([]byte(`{"id": "9999", "key": "DEV", "name": "Development"}`))
+			return
+		}
+		w.Write([]byte(`{
+			"id": "12345",
+			"title": "Runbook",
+			"spaceId": "9999",
+			"version": {"number": 3},
+			"body": {"storage": {"value": "<p>Hello</p>"}}
+		}`))
+	}))
+	defer server.Close()
+
+	cache := pagecache.New(t.TempDir(), server.URL)
+
+	// Not cached yet
+	err := runView("12345", &viewOptions{offline: true, cache: cache, noColor: true}, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, pagecache.ErrNotCached))
+
+	// Viewing online caches the page
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	require.NoError(t, runView("12345", &viewOptions{contentOnly: true, cache: cache, noColor: true}, client))
+	entry, err := cache.Get("12345", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "DEV", entry.SpaceKey)
+	assert.Equal(t, "<p>Hello</p>", entry.Page.Body.Storage.Value)
+
+	server.Close()
+	for _, ref := range []string{"12345", "DEV/Runbook", "https://x.atlassian.net/wiki/spaces/DEV/pages/12345/Runbook"} {
+		require.NoError(t, runView(ref, &viewOptions{offline: true, cache: cache, noColor: true}, nil), ref)
+	}
+	require.NoError(t, runView("12345", &viewOptions{offline: true, version: 3, output: "json", cache: cache}, nil))
+
+	err = runView("12345", &viewOptions{offline: true, version: 2, cache: cache}, nil)
+	assert.True(t, errors.Is(err, pagecache.ErrNotCached))
+	err = runView("12345", &viewOptions{offline: true, web: true, cache: cache}, nil)
+	assert.EqualError(t, err, "--offline is incompatible with --web")
+}
@@ -0,0 +1,129 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type watchOptions struct {
+	unwatch   bool
+	accountID string
+	output    string
+	noColor   bool
+	stdout    io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdWatch creates the page watch command.
+func NewCmdWatch() *cobra.Command {
+	return newWatchCmd(false)
+}
+
+// NewCmdUnwatch creates the page unwatch command.
+func NewCmdUnwatch() *cobra.Command {
+	return newWatchCmd(true)
+}
+
+func newWatchCmd(unwatch bool) *cobra.Command {
+	opts := &watchOptions{unwatch: unwatch}
+
+	cmd := &cobra.Command{
+		Use:   "watch <page>...",
+		Short: "Watch pages",
+		Long: `Watch pages, to be notified when they change. Watching a page you already
+watch does nothing.
+
+Use --user to watch on behalf of another user, by account ID; this needs
+the Confluence Administrator permission. Pages can be ` + pageref.Usage + `.`,
+		Example: `  # Watch a page
+  cfl page watch 12345
+
+  # Subscribe the on-call engineer to the runbooks
+  cfl page watch 12345 12346 --user 5b10ac8d82e05b22cc7d4ef5`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runWatch(args, opts, nil)
+		},
+	}
+	if unwatch {
+		cmd.Use = "unwatch <page>..."
+		cmd.Short = "Stop watching pages"
+		cmd.Long = `Stop watching pages. Unwatching a page you don't watch does nothing.
+
+Use --user to unwatch on behalf of another user, by account ID; this needs
+the Confluence Administrator permission. Pages can be ` + pageref.Usage + `.`
+		cmd.Example = `  # Stop watching a page
+  cfl page unwatch 12345
+
+  # Unsubscribe the previous on-call engineer
+  cfl page unwatch 12345 12346 --user 5b10ac8d82e05b22cc7d4ef5`
+	}
+
+	cmd.Flags().StringVar(&opts.accountID, "user", "", "Account ID of the user (default: you)")
+
+	return cmd
+}
+
+func runWatch(pageRefs []string, opts *watchOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	action, verb := client.WatchPage, "Watching"
+	if opts.unwatch {
+		action, verb = client.UnwatchPage, "Stopped watching"
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	pageIDs := make([]string, 0, len(pageRefs))
+	for _, ref := range pageRefs {
+		pageID, err := pageref.Resolve(ctx, client, ref)
+		if err != nil {
+			return err
+		}
+		if err := action(ctx, pageID, opts.accountID); err != nil {
+			return fmt.Errorf("failed to update watch on page %s: %w", pageID, err)
+		}
+		pageIDs = append(pageIDs, pageID)
+		if !view.IsStructured(opts.output) {
+			renderer.Success(fmt.Sprintf("%s page %s", verb, pageID))
+		}
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]any{
+			"pageIds":  pageIDs,
+			"watching": !opts.unwatch,
+		})
+	}
+	return nil
+}
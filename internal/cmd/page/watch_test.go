@@ -0,0 +1,85 @@
+package page
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunWatch(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *watchOptions
+		want []string
+	}{
+		{"watch", &watchOptions{}, []string{"POST /rest/api/user/watch/content/1 ", "POST /rest/api/user/watch/content/2 "}},
+		{"unwatch for user", &watchOptions{unwatch: true, accountID: "abc"},
+			[]string{"DELETE /rest/api/user/watch/content/1 accountId=abc", "DELETE /rest/api/user/watch/content/2 accountId=abc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path+" "+r.URL.RawQuery)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			var stdout bytes.Buffer
+			tt.opts.output, tt.opts.stdout = "json", &stdout
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			require.NoError(t, runWatch([]string{"1", "2"}, tt.opts, client))
+			assert.Equal(t, tt.want, requests)
+
+			var result struct {
+				PageIDs  []string `json:"pageIds"`
+				Watching bool     `json:"watching"`
+			}
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+			assert.Equal(t, []string{"1", "2"}, result.PageIDs)
+			assert.Equal(t, !tt.opts.unwatch, result.Watching)
+		})
+	}
+}
+
+func TestRunWatchers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/content/123/notification/child-created", r.URL.Path)
+		_, _ = w.Write([]byte(`{"results": [{"type": "watch", "watcher": {"accountId": "abc",
+			"displayName": "Ada Lovelace", "email": "ada@example.com"}}], "start": 0, "size": 1}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	require.NoError(t, runWatchers("123", &watchersOptions{noColor: true, stdout: &stdout}, client))
+	assert.Contains(t, stdout.String(), "abc")
+	assert.Contains(t, stdout.String(), "Ada Lovelace")
+
+	stdout.Reset()
+	require.NoError(t, runWatchers("123", &watchersOptions{output: "json", stdout: &stdout}, client))
+	var users []api.User
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &users))
+	require.Len(t, users, 1)
+	assert.Equal(t, "ada@example.com", users[0].Email)
+}
+
+func TestRunWatchers_None(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"results": [], "start": 0, "size": 0}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	require.NoError(t, runWatchers("123", &watchersOptions{noColor: true, stdout: &stdout}, client))
+	assert.Contains(t, stdout.String(), "No watchers found.")
+}
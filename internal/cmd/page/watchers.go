@@ -0,0 +1,106 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type watchersOptions struct {
+	output  string
+	noColor bool
+	stdout  io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdWatchers creates the page watchers command.
+func NewCmdWatchers() *cobra.Command {
+	opts := &watchersOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "watchers <page>",
+		Short: "List the users watching a page",
+		Long: `List the users watching a page, who are notified when it changes. Users
+watching the page's space aren't listed.
+
+The page can be ` + pageref.Usage + `.`,
+		Example: `  # List a page's watchers
+  cfl page watchers 12345
+
+  # Account IDs for scripting
+  cfl page watchers 12345 -o json | jq -r '.[].accountId'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runWatchers(args[0], opts, nil)
+		},
+	}
+
+	return cmd
+}
+
+func runWatchers(pageRef string, opts *watchersOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pageID, err := pageref.Resolve(ctx, client, pageRef)
+	if err != nil {
+		return err
+	}
+
+	watches, err := client.GetPageWatchers(ctx, pageID)
+	if err != nil {
+		return fmt.Errorf("failed to list watchers: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	users := make([]api.User, 0, len(watches))
+	for _, w := range watches {
+		users = append(users, w.Watcher)
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(users)
+	}
+
+	if len(users) == 0 {
+		renderer.RenderText("No watchers found.")
+		return nil
+	}
+
+	headers := []string{"ACCOUNT ID", "NAME", "EMAIL"}
+	var rows [][]string
+	for _, u := range users {
+		rows = append(rows, []string{u.AccountID, u.DisplayName, u.Email})
+	}
+	renderer.RenderTable(headers, rows)
+	return nil
+}
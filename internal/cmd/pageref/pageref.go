@@ -0,0 +1,141 @@
+// Package pageref resolves the page references that commands accept
+// wherever they take a page ID.
+package pageref
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+var (
+	// pageIDPath matches the page ID in the path of a page URL, including
+	// edit URLs.
+	pageIDPath = regexp.MustCompile(`/pages/(?:edit-v2/|edit/)?(\d+)(?:/|$)`)
+
+	// displayPath matches the space key and title in a legacy page URL.
+	displayPath = regexp.MustCompile(`/display/([^/]+)/([^/]+)/?$`)
+
+	// tinyPath matches the code of a short link.
+	tinyPath = regexp.MustCompile(`/x/([A-Za-z0-9_-]+)/?$`)
+)
+
+// Usage describes the accepted references for command help.
+const Usage = "a page ID, a page URL, or SPACE/Title"
+
+// Resolve returns the ID of the page a reference points to. A reference
+// is one of:
+//
+//	12345                                          a page ID
+//	https://x.atlassian.net/wiki/spaces/DEV/pages/12345/Title
+//	https://x.atlassian.net/wiki/x/OQAB             a short link
+//	https://x.atlassian.net/wiki/display/DEV/Title  a legacy page URL
+//	DEV/Page Title                                 a space key and title
+//
+// Page IDs and URLs naming one are resolved without a request.
+func Resolve(ctx context.Context, client *api.Client, ref string) (string, error) {
+	id, spaceKey, title, err := Parse(ref, client.BaseURL())
+	if err != nil || id != "" {
+		return id, err
+	}
+	return findByTitle(ctx, client, spaceKey, title)
+}
+
+// Parse splits a reference without making requests. It returns the page
+// ID of a page ID or a URL naming one, or else the space key and title of
+// a SPACE/Title reference or legacy page URL. baseURL is the configured
+// site, which URLs must be on.
+func Parse(ref, baseURL string) (id, spaceKey, title string, err error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", "", "", fmt.Errorf("page reference is empty")
+	}
+	if isPageID(ref) {
+		return ref, "", "", nil
+	}
+
+	if strings.HasPrefix(ref, "https://") || strings.HasPrefix(ref, "http://") {
+		return parseURL(ref, baseURL)
+	}
+
+	spaceKey, title, ok := strings.Cut(ref, "/")
+	if !ok || spaceKey == "" || title == "" {
+		return "", "", "", fmt.Errorf("invalid page reference %q: use %s", ref, Usage)
+	}
+	return "", spaceKey, title, nil
+}
+
+// isPageID reports whether s is a numeric page ID.
+func isPageID(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+// parseURL returns the page ID in a page URL, or the space key and title
+// of a legacy page URL.
+func parseURL(rawURL, baseURL string) (id, spaceKey, title string, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid page URL: %w", err)
+	}
+	if base, err := url.Parse(baseURL); err == nil && base.Host != "" && !strings.EqualFold(u.Host, base.Host) {
+		return "", "", "", fmt.Errorf("page URL %s is not on the configured site %s", rawURL, base.Host)
+	}
+
+	if id := u.Query().Get("pageId"); isPageID(id) {
+		return id, "", "", nil
+	}
+	if m := pageIDPath.FindStringSubmatch(u.Path); m != nil {
+		return m[1], "", "", nil
+	}
+	if m := tinyPath.FindStringSubmatch(u.Path); m != nil {
+		id, err := decodeTinyLink(m[1])
+		return id, "", "", err
+	}
+	if m := displayPath.FindStringSubmatch(u.EscapedPath()); m != nil {
+		// Legacy URLs encode spaces in titles as +
+		title, err := url.QueryUnescape(m[2])
+		if err != nil {
+			return "", "", "", fmt.Errorf("invalid page URL: %w", err)
+		}
+		return "", m[1], title, nil
+	}
+	return "", "", "", fmt.Errorf("no page in URL %s", rawURL)
+}
+
+// decodeTinyLink returns the page ID encoded in a short link code: the ID's
+// little-endian bytes in URL-safe base64 with trailing zero digits removed.
+func decodeTinyLink(code string) (string, error) {
+	if len(code) > 11 {
+		return "", fmt.Errorf("invalid short link code %q", code)
+	}
+	padded := strings.NewReplacer("-", "/", "_", "+").Replace(code) + strings.Repeat("A", 11-len(code)) + "="
+	data, err := base64.StdEncoding.DecodeString(padded)
+	if err != nil {
+		return "", fmt.Errorf("invalid short link code %q", code)
+	}
+	return strconv.FormatUint(binary.LittleEndian.Uint64(data), 10), nil
+}
+
+// findByTitle returns the ID of the current page with a title in a space.
+func findByTitle(ctx context.Context, client *api.Client, spaceKey, title string) (string, error) {
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+	page, err := client.FindPageByTitle(ctx, space.ID, title)
+	if err != nil {
+		return "", fmt.Errorf("failed to find page %q: %w", title, err)
+	}
+	if page == nil {
+		return "", fmt.Errorf("no page titled %q in space %s", title, spaceKey)
+	}
+	return page.ID, nil
+}
@@ -0,0 +1,139 @@
+package pageref
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// tinyLinkCode encodes a page ID the way Confluence's short links do.
+func tinyLinkCode(id uint64) string {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, id)
+	code := strings.TrimRight(base64.StdEncoding.EncodeToString(data), "A=")
+	return strings.NewReplacer("/", "-", "+", "_").Replace(code)
+}
+
+// newResolveServer serves space DEV with the page "Release Notes" (ID 42).
+func newResolveServer(t *testing.T, requests *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		switch strings.TrimPrefix(r.URL.Path, "/wiki") {
+		case "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "100", "key": "DEV"}]}`))
+		case "/api/v2/spaces/100/pages":
+			if r.URL.Query().Get("title") == "Release Notes" {
+				w.Write([]byte(`{"results": [{"id": "42", "title": "Release Notes"}]}`))
+				return
+			}
+			w.Write([]byte(`{"results": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name         string
+		ref          string
+		want         string
+		wantRequests bool
+	}{
+		{"id", "12345", "12345", false},
+		{"page url", "{site}/wiki/spaces/DEV/pages/12345/Some+Title", "12345", false},
+		{"page url without title", "{site}/wiki/spaces/DEV/pages/12345", "12345", false},
+		{"edit url", "{site}/wiki/spaces/DEV/pages/edit-v2/12345?draftShareId=x", "12345", false},
+		{"viewpage url", "{site}/wiki/pages/viewpage.action?pageId=12345", "12345", false},
+		{"short link", "{site}/wiki/x/" + tinyLinkCode(987654321), "987654321", false},
+		{"display url", "{site}/wiki/display/DEV/Release+Notes", "42", true},
+		{"space and title", "DEV/Release Notes", "42", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			server := newResolveServer(t, &requests)
+			client := api.NewClient(server.URL+"/wiki", "test@example.com", "token")
+
+			got, err := Resolve(context.Background(), client, strings.ReplaceAll(tt.ref, "{site}", server.URL))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRequests, requests > 0)
+		})
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     string
+		wantErr string
+	}{
+		{"empty", " ", "page reference is empty"},
+		{"no title", "DEV/", "invalid page reference"},
+		{"plain word", "Release Notes", "invalid page reference"},
+		{"other site", "https://other.atlassian.net/wiki/spaces/DEV/pages/1", "is not on the configured site"},
+		{"not a page", "{site}/wiki/spaces/DEV/overview", "no page in URL"},
+		{"unknown title", "DEV/Missing", `no page titled "Missing" in space DEV`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			server := newResolveServer(t, &requests)
+			client := api.NewClient(server.URL+"/wiki", "test@example.com", "token")
+
+			_, err := Resolve(context.Background(), client, strings.ReplaceAll(tt.ref, "{site}", server.URL))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestDecodeTinyLink(t *testing.T) {
+	for _, id := range []uint64{1, 65541, 123456789, 4294967296} {
+		got, err := decodeTinyLink(tinyLinkCode(id))
+		require.NoError(t, err)
+		assert.Equal(t, strconv.FormatUint(id, 10), got)
+	}
+
+	_, err := decodeTinyLink("toolongforacode")
+	require.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	base := "https://example.atlassian.net/wiki"
+	tests := []struct {
+		ref                 string
+		id, spaceKey, title string
+	}{
+		{" 12345 ", "12345", "", ""},
+		{base + "/spaces/DEV/pages/777/Title", "777", "", ""},
+		{base + "/display/DEV/Release+Notes", "", "DEV", "Release Notes"},
+		{"DEV/Runbook/Part 2", "", "DEV", "Runbook/Part 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			id, spaceKey, title, err := Parse(tt.ref, base)
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.id, tt.spaceKey, tt.title}, []string{id, spaceKey, title})
+		})
+	}
+
+	_, _, _, err := Parse("Runbook", base)
+	require.Error(t, err)
+}
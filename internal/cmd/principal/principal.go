@@ -0,0 +1,53 @@
+// Package principal resolves the --user and --group selectors that
+// permission commands take.
+package principal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// Usage texts for the selector flags.
+const (
+	UserUsage  = `Account ID of a user, or "me"`
+	GroupUsage = "Name of a group"
+)
+
+// Resolve returns the principal selected by --user or --group, or nil if
+// neither is given. user is an account ID, or "me" for the current user;
+// group is a group name.
+func Resolve(ctx context.Context, client *api.Client, user, group string) (*api.Principal, error) {
+	switch {
+	case user != "" && group != "":
+		return nil, fmt.Errorf("--user and --group cannot be used together")
+	case user == "me":
+		current, err := client.GetCurrentUser(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current user: %w", err)
+		}
+		return &api.Principal{Type: api.PrincipalUser, ID: current.AccountID}, nil
+	case user != "":
+		return &api.Principal{Type: api.PrincipalUser, ID: user}, nil
+	case group != "":
+		g, err := client.GetGroupByName(ctx, group)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find group '%s': %w", group, err)
+		}
+		return &api.Principal{Type: api.PrincipalGroup, ID: g.ID}, nil
+	}
+	return nil, nil
+}
+
+// Require is Resolve for commands that need a principal.
+func Require(ctx context.Context, client *api.Client, user, group string) (api.Principal, error) {
+	p, err := Resolve(ctx, client, user, group)
+	if err != nil {
+		return api.Principal{}, err
+	}
+	if p == nil {
+		return api.Principal{}, fmt.Errorf("--user or --group is required")
+	}
+	return *p, nil
+}
@@ -0,0 +1,75 @@
+package principal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func newPrincipalServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/user/current":
+			w.Write([]byte(`{"type": "known", "accountId": "me-123"}`))
+		case "/rest/api/group/by-name":
+			if r.URL.Query().Get("name") == "editors" {
+				w.Write([]byte(`{"type": "group", "name": "editors", "id": "g-1"}`))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "No group found"}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestResolve(t *testing.T) {
+	server := newPrincipalServer(t)
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	tests := []struct {
+		name  string
+		user  string
+		group string
+		want  *api.Principal
+	}{
+		{"neither", "", "", nil},
+		{"account ID", "abc", "", &api.Principal{Type: api.PrincipalUser, ID: "abc"}},
+		{"me", "me", "", &api.Principal{Type: api.PrincipalUser, ID: "me-123"}},
+		{"group", "", "editors", &api.Principal{Type: api.PrincipalGroup, ID: "g-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve(context.Background(), client, tt.user, tt.group)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	server := newPrincipalServer(t)
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	_, err := Resolve(context.Background(), client, "abc", "editors")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used together")
+
+	_, err = Resolve(context.Background(), client, "", "nobody")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to find group 'nobody'")
+
+	_, err = Require(context.Background(), client, "", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--user or --group is required")
+}
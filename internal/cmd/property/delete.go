@@ -0,0 +1,88 @@
+package property
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type deleteOptions struct {
+	output  string
+	noColor bool
+}
+
+// NewCmdDelete creates the property delete command.
+func NewCmdDelete() *cobra.Command {
+	opts := &deleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "delete <page-id> <key>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a page's content property",
+		Long:    `Delete a content property from a page. Deleting a property the page doesn't have is an error.`,
+		Example: `  # Clear a page's review mark
+  cfl property delete 12345 reviewed`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runDelete(args[0], args[1], opts, nil)
+		},
+	}
+
+	return cmd
+}
+
+func runDelete(pageID, key string, opts *deleteOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pageID, err := pageref.Resolve(ctx, client, pageID)
+	if err != nil {
+		return err
+	}
+
+	prop, err := getProperty(ctx, client, pageID, key)
+	if err != nil {
+		return err
+	}
+	if err := client.DeletePageProperty(ctx, pageID, prop.ID); err != nil {
+		return fmt.Errorf("failed to delete property %s: %w", key, err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]interface{}{
+			"status":  "deleted",
+			"page_id": pageID,
+			"key":     key,
+		})
+	}
+
+	renderer.Success(fmt.Sprintf("Deleted property %s from page %s", key, pageID))
+	return nil
+}
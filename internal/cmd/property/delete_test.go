@@ -0,0 +1,43 @@
+package property
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunDelete(t *testing.T) {
+	var deleted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"results": [{"id": "p1", "key": "reviewed", "value": true}]}`))
+		case "DELETE":
+			deleted = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	require.NoError(t, runDelete("12345", "reviewed", &deleteOptions{noColor: true}, client))
+	assert.Equal(t, "/api/v2/pages/12345/properties/p1", deleted)
+}
+
+func TestRunDelete_Missing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runDelete("12345", "reviewed", &deleteOptions{noColor: true}, client)
+	require.Error(t, err)
+	assert.Equal(t, "page 12345 has no property reviewed", err.Error())
+}
@@ -0,0 +1,148 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type getOptions struct {
+	output  string
+	noColor bool
+	stdout  io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdGet creates the property get command.
+func NewCmdGet() *cobra.Command {
+	opts := &getOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "get <page-id> [key]",
+		Short: "Show a page's content properties",
+		Long: `Show the value of a page's content property, or list all its properties
+when no key is given.
+
+The value is printed as indented JSON, so scripts can read it directly or
+pipe it to jq. With -o json the whole property is printed, including its
+ID and version.`,
+		Example: `  # List a page's properties
+  cfl property get 12345
+
+  # Print one property's value
+  cfl property get 12345 reviewed
+
+  # Read a field of a property's value
+  cfl property get 12345 reviewed | jq -r .at`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			key := ""
+			if len(args) > 1 {
+				key = args[1]
+			}
+			return runGet(args[0], key, opts, nil)
+		},
+	}
+
+	return cmd
+}
+
+func runGet(pageID, key string, opts *getOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pageID, err := pageref.Resolve(ctx, client, pageID)
+	if err != nil {
+		return err
+	}
+
+	out := opts.stdout
+	if out == nil {
+		out = os.Stdout
+	}
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	renderer.SetWriter(out)
+
+	if key != "" {
+		prop, err := getProperty(ctx, client, pageID, key)
+		if err != nil {
+			return err
+		}
+		if view.IsStructured(opts.output) {
+			return renderer.RenderJSON(prop)
+		}
+		var value bytes.Buffer
+		if err := json.Indent(&value, prop.Value, "", "  "); err != nil {
+			return fmt.Errorf("failed to format property %s: %w", key, err)
+		}
+		fmt.Fprintln(out, value.String())
+		return nil
+	}
+
+	props, err := client.ListPageProperties(ctx, pageID)
+	if err != nil {
+		return fmt.Errorf("failed to list properties: %w", err)
+	}
+
+	if view.IsStructured(opts.output) {
+		if props == nil {
+			props = []api.ContentProperty{}
+		}
+		return renderer.RenderJSON(props)
+	}
+
+	if len(props) == 0 {
+		fmt.Fprintln(out, "No properties found.")
+		return nil
+	}
+
+	headers := []string{"KEY", "VALUE", "VERSION"}
+	var rows [][]string
+	for _, p := range props {
+		version := ""
+		if p.Version != nil {
+			version = strconv.Itoa(p.Version.Number)
+		}
+		rows = append(rows, []string{p.Key, compactJSON(p.Value), version})
+	}
+	renderer.RenderTable(headers, rows)
+	return nil
+}
+
+// compactJSON returns a JSON value on one line, for tables.
+func compactJSON(value json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, value); err != nil {
+		return string(value)
+	}
+	return buf.String()
+}
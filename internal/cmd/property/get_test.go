@@ -0,0 +1,83 @@
+package property
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// newPropertiesServer serves the content properties of page 12345.
+func newPropertiesServer(t *testing.T, props string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v2/pages/12345/properties", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"results": ` + props + `}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunGet(t *testing.T) {
+	const props = `[
+		{"id": "p1", "key": "reviewed", "value": {"by": "alex", "at": "2026-10-01"}, "version": {"number": 2}},
+		{"id": "p2", "key": "owner", "value": "docs", "version": {"number": 1}}
+	]`
+
+	tests := []struct {
+		name   string
+		key    string
+		output string
+		want   []string
+	}{
+		{
+			name: "value",
+			key:  "reviewed",
+			want: []string{"{\n  \"by\": \"alex\",\n  \"at\": \"2026-10-01\"\n}\n"},
+		},
+		{
+			name:   "property as JSON",
+			key:    "reviewed",
+			output: "json",
+			want:   []string{`"id": "p1"`, `"number": 2`},
+		},
+		{
+			name: "list",
+			want: []string{"KEY", "reviewed", `{"by":"alex","at":"2026-10-01"}`, "owner", `"docs"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newPropertiesServer(t, props)
+			client := api.NewClient(server.URL, "test@example.com", "token")
+
+			var out bytes.Buffer
+			err := runGet("12345", tt.key, &getOptions{output: tt.output, noColor: true, stdout: &out}, client)
+			require.NoError(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+}
+
+func TestRunGet_Missing(t *testing.T) {
+	server := newPropertiesServer(t, `[]`)
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	err := runGet("12345", "reviewed", &getOptions{noColor: true, stdout: &bytes.Buffer{}}, client)
+	require.Error(t, err)
+	assert.Equal(t, "page 12345 has no property reviewed", err.Error())
+
+	var out bytes.Buffer
+	require.NoError(t, runGet("12345", "", &getOptions{noColor: true, stdout: &out}, client))
+	assert.Equal(t, "No properties found.\n", out.String())
+}
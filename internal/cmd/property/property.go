@@ -0,0 +1,46 @@
+// Package property provides page content property commands.
+package property
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// NewCmdProperty creates the property command.
+func NewCmdProperty() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "property",
+		Aliases: []string{"prop"},
+		Short:   "Manage page content properties",
+		Long: `Commands for reading, setting, and deleting the content properties of
+Confluence pages: JSON values stored on a page under a key, hidden from
+readers, for automation to record metadata such as when a page was last
+reviewed.
+
+These are not the rows of a Page Properties table; for those, use
+'cfl page props'.`,
+	}
+
+	cmd.AddCommand(NewCmdGet())
+	cmd.AddCommand(NewCmdSet())
+	cmd.AddCommand(NewCmdDelete())
+
+	return cmd
+}
+
+// getProperty returns the page's property with the given key, failing when
+// the page doesn't have it.
+func getProperty(ctx context.Context, client *api.Client, pageID, key string) (*api.ContentProperty, error) {
+	prop, err := client.GetPageProperty(ctx, pageID, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get property %s: %w", key, err)
+	}
+	if prop == nil {
+		return nil, fmt.Errorf("page %s has no property %s", pageID, key)
+	}
+	return prop, nil
+}
@@ -0,0 +1,152 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+// maxKeyLength is the longest content property key Confluence accepts.
+const maxKeyLength = 255
+
+type setOptions struct {
+	asString bool // Store the value as a JSON string even if it parses as JSON
+	output   string
+	noColor  bool
+	stdin    io.Reader // For testing; defaults to os.Stdin
+}
+
+// NewCmdSet creates the property set command.
+func NewCmdSet() *cobra.Command {
+	opts := &setOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "set <page-id> <key> <value>",
+		Short: "Set a page's content property",
+		Long: `Set a page's content property, creating it or replacing its value.
+
+The value is stored as JSON: a JSON object, array, number, boolean or
+quoted string is stored as given, and anything else as a string. Use
+--string to store a value such as 42 or true as a string instead. Give -
+as the value to read it from standard input.`,
+		Example: `  # Mark a page as reviewed
+  cfl property set 12345 reviewed "{\"by\": \"$USER\", \"at\": \"$(date -u +%FT%TZ)\"}"
+
+  # Store a plain string
+  cfl property set 12345 owner docs-team
+
+  # Store a number as a string
+  cfl property set 12345 ticket 4711 --string
+
+  # Store the output of another command
+  jq '{score: .score}' report.json | cfl property set 12345 quality -`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runSet(args[0], args[1], args[2], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.asString, "string", false, "Store the value as a string even if it is valid JSON")
+
+	return cmd
+}
+
+func runSet(pageID, key, value string, opts *setOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	if value == "-" {
+		stdin := opts.stdin
+		if stdin == nil {
+			stdin = os.Stdin
+		}
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read value: %w", err)
+		}
+		value = strings.TrimSpace(string(data))
+	}
+	jsonValue, err := propertyValue(value, opts.asString)
+	if err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	pageID, err = pageref.Resolve(ctx, client, pageID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := client.GetPageProperty(ctx, pageID, key)
+	if err != nil {
+		return fmt.Errorf("failed to get property %s: %w", key, err)
+	}
+	prop, err := client.SetPageProperty(ctx, pageID, key, jsonValue, existing)
+	if err != nil {
+		return fmt.Errorf("failed to set property %s: %w", key, err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(prop)
+	}
+
+	renderer.Success(fmt.Sprintf("Set property %s on page %s", key, pageID))
+	return nil
+}
+
+// validateKey checks a content property key.
+func validateKey(key string) error {
+	switch {
+	case strings.TrimSpace(key) == "":
+		return fmt.Errorf("property key cannot be empty")
+	case len(key) > maxKeyLength:
+		return fmt.Errorf("property key is %d characters long; the limit is %d", len(key), maxKeyLength)
+	}
+	return nil
+}
+
+// propertyValue returns the JSON to store for a value given on the command
+// line: the value itself when it is JSON, or else the value as a string.
+func propertyValue(value string, asString bool) (json.RawMessage, error) {
+	if !asString && json.Valid([]byte(value)) {
+		return json.RawMessage(value), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode value: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,99 @@
+package property
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string // Properties the page has
+		value    string
+		opts     setOptions
+		method   string
+		request  string
+	}{
+		{
+			name:     "create JSON",
+			existing: `[]`,
+			value:    `{"by": "alex"}`,
+			method:   "POST",
+			request:  `{"key": "reviewed", "value": {"by": "alex"}}`,
+		},
+		{
+			name:     "update",
+			existing: `[{"id": "p1", "key": "reviewed", "value": true, "version": {"number": 3}}]`,
+			value:    "false",
+			method:   "PUT",
+			request:  `{"key": "reviewed", "value": false, "version": {"number": 4}}`,
+		},
+		{
+			name:     "plain string",
+			existing: `[]`,
+			value:    "docs team",
+			method:   "POST",
+			request:  `{"key": "reviewed", "value": "docs team"}`,
+		},
+		{
+			name:     "number as string",
+			existing: `[]`,
+			value:    "4711",
+			opts:     setOptions{asString: true},
+			method:   "POST",
+			request:  `{"key": "reviewed", "value": "4711"}`,
+		},
+		{
+			name:     "from stdin",
+			existing: `[]`,
+			value:    "-",
+			opts:     setOptions{stdin: strings.NewReader("[1, 2]\n")},
+			method:   "POST",
+			request:  `{"key": "reviewed", "value": [1, 2]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, received string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "GET" {
+					assert.Equal(t, "reviewed", r.URL.Query().Get("key"))
+					w.Write([]byte(`{"results": ` + tt.existing + `}`))
+					return
+				}
+				body, _ := io.ReadAll(r.Body)
+				method, received = r.Method, string(body)
+				w.Write([]byte(`{"id": "p1", "key": "reviewed", "value": null}`))
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			opts := tt.opts
+			opts.noColor = true
+			require.NoError(t, runSet("12345", "reviewed", tt.value, &opts, client))
+			assert.Equal(t, tt.method, method)
+			assert.JSONEq(t, tt.request, received)
+		})
+	}
+}
+
+func TestRunSet_InvalidKey(t *testing.T) {
+	client := api.NewClient("http://unused", "test@example.com", "token")
+
+	err := runSet("12345", " ", "x", &setOptions{}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "property key cannot be empty")
+
+	err = runSet("12345", strings.Repeat("k", 256), "x", &setOptions{}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the limit is 255")
+}
@@ -0,0 +1,190 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+// macroName matches the name of a macro in storage format, in both the
+// current and the legacy macro elements.
+var macroName = regexp.MustCompile(`<ac:(?:structured-)?macro\b[^>]*?\bac:name="([^"]+)"`)
+
+type brokenMacrosOptions struct {
+	space   string
+	ignore  []string
+	output  string
+	noColor bool
+}
+
+// brokenPage is a page using macros that aren't installed.
+type brokenPage struct {
+	ID     string         `json:"id"`
+	Title  string         `json:"title"`
+	Macros map[string]int `json:"macros"` // Unknown macro name → uses
+}
+
+// brokenMacrosReport is the JSON output of the report.
+type brokenMacrosReport struct {
+	Space   string        `json:"space"`
+	Scanned int           `json:"scanned"`
+	Pages   []*brokenPage `json:"pages"`
+}
+
+// NewCmdBrokenMacros creates the report broken-macros command.
+func NewCmdBrokenMacros() *cobra.Command {
+	opts := &brokenMacrosOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "broken-macros",
+		Short: "Find pages using macros that aren't installed",
+		Long: `Scan a space's pages for macros that aren't installed on the site, which
+Confluence shows as "Unknown macro" errors. This typically happens after
+an app is uninstalled, leaving its macros behind on every page that used
+them.
+
+Macro names in each page's storage format are checked against the site's
+installed macros. Use --ignore for macros that work but aren't listed,
+such as ones provided by the editor itself.`,
+		Example: `  # Report pages in a space with unknown macros
+  cfl report broken-macros --space DOCS
+
+  # Ignore a macro known to work
+  cfl report broken-macros --space DOCS --ignore legacy-widget
+
+  # Machine-readable report
+  cfl report broken-macros --space DOCS -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runBrokenMacros(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key (default: from config)")
+	cmd.Flags().StringSliceVar(&opts.ignore, "ignore", nil, "Macro names to treat as installed (repeatable)")
+
+	return cmd
+}
+
+func runBrokenMacros(opts *brokenMacrosOptions, client *api.Client) error {
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	spaceKey := opts.space
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if spaceKey == "" {
+			spaceKey = cfg.DefaultSpace
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	if spaceKey == "" {
+		return fmt.Errorf("space is required: use --space flag or set default_space in config")
+	}
+
+	ctx := context.Background()
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	names, err := client.ListInstalledMacros(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list installed macros: %w", err)
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("the site listed no installed macros, so every macro would be reported as broken")
+	}
+	installed := make(map[string]bool)
+	for _, name := range append(names, opts.ignore...) {
+		installed[strings.ToLower(name)] = true
+	}
+
+	pages, err := listSpacePages(ctx, client, space.ID)
+	if err != nil {
+		return err
+	}
+
+	report := &brokenMacrosReport{Space: space.Key, Scanned: len(pages), Pages: []*brokenPage{}}
+	for i := range pages {
+		if unknown := unknownMacros(&pages[i], installed); len(unknown) > 0 {
+			report.Pages = append(report.Pages, &brokenPage{ID: pages[i].ID, Title: pages[i].Title, Macros: unknown})
+		}
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(report)
+	}
+
+	if len(report.Pages) == 0 {
+		renderer.Success(fmt.Sprintf("No unknown macros in %d pages", report.Scanned))
+		return nil
+	}
+
+	headers := []string{"ID", "TITLE", "UNKNOWN MACROS"}
+	var rows [][]string
+	for _, p := range report.Pages {
+		rows = append(rows, []string{p.ID, p.Title, formatMacroCounts(p.Macros)})
+	}
+	renderer.RenderTable(headers, rows)
+	renderer.Warning(fmt.Sprintf("%d of %d pages use unknown macros", len(report.Pages), report.Scanned))
+	return nil
+}
+
+// unknownMacros counts the uses of macros on a page that aren't installed.
+func unknownMacros(page *api.Page, installed map[string]bool) map[string]int {
+	var unknown map[string]int
+	for _, m := range macroName.FindAllStringSubmatch(storageBody(page), -1) {
+		if installed[strings.ToLower(m[1])] {
+			continue
+		}
+		if unknown == nil {
+			unknown = make(map[string]int)
+		}
+		unknown[m[1]]++
+	}
+	return unknown
+}
+
+// formatMacroCounts lists macro names by name, with counts above one.
+func formatMacroCounts(counts map[string]int) string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name
+		if n := counts[name]; n > 1 {
+			parts[i] = fmt.Sprintf("%s (%d)", name, n)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
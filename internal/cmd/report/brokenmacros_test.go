@@ -0,0 +1,70 @@
+package report
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestUnknownMacros(t *testing.T) {
+	installed := map[string]bool{"toc": true, "info": true}
+	page := &api.Page{Body: &api.Body{Storage: &api.BodyRepresentation{Value: `<ac:structured-macro ac:name="toc" ac:schema-version="1"/>` +
+		`<ac:structured-macro ac:schema-version="1" ac:name="gliffy"><ac:parameter ac:name="name">x</ac:parameter></ac:structured-macro>` +
+		`<ac:macro ac:name="gliffy"/><ac:structured-macro ac:name="INFO"/><ac:structured-macro ac:name="drawio"/>`}}}
+
+	assert.Equal(t, map[string]int{"gliffy": 2, "drawio": 1}, unknownMacros(page, installed))
+	assert.Nil(t, unknownMacros(&api.Page{}, installed))
+}
+
+func TestFormatMacroCounts(t *testing.T) {
+	assert.Equal(t, "drawio, gliffy (2)", formatMacroCounts(map[string]int{"gliffy": 2, "drawio": 1}))
+}
+
+func TestRunBrokenMacros(t *testing.T) {
+	tests := []struct {
+		name       string
+		macrosCode int
+		macros     string
+		wantErr    string
+	}{
+		{"scans space", http.StatusOK, `{"macros": [{"macroName": "toc"}]}`, ""},
+		{"macro list unavailable", http.StatusNotFound, `{"macros": [{"macroName": "toc"}]}`, "failed to list installed macros"},
+		{"no macros listed", http.StatusOK, `{"macros": []}`, "listed no installed macros"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodyFormat string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v2/spaces":
+					w.Write([]byte(`{"results": [{"id": "100", "key": "DOCS"}]}`))
+				case "/plugins/macrobrowser/browse-macros.action":
+					w.WriteHeader(tt.macrosCode)
+					w.Write([]byte(tt.macros))
+				case "/api/v2/spaces/100/pages":
+					bodyFormat = r.URL.Query().Get("body-format")
+					w.Write([]byte(`{"results": [{"id": "1", "title": "Home", "body": {"storage": {"value": "<ac:structured-macro ac:name=\"gliffy\"/>"}}}]}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			err := runBrokenMacros(&brokenMacrosOptions{space: "DOCS", noColor: true}, client)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "storage", bodyFormat)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// Graph output formats.
+const (
+	formatDOT  = "dot"
+	formatJSON = "json"
+)
+
+// graph is a directed graph of pages for DOT or JSON output.
+type graph struct {
+	Name  string       `json:"name"`
+	Nodes []*graphNode `json:"nodes"`
+	Edges []*graphEdge `json:"edges"`
+
+	nodes map[string]*graphNode
+}
+
+// graphNode is a page. Pages outside the scanned space, or that couldn't be
+// found, are external and identified by space key and title.
+type graphNode struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Space    string `json:"space"`
+	External bool   `json:"external,omitempty"`
+}
+
+// graphEdge is a reference from one page to another.
+type graphEdge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Label string `json:"label,omitempty"`
+}
+
+func newGraph(name string) *graph {
+	return &graph{Name: name, Nodes: []*graphNode{}, Edges: []*graphEdge{}, nodes: make(map[string]*graphNode)}
+}
+
+// addNode adds a node unless one with the same ID exists, and returns the
+// node with that ID.
+func (g *graph) addNode(n *graphNode) *graphNode {
+	if existing, ok := g.nodes[n.ID]; ok {
+		return existing
+	}
+	g.nodes[n.ID] = n
+	g.Nodes = append(g.Nodes, n)
+	return n
+}
+
+// externalNodeID identifies a page that isn't in the scanned space.
+func externalNodeID(spaceKey, title string) string {
+	return spaceKey + ":" + title
+}
+
+// write writes the graph in the given format.
+func (g *graph) write(w io.Writer, format string) error {
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(g)
+	case formatDOT:
+		return g.writeDOT(w)
+	}
+	return fmt.Errorf("invalid format %q: must be %s or %s", format, formatDOT, formatJSON)
+}
+
+// writeDOT writes the graph in Graphviz DOT format, with external pages
+// drawn dashed.
+func (g *graph) writeDOT(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(g.Name))
+	b.WriteString("  rankdir=LR;\n  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		label := n.Title
+		attrs := ""
+		if n.External {
+			if n.Space != "" {
+				label = n.Space + ": " + n.Title
+			}
+			attrs = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s%s];\n", dotQuote(n.ID), dotQuote(label), attrs)
+	}
+	for _, e := range g.Edges {
+		if e.Label != "" {
+			fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", dotQuote(e.From), dotQuote(e.To), dotQuote(e.Label))
+		} else {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote(e.From), dotQuote(e.To))
+		}
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote quotes s as a DOT ID.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// listSpacePages returns the current pages of a space with their storage
+// format bodies.
+func listSpacePages(ctx context.Context, client *api.Client, spaceID string) ([]api.Page, error) {
+	var pages []api.Page
+	opts := &api.ListPagesOptions{Limit: 100, Status: "current", BodyFormat: "storage"}
+	for {
+		result, err := client.ListPages(ctx, spaceID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pages: %w", err)
+		}
+		pages = append(pages, result.Results...)
+		if opts.Cursor = result.NextCursor(); opts.Cursor == "" {
+			return pages, nil
+		}
+	}
+}
+
+// storageBody returns the storage format body of a page, if it has one.
+func storageBody(page *api.Page) string {
+	if page.Body == nil || page.Body.Storage == nil {
+		return ""
+	}
+	return page.Body.Storage.Value
+}
@@ -0,0 +1,162 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"regexp"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+var (
+	// includeMacro matches include and excerpt-include macros, capturing
+	// the macro name and body.
+	includeMacro = regexp.MustCompile(`(?s)<ac:structured-macro\b[^>]*?\bac:name="(include|excerpt-include)"[^>]*>(.*?)</ac:structured-macro>`)
+
+	// pageRef matches a page reference, capturing its attributes.
+	pageRef = regexp.MustCompile(`<ri:page\b([^>]*?)/?>`)
+
+	// refAttr matches an attribute of a resource reference.
+	refAttr = regexp.MustCompile(`ri:(content-title|space-key)="([^"]*)"`)
+)
+
+type includesOptions struct {
+	space   string
+	format  string
+	output  string
+	noColor bool
+	out     io.Writer // injectable for testing
+}
+
+// NewCmdIncludes creates the report includes command.
+func NewCmdIncludes() *cobra.Command {
+	opts := &includesOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "includes",
+		Short: "Graph which pages include content from which",
+		Long: `Build the graph of include and excerpt-include macros across a space, so
+you know which pages are affected before changing or deleting a page whose
+content is reused.
+
+Each page is a node, and each include is an edge from the including page
+to the page it includes, labeled with the macro. Included pages in other
+spaces, or that can't be found, are drawn dashed.
+
+Formats:
+  dot   Graphviz DOT (render with: dot -Tsvg includes.dot -o includes.svg)
+  json  Nodes and edges`,
+		Example: `  # Render the include graph of a space
+  cfl report includes --space DOCS | dot -Tsvg -o includes.svg
+
+  # As JSON
+  cfl report includes --space DOCS --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.out = os.Stdout
+			return runIncludes(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key (default: from config)")
+	cmd.Flags().StringVarP(&opts.format, "format", "f", formatDOT, "Graph format: dot, json")
+
+	return cmd
+}
+
+func runIncludes(opts *includesOptions, client *api.Client) error {
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.format != formatDOT && opts.format != formatJSON {
+		return fmt.Errorf("invalid format %q: must be %s or %s", opts.format, formatDOT, formatJSON)
+	}
+
+	spaceKey := opts.space
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if spaceKey == "" {
+			spaceKey = cfg.DefaultSpace
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	if spaceKey == "" {
+		return fmt.Errorf("space is required: use --space flag or set default_space in config")
+	}
+
+	ctx := context.Background()
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	pages, err := listSpacePages(ctx, client, space.ID)
+	if err != nil {
+		return err
+	}
+
+	return includeGraph(space.Key, pages).write(opts.out, opts.format)
+}
+
+// includeGraph builds the include graph of the pages of a space. Only
+// pages that include or are included are part of it.
+func includeGraph(spaceKey string, pages []api.Page) *graph {
+	byTitle := make(map[string]*api.Page)
+	for i := range pages {
+		byTitle[pages[i].Title] = &pages[i]
+	}
+
+	g := newGraph(spaceKey + " includes")
+	for i := range pages {
+		page := &pages[i]
+		for _, m := range includeMacro.FindAllStringSubmatch(storageBody(page), -1) {
+			ref := pageRef.FindStringSubmatch(m[2])
+			if ref == nil {
+				continue
+			}
+			title, refSpace := "", spaceKey
+			for _, attr := range refAttr.FindAllStringSubmatch(ref[1], -1) {
+				value := html.UnescapeString(attr[2])
+				if attr[1] == "content-title" {
+					title = value
+				} else if value != "" {
+					refSpace = value
+				}
+			}
+			if title == "" {
+				continue
+			}
+
+			from := g.addNode(&graphNode{ID: page.ID, Title: page.Title, Space: spaceKey})
+			var to *graphNode
+			if target, ok := byTitle[title]; ok && refSpace == spaceKey {
+				to = g.addNode(&graphNode{ID: target.ID, Title: target.Title, Space: spaceKey})
+			} else {
+				to = g.addNode(&graphNode{ID: externalNodeID(refSpace, title), Title: title, Space: refSpace, External: true})
+			}
+			g.Edges = append(g.Edges, &graphEdge{From: from.ID, To: to.ID, Label: m[1]})
+		}
+	}
+	return g
+}
@@ -0,0 +1,93 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func storagePage(id, title, storage string) api.Page {
+	return api.Page{ID: id, Title: title, Body: &api.Body{Storage: &api.BodyRepresentation{Value: storage}}}
+}
+
+func TestIncludeGraph(t *testing.T) {
+	pages := []api.Page{
+		storagePage("1", "Install", `<ac:structured-macro ac:name="include" ac:schema-version="1"><ac:parameter ac:name=""><ac:link><ri:page ri:content-title="Shared &amp; Common"/></ac:link></ac:parameter></ac:structured-macro>`+
+			`<ac:structured-macro ac:name="excerpt-include"><ac:parameter ac:name="name"><ac:link><ri:page ri:space-key="HR" ri:content-title="Policies"/></ac:link></ac:parameter></ac:structured-macro>`),
+		storagePage("2", "Shared & Common", `<p>Reused</p>`),
+		storagePage("3", "Upgrade", `<ac:structured-macro ac:name="include"><ac:parameter ac:name=""><ac:link><ri:page ri:content-title="Shared &amp; Common" ri:space-key="DOCS"/></ac:link></ac:parameter></ac:structured-macro>`),
+		storagePage("4", "Unrelated", `<ac:structured-macro ac:name="toc"/>`),
+	}
+
+	g := includeGraph("DOCS", pages)
+
+	var ids []string
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+	assert.Equal(t, []string{"1", "2", "HR:Policies", "3"}, ids)
+	assert.True(t, g.nodes["HR:Policies"].External)
+
+	assert.Equal(t, []*graphEdge{
+		{From: "1", To: "2", Label: "include"},
+		{From: "1", To: "HR:Policies", Label: "excerpt-include"},
+		{From: "3", To: "2", Label: "include"},
+	}, g.Edges)
+}
+
+func TestGraph_WriteDOT(t *testing.T) {
+	g := newGraph("DOCS includes")
+	g.addNode(&graphNode{ID: "1", Title: `Say "hi"`, Space: "DOCS"})
+	g.addNode(&graphNode{ID: "HR:Policies", Title: "Policies", Space: "HR", External: true})
+	g.Edges = append(g.Edges, &graphEdge{From: "1", To: "HR:Policies", Label: "include"})
+
+	var buf bytes.Buffer
+	require.NoError(t, g.write(&buf, formatDOT))
+	assert.Equal(t, `digraph "DOCS includes" {
+  rankdir=LR;
+  node [shape=box];
+  "1" [label="Say \"hi\""];
+  "HR:Policies" [label="HR: Policies", style=dashed];
+  "1" -> "HR:Policies" [label="include"];
+}
+`, buf.String())
+}
+
+func TestRunIncludes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "100", "key": "DOCS"}]}`))
+		case "/api/v2/spaces/100/pages":
+			w.Write([]byte(`{"results": [
+				{"id": "1", "title": "Install", "body": {"storage": {"value": "<ac:structured-macro ac:name=\"include\"><ac:parameter ac:name=\"\"><ac:link><ri:page ri:content-title=\"Shared\"/></ac:link></ac:parameter></ac:structured-macro>"}}},
+				{"id": "2", "title": "Shared", "body": {"storage": {"value": "<p>x</p>"}}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	var buf bytes.Buffer
+	err := runIncludes(&includesOptions{space: "DOCS", format: formatJSON, out: &buf}, client)
+	require.NoError(t, err)
+
+	var got graph
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Len(t, got.Nodes, 2)
+	require.Len(t, got.Edges, 1)
+	assert.Equal(t, graphEdge{From: "1", To: "2", Label: "include"}, *got.Edges[0])
+
+	err = runIncludes(&includesOptions{space: "DOCS", format: "svg", out: &buf}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid format")
+}
@@ -0,0 +1,199 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+var (
+	// pageLink matches a link to a page by title, capturing the reference's
+	// attributes.
+	pageLink = regexp.MustCompile(`<ac:link\b[^>]*>\s*<ri:page\b([^>]*?)/?>`)
+
+	// hrefAttr matches the target of an HTML link.
+	hrefAttr = regexp.MustCompile(`<a\b[^>]*?\bhref="([^"]+)"`)
+
+	// pageURLID matches the page ID in the path of a Confluence page URL.
+	pageURLID = regexp.MustCompile(`/pages/(\d+)(?:/|$)`)
+)
+
+type linkGraphOptions struct {
+	space   string
+	format  string
+	output  string
+	noColor bool
+	out     io.Writer // injectable for testing
+}
+
+// NewCmdLinkGraph creates the report linkgraph command.
+func NewCmdLinkGraph() *cobra.Command {
+	opts := &linkGraphOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "linkgraph",
+		Short: "Export the graph of links between a space's pages",
+		Long: `Export the internal link graph of a space for visualization in tools
+such as Graphviz or Gephi, to support information architecture reviews.
+
+Every page in the space is a node, so pages nothing links to show up as
+orphans. Each page linking to another is an edge, whether the link is a
+page link or a URL of a page on the same site. Linked pages in other
+spaces, or that can't be found, are drawn dashed. Links made by include
+macros are left out; see 'cfl report includes'.
+
+Formats:
+  dot   Graphviz DOT (render with: sfdp -Tsvg links.dot -o links.svg)
+  json  Nodes and edges`,
+		Example: `  # Render the link graph of a space
+  cfl report linkgraph --space DOCS | sfdp -Tsvg -o links.svg
+
+  # As JSON, for Gephi or scripts
+  cfl report linkgraph --space DOCS --format json > links.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.out = os.Stdout
+			return runLinkGraph(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key (default: from config)")
+	cmd.Flags().StringVarP(&opts.format, "format", "f", formatDOT, "Graph format: dot, json")
+
+	return cmd
+}
+
+func runLinkGraph(opts *linkGraphOptions, client *api.Client) error {
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.format != formatDOT && opts.format != formatJSON {
+		return fmt.Errorf("invalid format %q: must be %s or %s", opts.format, formatDOT, formatJSON)
+	}
+
+	spaceKey := opts.space
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if spaceKey == "" {
+			spaceKey = cfg.DefaultSpace
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	if spaceKey == "" {
+		return fmt.Errorf("space is required: use --space flag or set default_space in config")
+	}
+
+	ctx := context.Background()
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	pages, err := listSpacePages(ctx, client, space.ID)
+	if err != nil {
+		return err
+	}
+
+	return linkGraph(space.Key, client.BaseURL(), pages).write(opts.out, opts.format)
+}
+
+// linkGraph builds the graph of links between the pages of a space on the
+// site at baseURL.
+func linkGraph(spaceKey, baseURL string, pages []api.Page) *graph {
+	g := newGraph(spaceKey + " links")
+	byTitle := make(map[string]*graphNode)
+	for i := range pages {
+		n := g.addNode(&graphNode{ID: pages[i].ID, Title: pages[i].Title, Space: spaceKey})
+		byTitle[n.Title] = n
+	}
+
+	for i := range pages {
+		from := pages[i].ID
+		linked := make(map[string]bool)
+		link := func(to *graphNode) {
+			if to.ID == from || linked[to.ID] {
+				return
+			}
+			linked[to.ID] = true
+			g.Edges = append(g.Edges, &graphEdge{From: from, To: to.ID})
+		}
+
+		body := includeMacro.ReplaceAllString(storageBody(&pages[i]), "")
+		for _, m := range pageLink.FindAllStringSubmatch(body, -1) {
+			title, refSpace := "", spaceKey
+			for _, attr := range refAttr.FindAllStringSubmatch(m[1], -1) {
+				value := html.UnescapeString(attr[2])
+				if attr[1] == "content-title" {
+					title = value
+				} else if value != "" {
+					refSpace = value
+				}
+			}
+			switch {
+			case title == "":
+				continue
+			case refSpace == spaceKey && byTitle[title] != nil:
+				link(byTitle[title])
+			default:
+				link(g.addNode(&graphNode{ID: externalNodeID(refSpace, title), Title: title, Space: refSpace, External: true}))
+			}
+		}
+
+		for _, m := range hrefAttr.FindAllStringSubmatch(body, -1) {
+			id := sitePageID(html.UnescapeString(m[1]), baseURL)
+			if id == "" {
+				continue
+			}
+			// Pages on the site outside the space are only known by ID
+			link(g.addNode(&graphNode{ID: id, Title: "Page " + id, External: true}))
+		}
+	}
+	return g
+}
+
+// sitePageID returns the ID of the page a URL links to, if it is a page on
+// the site at baseURL.
+func sitePageID(href, baseURL string) string {
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	if u.Host != "" {
+		base, err := url.Parse(baseURL)
+		if err != nil || !strings.EqualFold(u.Host, base.Host) {
+			return ""
+		}
+	} else if !strings.HasPrefix(u.Path, "/") {
+		return ""
+	}
+
+	if m := pageURLID.FindStringSubmatch(u.Path); m != nil {
+		return m[1]
+	}
+	return ""
+}
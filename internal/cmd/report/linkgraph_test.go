@@ -0,0 +1,91 @@
+package report
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestLinkGraph(t *testing.T) {
+	base := "https://example.atlassian.net/wiki"
+	pages := []api.Page{
+		storagePage("1", "Home", `<p><ac:link><ri:page ri:content-title="Guide"/><ac:plain-text-link-body><![CDATA[guide]]></ac:plain-text-link-body></ac:link>`+
+			`<ac:link><ri:page ri:content-title="Guide"/></ac:link>`+
+			`<ac:link><ri:page ri:space-key="HR" ri:content-title="Leave"/></ac:link>`+
+			`<a href="https://example.atlassian.net/wiki/spaces/DOCS/pages/3/FAQ">faq</a>`+
+			`<a href="/wiki/spaces/ENG/pages/99">eng</a>`+
+			`<a href="https://other.example.com/wiki/pages/5">elsewhere</a></p>`),
+		storagePage("2", "Guide", `<ac:link><ri:page ri:content-title="Guide"/></ac:link>`+
+			`<ac:structured-macro ac:name="include"><ac:parameter ac:name=""><ac:link><ri:page ri:content-title="FAQ"/></ac:link></ac:parameter></ac:structured-macro>`),
+		storagePage("3", "FAQ", ``),
+		storagePage("4", "Orphan", ``),
+	}
+
+	g := linkGraph("DOCS", base, pages)
+
+	var ids []string
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+	assert.Equal(t, []string{"1", "2", "3", "4", "HR:Leave", "99"}, ids)
+	assert.True(t, g.nodes["99"].External)
+
+	// Links are deduplicated, self-links and includes left out
+	assert.Equal(t, []*graphEdge{
+		{From: "1", To: "2"},
+		{From: "1", To: "HR:Leave"},
+		{From: "1", To: "3"},
+		{From: "1", To: "99"},
+	}, g.Edges)
+}
+
+func TestSitePageID(t *testing.T) {
+	base := "https://example.atlassian.net/wiki"
+	tests := []struct {
+		href string
+		want string
+	}{
+		{"https://example.atlassian.net/wiki/spaces/DOCS/pages/123/Title", "123"},
+		{"/wiki/spaces/DOCS/pages/123", "123"},
+		{"https://other.example.com/wiki/spaces/DOCS/pages/123", ""},
+		{"pages/123", ""},
+		{"/wiki/spaces/DOCS/pages/edit-v2/123", ""},
+		{"https://example.atlassian.net/wiki/spaces/DOCS/overview", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.href, func(t *testing.T) {
+			assert.Equal(t, tt.want, sitePageID(tt.href, base))
+		})
+	}
+}
+
+func TestRunLinkGraph(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "100", "key": "DOCS"}]}`))
+		case "/api/v2/spaces/100/pages":
+			w.Write([]byte(`{"results": [
+				{"id": "1", "title": "Home", "body": {"storage": {"value": "<ac:link><ri:page ri:content-title=\"Guide\"/></ac:link>"}}},
+				{"id": "2", "title": "Guide", "body": {"storage": {"value": ""}}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	var buf bytes.Buffer
+	err := runLinkGraph(&linkGraphOptions{space: "DOCS", format: formatDOT, out: &buf}, client)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `digraph "DOCS links" {`)
+	assert.Contains(t, buf.String(), `"1" -> "2";`)
+}
@@ -0,0 +1,21 @@
+// Package report provides commands that audit a space's content.
+package report
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmdReport creates the report command.
+func NewCmdReport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Audit content",
+		Long:  `Commands that scan a space's pages and report those needing attention.`,
+	}
+
+	cmd.AddCommand(NewCmdBrokenMacros())
+	cmd.AddCommand(NewCmdIncludes())
+	cmd.AddCommand(NewCmdLinkGraph())
+
+	return cmd
+}
@@ -0,0 +1,43 @@
+package root
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+// quickstart is printed instead of the configuration error when a command
+// runs before cfl has been configured.
+const quickstart = `cfl isn't configured yet. To get started:
+
+  1. Create an API token at https://id.atlassian.com/manage-profile/security/api-tokens
+
+  2. Run the setup wizard:
+
+       cfl init
+
+     or, for CI and scripts, set the connection in the environment instead:
+
+       export CFL_URL=https://your-domain.atlassian.net
+       export CFL_EMAIL=you@example.com
+       export CFL_API_TOKEN=your-api-token
+
+  3. Try it out:
+
+       cfl space list
+       cfl page list --space KEY
+
+The config file is read from %s.
+`
+
+// PrintError writes err for the user, replacing configuration errors on
+// first run with a quickstart.
+func PrintError(w io.Writer, err error) {
+	if errors.Is(err, config.ErrNotConfigured) {
+		fmt.Fprintf(w, quickstart, config.DefaultConfigPath())
+		return
+	}
+	fmt.Fprintf(w, "Error: %s\n", err)
+}
@@ -0,0 +1,43 @@
+package root
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+func TestPrintError(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/cfl-test")
+
+	tests := []struct {
+		name     string
+		err      error
+		contains []string
+	}{
+		{
+			name:     "other error",
+			err:      errors.New("failed to get page: not found"),
+			contains: []string{"Error: failed to get page: not found\n"},
+		},
+		{
+			name:     "not configured",
+			err:      fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", config.ErrNotConfigured),
+			contains: []string{"cfl init", "CFL_API_TOKEN", "cfl space list", "/tmp/cfl-test/cfl/config.yml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			PrintError(&buf, tt.err)
+			for _, s := range tt.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+		})
+	}
+}
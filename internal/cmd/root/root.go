@@ -2,20 +2,45 @@
 package root
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/confluence-cli/internal/cmd/attachment"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/auth"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/browse"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/cachecmd"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/comment"
 	"github.com/open-cli-collective/confluence-cli/internal/cmd/completion"
 	"github.com/open-cli-collective/confluence-cli/internal/cmd/configcmd"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/database"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/debugcmd"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/generate"
 	initcmd "github.com/open-cli-collective/confluence-cli/internal/cmd/init"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/label"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/links"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/lint"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/migrate"
 	"github.com/open-cli-collective/confluence-cli/internal/cmd/page"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/property"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/report"
 	"github.com/open-cli-collective/confluence-cli/internal/cmd/search"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/serve"
 	"github.com/open-cli-collective/confluence-cli/internal/cmd/space"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/task"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/templatecmd"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/versioncmd"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/whiteboard"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/whoami"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
 	"github.com/open-cli-collective/confluence-cli/internal/version"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
 )
 
 // NewCmdRoot creates the root command for cfl.
 func NewCmdRoot() *cobra.Command {
+	info := version.Get()
 	cmd := &cobra.Command{
 		Use:   "cfl",
 		Short: "A command-line interface for Atlassian Confluence",
@@ -27,25 +52,99 @@ with a markdown-first approach for content editing.
 Get started by running: cfl init`,
 		SilenceUsage:  true,
 		SilenceErrors: true,
-		Version:       version.Version,
+		Version:       info.Version,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if profile, _ := cmd.Flags().GetString("profile"); profile != "" {
+				config.SetProfileFlag(profile)
+			}
+			if cmd.Flags().Changed("retries") {
+				retries, _ := cmd.Flags().GetInt("retries")
+				if retries < 0 {
+					return fmt.Errorf("invalid --retries: %d (must be >= 0)", retries)
+				}
+				config.SetRetriesFlag(retries)
+			}
+			if cmd.Flags().Changed("notify-watchers") {
+				notify, _ := cmd.Flags().GetBool("notify-watchers")
+				config.SetNotifyWatchersFlag(notify)
+			}
+			if body, _ := cmd.Flags().GetBool("debug-body"); body {
+				config.SetDebugFlag(config.DebugBodies)
+			} else if debug, _ := cmd.Flags().GetBool("debug"); debug {
+				config.SetDebugFlag(config.DebugRequests)
+			}
+			if !cmd.Flags().Changed("output") {
+				// Errors surface when the command itself loads the config
+				if cfg, err := config.LoadWithEnv(config.DefaultConfigPath()); err == nil && cfg.OutputFormat != "" {
+					_ = cmd.Flags().Set("output", cfg.OutputFormat)
+				}
+			}
+			// page create has its own --template, which shadows this one
+			query, _ := cmd.Root().PersistentFlags().GetString("jq")
+			tmpl, _ := cmd.Root().PersistentFlags().GetString("template")
+			quiet, _ := cmd.Root().PersistentFlags().GetBool("quiet")
+			if query != "" && tmpl != "" {
+				return fmt.Errorf("--jq and --template cannot be used together")
+			}
+			if quiet && (query != "" || tmpl != "") {
+				return fmt.Errorf("--quiet cannot be used with --jq or --template")
+			}
+			view.SetQuiet(quiet)
+			if err := view.SetQuery(query); err != nil {
+				return err
+			}
+			return view.SetTemplate(tmpl)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, _ []string) {
+			notifyUpdate(cmd, os.Stderr, isTerminal(os.Stderr))
+		},
 	}
 
 	// Global flags
 	cmd.PersistentFlags().StringP("config", "c", "", "config file (default: ~/.config/cfl/config.yml)")
-	cmd.PersistentFlags().StringP("output", "o", "table", "output format: table, json, plain")
+	cmd.PersistentFlags().String("profile", "", "config profile (context) to use (default: current_context, or set CFL_PROFILE)")
+	cmd.PersistentFlags().StringP("output", "o", "table", "output format: table, json, plain, yaml, csv, tsv (default: output_format in config, or set CFL_OUTPUT)")
+	cmd.PersistentFlags().String("jq", "", "filter JSON output with a jq expression, e.g. '.[] | .title'")
+	cmd.PersistentFlags().String("template", "", "format output with a Go template, applied to each item of a list, e.g. '{{.ID}}\\t{{.Title}}'")
+	cmd.PersistentFlags().BoolP("quiet", "q", false, "print only the IDs of listed or created items, one per line")
 	cmd.PersistentFlags().Bool("no-color", false, "disable colored output")
+	cmd.PersistentFlags().Int("retries", config.DefaultRetries, "retries for rate-limited or failed requests (overrides config)")
+	cmd.PersistentFlags().Bool("debug", false, "log HTTP requests to stderr (or set CFL_DEBUG=1)")
+	cmd.PersistentFlags().Bool("debug-body", false, "log HTTP requests with redacted bodies to stderr (or set CFL_DEBUG=body)")
+	cmd.PersistentFlags().Bool("notify-watchers", true, "notify page watchers of edits; false saves edits as minor (overrides config)")
 
 	// Set version template
-	cmd.SetVersionTemplate("cfl version {{.Version}} (commit: " + version.Commit + ", built: " + version.Date + ")\n")
+	cmd.SetVersionTemplate("cfl version {{.Version}} (commit: " + info.Commit + ", built: " + info.Date + ")\n")
 
 	// Subcommands
 	cmd.AddCommand(initcmd.NewCmdInit())
 	cmd.AddCommand(configcmd.NewCmdConfig())
+	cmd.AddCommand(auth.NewCmdAuth())
+	cmd.AddCommand(whoami.NewCmdWhoami())
 	cmd.AddCommand(page.NewCmdPage())
+	cmd.AddCommand(page.NewCmdSync())
+	cmd.AddCommand(page.NewCmdReplace())
 	cmd.AddCommand(space.NewCmdSpace())
 	cmd.AddCommand(attachment.NewCmdAttachment())
+	cmd.AddCommand(comment.NewCmdComment())
+	cmd.AddCommand(label.NewCmdLabel())
+	cmd.AddCommand(property.NewCmdProperty())
+	cmd.AddCommand(templatecmd.NewCmdTemplate())
+	cmd.AddCommand(task.NewCmdTask())
+	cmd.AddCommand(whiteboard.NewCmdWhiteboard())
+	cmd.AddCommand(database.NewCmdDatabase())
 	cmd.AddCommand(search.NewCmdSearch())
+	cmd.AddCommand(cachecmd.NewCmdCache())
+	cmd.AddCommand(browse.NewCmdBrowse())
+	cmd.AddCommand(generate.NewCmdGenerate())
+	cmd.AddCommand(report.NewCmdReport())
+	cmd.AddCommand(lint.NewCmdLint())
+	cmd.AddCommand(links.NewCmdLinks())
+	cmd.AddCommand(migrate.NewCmdMigrate())
+	cmd.AddCommand(serve.NewCmdServe())
+	cmd.AddCommand(debugcmd.NewCmdDebug())
 	cmd.AddCommand(completion.NewCmdCompletion())
+	cmd.AddCommand(versioncmd.NewCmdVersion())
 
 	return cmd
 }
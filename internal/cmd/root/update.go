@@ -0,0 +1,50 @@
+package root
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/version"
+)
+
+// updateChecker looks up newer releases; tests replace it.
+var updateChecker = func() *version.UpdateChecker {
+	return &version.UpdateChecker{CachePath: config.UpdateCheckCachePath()}
+}
+
+// notifyUpdate tells the user on w when a newer release of cfl exists.
+// Setting CFL_NO_UPDATE_CHECK turns the check off; it is also skipped for
+// development builds, shell completion, and when w isn't a terminal, so
+// scripts and CI never wait on it.
+func notifyUpdate(cmd *cobra.Command, w io.Writer, interactive bool) {
+	if os.Getenv("CFL_NO_UPDATE_CHECK") != "" || !interactive {
+		return
+	}
+	if name := cmd.Name(); name == "completion" || strings.HasPrefix(name, "__") {
+		return
+	}
+
+	current := version.Get().Version
+	latest, err := updateChecker().Check(context.Background(), current)
+	if err != nil || latest == "" {
+		return
+	}
+	fmt.Fprintf(w, "\nA new release of cfl is available: %s → %s\n", current, latest)
+	fmt.Fprintf(w, "https://github.com/open-cli-collective/confluence-cli/releases/latest\n")
+	fmt.Fprintf(w, "(set CFL_NO_UPDATE_CHECK=1 to turn off this check)\n")
+}
+
+// isTerminal reports whether f is a terminal.
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return (stat.Mode() & os.ModeCharDevice) != 0
+}
@@ -0,0 +1,56 @@
+package root
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-cli-collective/confluence-cli/internal/version"
+)
+
+func TestNotifyUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name": "v9.9.9"}`))
+	}))
+	defer server.Close()
+
+	defer func(v string) { version.Version = v }(version.Version)
+	version.Version = "1.0.0"
+	defer func(f func() *version.UpdateChecker) { updateChecker = f }(updateChecker)
+
+	tests := []struct {
+		name        string
+		cmd         string
+		env         string
+		interactive bool
+		want        bool
+	}{
+		{name: "newer release", cmd: "list", interactive: true, want: true},
+		{name: "not a terminal", cmd: "list", interactive: false},
+		{name: "turned off", cmd: "list", env: "1", interactive: true},
+		{name: "shell completion", cmd: "__complete", interactive: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cachePath := filepath.Join(t.TempDir(), "update-check.json")
+			updateChecker = func() *version.UpdateChecker {
+				return &version.UpdateChecker{CachePath: cachePath, URL: server.URL}
+			}
+			t.Setenv("CFL_NO_UPDATE_CHECK", tt.env)
+
+			var stderr bytes.Buffer
+			notifyUpdate(&cobra.Command{Use: tt.cmd}, &stderr, tt.interactive)
+			if tt.want {
+				assert.Contains(t, stderr.String(), "A new release of cfl is available: 1.0.0 → v9.9.9")
+			} else {
+				assert.Empty(t, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/page"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+// exportSearchLimit is the page size used to gather the pages to export.
+const exportSearchLimit = 100
+
+// exportedFile describes a page written by --export.
+type exportedFile struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	File  string `json:"file"`
+}
+
+// runSearchExport writes every page matching the search to a markdown file
+// in opts.exportDir, with front matter describing the page.
+func runSearchExport(ctx context.Context, opts *searchOptions, client *api.Client, apiOpts *api.SearchOptions, renderer *view.Renderer) error {
+	pages, skipped, err := searchAllPages(ctx, client, apiOpts)
+	if err != nil {
+		return err
+	}
+
+	// Name every file and check for existing ones before writing any, so a
+	// clash doesn't leave a partial export behind
+	files := make([]string, len(pages))
+	used := make(map[string]bool)
+	for i, p := range pages {
+		name := page.Filename(&api.Page{ID: p.ID, Title: p.Title})
+		if used[strings.ToLower(name)] {
+			name += " (" + p.ID + ")"
+		}
+		used[strings.ToLower(name)] = true
+		files[i] = filepath.Join(opts.exportDir, name+".md")
+
+		if !opts.force {
+			if _, err := os.Stat(files[i]); err == nil {
+				return fmt.Errorf("file already exists: %s (use --force to overwrite)", files[i])
+			}
+		}
+	}
+
+	if err := os.MkdirAll(opts.exportDir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	ids := make([]string, len(pages))
+	for i, p := range pages {
+		ids[i] = p.ID
+	}
+	_, contents, err := page.DownloadAll(ctx, client, ids)
+	if err != nil {
+		return fmt.Errorf("failed to export pages: %w", err)
+	}
+
+	exported := make([]exportedFile, 0, len(pages))
+	for i, p := range pages {
+		if err := os.WriteFile(files[i], []byte(contents[i]), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", files[i], err)
+		}
+		exported = append(exported, exportedFile{ID: p.ID, Title: p.Title, File: files[i]})
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(exported)
+	}
+
+	rows := make([][]string, 0, len(exported))
+	for _, e := range exported {
+		rows = append(rows, []string{e.ID, view.Truncate(e.Title, 50), e.File})
+	}
+	renderer.RenderTable([]string{"ID", "TITLE", "FILE"}, rows)
+	if opts.output != "plain" {
+		if skipped > 0 {
+			renderer.Warning(fmt.Sprintf("Skipped %d results that aren't pages", skipped))
+		}
+		renderer.Success(fmt.Sprintf("Exported %d pages to %s", len(exported), opts.exportDir))
+	}
+	return nil
+}
+
+// searchAllPages returns every page matching the search, and how many
+// results of other content types were left out.
+func searchAllPages(ctx context.Context, client *api.Client, apiOpts *api.SearchOptions) ([]api.SearchContent, int, error) {
+	var pages []api.SearchContent
+	skipped := 0
+	apiOpts.Limit = exportSearchLimit
+	for {
+		result, err := client.Search(ctx, apiOpts)
+		if err != nil {
+			return nil, 0, fmt.Errorf("search failed: %w", err)
+		}
+		for _, r := range result.Results {
+			if r.Content.Type == "page" {
+				pages = append(pages, r.Content)
+			} else {
+				skipped++
+			}
+		}
+		if !result.HasMore() || len(result.Results) == 0 {
+			return pages, skipped, nil
+		}
+		apiOpts.Start += len(result.Results)
+	}
+}
@@ -19,13 +19,17 @@ type searchOptions struct {
 	query       string // Positional arg: free-text search
 	cql         string // Raw CQL (power users)
 	space       string // Filter by space key
-	contentType string // page, blogpost, attachment, comment
+	contentType string // page, blogpost, attachment, comment, whiteboard, database
 	title       string // Title contains
 	label       string // Label filter
 
 	// Pagination
 	limit int
 
+	// Export
+	exportDir string // Write matching pages to this directory as markdown
+	force     bool   // Overwrite existing files when exporting
+
 	// Output
 	output  string
 	noColor bool
@@ -37,6 +41,8 @@ var validTypes = map[string]bool{
 	"blogpost":   true,
 	"attachment": true,
 	"comment":    true,
+	"whiteboard": true,
+	"database":   true,
 }
 
 // NewCmdSearch creates the search command.
@@ -49,7 +55,13 @@ func NewCmdSearch() *cobra.Command {
 		Long: `Search for pages, blog posts, attachments, and comments in Confluence.
 
 Uses Confluence Query Language (CQL) under the hood. You can use the
-convenient flags for common filters, or provide raw CQL for advanced queries.`,
+convenient flags for common filters, or provide raw CQL for advanced queries.
+
+With --export, every matching page is written to the directory as a
+markdown file named after its title, starting with the same front matter as
+'cfl page view --front-matter'. All matches are exported regardless of
+--limit, and results that aren't pages are skipped. Existing files are only
+overwritten with --force.`,
 		Example: `  # Full-text search across all content
   cfl search "deployment guide"
 
@@ -72,7 +84,10 @@ convenient flags for common filters, or provide raw CQL for advanced queries.`,
   cfl search --cql "type=page AND space=DEV AND lastModified > now('-7d')"
 
   # Output as JSON for scripting
-  cfl search "config" -o json`,
+  cfl search "config" -o json
+
+  # Download matching pages as markdown files
+  cfl search --cql "space=DEV AND label=runbook" --export runbooks/`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
@@ -87,13 +102,17 @@ convenient flags for common filters, or provide raw CQL for advanced queries.`,
 	// Query building flags
 	cmd.Flags().StringVar(&opts.cql, "cql", "", "Raw CQL query (advanced)")
 	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Filter by space key")
-	cmd.Flags().StringVarP(&opts.contentType, "type", "t", "", "Content type: page, blogpost, attachment, comment")
+	cmd.Flags().StringVarP(&opts.contentType, "type", "t", "", "Content type: page, blogpost, attachment, comment, whiteboard, database")
 	cmd.Flags().StringVar(&opts.title, "title", "", "Filter by title (contains)")
 	cmd.Flags().StringVar(&opts.label, "label", "", "Filter by label")
 
 	// Pagination
 	cmd.Flags().IntVarP(&opts.limit, "limit", "l", 25, "Maximum number of results")
 
+	// Export
+	cmd.Flags().StringVar(&opts.exportDir, "export", "", "Write every matching page to this directory as markdown")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Overwrite existing files when exporting")
+
 	return cmd
 }
 
@@ -121,9 +140,13 @@ func runSearch(opts *searchOptions, client *api.Client) error {
 
 	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
 
+	if opts.force && opts.exportDir == "" {
+		return fmt.Errorf("--force requires --export")
+	}
+
 	// Handle limit 0 - return empty
-	if opts.limit == 0 {
-		if opts.output == "json" {
+	if opts.limit == 0 && opts.exportDir == "" {
+		if view.IsStructured(opts.output) {
 			return renderer.RenderJSON([]interface{}{})
 		}
 		renderer.RenderText("No results.")
@@ -146,7 +169,7 @@ func runSearch(opts *searchOptions, client *api.Client) error {
 			opts.space = cfg.DefaultSpace
 		}
 
-		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken)
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
 	}
 
 	// Build API options
@@ -160,12 +183,19 @@ func runSearch(opts *searchOptions, client *api.Client) error {
 		Limit: opts.limit,
 	}
 
+	if opts.exportDir != "" {
+		return runSearchExport(context.Background(), opts, client, apiOpts, renderer)
+	}
+
 	result, err := client.Search(context.Background(), apiOpts)
 	if err != nil {
 		return fmt.Errorf("search failed: %w", err)
 	}
 
 	if len(result.Results) == 0 {
+		if view.IsStructured(opts.output) {
+			return renderer.RenderJSON([]interface{}{})
+		}
 		renderer.RenderText("No results found.")
 		return nil
 	}
@@ -188,7 +218,7 @@ func runSearch(opts *searchOptions, client *api.Client) error {
 
 	renderer.RenderList(headers, rows, result.HasMore())
 
-	if result.HasMore() && opts.output != "json" {
+	if result.HasMore() && !view.IsStructured(opts.output) {
 		fmt.Fprintf(os.Stderr, "\n(showing %d of %d results, use --limit to see more)\n",
 			len(result.Results), result.TotalSize)
 	}
@@ -3,6 +3,8 @@ package search
 import (
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -154,7 +156,7 @@ func TestRunSearch_InvalidType(t *testing.T) {
 }
 
 func TestRunSearch_ValidTypes(t *testing.T) {
-	validTypes := []string{"page", "blogpost", "attachment", "comment"}
+	validTypes := []string{"page", "blogpost", "attachment", "comment", "whiteboard", "database"}
 
 	for _, contentType := range validTypes {
 		t.Run(contentType, func(t *testing.T) {
@@ -451,3 +453,57 @@ func TestRunSearch_LimitParameter(t *testing.T) {
 	err := runSearch(opts, client)
 	require.NoError(t, err)
 }
+
+func TestRunSearch_Export(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/rest/api/search") && r.URL.Query().Get("start") == "":
+			_, _ = w.Write([]byte(`{"results": [
+				{"content": {"id": "1", "type": "page", "title": "Runbook: DB"}},
+				{"content": {"id": "9", "type": "attachment", "title": "diagram.png"}}
+			], "start": 0, "size": 2, "totalSize": 3}`))
+		case strings.Contains(r.URL.Path, "/rest/api/search"):
+			assert.Equal(t, "2", r.URL.Query().Get("start"))
+			_, _ = w.Write([]byte(`{"results": [
+				{"content": {"id": "2", "type": "page", "title": "Runbook: DB"}}
+			], "start": 2, "size": 1, "totalSize": 3}`))
+		case strings.HasSuffix(r.URL.Path, "/labels"):
+			_, _ = w.Write([]byte(`{"results": [{"name": "runbook"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/spaces/100"):
+			_, _ = w.Write([]byte(`{"id": "100", "key": "OPS"}`))
+		case strings.Contains(r.URL.Path, "/pages/"):
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			_, _ = w.Write([]byte(`{"id": "` + id + `", "title": "Runbook: DB", "spaceId": "100", "version": {"number": 4},
+				"body": {"storage": {"value": "<p>Restart page ` + id + `</p>"}}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "out")
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &searchOptions{cql: "label=runbook", limit: 25, exportDir: dir, noColor: true}
+	require.NoError(t, runSearch(opts, client))
+
+	first, err := os.ReadFile(filepath.Join(dir, "Runbook_ DB.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntitle: 'Runbook: DB'\nid: \"1\"\nspace: OPS\nversion: 4\nlabels:\n    - runbook\n---\nRestart page 1\n", string(first))
+
+	second, err := os.ReadFile(filepath.Join(dir, "Runbook_ DB (2).md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(second), "Restart page 2")
+
+	// Existing files are kept unless --force is given
+	err = runSearch(&searchOptions{cql: "label=runbook", limit: 25, exportDir: dir, noColor: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file already exists")
+	require.NoError(t, runSearch(&searchOptions{cql: "label=runbook", limit: 25, exportDir: dir, force: true, noColor: true}, client))
+}
+
+func TestRunSearch_ForceRequiresExport(t *testing.T) {
+	err := runSearch(&searchOptions{query: "x", limit: 25, force: true}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force requires --export")
+}
@@ -0,0 +1,229 @@
+// Package serve provides a local preview server for markdown content.
+package serve
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// versionPath is polled by preview pages to detect changes and reload.
+const versionPath = "/__cfl/version"
+
+type serveOptions struct {
+	dir  string
+	host string
+	port int
+}
+
+// NewCmdServe creates the serve command.
+func NewCmdServe() *cobra.Command {
+	opts := &serveOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "serve [directory]",
+		Short: "Preview markdown as Confluence pages in the browser",
+		Long: `Serve a local directory of markdown files as rendered HTML.
+
+Each .md file is converted with the same converter used by 'cfl page create'
+and shown the way Confluence will display it, with panels, code blocks, and
+expand sections approximated in HTML. Pages reload automatically when any
+markdown file in the directory changes.
+
+Other files (such as images) are served as-is so relative references work.`,
+		Example: `  # Preview the current directory on http://127.0.0.1:8080
+  cfl serve
+
+  # Preview a docs folder on a different port
+  cfl serve ./docs --port 9000`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			opts.dir = "."
+			if len(args) > 0 {
+				opts.dir = args[0]
+			}
+			return runServe(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.host, "host", "127.0.0.1", "Address to listen on")
+	cmd.Flags().IntVarP(&opts.port, "port", "p", 8080, "Port to listen on")
+
+	return cmd
+}
+
+func runServe(opts *serveOptions) error {
+	info, err := os.Stat(opts.dir)
+	if err != nil {
+		return fmt.Errorf("failed to open directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", opts.dir)
+	}
+
+	addr := net.JoinHostPort(opts.host, strconv.Itoa(opts.port))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	fmt.Printf("Serving %s at http://%s (Ctrl+C to stop)\n", opts.dir, listener.Addr())
+	return http.Serve(listener, newPreviewHandler(opts.dir))
+}
+
+// previewHandler serves rendered markdown, directory indexes, and static files.
+type previewHandler struct {
+	root   http.Dir
+	static http.Handler
+}
+
+func newPreviewHandler(dir string) http.Handler {
+	root := http.Dir(dir)
+	return &previewHandler{root: root, static: http.FileServer(root)}
+}
+
+func (h *previewHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := path.Clean("/" + r.URL.Path)
+
+	if name == versionPath {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = io.WriteString(w, strconv.FormatInt(h.latestModTime(), 10))
+		return
+	}
+
+	f, err := h.root.Open(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	info, err := f.Stat()
+	_ = f.Close()
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case info.IsDir():
+		h.serveIndex(w, name)
+	case isMarkdown(name):
+		h.serveMarkdown(w, name)
+	default:
+		h.static.ServeHTTP(w, r)
+	}
+}
+
+// serveMarkdown renders a markdown file as a preview page.
+func (h *previewHandler) serveMarkdown(w http.ResponseWriter, name string) {
+	f, err := h.root.Open(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := io.ReadAll(f)
+	_ = f.Close()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	fm, body, err := md.ParseFrontMatter(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	storage, err := md.ToConfluenceStorage(body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to convert markdown: %v", err), http.StatusUnprocessableEntity)
+		return
+	}
+
+	page := previewPage{
+		Title: strings.TrimSuffix(path.Base(name), path.Ext(name)),
+		Path:  name,
+		Body:  template.HTML(md.StorageToPreviewHTML(storage)), //nolint:gosec // rendered from local files the user is previewing
+	}
+	if fm != nil {
+		page.Summary = fm.Summary
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = pageTemplate.Execute(w, page)
+}
+
+// serveIndex lists the markdown files and subdirectories of a directory.
+func (h *previewHandler) serveIndex(w http.ResponseWriter, name string) {
+	f, err := h.root.Open(name)
+	if err != nil {
+		http.NotFound(w, nil)
+		return
+	}
+	entries, err := f.Readdir(-1)
+	_ = f.Close()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var links []indexLink
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		switch {
+		case e.IsDir():
+			links = append(links, indexLink{Name: e.Name() + "/", Href: path.Join(name, e.Name()) + "/"})
+		case isMarkdown(e.Name()):
+			links = append(links, indexLink{Name: e.Name(), Href: path.Join(name, e.Name())})
+		}
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].Name < links[j].Name })
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = indexTemplate.Execute(w, indexPage{Title: name, Path: name, Links: links})
+}
+
+// latestModTime returns the newest modification time (unix nanoseconds) of
+// any markdown file under the root. Preview pages reload when it changes.
+func (h *previewHandler) latestModTime() int64 {
+	var latest int64
+	_ = filepath.WalkDir(string(h.root), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && p != string(h.root) && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !isMarkdown(p) {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.ModTime().UnixNano() > latest {
+			latest = info.ModTime().UnixNano()
+		}
+		return nil
+	})
+	return latest
+}
+
+func isMarkdown(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".md", ".markdown":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package serve
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPreviewDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "guide.md"),
+		[]byte("---\nsummary: A short guide\n---\n# Guide\n\n[INFO]\nRemember this\n[/INFO]\n"), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "nested.md"), []byte("# Nested\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "image.png"), []byte("png-data"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden.md"), []byte("# Hidden\n"), 0600))
+	return dir
+}
+
+func get(t *testing.T, h http.Handler, path string) (*http.Response, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	resp := rec.Result()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestPreviewHandler_Index(t *testing.T) {
+	h := newPreviewHandler(setupPreviewDir(t))
+
+	resp, body := get(t, h, "/")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, `href="/guide.md"`)
+	assert.Contains(t, body, `href="/sub/"`)
+	assert.NotContains(t, body, "image.png")
+	assert.NotContains(t, body, ".hidden.md")
+
+	resp, body = get(t, h, "/sub/")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, `href="/sub/nested.md"`)
+}
+
+func TestPreviewHandler_Markdown(t *testing.T) {
+	h := newPreviewHandler(setupPreviewDir(t))
+
+	resp, body := get(t, h, "/guide.md")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+	assert.Contains(t, body, "<h1>Guide</h1>")
+	assert.Contains(t, body, "cfl-panel-info")
+	assert.Contains(t, body, "A short guide")
+	assert.Contains(t, body, versionPath)
+	assert.NotContains(t, body, "summary:")
+}
+
+func TestPreviewHandler_StaticFile(t *testing.T) {
+	h := newPreviewHandler(setupPreviewDir(t))
+
+	resp, body := get(t, h, "/image.png")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "png-data", body)
+}
+
+func TestPreviewHandler_NotFound(t *testing.T) {
+	h := newPreviewHandler(setupPreviewDir(t))
+
+	resp, _ := get(t, h, "/missing.md")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestPreviewHandler_PathTraversal(t *testing.T) {
+	parent := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(parent, "secret.md"), []byte("# Secret\n"), 0600))
+	root := filepath.Join(parent, "docs")
+	require.NoError(t, os.MkdirAll(root, 0700))
+
+	h := newPreviewHandler(root)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.URL.Path = "/../secret.md"
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "Secret")
+}
+
+func TestPreviewHandler_VersionChangesOnEdit(t *testing.T) {
+	dir := setupPreviewDir(t)
+	h := newPreviewHandler(dir)
+
+	_, before := get(t, h, versionPath)
+	_, err := strconv.ParseInt(before, 10, 64)
+	require.NoError(t, err)
+
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "sub", "nested.md"), later, later))
+
+	_, after := get(t, h, versionPath)
+	assert.NotEqual(t, before, after)
+}
+
+func TestRunServe_NotADirectory(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file.md")
+	require.NoError(t, os.WriteFile(file, []byte("x"), 0600))
+
+	err := runServe(&serveOptions{dir: file, host: "127.0.0.1", port: 0})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a directory")
+}
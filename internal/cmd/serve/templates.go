@@ -0,0 +1,99 @@
+package serve
+
+import "html/template"
+
+type previewPage struct {
+	Title   string
+	Path    string
+	Summary string
+	Body    template.HTML
+}
+
+type indexLink struct {
+	Name string
+	Href string
+}
+
+type indexPage struct {
+	Title string
+	Path  string
+	Links []indexLink
+}
+
+// previewStyles approximate Confluence's page styling.
+const previewStyles = `
+body { font-family: -apple-system, "Segoe UI", Roboto, Helvetica, Arial, sans-serif; color: #172b4d; max-width: 760px; margin: 2em auto; padding: 0 1em; line-height: 1.6; }
+h1, h2, h3, h4, h5, h6 { color: #172b4d; }
+a { color: #0052cc; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #dfe1e6; padding: 7px 10px; }
+th { background: #f4f5f7; }
+pre, .cfl-code { background: #f4f5f7; border: 1px solid #dfe1e6; border-radius: 3px; padding: 8px 12px; overflow-x: auto; }
+blockquote { border-left: 2px solid #dfe1e6; margin-left: 0; padding-left: 1em; color: #5e6c84; }
+.cfl-panel { border-radius: 3px; padding: 8px 12px; margin: 1em 0; }
+.cfl-panel-title { font-weight: 600; }
+.cfl-panel-info { background: #deebff; }
+.cfl-panel-note { background: #eae6ff; }
+.cfl-panel-warning { background: #ffebe6; }
+.cfl-panel-tip { background: #e3fcef; }
+.cfl-expand summary { cursor: pointer; color: #0052cc; }
+.cfl-toc, .cfl-macro { border: 1px dashed #c1c7d0; padding: 8px 12px; margin: 1em 0; color: #5e6c84; }
+.cfl-macro-title { font-size: 12px; text-transform: uppercase; }
+.cfl-excerpt { display: none; }
+.cfl-summary { color: #5e6c84; font-style: italic; }
+.cfl-path { color: #5e6c84; font-size: 12px; }
+`
+
+// reloadScript polls the server and reloads the page when content changes.
+const reloadScript = `
+(function () {
+  var last = null;
+  function poll() {
+    fetch("/__cfl/version", {cache: "no-store"})
+      .then(function (r) { return r.text(); })
+      .then(function (v) {
+        if (last !== null && v !== last) { location.reload(); return; }
+        last = v;
+        setTimeout(poll, 1000);
+      })
+      .catch(function () { setTimeout(poll, 2000); });
+  }
+  poll();
+})();
+`
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>` + previewStyles + `</style>
+</head>
+<body>
+<div class="cfl-path"><a href="./">&larr; index</a> {{.Path}}</div>
+<h1>{{.Title}}</h1>
+{{if .Summary}}<p class="cfl-summary">{{.Summary}}</p>{{end}}
+{{.Body}}
+<script>` + reloadScript + `</script>
+</body>
+</html>
+`))
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>` + previewStyles + `</style>
+</head>
+<body>
+<div class="cfl-path">{{.Path}}</div>
+<ul>
+{{range .Links}}<li><a href="{{.Href}}">{{.Name}}</a></li>
+{{else}}<li>No markdown files found.</li>
+{{end}}
+</ul>
+<script>` + reloadScript + `</script>
+</body>
+</html>
+`))
@@ -0,0 +1,87 @@
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type archiveOptions struct {
+	output  string
+	noColor bool
+}
+
+// NewCmdArchive creates the space archive command.
+func NewCmdArchive() *cobra.Command {
+	opts := &archiveOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "archive <space-key>",
+		Short: "Archive a space",
+		Long: `Archive a Confluence space.
+
+Archived spaces are read-only and hidden from search and the space
+directory. They can be restored from the space settings in Confluence.`,
+		Example: `  # Archive a space
+  cfl space archive OLDDOCS`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runArchive(args[0], opts, nil)
+		},
+	}
+
+	return cmd
+}
+
+func runArchive(spaceKey string, opts *archiveOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if space.Status == "archived" {
+		renderer.Warning(fmt.Sprintf("Space %s is already archived", space.Key))
+	} else if err := client.ArchiveSpace(ctx, space.Key); err != nil {
+		return fmt.Errorf("failed to archive space: %w", err)
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]string{
+			"status":   "archived",
+			"spaceKey": space.Key,
+			"name":     space.Name,
+		})
+	}
+
+	renderer.Success(fmt.Sprintf("Archived space: %s (%s)", space.Name, space.Key))
+	return nil
+}
@@ -0,0 +1,34 @@
+package space
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunArchive(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		want   []string
+	}{
+		{"current space", "current", []string{`PUT /rest/api/space/DOCS {"status":"archived"}`}},
+		{"already archived", "archived", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writes []string
+			server := newSpaceServer(t, tt.status, &writes)
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			err := runArchive("DOCS", &archiveOptions{noColor: true}, client)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, writes)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type createOptions struct {
+	name        string
+	description string
+	output      string
+	noColor     bool
+}
+
+// NewCmdCreate creates the space create command.
+func NewCmdCreate() *cobra.Command {
+	opts := &createOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create <space-key>",
+		Short: "Create a space",
+		Long: `Create a global Confluence space.
+
+Space keys can only contain letters and digits. Confluence creates a
+homepage for the new space, which can be changed later with set-home.`,
+		Example: `  # Create a space
+  cfl space create DOCS --name "Documentation"
+
+  # With a description
+  cfl space create DOCS --name "Documentation" --description "Team docs"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runCreate(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.name, "name", "n", "", "Space name (required)")
+	cmd.Flags().StringVarP(&opts.description, "description", "d", "", "Space description")
+
+	_ = cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+func runCreate(spaceKey string, opts *createOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if err := api.ValidateSpaceKey(spaceKey); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	space, err := client.CreateSpace(context.Background(), &api.CreateSpaceRequest{
+		Key:         spaceKey,
+		Name:        opts.name,
+		Description: opts.description,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create space: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(space)
+	}
+
+	renderer.Success(fmt.Sprintf("Created space: %s (%s)", space.Name, space.Key))
+	renderer.RenderKeyValue("ID", space.ID)
+	if space.HomepageID != "" {
+		renderer.RenderKeyValue("Homepage ID", space.HomepageID)
+	}
+	if space.Links.WebUI != "" {
+		renderer.RenderKeyValue("URL", client.BaseURL()+space.Links.WebUI)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package space
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// newSpaceServer serves space DOCS with the given status and records each
+// write as "METHOD path body".
+func newSpaceServer(t *testing.T, status string, writes *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "777", "key": "DOCS", "name": "Docs", "status": "` + status + `"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/pages/42":
+			w.Write([]byte(`{"id": "42", "title": "Home", "spaceId": "777"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/pages/43":
+			w.Write([]byte(`{"id": "43", "title": "Elsewhere", "spaceId": "888"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/longtask/task-7":
+			w.Write([]byte(`{"id": "task-7", "percentageComplete": 100, "finished": true, "successful": true}`))
+		case r.Method != http.MethodGet:
+			body, _ := io.ReadAll(r.Body)
+			*writes = append(*writes, r.Method+" "+r.URL.Path+" "+string(body))
+			if r.Method == http.MethodDelete {
+				w.WriteHeader(http.StatusAccepted)
+				w.Write([]byte(`{"id": "task-7"}`))
+				return
+			}
+			w.Write([]byte(`{"id": 777, "key": "DOCS", "name": "Docs", "homepage": {"id": "42"}, "_links": {"webui": "/spaces/DOCS"}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunCreate(t *testing.T) {
+	var writes []string
+	server := newSpaceServer(t, "current", &writes)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runCreate("DOCS", &createOptions{name: "Docs", description: "Team docs", noColor: true}, client)
+	require.NoError(t, err)
+
+	require.Len(t, writes, 1)
+	assert.Equal(t, `POST /rest/api/space {"key":"DOCS","name":"Docs","description":{"plain":{"value":"Team docs","representation":"plain"}}}`, writes[0])
+}
+
+func TestRunCreate_InvalidKey(t *testing.T) {
+	err := runCreate("MY DOCS", &createOptions{name: "Docs"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "letters and digits")
+}
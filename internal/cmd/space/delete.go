@@ -0,0 +1,190 @@
+package space
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/backup"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/task"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type deleteOptions struct {
+	force        bool
+	skipBackup   bool
+	wait         bool
+	backupMaxAge time.Duration
+	backupPath   string // Export registry
+	output       string
+	noColor      bool
+	stdin        io.Reader // injectable for testing
+}
+
+// NewCmdDelete creates the space delete command.
+func NewCmdDelete() *cobra.Command {
+	opts := &deleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "delete <space-key>",
+		Short: "Delete a space",
+		Long: `Delete a Confluence space and all of its content.
+
+The space is moved to the trash, from which a site administrator can
+restore it until the trash is emptied. Deletion runs in the background on
+the server, so the space may take a while to disappear. Use --wait to wait
+for it, or 'cfl task status <task-id> --wait' to follow it later.
+
+Deletion is refused unless the space was exported with
+'cfl page export --space' or 'cfl space dump' within --backup-max-age
+(default 24h) and the export directory still exists. Use --skip-backup to delete without one.
+
+You are asked to confirm by typing the space key, unless --force is given.`,
+		Example: `  # Back up and delete a space
+  cfl page export --space OLDDOCS --format html
+  cfl space delete OLDDOCS
+
+  # Delete a space that doesn't need a backup
+  cfl space delete SCRATCH --skip-backup
+
+  # Delete without confirmation
+  cfl space delete OLDDOCS --force
+
+  # Delete and wait until the space is gone
+  cfl space delete OLDDOCS --wait`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.stdin = os.Stdin // default to os.Stdin, can be overridden in tests
+			opts.backupPath = backup.DefaultPath()
+			return runDelete(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&opts.skipBackup, "skip-backup", false, "Delete without a recent export of the space")
+	cmd.Flags().BoolVar(&opts.wait, "wait", false, "Wait for the deletion to finish")
+	cmd.Flags().DurationVar(&opts.backupMaxAge, "backup-max-age", 24*time.Hour, "How recent the export of the space must be")
+
+	return cmd
+}
+
+func runDelete(spaceKey string, opts *deleteOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	var rec *backup.Record
+	if !opts.skipBackup {
+		if rec, err = findBackup(client.BaseURL(), space.Key, opts); err != nil {
+			return err
+		}
+	}
+
+	// Deleting a whole space is confirmed with its key rather than y/N
+	if !opts.force {
+		fmt.Printf("About to delete space %s (%s) and all of its content.\n", space.Name, space.Key)
+		if rec != nil {
+			fmt.Printf("Backup: %d pages exported to %s at %s.\n", rec.Pages, rec.Dir, rec.Time.Local().Format("2006-01-02 15:04"))
+		}
+		fmt.Printf("Type the space key to confirm: ")
+
+		scanner := bufio.NewScanner(opts.stdin)
+		var confirm string
+		if scanner.Scan() {
+			confirm = strings.TrimSpace(scanner.Text())
+		}
+
+		if confirm != space.Key {
+			fmt.Println("Deletion cancelled.")
+			return nil
+		}
+	}
+
+	taskID, err := client.DeleteSpace(ctx, space.Key)
+	if err != nil {
+		return fmt.Errorf("failed to delete space: %w", err)
+	}
+
+	waited := false
+	if opts.wait && taskID != "" {
+		if _, err := task.Wait(ctx, client, taskID); err != nil {
+			return fmt.Errorf("failed to delete space: %w", err)
+		}
+		waited = true
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		result := map[string]string{
+			"status":   "deleted",
+			"spaceKey": space.Key,
+			"name":     space.Name,
+			"taskId":   taskID,
+		}
+		if rec != nil {
+			result["backup"] = rec.Dir
+		}
+		return renderer.RenderJSON(result)
+	}
+
+	if waited {
+		renderer.Success(fmt.Sprintf("Deleted space: %s (%s)", space.Name, space.Key))
+		return nil
+	}
+	renderer.Success(fmt.Sprintf("Deleting space: %s (%s)", space.Name, space.Key))
+	if taskID != "" {
+		renderer.RenderKeyValue("Task ID", taskID)
+	}
+	return nil
+}
+
+// findBackup returns the latest export of a space, or an error explaining
+// how to make one if there is no export within the maximum age.
+func findBackup(site, spaceKey string, opts *deleteOptions) (*backup.Record, error) {
+	rec, err := backup.Latest(opts.backupPath, site, spaceKey)
+	if err != nil {
+		return nil, err
+	}
+
+	hint := fmt.Sprintf("run 'cfl page export --space %s' or 'cfl space dump %s' first, or use --skip-backup", spaceKey, spaceKey)
+	if rec == nil {
+		return nil, fmt.Errorf("no export of space %s found: %s", spaceKey, hint)
+	}
+	if age := time.Since(rec.Time); age > opts.backupMaxAge {
+		return nil, fmt.Errorf("latest export of space %s is %s old (max %s): %s",
+			spaceKey, age.Round(time.Minute), opts.backupMaxAge, hint)
+	}
+	return rec, nil
+}
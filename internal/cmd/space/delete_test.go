@@ -0,0 +1,98 @@
+package space
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/backup"
+)
+
+func TestRunDelete(t *testing.T) {
+	tests := []struct {
+		name  string
+		force bool
+		input string
+		want  []string
+	}{
+		{"confirmed with key", false, "DOCS\n", []string{"DELETE /rest/api/space/DOCS "}},
+		{"y is not enough", false, "y\n", nil},
+		{"wrong key", false, "DOC\n", nil},
+		{"force", true, "", []string{"DELETE /rest/api/space/DOCS "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writes []string
+			server := newSpaceServer(t, "current", &writes)
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			opts := &deleteOptions{force: tt.force, skipBackup: true, noColor: true, stdin: strings.NewReader(tt.input)}
+			err := runDelete("DOCS", opts, client)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, writes)
+		})
+	}
+}
+
+func TestRunDelete_Backup(t *testing.T) {
+	tests := []struct {
+		name    string
+		age     time.Duration // Age of the recorded export; 0 for none
+		wantErr string
+	}{
+		{"no export", 0, "no export of space DOCS found"},
+		{"stale export", 48 * time.Hour, "latest export of space DOCS is 48h0m0s old"},
+		{"fresh export", time.Hour, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writes []string
+			server := newSpaceServer(t, "current", &writes)
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			dir := t.TempDir()
+			path := filepath.Join(dir, "backups.json")
+			if tt.age > 0 {
+				require.NoError(t, backup.Add(path, backup.Record{
+					Site:     client.BaseURL(),
+					SpaceKey: "DOCS",
+					Dir:      dir,
+					Time:     time.Now().Add(-tt.age),
+				}))
+			}
+
+			opts := &deleteOptions{force: true, backupMaxAge: 24 * time.Hour, backupPath: path, noColor: true}
+			err := runDelete("DOCS", opts, client)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Contains(t, err.Error(), "cfl page export --space DOCS")
+				assert.Empty(t, writes)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"DELETE /rest/api/space/DOCS "}, writes)
+		})
+	}
+}
+
+func TestRunDelete_Wait(t *testing.T) {
+	var writes []string
+	server := newSpaceServer(t, "current", &writes)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &deleteOptions{force: true, skipBackup: true, wait: true, noColor: true}
+	err := runDelete("DOCS", opts, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DELETE /rest/api/space/DOCS "}, writes)
+}
@@ -0,0 +1,353 @@
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/backup"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/page"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+// defaultDumpConcurrency is how many pages space dump downloads at once.
+const defaultDumpConcurrency = 8
+
+// dumpFormat is the format space dump records in the backup registry.
+const dumpFormat = "markdown"
+
+type dumpOptions struct {
+	dir         string
+	attachments bool
+	force       bool
+	concurrency int
+	backupPath  string // Export registry; empty to skip recording
+	output      string
+	noColor     bool
+	stdout      io.Writer // For testing; defaults to os.Stdout
+}
+
+// dumpedPage describes a page written by space dump.
+type dumpedPage struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Version     int      `json:"version"`
+	File        string   `json:"file"`
+	Attachments []string `json:"attachments,omitempty"`
+}
+
+// dumpFile is a page and the file it is written to.
+type dumpFile struct {
+	id    string
+	title string
+	file  string
+}
+
+// NewCmdDump creates the space dump command.
+func NewCmdDump() *cobra.Command {
+	opts := &dumpOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "dump <space-key>",
+		Short: "Download every page of a space as markdown files",
+		Long: `Download every current page of a space as markdown, into a directory
+tree that mirrors the page tree.
+
+A page without children is written as <title>.md. A page with children
+becomes a directory named after it, holding its content as index.md and
+its children, which is the layout 'cfl page sync' and 'cfl page create
+--from-dir' publish. Siblings whose titles make the same file name are
+told apart by page ID.
+
+Each file starts with front matter giving the page's title, ID, version,
+space and labels, as written by 'cfl page view --front-matter'.
+
+With --attachments, each page's attachments are downloaded into a
+directory next to its file: <title>.attachments/ (or index.attachments/
+inside a page's directory).
+
+Pages are downloaded up to --concurrency at a time (default: config
+concurrency or 8). Existing files are not overwritten unless --force is
+given. The dump is recorded as a backup of the space, which 'cfl space
+delete' accepts in place of 'cfl page export --space'.`,
+		Example: `  # Dump a space into ./DOCS
+  cfl space dump DOCS
+
+  # Dump into a directory, with attachments
+  cfl space dump DOCS -O backup/docs --attachments
+
+  # List the files written as JSON
+  cfl space dump DOCS -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.backupPath = backup.DefaultPath()
+			return runDump(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.dir, "output-dir", "O", "", "Directory to write to (default: the space key)")
+	cmd.Flags().BoolVar(&opts.attachments, "attachments", false, "Download each page's attachments too")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Overwrite existing files")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 0, fmt.Sprintf("Maximum pages downloaded at once (default: config concurrency or %d)", defaultDumpConcurrency))
+
+	return cmd
+}
+
+func runDump(spaceKey string, opts *dumpOptions, client *api.Client) error {
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if opts.concurrency <= 0 {
+			opts.concurrency = cfg.Concurrency
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+	if opts.concurrency <= 0 {
+		opts.concurrency = defaultDumpConcurrency
+	}
+
+	ctx := context.Background()
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+	if space.Key != "" {
+		spaceKey = space.Key
+	}
+	pages, err := listIndexPages(ctx, client, space.ID)
+	if err != nil {
+		return err
+	}
+
+	dir := opts.dir
+	if dir == "" {
+		dir = spaceKey
+	}
+	files := dumpFiles((&treeIndex{Pages: pages}).tree(), dir, false)
+
+	// Check for existing files before writing any, so a clash doesn't leave
+	// a partial dump behind
+	if !opts.force {
+		for _, f := range files {
+			if _, err := os.Stat(f.file); err == nil {
+				return fmt.Errorf("file already exists: %s (use --force to overwrite)", f.file)
+			}
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	dumped, err := dumpPages(ctx, client, files, opts)
+	if err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	// A failure to record the backup doesn't undo the dump
+	if opts.backupPath != "" {
+		absDir, err := filepath.Abs(dir)
+		if err == nil {
+			err = backup.Add(opts.backupPath, backup.Record{
+				Site:     client.BaseURL(),
+				SpaceKey: spaceKey,
+				Dir:      absDir,
+				Format:   dumpFormat,
+				Pages:    len(dumped),
+				Time:     time.Now(),
+			})
+		}
+		if err != nil {
+			renderer.Warning(fmt.Sprintf("Dump not recorded as a backup: %v", err))
+		}
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(dumped)
+	}
+
+	rows := make([][]string, 0, len(dumped))
+	for _, d := range dumped {
+		rows = append(rows, []string{d.ID, view.Truncate(d.Title, 50), d.File, fmt.Sprintf("%d", len(d.Attachments))})
+	}
+	if len(rows) > 0 {
+		renderer.RenderTable([]string{"ID", "TITLE", "FILE", "ATTACHMENTS"}, rows)
+	}
+	renderer.Success(fmt.Sprintf("Dumped %d pages of %s to %s", len(dumped), spaceKey, dir))
+	return nil
+}
+
+// dumpFiles names the files of the pages in trees, written into dir. A page
+// with children is written as index.md in a directory named after it, which
+// holds its children; inPage says dir is such a directory, where "index" is
+// taken.
+func dumpFiles(nodes []*treeNode, dir string, inPage bool) []dumpFile {
+	used := make(map[string]bool)
+	if inPage {
+		used["index"] = true
+	}
+
+	var files []dumpFile
+	for _, node := range nodes {
+		name := page.Filename(&api.Page{ID: node.ID, Title: node.Title})
+		if used[strings.ToLower(name)] {
+			name += " (" + node.ID + ")"
+		}
+		used[strings.ToLower(name)] = true
+
+		if len(node.Children) == 0 {
+			files = append(files, dumpFile{id: node.ID, title: node.Title, file: filepath.Join(dir, name+".md")})
+			continue
+		}
+		pageDir := filepath.Join(dir, name)
+		files = append(files, dumpFile{id: node.ID, title: node.Title, file: filepath.Join(pageDir, "index.md")})
+		files = append(files, dumpFiles(node.Children, pageDir, true)...)
+	}
+	return files
+}
+
+// dumpPages downloads pages into their files, up to opts.concurrency at
+// once, and returns the first error. Other downloads are cancelled once
+// one fails.
+func dumpPages(ctx context.Context, client *api.Client, files []dumpFile, opts *dumpOptions) ([]dumpedPage, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	progress := view.NewProgress(len(files))
+	var progressMu sync.Mutex
+
+	sem := make(chan struct{}, opts.concurrency)
+	dumped := make([]dumpedPage, len(files))
+	errs := make([]error, len(files))
+	var wg sync.WaitGroup
+	for i, f := range files {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			if dumped[i], errs[i] = dumpPage(ctx, client, f, opts.attachments); errs[i] != nil {
+				cancel()
+				return
+			}
+			progressMu.Lock()
+			progress.Increment(f.title)
+			progressMu.Unlock()
+		}()
+	}
+	wg.Wait()
+	progress.Done()
+
+	// Report the failure that caused the cancellation, not the ones it caused
+	var first error
+	for _, err := range errs {
+		if err != nil && (first == nil || errors.Is(first, context.Canceled)) {
+			first = err
+		}
+	}
+	if first != nil {
+		return nil, first
+	}
+	return dumped, nil
+}
+
+// dumpPage writes a page as markdown with front matter, and its
+// attachments when attachments is set.
+func dumpPage(ctx context.Context, client *api.Client, f dumpFile, attachments bool) (dumpedPage, error) {
+	p, content, err := page.Download(ctx, client, f.id, 0)
+	if err != nil {
+		return dumpedPage{}, fmt.Errorf("failed to download page %s: %w", f.id, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(f.file), 0755); err != nil {
+		return dumpedPage{}, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(f.file, []byte(content), 0644); err != nil {
+		return dumpedPage{}, fmt.Errorf("failed to write %s: %w", f.file, err)
+	}
+
+	result := dumpedPage{ID: p.ID, Title: p.Title, File: f.file}
+	if p.Version != nil {
+		result.Version = p.Version.Number
+	}
+	if attachments {
+		dir := strings.TrimSuffix(f.file, ".md") + ".attachments"
+		if result.Attachments, err = dumpAttachments(ctx, client, p.ID, dir); err != nil {
+			return dumpedPage{}, err
+		}
+	}
+	return result, nil
+}
+
+// dumpAttachments downloads every attachment of a page into dir and
+// returns the files written. dir is only created when there are any.
+func dumpAttachments(ctx context.Context, client *api.Client, pageID, dir string) ([]string, error) {
+	var files []string
+	for att, err := range client.AttachmentsIter(ctx, pageID, &api.ListAttachmentsOptions{Limit: 250}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list attachments of page %s: %w", pageID, err)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+		file := filepath.Join(dir, page.Filename(&api.Page{ID: att.ID, Title: att.Title}))
+		if err := downloadAttachment(ctx, client, att.ID, file); err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// downloadAttachment writes an attachment's content to file.
+func downloadAttachment(ctx context.Context, client *api.Client, attachmentID, file string) error {
+	content, err := client.DownloadAttachment(ctx, attachmentID)
+	if err != nil {
+		return fmt.Errorf("failed to download attachment %s: %w", attachmentID, err)
+	}
+	defer func() { _ = content.Close() }()
+
+	out, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", file, err)
+	}
+	if _, err := io.Copy(out, content); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return out.Close()
+}
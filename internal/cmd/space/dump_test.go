@@ -0,0 +1,151 @@
+package space
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/backup"
+)
+
+// mockDumpServer serves space DOCS: Home (1) with children Guide (2) and
+// guide (5), whose names clash, and Guide's child Install (4). Install has
+// an attachment. Fetching page failID fails.
+func mockDumpServer(t *testing.T, failID string) *httptest.Server {
+	titles := map[string]string{"1": "Home", "2": "Guide", "4": "Install", "5": "guide"}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v2/"), "/")
+		switch {
+		case r.URL.Path == "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "777", "key": "DOCS"}]}`))
+		case r.URL.Path == "/api/v2/spaces/777":
+			w.Write([]byte(`{"id": "777", "key": "DOCS"}`))
+		case r.URL.Path == "/api/v2/spaces/777/pages":
+			w.Write([]byte(`{"results": [
+				{"id": "2", "title": "Guide", "parentId": "1", "position": 0},
+				{"id": "4", "title": "Install", "parentId": "2"},
+				{"id": "1", "title": "Home"},
+				{"id": "5", "title": "guide", "parentId": "1", "position": 1}
+			]}`))
+		case len(parts) == 2 && parts[0] == "pages":
+			if parts[1] == failID {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"message": "boom"}`))
+				return
+			}
+			fmt.Fprintf(w, `{"id": %q, "title": %q, "spaceId": "777", "version": {"number": 3}, "body": {"storage": {"value": "<p>About %s</p>"}}}`,
+				parts[1], titles[parts[1]], titles[parts[1]])
+		case len(parts) == 3 && parts[0] == "pages" && parts[2] == "labels":
+			w.Write([]byte(`{"results": []}`))
+		case r.URL.Path == "/api/v2/pages/4/attachments":
+			w.Write([]byte(`{"results": [{"id": "att1", "title": "diagram.png"}]}`))
+		case len(parts) == 3 && parts[0] == "pages" && parts[2] == "attachments":
+			w.Write([]byte(`{"results": []}`))
+		case r.URL.Path == "/api/v2/attachments/att1":
+			w.Write([]byte(`{"id": "att1", "title": "diagram.png", "downloadLink": "/download/attachments/4/diagram.png"}`))
+		case r.URL.Path == "/download/attachments/4/diagram.png":
+			w.Write([]byte("PNG"))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunDump(t *testing.T) {
+	server := mockDumpServer(t, "")
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	dir := filepath.Join(t.TempDir(), "out")
+	backups := filepath.Join(t.TempDir(), "exports.json")
+	var out bytes.Buffer
+	opts := &dumpOptions{dir: dir, attachments: true, concurrency: 2, backupPath: backups, output: "json", stdout: &out}
+	require.NoError(t, runDump("DOCS", opts, client))
+
+	// Pages with children become directories holding index.md
+	home := filepath.Join(dir, "Home", "index.md")
+	guide := filepath.Join(dir, "Home", "Guide", "index.md")
+	install := filepath.Join(dir, "Home", "Guide", "Install.md")
+	other := filepath.Join(dir, "Home", "guide (5).md")
+	for _, f := range []string{home, guide, install, other} {
+		assert.FileExists(t, f)
+	}
+
+	content, err := os.ReadFile(install)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "title: Install")
+	assert.Contains(t, string(content), "id: \"4\"")
+	assert.Contains(t, string(content), "version: 3")
+	assert.Contains(t, string(content), "space: DOCS")
+	assert.Contains(t, string(content), "About Install")
+
+	attachment := filepath.Join(dir, "Home", "Guide", "Install.attachments", "diagram.png")
+	data, err := os.ReadFile(attachment)
+	require.NoError(t, err)
+	assert.Equal(t, "PNG", string(data))
+	assert.NoDirExists(t, filepath.Join(dir, "Home", "index.attachments"))
+
+	var dumped []dumpedPage
+	require.NoError(t, json.Unmarshal(out.Bytes(), &dumped))
+	require.Len(t, dumped, 4)
+	assert.Equal(t, dumpedPage{ID: "4", Title: "Install", Version: 3, File: install, Attachments: []string{attachment}}, dumped[2])
+
+	records, err := backup.Load(backups)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "DOCS", records[0].SpaceKey)
+	assert.Equal(t, dumpFormat, records[0].Format)
+	assert.Equal(t, 4, records[0].Pages)
+
+	// A second dump doesn't overwrite without --force
+	err = runDump("DOCS", &dumpOptions{dir: dir, output: "json", stdout: &out}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "use --force to overwrite")
+
+	opts = &dumpOptions{dir: dir, force: true, output: "json", stdout: &out}
+	require.NoError(t, runDump("DOCS", opts, client))
+}
+
+func TestRunDump_PageFails(t *testing.T) {
+	server := mockDumpServer(t, "4")
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	var out bytes.Buffer
+	err := runDump("DOCS", &dumpOptions{dir: t.TempDir(), concurrency: 1, output: "json", stdout: &out}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to download page 4")
+	assert.Empty(t, out.String())
+}
+
+func TestDumpFiles(t *testing.T) {
+	nodes := []*treeNode{
+		{ID: "1", Title: "Docs", Children: []*treeNode{
+			{ID: "2", Title: "Index"},
+			{ID: "3", Title: "a/b"},
+		}},
+		{ID: "4", Title: "DOCS"},
+	}
+
+	var got []string
+	for _, f := range dumpFiles(nodes, "out", false) {
+		got = append(got, f.id+" "+filepath.ToSlash(f.file))
+	}
+	assert.Equal(t, []string{
+		"1 out/Docs/index.md",
+		"2 out/Docs/Index (2).md",
+		"3 out/Docs/a_b.md",
+		"4 out/DOCS (4).md",
+	}, got)
+}
@@ -16,6 +16,7 @@ import (
 type listOptions struct {
 	limit     int
 	cursor    string
+	all       bool
 	spaceType string
 	output    string
 	noColor   bool
@@ -40,6 +41,9 @@ func NewCmdList() *cobra.Command {
   cfl space list --limit 50
   cfl space list --cursor "eyJpZCI6MTIzfQ=="
 
+  # List every space, following pagination
+  cfl space list --all
+
   # Output as JSON
   cfl space list -o json`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -52,11 +56,15 @@ func NewCmdList() *cobra.Command {
 
 	cmd.Flags().IntVarP(&opts.limit, "limit", "l", 25, "Maximum number of spaces to return")
 	cmd.Flags().StringVar(&opts.cursor, "cursor", "", "Pagination cursor from a previous request")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Return all spaces, following pagination (ignores --limit)")
 	cmd.Flags().StringVarP(&opts.spaceType, "type", "t", "", "Filter by space type (global, personal)")
 
 	return cmd
 }
 
+// listAllBatchSize is the number of spaces requested at a time with --all.
+const listAllBatchSize = 250
+
 func runList(opts *listOptions, client *api.Client) error {
 	// Validate output format
 	if err := view.ValidateFormat(opts.output); err != nil {
@@ -67,13 +75,16 @@ func runList(opts *listOptions, client *api.Client) error {
 	if opts.limit < 0 {
 		return fmt.Errorf("invalid limit: %d (must be >= 0)", opts.limit)
 	}
+	if opts.all && opts.cursor != "" {
+		return fmt.Errorf("--all and --cursor cannot be used together")
+	}
 
 	// Render output
 	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
 
 	// Handle limit 0 - return empty list
-	if opts.limit == 0 {
-		if opts.output == "json" {
+	if opts.limit == 0 && !opts.all {
+		if view.IsStructured(opts.output) {
 			return renderer.RenderJSON([]interface{}{})
 		}
 		renderer.RenderText("No spaces found.")
@@ -91,7 +102,7 @@ func runList(opts *listOptions, client *api.Client) error {
 			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
 		}
 
-		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken)
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
 	}
 
 	// List spaces
@@ -101,12 +112,27 @@ func runList(opts *listOptions, client *api.Client) error {
 		Type:   opts.spaceType,
 	}
 
-	result, err := client.ListSpaces(context.Background(), apiOpts)
-	if err != nil {
-		return fmt.Errorf("failed to list spaces: %w", err)
+	var spaces []api.Space
+	var next string
+	if opts.all {
+		apiOpts.Limit = listAllBatchSize
+		var err error
+		spaces, err = api.ListAll(client.SpacesIter(context.Background(), apiOpts, api.WithPrefetch()))
+		if err != nil {
+			return fmt.Errorf("failed to list spaces: %w", err)
+		}
+	} else {
+		result, err := client.ListSpaces(context.Background(), apiOpts)
+		if err != nil {
+			return fmt.Errorf("failed to list spaces: %w", err)
+		}
+		spaces, next = result.Results, result.Links.Next
 	}
 
-	if len(result.Results) == 0 {
+	if len(spaces) == 0 {
+		if view.IsStructured(opts.output) {
+			return renderer.RenderJSON([]interface{}{})
+		}
 		renderer.RenderText("No spaces found.")
 		return nil
 	}
@@ -114,7 +140,7 @@ func runList(opts *listOptions, client *api.Client) error {
 	headers := []string{"KEY", "NAME", "TYPE", "DESCRIPTION"}
 	var rows [][]string
 
-	for _, space := range result.Results {
+	for _, space := range spaces {
 		desc := ""
 		if space.Description != nil && space.Description.Plain != nil {
 			desc = view.Truncate(space.Description.Plain.Value, 50)
@@ -127,14 +153,14 @@ func runList(opts *listOptions, client *api.Client) error {
 		})
 	}
 
-	renderer.RenderList(headers, rows, result.HasMore())
+	renderer.RenderList(headers, rows, next != "")
 
-	if result.HasMore() && opts.output != "json" {
-		cursor := extractCursor(result.Links.Next)
+	if next != "" && !view.IsStructured(opts.output) {
+		cursor := extractCursor(next)
 		if cursor != "" {
-			fmt.Fprintf(os.Stderr, "\n(more results available, use --cursor %q to see the next page)\n", cursor)
+			fmt.Fprintf(os.Stderr, "\n(more results available, use --cursor %q or --all to see the next page)\n", cursor)
 		} else {
-			fmt.Fprintf(os.Stderr, "\n(showing first %d results, use --limit to see more)\n", len(result.Results))
+			fmt.Fprintf(os.Stderr, "\n(showing first %d results, use --limit or --all to see more)\n", len(spaces))
 		}
 	}
 
@@ -3,6 +3,7 @@ package space
 import (
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -278,3 +279,43 @@ func TestRunList_NullDescription(t *testing.T) {
 	err := runList(opts, client)
 	require.NoError(t, err)
 }
+
+func TestRunList_All(t *testing.T) {
+	var mu sync.Mutex
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "250", r.URL.Query().Get("limit"))
+		mu.Lock()
+		cursors = append(cursors, r.URL.Query().Get("cursor"))
+		mu.Unlock()
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"results": [{"id": "1", "key": "DEV"}], "_links": {"next": "/api/v2/spaces?cursor=next"}}`))
+			return
+		}
+		w.Write([]byte(`{"results": [{"id": "2", "key": "DOCS"}]}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	opts := &listOptions{
+		limit:   25,
+		all:     true,
+		noColor: true,
+	}
+
+	err := runList(opts, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "next"}, cursors)
+}
+
+func TestRunList_AllWithCursor(t *testing.T) {
+	opts := &listOptions{
+		limit:  25,
+		all:    true,
+		cursor: "abc",
+	}
+
+	err := runList(opts, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--all and --cursor cannot be used together")
+}
@@ -0,0 +1,105 @@
+package space
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/browser"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type openOptions struct {
+	print   bool
+	output  string
+	noColor bool
+	stdout  io.Writer          // For testing; defaults to os.Stdout
+	open    func(string) error // For testing; defaults to browser.Open
+}
+
+// NewCmdOpen creates the space open command.
+func NewCmdOpen() *cobra.Command {
+	opts := &openOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "open <space-key>",
+		Short: "Open a space in the browser",
+		Long: `Open a space's overview in the default browser. With --print, the
+space's URL is printed instead of opened.`,
+		Example: `  # Open a space
+  cfl space open DOCS
+
+  # Print the URL instead
+  cfl space open DOCS --print`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runOpen(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.print, "print", false, "Print the URL instead of opening it")
+
+	return cmd
+}
+
+func runOpen(spaceKey string, opts *openOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	space, err := client.GetSpaceByKey(context.Background(), spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	spaceURL := client.BaseURL() + space.Links.WebUI
+	if space.Links.WebUI == "" {
+		spaceURL = client.BaseURL() + "/spaces/" + url.PathEscape(space.Key)
+	}
+
+	if view.IsStructured(opts.output) {
+		renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+		return renderer.RenderJSON(map[string]string{"id": space.ID, "key": space.Key, "url": spaceURL})
+	}
+
+	if opts.print {
+		stdout := opts.stdout
+		if stdout == nil {
+			stdout = os.Stdout
+		}
+		_, err = fmt.Fprintln(stdout, spaceURL)
+		return err
+	}
+
+	open := opts.open
+	if open == nil {
+		open = browser.Open
+	}
+	if err := open(spaceURL); err != nil {
+		return fmt.Errorf("failed to open %s: %w", spaceURL, err)
+	}
+	return nil
+}
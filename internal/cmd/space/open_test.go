@@ -0,0 +1,44 @@
+package space
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("keys") {
+		case "DOCS":
+			w.Write([]byte(`{"results": [{"id": "777", "key": "DOCS", "_links": {"webui": "/spaces/DOCS/overview"}}]}`))
+		case "OLD":
+			w.Write([]byte(`{"results": [{"id": "778", "key": "OLD"}]}`))
+		default:
+			w.Write([]byte(`{"results": []}`))
+		}
+	}))
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	var opened string
+	opts := &openOptions{open: func(url string) error {
+		opened = url
+		return nil
+	}}
+	require.NoError(t, runOpen("DOCS", opts, client))
+	assert.Equal(t, server.URL+"/spaces/DOCS/overview", opened)
+
+	var out bytes.Buffer
+	require.NoError(t, runOpen("OLD", &openOptions{print: true, stdout: &out}, client))
+	assert.Equal(t, server.URL+"/spaces/OLD\n", out.String())
+
+	err := runOpen("NOPE", &openOptions{print: true, stdout: &out}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to find space 'NOPE'")
+}
@@ -0,0 +1,372 @@
+package space
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/principal"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type permissionsOptions struct {
+	user       string
+	group      string
+	operations []string
+	output     string
+	noColor    bool
+	stdout     io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdPermissions creates the space permissions command.
+func NewCmdPermissions() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "permissions",
+		Aliases: []string{"permission", "perms"},
+		Short:   "Manage space permissions",
+		Long: `Commands for listing, granting, and revoking the permissions users and
+groups have on a space.
+
+A permission is an operation on a target type, written operation:target, as
+in read:space, create:page or delete:comment; the target defaults to space.
+Users are given by account ID, or "me"; groups by name.`,
+	}
+
+	cmd.AddCommand(NewCmdPermissionsList())
+	cmd.AddCommand(NewCmdPermissionsAdd())
+	cmd.AddCommand(NewCmdPermissionsRemove())
+
+	return cmd
+}
+
+// NewCmdPermissionsList creates the space permissions list command.
+func NewCmdPermissionsList() *cobra.Command {
+	opts := &permissionsOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list <space-key>",
+		Aliases: []string{"ls"},
+		Short:   "List the permissions on a space",
+		Long: `List the permissions granted on a space, optionally only those of a user or
+group. Principals are shown by account or group ID.`,
+		Example: `  # List a space's permissions
+  cfl space permissions list DEV
+
+  # What a group may do, as JSON for an audit
+  cfl space permissions list DEV --group confluence-users -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runPermissionsList(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.user, "user", "", principal.UserUsage)
+	cmd.Flags().StringVar(&opts.group, "group", "", principal.GroupUsage)
+	cmd.MarkFlagsMutuallyExclusive("user", "group")
+
+	return cmd
+}
+
+// NewCmdPermissionsAdd creates the space permissions add command.
+func NewCmdPermissionsAdd() *cobra.Command {
+	opts := &permissionsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "add <space-key>",
+		Short: "Grant permissions on a space",
+		Long: `Grant a user or group permissions on a space. Granting a permission the
+user or group already has is an error.`,
+		Example: `  # Let a group read the space
+  cfl space permissions add DEV --group contractors --operation read
+
+  # Let a user create pages and comment
+  cfl space permissions add DEV --user 5b10ac8d82e05b22cc7d4ef5 --operation create:page --operation create:comment`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runPermissionsAdd(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.user, "user", "", principal.UserUsage)
+	cmd.Flags().StringVar(&opts.group, "group", "", principal.GroupUsage)
+	cmd.Flags().StringArrayVar(&opts.operations, "operation", nil, "Permission to grant as operation[:target] (repeatable)")
+	cmd.MarkFlagsMutuallyExclusive("user", "group")
+	_ = cmd.MarkFlagRequired("operation")
+
+	return cmd
+}
+
+// NewCmdPermissionsRemove creates the space permissions remove command.
+func NewCmdPermissionsRemove() *cobra.Command {
+	opts := &permissionsOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "remove <space-key> [permission-id]...",
+		Aliases: []string{"rm"},
+		Short:   "Revoke permissions on a space",
+		Long: `Revoke permissions on a space, by the IDs 'cfl space permissions list'
+shows, or all permissions of a user or group, optionally only the operations
+given with --operation.`,
+		Example: `  # Revoke a permission by ID
+  cfl space permissions remove DEV 4567
+
+  # Stop a group deleting pages
+  cfl space permissions remove DEV --group contractors --operation delete:page
+
+  # Revoke everything a user was granted
+  cfl space permissions remove DEV --user 5b10ac8d82e05b22cc7d4ef5`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runPermissionsRemove(args[0], args[1:], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.user, "user", "", principal.UserUsage)
+	cmd.Flags().StringVar(&opts.group, "group", "", principal.GroupUsage)
+	cmd.Flags().StringArrayVar(&opts.operations, "operation", nil, "Only revoke this permission, as operation[:target] (repeatable)")
+	cmd.MarkFlagsMutuallyExclusive("user", "group")
+
+	return cmd
+}
+
+func runPermissionsList(spaceKey string, opts *permissionsOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	selected, err := principal.Resolve(ctx, client, opts.user, opts.group)
+	if err != nil {
+		return err
+	}
+	perms, err := spacePermissions(ctx, client, spaceKey, selected, nil)
+	if err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(perms)
+	}
+
+	if len(perms) == 0 {
+		renderer.RenderText("No permissions found.")
+		return nil
+	}
+
+	headers := []string{"ID", "TYPE", "PRINCIPAL", "PERMISSION"}
+	var rows [][]string
+	for _, p := range perms {
+		rows = append(rows, []string{p.ID, p.Principal.Type, p.Principal.ID, p.Operation.String()})
+	}
+	renderer.RenderTable(headers, rows)
+	return nil
+}
+
+func runPermissionsAdd(spaceKey string, opts *permissionsOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if len(opts.operations) == 0 {
+		return fmt.Errorf("--operation is required")
+	}
+	ops, err := parseOperations(opts.operations)
+	if err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	selected, err := principal.Require(ctx, client, opts.user, opts.group)
+	if err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	added := make([]*api.SpacePermission, 0, len(ops))
+	for _, op := range ops {
+		perm, err := client.AddSpacePermission(ctx, spaceKey, selected, op)
+		if err != nil {
+			return fmt.Errorf("failed to grant %s: %w", op, err)
+		}
+		added = append(added, perm)
+		if !view.IsStructured(opts.output) {
+			renderer.Success(fmt.Sprintf("Granted %s on %s to %s %s", op, spaceKey, selected.Type, selected.ID))
+		}
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(added)
+	}
+	return nil
+}
+
+func runPermissionsRemove(spaceKey string, permissionIDs []string, opts *permissionsOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	hasSelector := opts.user != "" || opts.group != ""
+	if len(permissionIDs) > 0 && (hasSelector || len(opts.operations) > 0) {
+		return fmt.Errorf("permission IDs cannot be combined with --user, --group or --operation")
+	}
+	if len(permissionIDs) == 0 && !hasSelector {
+		return fmt.Errorf("give permission IDs, or --user or --group")
+	}
+	ops, err := parseOperations(opts.operations)
+	if err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	if hasSelector {
+		selected, err := principal.Require(ctx, client, opts.user, opts.group)
+		if err != nil {
+			return err
+		}
+		perms, err := spacePermissions(ctx, client, spaceKey, &selected, ops)
+		if err != nil {
+			return err
+		}
+		if len(perms) == 0 {
+			return fmt.Errorf("%s %s has no matching permissions on %s", selected.Type, selected.ID, spaceKey)
+		}
+		for _, p := range perms {
+			permissionIDs = append(permissionIDs, p.ID)
+		}
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	for _, id := range permissionIDs {
+		if err := client.RemoveSpacePermission(ctx, spaceKey, id); err != nil {
+			return fmt.Errorf("failed to revoke permission %s: %w", id, err)
+		}
+		if !view.IsStructured(opts.output) {
+			renderer.Success(fmt.Sprintf("Revoked permission %s on %s", id, spaceKey))
+		}
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]any{
+			"spaceKey": spaceKey,
+			"removed":  permissionIDs,
+		})
+	}
+	return nil
+}
+
+// spacePermissions returns the permissions on a space, only those of a
+// principal when it isn't nil, and only the given operations if any.
+func spacePermissions(ctx context.Context, client *api.Client, spaceKey string, p *api.Principal, ops []api.PermissionOperation) ([]api.SpacePermission, error) {
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+	all, err := api.ListAll(client.SpacePermissionsIter(ctx, space.ID, &api.ListSpacePermissionsOptions{Limit: 250}, api.WithPrefetch()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+
+	perms := make([]api.SpacePermission, 0, len(all))
+	for _, perm := range all {
+		if p != nil && perm.Principal != *p {
+			continue
+		}
+		if len(ops) > 0 && !containsOperation(ops, perm.Operation) {
+			continue
+		}
+		perms = append(perms, perm)
+	}
+	return perms, nil
+}
+
+// parseOperations parses permissions given as operation[:target].
+func parseOperations(values []string) ([]api.PermissionOperation, error) {
+	ops := make([]api.PermissionOperation, 0, len(values))
+	for _, v := range values {
+		key, target, _ := strings.Cut(strings.ToLower(strings.TrimSpace(v)), ":")
+		if target == "" {
+			target = "space"
+		}
+		if key == "" {
+			return nil, fmt.Errorf("invalid permission %q: must be operation[:target], as in read or create:page", v)
+		}
+		ops = append(ops, api.PermissionOperation{Key: key, TargetType: target})
+	}
+	return ops, nil
+}
+
+func containsOperation(ops []api.PermissionOperation, op api.PermissionOperation) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package space
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// newPermissionsServer serves space DEV with three permissions, recording
+// the permissions added and removed.
+func newPermissionsServer(t *testing.T, writes *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "98304", "key": "DEV", "name": "Development"}]}`))
+		case r.URL.Path == "/api/v2/spaces/98304/permissions":
+			w.Write([]byte(`{"results": [
+				{"id": "1", "principal": {"type": "user", "id": "abc"}, "operation": {"key": "read", "targetType": "space"}},
+				{"id": "2", "principal": {"type": "group", "id": "g-1"}, "operation": {"key": "read", "targetType": "space"}},
+				{"id": "3", "principal": {"type": "group", "id": "g-1"}, "operation": {"key": "delete", "targetType": "page"}}
+			]}`))
+		case r.URL.Path == "/rest/api/group/by-name":
+			w.Write([]byte(`{"type": "group", "name": "contractors", "id": "g-1"}`))
+		case r.Method == "POST" && r.URL.Path == "/rest/api/space/DEV/permission":
+			body, _ := io.ReadAll(r.Body)
+			*writes = append(*writes, "POST "+string(body))
+			w.Write([]byte(`{"id": 9, "subject": {"type": "group", "identifier": "g-1"}, "operation": {"key": "create", "target": "page"}}`))
+		case r.Method == "DELETE":
+			*writes = append(*writes, "DELETE "+r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunPermissionsList(t *testing.T) {
+	var writes []string
+	server := newPermissionsServer(t, &writes)
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	var stdout bytes.Buffer
+	err := runPermissionsList("DEV", &permissionsOptions{noColor: true, stdout: &stdout}, client)
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "delete:page")
+	assert.Contains(t, stdout.String(), "abc")
+
+	stdout.Reset()
+	err = runPermissionsList("DEV", &permissionsOptions{group: "contractors", output: "json", stdout: &stdout}, client)
+	require.NoError(t, err)
+	var perms []api.SpacePermission
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &perms))
+	require.Len(t, perms, 2)
+	assert.Equal(t, "2", perms[0].ID)
+	assert.Equal(t, "3", perms[1].ID)
+}
+
+func TestRunPermissionsAdd(t *testing.T) {
+	var writes []string
+	server := newPermissionsServer(t, &writes)
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	opts := &permissionsOptions{group: "contractors", operations: []string{"create:page", "READ"}, noColor: true, stdout: &bytes.Buffer{}}
+	require.NoError(t, runPermissionsAdd("DEV", opts, client))
+	assert.Equal(t, []string{
+		`POST {"subject":{"type":"group","identifier":"g-1"},"operation":{"key":"create","target":"page"}}`,
+		`POST {"subject":{"type":"group","identifier":"g-1"},"operation":{"key":"read","target":"space"}}`,
+	}, writes)
+}
+
+func TestRunPermissionsRemove(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []string
+		opts *permissionsOptions
+		want []string
+	}{
+		{"by ID", []string{"7"}, &permissionsOptions{}, []string{"DELETE /rest/api/space/DEV/permission/7"}},
+		{"all of a group", nil, &permissionsOptions{group: "contractors"},
+			[]string{"DELETE /rest/api/space/DEV/permission/2", "DELETE /rest/api/space/DEV/permission/3"}},
+		{"one operation of a group", nil, &permissionsOptions{group: "contractors", operations: []string{"delete:page"}},
+			[]string{"DELETE /rest/api/space/DEV/permission/3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writes []string
+			server := newPermissionsServer(t, &writes)
+			defer server.Close()
+			client := api.NewClient(server.URL, "test@example.com", "token")
+
+			tt.opts.noColor, tt.opts.stdout = true, &bytes.Buffer{}
+			require.NoError(t, runPermissionsRemove("DEV", tt.ids, tt.opts, client))
+			assert.Equal(t, tt.want, writes)
+		})
+	}
+}
+
+func TestRunPermissions_Errors(t *testing.T) {
+	var writes []string
+	server := newPermissionsServer(t, &writes)
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	err := runPermissionsAdd("DEV", &permissionsOptions{operations: []string{"read"}}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--user or --group is required")
+
+	err = runPermissionsAdd("DEV", &permissionsOptions{user: "abc", operations: []string{":page"}}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid permission")
+
+	err = runPermissionsRemove("DEV", []string{"7"}, &permissionsOptions{user: "abc"}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be combined")
+
+	err = runPermissionsRemove("DEV", nil, &permissionsOptions{}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "give permission IDs")
+
+	err = runPermissionsRemove("DEV", nil, &permissionsOptions{user: "nobody"}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no matching permissions")
+	assert.Empty(t, writes)
+}
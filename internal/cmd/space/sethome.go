@@ -0,0 +1,107 @@
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type setHomeOptions struct {
+	output  string
+	noColor bool
+}
+
+// NewCmdSetHome creates the space set-home command.
+func NewCmdSetHome() *cobra.Command {
+	opts := &setHomeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "set-home <space-key> <page-id>",
+		Short: "Set a space's homepage",
+		Long: `Make a page the homepage of a space.
+
+The page must belong to the space.`,
+		Example: `  # Make page 12345 the homepage of DOCS
+  cfl space set-home DOCS 12345`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runSetHome(args[0], args[1], opts, nil)
+		},
+	}
+
+	return cmd
+}
+
+func runSetHome(spaceKey, pageID string, opts *setHomeOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	pageID, err := pageref.Resolve(context.Background(), client, pageID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	page, err := spacePage(ctx, client, space, pageID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.SetSpaceHomepage(ctx, space.Key, page.ID); err != nil {
+		return fmt.Errorf("failed to set homepage: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]string{
+			"spaceKey":      space.Key,
+			"homepageId":    page.ID,
+			"homepageTitle": page.Title,
+		})
+	}
+
+	renderer.Success(fmt.Sprintf("Set homepage of %s to: %s", space.Key, page.Title))
+	return nil
+}
+
+// spacePage returns a page that must belong to space, for use as its homepage.
+func spacePage(ctx context.Context, client *api.Client, space *api.Space, pageID string) (*api.Page, error) {
+	page, err := client.GetPage(ctx, pageID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+	if page.SpaceID != space.ID {
+		return nil, fmt.Errorf("page %s is not in space %s", pageID, space.Key)
+	}
+	return page, nil
+}
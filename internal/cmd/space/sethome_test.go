@@ -0,0 +1,55 @@
+package space
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func newSetHomeServer(t *testing.T, pageSpaceID string, updated *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "777", "key": "DOCS"}]}`))
+		case r.URL.Path == "/api/v2/pages/42":
+			w.Write([]byte(`{"id": "42", "title": "New Home", "spaceId": "` + pageSpaceID + `"}`))
+		case r.URL.Path == "/rest/api/space/DOCS" && r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			*updated = string(body)
+			w.Write([]byte(`{"key": "DOCS"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestRunSetHome_Success(t *testing.T) {
+	var updated string
+	server := newSetHomeServer(t, "777", &updated)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runSetHome("DOCS", "42", &setHomeOptions{noColor: true}, client)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"homepage": {"id": "42"}}`, updated)
+}
+
+func TestRunSetHome_PageInOtherSpace(t *testing.T) {
+	var updated string
+	server := newSetHomeServer(t, "888", &updated)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runSetHome("DOCS", "42", &setHomeOptions{noColor: true}, client)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "page 42 is not in space DOCS")
+	assert.Empty(t, updated)
+}
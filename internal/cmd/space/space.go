@@ -11,10 +11,22 @@ func NewCmdSpace() *cobra.Command {
 		Use:     "space",
 		Aliases: []string{"spaces"},
 		Short:   "Manage Confluence spaces",
-		Long:    `Commands for listing and viewing Confluence spaces.`,
+		Long:    `Commands for listing, viewing, creating and managing Confluence spaces.`,
 	}
 
 	cmd.AddCommand(NewCmdList())
+	cmd.AddCommand(NewCmdView())
+	cmd.AddCommand(NewCmdOpen())
+	cmd.AddCommand(NewCmdSetHome())
+	cmd.AddCommand(NewCmdCreate())
+	cmd.AddCommand(NewCmdUpdate())
+	cmd.AddCommand(NewCmdArchive())
+	cmd.AddCommand(NewCmdDelete())
+	cmd.AddCommand(NewCmdWatch())
+	cmd.AddCommand(NewCmdUnwatch())
+	cmd.AddCommand(NewCmdPermissions())
+	cmd.AddCommand(NewCmdTree())
+	cmd.AddCommand(NewCmdDump())
 
 	return cmd
 }
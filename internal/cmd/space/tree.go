@@ -0,0 +1,278 @@
+package space
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+// defaultTreeConcurrency is how many label lookups space tree runs at once.
+const defaultTreeConcurrency = 8
+
+// Formats of space tree.
+const (
+	treeFormatText = "text"
+	treeFormatJSON = "json"
+	treeFormatXML  = "xml"
+	treeFormatOPML = "opml"
+)
+
+type treeOptions struct {
+	format      string
+	refresh     bool
+	concurrency int
+	cachePath   string // Tree index cache; no caching when empty
+	output      string
+	noColor     bool
+}
+
+// NewCmdTree creates the space tree command.
+func NewCmdTree() *cobra.Command {
+	opts := &treeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "tree [space-key]",
+		Short: "Export the page hierarchy of a space",
+		Long: `Export the page hierarchy of a space, with each page's URL and labels.
+
+--format selects the output:
+  text  An indented tree (default)
+  json  Nested pages, each with its children under "children"
+  xml   A <space> element with nested <page> elements and their <label>s
+  opml  An OPML 2.0 outline, for mind-mapping and navigation tools; labels
+        are given as categories
+
+The space defaults to default_space in config. The page list is built from
+an index of the space, cached for 15 minutes so repeated exports don't list
+the space again; --refresh rebuilds it.`,
+		Example: `  # Show the tree of a space
+  cfl space tree DOCS
+
+  # Export an OPML outline for a mind-mapping tool
+  cfl space tree DOCS --format opml > docs.opml
+
+  # Export XML, ignoring the cached index
+  cfl space tree DOCS --format xml --refresh`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			opts.cachePath = config.TreeIndexCachePath()
+			var spaceKey string
+			if len(args) > 0 {
+				spaceKey = args[0]
+			}
+			return runTree(spaceKey, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.format, "format", "", "Output format: text, json, xml or opml (default: text, or json with --output json)")
+	cmd.Flags().BoolVar(&opts.refresh, "refresh", false, "Rebuild the cached index of the space")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 0, fmt.Sprintf("Maximum concurrent requests (default: config concurrency or %d)", defaultTreeConcurrency))
+
+	return cmd
+}
+
+func runTree(spaceKey string, opts *treeOptions, client *api.Client) error {
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	format := opts.format
+	if format == "" {
+		format = treeFormatText
+		if view.IsStructured(opts.output) {
+			format = treeFormatJSON
+		}
+	}
+	switch format {
+	case treeFormatText, treeFormatJSON, treeFormatXML, treeFormatOPML:
+	default:
+		return fmt.Errorf("invalid format: %s (must be text, json, xml or opml)", format)
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if spaceKey == "" {
+			spaceKey = cfg.DefaultSpace
+		}
+		if opts.concurrency <= 0 {
+			opts.concurrency = cfg.Concurrency
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	if spaceKey == "" {
+		return fmt.Errorf("space is required: give a space key or set default_space in config")
+	}
+	if opts.concurrency <= 0 {
+		opts.concurrency = defaultTreeConcurrency
+	}
+
+	var index *treeIndex
+	if !opts.refresh {
+		index = loadTreeIndex(opts.cachePath, client.BaseURL(), spaceKey)
+	}
+	if index == nil {
+		var err error
+		index, err = buildTreeIndex(context.Background(), client, spaceKey, opts.concurrency)
+		if err != nil {
+			return err
+		}
+		if opts.cachePath != "" {
+			// A failed save only means the next run rebuilds the index from the API
+			_ = saveTreeIndex(opts.cachePath, index)
+		}
+	}
+	roots := index.tree()
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	switch format {
+	case treeFormatJSON:
+		return renderer.RenderJSON(roots)
+	case treeFormatXML:
+		out, err := treeXML(index, roots)
+		if err != nil {
+			return err
+		}
+		renderer.RenderText(out)
+	case treeFormatOPML:
+		out, err := treeOPML(index, roots)
+		if err != nil {
+			return err
+		}
+		renderer.RenderText(out)
+	default:
+		if len(roots) == 0 {
+			renderer.RenderText("No pages found.")
+			return nil
+		}
+		renderer.RenderText(strings.Join(treeText(roots, 0, nil), "\n"))
+	}
+	return nil
+}
+
+// treeText indents each page under its parent, one line per page.
+func treeText(nodes []*treeNode, depth int, lines []string) []string {
+	for _, node := range nodes {
+		line := strings.Repeat("  ", depth) + node.Title + "  " + node.URL
+		if len(node.Labels) > 0 {
+			line += "  [" + strings.Join(node.Labels, ", ") + "]"
+		}
+		lines = append(lines, line)
+		lines = treeText(node.Children, depth+1, lines)
+	}
+	return lines
+}
+
+// xmlSpace is the root element of the XML export.
+type xmlSpace struct {
+	XMLName xml.Name   `xml:"space"`
+	Key     string     `xml:"key,attr"`
+	Name    string     `xml:"name,attr,omitempty"`
+	URL     string     `xml:"url,attr,omitempty"`
+	Pages   []*xmlPage `xml:"page"`
+}
+
+// xmlPage is a page in the XML export.
+type xmlPage struct {
+	ID     string     `xml:"id,attr"`
+	Title  string     `xml:"title,attr"`
+	URL    string     `xml:"url,attr"`
+	Labels []string   `xml:"label"`
+	Pages  []*xmlPage `xml:"page"`
+}
+
+// treeXML renders trees as a <space> element of nested <page> elements.
+func treeXML(index *treeIndex, roots []*treeNode) (string, error) {
+	var pages func(nodes []*treeNode) []*xmlPage
+	pages = func(nodes []*treeNode) []*xmlPage {
+		var out []*xmlPage
+		for _, node := range nodes {
+			out = append(out, &xmlPage{ID: node.ID, Title: node.Title, URL: node.URL, Labels: node.Labels, Pages: pages(node.Children)})
+		}
+		return out
+	}
+	return marshalXML(xmlSpace{Key: index.Space, Name: index.SpaceName, URL: index.SpaceURL, Pages: pages(roots)})
+}
+
+// opmlDocument is an OPML 2.0 document.
+type opmlDocument struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    struct {
+		Title       string `xml:"title"`
+		DateCreated string `xml:"dateCreated"`
+	} `xml:"head"`
+	Body struct {
+		Outlines []*opmlOutline `xml:"outline"`
+	} `xml:"body"`
+}
+
+// opmlOutline is a page in an OPML outline.
+type opmlOutline struct {
+	Text     string         `xml:"text,attr"`
+	Type     string         `xml:"type,attr"`
+	URL      string         `xml:"url,attr"`
+	Category string         `xml:"category,attr,omitempty"`
+	Outlines []*opmlOutline `xml:"outline"`
+}
+
+// treeOPML renders trees as an OPML outline of links. Labels become
+// categories, which OPML writes as /-prefixed, comma-separated names.
+func treeOPML(index *treeIndex, roots []*treeNode) (string, error) {
+	var outlines func(nodes []*treeNode) []*opmlOutline
+	outlines = func(nodes []*treeNode) []*opmlOutline {
+		var out []*opmlOutline
+		for _, node := range nodes {
+			var categories []string
+			for _, label := range node.Labels {
+				categories = append(categories, "/"+label)
+			}
+			out = append(out, &opmlOutline{
+				Text:     node.Title,
+				Type:     "link",
+				URL:      node.URL,
+				Category: strings.Join(categories, ","),
+				Outlines: outlines(node.Children),
+			})
+		}
+		return out
+	}
+
+	doc := opmlDocument{Version: "2.0"}
+	doc.Head.Title = index.Space
+	if index.SpaceName != "" {
+		doc.Head.Title = index.SpaceName
+	}
+	doc.Head.DateCreated = index.BuiltAt.Format(time.RFC1123Z)
+	doc.Body.Outlines = outlines(roots)
+	return marshalXML(doc)
+}
+
+// marshalXML renders v as an indented XML document.
+func marshalXML(v interface{}) (string, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to render XML: %w", err)
+	}
+	return xml.Header + string(data), nil
+}
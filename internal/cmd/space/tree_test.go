@@ -0,0 +1,134 @@
+package space
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// mockTreeIndexServer serves space DOCS: Home (1) with children FAQ (3) and
+// Guide (2), listed out of order, and Guide's child Install (4). Guide is
+// labeled howto and public.
+func mockTreeIndexServer(t *testing.T, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		switch r.URL.Path {
+		case "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "777", "key": "DOCS", "name": "Docs & Guides", "_links": {"webui": "/spaces/DOCS"}}]}`))
+		case "/api/v2/spaces/777/pages":
+			w.Write([]byte(`{"results": [
+				{"id": "2", "title": "Guide", "parentId": "1", "position": 1, "_links": {"webui": "/spaces/DOCS/pages/2"}},
+				{"id": "4", "title": "Install", "parentId": "2", "_links": {"webui": "/spaces/DOCS/pages/4"}},
+				{"id": "1", "title": "Home", "_links": {"webui": "/spaces/DOCS/pages/1"}},
+				{"id": "3", "title": "FAQ", "parentId": "1", "position": 0, "_links": {"webui": "/spaces/DOCS/pages/3"}}
+			]}`))
+		case "/api/v2/pages/2/labels":
+			w.Write([]byte(`{"results": [{"id": "10", "name": "howto"}, {"id": "11", "name": "public"}]}`))
+		case "/api/v2/pages/1/labels", "/api/v2/pages/3/labels", "/api/v2/pages/4/labels":
+			w.Write([]byte(`{"results": []}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestBuildTreeIndex(t *testing.T) {
+	var requests int32
+	server := mockTreeIndexServer(t, &requests)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	index, err := buildTreeIndex(context.Background(), client, "DOCS", 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Docs & Guides", index.SpaceName)
+	assert.Equal(t, server.URL+"/spaces/DOCS", index.SpaceURL)
+	assert.Equal(t, []string{
+		"Home  " + server.URL + "/spaces/DOCS/pages/1",
+		"  FAQ  " + server.URL + "/spaces/DOCS/pages/3",
+		"  Guide  " + server.URL + "/spaces/DOCS/pages/2  [howto, public]",
+		"    Install  " + server.URL + "/spaces/DOCS/pages/4",
+	}, treeText(index.tree(), 0, nil))
+}
+
+func TestTreeExports(t *testing.T) {
+	index := &treeIndex{
+		Space:     "DOCS",
+		SpaceName: "Docs & Guides",
+		SpaceURL:  "https://example.atlassian.net/wiki/spaces/DOCS",
+		BuiltAt:   time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Pages: []*indexPage{
+			{ID: "1", Title: "Home", URL: "https://example.atlassian.net/wiki/spaces/DOCS/pages/1"},
+			{ID: "2", Title: "Q&A", ParentID: "1", URL: "https://example.atlassian.net/wiki/spaces/DOCS/pages/2", Labels: []string{"howto", "public"}},
+		},
+	}
+
+	xmlOut, err := treeXML(index, index.tree())
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<space key="DOCS" name="Docs &amp; Guides" url="https://example.atlassian.net/wiki/spaces/DOCS">
+  <page id="1" title="Home" url="https://example.atlassian.net/wiki/spaces/DOCS/pages/1">
+    <page id="2" title="Q&amp;A" url="https://example.atlassian.net/wiki/spaces/DOCS/pages/2">
+      <label>howto</label>
+      <label>public</label>
+    </page>
+  </page>
+</space>`, xmlOut)
+
+	opmlOut, err := treeOPML(index, index.tree())
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head>
+    <title>Docs &amp; Guides</title>
+    <dateCreated>Fri, 01 Mar 2024 12:00:00 +0000</dateCreated>
+  </head>
+  <body>
+    <outline text="Home" type="link" url="https://example.atlassian.net/wiki/spaces/DOCS/pages/1">
+      <outline text="Q&amp;A" type="link" url="https://example.atlassian.net/wiki/spaces/DOCS/pages/2" category="/howto,/public"></outline>
+    </outline>
+  </body>
+</opml>`, opmlOut)
+}
+
+func TestRunTree_CachedIndex(t *testing.T) {
+	var requests int32
+	server := mockTreeIndexServer(t, &requests)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	cachePath := filepath.Join(t.TempDir(), "tree-index.json")
+
+	require.NoError(t, runTree("DOCS", &treeOptions{format: "opml", cachePath: cachePath, noColor: true}, client))
+	built := atomic.LoadInt32(&requests)
+	assert.Equal(t, int32(6), built, "space, page list and four label lookups")
+
+	// The cached index is used until it's refreshed
+	require.NoError(t, runTree("DOCS", &treeOptions{format: "xml", cachePath: cachePath, noColor: true}, client))
+	assert.Equal(t, built, atomic.LoadInt32(&requests))
+
+	require.NoError(t, runTree("DOCS", &treeOptions{refresh: true, cachePath: cachePath, noColor: true}, client))
+	assert.Equal(t, 2*built, atomic.LoadInt32(&requests))
+}
+
+func TestRunTree_InvalidFormat(t *testing.T) {
+	err := runTree("DOCS", &treeOptions{format: "yaml"}, api.NewClient("http://unused.invalid", "test@example.com", "token"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid format: yaml")
+}
+
+func TestRunTree_SpaceRequired(t *testing.T) {
+	err := runTree("", &treeOptions{}, api.NewClient("http://unused.invalid", "test@example.com", "token"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "space is required")
+}
@@ -0,0 +1,219 @@
+package space
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// treeIndexTTL is how long a cached tree index is used before it's rebuilt.
+const treeIndexTTL = 15 * time.Minute
+
+// treeIndex is every page of a space with its parent, URL and labels,
+// cached so exports don't list the space each time.
+type treeIndex struct {
+	Site      string       `json:"site"`
+	Space     string       `json:"space"`
+	SpaceName string       `json:"spaceName"`
+	SpaceURL  string       `json:"spaceUrl,omitempty"`
+	BuiltAt   time.Time    `json:"builtAt"`
+	Pages     []*indexPage `json:"pages"`
+}
+
+// indexPage is a page in a tree index.
+type indexPage struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	ParentID string   `json:"parentId,omitempty"`
+	Position int      `json:"position,omitempty"`
+	URL      string   `json:"url"`
+	Labels   []string `json:"labels,omitempty"`
+}
+
+// treeNode is a page of a tree index and its children.
+type treeNode struct {
+	ID       string      `json:"id"`
+	Title    string      `json:"title"`
+	URL      string      `json:"url"`
+	Labels   []string    `json:"labels,omitempty"`
+	Children []*treeNode `json:"children,omitempty"`
+}
+
+// treeIndexKey is the key of a space's index in the cache.
+func treeIndexKey(site, spaceKey string) string {
+	return site + "|" + spaceKey
+}
+
+// loadTreeIndex returns the cached index of a space, or nil when there is
+// none or it is older than treeIndexTTL.
+func loadTreeIndex(path, site, spaceKey string) *treeIndex {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var cache map[string]*treeIndex
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil
+	}
+	index := cache[treeIndexKey(site, spaceKey)]
+	if index == nil || time.Since(index.BuiltAt) >= treeIndexTTL {
+		return nil
+	}
+	return index
+}
+
+// saveTreeIndex stores index in the cache at path, alongside the indexes of
+// other spaces.
+func saveTreeIndex(path string, index *treeIndex) error {
+	cache := map[string]*treeIndex{}
+	if data, err := os.ReadFile(path); err == nil {
+		// A corrupt cache is replaced
+		_ = json.Unmarshal(data, &cache)
+	}
+	for key, cached := range cache {
+		if time.Since(cached.BuiltAt) >= treeIndexTTL {
+			delete(cache, key)
+		}
+	}
+	cache[treeIndexKey(index.Site, index.Space)] = index
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// buildTreeIndex lists every page of a space and its labels. Labels are
+// fetched for up to concurrency pages at once.
+func buildTreeIndex(ctx context.Context, client *api.Client, spaceKey string, concurrency int) (*treeIndex, error) {
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	index := &treeIndex{Site: client.BaseURL(), Space: space.Key, SpaceName: space.Name, BuiltAt: time.Now().UTC()}
+	if index.Space == "" {
+		index.Space = spaceKey
+	}
+	if space.Links.WebUI != "" {
+		index.SpaceURL = client.BaseURL() + space.Links.WebUI
+	}
+
+	if index.Pages, err = listIndexPages(ctx, client, space.ID); err != nil {
+		return nil, err
+	}
+	if err := indexLabels(ctx, client, index.Pages, concurrency); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// listIndexPages lists every current page of a space, without labels.
+func listIndexPages(ctx context.Context, client *api.Client, spaceID string) ([]*indexPage, error) {
+	var pages []*indexPage
+	opts := &api.ListPagesOptions{Limit: 250, Status: "current"}
+	for {
+		result, err := client.ListPages(ctx, spaceID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pages: %w", err)
+		}
+		for _, p := range result.Results {
+			pages = append(pages, &indexPage{
+				ID:       p.ID,
+				Title:    p.Title,
+				ParentID: p.ParentID,
+				Position: p.Position,
+				URL:      client.BaseURL() + p.Links.WebUI,
+			})
+		}
+		if opts.Cursor = result.NextCursor(); opts.Cursor == "" {
+			return pages, nil
+		}
+	}
+}
+
+// indexLabels fills in the labels of pages, fetching up to concurrency at
+// once, and returns the first error.
+func indexLabels(ctx context.Context, client *api.Client, pages []*indexPage, concurrency int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, concurrency)
+	errs := make([]error, len(pages))
+	var wg sync.WaitGroup
+	for i, page := range pages {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			labels, err := client.GetLabels(ctx, page.ID)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to get labels of page %s: %w", page.ID, err)
+				cancel()
+				return
+			}
+			for _, l := range labels {
+				page.Labels = append(page.Labels, l.Name)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Report the failure that caused the cancellation, not the ones it caused
+	var first error
+	for _, err := range errs {
+		if err != nil && (first == nil || errors.Is(first, context.Canceled)) {
+			first = err
+		}
+	}
+	return first
+}
+
+// tree arranges the pages of the index into trees, with children in their
+// order in the page tree. Pages whose parent isn't in the space are roots.
+func (index *treeIndex) tree() []*treeNode {
+	nodes := make(map[string]*treeNode, len(index.Pages))
+	for _, p := range index.Pages {
+		nodes[p.ID] = &treeNode{ID: p.ID, Title: p.Title, URL: p.URL, Labels: p.Labels}
+	}
+
+	pages := append([]*indexPage(nil), index.Pages...)
+	sort.SliceStable(pages, func(i, j int) bool {
+		if pages[i].Position != pages[j].Position {
+			return pages[i].Position < pages[j].Position
+		}
+		return pages[i].Title < pages[j].Title
+	})
+
+	var roots []*treeNode
+	for _, p := range pages {
+		if parent := nodes[p.ParentID]; parent != nil && p.ParentID != p.ID {
+			parent.Children = append(parent.Children, nodes[p.ID])
+		} else {
+			roots = append(roots, nodes[p.ID])
+		}
+	}
+	return roots
+}
@@ -0,0 +1,109 @@
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type updateOptions struct {
+	name        string
+	description *string // nil when --description isn't given
+	homepage    string
+	output      string
+	noColor     bool
+}
+
+// NewCmdUpdate creates the space update command.
+func NewCmdUpdate() *cobra.Command {
+	opts := &updateOptions{}
+	var description string
+
+	cmd := &cobra.Command{
+		Use:   "update <space-key>",
+		Short: "Update a space's details",
+		Long: `Change the name, description or homepage of a space.
+
+Only the given fields are changed. An empty --description clears the
+description. The homepage must be a page in the space.`,
+		Example: `  # Rename a space
+  cfl space update DOCS --name "Engineering Docs"
+
+  # Change the description and homepage
+  cfl space update DOCS --description "All engineering docs" --homepage 12345`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			if cmd.Flags().Changed("description") {
+				opts.description = &description
+			}
+			return runUpdate(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.name, "name", "n", "", "New space name")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "New space description")
+	cmd.Flags().StringVar(&opts.homepage, "homepage", "", "ID of the page to make the homepage")
+
+	return cmd
+}
+
+func runUpdate(spaceKey string, opts *updateOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.name == "" && opts.description == nil && opts.homepage == "" {
+		return fmt.Errorf("nothing to update: use --name, --description or --homepage")
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	if opts.homepage != "" {
+		if _, err := spacePage(ctx, client, space, opts.homepage); err != nil {
+			return err
+		}
+	}
+
+	updated, err := client.UpdateSpace(ctx, space.Key, &api.UpdateSpaceRequest{
+		Name:        opts.name,
+		Description: opts.description,
+		HomepageID:  opts.homepage,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update space: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(updated)
+	}
+
+	renderer.Success(fmt.Sprintf("Updated space: %s (%s)", updated.Name, updated.Key))
+	return nil
+}
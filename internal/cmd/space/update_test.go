@@ -0,0 +1,56 @@
+package space
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunUpdate(t *testing.T) {
+	empty := ""
+
+	tests := []struct {
+		name string
+		opts *updateOptions
+		want string
+	}{
+		{"name", &updateOptions{name: "Renamed"}, `{"name":"Renamed"}`},
+		{"clear description", &updateOptions{description: &empty}, `{"description":{"plain":{"value":"","representation":"plain"}}}`},
+		{"homepage", &updateOptions{homepage: "42"}, `{"homepage":{"id":"42"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writes []string
+			server := newSpaceServer(t, "current", &writes)
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			tt.opts.noColor = true
+			err := runUpdate("DOCS", tt.opts, client)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"PUT /rest/api/space/DOCS " + tt.want}, writes)
+		})
+	}
+}
+
+func TestRunUpdate_NothingToUpdate(t *testing.T) {
+	err := runUpdate("DOCS", &updateOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to update")
+}
+
+func TestRunUpdate_HomepageInOtherSpace(t *testing.T) {
+	var writes []string
+	server := newSpaceServer(t, "current", &writes)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runUpdate("DOCS", &updateOptions{homepage: "43", noColor: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "page 43 is not in space DOCS")
+	assert.Empty(t, writes)
+}
@@ -0,0 +1,110 @@
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type viewOptions struct {
+	output  string
+	noColor bool
+}
+
+// NewCmdView creates the space view command.
+func NewCmdView() *cobra.Command {
+	opts := &viewOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "view <space-key>",
+		Short: "View a space",
+		Long:  `View the details of a Confluence space, including its homepage.`,
+		Example: `  # View a space
+  cfl space view DOCS
+
+  # Output as JSON
+  cfl space view DOCS -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runView(args[0], opts, nil)
+		},
+	}
+
+	return cmd
+}
+
+func runView(spaceKey string, opts *viewOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	space, err := client.GetSpaceByKey(ctx, spaceKey)
+	if err != nil {
+		return fmt.Errorf("failed to find space '%s': %w", spaceKey, err)
+	}
+
+	// The homepage title is informational; a page we cannot read is shown by ID
+	var homepage *api.Page
+	if space.HomepageID != "" {
+		homepage, _ = client.GetPage(ctx, space.HomepageID, nil)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+
+	if view.IsStructured(opts.output) {
+		type enrichedSpace struct {
+			*api.Space
+			HomepageTitle string `json:"homepageTitle,omitempty"`
+		}
+		result := enrichedSpace{Space: space}
+		if homepage != nil {
+			result.HomepageTitle = homepage.Title
+		}
+		return renderer.RenderJSON(result)
+	}
+
+	renderer.RenderKeyValue("Key", space.Key)
+	renderer.RenderKeyValue("Name", space.Name)
+	renderer.RenderKeyValue("ID", space.ID)
+	renderer.RenderKeyValue("Type", space.Type)
+	renderer.RenderKeyValue("Status", space.Status)
+	if space.Description != nil && space.Description.Plain != nil && space.Description.Plain.Value != "" {
+		renderer.RenderKeyValue("Description", space.Description.Plain.Value)
+	}
+	switch {
+	case homepage != nil:
+		renderer.RenderKeyValue("Homepage", fmt.Sprintf("%s (ID: %s)", homepage.Title, homepage.ID))
+	case space.HomepageID != "":
+		renderer.RenderKeyValue("Homepage ID", space.HomepageID)
+	default:
+		renderer.RenderKeyValue("Homepage", "(none)")
+	}
+	if space.Links.WebUI != "" {
+		renderer.RenderKeyValue("URL", client.BaseURL()+space.Links.WebUI)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package space
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunView_Success(t *testing.T) {
+	var gotPage bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/spaces":
+			assert.Equal(t, "DOCS", r.URL.Query().Get("keys"))
+			w.Write([]byte(`{"results": [{"id": "777", "key": "DOCS", "name": "Docs", "homepageId": "42"}]}`))
+		case "/api/v2/pages/42":
+			gotPage = true
+			w.Write([]byte(`{"id": "42", "title": "Docs Home"}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runView("DOCS", &viewOptions{noColor: true}, client)
+
+	require.NoError(t, err)
+	assert.True(t, gotPage, "homepage is looked up")
+}
+
+func TestRunView_HomepageUnreadable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/spaces" {
+			w.Write([]byte(`{"results": [{"id": "777", "key": "DOCS", "homepageId": "42"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runView("DOCS", &viewOptions{noColor: true}, client)
+
+	require.NoError(t, err)
+}
+
+func TestRunView_SpaceNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runView("NOPE", &viewOptions{}, client)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to find space 'NOPE'")
+}
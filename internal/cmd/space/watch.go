@@ -0,0 +1,122 @@
+package space
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type watchOptions struct {
+	unwatch   bool
+	accountID string
+	output    string
+	noColor   bool
+	stdout    io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdWatch creates the space watch command.
+func NewCmdWatch() *cobra.Command {
+	return newWatchCmd(false)
+}
+
+// NewCmdUnwatch creates the space unwatch command.
+func NewCmdUnwatch() *cobra.Command {
+	return newWatchCmd(true)
+}
+
+func newWatchCmd(unwatch bool) *cobra.Command {
+	opts := &watchOptions{unwatch: unwatch}
+
+	cmd := &cobra.Command{
+		Use:   "watch <space-key>...",
+		Short: "Watch spaces",
+		Long: `Watch spaces, to be notified when any of their content changes. Watching a
+space you already watch does nothing.
+
+Use --user to watch on behalf of another user, by account ID; this needs
+the Confluence Administrator permission.`,
+		Example: `  # Watch a space
+  cfl space watch DEV
+
+  # Subscribe the on-call engineer to the incident spaces
+  cfl space watch OPS INC --user 5b10ac8d82e05b22cc7d4ef5`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runWatch(args, opts, nil)
+		},
+	}
+	if unwatch {
+		cmd.Use = "unwatch <space-key>..."
+		cmd.Short = "Stop watching spaces"
+		cmd.Long = `Stop watching spaces. Unwatching a space you don't watch does nothing.
+
+Use --user to unwatch on behalf of another user, by account ID; this needs
+the Confluence Administrator permission.`
+		cmd.Example = `  # Stop watching a space
+  cfl space unwatch DEV
+
+  # Unsubscribe the previous on-call engineer
+  cfl space unwatch OPS INC --user 5b10ac8d82e05b22cc7d4ef5`
+	}
+
+	cmd.Flags().StringVar(&opts.accountID, "user", "", "Account ID of the user (default: you)")
+
+	return cmd
+}
+
+func runWatch(spaceKeys []string, opts *watchOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	action, verb := client.WatchSpace, "Watching"
+	if opts.unwatch {
+		action, verb = client.UnwatchSpace, "Stopped watching"
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	for _, key := range spaceKeys {
+		if err := action(ctx, key, opts.accountID); err != nil {
+			return fmt.Errorf("failed to update watch on space %s: %w", key, err)
+		}
+		if !view.IsStructured(opts.output) {
+			renderer.Success(fmt.Sprintf("%s space %s", verb, key))
+		}
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]any{
+			"spaceKeys": spaceKeys,
+			"watching":  !opts.unwatch,
+		})
+	}
+	return nil
+}
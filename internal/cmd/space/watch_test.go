@@ -0,0 +1,56 @@
+package space
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunWatch(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *watchOptions
+		want []string
+	}{
+		{"watch", &watchOptions{}, []string{"POST /rest/api/user/watch/space/OPS ", "POST /rest/api/user/watch/space/INC "}},
+		{"unwatch for user", &watchOptions{unwatch: true, accountID: "abc"},
+			[]string{"DELETE /rest/api/user/watch/space/OPS accountId=abc", "DELETE /rest/api/user/watch/space/INC accountId=abc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path+" "+r.URL.RawQuery)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			var stdout bytes.Buffer
+			tt.opts.noColor, tt.opts.stdout = true, &stdout
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			require.NoError(t, runWatch([]string{"OPS", "INC"}, tt.opts, client))
+			assert.Equal(t, tt.want, requests)
+			assert.Contains(t, stdout.String(), "space INC")
+		})
+	}
+}
+
+func TestRunWatch_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "No space with key NOPE"}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runWatch([]string{"NOPE"}, &watchOptions{noColor: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to update watch on space NOPE")
+}
@@ -0,0 +1,99 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type completeOptions struct {
+	reopen  bool
+	output  string
+	noColor bool
+	stdout  io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdComplete creates the task complete command.
+func NewCmdComplete() *cobra.Command {
+	opts := &completeOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "complete <task-id>...",
+		Aliases: []string{"done"},
+		Short:   "Mark inline tasks complete",
+		Long: `Mark inline tasks (action items) complete, by the IDs 'cfl task list' shows.
+Use --reopen to mark them incomplete again.`,
+		Example: `  # Complete a task
+  cfl task complete 1234567
+
+  # Complete several tasks
+  cfl task complete 1234567 1234568
+
+  # Reopen a task
+  cfl task complete 1234567 --reopen`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runComplete(args, opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.reopen, "reopen", false, "Mark the tasks incomplete instead")
+
+	return cmd
+}
+
+func runComplete(taskIDs []string, opts *completeOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	status, verb := api.InlineTaskComplete, "Completed"
+	if opts.reopen {
+		status, verb = api.InlineTaskIncomplete, "Reopened"
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	tasks := make([]*api.InlineTask, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		task, err := client.SetInlineTaskStatus(context.Background(), id, status)
+		if err != nil {
+			return fmt.Errorf("failed to update task %s: %w", id, err)
+		}
+		tasks = append(tasks, task)
+		if !view.IsStructured(opts.output) {
+			renderer.Success(fmt.Sprintf("%s task %s", verb, id))
+		}
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(tasks)
+	}
+	return nil
+}
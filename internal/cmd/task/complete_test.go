@@ -0,0 +1,64 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunComplete(t *testing.T) {
+	tests := []struct {
+		name       string
+		reopen     bool
+		wantStatus string
+	}{
+		{name: "complete", wantStatus: "complete"},
+		{name: "reopen", reopen: true, wantStatus: "incomplete"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "PUT", r.Method)
+				var req map[string]string
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, tt.wantStatus, req["status"])
+				updated = append(updated, r.URL.Path)
+				json.NewEncoder(w).Encode(req)
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			var stdout bytes.Buffer
+			err := runComplete([]string{"1", "2"}, &completeOptions{reopen: tt.reopen, output: "json", stdout: &stdout}, client)
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{"/api/v2/tasks/1", "/api/v2/tasks/2"}, updated)
+			var tasks []api.InlineTask
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &tasks))
+			require.Len(t, tasks, 2)
+			assert.Equal(t, tt.wantStatus, tasks[1].Status)
+		})
+	}
+}
+
+func TestRunComplete_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Task not found"}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runComplete([]string{"9"}, &completeOptions{}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to update task 9")
+}
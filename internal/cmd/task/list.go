@@ -0,0 +1,226 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/pageref"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+type listOptions struct {
+	space      string
+	page       string
+	assignee   string
+	incomplete bool
+	complete   bool
+	limit      int
+	all        bool
+	output     string
+	noColor    bool
+	stdout     io.Writer // For testing; defaults to os.Stdout
+}
+
+// listAllBatchSize is the number of tasks requested at a time with --all.
+const listAllBatchSize = 250
+
+// NewCmdList creates the task list command.
+func NewCmdList() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List inline tasks (action items)",
+		Long: `List the inline tasks (action items) on pages and blog posts, across all
+spaces unless --space or --page is given.
+
+--assignee takes an account ID, or "me" for your own tasks.`,
+		Example: `  # My open action items in a space
+  cfl task list --space DEV --assignee me --incomplete
+
+  # Tasks on a page
+  cfl task list --page "DEV/Sprint 42 Retro"
+
+  # Every open task assigned to me, as JSON for scripts
+  cfl task list --assignee me --incomplete --all -o json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runList(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "Space key")
+	cmd.Flags().StringVar(&opts.page, "page", "", "Page ("+pageref.Usage+")")
+	cmd.Flags().StringVar(&opts.assignee, "assignee", "", `Account ID of the assignee, or "me"`)
+	cmd.Flags().BoolVar(&opts.incomplete, "incomplete", false, "Only incomplete tasks")
+	cmd.Flags().BoolVar(&opts.complete, "complete", false, "Only completed tasks")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "l", 25, "Maximum number of tasks to return")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Return all tasks, following pagination (ignores --limit)")
+	cmd.MarkFlagsMutuallyExclusive("incomplete", "complete")
+
+	return cmd
+}
+
+func runList(opts *listOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.incomplete && opts.complete {
+		return fmt.Errorf("--incomplete and --complete cannot be used together")
+	}
+
+	// Validate limit
+	if opts.limit < 0 {
+		return fmt.Errorf("invalid limit: %d (must be >= 0)", opts.limit)
+	}
+
+	stdout := opts.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	renderer.SetWriter(stdout)
+
+	// Handle limit 0 - return empty list
+	if opts.limit == 0 && !opts.all {
+		if view.IsStructured(opts.output) {
+			return renderer.RenderJSON([]interface{}{})
+		}
+		renderer.RenderText("No tasks found.")
+		return nil
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	apiOpts := &api.ListInlineTasksOptions{
+		BodyFormat: "storage",
+		Limit:      opts.limit,
+	}
+	switch {
+	case opts.incomplete:
+		apiOpts.Status = api.InlineTaskIncomplete
+	case opts.complete:
+		apiOpts.Status = api.InlineTaskComplete
+	}
+	if opts.space != "" {
+		space, err := client.GetSpaceByKey(ctx, opts.space)
+		if err != nil {
+			return fmt.Errorf("failed to find space '%s': %w", opts.space, err)
+		}
+		apiOpts.SpaceIDs = []string{space.ID}
+	}
+	if opts.page != "" {
+		pageID, err := pageref.Resolve(ctx, client, opts.page)
+		if err != nil {
+			return err
+		}
+		apiOpts.PageIDs = []string{pageID}
+	}
+	if opts.assignee != "" {
+		assignee := opts.assignee
+		if assignee == "me" {
+			user, err := client.GetCurrentUser(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get current user: %w", err)
+			}
+			assignee = user.AccountID
+		}
+		apiOpts.AssignedTo = []string{assignee}
+	}
+
+	var tasks []api.InlineTask
+	var hasMore bool
+	if opts.all {
+		apiOpts.Limit = listAllBatchSize
+		var err error
+		tasks, err = api.ListAll(client.InlineTasksIter(ctx, apiOpts, api.WithPrefetch()))
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+	} else {
+		result, err := client.ListInlineTasks(ctx, apiOpts)
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		tasks, hasMore = result.Results, result.HasMore()
+	}
+
+	if view.IsStructured(opts.output) {
+		if tasks == nil {
+			tasks = []api.InlineTask{}
+		}
+		return renderer.RenderJSON(tasks)
+	}
+
+	if len(tasks) == 0 {
+		renderer.RenderText("No tasks found.")
+		return nil
+	}
+
+	headers := []string{"ID", "STATUS", "DUE", "PAGE", "TASK"}
+	rows := make([][]string, 0, len(tasks))
+	for _, t := range tasks {
+		rows = append(rows, []string{
+			t.ID,
+			t.Status,
+			formatDue(t.DueAt),
+			taskContainer(t),
+			view.Truncate(taskText(t), 60),
+		})
+	}
+
+	renderer.RenderList(headers, rows, hasMore)
+
+	if hasMore {
+		fmt.Fprintf(os.Stderr, "\n(showing first %d results, use --limit or --all to see more)\n", len(tasks))
+	}
+
+	return nil
+}
+
+// taskText returns the text of an inline task.
+func taskText(t api.InlineTask) string {
+	if t.Body == nil || t.Body.Storage == nil {
+		return ""
+	}
+	return md.StorageText(t.Body.Storage.Value)
+}
+
+// taskContainer returns the ID of the page or blog post a task is on.
+func taskContainer(t api.InlineTask) string {
+	if t.PageID != "" {
+		return t.PageID
+	}
+	return t.BlogPostID
+}
+
+// formatDue returns a task's due date, or "" if it has none.
+func formatDue(due api.Time) string {
+	if due.IsZero() {
+		return ""
+	}
+	return due.Format("2006-01-02")
+}
@@ -0,0 +1,98 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// mockTasksServer serves the DEV space, the current user u1 and a page of
+// inline tasks, recording the task query.
+func mockTasksServer(t *testing.T, query *map[string][]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/spaces":
+			w.Write([]byte(`{"results": [{"id": "777", "key": "DEV"}]}`))
+		case "/rest/api/user/current":
+			w.Write([]byte(`{"accountId": "u1", "displayName": "Ann"}`))
+		case "/api/v2/tasks":
+			*query = r.URL.Query()
+			w.Write([]byte(`{"results": [
+				{"id": "1", "pageId": "42", "status": "incomplete", "assignedTo": "u1", "dueAt": "2026-11-01T00:00:00.000Z",
+				 "body": {"storage": {"value": "<ac:task-body><span>Ship the <strong>release</strong></span></ac:task-body>"}}},
+				{"id": "2", "blogPostId": "43", "status": "incomplete"}]}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunList(t *testing.T) {
+	var query map[string][]string
+	server := mockTasksServer(t, &query)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	var stdout bytes.Buffer
+	err := runList(&listOptions{space: "DEV", assignee: "me", incomplete: true, limit: 25, noColor: true, stdout: &stdout}, client)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"777"}, query["space-id"])
+	assert.Equal(t, []string{"u1"}, query["assigned-to"])
+	assert.Equal(t, []string{"incomplete"}, query["status"])
+	assert.Equal(t, []string{"storage"}, query["body-format"])
+
+	out := stdout.String()
+	assert.Contains(t, out, "Ship the release")
+	assert.Contains(t, out, "2026-11-01")
+	assert.Contains(t, out, "43")
+}
+
+func TestRunList_JSON(t *testing.T) {
+	var query map[string][]string
+	server := mockTasksServer(t, &query)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	var stdout bytes.Buffer
+	err := runList(&listOptions{assignee: "u9", complete: true, limit: 10, output: "json", stdout: &stdout}, client)
+	require.NoError(t, err)
+
+	assert.Empty(t, query["space-id"])
+	assert.Equal(t, []string{"u9"}, query["assigned-to"])
+	assert.Equal(t, []string{"complete"}, query["status"])
+	assert.Equal(t, []string{"10"}, query["limit"])
+
+	var tasks []api.InlineTask
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &tasks))
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "42", tasks[0].PageID)
+}
+
+func TestRunList_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *listOptions
+		wantErr string
+	}{
+		{name: "both statuses", opts: &listOptions{incomplete: true, complete: true, limit: 25}, wantErr: "--incomplete and --complete cannot be used together"},
+		{name: "negative limit", opts: &listOptions{limit: -1}, wantErr: "invalid limit"},
+		{name: "invalid output", opts: &listOptions{limit: 25, output: "xml"}, wantErr: "invalid output format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runList(tt.opts, api.NewClient("http://unused", "test@example.com", "token"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type statusOptions struct {
+	wait    bool
+	output  string
+	noColor bool
+	stdout  io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdStatus creates the task status command.
+func NewCmdStatus() *cobra.Command {
+	opts := &statusOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "status <task-id>",
+		Short: "Show the progress of a long-running task",
+		Long: `Show the progress of a long-running task by its ID.
+
+With --wait, keep watching until the task finishes, for example to resume
+following a space deletion. The command fails if the task fails.`,
+		Example: `  # Check on a task
+  cfl task status 8e3b2c1a-6b2f-4d8e-9f1a-0c2d3e4f5a6b
+
+  # Wait for a task to finish
+  cfl task status 8e3b2c1a-6b2f-4d8e-9f1a-0c2d3e4f5a6b --wait`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runStatus(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.wait, "wait", false, "Wait for the task to finish")
+
+	return cmd
+}
+
+func runStatus(taskID string, opts *statusOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	ctx := context.Background()
+	var task *api.LongTask
+	var waitErr error
+	if opts.wait {
+		// A failed task is still shown before the error is returned
+		task, waitErr = Wait(ctx, client, taskID)
+		if task == nil {
+			return fmt.Errorf("failed to get task: %w", waitErr)
+		}
+	} else {
+		var err error
+		if task, err = client.GetTask(ctx, taskID); err != nil {
+			return fmt.Errorf("failed to get task: %w", err)
+		}
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	if view.IsStructured(opts.output) {
+		if err := renderer.RenderJSON(task); err != nil {
+			return err
+		}
+		return waitErr
+	}
+
+	renderer.RenderKeyValue("ID", task.ID)
+	if task.Name.Key != "" {
+		renderer.RenderKeyValue("Task", task.Name.Key)
+	}
+	renderer.RenderKeyValue("State", state(task))
+	renderer.RenderKeyValue("Progress", strconv.Itoa(task.PercentageComplete)+"%")
+	renderer.RenderKeyValue("Elapsed", task.Elapsed().Round(time.Second).String())
+	if msg := task.Message(); msg != "" {
+		renderer.RenderKeyValue("Message", msg)
+	}
+	return waitErr
+}
+
+// state summarizes whether a task is running, done, or failed.
+func state(t *api.LongTask) string {
+	switch {
+	case !t.Finished:
+		return "running"
+	case t.Successful:
+		return "succeeded"
+	default:
+		return "failed"
+	}
+}
@@ -0,0 +1,87 @@
+package task
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestRunStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		opts    statusOptions
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "running",
+			body: `{"id": "task-1", "name": {"key": "space.delete"}, "percentageComplete": 40, "elapsedTime": 2000, "finished": false}`,
+			want: []string{"ID: task-1", "Task: space.delete", "State: running", "Progress: 40%", "Elapsed: 2s"},
+		},
+		{
+			name: "succeeded, waiting",
+			body: `{"id": "task-1", "percentageComplete": 100, "finished": true, "successful": true}`,
+			opts: statusOptions{wait: true},
+			want: []string{"State: succeeded", "Progress: 100%"},
+		},
+		{
+			name:    "failed, waiting",
+			body:    `{"id": "task-1", "finished": true, "successful": false, "messages": [{"translation": "No permission"}]}`,
+			opts:    statusOptions{wait: true},
+			want:    []string{"State: failed", "Message: No permission"},
+			wantErr: "task task-1 failed: No permission",
+		},
+		{
+			name: "json",
+			body: `{"id": "task-1", "percentageComplete": 40, "finished": false}`,
+			opts: statusOptions{output: "json"},
+			want: []string{`"percentageComplete": 40`, `"finished": false`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/rest/api/longtask/task-1", r.URL.Path)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			var stdout bytes.Buffer
+			opts := tt.opts
+			opts.noColor = true
+			opts.stdout = &stdout
+			client := api.NewClient(server.URL, "test@example.com", "token")
+			err := runStatus("task-1", &opts, client)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			for _, want := range tt.want {
+				assert.Contains(t, stdout.String(), want)
+			}
+		})
+	}
+}
+
+func TestRunStatus_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"statusCode": 404, "message": "not found"}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test@example.com", "token")
+	err := runStatus("nope", &statusOptions{wait: true}, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get task")
+}
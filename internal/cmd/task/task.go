@@ -0,0 +1,54 @@
+// Package task provides commands for inline tasks (action items) and for
+// following long-running server tasks.
+package task
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+// NewCmdTask creates the task command.
+func NewCmdTask() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "task",
+		Aliases: []string{"tasks"},
+		Short:   "Manage inline tasks and follow long-running server tasks",
+		Long: `Commands for inline tasks, the action items on pages and blog posts, and for
+following long-running Confluence tasks, such as deleting a space or
+archiving pages. Commands that start a long-running task print its ID.`,
+	}
+
+	cmd.AddCommand(NewCmdList())
+	cmd.AddCommand(NewCmdComplete())
+	cmd.AddCommand(NewCmdStatus())
+
+	return cmd
+}
+
+// Wait waits for a long task to finish, drawing its progress on stderr.
+func Wait(ctx context.Context, client *api.Client, taskID string) (*api.LongTask, error) {
+	progress := view.NewProgress(100)
+	defer progress.Done()
+
+	return client.WaitForTask(ctx, taskID, &api.PollOptions{
+		OnProgress: func(t *api.LongTask) {
+			progress.Set(t.PercentageComplete, Describe(t))
+		},
+	})
+}
+
+// Describe returns a short description of what a task is doing.
+func Describe(t *api.LongTask) string {
+	if msg := t.Message(); msg != "" {
+		return msg
+	}
+	if t.Status != "" {
+		return t.Status
+	}
+	return strings.ReplaceAll(t.Name.Key, ".", " ")
+}
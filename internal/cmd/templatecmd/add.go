@@ -0,0 +1,80 @@
+package templatecmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/tmpl"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type addOptions struct {
+	name    string
+	force   bool
+	dir     string // For testing; defaults to config.TemplateDir()
+	output  string
+	noColor bool
+	stdout  io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdAdd creates the template add command.
+func NewCmdAdd() *cobra.Command {
+	opts := &addOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "add <file>",
+		Short: "Store a template",
+		Long: `Store a copy of a template file so it can be used by name.
+
+The template is stored under its file name without extensions, or --name.
+The extension before .tmpl is kept, since it decides whether the rendered
+content is read as markdown; files without one are stored as markdown.`,
+		Example: `  # Store release-notes.md.tmpl as "release-notes"
+  cfl template add release-notes.md.tmpl
+
+  # Store under another name, replacing an existing template
+  cfl template add notes.md.tmpl --name release-notes --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runAdd(args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "Name to store the template under (default: file name)")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Replace an existing template of the same name")
+
+	return cmd
+}
+
+func runAdd(src string, opts *addOptions) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.dir == "" && config.NoConfig() {
+		return config.ErrNoConfig
+	}
+
+	t, err := tmpl.Add(templateDir(opts.dir), src, opts.name, opts.force)
+	if err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(t)
+	}
+
+	renderer.Success(fmt.Sprintf("Stored template %s", t.Name))
+	renderer.RenderKeyValue("Path", t.Path)
+	return nil
+}
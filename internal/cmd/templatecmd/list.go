@@ -0,0 +1,168 @@
+package templatecmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/tmpl"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type listOptions struct {
+	space      string
+	global     bool
+	blueprints bool
+	dir        string // For testing; defaults to config.TemplateDir()
+	output     string
+	noColor    bool
+	stdout     io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdList creates the template list command.
+func NewCmdList() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List stored or Confluence templates",
+		Long: `List the templates stored with 'cfl template add', or with --space or
+--global the Confluence templates of a space or the site.
+
+Create a page from a Confluence template with
+'cfl page create --from-template <id>'.`,
+		Example: `  # List stored templates
+  cfl template list
+
+  # List the templates of a space
+  cfl template list --space DEV
+
+  # List global blueprint templates
+  cfl template list --global --blueprints
+
+  # Output as JSON
+  cfl template list -o json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runList(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.space, "space", "s", "", "List the Confluence templates of a space")
+	cmd.Flags().BoolVar(&opts.global, "global", false, "List the global Confluence templates")
+	cmd.Flags().BoolVar(&opts.blueprints, "blueprints", false, "List blueprint templates instead of page templates")
+
+	return cmd
+}
+
+func runList(opts *listOptions, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.space != "" && opts.global {
+		return fmt.Errorf("--space and --global cannot be used together")
+	}
+	if opts.space != "" || opts.global {
+		return runListConfluence(opts, client)
+	}
+	if opts.blueprints {
+		return fmt.Errorf("--blueprints requires --space or --global")
+	}
+
+	dir := templateDir(opts.dir)
+	templates, err := tmpl.List(dir)
+	if err != nil {
+		return err
+	}
+
+	stdout := opts.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	renderer.SetWriter(stdout)
+
+	if view.IsStructured(opts.output) {
+		if templates == nil {
+			templates = []tmpl.Template{}
+		}
+		return renderer.RenderJSON(templates)
+	}
+
+	if len(templates) == 0 {
+		_, err := fmt.Fprintf(stdout, "No templates found in %s.\n", dir)
+		return err
+	}
+
+	headers := []string{"NAME", "PATH"}
+	var rows [][]string
+	for _, t := range templates {
+		rows = append(rows, []string{t.Name, t.Path})
+	}
+	renderer.RenderTable(headers, rows)
+	return nil
+}
+
+// runListConfluence lists the Confluence templates of a space or the site.
+func runListConfluence(opts *listOptions, client *api.Client) error {
+	// Create API client if not provided (allows injection for testing)
+	if client == nil {
+		cfg, err := config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	templates, err := client.GetTemplates(context.Background(), &api.ListTemplatesOptions{
+		SpaceKey:   opts.space,
+		Blueprints: opts.blueprints,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	stdout := opts.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	renderer.SetWriter(stdout)
+
+	if view.IsStructured(opts.output) {
+		if templates == nil {
+			templates = []api.ContentTemplate{}
+		}
+		return renderer.RenderJSON(templates)
+	}
+
+	if len(templates) == 0 {
+		_, err := fmt.Fprintln(stdout, "No templates found.")
+		return err
+	}
+
+	headers := []string{"ID", "NAME", "SPACE", "DESCRIPTION"}
+	var rows [][]string
+	for _, t := range templates {
+		space := "(global)"
+		if t.Space != nil {
+			space = t.Space.Key
+		}
+		rows = append(rows, []string{t.TemplateID, t.Name, space, view.Truncate(t.Description, 50)})
+	}
+	renderer.RenderTable(headers, rows)
+	return nil
+}
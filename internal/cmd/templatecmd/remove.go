@@ -0,0 +1,69 @@
+package templatecmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/tmpl"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type removeOptions struct {
+	dir     string // For testing; defaults to config.TemplateDir()
+	output  string
+	noColor bool
+	stdout  io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdRemove creates the template remove command.
+func NewCmdRemove() *cobra.Command {
+	opts := &removeOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "remove <name>",
+		Aliases: []string{"rm"},
+		Short:   "Remove a stored template",
+		Example: `  # Remove a template
+  cfl template remove release-notes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runRemove(args[0], opts)
+		},
+	}
+
+	return cmd
+}
+
+func runRemove(name string, opts *removeOptions) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.dir == "" && config.NoConfig() {
+		return config.ErrNoConfig
+	}
+
+	if err := tmpl.Remove(templateDir(opts.dir), name); err != nil {
+		return err
+	}
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(map[string]string{
+			"status": "removed",
+			"name":   name,
+		})
+	}
+
+	renderer.Success(fmt.Sprintf("Removed template %s", name))
+	return nil
+}
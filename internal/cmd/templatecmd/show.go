@@ -0,0 +1,53 @@
+package templatecmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/internal/tmpl"
+)
+
+type showOptions struct {
+	dir    string    // For testing; defaults to config.TemplateDir()
+	stdout io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdShow creates the template show command.
+func NewCmdShow() *cobra.Command {
+	opts := &showOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "show <name>",
+		Aliases: []string{"cat"},
+		Short:   "Print a stored template",
+		Example: `  # Print a template, to see which variables it uses
+  cfl template show release-notes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runShow(args[0], opts)
+		},
+	}
+
+	return cmd
+}
+
+func runShow(name string, opts *showOptions) error {
+	path, err := tmpl.Find(templateDir(opts.dir), name)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+
+	stdout := opts.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	_, err = stdout.Write(data)
+	return err
+}
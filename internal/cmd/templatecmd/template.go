@@ -0,0 +1,38 @@
+// Package templatecmd provides commands for managing page templates.
+package templatecmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+// NewCmdTemplate creates the template command.
+func NewCmdTemplate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "template",
+		Aliases: []string{"templates", "tmpl"},
+		Short:   "Manage page templates",
+		Long: `Commands for listing, storing, showing, and removing reusable page templates.
+
+Templates are Go text/template files stored in the templates directory next
+to the config file. Create a page from one with
+'cfl page create --template <name> --var key=value'.`,
+	}
+
+	cmd.AddCommand(NewCmdList())
+	cmd.AddCommand(NewCmdAdd())
+	cmd.AddCommand(NewCmdShow())
+	cmd.AddCommand(NewCmdRemove())
+
+	return cmd
+}
+
+// templateDir returns dir, or the configured template directory when dir
+// is empty.
+func templateDir(dir string) string {
+	if dir == "" {
+		return config.TemplateDir()
+	}
+	return dir
+}
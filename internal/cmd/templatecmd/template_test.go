@@ -0,0 +1,93 @@
+package templatecmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func TestTemplateCommands(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "templates")
+	src := filepath.Join(t.TempDir(), "release-notes.md.tmpl")
+	require.NoError(t, os.WriteFile(src, []byte("# Release {{.version}}\n"), 0644))
+
+	var out bytes.Buffer
+	require.NoError(t, runList(&listOptions{dir: dir, noColor: true, stdout: &out}, nil))
+	assert.Contains(t, out.String(), "No templates found")
+
+	out.Reset()
+	require.NoError(t, runAdd(src, &addOptions{dir: dir, noColor: true, stdout: &out}))
+	assert.Contains(t, out.String(), "Stored template release-notes")
+
+	err := runAdd(src, &addOptions{dir: dir, noColor: true, stdout: &out})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+
+	out.Reset()
+	require.NoError(t, runList(&listOptions{dir: dir, noColor: true, stdout: &out}, nil))
+	assert.Contains(t, out.String(), "release-notes")
+	assert.Contains(t, out.String(), filepath.Join(dir, "release-notes.md.tmpl"))
+
+	out.Reset()
+	require.NoError(t, runList(&listOptions{dir: dir, output: "json", stdout: &out}, nil))
+	assert.Contains(t, out.String(), `"name": "release-notes"`)
+
+	out.Reset()
+	require.NoError(t, runShow("release-notes", &showOptions{dir: dir, stdout: &out}))
+	assert.Equal(t, "# Release {{.version}}\n", out.String())
+
+	out.Reset()
+	require.NoError(t, runRemove("release-notes", &removeOptions{dir: dir, output: "json", stdout: &out}))
+	assert.Contains(t, out.String(), `"status": "removed"`)
+
+	err = runShow("release-notes", &showOptions{dir: dir, stdout: &out})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "template not found")
+}
+
+func TestRunAdd_NoConfig(t *testing.T) {
+	t.Setenv("CFL_NO_CONFIG", "1")
+
+	err := runAdd("notes.md.tmpl", &addOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CFL_NO_CONFIG")
+}
+
+func TestRunList_Confluence(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/template/page", r.URL.Path)
+		assert.Equal(t, "DEV", r.URL.Query().Get("spaceKey"))
+		w.Write([]byte(`{"results": [{"templateId": "98305", "name": "Retrospective", "space": {"key": "DEV"},
+			"description": "Team retros"}, {"templateId": "98306", "name": "Decision"}], "size": 2}`))
+	}))
+	defer server.Close()
+	client := api.NewClient(server.URL, "test@example.com", "token")
+
+	var out bytes.Buffer
+	require.NoError(t, runList(&listOptions{space: "DEV", noColor: true, stdout: &out}, client))
+	assert.Contains(t, out.String(), "98305")
+	assert.Contains(t, out.String(), "Team retros")
+	assert.Contains(t, out.String(), "(global)")
+
+	out.Reset()
+	require.NoError(t, runList(&listOptions{space: "DEV", output: "json", stdout: &out}, client))
+	assert.Contains(t, out.String(), `"templateId": "98305"`)
+}
+
+func TestRunList_FlagErrors(t *testing.T) {
+	err := runList(&listOptions{space: "DEV", global: true}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--space and --global cannot be used together")
+
+	err = runList(&listOptions{blueprints: true}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--blueprints requires --space or --global")
+}
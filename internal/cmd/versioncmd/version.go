@@ -0,0 +1,78 @@
+// Package versioncmd provides the version command.
+package versioncmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/internal/version"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type versionOptions struct {
+	json    bool
+	output  string
+	noColor bool
+	stdout  io.Writer // For testing; defaults to os.Stdout
+}
+
+// NewCmdVersion creates the version command.
+func NewCmdVersion() *cobra.Command {
+	opts := &versionOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show version and build information",
+		Long: `Show the cfl version, the commit and date it was built from, the Go version,
+platform, and the Confluence REST API level it is built against.
+
+Use --json for the same information as JSON, for bug reports and scripts.`,
+		Example: `  # Show the version
+  cfl version
+
+  # As JSON
+  cfl version --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runVersion(opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output as JSON (same as -o json)")
+
+	return cmd
+}
+
+func runVersion(opts *versionOptions) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+	if opts.json {
+		opts.output = "json"
+	}
+
+	info := version.Get()
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	stdout := opts.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	renderer.SetWriter(stdout)
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(info)
+	}
+
+	renderer.RenderKeyValue("Version", info.Version)
+	renderer.RenderKeyValue("Commit", info.Commit)
+	renderer.RenderKeyValue("Built", info.Date)
+	renderer.RenderKeyValue("Go", info.GoVersion)
+	renderer.RenderKeyValue("Platform", info.Platform)
+	renderer.RenderKeyValue("API", info.APILevel)
+	return nil
+}
@@ -0,0 +1,50 @@
+package versioncmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/internal/version"
+)
+
+func TestRunVersion_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *versionOptions
+	}{
+		{name: "--json", opts: &versionOptions{json: true}},
+		{name: "-o json", opts: &versionOptions{output: "json"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			tt.opts.stdout = &stdout
+			require.NoError(t, runVersion(tt.opts))
+
+			var info version.Info
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &info))
+			assert.NotEmpty(t, info.Version)
+			assert.Equal(t, runtime.Version(), info.GoVersion)
+			assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, info.Platform)
+			assert.Equal(t, version.APILevel, info.APILevel)
+		})
+	}
+}
+
+func TestRunVersion_Text(t *testing.T) {
+	var stdout bytes.Buffer
+	require.NoError(t, runVersion(&versionOptions{noColor: true, stdout: &stdout}))
+	assert.Contains(t, stdout.String(), runtime.Version())
+	assert.Contains(t, stdout.String(), version.APILevel)
+}
+
+func TestRunVersion_InvalidOutput(t *testing.T) {
+	err := runVersion(&versionOptions{output: "xml"})
+	require.Error(t, err)
+}
@@ -0,0 +1,24 @@
+// Package whiteboard provides whiteboard-related commands.
+package whiteboard
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/cmd/contentcmd"
+)
+
+// NewCmdWhiteboard creates the whiteboard command.
+func NewCmdWhiteboard() *cobra.Command {
+	return contentcmd.NewCmd(contentType)
+}
+
+// contentType describes whiteboards to the content type commands.
+var contentType = contentcmd.ContentType{
+	Name:    "whiteboard",
+	Aliases: []string{"whiteboards", "wb"},
+	Hidden:  "what is drawn on a whiteboard",
+	Example: "architecture",
+	List:    (*api.Client).ListWhiteboards,
+	Get:     (*api.Client).GetWhiteboard,
+}
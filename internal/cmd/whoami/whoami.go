@@ -0,0 +1,150 @@
+// Package whoami provides the whoami command.
+package whoami
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+	"github.com/open-cli-collective/confluence-cli/internal/view"
+)
+
+type whoamiOptions struct {
+	output  string
+	noColor bool
+	stdout  io.Writer // For testing; defaults to os.Stdout
+}
+
+// Identity is who cfl signs in as, and where.
+type Identity struct {
+	AccountID   string `json:"accountId"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"email,omitempty"` // Empty when hidden by profile visibility
+	Site        string `json:"site"`
+	Deployment  string `json:"deployment,omitempty"` // api.DeploymentCloud or api.DeploymentDataCenter; empty if unknown
+	Profile     string `json:"profile,omitempty"`
+}
+
+// NewCmdWhoami creates the whoami command.
+func NewCmdWhoami() *cobra.Command {
+	opts := &whoamiOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the signed-in user",
+		Long: `Sign in and show the account cfl uses: its account ID, display name and
+email, and the site with its deployment type (cloud or data-center).
+
+The command fails when the credentials are missing, rejected or ignored,
+so it can be used as a preflight check in CI. Use 'cfl auth token-check'
+to find out what is wrong with rejected credentials.`,
+		Example: `  # Show the signed-in user
+  cfl whoami
+
+  # Fail a CI job early when the token is invalid
+  cfl whoami -o json > /dev/null`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.output, _ = cmd.Flags().GetString("output")
+			opts.noColor, _ = cmd.Flags().GetBool("no-color")
+			return runWhoami(opts, nil, nil)
+		},
+	}
+
+	return cmd
+}
+
+func runWhoami(opts *whoamiOptions, cfg *config.Config, client *api.Client) error {
+	// Validate output format
+	if err := view.ValidateFormat(opts.output); err != nil {
+		return err
+	}
+
+	// Load config if not provided (allows injection for testing)
+	if cfg == nil {
+		var err error
+		cfg, err = config.LoadWithEnv(config.DefaultConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w (run 'cfl init' to configure)", err)
+		}
+	}
+	if client == nil {
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w (run 'cfl init' to configure)", err)
+		}
+		client = api.NewClient(cfg.URL, cfg.Email, cfg.APIToken, cfg.ClientOptions()...)
+	}
+
+	identity, err := Lookup(context.Background(), client)
+	if err != nil {
+		return err
+	}
+	identity.Profile = cfg.ActiveProfile()
+
+	renderer := view.NewRenderer(view.Format(opts.output), opts.noColor)
+	if opts.stdout != nil {
+		renderer.SetWriter(opts.stdout)
+	}
+
+	if view.IsStructured(opts.output) {
+		return renderer.RenderJSON(identity)
+	}
+
+	email := identity.Email
+	if email == "" {
+		email = "(hidden)"
+	}
+	deployment := identity.Deployment
+	if deployment == "" {
+		deployment = "unknown"
+	}
+	renderer.RenderKeyValue("Account ID", identity.AccountID)
+	renderer.RenderKeyValue("Name", identity.DisplayName)
+	renderer.RenderKeyValue("Email", email)
+	renderer.RenderKeyValue("Site", identity.Site)
+	renderer.RenderKeyValue("Deployment", deployment)
+	if identity.Profile != "" {
+		renderer.RenderKeyValue("Profile", identity.Profile)
+	}
+	return nil
+}
+
+// Lookup signs in with the client and returns who it signs in as. It fails
+// when the credentials are rejected, or ignored by a site allowing
+// anonymous access. The deployment type is left empty when it can't be
+// detected.
+func Lookup(ctx context.Context, client *api.Client) (*Identity, error) {
+	site := client.BaseURL()
+
+	user, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		var apiErr *api.ErrorResponse
+		switch {
+		case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized:
+			return nil, fmt.Errorf("%s rejected the credentials (401): check the email and API token, or run 'cfl auth token-check'", site)
+		case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden:
+			return nil, fmt.Errorf("%s denied access (403): the account may lack access to this site", site)
+		}
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if user.IsAnonymous() {
+		return nil, fmt.Errorf("%s treated the request as anonymous: the credentials were ignored (run 'cfl auth token-check')", site)
+	}
+
+	identity := &Identity{
+		AccountID:   user.AccountID,
+		DisplayName: user.DisplayName,
+		Email:       user.Email,
+		Site:        site,
+	}
+	if caps, err := client.Capabilities(ctx); err == nil {
+		identity.Deployment = caps.Deployment()
+	}
+	return identity, nil
+}
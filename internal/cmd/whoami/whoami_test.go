@@ -0,0 +1,124 @@
+package whoami
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+// newSiteServer serves the current user with the given status and
+// response, and the v2 API when cloud is set.
+func newSiteServer(t *testing.T, status int, user string, cloud bool) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/user/current":
+			w.WriteHeader(status)
+			w.Write([]byte(user))
+		case cloud && strings.HasPrefix(r.URL.Path, "/api/v2/"):
+			w.Write([]byte(`{"results": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunWhoami(t *testing.T) {
+	const user = `{"type": "known", "accountId": "5b10ac8d82e05b22cc7d4ef5", "email": "alex@example.com", "displayName": "Alex Doe"}`
+
+	tests := []struct {
+		name  string
+		user  string
+		cloud bool
+		want  []string
+	}{
+		{
+			name:  "cloud",
+			user:  user,
+			cloud: true,
+			want:  []string{"5b10ac8d82e05b22cc7d4ef5", "Alex Doe", "alex@example.com", "cloud", "work"},
+		},
+		{
+			name: "data center",
+			user: user,
+			want: []string{"Alex Doe", "data-center"},
+		},
+		{
+			name:  "hidden email",
+			user:  `{"type": "known", "accountId": "5b10", "displayName": "Alex Doe"}`,
+			cloud: true,
+			want:  []string{"(hidden)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newSiteServer(t, http.StatusOK, tt.user, tt.cloud)
+			cfg := &config.Config{URL: server.URL, CurrentContext: "work"}
+			client := api.NewClient(server.URL, "alex@example.com", "token")
+
+			var out bytes.Buffer
+			err := runWhoami(&whoamiOptions{noColor: true, stdout: &out}, cfg, client)
+			require.NoError(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+}
+
+func TestRunWhoami_JSON(t *testing.T) {
+	server := newSiteServer(t, http.StatusOK, `{"type": "known", "accountId": "5b10", "email": "alex@example.com", "displayName": "Alex Doe"}`, true)
+	client := api.NewClient(server.URL, "alex@example.com", "token")
+
+	var out bytes.Buffer
+	err := runWhoami(&whoamiOptions{output: "json", stdout: &out}, &config.Config{URL: server.URL}, client)
+	require.NoError(t, err)
+
+	var identity Identity
+	require.NoError(t, json.Unmarshal(out.Bytes(), &identity))
+	assert.Equal(t, Identity{
+		AccountID:   "5b10",
+		DisplayName: "Alex Doe",
+		Email:       "alex@example.com",
+		Site:        server.URL,
+		Deployment:  api.DeploymentCloud,
+	}, identity)
+}
+
+func TestRunWhoami_InvalidCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		user    string
+		wantErr string
+	}{
+		{"rejected", http.StatusUnauthorized, `{"message": "Unauthorized"}`, "rejected the credentials (401)"},
+		{"forbidden", http.StatusForbidden, `{"message": "Forbidden"}`, "denied access (403)"},
+		{"anonymous", http.StatusOK, `{"type": "anonymous"}`, "treated the request as anonymous"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newSiteServer(t, tt.status, tt.user, true)
+			client := api.NewClient(server.URL, "alex@example.com", "token")
+
+			var out bytes.Buffer
+			err := runWhoami(&whoamiOptions{stdout: &out}, &config.Config{URL: server.URL}, client)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Empty(t, out.String())
+		})
+	}
+}
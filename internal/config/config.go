@@ -4,13 +4,48 @@ package config
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+// DefaultRetries is how many times failed requests are retried when the
+// retries key isn't set.
+const DefaultRetries = 3
+
+// retriesFlag is the --retries global flag, or -1 when it isn't given.
+var retriesFlag = -1
+
+// ErrNoConfig is returned when saving the configuration with CFL_NO_CONFIG
+// set.
+var ErrNoConfig = errors.New("CFL_NO_CONFIG is set, so the config file is neither read nor written")
+
+// DebugMode is how much of each HTTP request is logged to stderr.
+type DebugMode int
+
+const (
+	DebugOff      DebugMode = iota
+	DebugRequests           // Method, URL, status and duration
+	DebugBodies             // Requests plus redacted bodies
 )
 
+// debugFlag is the mode set by the --debug and --debug-body global flags.
+var debugFlag DebugMode
+
+// profileFlag is the --profile global flag.
+var profileFlag string
+
+// notifyWatchersFlag is the --notify-watchers global flag, or nil when it
+// isn't given.
+var notifyWatchersFlag *bool
+
 // Config holds the cfl configuration.
 type Config struct {
 	URL          string `yaml:"url"`
@@ -18,10 +53,112 @@ type Config struct {
 	APIToken     string `yaml:"api_token"`
 	DefaultSpace string `yaml:"default_space,omitempty"`
 	OutputFormat string `yaml:"output_format,omitempty"`
+	Retries      *int   `yaml:"retries,omitempty"` // Retries for rate-limited and failed requests; nil for DefaultRetries
+
+	NotifyWatchers *bool `yaml:"notify_watchers,omitempty"` // Whether page updates notify watchers; nil for true
+
+	Timeout     time.Duration `yaml:"timeout,omitempty"`     // Per-request timeout, e.g. 45s; zero for the client default
+	Concurrency int           `yaml:"concurrency,omitempty"` // Maximum concurrent requests for commands that fan out; zero for their default
+
+	LegacySpaces []string `yaml:"legacy_spaces,omitempty"` // Space keys published in legacy editor format unless --legacy is given
+
+	PageCache bool `yaml:"page_cache,omitempty"` // Whether page view caches the pages it fetches, for --offline
+	HTTPCache bool `yaml:"http_cache,omitempty"` // Whether GET responses are cached and revalidated with ETags
+
+	CurrentContext string             `yaml:"current_context,omitempty"` // Profile used when none is selected
+	Contexts       map[string]Profile `yaml:"contexts,omitempty"`        // Named profiles
+
+	PlantUML PlantUMLConfig `yaml:"plantuml,omitempty"`
+	Mermaid  MermaidConfig  `yaml:"mermaid,omitempty"`
+	Glossary GlossaryConfig `yaml:"glossary,omitempty"`
+	Jira     JiraConfig     `yaml:"jira,omitempty"`
+	Images   ImagesConfig   `yaml:"images,omitempty"`
+
+	Classification ClassificationConfig `yaml:"classification,omitempty"`
+}
+
+// Profile holds the settings for one Confluence site. The fields that are
+// set override the top-level ones when the profile is active.
+type Profile struct {
+	URL          string `yaml:"url,omitempty"`
+	Email        string `yaml:"email,omitempty"`
+	APIToken     string `yaml:"api_token,omitempty"`
+	DefaultSpace string `yaml:"default_space,omitempty"`
+	OutputFormat string `yaml:"output_format,omitempty"`
+
+	LegacySpaces []string `yaml:"legacy_spaces,omitempty"`
+}
+
+// PlantUMLConfig configures rendering of plantuml code fences. Fences are
+// left as code blocks unless a jar or server is set.
+type PlantUMLConfig struct {
+	Jar    string `yaml:"jar,omitempty"`    // Path to a local plantuml.jar
+	Server string `yaml:"server,omitempty"` // PlantUML server URL
+}
+
+// MermaidConfig configures how mermaid code fences are published with
+// --mermaid.
+type MermaidConfig struct {
+	Mmdc   string `yaml:"mmdc,omitempty"`   // mermaid-cli executable for --mermaid image (default "mmdc")
+	Format string `yaml:"format,omitempty"` // Image format for --mermaid image: svg (default) or png
+	Macro  string `yaml:"macro,omitempty"`  // Macro name for --mermaid macro (default "mermaid")
+}
+
+// GlossaryConfig lists glossary terms linked to their definition pages
+// when publishing with --glossary.
+type GlossaryConfig struct {
+	Terms map[string]string `yaml:"terms,omitempty"` // Term → definition page ID or URL
+	Space string            `yaml:"space,omitempty"` // Space key whose page titles are terms
+}
+
+// JiraConfig configures how Jira issues in markdown are linked.
+type JiraConfig struct {
+	Projects []string `yaml:"projects,omitempty"`  // Project keys whose bare issue keys, e.g. PROJ-123, are linked
+	URL      string   `yaml:"url,omitempty"`       // Jira site for smart links; defaults to the Confluence site
+	Server   string   `yaml:"server,omitempty"`    // Application link name for Jira macros in legacy pages
+	ServerID string   `yaml:"server_id,omitempty"` // Application link ID for Jira macros in legacy pages
+}
+
+// ImagesConfig configures how images are shrunk before upload, by
+// attachment upload and for the local images of pages published with page
+// create, edit and sync. The --optimize, --max-width, --max-height and
+// --quality flags override it.
+type ImagesConfig struct {
+	Optimize  bool `yaml:"optimize,omitempty"`   // Optimize images without --optimize
+	MaxWidth  int  `yaml:"max_width,omitempty"`  // Images wider than this are scaled down; zero for 1920
+	MaxHeight int  `yaml:"max_height,omitempty"` // Images taller than this are scaled down; zero for 1920
+	Quality   int  `yaml:"quality,omitempty"`    // JPEG quality 1-100; zero for 85
+}
+
+// ClassificationConfig requires every page published with page create,
+// edit or sync to carry one of a set of classification labels.
+type ClassificationConfig struct {
+	Labels  []string `yaml:"labels,omitempty"`  // Classification labels, e.g. public, internal, confidential; empty to not require one
+	Default string   `yaml:"default,omitempty"` // Label added to pages without one; empty to refuse to publish them
+}
+
+// Classified returns the classification label among labels, or "" when
+// there is none. Labels are compared case-insensitively.
+func (c ClassificationConfig) Classified(labels []string) string {
+	for _, label := range labels {
+		for _, classification := range c.Labels {
+			if strings.EqualFold(label, classification) {
+				return label
+			}
+		}
+	}
+	return ""
 }
 
+// ErrNotConfigured is returned by Validate when none of the connection
+// settings are set, which is the case on first run.
+var ErrNotConfigured = errors.New("cfl is not configured")
+
 // Validate checks that all required fields are present and valid.
 func (c *Config) Validate() error {
+	if c.URL == "" && c.Email == "" && c.APIToken == "" {
+		return ErrNotConfigured
+	}
 	if c.URL == "" {
 		return errors.New("url is required")
 	}
@@ -37,6 +174,70 @@ func (c *Config) Validate() error {
 		return errors.New("url must use https")
 	}
 
+	if c.Retries != nil && *c.Retries < 0 {
+		return errors.New("retries must not be negative")
+	}
+	if c.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	if c.Concurrency < 0 {
+		return errors.New("concurrency must not be negative")
+	}
+	if c.Images.MaxWidth < 0 || c.Images.MaxHeight < 0 {
+		return errors.New("images.max_width and images.max_height must not be negative")
+	}
+	if c.Images.Quality < 0 || c.Images.Quality > 100 {
+		return errors.New("images.quality must be between 1 and 100")
+	}
+	if c.Classification.Default != "" && c.Classification.Classified([]string{c.Classification.Default}) == "" {
+		return fmt.Errorf("classification.default %q is not one of classification.labels", c.Classification.Default)
+	}
+
+	return nil
+}
+
+// ActiveProfile returns the name of the selected profile: the --profile
+// flag, then CFL_PROFILE, then current_context. It is empty when the
+// top-level settings are used.
+func (c *Config) ActiveProfile() string {
+	if profileFlag != "" {
+		return profileFlag
+	}
+	if name := os.Getenv("CFL_PROFILE"); name != "" {
+		return name
+	}
+	return c.CurrentContext
+}
+
+// UseProfile overrides the top-level settings with those of the named
+// profile. An empty name leaves the configuration unchanged.
+func (c *Config) UseProfile(name string) error {
+	if name == "" {
+		return nil
+	}
+	p, ok := c.Contexts[name]
+	if !ok {
+		return fmt.Errorf("unknown profile %q", name)
+	}
+
+	if p.URL != "" {
+		c.URL = p.URL
+	}
+	if p.Email != "" {
+		c.Email = p.Email
+	}
+	if p.APIToken != "" {
+		c.APIToken = p.APIToken
+	}
+	if p.DefaultSpace != "" {
+		c.DefaultSpace = p.DefaultSpace
+	}
+	if p.OutputFormat != "" {
+		c.OutputFormat = p.OutputFormat
+	}
+	if p.LegacySpaces != nil {
+		c.LegacySpaces = p.LegacySpaces
+	}
 	return nil
 }
 
@@ -64,6 +265,152 @@ func (c *Config) LoadFromEnv() {
 	if space := os.Getenv("CFL_DEFAULT_SPACE"); space != "" {
 		c.DefaultSpace = space
 	}
+	if jar := os.Getenv("CFL_PLANTUML_JAR"); jar != "" {
+		c.PlantUML.Jar = jar
+	}
+	if server := os.Getenv("CFL_PLANTUML_SERVER"); server != "" {
+		c.PlantUML.Server = server
+	}
+	if mmdc := os.Getenv("CFL_MERMAID_MMDC"); mmdc != "" {
+		c.Mermaid.Mmdc = mmdc
+	}
+	if format := os.Getenv("CFL_OUTPUT"); format != "" {
+		c.OutputFormat = format
+	}
+	if timeout, err := time.ParseDuration(os.Getenv("CFL_TIMEOUT")); err == nil {
+		c.Timeout = timeout
+	}
+	if concurrency, err := strconv.Atoi(os.Getenv("CFL_CONCURRENCY")); err == nil {
+		c.Concurrency = concurrency
+	}
+	if retries, err := strconv.Atoi(os.Getenv("CFL_RETRIES")); err == nil {
+		c.Retries = &retries
+	}
+	if notify, err := strconv.ParseBool(os.Getenv("CFL_NOTIFY_WATCHERS")); err == nil {
+		c.NotifyWatchers = &notify
+	}
+	if spaces := os.Getenv("CFL_LEGACY_SPACES"); spaces != "" {
+		c.LegacySpaces = splitList(spaces)
+	}
+	if cache, err := strconv.ParseBool(os.Getenv("CFL_PAGE_CACHE")); err == nil {
+		c.PageCache = cache
+	}
+	if cache, err := strconv.ParseBool(os.Getenv("CFL_HTTP_CACHE")); err == nil {
+		c.HTTPCache = cache
+	}
+	if optimize, err := strconv.ParseBool(os.Getenv("CFL_OPTIMIZE_IMAGES")); err == nil {
+		c.Images.Optimize = optimize
+	}
+	if width, err := strconv.Atoi(os.Getenv("CFL_IMAGE_MAX_WIDTH")); err == nil {
+		c.Images.MaxWidth = width
+	}
+	if height, err := strconv.Atoi(os.Getenv("CFL_IMAGE_MAX_HEIGHT")); err == nil {
+		c.Images.MaxHeight = height
+	}
+	if quality, err := strconv.Atoi(os.Getenv("CFL_IMAGE_QUALITY")); err == nil {
+		c.Images.Quality = quality
+	}
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(s string) []string {
+	list := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// MaxRetries returns how many times failed requests are retried.
+func (c *Config) MaxRetries() int {
+	if c.Retries == nil {
+		return DefaultRetries
+	}
+	return *c.Retries
+}
+
+// IsLegacySpace reports whether pages in the space are published in legacy
+// editor format by default, according to the configured legacy spaces.
+// Space keys are compared case-insensitively.
+func IsLegacySpace(legacySpaces []string, spaceKey string) bool {
+	for _, key := range legacySpaces {
+		if strings.EqualFold(key, spaceKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// Notify reports whether page updates notify the page's watchers.
+func (c *Config) Notify() bool {
+	return c.NotifyWatchers == nil || *c.NotifyWatchers
+}
+
+// ClientOptions returns the options for an API client using this
+// configuration, including HTTP logging when debugging is enabled.
+func (c *Config) ClientOptions() []api.Option {
+	opts := []api.Option{
+		api.WithCapabilityCache(CapabilitiesCachePath()),
+		api.WithRetry(c.MaxRetries()),
+		api.WithNotifyWatchers(c.Notify()),
+	}
+	if c.Timeout > 0 {
+		opts = append(opts, api.WithTimeout(c.Timeout))
+	}
+	if c.HTTPCache {
+		opts = append(opts, api.WithResponseCache(ResponseCacheDir()))
+	}
+
+	switch Debug() {
+	case DebugBodies:
+		opts = append(opts, api.WithBodyLogging())
+		fallthrough
+	case DebugRequests:
+		opts = append(opts, api.WithLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	}
+	return opts
+}
+
+// Debug returns the HTTP logging mode: the --debug and --debug-body flags,
+// or else CFL_DEBUG ("1" or "true" for requests, "body" for bodies too).
+func Debug() DebugMode {
+	if debugFlag != DebugOff {
+		return debugFlag
+	}
+	env := os.Getenv("CFL_DEBUG")
+	if strings.EqualFold(env, "body") {
+		return DebugBodies
+	}
+	if on, _ := strconv.ParseBool(env); on {
+		return DebugRequests
+	}
+	return DebugOff
+}
+
+// SetDebugFlag records the mode set by the --debug and --debug-body
+// global flags, which takes precedence over CFL_DEBUG.
+func SetDebugFlag(mode DebugMode) {
+	debugFlag = mode
+}
+
+// SetProfileFlag records the --profile global flag, which takes precedence
+// over CFL_PROFILE and current_context.
+func SetProfileFlag(name string) {
+	profileFlag = name
+}
+
+// SetRetriesFlag records the --retries global flag, which takes precedence
+// over the retries key and CFL_RETRIES.
+func SetRetriesFlag(n int) {
+	retriesFlag = n
+}
+
+// SetNotifyWatchersFlag records the --notify-watchers global flag, which
+// takes precedence over the notify_watchers key and CFL_NOTIFY_WATCHERS.
+func SetNotifyWatchersFlag(notify bool) {
+	notifyWatchersFlag = &notify
 }
 
 // getEnvWithFallback returns the value of the primary env var, or the fallback if primary is empty.
@@ -74,6 +421,14 @@ func getEnvWithFallback(primary, fallback string) string {
 	return os.Getenv(fallback)
 }
 
+// NoConfig reports whether CFL_NO_CONFIG is set, for CI: configuration
+// comes from the environment only and nothing is written to the home
+// directory. Caches are kept in the system temp directory instead.
+func NoConfig() bool {
+	noConfig, _ := strconv.ParseBool(os.Getenv("CFL_NO_CONFIG"))
+	return noConfig
+}
+
 // DefaultConfigPath returns the default configuration file path.
 func DefaultConfigPath() string {
 	// Try XDG config directory first
@@ -90,8 +445,62 @@ func DefaultConfigPath() string {
 	return filepath.Join(home, ".config", "cfl", "config.yml")
 }
 
-// Save writes the configuration to the specified path.
+// TemplateDir returns the directory page templates are stored in, next to
+// the config file.
+func TemplateDir() string {
+	return filepath.Join(filepath.Dir(DefaultConfigPath()), "templates")
+}
+
+// DefaultCacheDir returns the directory cfl caches data in.
+func DefaultCacheDir() string {
+	if NoConfig() {
+		return filepath.Join(os.TempDir(), "cfl-cache")
+	}
+	if xdgCache := os.Getenv("XDG_CACHE_HOME"); xdgCache != "" {
+		return filepath.Join(xdgCache, "cfl")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".", ".cfl", "cache")
+	}
+
+	return filepath.Join(home, ".cache", "cfl")
+}
+
+// CapabilitiesCachePath returns the path of the per-site capability cache.
+func CapabilitiesCachePath() string {
+	return filepath.Join(DefaultCacheDir(), "capabilities.json")
+}
+
+// ResponseCacheDir returns the directory of cached GET responses.
+func ResponseCacheDir() string {
+	return filepath.Join(DefaultCacheDir(), "http")
+}
+
+// UpdateCheckCachePath returns the path of the cached release lookup.
+func UpdateCheckCachePath() string {
+	return filepath.Join(DefaultCacheDir(), "update-check.json")
+}
+
+// TreeIndexCachePath returns the path of the cached space page trees.
+func TreeIndexCachePath() string {
+	return filepath.Join(DefaultCacheDir(), "tree-index.json")
+}
+
+// DiagramCacheDir returns the directory rendered diagrams of a kind, such
+// as "plantuml", are cached in.
+func DiagramCacheDir(kind string) string {
+	return filepath.Join(DefaultCacheDir(), kind)
+}
+
+// Save writes the configuration to the specified path. It fails with
+// ErrNoConfig when CFL_NO_CONFIG is set.
 func (c *Config) Save(path string) error {
+	if NoConfig() {
+		return ErrNoConfig
+	}
+
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -126,14 +535,56 @@ func Load(path string) (*Config, error) {
 	return &cfg, nil
 }
 
-// LoadWithEnv loads configuration from file and overrides with environment variables.
+// LoadWithEnv loads configuration from file, applies the active profile
+// and overrides with environment variables. The file is skipped when
+// CFL_NO_CONFIG is set.
 func LoadWithEnv(path string) (*Config, error) {
-	cfg, err := Load(path)
-	if err != nil {
-		// If file doesn't exist, start with empty config
-		cfg = &Config{}
+	cfg := &Config{}
+	if !NoConfig() {
+		if loaded, err := Load(path); err == nil {
+			cfg = loaded
+		}
+	}
+
+	if err := cfg.UseProfile(cfg.ActiveProfile()); err != nil {
+		return nil, err
+	}
+
+	cfg.LoadFromEnv()
+	if retriesFlag >= 0 {
+		retries := retriesFlag
+		cfg.Retries = &retries
+	}
+	if notifyWatchersFlag != nil {
+		notify := *notifyWatchersFlag
+		cfg.NotifyWatchers = &notify
+	}
+	return cfg, nil
+}
+
+// LoadProfile loads configuration as LoadWithEnv does, but for the named
+// profile rather than the active one, for commands that talk to two sites
+// at once. The profile's settings take precedence over the environment, so
+// CFL_URL and the like can't point both sites at the same one.
+func LoadProfile(path, name string) (*Config, error) {
+	cfg := &Config{}
+	if !NoConfig() {
+		if loaded, err := Load(path); err == nil {
+			cfg = loaded
+		}
 	}
 
 	cfg.LoadFromEnv()
+	if err := cfg.UseProfile(name); err != nil {
+		return nil, err
+	}
+	if retriesFlag >= 0 {
+		retries := retriesFlag
+		cfg.Retries = &retries
+	}
+	if notifyWatchersFlag != nil {
+		notify := *notifyWatchersFlag
+		cfg.NotifyWatchers = &notify
+	}
 	return cfg, nil
 }
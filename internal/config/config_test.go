@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,6 +27,12 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "not configured",
+			config:  Config{},
+			wantErr: true,
+			errMsg:  "cfl is not configured",
+		},
 		{
 			name: "missing URL",
 			config: Config{
@@ -299,3 +306,406 @@ func TestGetEnvWithFallback(t *testing.T) {
 		assert.Equal(t, "", getEnvWithFallback("TEST_PRIMARY", "TEST_FALLBACK"))
 	})
 }
+
+func TestConfig_LoadFromEnv_PlantUML(t *testing.T) {
+	t.Setenv("CFL_PLANTUML_JAR", "/opt/plantuml.jar")
+	t.Setenv("CFL_PLANTUML_SERVER", "https://plantuml.example.com")
+
+	cfg := &Config{PlantUML: PlantUMLConfig{Server: "https://original.example.com"}}
+	cfg.LoadFromEnv()
+
+	assert.Equal(t, "/opt/plantuml.jar", cfg.PlantUML.Jar)
+	assert.Equal(t, "https://plantuml.example.com", cfg.PlantUML.Server)
+}
+
+func TestConfig_LoadFromEnv_Mermaid(t *testing.T) {
+	t.Setenv("CFL_MERMAID_MMDC", "/opt/mmdc")
+
+	cfg := &Config{Mermaid: MermaidConfig{Mmdc: "mmdc", Format: "png"}}
+	cfg.LoadFromEnv()
+
+	assert.Equal(t, "/opt/mmdc", cfg.Mermaid.Mmdc)
+	assert.Equal(t, "png", cfg.Mermaid.Format)
+}
+
+func TestLoad_Glossary(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `url: https://test.atlassian.net/wiki
+email: test@example.com
+api_token: token
+glossary:
+  space: GLOSS
+  terms:
+    API: "12345"
+    Widget: https://example.com/widget
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "GLOSS", cfg.Glossary.Space)
+	assert.Equal(t, map[string]string{"API": "12345", "Widget": "https://example.com/widget"}, cfg.Glossary.Terms)
+}
+
+func TestLoad_Classification(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `url: https://test.atlassian.net/wiki
+email: test@example.com
+api_token: token
+classification:
+  labels: [public, internal, confidential]
+  default: internal
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, []string{"public", "internal", "confidential"}, cfg.Classification.Labels)
+	assert.Equal(t, "internal", cfg.Classification.Default)
+
+	assert.Equal(t, "Confidential", cfg.Classification.Classified([]string{"draft", "Confidential"}))
+	assert.Equal(t, "", cfg.Classification.Classified([]string{"draft"}))
+
+	cfg.Classification.Default = "secret"
+	assert.ErrorContains(t, cfg.Validate(), `classification.default "secret" is not one of classification.labels`)
+}
+
+func TestConfig_Retries(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `url: https://test.atlassian.net/wiki
+email: test@example.com
+api_token: token
+retries: 5
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	// Unset uses the default
+	assert.Equal(t, DefaultRetries, (&Config{}).MaxRetries())
+
+	// Config file
+	cfg, err := LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, 5, cfg.MaxRetries())
+
+	// Environment overrides the config file, and 0 disables retries
+	t.Setenv("CFL_RETRIES", "0")
+	cfg, err = LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, 0, cfg.MaxRetries())
+
+	// The --retries flag overrides both
+	SetRetriesFlag(8)
+	t.Cleanup(func() { SetRetriesFlag(-1) })
+	cfg, err = LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, 8, cfg.MaxRetries())
+}
+
+func TestConfig_NotifyWatchers(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `url: https://test.atlassian.net/wiki
+email: test@example.com
+api_token: token
+notify_watchers: false
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	// Unset notifies
+	assert.True(t, (&Config{}).Notify())
+
+	// Config file
+	cfg, err := LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.False(t, cfg.Notify())
+
+	// Environment overrides the config file
+	t.Setenv("CFL_NOTIFY_WATCHERS", "true")
+	cfg, err = LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.True(t, cfg.Notify())
+
+	// The --notify-watchers flag overrides both
+	SetNotifyWatchersFlag(false)
+	t.Cleanup(func() { notifyWatchersFlag = nil })
+	cfg, err = LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.False(t, cfg.Notify())
+}
+
+func TestConfig_PageCache(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `url: https://test.atlassian.net/wiki
+email: test@example.com
+api_token: token
+page_cache: true
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	cfg, err := LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.True(t, cfg.PageCache)
+
+	// Environment overrides the config file
+	t.Setenv("CFL_PAGE_CACHE", "0")
+	cfg, err = LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.False(t, cfg.PageCache)
+}
+
+func TestConfig_HTTPCache(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `url: https://test.atlassian.net/wiki
+email: test@example.com
+api_token: token
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	cfg, err := LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.False(t, cfg.HTTPCache)
+	withoutCache := len(cfg.ClientOptions())
+
+	t.Setenv("CFL_HTTP_CACHE", "true")
+	cfg, err = LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.True(t, cfg.HTTPCache)
+	assert.Len(t, cfg.ClientOptions(), withoutCache+1)
+}
+
+func TestConfig_Images(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `url: https://test.atlassian.net/wiki
+email: test@example.com
+api_token: token
+images:
+  optimize: true
+  max_width: 1280
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	cfg, err := LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, ImagesConfig{Optimize: true, MaxWidth: 1280}, cfg.Images)
+
+	// Environment overrides the config file
+	t.Setenv("CFL_OPTIMIZE_IMAGES", "false")
+	t.Setenv("CFL_IMAGE_MAX_HEIGHT", "720")
+	t.Setenv("CFL_IMAGE_QUALITY", "70")
+	cfg, err = LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, ImagesConfig{MaxWidth: 1280, MaxHeight: 720, Quality: 70}, cfg.Images)
+
+	cfg.Images.Quality = 101
+	assert.ErrorContains(t, cfg.Validate(), "images.quality")
+}
+
+func TestDebug(t *testing.T) {
+	tests := []struct {
+		env  string
+		flag DebugMode
+		want DebugMode
+	}{
+		{"", DebugOff, DebugOff},
+		{"1", DebugOff, DebugRequests},
+		{"true", DebugOff, DebugRequests},
+		{"BODY", DebugOff, DebugBodies},
+		{"0", DebugOff, DebugOff},
+		{"", DebugBodies, DebugBodies},
+		{"body", DebugRequests, DebugRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			t.Setenv("CFL_DEBUG", tt.env)
+			SetDebugFlag(tt.flag)
+			t.Cleanup(func() { SetDebugFlag(DebugOff) })
+			assert.Equal(t, tt.want, Debug())
+		})
+	}
+}
+
+func TestLoadWithEnv_Profiles(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `url: https://default.atlassian.net/wiki
+email: me@example.com
+api_token: default-token
+current_context: work
+contexts:
+  work:
+    url: https://work.atlassian.net/wiki
+    api_token: work-token
+  personal:
+    url: https://me.atlassian.net/wiki
+    default_space: HOME
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	// current_context, falling back to top-level settings for unset fields
+	cfg, err := LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "work", cfg.ActiveProfile())
+	assert.Equal(t, "https://work.atlassian.net/wiki", cfg.URL)
+	assert.Equal(t, "me@example.com", cfg.Email)
+	assert.Equal(t, "work-token", cfg.APIToken)
+
+	// CFL_PROFILE overrides current_context
+	t.Setenv("CFL_PROFILE", "personal")
+	cfg, err = LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "https://me.atlassian.net/wiki", cfg.URL)
+	assert.Equal(t, "default-token", cfg.APIToken)
+	assert.Equal(t, "HOME", cfg.DefaultSpace)
+
+	// The --profile flag overrides both
+	SetProfileFlag("missing")
+	t.Cleanup(func() { SetProfileFlag("") })
+	_, err = LoadWithEnv(configPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown profile "missing"`)
+}
+
+func TestLoadProfile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `url: https://default.atlassian.net/wiki
+email: me@example.com
+api_token: default-token
+current_context: work
+contexts:
+  work:
+    url: https://work.atlassian.net/wiki
+  personal:
+    url: https://me.atlassian.net/wiki
+    api_token: personal-token
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+	t.Setenv("CFL_URL", "https://env.atlassian.net/wiki")
+	t.Setenv("CFL_API_TOKEN", "env-token")
+
+	// The named profile is used whatever is active, and its settings win
+	// over the environment's
+	cfg, err := LoadProfile(configPath, "personal")
+	require.NoError(t, err)
+	assert.Equal(t, "https://me.atlassian.net/wiki", cfg.URL)
+	assert.Equal(t, "personal-token", cfg.APIToken)
+	assert.Equal(t, "me@example.com", cfg.Email)
+
+	cfg, err = LoadProfile(configPath, "work")
+	require.NoError(t, err)
+	assert.Equal(t, "https://work.atlassian.net/wiki", cfg.URL)
+	assert.Equal(t, "env-token", cfg.APIToken)
+
+	_, err = LoadProfile(configPath, "missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown profile "missing"`)
+}
+
+func TestConfig_Validate_NegativeRetries(t *testing.T) {
+	retries := -1
+	cfg := &Config{URL: "https://test.atlassian.net/wiki", Email: "test@example.com", APIToken: "token", Retries: &retries}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "retries must not be negative")
+}
+
+func TestConfig_LoadFromEnv_CISettings(t *testing.T) {
+	t.Setenv("CFL_OUTPUT", "json")
+	t.Setenv("CFL_TIMEOUT", "45s")
+	t.Setenv("CFL_CONCURRENCY", "2")
+
+	cfg := &Config{OutputFormat: "table", Timeout: 10 * time.Second, Concurrency: 8}
+	cfg.LoadFromEnv()
+
+	assert.Equal(t, "json", cfg.OutputFormat)
+	assert.Equal(t, 45*time.Second, cfg.Timeout)
+	assert.Equal(t, 2, cfg.Concurrency)
+}
+
+func TestLoad_TimeoutAndConcurrency(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `url: https://test.atlassian.net/wiki
+timeout: 1m30s
+concurrency: 4
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, cfg.Timeout)
+	assert.Equal(t, 4, cfg.Concurrency)
+}
+
+func TestLegacySpaces(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `url: https://test.atlassian.net/wiki
+legacy_spaces: [OLD, Wiki]
+contexts:
+  archive:
+    legacy_spaces: [ARCH]
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	cfg, err := LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OLD", "Wiki"}, cfg.LegacySpaces)
+	assert.True(t, IsLegacySpace(cfg.LegacySpaces, "OLD"))
+	assert.True(t, IsLegacySpace(cfg.LegacySpaces, "WIKI"))
+	assert.False(t, IsLegacySpace(cfg.LegacySpaces, "DEV"))
+
+	// A profile's list replaces the top-level one
+	t.Setenv("CFL_PROFILE", "archive")
+	cfg, err = LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ARCH"}, cfg.LegacySpaces)
+
+	// CFL_LEGACY_SPACES overrides both
+	t.Setenv("CFL_LEGACY_SPACES", "CI, OPS,")
+	cfg, err = LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CI", "OPS"}, cfg.LegacySpaces)
+}
+
+func TestConfig_Validate_NegativeTimeoutAndConcurrency(t *testing.T) {
+	valid := Config{URL: "https://test.atlassian.net/wiki", Email: "test@example.com", APIToken: "token"}
+
+	cfg := valid
+	cfg.Timeout = -time.Second
+	assert.ErrorContains(t, cfg.Validate(), "timeout must not be negative")
+
+	cfg = valid
+	cfg.Concurrency = -1
+	assert.ErrorContains(t, cfg.Validate(), "concurrency must not be negative")
+}
+
+func TestNoConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+
+	configPath := DefaultConfigPath()
+	cfg := &Config{URL: "https://file.atlassian.net/wiki", Email: "file@example.com", APIToken: "file-token"}
+	require.NoError(t, cfg.Save(configPath))
+
+	t.Setenv("CFL_NO_CONFIG", "1")
+	t.Setenv("CFL_URL", "https://env.atlassian.net/wiki")
+	assert.True(t, NoConfig())
+
+	// The config file is ignored
+	loaded, err := LoadWithEnv(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "https://env.atlassian.net/wiki", loaded.URL)
+	assert.Empty(t, loaded.Email)
+	assert.Empty(t, loaded.APIToken)
+
+	// Nothing is written under the home directory
+	assert.ErrorIs(t, loaded.Save(configPath), ErrNoConfig)
+	assert.False(t, strings.HasPrefix(DefaultCacheDir(), home))
+	assert.False(t, strings.HasPrefix(CapabilitiesCachePath(), home))
+	assert.False(t, strings.HasPrefix(DiagramCacheDir("plantuml"), home))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "file@example.com")
+}
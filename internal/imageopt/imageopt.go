@@ -0,0 +1,165 @@
+// Package imageopt shrinks images before they are uploaded as attachments.
+package imageopt
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"path/filepath"
+	"strings"
+)
+
+// Default limits applied when Options fields are zero.
+const (
+	DefaultMaxWidth  = 1920
+	DefaultMaxHeight = 1920
+	DefaultQuality   = 85
+)
+
+// Options controls image optimization.
+type Options struct {
+	MaxWidth  int // Images wider than this are scaled down (default 1920)
+	MaxHeight int // Images taller than this are scaled down (default 1920)
+	Quality   int // JPEG quality 1-100 (default 85); PNGs are always lossless
+}
+
+// Result describes the outcome of Optimize.
+type Result struct {
+	Data           []byte
+	Optimized      bool // False if the original data was kept
+	OriginalSize   int
+	OriginalWidth  int
+	OriginalHeight int
+	Width          int
+	Height         int
+}
+
+// Optimize downscales and recompresses a JPEG or PNG image. Other formats
+// (including SVG, which is vector, and GIF, which may be animated) are
+// returned unchanged. The original data is also kept if optimizing would
+// not make it smaller.
+func Optimize(filename string, data []byte, opts Options) (*Result, error) {
+	opts = opts.withDefaults()
+	if opts.Quality < 1 || opts.Quality > 100 {
+		return nil, fmt.Errorf("quality must be between 1 and 100, got %d", opts.Quality)
+	}
+
+	result := &Result{Data: data, OriginalSize: len(data)}
+
+	if !Supported(filename) {
+		return result, nil
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", filepath.Base(filename), err)
+	}
+
+	bounds := img.Bounds()
+	result.OriginalWidth, result.OriginalHeight = bounds.Dx(), bounds.Dy()
+	result.Width, result.Height = result.OriginalWidth, result.OriginalHeight
+
+	width, height := fitWithin(bounds.Dx(), bounds.Dy(), opts.MaxWidth, opts.MaxHeight)
+	if width != bounds.Dx() || height != bounds.Dy() {
+		img = downscale(img, width, height)
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: opts.Quality})
+	case "png":
+		enc := png.Encoder{CompressionLevel: png.BestCompression}
+		err = enc.Encode(&buf, img)
+	default:
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", filepath.Base(filename), err)
+	}
+
+	if buf.Len() >= len(data) {
+		return result, nil
+	}
+
+	result.Data = buf.Bytes()
+	result.Optimized = true
+	result.Width, result.Height = width, height
+	return result, nil
+}
+
+// Supported reports whether Optimize can process a file, based on its extension.
+func Supported(filename string) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".jpg", ".jpeg", ".png":
+		return true
+	}
+	return false
+}
+
+func (o Options) withDefaults() Options {
+	if o.MaxWidth <= 0 {
+		o.MaxWidth = DefaultMaxWidth
+	}
+	if o.MaxHeight <= 0 {
+		o.MaxHeight = DefaultMaxHeight
+	}
+	if o.Quality == 0 {
+		o.Quality = DefaultQuality
+	}
+	return o
+}
+
+// fitWithin returns the largest size with the same aspect ratio as w×h
+// that fits in maxW×maxH. Images that already fit are not enlarged.
+func fitWithin(w, h, maxW, maxH int) (int, int) {
+	if w <= maxW && h <= maxH {
+		return w, h
+	}
+	if w*maxH > h*maxW {
+		return maxW, max(1, h*maxW/w)
+	}
+	return max(1, w*maxH/h), maxH
+}
+
+// downscale resizes img to w×h by averaging the source pixels that fall
+// into each destination pixel (a box filter), which avoids the aliasing of
+// nearest-neighbour sampling when shrinking screenshots.
+func downscale(img image.Image, w, h int) image.Image {
+	sb := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, sb.Dx(), sb.Dy()))
+	draw.Draw(src, src.Bounds(), img, sb.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	sw, sh := sb.Dx(), sb.Dy()
+
+	for y := 0; y < h; y++ {
+		y0, y1 := y*sh/h, max((y+1)*sh/h, y*sh/h+1)
+		for x := 0; x < w; x++ {
+			x0, x1 := x*sw/w, max((x+1)*sw/w, x*sw/w+1)
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				off := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += int(src.Pix[off])
+					g += int(src.Pix[off+1])
+					b += int(src.Pix[off+2])
+					a += int(src.Pix[off+3])
+					off += 4
+					n++
+				}
+			}
+
+			d := dst.PixOffset(x, y)
+			dst.Pix[d] = uint8(r / n)
+			dst.Pix[d+1] = uint8(g / n)
+			dst.Pix[d+2] = uint8(b / n)
+			dst.Pix[d+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,115 @@
+package imageopt
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gradient(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: uint8(x + y), A: 255})
+		}
+	}
+	return img
+}
+
+func encodeJPEG(t *testing.T, img image.Image, quality int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}))
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := png.Encoder{CompressionLevel: png.NoCompression}
+	require.NoError(t, enc.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestOptimize_DownscalesJPEG(t *testing.T) {
+	data := encodeJPEG(t, gradient(800, 400), 100)
+
+	result, err := Optimize("shot.jpg", data, Options{MaxWidth: 200, Quality: 70})
+	require.NoError(t, err)
+
+	assert.True(t, result.Optimized)
+	assert.Less(t, len(result.Data), len(data))
+	assert.Equal(t, 800, result.OriginalWidth)
+	assert.Equal(t, 200, result.Width)
+	assert.Equal(t, 100, result.Height)
+
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(result.Data))
+	require.NoError(t, err)
+	assert.Equal(t, 200, cfg.Width)
+	assert.Equal(t, 100, cfg.Height)
+}
+
+func TestOptimize_RecompressesPNG(t *testing.T) {
+	data := encodePNG(t, gradient(300, 300))
+
+	result, err := Optimize("diagram.PNG", data, Options{})
+	require.NoError(t, err)
+
+	assert.True(t, result.Optimized)
+	assert.Less(t, len(result.Data), len(data))
+	assert.Equal(t, 300, result.Width)
+
+	_, err = png.Decode(bytes.NewReader(result.Data))
+	require.NoError(t, err)
+}
+
+func TestOptimize_KeepsOriginalWhenNotSmaller(t *testing.T) {
+	data := encodeJPEG(t, gradient(50, 50), 10)
+
+	result, err := Optimize("tiny.jpg", data, Options{Quality: 100})
+	require.NoError(t, err)
+
+	assert.False(t, result.Optimized)
+	assert.Equal(t, data, result.Data)
+}
+
+func TestOptimize_SkipsUnsupported(t *testing.T) {
+	for _, name := range []string{"diagram.svg", "anim.gif", "doc.pdf"} {
+		data := []byte("<svg></svg>")
+		result, err := Optimize(name, data, Options{})
+		require.NoError(t, err, name)
+		assert.False(t, result.Optimized, name)
+		assert.Equal(t, data, result.Data, name)
+	}
+}
+
+func TestOptimize_Errors(t *testing.T) {
+	_, err := Optimize("broken.png", []byte("not a png"), Options{})
+	assert.Error(t, err)
+
+	_, err = Optimize("photo.jpg", nil, Options{Quality: 101})
+	assert.Error(t, err)
+}
+
+func TestFitWithin(t *testing.T) {
+	tests := []struct {
+		w, h, maxW, maxH int
+		wantW, wantH     int
+	}{
+		{100, 50, 200, 200, 100, 50},
+		{400, 200, 200, 200, 200, 100},
+		{200, 400, 200, 200, 100, 200},
+		{5000, 1, 100, 100, 100, 1},
+	}
+	for _, tt := range tests {
+		w, h := fitWithin(tt.w, tt.h, tt.maxW, tt.maxH)
+		assert.Equal(t, tt.wantW, w)
+		assert.Equal(t, tt.wantH, h)
+	}
+}
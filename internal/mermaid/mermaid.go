@@ -0,0 +1,135 @@
+// Package mermaid renders Mermaid diagrams in markdown to image files.
+package mermaid
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// fenceLang is the code fence language rendered as a diagram.
+const fenceLang = "mermaid"
+
+// Image formats the renderer can produce.
+const (
+	FormatSVG = "svg"
+	FormatPNG = "png"
+)
+
+// Renderer renders Mermaid source with the mermaid-cli (mmdc). Rendered
+// diagrams are cached on disk by source hash, so unchanged diagrams are
+// not rendered again.
+type Renderer struct {
+	Mmdc     string // mmdc executable (default "mmdc")
+	Format   string // FormatSVG (default) or FormatPNG
+	CacheDir string // Directory for rendered diagrams
+}
+
+// New creates a renderer caching diagrams in cacheDir.
+func New(mmdc, format, cacheDir string) *Renderer {
+	return &Renderer{Mmdc: mmdc, Format: format, CacheDir: cacheDir}
+}
+
+// ValidateFormat checks an image format name.
+func ValidateFormat(format string) error {
+	switch format {
+	case "", FormatSVG, FormatPNG:
+		return nil
+	}
+	return fmt.Errorf("invalid mermaid format %q: must be %s or %s", format, FormatSVG, FormatPNG)
+}
+
+func (r *Renderer) format() string {
+	if r.Format == "" {
+		return FormatSVG
+	}
+	return r.Format
+}
+
+// RenderMarkdown replaces ```mermaid fences in markdown with image
+// references to rendered files, which are then uploaded and embedded like
+// any other local image. Markdown is returned unchanged by a nil renderer.
+func (r *Renderer) RenderMarkdown(ctx context.Context, markdown []byte) ([]byte, error) {
+	if r == nil {
+		return markdown, nil
+	}
+	return md.ReplaceFencedBlocks(markdown, fenceLang, func(block md.FencedBlock) (string, error) {
+		path, err := r.Render(ctx, block.Code)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("![Mermaid diagram](<%s>)\n", filepath.ToSlash(path)), nil
+	})
+}
+
+// Render renders diagram source to an image file and returns its path.
+func (r *Renderer) Render(ctx context.Context, source string) (string, error) {
+	if err := ValidateFormat(r.Format); err != nil {
+		return "", err
+	}
+	source = strings.TrimSpace(source) + "\n"
+
+	sum := sha256.Sum256([]byte(source))
+	path := filepath.Join(r.CacheDir, "mermaid-"+hex.EncodeToString(sum[:8])+"."+r.format())
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	if err := os.MkdirAll(r.CacheDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create diagram cache: %w", err)
+	}
+	// Render into a scratch directory so a failed run never leaves a partial cache entry
+	tmp, err := os.MkdirTemp(r.CacheDir, "render-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create diagram cache: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	input := filepath.Join(tmp, "diagram.mmd")
+	if err := os.WriteFile(input, []byte(source), 0644); err != nil {
+		return "", fmt.Errorf("failed to write diagram source: %w", err)
+	}
+	output := filepath.Join(tmp, "diagram."+r.format())
+	if err := r.run(ctx, input, output); err != nil {
+		return "", fmt.Errorf("failed to render Mermaid diagram: %w", err)
+	}
+
+	if err := os.Rename(output, path); err != nil {
+		return "", fmt.Errorf("failed to cache diagram: %w", err)
+	}
+	return path, nil
+}
+
+func (r *Renderer) run(ctx context.Context, input, output string) error {
+	mmdc := r.Mmdc
+	if mmdc == "" {
+		mmdc = "mmdc"
+	}
+
+	cmd := exec.CommandContext(ctx, mmdc, "--input", input, "--output", output, "--quiet")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		if errors.Is(err, exec.ErrNotFound) {
+			return fmt.Errorf("%s not found: install it with 'npm install -g @mermaid-js/mermaid-cli' or set mermaid.mmdc in the config file", mmdc)
+		}
+		return err
+	}
+	if _, err := os.Stat(output); err != nil {
+		return fmt.Errorf("%s produced no output", mmdc)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package mermaid
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMmdc writes a script that copies its --input file to its --output
+// file, or fails with a parse error, and counts its runs in a file next to it.
+func fakeMmdc(t *testing.T, fail bool) (mmdc, runs string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake mmdc is a shell script")
+	}
+	dir := t.TempDir()
+	runs = filepath.Join(dir, "runs")
+	script := "#!/bin/sh\necho run >> \"" + runs + "\"\n"
+	if fail {
+		script += "echo 'Parse error on line 1' >&2\nexit 1\n"
+	} else {
+		script += "cp \"$2\" \"$4\"\n"
+	}
+	mmdc = filepath.Join(dir, "mmdc")
+	require.NoError(t, os.WriteFile(mmdc, []byte(script), 0755))
+	return mmdc, runs
+}
+
+func TestRenderer_Render(t *testing.T) {
+	mmdc, runs := fakeMmdc(t, false)
+	r := &Renderer{Mmdc: mmdc, CacheDir: t.TempDir()}
+
+	path, err := r.Render(context.Background(), "graph TD; A-->B\n")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(filepath.Base(path), "mermaid-"))
+	assert.Equal(t, ".svg", filepath.Ext(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "graph TD; A-->B\n", string(data))
+
+	// Unchanged source is served from the cache
+	again, err := r.Render(context.Background(), "  graph TD; A-->B")
+	require.NoError(t, err)
+	assert.Equal(t, path, again)
+	count, err := os.ReadFile(runs)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(count), "run"))
+}
+
+func TestRenderer_RenderPNG(t *testing.T) {
+	mmdc, _ := fakeMmdc(t, false)
+	r := &Renderer{Mmdc: mmdc, Format: FormatPNG, CacheDir: t.TempDir()}
+
+	path, err := r.Render(context.Background(), "graph TD; A-->B")
+	require.NoError(t, err)
+	assert.Equal(t, ".png", filepath.Ext(path))
+}
+
+func TestRenderer_RenderError(t *testing.T) {
+	mmdc, _ := fakeMmdc(t, true)
+	cacheDir := t.TempDir()
+	r := &Renderer{Mmdc: mmdc, CacheDir: cacheDir}
+
+	_, err := r.Render(context.Background(), "not a diagram")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Parse error on line 1")
+
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestRenderer_MissingMmdc(t *testing.T) {
+	r := &Renderer{Mmdc: "cfl-test-no-such-mmdc", CacheDir: t.TempDir()}
+
+	_, err := r.Render(context.Background(), "graph TD; A-->B")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mermaid-cli")
+}
+
+func TestRenderer_RenderMarkdown(t *testing.T) {
+	mmdc, _ := fakeMmdc(t, false)
+	r := &Renderer{Mmdc: mmdc, CacheDir: t.TempDir()}
+	input := "# Flow\n\n```mermaid\ngraph TD; A-->B\n```\n\n```go\nx := 1\n```\n"
+
+	got, err := r.RenderMarkdown(context.Background(), []byte(input))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(got), "![Mermaid diagram](<"+filepath.ToSlash(r.CacheDir))
+	assert.NotContains(t, string(got), "```mermaid")
+	assert.Contains(t, string(got), "```go\nx := 1\n```")
+}
+
+func TestValidateFormat(t *testing.T) {
+	assert.NoError(t, ValidateFormat(""))
+	assert.NoError(t, ValidateFormat(FormatSVG))
+	assert.NoError(t, ValidateFormat(FormatPNG))
+	assert.Error(t, ValidateFormat("gif"))
+}
+
+func TestRenderer_Nil(t *testing.T) {
+	input := []byte("```mermaid\ngraph TD; A-->B\n```\n")
+
+	var r *Renderer
+	got, err := r.RenderMarkdown(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, input, got)
+}
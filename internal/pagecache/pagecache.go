@@ -0,0 +1,284 @@
+// Package pagecache stores fetched pages on disk, keyed by site, page ID
+// and version, so they can be read without a connection.
+package pagecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+	"github.com/open-cli-collective/confluence-cli/internal/config"
+)
+
+// ErrNotCached is returned for pages that aren't in the cache.
+var ErrNotCached = errors.New("page is not cached")
+
+// Entry is a cached page version.
+type Entry struct {
+	Page     *api.Page `json:"page"`
+	SpaceKey string    `json:"spaceKey,omitempty"`
+	CachedAt time.Time `json:"cachedAt"`
+}
+
+// Info describes a cached page version without its content.
+type Info struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	SpaceKey string    `json:"spaceKey,omitempty"`
+	Version  int       `json:"version"`
+	Size     int64     `json:"size"`
+	CachedAt time.Time `json:"cachedAt"`
+}
+
+// Cache is the page cache of one site.
+type Cache struct {
+	dir string
+}
+
+// DefaultDir returns the directory pages are cached in.
+func DefaultDir() string {
+	return filepath.Join(config.DefaultCacheDir(), "pages")
+}
+
+// New returns the cache of pages from site, under dir. Each site has its
+// own directory, named after its host.
+func New(dir, site string) *Cache {
+	name := strings.TrimSuffix(site, "/")
+	if u, err := url.Parse(site); err == nil && u.Host != "" {
+		name = u.Host
+	}
+	// Sites on the same host under different paths stay apart
+	sum := sha256.Sum256([]byte(strings.TrimSuffix(site, "/")))
+	return &Cache{dir: filepath.Join(dir, sanitize(name)+"-"+hex.EncodeToString(sum[:4]))}
+}
+
+// Dir returns the directory of the site's cached pages.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Put stores a page version. The page needs a version and a body.
+func (c *Cache) Put(page *api.Page, spaceKey string) error {
+	if page.Version == nil || page.Version.Number == 0 {
+		return fmt.Errorf("page %s has no version to cache", page.ID)
+	}
+	if page.Body == nil {
+		return fmt.Errorf("page %s has no body to cache", page.ID)
+	}
+	if !isID(page.ID) {
+		return fmt.Errorf("invalid page ID: %q", page.ID)
+	}
+
+	data, err := json.Marshal(Entry{Page: page, SpaceKey: spaceKey, CachedAt: time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cached page: %w", err)
+	}
+	dir := filepath.Join(c.dir, page.ID)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	// Write then rename, so readers never see a partial file
+	path := filepath.Join(dir, strconv.Itoa(page.Version.Number)+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cached page: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write cached page: %w", err)
+	}
+	return nil
+}
+
+// Get returns a cached version of a page, or its latest cached version
+// when version is 0.
+func (c *Cache) Get(id string, version int) (*Entry, error) {
+	if !isID(id) {
+		return nil, fmt.Errorf("invalid page ID: %q", id)
+	}
+	if version == 0 {
+		versions, err := c.versions(id)
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) == 0 {
+			return nil, fmt.Errorf("page %s: %w", id, ErrNotCached)
+		}
+		version = versions[len(versions)-1]
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, id, strconv.Itoa(version)+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("page %s version %d: %w", id, version, ErrNotCached)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached page: %w", err)
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse cached page %s: %w", id, err)
+	}
+	return &entry, nil
+}
+
+// Find returns the latest cached version of the page titled title, in the
+// space spaceKey if it isn't empty.
+func (c *Cache) Find(spaceKey, title string) (*Entry, error) {
+	infos, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+	var found *Info
+	for i := range infos {
+		info := &infos[i]
+		if info.Title != title || (spaceKey != "" && !strings.EqualFold(info.SpaceKey, spaceKey)) {
+			continue
+		}
+		if found != nil && found.ID != info.ID {
+			return nil, fmt.Errorf("more than one cached page is titled %q", title)
+		}
+		if found == nil || info.Version > found.Version {
+			found = info
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("page %q: %w", title, ErrNotCached)
+	}
+	return c.Get(found.ID, found.Version)
+}
+
+// List describes every cached page version, by page ID and version.
+func (c *Cache) List() ([]Info, error) {
+	dirs, err := os.ReadDir(c.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	var infos []Info
+	for _, d := range dirs {
+		if !d.IsDir() || !isID(d.Name()) {
+			continue
+		}
+		versions, err := c.versions(d.Name())
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range versions {
+			path := filepath.Join(c.dir, d.Name(), strconv.Itoa(v)+".json")
+			stat, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			entry, err := c.Get(d.Name(), v)
+			if err != nil {
+				return nil, err
+			}
+			infos = append(infos, Info{
+				ID:       d.Name(),
+				Title:    entry.Page.Title,
+				SpaceKey: entry.SpaceKey,
+				Version:  v,
+				Size:     stat.Size(),
+				CachedAt: entry.CachedAt,
+			})
+		}
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		a, _ := strconv.Atoi(infos[i].ID)
+		b, _ := strconv.Atoi(infos[j].ID)
+		if a != b {
+			return a < b
+		}
+		return infos[i].Version < infos[j].Version
+	})
+	return infos, nil
+}
+
+// Clear removes the cached versions of a page, or of every page when id is
+// empty, and returns how many were removed.
+func (c *Cache) Clear(id string) (int, error) {
+	infos, err := c.List()
+	if err != nil {
+		return 0, err
+	}
+	if id == "" {
+		if err := os.RemoveAll(c.dir); err != nil {
+			return 0, fmt.Errorf("failed to clear cache: %w", err)
+		}
+		return len(infos), nil
+	}
+
+	if !isID(id) {
+		return 0, fmt.Errorf("invalid page ID: %q", id)
+	}
+	removed := 0
+	for _, info := range infos {
+		if info.ID == id {
+			removed++
+		}
+	}
+	if err := os.RemoveAll(filepath.Join(c.dir, id)); err != nil {
+		return 0, fmt.Errorf("failed to clear cached page %s: %w", id, err)
+	}
+	return removed, nil
+}
+
+// versions returns the cached versions of a page, in ascending order.
+func (c *Cache) versions(id string) ([]int, error) {
+	files, err := os.ReadDir(filepath.Join(c.dir, id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+	var versions []int
+	for _, f := range files {
+		name, ok := strings.CutSuffix(f.Name(), ".json")
+		if !ok {
+			continue
+		}
+		if v, err := strconv.Atoi(name); err == nil && v > 0 {
+			versions = append(versions, v)
+		}
+	}
+	sort.Ints(versions)
+	return versions, nil
+}
+
+// isID reports whether id is a page ID, and so safe in a path.
+func isID(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitize makes a host name safe as a directory name.
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,80 @@
+package pagecache
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/confluence-cli/api"
+)
+
+func testPage(id, title string, version int) *api.Page {
+	return &api.Page{
+		ID:      id,
+		Title:   title,
+		Version: &api.Version{Number: version},
+		Body:    &api.Body{Storage: &api.BodyRepresentation{Value: "<p>" + title + "</p>"}},
+	}
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	cache := New(dir, "https://example.atlassian.net/wiki")
+
+	require.NoError(t, cache.Put(testPage("42", "Runbook", 1), "DEV"))
+	require.NoError(t, cache.Put(testPage("42", "Runbook v2", 2), "DEV"))
+	require.NoError(t, cache.Put(testPage("7", "Home", 3), "DOCS"))
+
+	entry, err := cache.Get("42", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Runbook v2", entry.Page.Title)
+	assert.Equal(t, "DEV", entry.SpaceKey)
+	assert.Equal(t, "<p>Runbook v2</p>", entry.Page.Body.Storage.Value)
+
+	entry, err = cache.Get("42", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Runbook", entry.Page.Title)
+
+	_, err = cache.Get("42", 5)
+	assert.True(t, errors.Is(err, ErrNotCached))
+	_, err = cache.Get("99", 0)
+	assert.True(t, errors.Is(err, ErrNotCached))
+	_, err = cache.Get("../x", 0)
+	assert.Error(t, err)
+
+	entry, err = cache.Find("dev", "Runbook v2")
+	require.NoError(t, err)
+	assert.Equal(t, "42", entry.Page.ID)
+	_, err = cache.Find("DOCS", "Runbook v2")
+	assert.True(t, errors.Is(err, ErrNotCached))
+
+	infos, err := cache.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 3)
+	assert.Equal(t, "7", infos[0].ID)
+	assert.Equal(t, []int{1, 2}, []int{infos[1].Version, infos[2].Version})
+	assert.Positive(t, infos[0].Size)
+
+	// Other sites have their own cache
+	other, err := New(dir, "https://other.atlassian.net/wiki").List()
+	require.NoError(t, err)
+	assert.Empty(t, other)
+
+	removed, err := cache.Clear("42")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	removed, err = cache.Clear("")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	infos, err = cache.List()
+	require.NoError(t, err)
+	assert.Empty(t, infos)
+}
+
+func TestCache_PutRequiresVersionAndBody(t *testing.T) {
+	cache := New(t.TempDir(), "https://example.atlassian.net/wiki")
+	assert.Error(t, cache.Put(&api.Page{ID: "1", Body: &api.Body{}}, ""))
+	assert.Error(t, cache.Put(&api.Page{ID: "1", Version: &api.Version{Number: 1}}, ""))
+}
@@ -0,0 +1,186 @@
+// Package plantuml renders PlantUML diagrams in markdown to SVG files.
+package plantuml
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/open-cli-collective/confluence-cli/pkg/md"
+)
+
+// fenceLang is the code fence language rendered as a diagram.
+const fenceLang = "plantuml"
+
+// encoding is PlantUML's URL-safe base64 alphabet for text encoding.
+var encoding = base64.NewEncoding("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-_").WithPadding(base64.NoPadding)
+
+// Renderer renders PlantUML source with a local plantuml.jar or a PlantUML
+// server. Rendered diagrams are cached on disk by source hash, so unchanged
+// diagrams are not rendered again.
+type Renderer struct {
+	Jar        string // Path to plantuml.jar; takes precedence over Server
+	Server     string // PlantUML server URL, e.g. https://www.plantuml.com/plantuml
+	CacheDir   string // Directory for rendered diagrams
+	Java       string // Java executable (default "java")
+	HTTPClient *http.Client
+}
+
+// New creates a renderer caching diagrams in cacheDir. The renderer is
+// disabled if neither jar nor server is set.
+func New(jar, server, cacheDir string) *Renderer {
+	return &Renderer{Jar: jar, Server: server, CacheDir: cacheDir}
+}
+
+// Enabled reports whether a jar or server is configured.
+func (r *Renderer) Enabled() bool {
+	return r != nil && (r.Jar != "" || r.Server != "")
+}
+
+// RenderMarkdown replaces ```plantuml fences in markdown with image
+// references to rendered SVG files, which are then uploaded and embedded
+// like any other local image. Markdown is returned unchanged if the
+// renderer is not enabled.
+func (r *Renderer) RenderMarkdown(ctx context.Context, markdown []byte) ([]byte, error) {
+	if !r.Enabled() {
+		return markdown, nil
+	}
+	return md.ReplaceFencedBlocks(markdown, fenceLang, func(block md.FencedBlock) (string, error) {
+		path, err := r.Render(ctx, block.Code)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("![PlantUML diagram](<%s>)\n", filepath.ToSlash(path)), nil
+	})
+}
+
+// Render renders diagram source to an SVG file and returns its path.
+func (r *Renderer) Render(ctx context.Context, source string) (string, error) {
+	source = wrapSource(source)
+
+	sum := sha256.Sum256([]byte(source))
+	path := filepath.Join(r.CacheDir, "plantuml-"+hex.EncodeToString(sum[:8])+".svg")
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	var svg []byte
+	var err error
+	if r.Jar != "" {
+		svg, err = r.renderJar(ctx, source)
+	} else {
+		svg, err = r.renderServer(ctx, source)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to render PlantUML diagram: %w", err)
+	}
+
+	if err := os.MkdirAll(r.CacheDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create diagram cache: %w", err)
+	}
+	// Write to a temp file first so a failed write never leaves a truncated cache entry
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, svg, 0644); err != nil {
+		return "", fmt.Errorf("failed to cache diagram: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", fmt.Errorf("failed to cache diagram: %w", err)
+	}
+	return path, nil
+}
+
+// wrapSource adds @startuml/@enduml if the source has no start directive.
+func wrapSource(source string) string {
+	source = strings.TrimSpace(source)
+	if strings.HasPrefix(source, "@start") {
+		return source + "\n"
+	}
+	return "@startuml\n" + source + "\n@enduml\n"
+}
+
+func (r *Renderer) renderJar(ctx context.Context, source string) ([]byte, error) {
+	java := r.Java
+	if java == "" {
+		java = "java"
+	}
+
+	cmd := exec.CommandContext(ctx, java, "-Djava.awt.headless=true", "-jar", r.Jar, "-tsvg", "-pipe", "-charset", "UTF-8")
+	cmd.Stdin = strings.NewReader(source)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+func (r *Renderer) renderServer(ctx context.Context, source string) ([]byte, error) {
+	encoded, err := Encode(source)
+	if err != nil {
+		return nil, err
+	}
+
+	url := strings.TrimSuffix(r.Server, "/") + "/svg/" + encoded
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := r.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// PlantUML servers answer syntax errors with an error image and status 400
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+	return body, nil
+}
+
+// Encode compresses and encodes diagram source for a PlantUML server URL.
+func Encode(source string) (string, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write([]byte(source)); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	// PlantUML encodes partial trailing groups as if zero-padded to 3 bytes
+	data := buf.Bytes()
+	if rem := len(data) % 3; rem != 0 {
+		data = append(data, make([]byte, 3-rem)...)
+	}
+	return encoding.EncodeToString(data), nil
+}
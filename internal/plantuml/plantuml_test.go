@@ -0,0 +1,118 @@
+package plantuml
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, encoded string) string {
+	t.Helper()
+	data, err := encoding.DecodeString(encoded)
+	require.NoError(t, err)
+	out, err := io.ReadAll(flate.NewReader(bytes.NewReader(data)))
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	for _, source := range []string{"Bob -> Alice : hello", "@startuml\nA -> B\n@enduml\n", "x"} {
+		encoded, err := Encode(source)
+		require.NoError(t, err)
+		assert.NotContains(t, encoded, "+")
+		assert.NotContains(t, encoded, "/")
+		assert.Equal(t, source, decode(t, encoded))
+	}
+}
+
+func TestWrapSource(t *testing.T) {
+	assert.Equal(t, "@startuml\nA -> B\n@enduml\n", wrapSource("A -> B\n"))
+	assert.Equal(t, "@startmindmap\n* root\n@endmindmap\n", wrapSource("@startmindmap\n* root\n@endmindmap"))
+}
+
+func TestRenderer_Server(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.True(t, strings.HasPrefix(r.URL.Path, "/plantuml/svg/"))
+		source := decode(t, strings.TrimPrefix(r.URL.Path, "/plantuml/svg/"))
+		assert.Equal(t, "@startuml\nA -> B\n@enduml\n", source)
+		_, _ = w.Write([]byte("<svg>diagram</svg>"))
+	}))
+	defer server.Close()
+
+	r := &Renderer{Server: server.URL + "/plantuml/", CacheDir: t.TempDir()}
+
+	path, err := r.Render(context.Background(), "A -> B\n")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(filepath.Base(path), "plantuml-"))
+	assert.Equal(t, ".svg", filepath.Ext(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "<svg>diagram</svg>", string(data))
+
+	// Unchanged source is served from the cache
+	again, err := r.Render(context.Background(), "A -> B")
+	require.NoError(t, err)
+	assert.Equal(t, path, again)
+	assert.Equal(t, 1, requests)
+}
+
+func TestRenderer_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	r := &Renderer{Server: server.URL, CacheDir: cacheDir}
+
+	_, err := r.Render(context.Background(), "not a diagram")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestRenderer_RenderMarkdown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("<svg/>"))
+	}))
+	defer server.Close()
+
+	r := &Renderer{Server: server.URL, CacheDir: t.TempDir()}
+	input := "# Flow\n\n```plantuml\nA -> B\n```\n\n```go\nx := 1\n```\n"
+
+	got, err := r.RenderMarkdown(context.Background(), []byte(input))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(got), "![PlantUML diagram](<"+filepath.ToSlash(r.CacheDir))
+	assert.NotContains(t, string(got), "```plantuml")
+	assert.Contains(t, string(got), "```go\nx := 1\n```")
+}
+
+func TestRenderer_Disabled(t *testing.T) {
+	input := []byte("```plantuml\nA -> B\n```\n")
+
+	var nilRenderer *Renderer
+	got, err := nilRenderer.RenderMarkdown(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, input, got)
+
+	got, err = New("", "", t.TempDir()).RenderMarkdown(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, input, got)
+}
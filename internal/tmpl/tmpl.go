@@ -0,0 +1,185 @@
+// Package tmpl renders local page templates and manages the templates
+// stored in the config directory.
+//
+// Templates are Go text/template files: {{.version}} is replaced by the
+// value of --var version=... Template files end in .tmpl after the
+// extension of the content they produce, as in release-notes.md.tmpl.
+package tmpl
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Ext is the extension of template files.
+const Ext = ".tmpl"
+
+// ErrNotFound is returned when no stored template has a name.
+var ErrNotFound = errors.New("template not found")
+
+// Template is a template stored in the template directory.
+type Template struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// ParseVars parses key=value pairs into template variables.
+func ParseVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid variable %q: use key=value", pair)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// Render executes a template with variables. Referring to a variable that
+// isn't given is an error, so typos don't publish empty values.
+func Render(name, text string, vars map[string]string) (string, error) {
+	t, err := template.New(filepath.Base(name)).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+
+	var sb strings.Builder
+	if err := t.Execute(&sb, vars); err != nil {
+		return "", fmt.Errorf("failed to render template: %w (set variables with --var key=value)", err)
+	}
+	return sb.String(), nil
+}
+
+// ContentName returns the name of the file a template produces: its file
+// name without the .tmpl extension.
+func ContentName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), Ext)
+}
+
+// Name returns the name a template file is stored under: its content name
+// without extension.
+func Name(path string) string {
+	name := ContentName(path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// List returns the templates stored in dir, sorted by name.
+func List(dir string) ([]Template, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template directory: %w", err)
+	}
+
+	var templates []Template
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), Ext) {
+			continue
+		}
+		templates = append(templates, Template{Name: Name(e.Name()), Path: filepath.Join(dir, e.Name())})
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// Find returns the path of a template given as a file path or as the name
+// of a template stored in dir.
+func Find(dir, ref string) (string, error) {
+	if info, err := os.Stat(ref); err == nil && !info.IsDir() {
+		return ref, nil
+	}
+
+	path, err := stored(dir, ref)
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		return "", fmt.Errorf("%w: %s is neither a file nor a template in %s", ErrNotFound, ref, dir)
+	}
+	return path, nil
+}
+
+// Add stores a copy of the template file src in dir under name, or under
+// the file's own name when name is empty. An existing template of that name
+// is only replaced with force.
+func Add(dir, src, name string, force bool) (Template, error) {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return Template{}, fmt.Errorf("failed to read template: %w", err)
+	}
+	if _, err := template.New(filepath.Base(src)).Parse(string(data)); err != nil {
+		return Template{}, fmt.Errorf("invalid template: %w", err)
+	}
+
+	if name == "" {
+		name = Name(src)
+	}
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return Template{}, fmt.Errorf("invalid template name %q", name)
+	}
+
+	// Keep the content extension, which decides how the content is read
+	ext := filepath.Ext(ContentName(src))
+	if ext == "" {
+		ext = ".md"
+	}
+
+	existing, err := stored(dir, name)
+	if err != nil {
+		return Template{}, err
+	}
+	if existing != "" {
+		if !force {
+			return Template{}, fmt.Errorf("template %s already exists (use --force to replace it)", name)
+		}
+		if err := os.Remove(existing); err != nil {
+			return Template{}, fmt.Errorf("failed to replace template: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return Template{}, fmt.Errorf("failed to create template directory: %w", err)
+	}
+	path := filepath.Join(dir, name+ext+Ext)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return Template{}, fmt.Errorf("failed to store template: %w", err)
+	}
+	return Template{Name: name, Path: path}, nil
+}
+
+// Remove deletes the stored template with a name.
+func Remove(dir, name string) error {
+	path, err := stored(dir, name)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return os.Remove(path)
+}
+
+// stored returns the path of the template stored in dir under name, or ""
+// if there is none.
+func stored(dir, name string) (string, error) {
+	templates, err := List(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, t := range templates {
+		if t.Name == name {
+			return t.Path, nil
+		}
+	}
+	return "", nil
+}
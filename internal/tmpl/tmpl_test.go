@@ -0,0 +1,103 @@
+package tmpl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVars(t *testing.T) {
+	vars, err := ParseVars([]string{"version=2.3", "date=Fri Oct 16", "empty=", "eq=a=b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"version": "2.3", "date": "Fri Oct 16", "empty": "", "eq": "a=b"}, vars)
+
+	for _, bad := range []string{"version", "=2.3", " =x"} {
+		_, err := ParseVars([]string{bad})
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestRender(t *testing.T) {
+	out, err := Render("notes.md.tmpl", "# Release {{.version}}{{if .date}} ({{.date}}){{end}}", map[string]string{"version": "2.3", "date": "today"})
+	require.NoError(t, err)
+	assert.Equal(t, "# Release 2.3 (today)", out)
+
+	_, err = Render("notes.md.tmpl", "{{.version}} {{.missing}}", map[string]string{"version": "2.3"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing")
+
+	_, err = Render("notes.md.tmpl", "{{.version", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid template")
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, "release-notes.md", ContentName("/x/release-notes.md.tmpl"))
+	assert.Equal(t, "release-notes", Name("/x/release-notes.md.tmpl"))
+	assert.Equal(t, "notes", Name("notes.tmpl"))
+}
+
+func TestAddListFindRemove(t *testing.T) {
+	src := t.TempDir()
+	dir := filepath.Join(t.TempDir(), "templates")
+
+	list, err := List(dir)
+	require.NoError(t, err)
+	assert.Empty(t, list, "a missing directory has no templates")
+
+	notes := filepath.Join(src, "release-notes.md.tmpl")
+	require.NoError(t, os.WriteFile(notes, []byte("# {{.version}}"), 0644))
+	plain := filepath.Join(src, "plain.tmpl")
+	require.NoError(t, os.WriteFile(plain, []byte("text"), 0644))
+
+	added, err := Add(dir, notes, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, Template{Name: "release-notes", Path: filepath.Join(dir, "release-notes.md.tmpl")}, added)
+
+	added, err = Add(dir, plain, "adr", false)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "adr.md.tmpl"), added.Path, "content without extension is stored as markdown")
+
+	_, err = Add(dir, notes, "", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+	_, err = Add(dir, plain, "release-notes", true)
+	require.NoError(t, err)
+
+	list, err = List(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"adr", "release-notes"}, []string{list[0].Name, list[1].Name})
+
+	path, err := Find(dir, "release-notes")
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "text", string(data))
+
+	path, err = Find(dir, notes)
+	require.NoError(t, err)
+	assert.Equal(t, notes, path, "an existing file is used as is")
+
+	require.NoError(t, Remove(dir, "adr"))
+	_, err = Find(dir, "adr")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, Remove(dir, "adr"), ErrNotFound)
+}
+
+func TestAdd_Invalid(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "bad.md.tmpl")
+	require.NoError(t, os.WriteFile(src, []byte("{{.version"), 0644))
+
+	_, err := Add(t.TempDir(), src, "", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid template")
+
+	good := filepath.Join(t.TempDir(), "good.md.tmpl")
+	require.NoError(t, os.WriteFile(good, []byte("ok"), 0644))
+	_, err = Add(t.TempDir(), good, "a/b", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid template name")
+}
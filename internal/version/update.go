@@ -0,0 +1,166 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LatestReleaseURL is the GitHub API endpoint for the latest cfl release.
+const LatestReleaseURL = "https://api.github.com/repos/open-cli-collective/confluence-cli/releases/latest"
+
+// DefaultCheckInterval is how often the latest release is looked up.
+const DefaultCheckInterval = 24 * time.Hour
+
+// UpdateChecker looks up whether a newer release of cfl exists. Lookups are
+// cached, so the release is fetched at most once per interval, and each
+// newer release is reported once.
+type UpdateChecker struct {
+	CachePath string
+	URL       string        // Defaults to LatestReleaseURL
+	Interval  time.Duration // Defaults to DefaultCheckInterval
+	Client    *http.Client  // Defaults to a client with a short timeout
+	Now       func() time.Time
+}
+
+// updateCache is the state kept between checks.
+type updateCache struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Latest    string    `json:"latest,omitempty"`
+	Notified  string    `json:"notified,omitempty"` // The last release reported
+}
+
+// Check returns the latest release if it is newer than current and hasn't
+// been reported before, or "" otherwise. current must be a release version;
+// development builds are never reported as outdated.
+func (u *UpdateChecker) Check(ctx context.Context, current string) (string, error) {
+	if _, ok := parseRelease(current); !ok {
+		return "", nil
+	}
+	now := time.Now
+	if u.Now != nil {
+		now = u.Now
+	}
+	interval := u.Interval
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+
+	cache := u.load()
+	var fetchErr error
+	if now().Sub(cache.CheckedAt) >= interval {
+		// A failed lookup waits for the next interval too, so an offline
+		// machine isn't slowed down by every command
+		cache.CheckedAt = now()
+		var latest string
+		if latest, fetchErr = u.fetchLatest(ctx); fetchErr == nil {
+			cache.Latest = latest
+		}
+	}
+
+	result := ""
+	if Newer(cache.Latest, current) && cache.Notified != cache.Latest {
+		cache.Notified = cache.Latest
+		result = cache.Latest
+	}
+	if err := u.save(cache); err != nil && fetchErr == nil {
+		fetchErr = err
+	}
+	return result, fetchErr
+}
+
+// fetchLatest returns the tag of the latest release.
+func (u *UpdateChecker) fetchLatest(ctx context.Context) (string, error) {
+	url := u.URL
+	if url == "" {
+		url = LatestReleaseURL
+	}
+	client := u.Client
+	if client == nil {
+		client = &http.Client{Timeout: 2 * time.Second}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("release lookup failed with status %d", resp.StatusCode)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("failed to parse release response: %w", err)
+	}
+	return release.TagName, nil
+}
+
+func (u *UpdateChecker) load() updateCache {
+	var cache updateCache
+	data, err := os.ReadFile(u.CachePath)
+	if err == nil {
+		_ = json.Unmarshal(data, &cache)
+	}
+	return cache
+}
+
+func (u *UpdateChecker) save(cache updateCache) error {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(u.CachePath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(u.CachePath, data, 0o644)
+}
+
+// Newer reports whether release version a is newer than b. Versions that
+// aren't releases, such as "dev" or pre-releases, are never newer.
+func Newer(a, b string) bool {
+	va, ok := parseRelease(a)
+	if !ok {
+		return false
+	}
+	vb, ok := parseRelease(b)
+	if !ok {
+		return false
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			return va[i] > vb[i]
+		}
+	}
+	return false
+}
+
+// parseRelease parses a release version such as "v1.2.3" or "1.2.3".
+func parseRelease(v string) ([3]int, bool) {
+	var parsed [3]int
+	parts := strings.Split(strings.TrimPrefix(v, "v"), ".")
+	if len(parts) != 3 {
+		return parsed, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return parsed, false
+		}
+		parsed[i] = n
+	}
+	return parsed, true
+}
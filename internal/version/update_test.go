@@ -0,0 +1,113 @@
+package version
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"v1.2.4", "1.2.3", true},
+		{"v1.10.0", "v1.9.9", true},
+		{"v2.0.0", "v1.99.0", true},
+		{"v1.2.3", "v1.2.3", false},
+		{"v1.2.2", "v1.2.3", false},
+		{"v1.3.0-rc1", "v1.2.3", false},
+		{"v1.3.0", "dev", false},
+		{"", "v1.2.3", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, Newer(tt.a, tt.b))
+		})
+	}
+}
+
+func TestUpdateChecker_Check(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"tag_name": "v1.3.0"}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	checker := &UpdateChecker{
+		CachePath: filepath.Join(t.TempDir(), "update-check.json"),
+		URL:       server.URL,
+		Now:       func() time.Time { return now },
+	}
+	ctx := context.Background()
+
+	latest, err := checker.Check(ctx, "1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.3.0", latest)
+	assert.Equal(t, 1, requests)
+
+	// Reported once, and looked up again only after the interval
+	latest, err = checker.Check(ctx, "1.2.0")
+	require.NoError(t, err)
+	assert.Empty(t, latest)
+	assert.Equal(t, 1, requests)
+
+	now = now.Add(DefaultCheckInterval)
+	latest, err = checker.Check(ctx, "1.2.0")
+	require.NoError(t, err)
+	assert.Empty(t, latest, "the same release isn't reported twice")
+	assert.Equal(t, 2, requests)
+}
+
+func TestUpdateChecker_Check_UpToDate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name": "v1.3.0"}`))
+	}))
+	defer server.Close()
+
+	checker := &UpdateChecker{CachePath: filepath.Join(t.TempDir(), "update-check.json"), URL: server.URL}
+	latest, err := checker.Check(context.Background(), "v1.3.0")
+	require.NoError(t, err)
+	assert.Empty(t, latest)
+}
+
+func TestUpdateChecker_Check_DevBuild(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("development builds don't look up releases")
+	}))
+	defer server.Close()
+
+	checker := &UpdateChecker{CachePath: filepath.Join(t.TempDir(), "update-check.json"), URL: server.URL}
+	for _, current := range []string{"dev", "v1.2.3-4-gabcdef", "v1.2.3-dirty"} {
+		latest, err := checker.Check(context.Background(), current)
+		require.NoError(t, err)
+		assert.Empty(t, latest)
+	}
+}
+
+func TestUpdateChecker_Check_LookupFails(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	checker := &UpdateChecker{CachePath: filepath.Join(t.TempDir(), "update-check.json"), URL: server.URL}
+	_, err := checker.Check(context.Background(), "v1.2.0")
+	require.Error(t, err)
+
+	// A failed lookup isn't retried until the next interval
+	_, err = checker.Check(context.Background(), "v1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+}
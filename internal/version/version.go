@@ -1,9 +1,58 @@
 // Package version provides build-time version information.
 package version
 
+import (
+	"runtime"
+	"runtime/debug"
+)
+
 // These variables are set at build time via ldflags.
 var (
 	Version = "dev"
 	Commit  = "unknown"
 	Date    = "unknown"
 )
+
+// APILevel is the Confluence Cloud REST API cfl is built against. The v1 API
+// is used where v2 has no equivalent.
+const APILevel = "v2"
+
+// Info is the build metadata of cfl.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+	APILevel  string `json:"apiLevel"`
+}
+
+// Get returns the build metadata. Values not set via ldflags, as in builds
+// with 'go install', are taken from the build info Go embeds.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		APILevel:  APILevel,
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "dev" && build.Main.Version != "" && build.Main.Version != "(devel)" {
+		info.Version = build.Main.Version
+	}
+	for _, s := range build.Settings {
+		switch {
+		case s.Key == "vcs.revision" && info.Commit == "unknown":
+			info.Commit = s.Value
+		case s.Key == "vcs.time" && info.Date == "unknown":
+			info.Date = s.Value
+		}
+	}
+	return info
+}
@@ -0,0 +1,404 @@
+package view
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
+)
+
+// Styles for rendered markdown. They print plain text when color is off.
+var (
+	mdHeading1Style = color.New(color.Bold, color.Underline, color.FgHiBlue)
+	mdHeading2Style = color.New(color.Bold, color.FgHiBlue)
+	mdHeadingStyle  = color.New(color.Bold)
+	mdBoldStyle     = color.New(color.Bold)
+	mdItalicStyle   = color.New(color.Italic)
+	mdStrikeStyle   = color.New(color.CrossedOut)
+	mdCodeStyle     = color.New(color.FgHiYellow)
+	mdLinkStyle     = color.New(color.Underline, color.FgBlue)
+	mdFaintStyle    = color.New(color.Faint)
+	mdKeywordStyle  = color.New(color.FgMagenta)
+	mdStringStyle   = color.New(color.FgGreen)
+	mdNumberStyle   = color.New(color.FgCyan)
+	mdCommentStyle  = color.New(color.FgHiBlack)
+)
+
+var (
+	mdHeadingRe   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdRuleRe      = regexp.MustCompile(`^\s*(-\s*-\s*-[-\s]*|\*\s*\*\s*\*[*\s]*|_\s*_\s*_[_\s]*)$`)
+	mdListRe      = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdTaskRe      = regexp.MustCompile(`^\[([ xX])\]\s+`)
+	mdQuoteRe     = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	mdTableSepRe  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdFenceRe     = regexp.MustCompile("^\\s*(```+|~~~+)\\s*([^\\s`]*)")
+	mdInlineRe    = regexp.MustCompile("`[^`]+`|\\*\\*[^*]+\\*\\*|__[^_]+__|~~[^~]+~~|\\*[^*\\s][^*]*\\*|!?\\[[^\\]]*\\]\\([^)\\s]*\\)|\\\\.")
+	mdLinkPartsRe = regexp.MustCompile(`^(!?)\[([^\]]*)\]\(([^)\s]*)\)$`)
+)
+
+// TerminalWidth returns the width of the terminal on stdout, from COLUMNS
+// or the terminal itself, or 80 when neither is known.
+func TerminalWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	if w, _, err := term.GetSize(os.Stdout.Fd()); err == nil && w > 0 {
+		return w
+	}
+	return 80
+}
+
+// RenderMarkdown renders markdown for reading in a terminal width columns
+// wide: headings are styled, code blocks syntax highlighted, emphasis, code
+// and links styled inline, lists, quotes and tables laid out, and text
+// wrapped. Styles are left out when color is off (--no-color, or output
+// that isn't a terminal).
+func RenderMarkdown(markdown string, width int) string {
+	width = max(20, width)
+	lines := strings.Split(strings.TrimRight(markdown, "\n"), "\n")
+
+	var out []string
+	fence, lang := "", ""
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if fence != "" {
+			if strings.HasPrefix(strings.TrimSpace(line), fence) {
+				fence = ""
+				continue
+			}
+			out = append(out, "  "+highlightCode(line, lang))
+			continue
+		}
+		if m := mdFenceRe.FindStringSubmatch(line); m != nil {
+			fence, lang = m[1], strings.ToLower(m[2])
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			out = append(out, "")
+		case mdRuleRe.MatchString(line):
+			out = append(out, mdFaintStyle.Sprint(strings.Repeat("─", width)))
+		case mdHeadingRe.MatchString(line):
+			m := mdHeadingRe.FindStringSubmatch(line)
+			style := mdHeadingStyle
+			switch len(m[1]) {
+			case 1:
+				style = mdHeading1Style
+			case 2:
+				style = mdHeading2Style
+			}
+			out = append(out, style.Sprint(plainInline(m[2])))
+		case strings.HasPrefix(trimmed, "|"):
+			// Tables are the run of lines starting with |
+			end := i
+			for end < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[end]), "|") {
+				end++
+			}
+			out = append(out, renderMarkdownTable(lines[i:end])...)
+			i = end - 1
+		case strings.HasPrefix(trimmed, ":::"):
+			// Fences for panels, expands and columns
+			out = append(out, mdFaintStyle.Sprint(trimmed))
+		case mdQuoteRe.MatchString(line):
+			text := mdQuoteRe.FindStringSubmatch(line)[1]
+			for _, l := range wrapInline(text, width-2) {
+				out = append(out, mdFaintStyle.Sprint("│ ")+l)
+			}
+		case mdListRe.MatchString(line):
+			m := mdListRe.FindStringSubmatch(line)
+			indent, marker, text := m[1], m[2], m[3]
+			switch {
+			case mdTaskRe.MatchString(text):
+				if strings.TrimSpace(mdTaskRe.FindStringSubmatch(text)[1]) == "" {
+					marker = "☐"
+				} else {
+					marker = "☑"
+				}
+				text = mdTaskRe.ReplaceAllString(text, "")
+			case marker[0] < '0' || marker[0] > '9':
+				marker = "•"
+			}
+			prefix := indent + marker + " "
+			hang := strings.Repeat(" ", runewidth.StringWidth(prefix))
+			for j, l := range wrapInline(text, width-len(hang)) {
+				if j == 0 {
+					out = append(out, prefix+l)
+				} else {
+					out = append(out, hang+l)
+				}
+			}
+		default:
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			for _, l := range wrapInline(trimmed, width-len(indent)) {
+				out = append(out, indent+l)
+			}
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// mdSpan is a run of inline text in one style; a nil style is plain.
+type mdSpan struct {
+	text  string
+	style *color.Color
+}
+
+// parseInline splits a line of markdown into styled spans.
+func parseInline(text string) []mdSpan {
+	var spans []mdSpan
+	last := 0
+	for _, loc := range mdInlineRe.FindAllStringIndex(text, -1) {
+		if loc[0] > last {
+			spans = append(spans, mdSpan{text: text[last:loc[0]]})
+		}
+		last = loc[1]
+
+		token := text[loc[0]:loc[1]]
+		switch {
+		case strings.HasPrefix(token, `\`):
+			spans = append(spans, mdSpan{text: token[1:]})
+		case strings.HasPrefix(token, "`"):
+			spans = append(spans, mdSpan{text: strings.Trim(token, "`"), style: mdCodeStyle})
+		case strings.HasPrefix(token, "**"), strings.HasPrefix(token, "__"):
+			spans = append(spans, mdSpan{text: token[2 : len(token)-2], style: mdBoldStyle})
+		case strings.HasPrefix(token, "~~"):
+			spans = append(spans, mdSpan{text: token[2 : len(token)-2], style: mdStrikeStyle})
+		case strings.HasPrefix(token, "*"):
+			spans = append(spans, mdSpan{text: token[1 : len(token)-1], style: mdItalicStyle})
+		default:
+			m := mdLinkPartsRe.FindStringSubmatch(token)
+			label, url := m[2], m[3]
+			if m[1] == "!" {
+				spans = append(spans, mdSpan{text: "[image: " + label + "]", style: mdFaintStyle})
+				continue
+			}
+			if label == "" {
+				label = url
+			}
+			spans = append(spans, mdSpan{text: label, style: mdLinkStyle})
+			if url != label && !strings.HasPrefix(url, "#") {
+				spans = append(spans, mdSpan{text: " (" + url + ")", style: mdFaintStyle})
+			}
+		}
+	}
+	if last < len(text) {
+		spans = append(spans, mdSpan{text: text[last:]})
+	}
+	return spans
+}
+
+// plainInline returns a line of markdown without its inline markup.
+func plainInline(text string) string {
+	var sb strings.Builder
+	for _, span := range parseInline(text) {
+		sb.WriteString(span.text)
+	}
+	return sb.String()
+}
+
+// wrapInline renders a line of markdown, wrapped to lines of at most width
+// columns; longer words get a line of their own.
+func wrapInline(text string, width int) []string {
+	width = max(10, width)
+	var lines []string
+	var line strings.Builder
+	lineWidth := 0
+	pendingSpace := false
+
+	for _, span := range parseInline(text) {
+		words := strings.Split(span.text, " ")
+		for i, word := range words {
+			if i > 0 {
+				pendingSpace = true
+			}
+			if word == "" {
+				continue
+			}
+			w := runewidth.StringWidth(word)
+			if pendingSpace && lineWidth > 0 {
+				if lineWidth+1+w > width {
+					lines = append(lines, line.String())
+					line.Reset()
+					lineWidth = 0
+				} else {
+					line.WriteString(" ")
+					lineWidth++
+				}
+			}
+			pendingSpace = false
+			if span.style != nil {
+				word = span.style.Sprint(word)
+			}
+			line.WriteString(word)
+			lineWidth += w
+		}
+	}
+	return append(lines, line.String())
+}
+
+// renderMarkdownTable lays out the lines of a markdown table in aligned
+// columns, with a bold header.
+func renderMarkdownTable(lines []string) []string {
+	var rows [][]string
+	header := false
+	for _, line := range lines {
+		if mdTableSepRe.MatchString(line) {
+			header = len(rows) == 1
+			continue
+		}
+		line = strings.TrimSpace(line)
+		line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+		var cells []string
+		for _, cell := range strings.Split(line, "|") {
+			cells = append(cells, plainInline(strings.TrimSpace(cell)))
+		}
+		rows = append(rows, cells)
+	}
+
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], runewidth.StringWidth(cell))
+		}
+	}
+
+	var out []string
+	sep := mdFaintStyle.Sprint(" │ ")
+	for r, row := range rows {
+		cells := make([]string, len(widths))
+		for i := range widths {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			cell = runewidth.FillRight(cell, widths[i])
+			if header && r == 0 {
+				cell = mdBoldStyle.Sprint(cell)
+			}
+			cells[i] = cell
+		}
+		out = append(out, strings.TrimRight(strings.Join(cells, sep), " "))
+		if header && r == 0 {
+			rule := make([]string, len(widths))
+			for i, w := range widths {
+				rule[i] = strings.Repeat("─", w)
+			}
+			out = append(out, mdFaintStyle.Sprint(strings.Join(rule, "─┼─")))
+		}
+	}
+	return out
+}
+
+const jsKeywords = "async await break case catch class const continue default delete do else export " +
+	"extends false finally for function if import in instanceof let new null return super switch " +
+	"this throw true try typeof undefined var void while yield"
+
+// Comment markers and keywords for syntax highlighting, by language.
+var (
+	codeComments = map[string]string{
+		"go": "//", "js": "//", "ts": "//", "java": "//", "c": "//", "cpp": "//", "rust": "//",
+		"kotlin": "//", "swift": "//", "csharp": "//", "scala": "//", "php": "//",
+		"sh": "#", "python": "#", "ruby": "#", "yaml": "#", "toml": "#", "perl": "#",
+		"r": "#", "dockerfile": "#", "makefile": "#", "powershell": "#",
+		"sql": "--", "lua": "--", "haskell": "--",
+	}
+	codeAliases = map[string]string{
+		"golang": "go", "javascript": "js", "jsx": "js", "typescript": "ts", "tsx": "ts",
+		"bash": "sh", "shell": "sh", "zsh": "sh", "console": "sh", "py": "python",
+		"rb": "ruby", "yml": "yaml", "c++": "cpp", "cs": "csharp", "c#": "csharp",
+		"rs": "rust", "kt": "kotlin", "ps1": "powershell",
+	}
+	codeKeywords = map[string]map[string]bool{
+		"go": keywordSet("break case chan const continue default defer else fallthrough for func go goto if " +
+			"import interface map package range return select struct switch type var nil true false"),
+		"python": keywordSet("and as assert async await break class continue def del elif else except False " +
+			"finally for from global if import in is lambda None nonlocal not or pass raise return True try " +
+			"while with yield"),
+		"js": keywordSet(jsKeywords),
+		"ts": keywordSet(jsKeywords + " interface type enum implements private public readonly"),
+		"sh": keywordSet("if then else elif fi for while until do done case esac function return in " +
+			"export local exit"),
+		"sql": keywordSet("select from where and or not insert into values update set delete create table " +
+			"drop alter join left right inner outer on group by order having limit as null is in like " +
+			"distinct union case when then else end"),
+		"java": keywordSet("abstract boolean break case catch class continue default do double else enum " +
+			"extends false final finally float for if implements import instanceof int interface long new " +
+			"null package private protected public return static super switch this throw throws true try " +
+			"void while"),
+		"rust": keywordSet("as async await break const continue crate else enum extern false fn for if impl " +
+			"in let loop match mod move mut pub ref return self Self static struct super trait true type " +
+			"unsafe use where while"),
+		"json": keywordSet("true false null"),
+		"yaml": keywordSet("true false null yes no"),
+	}
+)
+
+func keywordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// highlightCode colours the keywords, strings, numbers and comments of a
+// line of code in lang.
+func highlightCode(line, lang string) string {
+	if alias, ok := codeAliases[lang]; ok {
+		lang = alias
+	}
+	comment := codeComments[lang]
+	keywords := codeKeywords[lang]
+	isWord := func(c byte) bool {
+		return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case comment != "" && strings.HasPrefix(line[i:], comment):
+			sb.WriteString(mdCommentStyle.Sprint(line[i:]))
+			return sb.String()
+		case c == '"' || c == '\'' || c == '`':
+			j := i + 1
+			for j < len(line) && line[j] != c {
+				if line[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			j = min(j+1, len(line))
+			sb.WriteString(mdStringStyle.Sprint(line[i:j]))
+			i = j
+		case isWord(c):
+			j := i
+			for j < len(line) && (isWord(line[j]) || line[j] == '.' && c >= '0' && c <= '9') {
+				j++
+			}
+			word := line[i:j]
+			switch {
+			case c >= '0' && c <= '9':
+				sb.WriteString(mdNumberStyle.Sprint(word))
+			case keywords[word] || lang == "sql" && keywords[strings.ToLower(word)]:
+				sb.WriteString(mdKeywordStyle.Sprint(word))
+			default:
+				sb.WriteString(word)
+			}
+			i = j
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,58 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	tests := []struct {
+		name     string
+		markdown string
+		width    int
+		want     string
+	}{
+		{"heading", "# Title\n\n## Sub *section* ##", 80, "Title\n\nSub section"},
+		{"inline", "Use `cfl`, **bold**, ~~old~~ and \\*stars\\*.", 80, "Use cfl, bold, old and *stars*."},
+		{"links", "See [docs](https://example.com), [top](#top) and ![logo](logo.png).", 80,
+			"See docs (https://example.com), top and [image: logo]."},
+		{"wrap", "one two three four five six", 20, "one two three four\nfive six"},
+		{"list", "- first item wraps here\n  1. nested\n- [ ] todo\n- [x] done", 20,
+			"• first item wraps\n  here\n  1. nested\n☐ todo\n☑ done"},
+		{"quote", "> quoted text", 80, "│ quoted text"},
+		{"rule", "---", 20, strings.Repeat("─", 20)},
+		{"code", "```go\nfunc main() {}\n```", 80, "  func main() {}"},
+		{"table", "| Name | Size |\n|---|---|\n| a | 10 |\n| long name | 2 |", 80,
+			"Name      │ Size\n──────────┼─────\na         │ 10\nlong name │ 2"},
+		{"panel fence", "::: info\nNote\n:::", 80, "::: info\nNote\n:::"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RenderMarkdown(tt.markdown, tt.width))
+		})
+	}
+}
+
+func TestHighlightCode(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	out := highlightCode(`return "x" + 42 // done`, "golang")
+	assert.Contains(t, out, mdKeywordStyle.Sprint("return"))
+	assert.Contains(t, out, mdStringStyle.Sprint(`"x"`))
+	assert.Contains(t, out, mdNumberStyle.Sprint("42"))
+	assert.Contains(t, out, mdCommentStyle.Sprint("// done"))
+
+	out = highlightCode("SELECT id FROM pages", "sql")
+	assert.Contains(t, out, mdKeywordStyle.Sprint("SELECT"))
+	assert.Contains(t, out, " id ")
+}
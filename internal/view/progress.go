@@ -0,0 +1,63 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// progressWidth is the number of cells in a progress bar.
+const progressWidth = 30
+
+// Progress draws a single-line progress bar on stderr. It draws nothing
+// when stderr is not a terminal, so piped and scripted output stays clean.
+type Progress struct {
+	writer  io.Writer
+	enabled bool
+	total   int
+	done    int
+}
+
+// NewProgress creates a progress bar for total steps.
+func NewProgress(total int) *Progress {
+	return newProgress(os.Stderr, total, isTerminal(os.Stderr))
+}
+
+func newProgress(w io.Writer, total int, enabled bool) *Progress {
+	return &Progress{writer: w, enabled: enabled, total: total}
+}
+
+// Increment marks one more step done and redraws the bar, labelled with msg.
+func (p *Progress) Increment(msg string) {
+	p.Set(p.done+1, msg)
+}
+
+// Set marks done steps done and redraws the bar, labelled with msg.
+func (p *Progress) Set(done int, msg string) {
+	p.done = done
+	if !p.enabled || p.total <= 0 {
+		return
+	}
+	filled := p.done * progressWidth / p.total
+	if filled > progressWidth {
+		filled = progressWidth
+	}
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", progressWidth-filled)
+	_, _ = fmt.Fprintf(p.writer, "\r\033[K[%s] %d/%d %s", bar, p.done, p.total, msg)
+}
+
+// Done clears the bar so later output starts on a clean line.
+func (p *Progress) Done() {
+	if p.enabled {
+		_, _ = fmt.Fprint(p.writer, "\r\033[K")
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return (stat.Mode() & os.ModeCharDevice) != 0
+}
@@ -0,0 +1,44 @@
+package view
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgress(&buf, 2, true)
+
+	p.Increment("first")
+	assert.Contains(t, buf.String(), "[###############---------------] 1/2 first")
+
+	p.Increment("second")
+	assert.Contains(t, buf.String(), "[##############################] 2/2 second")
+
+	buf.Reset()
+	p.Done()
+	assert.Equal(t, "\r\033[K", buf.String())
+}
+
+func TestProgress_Disabled(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgress(&buf, 2, false)
+
+	p.Increment("first")
+	p.Done()
+
+	assert.Empty(t, buf.String())
+}
+
+func TestProgress_Set(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgress(&buf, 100, true)
+
+	p.Set(40, "copying")
+	assert.Contains(t, buf.String(), "[############------------------] 40/100 copying")
+
+	p.Increment("copying")
+	assert.Contains(t, buf.String(), "41/100 copying")
+}
@@ -0,0 +1,40 @@
+package view
+
+import (
+	"github.com/itchyny/gojq"
+)
+
+// Query is a compiled jq query over JSON data.
+type Query struct {
+	code *gojq.Code
+}
+
+// ParseQuery compiles a query.
+func ParseQuery(expr string) (*Query, error) {
+	parsed, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	code, err := gojq.Compile(parsed)
+	if err != nil {
+		return nil, err
+	}
+	return &Query{code: code}, nil
+}
+
+// Run runs the query on JSON data decoded into interface{} values, and
+// returns its results.
+func (q *Query) Run(data interface{}) ([]interface{}, error) {
+	var results []interface{}
+	iter := q.code.Run(data)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			return results, nil
+		}
+		if err, ok := v.(error); ok {
+			return nil, err
+		}
+		results = append(results, v)
+	}
+}
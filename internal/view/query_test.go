@@ -0,0 +1,83 @@
+package view
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	input := `{
+		"results": [
+			{"id": "1", "title": "Home", "version": 3, "labels": ["a", "b"]},
+			{"id": "2", "title": "Guide", "version": 1, "labels": []},
+			{"id": "3", "title": "FAQ", "version": 7, "labels": ["b"]}
+		],
+		"_meta": {"count": 3, "hasMore": false}
+	}`
+
+	tests := []struct {
+		query string
+		want  string // Results as a JSON array
+	}{
+		{`.`, `[` + input + `]`},
+		{`._meta.count`, `[3]`},
+		{`.results[0].title`, `["Home"]`},
+		{`.results[-1].id`, `["3"]`},
+		{`.results[].id`, `["1", "2", "3"]`},
+		{`.results | length`, `[3]`},
+		{`.results[] | select(.version > 2) | .title`, `["Home", "FAQ"]`},
+		{`.results[] | select(.title == "Guide" or .version == 7) | .id`, `["2", "3"]`},
+		{`.results[] | select(.labels | length > 0 and .[0] == "b") | .id`, `["3"]`},
+		{`[.results[] | .id]`, `[["1", "2", "3"]]`},
+		{`.results | map(.version)`, `[[3, 1, 7]]`},
+		{`.results | map(.version) | sort | first, last`, `[1, 7]`},
+		{`.results[] | {id, name: .title}`, `[{"id": "1", "name": "Home"}, {"id": "2", "name": "Guide"}, {"id": "3", "name": "FAQ"}]`},
+		{`.results[0].labels | join("+")`, `["a+b"]`},
+		{`._meta | keys`, `[["count", "hasMore"]]`},
+		{`.results[0] | has("title"), has("nope")`, `[true, false]`},
+		{`.results[] | select(.title | test("^G")) | .id`, `["2"]`},
+		{`.results[1].title | ascii_upcase`, `["GUIDE"]`},
+		{`.results[0].version | tostring`, `["3"]`},
+		{`._meta.hasMore | not`, `[true]`},
+		{`."_meta"["count"]`, `[3]`},
+		{`.missing.deeper`, `[null]`},
+		{`.results[0].title.x?`, `[]`},
+		{`.results | map(select(.labels | index("b"))) | map(.id) | @csv`, `["\"1\",\"3\""]`},
+		{`.results[] | empty`, `[]`},
+	}
+
+	var data interface{}
+	require.NoError(t, json.Unmarshal([]byte(input), &data))
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			require.NoError(t, err)
+			results, err := q.Run(data)
+			require.NoError(t, err)
+			if results == nil {
+				results = []interface{}{}
+			}
+			got, err := json.Marshal(results)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestQuery_Errors(t *testing.T) {
+	for _, query := range []string{`.[`, `.a |`, `frobnicate`, `"open`, `{(.a)}`, `.a ==`, `.a )`} {
+		t.Run(query, func(t *testing.T) {
+			_, err := ParseQuery(query)
+			assert.Error(t, err)
+		})
+	}
+
+	q, err := ParseQuery(`.title.x`)
+	require.NoError(t, err)
+	_, err = q.Run(map[string]interface{}{"title": "Home"})
+	assert.EqualError(t, err, `expected an object but got: string ("Home")`)
+}
@@ -0,0 +1,336 @@
+package view
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The --jq query and --template applied to structured output, if set, and
+// whether --quiet reduces it to IDs.
+var (
+	activeQuery    *Query
+	activeTemplate *template.Template
+	quiet          bool
+)
+
+// templateFuncs are the functions available to --template.
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// SetQuery sets the jq query that structured output is filtered
+// through, or clears it when expr is empty.
+func SetQuery(expr string) error {
+	activeQuery = nil
+	if expr == "" {
+		return nil
+	}
+	q, err := ParseQuery(expr)
+	if err != nil {
+		return fmt.Errorf("invalid --jq query: %w", err)
+	}
+	activeQuery = q
+	return nil
+}
+
+// SetTemplate sets the Go template that structured output is rendered
+// with, or clears it when text is empty. The escapes \t and \n in text
+// are tabs and newlines, as shells pass them through quoted.
+func SetTemplate(text string) error {
+	activeTemplate = nil
+	if text == "" {
+		return nil
+	}
+	text = strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(text)
+	t, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid --template: %w", err)
+	}
+	activeTemplate = t
+	return nil
+}
+
+// SetQuiet sets whether structured output is reduced to the IDs it
+// contains, one per line, and messages are left out.
+func SetQuiet(q bool) {
+	quiet = q
+}
+
+// IsQuiet reports whether --quiet is set.
+func IsQuiet() bool {
+	return quiet
+}
+
+// IsStructured reports whether output in format is data for scripts rather
+// than text for people: JSON, YAML, CSV or TSV, or anything filtered by
+// --jq, --template or --quiet. Commands render structured output with
+// RenderJSON.
+func IsStructured(format string) bool {
+	switch Format(format) {
+	case FormatJSON, FormatYAML, FormatCSV, FormatTSV:
+		return true
+	}
+	return activeQuery != nil || activeTemplate != nil || quiet
+}
+
+// renderFiltered renders v as IDs for --quiet, or through the --jq query
+// or --template, and reports whether any is set. data is v as JSON, for
+// IDs and the query.
+func (r *Renderer) renderFiltered(v interface{}, data func() ([]byte, error)) (bool, error) {
+	switch {
+	case quiet:
+		raw, err := data()
+		if err != nil {
+			return true, err
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return true, err
+		}
+		for _, id := range quietIDs(decoded) {
+			_, _ = fmt.Fprintln(r.writer, id)
+		}
+		return true, nil
+
+	case activeQuery != nil:
+		raw, err := data()
+		if err != nil {
+			return true, err
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return true, err
+		}
+		results, err := activeQuery.Run(decoded)
+		if err != nil {
+			return true, fmt.Errorf("--jq: %w", err)
+		}
+		for _, result := range results {
+			if s, ok := result.(string); ok {
+				_, _ = fmt.Fprintln(r.writer, s)
+				continue
+			}
+			out, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return true, err
+			}
+			_, _ = fmt.Fprintln(r.writer, string(out))
+		}
+		return true, nil
+
+	case activeTemplate != nil:
+		// Lists are rendered an item at a time
+		rv := reflect.ValueOf(v)
+		for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return true, r.executeTemplate(v)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if err := r.executeTemplate(rv.Index(i).Interface()); err != nil {
+				return true, err
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// quietIDs returns the "id" of each item of decoded JSON: of an array, the
+// results of a list response, or a single object.
+func quietIDs(decoded interface{}) []string {
+	items := []interface{}{decoded}
+	switch v := decoded.(type) {
+	case []interface{}:
+		items = v
+	case map[string]interface{}:
+		if results, ok := v["results"].([]interface{}); ok {
+			items = results
+		}
+	}
+
+	var ids []string
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch id := obj["id"].(type) {
+		case string:
+			if id != "" {
+				ids = append(ids, id)
+			}
+		case float64:
+			ids = append(ids, strconv.FormatFloat(id, 'f', -1, 64))
+		}
+	}
+	return ids
+}
+
+// executeTemplate renders v with the --template, ending it with a newline.
+func (r *Renderer) executeTemplate(v interface{}) error {
+	var sb strings.Builder
+	if err := activeTemplate.Execute(&sb, v); err != nil {
+		return fmt.Errorf("--template: %w", err)
+	}
+	out := sb.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	_, _ = fmt.Fprint(r.writer, out)
+	return nil
+}
+
+// tableTemplateRows returns the rows of a table for --template, each keyed
+// by its column headers as given ("TITLE"), in lower case ("title"), and
+// in Go style ("Title", "SpaceID").
+func tableTemplateRows(headers []string, rows [][]string) []map[string]string {
+	result := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		item := make(map[string]string)
+		for i, header := range headers {
+			if i < len(row) {
+				item[header] = row[i]
+				item[strings.ToLower(header)] = row[i]
+				item[goFieldName(header)] = row[i]
+			}
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// goFieldName returns a column header as a Go field name: "SPACE ID" is
+// SpaceID.
+func goFieldName(header string) string {
+	var sb strings.Builder
+	for _, word := range strings.FieldsFunc(header, func(r rune) bool { return r == ' ' || r == '_' || r == '-' }) {
+		switch upper := strings.ToUpper(word); upper {
+		case "ID", "URL", "API":
+			sb.WriteString(upper)
+		default:
+			sb.WriteString(upper[:1] + strings.ToLower(word[1:]))
+		}
+	}
+	return sb.String()
+}
+
+// writeYAML writes JSON data as YAML, keeping the order of object keys.
+func (r *Renderer) writeYAML(data []byte) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	clearYAMLStyle(&node)
+	out, err := yaml.Marshal(&node)
+	if err != nil {
+		return err
+	}
+	_, _ = r.writer.Write(out)
+	return nil
+}
+
+// clearYAMLStyle resets the JSON (flow and quoted) style of decoded nodes,
+// so they are written in block style.
+func clearYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearYAMLStyle(c)
+	}
+}
+
+// writeDelimited writes a header row and rows as CSV, or TSV.
+func (r *Renderer) writeDelimited(headers []string, rows [][]string) error {
+	w := csv.NewWriter(r.writer)
+	if r.format == FormatTSV {
+		w.Comma = '\t'
+	}
+	if err := w.Write(headers); err != nil {
+		return err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+// writeDelimitedJSON writes JSON data as CSV, or TSV: a row for each item
+// of an array, or a single row for anything else, with a column for each
+// object key. Nested arrays and objects are written as JSON.
+func (r *Renderer) writeDelimitedJSON(data []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	root := doc.Content[0]
+	items := []*yaml.Node{root}
+	if root.Kind == yaml.SequenceNode {
+		items = root.Content
+	}
+
+	// Columns are the keys of all items, in the order they're first seen
+	var headers []string
+	columns := make(map[string]int)
+	for _, item := range items {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			key := item.Content[i].Value
+			if _, ok := columns[key]; !ok {
+				columns[key] = len(headers)
+				headers = append(headers, key)
+			}
+		}
+	}
+
+	var rows [][]string
+	for _, item := range items {
+		if item.Kind != yaml.MappingNode {
+			if headers == nil {
+				headers = []string{"value"}
+			}
+			rows = append(rows, []string{delimitedCell(item)})
+			continue
+		}
+		row := make([]string, len(headers))
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			row[columns[item.Content[i].Value]] = delimitedCell(item.Content[i+1])
+		}
+		rows = append(rows, row)
+	}
+	return r.writeDelimited(headers, rows)
+}
+
+// delimitedCell returns a decoded JSON value as a CSV cell.
+func delimitedCell(n *yaml.Node) string {
+	if n.Kind == yaml.ScalarNode {
+		if n.Tag == "!!null" {
+			return ""
+		}
+		return n.Value
+	}
+	var v interface{}
+	if err := n.Decode(&v); err != nil {
+		return ""
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
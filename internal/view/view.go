@@ -19,22 +19,28 @@ const (
 	FormatTable Format = "table"
 	FormatJSON  Format = "json"
 	FormatPlain Format = "plain"
+	FormatYAML  Format = "yaml"
+	FormatCSV   Format = "csv"
+	FormatTSV   Format = "tsv"
 )
 
 // ValidFormats returns the list of valid output formats.
 func ValidFormats() []string {
-	return []string{string(FormatTable), string(FormatJSON), string(FormatPlain)}
+	return []string{string(FormatTable), string(FormatJSON), string(FormatPlain), string(FormatYAML), string(FormatCSV), string(FormatTSV)}
 }
 
 // ValidateFormat checks if a format string is valid.
 // Returns an error if the format is not supported.
 func ValidateFormat(format string) error {
-	switch format {
-	case "", string(FormatTable), string(FormatJSON), string(FormatPlain):
+	if format == "" {
 		return nil
-	default:
-		return fmt.Errorf("invalid output format: %q (valid formats: table, json, plain)", format)
 	}
+	for _, f := range ValidFormats() {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format: %q (valid formats: %s)", format, strings.Join(ValidFormats(), ", "))
 }
 
 // Renderer renders data in a specific format.
@@ -63,12 +69,26 @@ func (r *Renderer) SetWriter(w io.Writer) {
 
 // RenderTable renders data as a table.
 func (r *Renderer) RenderTable(headers []string, rows [][]string) {
-	if r.format == FormatJSON {
-		r.renderTableAsJSON(headers, rows)
+	filtered, err := r.renderFiltered(tableTemplateRows(headers, rows), func() ([]byte, error) {
+		return json.Marshal(tableRecords(headers, rows))
+	})
+	if filtered {
+		r.reportError(err)
 		return
 	}
 
-	if r.format == FormatPlain {
+	switch r.format {
+	case FormatJSON:
+		r.renderTableAsJSON(headers, rows)
+		return
+	case FormatYAML:
+		data, _ := json.Marshal(tableRecords(headers, rows))
+		r.reportError(r.writeYAML(data))
+		return
+	case FormatCSV, FormatTSV:
+		r.reportError(r.writeDelimited(headers, rows))
+		return
+	case FormatPlain:
 		r.renderTableAsPlain(headers, rows)
 		return
 	}
@@ -96,6 +116,18 @@ func (r *Renderer) RenderTable(headers []string, rows [][]string) {
 
 func (r *Renderer) renderTableAsJSON(headers []string, rows [][]string) {
 	var result []map[string]string
+	if len(rows) > 0 {
+		result = tableRecords(headers, rows)
+	}
+
+	data, _ := json.MarshalIndent(result, "", "  ")
+	_, _ = fmt.Fprintln(r.writer, string(data))
+}
+
+// tableRecords returns the rows of a table as objects keyed by their
+// lower-cased column headers.
+func tableRecords(headers []string, rows [][]string) []map[string]string {
+	result := make([]map[string]string, 0, len(rows))
 	for _, row := range rows {
 		item := make(map[string]string)
 		for i, header := range headers {
@@ -105,9 +137,15 @@ func (r *Renderer) renderTableAsJSON(headers []string, rows [][]string) {
 		}
 		result = append(result, item)
 	}
+	return result
+}
 
-	data, _ := json.MarshalIndent(result, "", "  ")
-	_, _ = fmt.Fprintln(r.writer, string(data))
+// reportError prints an error from rendering output that has no way to
+// return it.
+func (r *Renderer) reportError(err error) {
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "Error: "+err.Error())
+	}
 }
 
 func (r *Renderer) renderTableAsPlain(_ []string, rows [][]string) {
@@ -135,47 +173,55 @@ type ListResponse struct {
 }
 
 // RenderList renders tabular data with pagination metadata.
-// For JSON output, wraps results in an object with _meta field.
-// For other formats, delegates to RenderTable.
+// For JSON and YAML output, and --jq queries, wraps results in an object
+// with _meta field. For other formats, delegates to RenderTable.
 func (r *Renderer) RenderList(headers []string, rows [][]string, hasMore bool) {
-	if r.format == FormatJSON {
-		r.renderListAsJSON(headers, rows, hasMore)
-		return
-	}
-	// For non-JSON, delegate to existing RenderTable
-	r.RenderTable(headers, rows)
-}
-
-func (r *Renderer) renderListAsJSON(headers []string, rows [][]string, hasMore bool) {
-	results := make([]map[string]string, 0, len(rows))
-	for _, row := range rows {
-		item := make(map[string]string)
-		for i, header := range headers {
-			if i < len(row) {
-				item[strings.ToLower(header)] = row[i]
-			}
-		}
-		results = append(results, item)
-	}
-
 	response := ListResponse{
-		Results: results,
+		Results: tableRecords(headers, rows),
 		Meta: ListMeta{
-			Count:   len(results),
+			Count:   len(rows),
 			HasMore: hasMore,
 		},
 	}
 
-	data, _ := json.MarshalIndent(response, "", "  ")
-	_, _ = fmt.Fprintln(r.writer, string(data))
+	filtered, err := r.renderFiltered(tableTemplateRows(headers, rows), func() ([]byte, error) {
+		return json.Marshal(response)
+	})
+	if filtered {
+		r.reportError(err)
+		return
+	}
+
+	switch r.format {
+	case FormatJSON:
+		data, _ := json.MarshalIndent(response, "", "  ")
+		_, _ = fmt.Fprintln(r.writer, string(data))
+	case FormatYAML:
+		data, _ := json.Marshal(response)
+		r.reportError(r.writeYAML(data))
+	default:
+		r.RenderTable(headers, rows)
+	}
 }
 
-// RenderJSON renders an object as JSON.
+// RenderJSON renders an object as JSON, or in the renderer's other
+// structured format (YAML, CSV or TSV), or through the --jq query or
+// --template when one is set.
 func (r *Renderer) RenderJSON(v interface{}) error {
+	if filtered, err := r.renderFiltered(v, func() ([]byte, error) { return json.Marshal(v) }); filtered {
+		return err
+	}
+
 	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		return err
 	}
+	switch r.format {
+	case FormatYAML:
+		return r.writeYAML(data)
+	case FormatCSV, FormatTSV:
+		return r.writeDelimitedJSON(data)
+	}
 	_, _ = fmt.Fprintln(r.writer, string(data))
 	return nil
 }
@@ -196,8 +242,11 @@ func (r *Renderer) RenderKeyValue(key, value string) {
 	_, _ = fmt.Fprintln(r.writer, value)
 }
 
-// Success prints a success message.
+// Success prints a success message, unless --quiet is set.
 func (r *Renderer) Success(msg string) {
+	if quiet {
+		return
+	}
 	green := color.New(color.FgGreen)
 	_, _ = green.Fprintln(r.writer, "✓ "+msg)
 }
@@ -208,12 +257,35 @@ func (r *Renderer) Error(msg string) {
 	_, _ = red.Fprintln(r.writer, "✗ "+msg)
 }
 
-// Warning prints a warning message.
+// Warning prints a warning message, unless --quiet is set.
 func (r *Renderer) Warning(msg string) {
+	if quiet {
+		return
+	}
 	yellow := color.New(color.FgYellow)
 	_, _ = yellow.Fprintln(r.writer, "⚠ "+msg)
 }
 
+// FormatFileSize formats a byte count as a human-readable size.
+func FormatFileSize(bytes int64) string {
+	const (
+		KB = 1024
+		MB = KB * 1024
+		GB = MB * 1024
+	)
+
+	switch {
+	case bytes >= GB:
+		return fmt.Sprintf("%.1f GB", float64(bytes)/GB)
+	case bytes >= MB:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/MB)
+	case bytes >= KB:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/KB)
+	default:
+		return fmt.Sprintf("%d B", bytes)
+	}
+}
+
 // Truncate truncates a string to the specified length.
 func Truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -20,6 +20,9 @@ func TestValidateFormat(t *testing.T) {
 		{"table", "table", false},
 		{"json", "json", false},
 		{"plain", "plain", false},
+		{"yaml", "yaml", false},
+		{"csv", "csv", false},
+		{"tsv", "tsv", false},
 		{"invalid", "invalid", true},
 		{"xml", "xml", true},
 		{"TABLE uppercase", "TABLE", true}, // case-sensitive
@@ -43,7 +46,10 @@ func TestValidFormats(t *testing.T) {
 	assert.Contains(t, formats, "table")
 	assert.Contains(t, formats, "json")
 	assert.Contains(t, formats, "plain")
-	assert.Len(t, formats, 3)
+	assert.Contains(t, formats, "yaml")
+	assert.Contains(t, formats, "csv")
+	assert.Contains(t, formats, "tsv")
+	assert.Len(t, formats, 6)
 }
 
 func TestTruncate(t *testing.T) {
@@ -370,3 +376,172 @@ func TestRenderer_RowWithFewerColumns(t *testing.T) {
 	_, exists := result[0]["status"]
 	assert.False(t, exists)
 }
+
+func TestFormatFileSize(t *testing.T) {
+	tests := []struct {
+		bytes    int64
+		expected string
+	}{
+		{0, "0 B"},
+		{500, "500 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{1048576, "1.0 MB"},
+		{1572864, "1.5 MB"},
+		{1073741824, "1.0 GB"},
+		{1610612736, "1.5 GB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			result := FormatFileSize(tt.bytes)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestRenderer_RenderTable_Structured(t *testing.T) {
+	headers := []string{"ID", "SPACE KEY"}
+	rows := [][]string{{"1", "DEV"}, {"2", "a,b"}}
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{FormatYAML, "- id: \"1\"\n  space key: DEV\n- id: \"2\"\n  space key: a,b\n"},
+		{FormatCSV, "ID,SPACE KEY\n1,DEV\n2,\"a,b\"\n"},
+		{FormatTSV, "ID\tSPACE KEY\n1\tDEV\n2\ta,b\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			r := NewRenderer(tt.format, true)
+			r.SetWriter(&buf)
+			r.RenderTable(headers, rows)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderer_RenderJSON_Structured(t *testing.T) {
+	type page struct {
+		ID     string            `json:"id"`
+		Title  string            `json:"title"`
+		Labels []string          `json:"labels"`
+		Extra  map[string]string `json:"extra,omitempty"`
+	}
+	pages := []page{
+		{ID: "1", Title: "Home", Labels: []string{"a", "b"}},
+		{ID: "2", Title: "Guide", Extra: map[string]string{"k": "v"}},
+	}
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{FormatYAML, "- id: \"1\"\n  title: Home\n  labels:\n    - a\n    - b\n- id: \"2\"\n  title: Guide\n  labels: null\n  extra:\n    k: v\n"},
+		{FormatCSV, "id,title,labels,extra\n1,Home,\"[\"\"a\"\",\"\"b\"\"]\",\n2,Guide,,\"{\"\"k\"\":\"\"v\"\"}\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			r := NewRenderer(tt.format, true)
+			r.SetWriter(&buf)
+			require.NoError(t, r.RenderJSON(pages))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	t.Run("csv of an object", func(t *testing.T) {
+		var buf bytes.Buffer
+		r := NewRenderer(FormatCSV, true)
+		r.SetWriter(&buf)
+		require.NoError(t, r.RenderJSON(map[string]interface{}{"status": "ok", "count": 5}))
+		assert.Equal(t, "count,status\n5,ok\n", buf.String())
+	})
+}
+
+func TestRenderer_Query(t *testing.T) {
+	require.NoError(t, SetQuery(`.[] | select(.status == "current") | .title`))
+	defer func() { _ = SetQuery("") }()
+
+	var buf bytes.Buffer
+	r := NewRenderer(FormatTable, true)
+	r.SetWriter(&buf)
+	require.NoError(t, r.RenderJSON([]map[string]string{
+		{"title": "Home", "status": "current"},
+		{"title": "Old", "status": "archived"},
+	}))
+	assert.Equal(t, "Home\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, SetQuery(`.results | map(.id) | join(",")`))
+	r.RenderList([]string{"ID", "TITLE"}, [][]string{{"1", "Home"}, {"2", "Guide"}}, false)
+	assert.Equal(t, "1,2\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, SetQuery(`{count: length}`))
+	require.NoError(t, r.RenderJSON([]int{1, 2}))
+	assert.JSONEq(t, `{"count": 2}`, buf.String())
+
+	assert.Error(t, SetQuery(`.[`))
+}
+
+func TestRenderer_Template(t *testing.T) {
+	require.NoError(t, SetTemplate(`{{.ID}}\t{{.Title}}`))
+	defer func() { _ = SetTemplate("") }()
+	assert.True(t, IsStructured("table"))
+
+	var buf bytes.Buffer
+	r := NewRenderer(FormatTable, true)
+	r.SetWriter(&buf)
+
+	type page struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+	require.NoError(t, r.RenderJSON([]page{{"1", "Home"}, {"2", "Guide"}}))
+	assert.Equal(t, "1\tHome\n2\tGuide\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, r.RenderJSON(&page{"3", "One"}))
+	assert.Equal(t, "3\tOne\n", buf.String())
+
+	// Table columns are available as headers, lower case and Go names
+	buf.Reset()
+	require.NoError(t, SetTemplate(`{{.ID}} {{.title}} {{.SpaceKey}} {{index . "SPACE KEY"}}`))
+	r.RenderTable([]string{"ID", "TITLE", "SPACE KEY"}, [][]string{{"1", "Home", "DEV"}})
+	assert.Equal(t, "1 Home DEV DEV\n", buf.String())
+
+	assert.Error(t, SetTemplate("{{.ID"))
+}
+
+func TestIsStructured(t *testing.T) {
+	for _, format := range []string{"json", "yaml", "csv", "tsv"} {
+		assert.True(t, IsStructured(format), format)
+	}
+	for _, format := range []string{"", "table", "plain"} {
+		assert.False(t, IsStructured(format), format)
+	}
+}
+
+func TestRenderer_Quiet(t *testing.T) {
+	SetQuiet(true)
+	defer SetQuiet(false)
+	assert.True(t, IsStructured("table"))
+
+	var buf bytes.Buffer
+	r := NewRenderer(FormatTable, true)
+	r.SetWriter(&buf)
+
+	r.RenderList([]string{"ID", "TITLE"}, [][]string{{"1", "Home"}, {"2", "Guide"}}, true)
+	r.RenderTable([]string{"ID", "TITLE"}, [][]string{{"3", "FAQ"}})
+	require.NoError(t, r.RenderJSON(map[string]interface{}{"id": "4", "title": "New"}))
+	require.NoError(t, r.RenderJSON([]map[string]interface{}{{"id": 5}, {"title": "no id"}}))
+	require.NoError(t, r.RenderJSON([]interface{}{}))
+	r.Success("Created page")
+	r.Warning("Something odd")
+	assert.Equal(t, "1\n2\n3\n4\n5\n", buf.String())
+}
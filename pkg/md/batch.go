@@ -0,0 +1,148 @@
+// batch.go converts many documents at once on a pool of workers.
+package md
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// BatchInput is one document for ConvertBatch.
+type BatchInput struct {
+	Name    string // Identifies the document in errors, such as its file path
+	Content []byte
+}
+
+// BatchResult is the outcome of converting one BatchInput.
+type BatchResult struct {
+	Name   string
+	Output string
+	Err    error
+}
+
+// BatchOptions configures ConvertBatch.
+type BatchOptions struct {
+	// Direction selects the conversion: DirectionToStorage,
+	// DirectionToADF, DirectionFromStorage or DirectionFromADF.
+	Direction Direction
+
+	// Convert, when set, is used instead of the conversion Direction
+	// selects, for callers that wrap a converter with steps of their own.
+	Convert func(content []byte) (string, error)
+
+	// Options for the conversion Direction selects.
+	Options ConvertOptions
+
+	// Workers is the number of documents converted at once; zero uses
+	// one worker per CPU.
+	Workers int
+}
+
+// BatchError reports the documents a batch failed to convert.
+type BatchError struct {
+	Failed []BatchResult
+}
+
+// Error implements error.
+func (e *BatchError) Error() string {
+	if len(e.Failed) == 1 {
+		return fmt.Sprintf("failed to convert %s: %v", e.Failed[0].Name, e.Failed[0].Err)
+	}
+	msgs := make([]string, len(e.Failed))
+	for i, r := range e.Failed {
+		msgs[i] = fmt.Sprintf("  %s: %v", r.Name, r.Err)
+	}
+	return fmt.Sprintf("failed to convert %d documents:\n%s", len(e.Failed), strings.Join(msgs, "\n"))
+}
+
+// Unwrap returns the error of each failed document.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, r := range e.Failed {
+		errs[i] = r.Err
+	}
+	return errs
+}
+
+// ConvertBatch converts every input on a pool of workers and returns the
+// results in input order. A document that fails doesn't stop the others:
+// its result carries the error, and the returned error is a *BatchError
+// listing every failure. When ctx is canceled, inputs not yet started are
+// left unconverted and ctx's error is returned.
+func ConvertBatch(ctx context.Context, inputs []BatchInput, opts BatchOptions) ([]BatchResult, error) {
+	convert := opts.Convert
+	if convert == nil {
+		var err error
+		if convert, err = directionConverter(opts.Direction, opts.Options); err != nil {
+			return nil, err
+		}
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make([]BatchResult, len(inputs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(inputs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				output, err := convert(inputs[i].Content)
+				results[i] = BatchResult{Name: inputs[i].Name, Output: output, Err: err}
+			}
+		}()
+	}
+
+feed:
+	for i := range inputs {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+	var failed []BatchResult
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) > 0 {
+		return results, &BatchError{Failed: failed}
+	}
+	return results, nil
+}
+
+// directionConverter returns the converter for a direction.
+func directionConverter(dir Direction, opts ConvertOptions) (func([]byte) (string, error), error) {
+	switch dir {
+	case DirectionToStorage:
+		return func(content []byte) (string, error) {
+			return ToConfluenceStorageWithOptions(content, opts)
+		}, nil
+	case DirectionToADF:
+		return func(content []byte) (string, error) {
+			return ToADFWithOptions(content, opts)
+		}, nil
+	case DirectionFromStorage:
+		return func(content []byte) (string, error) {
+			return FromConfluenceStorageWithOptions(string(content), opts)
+		}, nil
+	case DirectionFromADF:
+		return func(content []byte) (string, error) {
+			return FromADFWithOptions(string(content), opts)
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown conversion direction %q", dir)
+}
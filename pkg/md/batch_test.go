@@ -0,0 +1,86 @@
+package md
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertBatch(t *testing.T) {
+	var inputs []BatchInput
+	for i := 0; i < 50; i++ {
+		inputs = append(inputs, BatchInput{Name: fmt.Sprintf("%d.md", i), Content: []byte(fmt.Sprintf("# Page %d", i))})
+	}
+
+	results, err := ConvertBatch(context.Background(), inputs, BatchOptions{Direction: DirectionToStorage, Workers: 4})
+	require.NoError(t, err)
+	require.Len(t, results, 50)
+	for i, r := range results {
+		assert.Equal(t, fmt.Sprintf("%d.md", i), r.Name)
+		assert.Equal(t, fmt.Sprintf("<h1>Page %d</h1>\n", i), r.Output)
+		assert.NoError(t, r.Err)
+	}
+}
+
+func TestConvertBatch_Directions(t *testing.T) {
+	tests := []struct {
+		dir      Direction
+		input    string
+		expected string
+	}{
+		{DirectionToStorage, "**bold**", "<p><strong>bold</strong></p>\n"},
+		{DirectionToADF, "hi", `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"hi"}]}]}`},
+		{DirectionFromStorage, "<p><em>hi</em></p>", "*hi*"},
+		{DirectionFromADF, `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"hi"}]}]}`, "hi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.dir), func(t *testing.T) {
+			results, err := ConvertBatch(context.Background(), []BatchInput{{Name: "doc", Content: []byte(tt.input)}}, BatchOptions{Direction: tt.dir})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, results[0].Output)
+		})
+	}
+
+	_, err := ConvertBatch(context.Background(), nil, BatchOptions{Direction: "sideways"})
+	assert.ErrorContains(t, err, `unknown conversion direction "sideways"`)
+}
+
+func TestConvertBatch_Errors(t *testing.T) {
+	errBad := errors.New("bad input")
+	inputs := []BatchInput{
+		{Name: "a.md", Content: []byte("ok")},
+		{Name: "b.md", Content: []byte("bad")},
+		{Name: "c.md", Content: []byte("bad")},
+	}
+	results, err := ConvertBatch(context.Background(), inputs, BatchOptions{
+		Convert: func(content []byte) (string, error) {
+			if string(content) == "bad" {
+				return "", errBad
+			}
+			return strings.ToUpper(string(content)), nil
+		},
+	})
+
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.ErrorIs(t, err, errBad)
+	assert.Equal(t, "failed to convert 2 documents:\n  b.md: bad input\n  c.md: bad input", err.Error())
+	assert.Equal(t, "OK", results[0].Output)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, errBad)
+}
+
+func TestConvertBatch_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := ConvertBatch(ctx, []BatchInput{{Name: "a.md", Content: []byte("x")}}, BatchOptions{Direction: DirectionToStorage})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, results, 1)
+}
@@ -0,0 +1,165 @@
+// canonical.go rewrites generated storage format and ADF into a canonical
+// form, so that converting the same content always gives the same bytes.
+package md
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Patterns for canonical storage format.
+var (
+	// tagAttrsPattern matches an opening tag with attributes.
+	tagAttrsPattern = regexp.MustCompile(`<([A-Za-z][\w:.-]*)((?:\s+[\w:.-]+(?:\s*=\s*(?:"[^"]*"|'[^']*'))?)+)\s*(/?)>`)
+
+	// tagAttrPattern matches one attribute of a tag.
+	tagAttrPattern = regexp.MustCompile(`([\w:.-]+)(?:\s*=\s*("[^"]*"|'[^']*'))?`)
+
+	// macroParamsPattern matches a run of macro parameters with plain values.
+	macroParamsPattern = regexp.MustCompile(`(?:<ac:parameter ac:name="[^"]*">[^<]*</ac:parameter>){2,}`)
+
+	// macroParamPattern matches one macro parameter and its name.
+	macroParamPattern = regexp.MustCompile(`<ac:parameter ac:name="([^"]*)">[^<]*</ac:parameter>`)
+
+	// trailingSpacePattern matches whitespace at the end of a line.
+	trailingSpacePattern = regexp.MustCompile(`[ \t]+\n`)
+
+	// blankLinesPattern matches two or more blank lines.
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// CanonicalStorage rewrites storage format so that the same content always
+// gives the same bytes: attributes are sorted by name, runs of macro
+// parameters by parameter name, line endings are \n, trailing whitespace
+// and repeated blank lines are removed and the output ends in a single
+// newline. CDATA sections, such as code macro bodies, are left as written.
+func CanonicalStorage(storage string) string {
+	storage = strings.ReplaceAll(storage, "\r\n", "\n")
+	storage = strings.ReplaceAll(storage, "\r", "\n")
+
+	var sb strings.Builder
+	last := 0
+	for _, loc := range cdataPattern.FindAllStringIndex(storage, -1) {
+		sb.WriteString(canonicalMarkup(storage[last:loc[0]]))
+		sb.WriteString(storage[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	sb.WriteString(canonicalMarkup(storage[last:]))
+
+	out := strings.TrimSpace(sb.String())
+	if out == "" {
+		return ""
+	}
+	return out + "\n"
+}
+
+// canonicalMarkup canonicalizes storage format outside CDATA sections.
+func canonicalMarkup(s string) string {
+	s = tagAttrsPattern.ReplaceAllStringFunc(s, func(tag string) string {
+		groups := tagAttrsPattern.FindStringSubmatch(tag)
+		attrs := tagAttrPattern.FindAllString(groups[2], -1)
+		sort.SliceStable(attrs, func(i, j int) bool {
+			return tagAttrPattern.FindStringSubmatch(attrs[i])[1] < tagAttrPattern.FindStringSubmatch(attrs[j])[1]
+		})
+		if groups[3] != "" {
+			return "<" + groups[1] + " " + strings.Join(attrs, " ") + " />"
+		}
+		return "<" + groups[1] + " " + strings.Join(attrs, " ") + ">"
+	})
+
+	s = macroParamsPattern.ReplaceAllStringFunc(s, func(run string) string {
+		params := macroParamPattern.FindAllStringSubmatch(run, -1)
+		sort.SliceStable(params, func(i, j int) bool { return params[i][1] < params[j][1] })
+		var sb strings.Builder
+		for _, p := range params {
+			sb.WriteString(p[0])
+		}
+		return sb.String()
+	})
+
+	s = trailingSpacePattern.ReplaceAllString(s, "\n")
+	return blankLinesPattern.ReplaceAllString(s, "\n\n")
+}
+
+// CanonicalADF rewrites an ADF document so that the same content always
+// gives the same bytes: object keys are sorted, the JSON is compact, the
+// marks of each node are sorted by type, and adjacent text nodes with the
+// same marks are merged, however the markdown parser split the text.
+func CanonicalADF(adf string) (string, error) {
+	if strings.TrimSpace(adf) == "" {
+		return adf, nil
+	}
+
+	dec := json.NewDecoder(strings.NewReader(adf))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return "", fmt.Errorf("failed to parse ADF: %w", err)
+	}
+	canonicalADFNode(doc)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	if err := enc.Encode(doc); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// canonicalADFNode canonicalizes a decoded ADF node and its descendants.
+func canonicalADFNode(v interface{}) {
+	node, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if marks, ok := node["marks"].([]interface{}); ok {
+		sort.SliceStable(marks, func(i, j int) bool { return adfKey(marks[i]) < adfKey(marks[j]) })
+	}
+
+	content, ok := node["content"].([]interface{})
+	if !ok {
+		return
+	}
+	merged := content[:0]
+	for _, child := range content {
+		canonicalADFNode(child)
+		if len(merged) > 0 && mergeTextNodes(merged[len(merged)-1], child) {
+			continue
+		}
+		merged = append(merged, child)
+	}
+	node["content"] = merged
+}
+
+// mergeTextNodes appends next's text to prev when both are text nodes with
+// the same marks, reporting whether it did.
+func mergeTextNodes(prev, next interface{}) bool {
+	p, ok := prev.(map[string]interface{})
+	if !ok || p["type"] != "text" {
+		return false
+	}
+	n, ok := next.(map[string]interface{})
+	if !ok || n["type"] != "text" || len(p) != len(n) {
+		return false
+	}
+	for key := range n {
+		if key != "text" && adfKey(p[key]) != adfKey(n[key]) {
+			return false
+		}
+	}
+	pText, _ := p["text"].(string)
+	nText, _ := n["text"].(string)
+	p["text"] = pText + nText
+	return true
+}
+
+// adfKey returns a decoded ADF value's JSON, to compare and order values.
+func adfKey(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -0,0 +1,93 @@
+package md
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalStorage(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "attributes sorted",
+			input:    `<ac:link><ri:page ri:space-key="DEV" ri:content-title="Home"/></ac:link>`,
+			expected: "<ac:link><ri:page ri:content-title=\"Home\" ri:space-key=\"DEV\" /></ac:link>\n",
+		},
+		{
+			name:     "macro parameters sorted",
+			input:    `<ac:structured-macro ac:name="toc"><ac:parameter ac:name="minLevel">1</ac:parameter><ac:parameter ac:name="maxLevel">3</ac:parameter></ac:structured-macro>`,
+			expected: "<ac:structured-macro ac:name=\"toc\"><ac:parameter ac:name=\"maxLevel\">3</ac:parameter><ac:parameter ac:name=\"minLevel\">1</ac:parameter></ac:structured-macro>\n",
+		},
+		{
+			name:     "whitespace",
+			input:    "\r\n<p>a</p>  \r\n\r\n\r\n<p>b</p>\n\n",
+			expected: "<p>a</p>\n\n<p>b</p>\n",
+		},
+		{
+			name:     "CDATA kept",
+			input:    "<ac:plain-text-body><![CDATA[x  \n\n\n<b c=\"1\" a=\"2\">]]></ac:plain-text-body>",
+			expected: "<ac:plain-text-body><![CDATA[x  \n\n\n<b c=\"1\" a=\"2\">]]></ac:plain-text-body>\n",
+		},
+		{
+			name:     "empty",
+			input:    " \n",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CanonicalStorage(tt.input))
+		})
+	}
+}
+
+func TestCanonicalADF(t *testing.T) {
+	input := `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[
+		{"type":"text","text":"["},{"type":"text","text":"x]"},
+		{"type":"text","text":"b","marks":[{"type":"strong"},{"type":"em"}]},
+		{"type":"text","text":"c","marks":[{"type":"em"},{"type":"strong"}]}]}]}`
+
+	got, err := CanonicalADF(input)
+	require.NoError(t, err)
+	assert.Equal(t, `{"content":[{"content":[{"text":"[x]","type":"text"},{"marks":[{"type":"em"},{"type":"strong"}],"text":"bc","type":"text"}],"type":"paragraph"}],"type":"doc","version":1}`, got)
+
+	_, err = CanonicalADF("{")
+	assert.ErrorContains(t, err, "failed to parse ADF")
+}
+
+func TestDeterministicOptions(t *testing.T) {
+	markdown := []byte("See [[DEV:Home]] and [x] [TOC minLevel=1 maxLevel=3]\n")
+
+	storage, err := ToConfluenceStorageWithOptions(markdown, ConvertOptions{Deterministic: true})
+	require.NoError(t, err)
+	assert.Contains(t, storage, `<ri:page ri:content-title="Home" ri:space-key="DEV" />`)
+	assert.Contains(t, storage, `<ac:parameter ac:name="maxLevel">3</ac:parameter><ac:parameter ac:name="minLevel">1</ac:parameter>`)
+	assert.Equal(t, storage, CanonicalStorage(storage))
+
+	adf, err := ToADFWithOptions(markdown, ConvertOptions{Deterministic: true})
+	require.NoError(t, err)
+	assert.Contains(t, adf, `{"text":" and [x] [TOC minLevel=1 maxLevel=3]","type":"text"}`)
+	again, err := CanonicalADF(adf)
+	require.NoError(t, err)
+	assert.Equal(t, adf, again)
+}
+
+func TestFromConfluenceStorage_ManyMacros(t *testing.T) {
+	// Placeholders for macro 1 and macro 10 share a prefix
+	var storage strings.Builder
+	for i := 0; i < 12; i++ {
+		storage.WriteString(`<ac:structured-macro ac:name="info"><ac:rich-text-body><p>x</p></ac:rich-text-body></ac:structured-macro>`)
+	}
+	got, err := FromConfluenceStorageWithOptions(storage.String(), ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Equal(t, 12, strings.Count(got, "[INFO]"))
+	assert.Equal(t, 12, strings.Count(got, "[/INFO]"))
+	assert.NotContains(t, got, placeholderOpenPrefix)
+}
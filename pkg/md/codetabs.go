@@ -0,0 +1,261 @@
+// codetabs.go converts code tab groups: adjacent fenced code blocks
+// annotated with tab=Name in their info string.
+package md
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Macros used by GroupCodeTabs to display a code tab group.
+const (
+	CodeTabsMacro = "ui-tabs"
+	CodeTabMacro  = "ui-tab"
+)
+
+// Placeholder markers for code tabs (avoid markdown and html-to-markdown escaping)
+const (
+	codeTabPlaceholderPrefix = "CFCODETAB"
+	codeTabPlaceholderSuffix = "END"
+	codeTabTitlePlaceholder  = "CFTABTITLE"
+)
+
+// codeTabDefaultLanguage is used for tabbed blocks without a language.
+const codeTabDefaultLanguage = "text"
+
+// tabAttrPattern matches the tab attribute of a fence info string: tab=Go or tab="Go 1.22".
+var tabAttrPattern = regexp.MustCompile(`(?:^|\s)tab=(?:"([^"]*)"|(\S+))`)
+
+// codeTabTitle returns the tab name from a fenced code block's info string.
+func codeTabTitle(info string) (string, bool) {
+	m := tabAttrPattern.FindStringSubmatch(info)
+	if m == nil {
+		return "", false
+	}
+	if m[1] != "" {
+		return m[1], true
+	}
+	return m[2], m[2] != ""
+}
+
+// formatTabInfo renders a fence info string for a tabbed code block.
+func formatTabInfo(lang, title string) string {
+	if lang == "" {
+		lang = codeTabDefaultLanguage
+	}
+	if strings.ContainsAny(title, " \t") {
+		return fmt.Sprintf(`%s tab="%s"`, lang, title)
+	}
+	return lang + " tab=" + title
+}
+
+// preprocessCodeTabs replaces tabbed fenced code blocks with placeholders
+// for titled code macros, which are sequential labeled blocks in any
+// Confluence instance. Returns the processed markdown and the macro XML
+// for each placeholder.
+func preprocessCodeTabs(markdown []byte) ([]byte, map[int]string) {
+	tabs := make(map[int]string)
+	isTab := func(b FencedBlock) bool {
+		_, ok := codeTabTitle(b.Info)
+		return ok
+	}
+
+	processed, _ := replaceFences(markdown, isTab, func(b FencedBlock) (string, error) {
+		title, _ := codeTabTitle(b.Info)
+		node := &MacroNode{
+			Name:       "code",
+			Parameters: map[string]string{"title": title},
+			Body:       strings.TrimSuffix(b.Code, "\n"),
+		}
+		if b.Lang != "" && !strings.HasPrefix(b.Lang, "tab=") {
+			node.Parameters["language"] = b.Lang
+		}
+
+		runTransformMacro(DirectionToStorage, node)
+		id := len(tabs)
+		tabs[id] = RenderMacroToXML(node)
+		return fmt.Sprintf("\n%s%d%s\n", codeTabPlaceholderPrefix, id, codeTabPlaceholderSuffix), nil
+	})
+	return processed, tabs
+}
+
+// postprocessCodeTabs replaces code tab placeholders with macro XML.
+func postprocessCodeTabs(html string, tabs map[int]string) string {
+	for id, xml := range tabs {
+		placeholder := fmt.Sprintf("%s%d%s", codeTabPlaceholderPrefix, id, codeTabPlaceholderSuffix)
+		if wrapped := "<p>" + placeholder + "</p>"; strings.Contains(html, wrapped) {
+			html = strings.Replace(html, wrapped, xml, 1)
+		} else {
+			html = strings.Replace(html, placeholder, xml, 1)
+		}
+	}
+	return html
+}
+
+// GroupCodeTabs wraps each run of adjacent titled code macros in storage
+// format in a ui-tabs macro, with one ui-tab per code block, for
+// instances with a tabs macro app installed. Single titled code blocks
+// are left as they are.
+func GroupCodeTabs(storage string) string {
+	matches := codeBlockPattern.FindAllStringIndex(storage, -1)
+
+	var out strings.Builder
+	last := 0
+	for i := 0; i < len(matches); {
+		// Collect the run of titled code macros separated only by whitespace
+		j := i
+		for j < len(matches) && codeTabTitlePattern.MatchString(storage[matches[j][0]:matches[j][1]]) {
+			if j > i && strings.TrimSpace(storage[matches[j-1][1]:matches[j][0]]) != "" {
+				break
+			}
+			j++
+		}
+		if j-i < 2 {
+			i = max(j, i+1)
+			continue
+		}
+
+		out.WriteString(storage[last:matches[i][0]])
+		fmt.Fprintf(&out, `<ac:structured-macro ac:name="%s" ac:schema-version="1"><ac:rich-text-body>`, CodeTabsMacro)
+		for _, m := range matches[i:j] {
+			code := storage[m[0]:m[1]]
+			title := codeTabTitlePattern.FindStringSubmatch(code)[1]
+			fmt.Fprintf(&out, `<ac:structured-macro ac:name="%s" ac:schema-version="1"><ac:parameter ac:name="title">%s</ac:parameter><ac:rich-text-body>%s</ac:rich-text-body></ac:structured-macro>`,
+				CodeTabMacro, title, code)
+		}
+		out.WriteString(`</ac:rich-text-body></ac:structured-macro>`)
+		last = matches[j-1][1]
+		i = j
+	}
+	out.WriteString(storage[last:])
+
+	return out.String()
+}
+
+// codeTabTitlePattern matches the title parameter of a code macro.
+var codeTabTitlePattern = regexp.MustCompile(`<ac:parameter[^>]*ac:name="title"[^>]*>([^<]*)</ac:parameter>`)
+
+// markCodeTabs prepares titled code macros in storage format for
+// conversion to markdown. ui-tabs groups are unwrapped to their code
+// macros, and each code macro's title is replaced by a placeholder
+// appended to its language, which survives as the fence info string.
+// Returns the processed XHTML and the title for each placeholder.
+func markCodeTabs(storage string) (string, map[int]string) {
+	titles := make(map[int]string)
+	if !strings.Contains(storage, `ac:name="code"`) {
+		return storage, titles
+	}
+
+	storage = unwrapCodeTabs(storage)
+
+	return codeBlockPattern.ReplaceAllStringFunc(storage, func(match string) string {
+		m := codeTabTitlePattern.FindStringSubmatch(match)
+		if m == nil {
+			return match
+		}
+		id := len(titles)
+		titles[id] = html.UnescapeString(m[1])
+		placeholder := fmt.Sprintf("%s%d", codeTabTitlePlaceholder, id)
+
+		match = strings.Replace(match, m[0], "", 1)
+		lang := codeLanguagePattern.FindStringSubmatch(match)
+		if lang != nil {
+			match = strings.Replace(match, lang[0], "", 1)
+		}
+		language := codeTabDefaultLanguage
+		if lang != nil && strings.TrimSpace(lang[1]) != "" {
+			language = strings.TrimSpace(lang[1])
+		}
+
+		// Re-add the language after the opening tag, with the title placeholder
+		param := fmt.Sprintf(`<ac:parameter ac:name="language">%s%s</ac:parameter>`, language, placeholder)
+		open := strings.Index(match, ">") + 1
+		return match[:open] + param + match[open:]
+	}), titles
+}
+
+// codeTabsOpenPattern matches the opening tag of a ui-tabs or ui-tab macro.
+var codeTabsOpenPattern = regexp.MustCompile(`<ac:structured-macro[^>]*ac:name="(ui-tabs?)"[^>]*>`)
+
+// unwrapCodeTabs replaces ui-tabs macros with the code macros they
+// contain, moving each tab's title onto its code macro.
+func unwrapCodeTabs(storage string) string {
+	var out strings.Builder
+	for {
+		loc := codeTabsOpenPattern.FindStringSubmatchIndex(storage)
+		if loc == nil || storage[loc[2]:loc[3]] != CodeTabsMacro {
+			if loc == nil {
+				out.WriteString(storage)
+				return out.String()
+			}
+			out.WriteString(storage[:loc[1]])
+			storage = storage[loc[1]:]
+			continue
+		}
+
+		end := macroEnd(storage, loc[0])
+		out.WriteString(storage[:loc[0]])
+		group := storage[loc[1]:end]
+		for _, tab := range codeTabsOpenPattern.FindAllStringSubmatchIndex(group, -1) {
+			if group[tab[2]:tab[3]] != CodeTabMacro {
+				continue
+			}
+			body := group[tab[1]:macroEnd(group, tab[0])]
+			var title string
+			if m := codeTabTitlePattern.FindStringSubmatchIndex(body); m != nil && m[0] == 0 {
+				title = body[m[2]:m[3]]
+			}
+			for _, code := range codeBlockPattern.FindAllString(body, -1) {
+				if title != "" && !codeTabTitlePattern.MatchString(code) {
+					code = strings.Replace(code, `>`, `><ac:parameter ac:name="title">`+title+`</ac:parameter>`, 1)
+				}
+				out.WriteString(code)
+			}
+		}
+		storage = storage[end:]
+	}
+}
+
+// macroEnd returns the index just past the structured macro that opens at
+// start, accounting for nested macros. Returns len(html) if unclosed.
+func macroEnd(storage string, start int) int {
+	const open, closing = "<ac:structured-macro", "</ac:structured-macro>"
+	depth := 0
+	for i := start; i < len(storage); {
+		switch {
+		case strings.HasPrefix(storage[i:], open):
+			depth++
+			i += len(open)
+		case strings.HasPrefix(storage[i:], closing):
+			depth--
+			i += len(closing)
+			if depth == 0 {
+				return i
+			}
+		default:
+			i++
+		}
+	}
+	return len(storage)
+}
+
+// replaceCodeTabTitles turns title placeholders in converted markdown
+// fence info strings into tab attributes.
+func replaceCodeTabTitles(markdown string, titles map[int]string) string {
+	for id, title := range titles {
+		placeholder := fmt.Sprintf("%s%d", codeTabTitlePlaceholder, id)
+		idx := strings.Index(markdown, placeholder)
+		if idx < 0 {
+			continue
+		}
+		// The language precedes the placeholder on the fence line
+		lineStart := strings.LastIndex(markdown[:idx], "\n") + 1
+		fence := strings.TrimLeft(markdown[lineStart:idx], " ")
+		lang := strings.TrimLeft(fence, "`~")
+		info := formatTabInfo(lang, title)
+		markdown = markdown[:idx-len(lang)] + info + markdown[idx+len(placeholder):]
+	}
+	return markdown
+}
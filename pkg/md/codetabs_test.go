@@ -0,0 +1,115 @@
+package md
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const codeTabsMarkdown = "```go tab=Go\nfmt.Println(\"hi\")\n```\n\n```python tab=\"Python 3\"\nprint('hi')\n```\n"
+
+func TestToConfluenceStorage_CodeTabs(t *testing.T) {
+	result, err := ToConfluenceStorage([]byte(codeTabsMarkdown))
+	require.NoError(t, err)
+
+	assert.Contains(t, result, `<ac:parameter ac:name="language">go</ac:parameter><ac:parameter ac:name="title">Go</ac:parameter>`)
+	assert.Contains(t, result, `<ac:parameter ac:name="title">Python 3</ac:parameter>`)
+	assert.Contains(t, result, `<![CDATA[fmt.Println("hi")]]>`)
+	assert.NotContains(t, result, "<p>")
+	assert.NotContains(t, result, codeTabPlaceholderPrefix)
+}
+
+func TestToConfluenceStorage_CodeTabWithoutLanguage(t *testing.T) {
+	result, err := ToConfluenceStorage([]byte("```tab=Output\nok\n```\n"))
+	require.NoError(t, err)
+
+	assert.Contains(t, result, `<ac:parameter ac:name="title">Output</ac:parameter>`)
+	assert.NotContains(t, result, `ac:name="language"`)
+}
+
+func TestToConfluenceStorage_UntabbedFenceUnchanged(t *testing.T) {
+	result, err := ToConfluenceStorage([]byte("```go\nx := 1\n```\n"))
+	require.NoError(t, err)
+
+	assert.Contains(t, result, `<pre><code class="language-go">`)
+}
+
+func TestGroupCodeTabs(t *testing.T) {
+	storage, err := ToConfluenceStorage([]byte("Intro\n\n" + codeTabsMarkdown + "\nBetween\n\n```sh tab=Shell\nls\n```\n"))
+	require.NoError(t, err)
+
+	grouped := GroupCodeTabs(storage)
+
+	assert.Equal(t, 1, strings.Count(grouped, `ac:name="ui-tabs"`))
+	assert.Equal(t, 2, strings.Count(grouped, `ac:name="ui-tab"`))
+	assert.Contains(t, grouped, `<ac:structured-macro ac:name="ui-tab" ac:schema-version="1"><ac:parameter ac:name="title">Python 3</ac:parameter>`)
+	// The lone Shell block is not grouped
+	assert.Contains(t, grouped, `<p>Between</p>`+"\n"+`<ac:structured-macro ac:name="code"`)
+}
+
+func TestGroupCodeTabs_NoTitledBlocks(t *testing.T) {
+	storage := `<ac:structured-macro ac:name="code"><ac:plain-text-body><![CDATA[a]]></ac:plain-text-body></ac:structured-macro>` +
+		`<ac:structured-macro ac:name="code"><ac:plain-text-body><![CDATA[b]]></ac:plain-text-body></ac:structured-macro>`
+
+	assert.Equal(t, storage, GroupCodeTabs(storage))
+}
+
+func TestCodeTabs_Roundtrip(t *testing.T) {
+	storage, err := ToConfluenceStorage([]byte(codeTabsMarkdown))
+	require.NoError(t, err)
+
+	for name, input := range map[string]string{
+		"titled code":  storage,
+		"tabs grouped": GroupCodeTabs(storage),
+	} {
+		t.Run(name, func(t *testing.T) {
+			markdown, err := FromConfluenceStorage(input)
+			require.NoError(t, err)
+			assert.Equal(t, strings.TrimSpace(codeTabsMarkdown), markdown)
+		})
+	}
+}
+
+func TestFromConfluenceStorage_TabTitleOnTabMacro(t *testing.T) {
+	input := `<ac:structured-macro ac:name="ui-tabs"><ac:rich-text-body>` +
+		`<ac:structured-macro ac:name="ui-tab"><ac:parameter ac:name="title">Bash</ac:parameter><ac:rich-text-body>` +
+		`<ac:structured-macro ac:name="code"><ac:plain-text-body><![CDATA[echo hi]]></ac:plain-text-body></ac:structured-macro>` +
+		`</ac:rich-text-body></ac:structured-macro></ac:rich-text-body></ac:structured-macro>`
+
+	markdown, err := FromConfluenceStorage(input)
+	require.NoError(t, err)
+	assert.Equal(t, "```text tab=Bash\necho hi\n```", markdown)
+}
+
+func TestToADF_CodeTabs(t *testing.T) {
+	result, err := ToADF([]byte(codeTabsMarkdown))
+	require.NoError(t, err)
+
+	var doc ADFDocument
+	require.NoError(t, json.Unmarshal([]byte(result), &doc))
+	require.Len(t, doc.Content, 4)
+
+	assert.Equal(t, "paragraph", doc.Content[0].Type)
+	assert.Equal(t, "Go", doc.Content[0].Content[0].Text)
+	assert.Equal(t, "strong", doc.Content[0].Content[0].Marks[0].Type)
+	assert.Equal(t, "codeBlock", doc.Content[1].Type)
+	assert.Equal(t, "go", doc.Content[1].Attrs["language"])
+	assert.Equal(t, "Python 3", doc.Content[2].Content[0].Text)
+}
+
+func TestCodeTabs_ADFRoundtrip(t *testing.T) {
+	adf, err := ToADF([]byte("Intro\n\n" + codeTabsMarkdown + "\n```tab=Output\nok\n```\n"))
+	require.NoError(t, err)
+
+	markdown, err := FromADF(adf)
+	require.NoError(t, err)
+	assert.Equal(t, "Intro\n\n"+codeTabsMarkdown+"\n```text tab=Output\nok\n```", markdown)
+
+	// Bold text that doesn't label a code block stays as it is
+	markdown, err = FromADF(`{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"Note","marks":[{"type":"strong"}]}]},{"type":"paragraph","content":[{"type":"text","text":"text"}]}]}`)
+	require.NoError(t, err)
+	assert.Equal(t, "**Note**\n\ntext", markdown)
+}
@@ -9,11 +9,13 @@ import (
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
 )
 
-// mdParser is a pre-configured goldmark instance with GFM table extension.
+// mdParser is a pre-configured goldmark instance with GFM table and task
+// list extensions.
 var mdParser = goldmark.New(
-	goldmark.WithExtensions(extension.Table),
+	goldmark.WithExtensions(extension.Table, tableExtension{}, taskListExtension{}),
 )
 
 // macroPlaceholder is used to mark where macros should be inserted after goldmark processing.
@@ -27,6 +29,23 @@ func ToConfluenceStorage(markdown []byte) (string, error) {
 		return "", nil
 	}
 
+	markdown, err := runPreParse(DirectionToStorage, markdown)
+	if err != nil {
+		return "", err
+	}
+
+	// [[Title]] links become links to confluence:// URLs
+	markdown = preprocessPageLinks(markdown)
+
+	// Tabbed code fences become titled code macros
+	markdown, tabs := preprocessCodeTabs(markdown)
+
+	// Columns fences become layout sections
+	markdown, layouts := preprocessLayouts(markdown)
+
+	// [JIRA:KEY] is shorthand for the Jira macro
+	markdown = preprocessJiraShorthand(markdown)
+
 	// Preprocess: replace macro placeholders with unique markers
 	processed, macros := preprocessMacros(markdown)
 
@@ -37,8 +56,22 @@ func ToConfluenceStorage(markdown []byte) (string, error) {
 
 	// Postprocess: replace markers with actual macro XML
 	result := postprocessMacros(buf.String(), macros)
+	result = postprocessCodeTabs(result, tabs)
+	result = postprocessLayouts(result, layouts)
+	result = postprocessPageLinks(result)
+	result = numberTasks(result)
 
-	return result, nil
+	return runPostRender(DirectionToStorage, result)
+}
+
+// ToConfluenceStorageWithOptions converts markdown to Confluence storage
+// format, in canonical form when opts.Deterministic is set.
+func ToConfluenceStorageWithOptions(markdown []byte, opts ConvertOptions) (string, error) {
+	storage, err := ToConfluenceStorage(markdown)
+	if err != nil || !opts.Deterministic {
+		return storage, err
+	}
+	return CanonicalStorage(storage), nil
 }
 
 // preprocessMacros replaces macro placeholders like [TOC] with unique markers.
@@ -49,11 +82,11 @@ func ToConfluenceStorage(markdown []byte) (string, error) {
 // - Close tags like [/INFO] are properly consumed (not left as text)
 // - Nested macros like [TOC] inside [INFO]...[/INFO] are correctly associated
 func preprocessMacros(markdown []byte) ([]byte, map[int]string) {
-	input := string(markdown)
 	macros := make(map[int]string)
 
-	// Parse using the stack-based parser which correctly handles nesting
-	result, err := ParseBracketMacros(input)
+	// Parse using the stack-based parser which correctly handles nesting.
+	// Macros shown in code stay as written.
+	result, err := ParseBracketMacros(string(maskCodeBrackets(markdown)))
 	if err != nil {
 		return markdown, macros
 	}
@@ -65,9 +98,10 @@ func preprocessMacros(markdown []byte) ([]byte, map[int]string) {
 	for _, seg := range result.Segments {
 		switch seg.Type {
 		case SegmentText:
-			outputBuf.WriteString(seg.Text)
+			outputBuf.WriteString(unmaskBrackets(seg.Text))
 		case SegmentMacro:
 			// Process macro node (handles nested children recursively)
+			unmaskMacro(seg.Macro)
 			processMacroNode(seg.Macro, &outputBuf, macros, &counter)
 		}
 	}
@@ -75,6 +109,55 @@ func preprocessMacros(markdown []byte) ([]byte, map[int]string) {
 	return []byte(outputBuf.String()), macros
 }
 
+// maskedBracket stands in for "[" in code while bracket macros are parsed.
+const maskedBracket = '\x00'
+
+// maskCodeBrackets returns a copy of markdown with the brackets in code
+// masked, so that the macro parser passes over them. Passed through macros
+// are masked within their bodies only, so that the macros themselves are
+// still parsed. The text of links to pages is masked too, so that
+// [[Page|click here]] isn't read as a CLICK macro.
+func maskCodeBrackets(markdown []byte) []byte {
+	masked := append([]byte{}, markdown...)
+	if !bytes.Contains(markdown, []byte("[")) {
+		return masked
+	}
+	mask := func(start, stop int) {
+		for i := start; i < stop; i++ {
+			if masked[i] == '[' {
+				masked[i] = maskedBracket
+			}
+		}
+	}
+	passthrough := passthroughRanges(markdown)
+	for _, r := range codeRanges(adfParser.Parser().Parse(text.NewReader(markdown)), markdown) {
+		if !overlaps(passthrough, r[0], r[1]) {
+			mask(r[0], r[1])
+		}
+	}
+	for _, r := range passthrough {
+		mask(r[2], r[3])
+	}
+	for _, r := range pageLinkTextPattern.FindAllIndex(markdown, -1) {
+		mask(r[0], r[0]+1)
+	}
+	return masked
+}
+
+// unmaskBrackets restores the brackets maskCodeBrackets masked.
+func unmaskBrackets(s string) string {
+	return strings.ReplaceAll(s, string(maskedBracket), "[")
+}
+
+// unmaskMacro restores the masked brackets in the bodies of a macro and
+// its nested macros.
+func unmaskMacro(node *MacroNode) {
+	node.Body = unmaskBrackets(node.Body)
+	for _, child := range node.Children {
+		unmaskMacro(child)
+	}
+}
+
 // processMacroNode recursively processes a macro and its nested children.
 // It converts markdown body content to HTML and renders the macro to XML.
 //
@@ -106,9 +189,12 @@ func processMacroNode(node *MacroNode, output *strings.Builder, macros map[int]s
 			bodyWithPlaceholders = strings.Replace(bodyWithPlaceholders, childMarker, childOutput.String(), 1)
 		}
 
-		// Convert body markdown to HTML (placeholders survive conversion)
+		// Convert body markdown to HTML (placeholders survive conversion);
+		// plain text bodies are kept as written
 		var bodyBuf bytes.Buffer
-		if err := mdParser.Convert([]byte(bodyWithPlaceholders), &bodyBuf); err == nil {
+		if macroType.BodyType == BodyTypePlainText {
+			node.Body = bodyWithPlaceholders
+		} else if err := mdParser.Convert([]byte(bodyWithPlaceholders), &bodyBuf); err == nil {
 			node.Body = bodyBuf.String()
 		} else {
 			node.Body = "<p>" + bodyWithPlaceholders + "</p>"
@@ -116,7 +202,14 @@ func processMacroNode(node *MacroNode, output *strings.Builder, macros map[int]s
 	}
 
 	// Render this macro to XML
+	runTransformMacro(DirectionToStorage, node)
+	if node.Name == "status" {
+		statusToStorage(node)
+	}
 	macroXML := RenderMacroToXML(node)
+	if node.Name == passthroughMacro {
+		macroXML = passthroughXML(node)
+	}
 	currentID := *counter
 	macros[currentID] = macroXML
 	*counter++
@@ -159,7 +252,7 @@ func postprocessMacros(html string, macros map[int]string) string {
 		placeholder := FormatPlaceholder(id)
 		// The placeholder might be wrapped in <p> tags, so handle that
 		wrappedPlaceholder := "<p>" + placeholder + "</p>"
-		if strings.Contains(html, wrappedPlaceholder) {
+		if strings.Contains(html, wrappedPlaceholder) && !isInlineMacro(macroXML) {
 			html = strings.Replace(html, wrappedPlaceholder, macroXML, 1)
 		} else {
 			html = strings.Replace(html, placeholder, macroXML, 1)
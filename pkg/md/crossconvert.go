@@ -0,0 +1,334 @@
+// crossconvert.go converts between storage format and ADF, through
+// markdown, and converts bracket macros in markdown to ADF nodes.
+package md
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// Placeholder markers for macros in markdown converted to ADF.
+const (
+	adfMacroPlaceholderPrefix = "CFADFMACRO"
+	adfMacroPlaceholderSuffix = "END"
+)
+
+// adfPanelTypes maps panel macros to ADF panel types, reversing adfPanelMacros.
+var adfPanelTypes = map[string]string{
+	"info":    "info",
+	"note":    "note",
+	"warning": "warning",
+	"tip":     "success",
+}
+
+// Patterns for cross-conversion.
+var (
+	// adfMacroPlaceholderPattern matches a macro placeholder.
+	adfMacroPlaceholderPattern = regexp.MustCompile(adfMacroPlaceholderPrefix + `(\d+)` + adfMacroPlaceholderSuffix)
+
+	// richTextBodyPattern matches a macro body that is entirely rich text.
+	richTextBodyPattern = regexp.MustCompile(`(?s)^\s*<ac:rich-text-body>(.*)</ac:rich-text-body>\s*$`)
+)
+
+// adfMacro is a macro converted to ADF, with the markdown it was written as
+// for when it can't be placed where it appears.
+type adfMacro struct {
+	node     *ADFNode
+	markdown string
+}
+
+// StorageToADF converts storage format to an ADF document. The content is
+// converted through markdown with macros shown, so macros with bracket
+// syntax become panels, expands, code blocks and extensions, and unknown
+// macros become extensions, keeping their macro IDs in the extension's
+// macro metadata. UnconvertedStorage lists what is lost.
+func StorageToADF(storage string) (string, error) {
+	markdown, err := FromConfluenceStorageWithOptions(storage, ConvertOptions{ShowMacros: true})
+	if err != nil {
+		return "", err
+	}
+	return ToADF([]byte(markdown))
+}
+
+// ADFToStorage converts an ADF document to storage format. The content is
+// converted through markdown with macros shown, so panels, expands and
+// extensions become macros.
+func ADFToStorage(adf string) (string, error) {
+	markdown, err := FromADFWithOptions(adf, ConvertOptions{ShowMacros: true})
+	if err != nil {
+		return "", err
+	}
+	return ToConfluenceStorage([]byte(markdown))
+}
+
+// UnconvertedStorage lists the elements of storage format StorageToADF
+// can't carry over: layout sections with more columns than ADF layouts
+// have, whose cells follow one another instead, and unknown macros whose
+// content isn't rich text, such as macros with plain text bodies. Each is
+// named once, as "layout" or "macro NAME".
+func UnconvertedStorage(storage string) []string {
+	found := make(map[string]bool)
+	_, sections := replaceStorageLayouts(storage)
+	for _, s := range sections {
+		if len(s.Cells) > adfMaxLayoutColumns {
+			found["layout"] = true
+		}
+	}
+	_, macros := replaceUnknownMacros(convertCodeBlockMacros(storage))
+	for _, macro := range macros {
+		node := parsePassthrough(macro)
+		if node != nil && strings.TrimSpace(node.Body) != "" && !richTextBodyPattern.MatchString(node.Body) {
+			found["macro "+node.Parameters["name"]] = true
+		}
+	}
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// preprocessADFMacros replaces bracket macros outside code with
+// placeholders, converting each to an ADF node. Block macros must start a
+// line; those within text have no place in ADF and stay as written.
+func preprocessADFMacros(markdown []byte, c *adfConverter) []byte {
+	if !bytes.Contains(markdown, []byte("[")) {
+		return markdown
+	}
+
+	masked := maskCodeBrackets(markdown)
+	tokens, err := TokenizeBrackets(string(masked))
+	if err != nil {
+		return markdown
+	}
+	hasMacros := false
+	var open []bool // Whether each open body macro is masked
+	for _, token := range tokens {
+		macroType, known := LookupMacro(token.MacroName)
+		switch {
+		case !known || token.Type == BracketTokenText:
+		case token.Type == BracketTokenCloseTag:
+			if len(open) > 0 {
+				if open[len(open)-1] {
+					masked[token.Position] = maskedBracket
+				}
+				open = open[:len(open)-1]
+			}
+		default:
+			inText := !macroType.Inline && !lineStart(markdown, token.Position)
+			if inText {
+				masked[token.Position] = maskedBracket
+			} else {
+				hasMacros = true
+			}
+			if token.Type == BracketTokenOpenTag && macroType.HasBody {
+				open = append(open, inText)
+			}
+		}
+	}
+	if !hasMacros {
+		return markdown
+	}
+
+	result, err := ParseBracketMacros(string(masked))
+	if err != nil {
+		return markdown
+	}
+	var sb strings.Builder
+	for _, seg := range result.Segments {
+		switch seg.Type {
+		case SegmentText:
+			sb.WriteString(seg.Text)
+		case SegmentMacro:
+			unmaskMacro(seg.Macro)
+			sb.WriteString(c.macroPlaceholder(seg.Macro))
+		}
+	}
+	return []byte(unmaskBrackets(sb.String()))
+}
+
+// lineStart reports whether only spaces precede pos on its line.
+func lineStart(source []byte, pos int) bool {
+	line := source[:pos]
+	if i := bytes.LastIndexByte(line, '\n'); i >= 0 {
+		line = line[i+1:]
+	}
+	return len(bytes.TrimLeft(line, " \t")) == 0
+}
+
+// macroPlaceholder converts a macro and its nested macros to ADF, returning
+// its placeholder. A block macro is set apart as a paragraph of its own.
+func (c *adfConverter) macroPlaceholder(node *MacroNode) string {
+	for i, child := range node.Children {
+		node.Body = strings.Replace(node.Body, childPlaceholderPrefix+strconv.Itoa(i), c.macroPlaceholder(child), 1)
+	}
+
+	runTransformMacro(DirectionToADF, node)
+	id := len(c.macros)
+	c.macros = append(c.macros, adfMacro{markdown: RenderMacroToBracket(node)})
+	c.macros[id].node = c.macroADF(node)
+
+	placeholder := fmt.Sprintf("%s%d%s", adfMacroPlaceholderPrefix, id, adfMacroPlaceholderSuffix)
+	if isInlineADF(c.macros[id].node) {
+		return placeholder
+	}
+	return "\n\n" + placeholder + "\n\n"
+}
+
+// macroADF converts a macro whose nested macros are placeholders to ADF.
+func (c *adfConverter) macroADF(node *MacroNode) *ADFNode {
+	macroType, _ := LookupMacro(node.Name)
+	switch {
+	case adfPanelTypes[node.Name] != "":
+		return &ADFNode{
+			Type:    "panel",
+			Attrs:   map[string]interface{}{"panelType": adfPanelTypes[node.Name]},
+			Content: c.adfBody(node.Body),
+		}
+	case node.Name == "expand":
+		return &ADFNode{
+			Type:    "expand",
+			Attrs:   map[string]interface{}{"title": node.Parameters["title"]},
+			Content: c.adfBody(node.Body),
+		}
+	case node.Name == "code":
+		code := &ADFNode{Type: "codeBlock"}
+		if body := strings.Trim(node.Body, "\n"); body != "" {
+			code.Content = []*ADFNode{{Type: "text", Text: body}}
+		}
+		if lang := node.Parameters["language"]; lang != "" {
+			code.Attrs = map[string]interface{}{"language": lang}
+		}
+		return code
+	case node.Name == passthroughMacro:
+		params := make(map[string]string, len(node.Parameters))
+		for k, v := range node.Parameters {
+			if k != "name" && k != passthroughIDParam {
+				params[k] = v
+			}
+		}
+		ext := adfExtension(node.Parameters["name"], MacroType{}, params)
+		if id := node.Parameters[passthroughIDParam]; id != "" {
+			parameters := ext.Attrs["parameters"].(map[string]interface{})
+			parameters["macroMetadata"] = map[string]interface{}{"macroId": map[string]interface{}{"value": id}}
+		}
+		if m := richTextBodyPattern.FindStringSubmatch(node.Body); m != nil {
+			if markdown, err := FromConfluenceStorageWithOptions(m[1], ConvertOptions{ShowMacros: true}); err == nil {
+				ext.Type = "bodiedExtension"
+				ext.Content = c.adfBody(markdown)
+			}
+		}
+		return ext
+	}
+
+	ext := adfExtension(node.Name, macroType, node.Parameters)
+	switch {
+	case macroType.HasBody && macroType.BodyType == BodyTypeRichText:
+		ext.Type = "bodiedExtension"
+		ext.Content = c.adfBody(node.Body)
+	case macroType.Inline:
+		ext.Type = "inlineExtension"
+		delete(ext.Attrs, "layout")
+	}
+	return ext
+}
+
+// adfExtension returns an ADF extension node for a Confluence macro.
+func adfExtension(name string, macroType MacroType, params map[string]string) *ADFNode {
+	macroParams := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		macroParams[macroType.storageParam(k)] = map[string]interface{}{"value": v}
+	}
+	return &ADFNode{
+		Type: "extension",
+		Attrs: map[string]interface{}{
+			"extensionType": "com.atlassian.confluence.macro.core",
+			"extensionKey":  name,
+			"layout":        "default",
+			"parameters":    map[string]interface{}{"macroParams": macroParams},
+		},
+	}
+}
+
+// adfBody converts the markdown body of a macro to ADF blocks. ADF nodes
+// that hold blocks need at least one, so an empty body is a paragraph.
+func (c *adfConverter) adfBody(body string) []*ADFNode {
+	source := c.source
+	c.source = []byte(body)
+	blocks := c.convertChildren(adfParser.Parser().Parse(text.NewReader(c.source)))
+	c.source = source
+	if len(blocks) == 0 {
+		return []*ADFNode{{Type: "paragraph"}}
+	}
+	return blocks
+}
+
+// isInlineADF reports whether an ADF node belongs within a paragraph.
+func isInlineADF(n *ADFNode) bool {
+	switch n.Type {
+	case "inlineExtension", "inlineCard", "status", "text":
+		return true
+	}
+	return false
+}
+
+// macroBlock returns the block node of a paragraph or text block holding
+// nothing but a placeholder for a block macro, or nil.
+func (c *adfConverter) macroBlock(n ast.Node) *ADFNode {
+	t, ok := n.FirstChild().(*ast.Text)
+	if !ok || t.NextSibling() != nil {
+		return nil
+	}
+	value := strings.TrimSpace(string(t.Segment.Value(c.source)))
+	m := adfMacroPlaceholderPattern.FindStringSubmatch(value)
+	if m == nil || m[0] != value {
+		return nil
+	}
+	id, err := strconv.Atoi(m[1])
+	if err != nil || id >= len(c.macros) || isInlineADF(c.macros[id].node) {
+		return nil
+	}
+	return c.macros[id].node
+}
+
+// macroText splits text at macro placeholders into text and macro nodes.
+// Block macros placed inline are written as they were in markdown.
+func (c *adfConverter) macroText(s string, marks []*ADFMark) []*ADFNode {
+	var nodes []*ADFNode
+	addText := func(t string) {
+		if t == "" {
+			return
+		}
+		if strings.Contains(t, statusPlaceholderPrefix) {
+			nodes = append(nodes, c.statusText(t, marks)...)
+			return
+		}
+		nodes = append(nodes, &ADFNode{Type: "text", Text: t, Marks: marks})
+	}
+	last := 0
+	for _, m := range adfMacroPlaceholderPattern.FindAllStringSubmatchIndex(s, -1) {
+		id, err := strconv.Atoi(s[m[2]:m[3]])
+		if err != nil || id >= len(c.macros) {
+			continue
+		}
+		if macro := c.macros[id]; isInlineADF(macro.node) {
+			addText(s[last:m[0]])
+			nodes = append(nodes, macro.node)
+			last = m[1]
+			continue
+		}
+		s = s[:m[0]] + c.macros[id].markdown + s[m[1]:]
+		return append(nodes, c.macroText(s[last:], marks)...)
+	}
+	addText(s[last:])
+	return nodes
+}
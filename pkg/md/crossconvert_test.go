@@ -0,0 +1,208 @@
+package md
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToADF_BracketMacros(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "panel",
+			input:    "[WARNING]\nBe **careful**\n[/WARNING]",
+			expected: `[{"type":"panel","attrs":{"panelType":"warning"},"content":[{"type":"paragraph","content":[{"type":"text","text":"Be "},{"type":"text","text":"careful","marks":[{"type":"strong"}]}]}]}]`,
+		},
+		{
+			name:     "tip is a success panel",
+			input:    "[TIP]Hint[/TIP]",
+			expected: `[{"type":"panel","attrs":{"panelType":"success"},"content":[{"type":"paragraph","content":[{"type":"text","text":"Hint"}]}]}]`,
+		},
+		{
+			name:     "expand with nested panel",
+			input:    "[EXPAND title=More]\n[NOTE]Inside[/NOTE]\n[/EXPAND]",
+			expected: `[{"type":"expand","attrs":{"title":"More"},"content":[{"type":"panel","attrs":{"panelType":"note"},"content":[{"type":"paragraph","content":[{"type":"text","text":"Inside"}]}]}]}]`,
+		},
+		{
+			name:     "macro without body",
+			input:    "Intro\n\n[TOC maxLevel=2]",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"Intro"}]},{"type":"extension","attrs":{"extensionKey":"toc","extensionType":"com.atlassian.confluence.macro.core","layout":"default","parameters":{"macroParams":{"maxLevel":{"value":"2"}}}}}]`,
+		},
+		{
+			name:     "inline macro",
+			input:    "See [JIRA key=PROJ-1] now",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"See "},{"type":"inlineExtension","attrs":{"extensionKey":"jira","extensionType":"com.atlassian.confluence.macro.core","parameters":{"macroParams":{"key":{"value":"PROJ-1"}}}}},{"type":"text","text":" now"}]}]`,
+		},
+		{
+			name:     "macro in code stays as written",
+			input:    "```\n[INFO]x[/INFO]\n```",
+			expected: `[{"type":"codeBlock","content":[{"type":"text","text":"[INFO]x[/INFO]"}]}]`,
+		},
+		{
+			name:     "passed through macro",
+			input:    `[MACRO name=section]<ac:rich-text-body><p>Body</p></ac:rich-text-body>[/MACRO]`,
+			expected: `[{"type":"bodiedExtension","attrs":{"extensionKey":"section","extensionType":"com.atlassian.confluence.macro.core","layout":"default","parameters":{"macroParams":{}}},"content":[{"type":"paragraph","content":[{"type":"text","text":"Body"}]}]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToADF([]byte(tt.input))
+			require.NoError(t, err)
+
+			var doc ADFDocument
+			require.NoError(t, json.Unmarshal([]byte(result), &doc))
+			content, err := json.Marshal(doc.Content)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(content))
+		})
+	}
+}
+
+func TestToADF_BlockMacroWithinText(t *testing.T) {
+	result, err := ToADF([]byte("See [TOC] here"))
+	require.NoError(t, err)
+
+	var doc ADFDocument
+	require.NoError(t, json.Unmarshal([]byte(result), &doc))
+	require.Len(t, doc.Content, 1)
+	var text string
+	for _, n := range doc.Content[0].Content {
+		assert.Equal(t, "text", n.Type)
+		text += n.Text
+	}
+	assert.Equal(t, "See [TOC] here", text)
+}
+
+func TestStorageToADF(t *testing.T) {
+	storage := `<p>Intro</p>` +
+		`<ac:structured-macro ac:name="info"><ac:rich-text-body><p>Note this</p></ac:rich-text-body></ac:structured-macro>` +
+		`<ac:structured-macro ac:name="toc"><ac:parameter ac:name="maxLevel">3</ac:parameter></ac:structured-macro>` +
+		`<ac:structured-macro ac:name="expand"><ac:parameter ac:name="title">Details</ac:parameter><ac:rich-text-body><p>Hidden</p></ac:rich-text-body></ac:structured-macro>` +
+		`<ac:structured-macro ac:name="code"><ac:parameter ac:name="language">go</ac:parameter><ac:plain-text-body><![CDATA[x := "[TOC]"]]></ac:plain-text-body></ac:structured-macro>`
+
+	result, err := StorageToADF(storage)
+	require.NoError(t, err)
+
+	var doc ADFDocument
+	require.NoError(t, json.Unmarshal([]byte(result), &doc))
+	var types []string
+	for _, n := range doc.Content {
+		types = append(types, n.Type)
+	}
+	assert.Equal(t, []string{"paragraph", "panel", "extension", "expand", "codeBlock"}, types)
+	assert.Equal(t, "info", doc.Content[1].Attrs["panelType"])
+	assert.Equal(t, "toc", doc.Content[2].Attrs["extensionKey"])
+	assert.Equal(t, "Details", doc.Content[3].Attrs["title"])
+	assert.Equal(t, "go", doc.Content[4].Attrs["language"])
+	assert.Equal(t, `x := "[TOC]"`, doc.Content[4].Content[0].Text)
+}
+
+func TestADFToStorage(t *testing.T) {
+	adf := `{"type":"doc","version":1,"content":[
+		{"type":"panel","attrs":{"panelType":"success"},"content":[{"type":"paragraph","content":[{"type":"text","text":"Done"}]}]},
+		{"type":"expand","attrs":{"title":"More"},"content":[{"type":"paragraph","content":[{"type":"text","text":"Hidden"}]}]},
+		{"type":"extension","attrs":{"extensionKey":"toc","parameters":{"macroParams":{"maxLevel":{"value":"2"}}}}},
+		{"type":"bodiedExtension","attrs":{"extensionKey":"section","parameters":{"macroParams":{}}},"content":[{"type":"paragraph","content":[{"type":"text","text":"In section"}]}]},
+		{"type":"bodiedExtension","attrs":{"extensionKey":"roadmap","parameters":{"macroParams":{"title":{"value":"[Q1]"}}}},"content":[{"type":"paragraph","content":[{"type":"text","text":"Ends with [/MACRO] here"}]}]}
+	]}`
+
+	result, err := ADFToStorage(adf)
+	require.NoError(t, err)
+	assert.Contains(t, result, `<ac:structured-macro ac:name="tip" ac:schema-version="1"><ac:rich-text-body><p>Done</p>`)
+	assert.Contains(t, result, `<ac:structured-macro ac:name="expand" ac:schema-version="1"><ac:parameter ac:name="title">More</ac:parameter><ac:rich-text-body><p>Hidden</p>`)
+	assert.Contains(t, result, `<ac:structured-macro ac:name="toc" ac:schema-version="1"><ac:parameter ac:name="maxLevel">2</ac:parameter></ac:structured-macro>`)
+	assert.Contains(t, result, `<ac:structured-macro ac:name="section" ac:schema-version="1"><ac:rich-text-body><p>In section</p>`)
+	assert.Contains(t, result, `<ac:structured-macro ac:name="roadmap" ac:schema-version="1"><ac:parameter ac:name="title">[Q1]</ac:parameter><ac:rich-text-body><p>Ends with [/MACRO] here</p>`)
+}
+
+func TestStorageADFRoundTrip(t *testing.T) {
+	storage := `<h1>Title</h1>` +
+		`<ac:structured-macro ac:name="note"><ac:rich-text-body><p>Careful</p></ac:rich-text-body></ac:structured-macro>` +
+		`<ac:structured-macro ac:name="roadmap"><ac:parameter ac:name="theme">dark</ac:parameter></ac:structured-macro>` +
+		`<p>Status <ac:structured-macro ac:name="status"><ac:parameter ac:name="title">DONE</ac:parameter></ac:structured-macro></p>`
+
+	adf, err := StorageToADF(storage)
+	require.NoError(t, err)
+	result, err := ADFToStorage(adf)
+	require.NoError(t, err)
+
+	assert.Contains(t, result, `<h1>Title</h1>`)
+	assert.Contains(t, result, `<ac:structured-macro ac:name="note" ac:schema-version="1"><ac:rich-text-body><p>Careful</p>`)
+	assert.Contains(t, result, `<ac:structured-macro ac:name="roadmap" ac:schema-version="1"><ac:parameter ac:name="theme">dark</ac:parameter></ac:structured-macro>`)
+	assert.Contains(t, result, `<ac:parameter ac:name="title">DONE</ac:parameter>`)
+}
+
+func TestStorageADFRoundTrip_UnknownMacro(t *testing.T) {
+	storage := `<ac:structured-macro ac:name="weird-macro" ac:schema-version="1" ac:macro-id="abc">` +
+		`<ac:rich-text-body><p>hi [/MACRO] there [x] *star*</p></ac:rich-text-body></ac:structured-macro>`
+
+	adf, err := StorageToADF(storage)
+	require.NoError(t, err)
+
+	var doc ADFDocument
+	require.NoError(t, json.Unmarshal([]byte(adf), &doc))
+	require.Len(t, doc.Content, 1)
+	ext := doc.Content[0]
+	assert.Equal(t, "bodiedExtension", ext.Type)
+	assert.Equal(t, "abc", adfMacroID(ext))
+	assert.Equal(t, "hi [/MACRO] there [x] *star*", ext.Content[0].Content[0].Text)
+
+	result, err := ADFToStorage(adf)
+	require.NoError(t, err)
+	assert.Contains(t, result, `<ac:structured-macro ac:name="weird-macro" ac:schema-version="1" ac:macro-id="abc">`)
+	assert.Contains(t, result, `<p>hi [/MACRO] there [x] *star*</p>`)
+}
+
+func TestToADF_EscapedText(t *testing.T) {
+	result, err := ToADF([]byte(`a \[b\] \*c\* but ` + "`\\[code`"))
+	require.NoError(t, err)
+	assert.Contains(t, result, `"text":"a [b] *c* but "`)
+	assert.Contains(t, result, `"text":"\\[code"`, "code is kept as written")
+}
+
+func TestToConfluenceStorage_MacroInCode(t *testing.T) {
+	got, err := ToConfluenceStorage([]byte("Use `[TOC]` for contents:\n\n```\n[INFO]x[/INFO]\n```\n\n[TOC]"))
+	require.NoError(t, err)
+	assert.Contains(t, got, `<code>[TOC]</code>`)
+	assert.Contains(t, got, "<pre><code>[INFO]x[/INFO]\n</code></pre>")
+	assert.Contains(t, got, `<ac:structured-macro ac:name="toc"`)
+}
+
+func TestUnconvertedStorage(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "known macros convert",
+			input:    `<ac:structured-macro ac:name="info"><ac:rich-text-body><p>x</p></ac:rich-text-body></ac:structured-macro>`,
+			expected: []string{},
+		},
+		{
+			name:     "unknown macro with rich text body converts",
+			input:    `<ac:structured-macro ac:name="section"><ac:rich-text-body><p>x</p></ac:rich-text-body></ac:structured-macro>`,
+			expected: []string{},
+		},
+		{
+			name: "layout of four columns and unknown macro with plain text body",
+			input: `<ac:layout><ac:layout-section ac:type="four_equal"><ac:layout-cell><p>A</p></ac:layout-cell><ac:layout-cell><p>B</p></ac:layout-cell><ac:layout-cell><p>C</p></ac:layout-cell><ac:layout-cell><p>D</p></ac:layout-cell></ac:layout-section></ac:layout>` +
+				`<ac:structured-macro ac:name="html"><ac:plain-text-body><![CDATA[<b>x</b>]]></ac:plain-text-body></ac:structured-macro>` +
+				`<ac:structured-macro ac:name="html"><ac:plain-text-body><![CDATA[<i>y</i>]]></ac:plain-text-body></ac:structured-macro>`,
+			expected: []string{"layout", "macro html"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, UnconvertedStorage(tt.input))
+		})
+	}
+}
@@ -0,0 +1,240 @@
+// diff.go provides line diffs of markdown documents.
+package md
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// diffOp is one line of an edit script: kept (' '), removed ('-') or
+// added ('+').
+type diffOp struct {
+	kind byte
+	text string
+}
+
+// UnifiedDiff returns a unified diff turning from into to, with the file
+// headers labelled fromName and toName. It returns "" when they are equal.
+func UnifiedDiff(fromName, toName, from, to string) string {
+	ops := diffLines(splitLines(from), splitLines(to))
+
+	var sb strings.Builder
+	for _, h := range diffHunks(ops) {
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		sb.WriteString(h)
+	}
+	return sb.String()
+}
+
+// splitLines splits text into lines, without a final empty line for a
+// trailing newline.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines computes a shortest edit script from a to b with Myers'
+// algorithm, in its linear space form: the middle snake of an optimal path
+// splits the problem in two, which are solved in turn.
+func diffLines(a, b []string) []diffOp {
+	return appendDiff(nil, a, b)
+}
+
+// appendDiff appends the edit script from a to b to ops.
+func appendDiff(ops []diffOp, a, b []string) []diffOp {
+	// Common prefix and suffix lines are kept as they are
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	a, b = a[prefix:], b[prefix:]
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	kept := a[len(a)-suffix:]
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+
+	x, y, ok := middleSnake(a, b)
+	if ok && len(a) > 0 && len(b) > 0 {
+		ops = appendDiff(ops, a[:x], b[:y])
+		ops = appendDiff(ops, a[x:], b[y:])
+	} else {
+		for _, line := range a {
+			ops = append(ops, diffOp{'-', line})
+		}
+		for _, line := range b {
+			ops = append(ops, diffOp{'+', line})
+		}
+	}
+
+	for _, line := range kept {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+// middleSnake searches for an optimal path from a to b forwards from the
+// start and backwards from the end at once, returning the point where the
+// two meet. It returns false when a and b have no line in common.
+func middleSnake(a, b []string) (x, y int, ok bool) {
+	n, m := len(a), len(b)
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+	// The furthest x reached on each diagonal k = x-y, forwards from the
+	// start and backwards from the end
+	forward := make([]int, 2*maxD+3)
+	backward := make([]int, 2*maxD+3)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+
+	// Diagonals that have left the edit graph are skipped
+	var fStart, fEnd, bStart, bEnd int
+	delta := n - m
+	odd := delta%2 != 0
+	for d := 0; d < maxD; d++ {
+		for k := -d + fStart; k <= d-fEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && forward[k-1+offset] < forward[k+1+offset]) {
+				x = forward[k+1+offset]
+			} else {
+				x = forward[k-1+offset] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[k+offset] = x
+			switch {
+			case x > n:
+				fEnd += 2
+			case y > m:
+				fStart += 2
+			case odd:
+				if rk := delta - k + offset; rk >= 0 && rk < len(backward) && backward[rk] != -1 && x >= n-backward[rk] {
+					return x, y, true
+				}
+			}
+		}
+		for k := -d + bStart; k <= d-bEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && backward[k-1+offset] < backward[k+1+offset]) {
+				x = backward[k+1+offset]
+			} else {
+				x = backward[k-1+offset] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-1-x] == b[m-1-y] {
+				x++
+				y++
+			}
+			backward[k+offset] = x
+			switch {
+			case x > n:
+				bEnd += 2
+			case y > m:
+				bStart += 2
+			case !odd:
+				if fk := delta - k + offset; fk >= 0 && fk < len(forward) && forward[fk] != -1 && forward[fk] >= n-x {
+					fx := forward[fk]
+					return fx, fx - (fk - offset), true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// diffHunks groups an edit script into unified diff hunks, each change
+// surrounded by up to diffContext unchanged lines.
+func diffHunks(ops []diffOp) []string {
+	var hunks []string
+	fromLine, toLine := 1, 1 // line numbers at ops[i]
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			fromLine++
+			toLine++
+			i++
+			continue
+		}
+
+		// Back up over leading context
+		start := i
+		for start > 0 && i-start < diffContext && ops[start-1].kind == ' ' {
+			start--
+		}
+		fromStart, toStart := fromLine-(i-start), toLine-(i-start)
+
+		// Extend until a run of unchanged lines long enough to split on
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-end > 2*diffContext {
+				end = min(end+diffContext, run)
+				break
+			}
+			end = run
+		}
+
+		var body strings.Builder
+		fromCount, toCount := 0, 0
+		for _, op := range ops[start:end] {
+			body.WriteByte(op.kind)
+			body.WriteString(op.text)
+			body.WriteByte('\n')
+			if op.kind != '+' {
+				fromCount++
+			}
+			if op.kind != '-' {
+				toCount++
+			}
+		}
+		hunks = append(hunks, fmt.Sprintf("@@ -%s +%s @@\n%s",
+			hunkRange(fromStart, fromCount), hunkRange(toStart, toCount), body.String()))
+
+		// Advance line numbers past the hunk
+		for _, op := range ops[i:end] {
+			if op.kind != '+' {
+				fromLine++
+			}
+			if op.kind != '-' {
+				toLine++
+			}
+		}
+		i = end
+	}
+	return hunks
+}
+
+// hunkRange formats a hunk's line range as in GNU diff: an empty range is
+// given by the line before it, and a count of 1 is left out.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	default:
+		return fmt.Sprintf("%d,%d", start, count)
+	}
+}
@@ -0,0 +1,104 @@
+package md
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want string
+	}{
+		{
+			name: "equal",
+			from: "a\nb\n",
+			to:   "a\nb\n",
+			want: "",
+		},
+		{
+			name: "changed line",
+			from: "# Title\n\nold text\n",
+			to:   "# Title\n\nnew text\n",
+			want: "--- v1\n+++ v2\n@@ -1,3 +1,3 @@\n # Title\n \n-old text\n+new text\n",
+		},
+		{
+			name: "added to empty",
+			from: "",
+			to:   "hello\n",
+			want: "--- v1\n+++ v2\n@@ -0,0 +1 @@\n+hello\n",
+		},
+		{
+			name: "removed everything",
+			from: "a\nb\n",
+			to:   "",
+			want: "--- v1\n+++ v2\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+		{
+			name: "separate hunks",
+			from: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			to:   "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			want: "--- v1\n+++ v2\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+		{
+			name: "nearby changes share a hunk",
+			from: "1\n2\n3\n4\n5\n6\n",
+			to:   "one\n2\n3\n4\n5\nsix\n",
+			want: "--- v1\n+++ v2\n@@ -1,6 +1,6 @@\n-1\n+one\n 2\n 3\n 4\n 5\n-6\n+six\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UnifiedDiff("v1", "v2", tt.from, tt.to))
+		})
+	}
+}
+
+func TestDiffLines_Reconstructs(t *testing.T) {
+	a := strings.Split("a b c a b b a", " ")
+	b := strings.Split("c b a b a c", " ")
+
+	var from, to []string
+	edits := 0
+	for _, op := range diffLines(a, b) {
+		if op.kind != '+' {
+			from = append(from, op.text)
+		}
+		if op.kind != '-' {
+			to = append(to, op.text)
+		}
+		if op.kind != ' ' {
+			edits++
+		}
+	}
+	assert.Equal(t, a, from)
+	assert.Equal(t, b, to)
+	assert.Equal(t, 5, edits, "shortest edit script")
+}
+
+func TestDiffLines_Large(t *testing.T) {
+	a := make([]string, 100000)
+	b := make([]string, len(a))
+	for i := range a {
+		a[i] = fmt.Sprintf("line %d", i)
+		b[i] = a[i]
+		if i%100 == 0 {
+			b[i] = "changed"
+		}
+	}
+
+	edits := 0
+	for _, op := range diffLines(a, b) {
+		if op.kind != ' ' {
+			edits++
+		}
+	}
+	assert.Equal(t, 2000, edits)
+	assert.Empty(t, diffLines(nil, nil))
+}
@@ -0,0 +1,217 @@
+// embed.go embeds local files referenced from markdown as page attachments.
+package md
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// Viewer macros for embedded .drawio diagrams.
+const (
+	DiagramMacroDrawio = "drawio"
+	DiagramMacroGliffy = "gliffy"
+)
+
+// embeddableExts are the local file types LocalFileRefs reports.
+var embeddableExts = map[string]bool{
+	".drawio": true,
+	".svg":    true,
+	".png":    true,
+	".jpg":    true,
+	".jpeg":   true,
+	".gif":    true,
+	".webp":   true,
+	".bmp":    true,
+}
+
+// EmbedOptions controls how local file references are embedded.
+type EmbedOptions struct {
+	// DiagramMacro is the viewer macro for .drawio files: "drawio" (default) or "gliffy".
+	DiagramMacro string
+
+	// Attachments maps image destinations, exactly as written in the
+	// markdown, to the page attachments they were uploaded as. Images
+	// without an entry are converted as usual.
+	Attachments map[string]EmbeddedFile
+}
+
+// EmbeddedFile identifies an uploaded attachment.
+type EmbeddedFile struct {
+	Filename   string // Attachment filename on the page
+	FileID     string // Media file ID; ADF media nodes are only emitted when set
+	Collection string // Media collection, e.g. "contentId-12345"
+}
+
+// LocalFileRefs returns the destinations of markdown images that refer to
+// local files which should be uploaded and embedded as attachments (images
+// and .drawio diagrams), in document order without duplicates. Remote URLs
+// are ignored.
+func LocalFileRefs(markdown []byte) []string {
+	doc := adfParser.Parser().Parse(text.NewReader(markdown))
+
+	var refs []string
+	seen := make(map[string]bool)
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		img, ok := n.(*ast.Image)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		dest := string(img.Destination)
+		if !seen[dest] && isLocalRef(dest) && embeddableExts[strings.ToLower(path.Ext(dest))] {
+			seen[dest] = true
+			refs = append(refs, dest)
+		}
+		return ast.WalkContinue, nil
+	})
+	return refs
+}
+
+// isLocalRef reports whether an image destination is a local file path.
+func isLocalRef(dest string) bool {
+	if dest == "" || strings.HasPrefix(dest, "//") {
+		return false
+	}
+	u, err := url.Parse(dest)
+	// A single-letter scheme is a Windows drive letter (C:/...)
+	return err == nil && len(u.Scheme) <= 1
+}
+
+// isDiagram reports whether an embedded file should use the diagram viewer macro.
+func isDiagram(filename string) bool {
+	return strings.EqualFold(path.Ext(filename), ".drawio")
+}
+
+// diagramMacro returns the macro node that displays a diagram attachment.
+func (o EmbedOptions) diagramMacro(filename string) *MacroNode {
+	if o.DiagramMacro == DiagramMacroGliffy {
+		return &MacroNode{Name: DiagramMacroGliffy, Parameters: map[string]string{"name": filename}}
+	}
+	return &MacroNode{Name: DiagramMacroDrawio, Parameters: map[string]string{"diagramName": filename}}
+}
+
+// lookup finds the attachment for an image destination. Destinations are
+// tried as written and URL-decoded, since the converters may escape them.
+func (o EmbedOptions) lookup(dest string) (EmbeddedFile, bool) {
+	if f, ok := o.Attachments[dest]; ok {
+		return f, true
+	}
+	if decoded, err := url.PathUnescape(dest); err == nil {
+		if f, ok := o.Attachments[decoded]; ok {
+			return f, true
+		}
+	}
+	return EmbeddedFile{}, false
+}
+
+// ToConfluenceStorageWithEmbeds converts markdown to Confluence storage
+// format, replacing images that refer to uploaded attachments with
+// attachment images, or with the diagram viewer macro for .drawio files.
+func ToConfluenceStorageWithEmbeds(markdown []byte, opts EmbedOptions) (string, error) {
+	storage, err := ToConfluenceStorage(markdown)
+	if err != nil || len(opts.Attachments) == 0 {
+		return storage, err
+	}
+	return embedStorageImages(storage, opts), nil
+}
+
+var (
+	storageImgPattern  = regexp.MustCompile(`(<p>)?(<img\s[^>]*>)(</p>)?`)
+	storageAttrPattern = regexp.MustCompile(`\s(src|alt)="([^"]*)"`)
+)
+
+// embedStorageImages rewrites <img> tags produced by the markdown renderer.
+func embedStorageImages(storage string, opts EmbedOptions) string {
+	return storageImgPattern.ReplaceAllStringFunc(storage, func(match string) string {
+		groups := storageImgPattern.FindStringSubmatch(match)
+		open, tag, closing := groups[1], groups[2], groups[3]
+
+		var src, alt string
+		for _, m := range storageAttrPattern.FindAllStringSubmatch(tag, -1) {
+			if m[1] == "src" {
+				src = html.UnescapeString(m[2])
+			} else {
+				alt = html.UnescapeString(m[2])
+			}
+		}
+
+		file, ok := opts.lookup(src)
+		if !ok {
+			return match
+		}
+
+		// A diagram on its own line replaces the paragraph, since macros are block content
+		if isDiagram(file.Filename) {
+			macro := RenderMacroToXML(opts.diagramMacro(file.Filename))
+			if open != "" && closing != "" {
+				return macro
+			}
+			return open + macro + closing
+		}
+
+		return open + fmt.Sprintf(`<ac:image ac:alt="%s"><ri:attachment ri:filename="%s" /></ac:image>`,
+			escapeXML(alt), escapeXML(file.Filename)) + closing
+	})
+}
+
+// ToADFWithEmbeds converts markdown to ADF, replacing images that refer to
+// uploaded attachments with media nodes, or with the diagram viewer macro
+// for .drawio files. Attachments without a FileID keep the usual alt-text
+// rendering, since ADF media nodes must reference a media file ID.
+func ToADFWithEmbeds(markdown []byte, opts EmbedOptions) (string, error) {
+	return toADF(markdown, &opts)
+}
+
+// embedADFImage returns the block node for a paragraph consisting of a
+// single image that refers to an embedded attachment, or nil.
+func (o *EmbedOptions) embedADFImage(img *ast.Image, alt string) *ADFNode {
+	if o == nil {
+		return nil
+	}
+	file, ok := o.lookup(string(img.Destination))
+	if !ok {
+		return nil
+	}
+
+	if isDiagram(file.Filename) {
+		macro := o.diagramMacro(file.Filename)
+		params := make(map[string]interface{}, len(macro.Parameters))
+		for k, v := range macro.Parameters {
+			params[k] = map[string]interface{}{"value": v}
+		}
+		return &ADFNode{
+			Type: "extension",
+			Attrs: map[string]interface{}{
+				"extensionType": "com.atlassian.confluence.macro.core",
+				"extensionKey":  macro.Name,
+				"parameters":    map[string]interface{}{"macroParams": params},
+			},
+		}
+	}
+
+	if file.FileID == "" {
+		return nil
+	}
+	media := &ADFNode{
+		Type: "media",
+		Attrs: map[string]interface{}{
+			"type":       "file",
+			"id":         file.FileID,
+			"collection": file.Collection,
+		},
+	}
+	if alt != "" {
+		media.Attrs["alt"] = alt
+	}
+	return &ADFNode{
+		Type:    "mediaSingle",
+		Attrs:   map[string]interface{}{"layout": "center"},
+		Content: []*ADFNode{media},
+	}
+}
@@ -0,0 +1,131 @@
+package md
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalFileRefs(t *testing.T) {
+	input := "# Diagrams\n\n" +
+		"![flow](diagrams/flow.drawio)\n\n" +
+		"Inline ![arch](arch.SVG) and ![photo](photo.png).\n\n" +
+		"![notes](notes.txt) ![shot](img/screen%20shot.JPEG)\n\n" +
+		"![remote](https://example.com/remote.svg) ![proto](//cdn.example.com/x.svg)\n\n" +
+		"![again](diagrams/flow.drawio)\n\n" +
+		"[INFO]\n![nested](nested.svg)\n[/INFO]\n"
+
+	assert.Equal(t, []string{"diagrams/flow.drawio", "arch.SVG", "photo.png", "img/screen%20shot.JPEG", "nested.svg"}, LocalFileRefs([]byte(input)))
+}
+
+func TestToConfluenceStorageWithEmbeds(t *testing.T) {
+	attachments := map[string]EmbeddedFile{
+		"img/arch.svg":         {Filename: "arch.svg"},
+		"diagrams/flow.drawio": {Filename: "flow.drawio"},
+		"my diagram.svg":       {Filename: "my diagram.svg"},
+		"./img/screen.png":     {Filename: "screen.png"},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		macro    string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "svg becomes attachment image",
+			input:    "![Architecture](img/arch.svg)",
+			contains: []string{`<ac:image ac:alt="Architecture"><ri:attachment ri:filename="arch.svg" /></ac:image>`},
+			excludes: []string{"<img"},
+		},
+		{
+			name:     "raster image becomes attachment image",
+			input:    "See ![Screen](./img/screen.png) above",
+			contains: []string{`<p>See <ac:image ac:alt="Screen"><ri:attachment ri:filename="screen.png" /></ac:image> above</p>`},
+			excludes: []string{"<img"},
+		},
+		{
+			name:     "drawio uses drawio macro",
+			input:    "Intro\n\n![Flow](diagrams/flow.drawio)\n",
+			contains: []string{`<ac:structured-macro ac:name="drawio"`, `<ac:parameter ac:name="diagramName">flow.drawio</ac:parameter>`},
+			excludes: []string{"<img", "<p><ac:structured-macro"},
+		},
+		{
+			name:     "drawio uses gliffy macro",
+			input:    "![Flow](diagrams/flow.drawio)",
+			macro:    DiagramMacroGliffy,
+			contains: []string{`ac:name="gliffy"`, `<ac:parameter ac:name="name">flow.drawio</ac:parameter>`},
+		},
+		{
+			name:     "escaped destination",
+			input:    "![Mine](<my diagram.svg>)",
+			contains: []string{`ri:filename="my diagram.svg"`},
+		},
+		{
+			name:     "unlisted image unchanged",
+			input:    "![Other](other.svg)",
+			contains: []string{`<img src="other.svg" alt="Other">`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToConfluenceStorageWithEmbeds([]byte(tt.input), EmbedOptions{
+				DiagramMacro: tt.macro,
+				Attachments:  attachments,
+			})
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, got, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, got, s)
+			}
+		})
+	}
+}
+
+func TestToADFWithEmbeds(t *testing.T) {
+	opts := EmbedOptions{
+		Attachments: map[string]EmbeddedFile{
+			"arch.svg":    {Filename: "arch.svg", FileID: "file-1", Collection: "contentId-42"},
+			"flow.drawio": {Filename: "flow.drawio"},
+			"pending.svg": {Filename: "pending.svg"},
+		},
+	}
+
+	result, err := ToADFWithEmbeds([]byte("![Arch](arch.svg)\n\n![Flow](flow.drawio)\n\n![Pending](pending.svg)\n"), opts)
+	require.NoError(t, err)
+
+	var doc ADFDocument
+	require.NoError(t, json.Unmarshal([]byte(result), &doc))
+	require.Len(t, doc.Content, 3)
+
+	media := doc.Content[0]
+	assert.Equal(t, "mediaSingle", media.Type)
+	require.Len(t, media.Content, 1)
+	assert.Equal(t, "media", media.Content[0].Type)
+	assert.Equal(t, "file-1", media.Content[0].Attrs["id"])
+	assert.Equal(t, "contentId-42", media.Content[0].Attrs["collection"])
+	assert.Equal(t, "Arch", media.Content[0].Attrs["alt"])
+
+	ext := doc.Content[1]
+	assert.Equal(t, "extension", ext.Type)
+	assert.Equal(t, "drawio", ext.Attrs["extensionKey"])
+	assert.Contains(t, result, `"diagramName":{"value":"flow.drawio"}`)
+
+	// Without a media file ID the image falls back to alt text
+	assert.Equal(t, "paragraph", doc.Content[2].Type)
+	assert.Equal(t, "Pending", doc.Content[2].Content[0].Text)
+}
+
+func TestToADF_ImagesUnchangedWithoutEmbeds(t *testing.T) {
+	withEmbeds, err := ToADFWithEmbeds([]byte("![Arch](arch.svg)"), EmbedOptions{})
+	require.NoError(t, err)
+	plain, err := ToADF([]byte("![Arch](arch.svg)"))
+	require.NoError(t, err)
+	assert.Equal(t, plain, withEmbeds)
+}
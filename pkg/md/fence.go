@@ -0,0 +1,108 @@
+// fence.go rewrites fenced code blocks before conversion.
+package md
+
+import (
+	"strings"
+)
+
+// FencedBlock is a fenced code block found by ReplaceFencedBlocks.
+type FencedBlock struct {
+	Lang string // First word of the info string, e.g. "plantuml"
+	Info string // Full info string after the fence marker
+	Code string // Block content, without the fences
+}
+
+// ReplaceFencedBlocks calls replace for every fenced code block whose
+// language matches lang (case-insensitively) and substitutes the returned
+// markdown for the whole block, fences included. Blocks that are never
+// closed are left unchanged. An error from replace stops the scan.
+func ReplaceFencedBlocks(markdown []byte, lang string, replace func(FencedBlock) (string, error)) ([]byte, error) {
+	return replaceFences(markdown, func(b FencedBlock) bool { return strings.EqualFold(b.Lang, lang) }, replace)
+}
+
+// replaceFences calls replace for every fenced code block for which match
+// returns true, substituting the result for the whole block.
+func replaceFences(markdown []byte, match func(FencedBlock) bool, replace func(FencedBlock) (string, error)) ([]byte, error) {
+	lines := strings.SplitAfter(string(markdown), "\n")
+
+	var out strings.Builder
+	for i := 0; i < len(lines); i++ {
+		marker, info, ok := openingFence(lines[i])
+		if !ok {
+			out.WriteString(lines[i])
+			continue
+		}
+
+		end := closingFence(lines, i+1, marker)
+		if end < 0 {
+			// Unclosed fences run to the end of the document and are never replaced
+			for _, line := range lines[i:] {
+				out.WriteString(line)
+			}
+			break
+		}
+
+		block := FencedBlock{Info: info, Code: strings.Join(lines[i+1:end], "")}
+		if fields := strings.Fields(info); len(fields) > 0 {
+			block.Lang = fields[0]
+		}
+
+		if !match(block) {
+			for _, line := range lines[i : end+1] {
+				out.WriteString(line)
+			}
+		} else {
+			replacement, err := replace(block)
+			if err != nil {
+				return nil, err
+			}
+			out.WriteString(replacement)
+			if !strings.HasSuffix(replacement, "\n") && strings.HasSuffix(lines[end], "\n") {
+				out.WriteString("\n")
+			}
+		}
+		i = end
+	}
+
+	return []byte(out.String()), nil
+}
+
+// openingFence reports whether line opens a fenced code block, returning
+// its marker (e.g. "```" or "~~~~") and info string.
+func openingFence(line string) (marker, info string, ok bool) {
+	trimmed := strings.TrimRight(line, "\r\n")
+	indent := len(trimmed) - len(strings.TrimLeft(trimmed, " "))
+	if indent > 3 {
+		return "", "", false
+	}
+	trimmed = trimmed[indent:]
+
+	for _, ch := range []byte{'`', '~'} {
+		n := 0
+		for n < len(trimmed) && trimmed[n] == ch {
+			n++
+		}
+		if n < 3 {
+			continue
+		}
+		info = strings.TrimSpace(trimmed[n:])
+		if ch == '`' && strings.Contains(info, "`") {
+			return "", "", false
+		}
+		return trimmed[:n], info, true
+	}
+	return "", "", false
+}
+
+// closingFence returns the index of the line closing a block opened with
+// marker, searching from start, or -1 if the block is never closed.
+func closingFence(lines []string, start int, marker string) int {
+	for i := start; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if len(trimmed) >= len(marker) && strings.Trim(trimmed, marker[:1]) == "" &&
+			strings.HasPrefix(trimmed, marker) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,81 @@
+package md
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceFencedBlocks(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "replaces matching block",
+			input: "Before\n\n```plantuml\nA -> B\n```\n\nAfter\n",
+			want:  "Before\n\n[A -> B\n]\n\nAfter\n",
+		},
+		{
+			name:  "ignores other languages",
+			input: "```go\nfunc main() {}\n```\n",
+			want:  "```go\nfunc main() {}\n```\n",
+		},
+		{
+			name:  "case insensitive with attributes",
+			input: "~~~~PlantUML title=x\nA\n~~~~\n",
+			want:  "[A\n]\n",
+		},
+		{
+			name:  "longer closing fence",
+			input: "```plantuml\nA\n`````\n",
+			want:  "[A\n]\n",
+		},
+		{
+			name:  "nested fence text is content",
+			input: "````markdown\n```plantuml\nA\n```\n````\n",
+			want:  "````markdown\n```plantuml\nA\n```\n````\n",
+		},
+		{
+			name:  "unclosed block unchanged",
+			input: "```plantuml\nA\n",
+			want:  "```plantuml\nA\n",
+		},
+		{
+			name:  "block at end without newline",
+			input: "```plantuml\nA\n```",
+			want:  "[A\n]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReplaceFencedBlocks([]byte(tt.input), "plantuml", func(b FencedBlock) (string, error) {
+				return "[" + b.Code + "]", nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestReplaceFencedBlocks_BlockFields(t *testing.T) {
+	var got FencedBlock
+	_, err := ReplaceFencedBlocks([]byte("```plantuml format=png\n@startuml\n@enduml\n```\n"), "plantuml", func(b FencedBlock) (string, error) {
+		got = b
+		return "", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, FencedBlock{Lang: "plantuml", Info: "plantuml format=png", Code: "@startuml\n@enduml\n"}, got)
+}
+
+func TestReplaceFencedBlocks_Error(t *testing.T) {
+	_, err := ReplaceFencedBlocks([]byte("```plantuml\nA\n```\n"), "plantuml", func(FencedBlock) (string, error) {
+		return "", errors.New("render failed")
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "render failed")
+}
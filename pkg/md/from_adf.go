@@ -0,0 +1,537 @@
+package md
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// adfPanelMacros maps ADF panel types to the macros they are shown as.
+var adfPanelMacros = map[string]string{
+	"info":    "info",
+	"note":    "note",
+	"warning": "warning",
+	"error":   "warning",
+	"success": "tip",
+	"tip":     "tip",
+}
+
+// adfTextEscaper escapes characters that markdown would read as formatting.
+var adfTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+)
+
+// FromADF converts an Atlassian Document Format (ADF) JSON document to markdown.
+func FromADF(adf string) (string, error) {
+	return FromADFWithOptions(adf, ConvertOptions{})
+}
+
+// FromADFWithOptions converts an ADF JSON document to markdown with options.
+// Nodes with no markdown equivalent are reduced to their text content;
+// extensions (macros) are dropped unless ShowMacros is set, in which case
+// they are shown in bracket syntax.
+func FromADFWithOptions(adf string, opts ConvertOptions) (string, error) {
+	if strings.TrimSpace(adf) == "" {
+		return "", nil
+	}
+
+	input, err := runPreParse(DirectionFromADF, []byte(adf))
+	if err != nil {
+		return "", err
+	}
+
+	var doc ADFDocument
+	if err := json.Unmarshal(input, &doc); err != nil {
+		return "", fmt.Errorf("failed to parse ADF: %w", err)
+	}
+
+	w := &adfWriter{opts: opts}
+	return runPostRender(DirectionFromADF, strings.TrimSpace(w.blocks(doc.Content, "\n\n")))
+}
+
+// adfWriter renders ADF nodes as markdown.
+type adfWriter struct {
+	opts ConvertOptions
+}
+
+// blocks renders block nodes, separated by sep.
+func (w *adfWriter) blocks(nodes []*ADFNode, sep string) string {
+	var parts []string
+	for i := 0; i < len(nodes); i++ {
+		// A code block labeled by a bold paragraph is a code tab, as ToADF
+		// writes them
+		if title, ok := adfCodeTabTitle(nodes[i]); ok && i+1 < len(nodes) && nodes[i+1].Type == "codeBlock" {
+			i++
+			parts = append(parts, w.fencedCode(nodes[i], formatTabInfo(adfStringAttr(nodes[i], "language"), title)))
+			continue
+		}
+		if s := w.block(nodes[i]); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// adfCodeTabTitle returns the title of a code tab label: a paragraph of
+// nothing but one line of bold text.
+func adfCodeTabTitle(n *ADFNode) (string, bool) {
+	if n.Type != "paragraph" || len(n.Content) != 1 {
+		return "", false
+	}
+	t := n.Content[0]
+	if t.Type != "text" || len(t.Marks) != 1 || t.Marks[0].Type != "strong" {
+		return "", false
+	}
+	title := strings.TrimSpace(t.Text)
+	return title, title != "" && !strings.ContainsAny(title, "\n\"")
+}
+
+// block renders a single block node.
+func (w *adfWriter) block(n *ADFNode) string {
+	switch n.Type {
+	case "paragraph":
+		return w.inline(n.Content)
+	case "heading":
+		level := adfIntAttr(n, "level", 1)
+		if level < 1 || level > 6 {
+			level = 1
+		}
+		return strings.Repeat("#", level) + " " + w.inline(n.Content)
+	case "bulletList":
+		return w.list(n, func(int) string { return "- " })
+	case "orderedList":
+		start := adfIntAttr(n, "order", 1)
+		return w.list(n, func(i int) string { return strconv.Itoa(start+i) + ". " })
+	case "taskList":
+		return w.list(n, func(int) string { return "- " })
+	case "decisionList":
+		return w.list(n, func(int) string { return "- " })
+	case "codeBlock":
+		return w.codeBlock(n)
+	case "blockquote":
+		return prefixLines(w.blocks(n.Content, "\n\n"), "> ")
+	case "rule":
+		return "---"
+	case "table":
+		return w.table(n)
+	case "panel":
+		name := adfPanelMacros[adfStringAttr(n, "panelType")]
+		if name == "" {
+			name = "info"
+		}
+		body := w.blocks(n.Content, "\n\n")
+		if !w.opts.ShowMacros {
+			return body
+		}
+		return RenderMacroToBracket(&MacroNode{Name: name, Parameters: map[string]string{}, Body: "\n" + body + "\n"})
+	case "expand", "nestedExpand":
+		body := w.blocks(n.Content, "\n\n")
+		if w.opts.ShowMacros {
+			node := &MacroNode{Name: "expand", Parameters: map[string]string{}, Body: "\n" + body + "\n"}
+			if title := adfStringAttr(n, "title"); title != "" {
+				node.Parameters["title"] = title
+			}
+			return RenderMacroToBracket(node)
+		}
+		if title := adfStringAttr(n, "title"); title != "" {
+			return "**" + adfTextEscaper.Replace(title) + "**\n\n" + body
+		}
+		return body
+	case "layoutSection":
+		return w.adfLayoutSection(n)
+	case "mediaSingle", "mediaGroup":
+		return w.blocks(n.Content, "\n\n")
+	case "media":
+		return w.media(n)
+	case "extension", "bodiedExtension":
+		return w.extension(n, true)
+	case "blockCard", "embedCard":
+		if url := adfStringAttr(n, "url"); url != "" {
+			return "<" + url + ">"
+		}
+		return ""
+	default:
+		// Unknown block: keep whatever content it has
+		if len(n.Content) > 0 {
+			return w.blocks(n.Content, "\n\n")
+		}
+		return w.inline([]*ADFNode{n})
+	}
+}
+
+// list renders a list whose items are prefixed by marker(index).
+func (w *adfWriter) list(n *ADFNode, marker func(int) string) string {
+	var items []string
+	for i, item := range n.Content {
+		prefix := marker(i)
+		// Continuation lines are indented to the item's content
+		indent := strings.Repeat(" ", len(prefix))
+		var body string
+		switch item.Type {
+		case "taskItem":
+			check := "[ ] "
+			if adfStringAttr(item, "state") == "DONE" {
+				check = "[x] "
+			}
+			prefix += check
+			body = w.inline(item.Content)
+		case "decisionItem":
+			body = w.inline(item.Content)
+		case "taskList":
+			// A nested task list belongs to the item before it
+			if len(items) > 0 {
+				items[len(items)-1] += "\n" + indent + indentLines(w.block(item), indent)
+				continue
+			}
+			body = w.block(item)
+		default:
+			body = w.blocks(item.Content, "\n")
+		}
+		items = append(items, prefix+indentLines(body, indent))
+	}
+	return strings.Join(items, "\n")
+}
+
+// codeBlock renders a fenced code block in its language.
+func (w *adfWriter) codeBlock(n *ADFNode) string {
+	return w.fencedCode(n, adfStringAttr(n, "language"))
+}
+
+// fencedCode renders a code block as a fenced block with the given info
+// string, lengthening the fence if the code itself contains one.
+func (w *adfWriter) fencedCode(n *ADFNode, info string) string {
+	var code strings.Builder
+	for _, c := range n.Content {
+		code.WriteString(c.Text)
+	}
+	fence := "```"
+	for strings.Contains(code.String(), fence) {
+		fence += "`"
+	}
+	return fence + info + "\n" + code.String() + "\n" + fence
+}
+
+// table renders a GFM table. The first row is the header row. Merged
+// cells, column widths, header cells outside it and data cells in it lead
+// the text of their cells, as table.go describes.
+func (w *adfWriter) table(n *ADFNode) string {
+	var rows [][]string
+	cols := 0
+	widths := adfColumnWidths(n)
+	var grid tableGrid
+	for _, row := range n.Content {
+		var cells []string
+		grid.newRow()
+		for _, cell := range row.Content {
+			for grid.covered() {
+				cells = append(cells, "")
+				grid.col++
+			}
+			attrs := cellAttrs{
+				Colspan: max(adfIntAttr(cell, "colspan", 1), 1),
+				Rowspan: max(adfIntAttr(cell, "rowspan", 1), 1),
+				Header:  cell.Type == "tableHeader" && grid.row > 0,
+				Data:    cell.Type != "tableHeader" && grid.row == 0,
+			}
+			col := grid.place(attrs.Colspan, attrs.Rowspan)
+			if grid.row == 0 {
+				attrs.Widths = columnWidths(widths, col, attrs.Colspan)
+			}
+			text := w.blocks(cell.Content, " ")
+			text = strings.ReplaceAll(text, "\n", " ")
+			cells = append(cells, attrs.String()+strings.ReplaceAll(text, "|", `\|`))
+			for i := 1; i < attrs.Colspan; i++ {
+				cells = append(cells, "")
+			}
+		}
+		for grid.covered() {
+			cells = append(cells, "")
+			grid.col++
+		}
+		if len(cells) > cols {
+			cols = len(cells)
+		}
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 || cols == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		sb.WriteString("|")
+		for i := 0; i < cols; i++ {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString("\n")
+	}
+	writeRow(rows[0])
+	sb.WriteString("|" + strings.Repeat(" --- |", cols) + "\n")
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// media renders an image. Attachments are referenced by their media ID.
+func (w *adfWriter) media(n *ADFNode) string {
+	alt := adfTextEscaper.Replace(adfStringAttr(n, "alt"))
+	if url := adfStringAttr(n, "url"); url != "" {
+		return "![" + alt + "](" + url + ")"
+	}
+	if id := adfStringAttr(n, "id"); id != "" {
+		return "![" + alt + "](" + id + ")"
+	}
+	return ""
+}
+
+// extension renders a macro in bracket syntax when macros are shown.
+// Without ShowMacros, only the body of a bodied extension is kept.
+func (w *adfWriter) extension(n *ADFNode, block bool) string {
+	var body string
+	if block {
+		body = w.blocks(n.Content, "\n\n")
+	}
+	if !w.opts.ShowMacros {
+		return body
+	}
+
+	name := adfStringAttr(n, "extensionKey")
+	if name == "" {
+		return body
+	}
+	node := &MacroNode{Name: name, Parameters: adfMacroParams(n)}
+	if body != "" {
+		node.Body = "\n" + body + "\n"
+	}
+	runTransformMacro(DirectionFromADF, node)
+	if _, known := LookupMacro(node.Name); !known {
+		if id := adfMacroID(n); id != "" {
+			node.Parameters[passthroughIDParam] = id
+		}
+		return adfPassthrough(node, strings.TrimSpace(node.Body))
+	}
+	if node.Body == "" {
+		return RenderMacroToBracketOpen(node)
+	}
+	return RenderMacroToBracketOpen(node) + node.Body + "[/" + strings.ToUpper(node.Name) + "]"
+}
+
+// adfPassthrough renders an extension for a macro cfl has no syntax for as
+// a passthrough macro, with its body converted to storage format.
+func adfPassthrough(node *MacroNode, body string) string {
+	node.Parameters["name"] = node.Name
+	node.Name = passthroughMacro
+	if body != "" {
+		if storage, err := ToConfluenceStorage([]byte(body)); err == nil {
+			node.Body = escapePassthroughBody("<ac:rich-text-body>" + storage + "</ac:rich-text-body>")
+		}
+	}
+	return RenderMacroToBracket(node)
+}
+
+// inline renders inline nodes as a single markdown string.
+func (w *adfWriter) inline(nodes []*ADFNode) string {
+	var sb strings.Builder
+	for _, n := range nodes {
+		switch n.Type {
+		case "text":
+			sb.WriteString(w.text(n))
+		case "hardBreak":
+			sb.WriteString("\\\n")
+		case "mention":
+			text := adfStringAttr(n, "text")
+			if !strings.HasPrefix(text, "@") {
+				text = "@" + text
+			}
+			sb.WriteString(adfTextEscaper.Replace(text))
+		case "emoji":
+			text := adfStringAttr(n, "text")
+			if text == "" {
+				text = adfStringAttr(n, "shortName")
+			}
+			sb.WriteString(text)
+		case "date":
+			sb.WriteString(adfDate(adfStringAttr(n, "timestamp")))
+		case "status":
+			if w.opts.ShowMacros {
+				sb.WriteString(adfStatus(n))
+			} else {
+				sb.WriteString(adfTextEscaper.Replace(adfStringAttr(n, "text")))
+			}
+		case "inlineCard":
+			url := adfStringAttr(n, "url")
+			if link, ok := pageLinkFromURL(url); ok {
+				sb.WriteString(link.wikiLink(""))
+			} else if key, ok := jiraIssueKey(url); ok && w.opts.ShowMacros {
+				sb.WriteString(RenderMacroToBracket(&MacroNode{Name: "jira", Parameters: map[string]string{"key": key}}))
+			} else if url != "" {
+				sb.WriteString("<" + url + ">")
+			}
+		case "inlineExtension":
+			sb.WriteString(w.extension(n, false))
+		case "mediaInline":
+			sb.WriteString(w.media(n))
+		case "placeholder":
+			// Editor hint text, not content
+		default:
+			sb.WriteString(w.inline(n.Content))
+		}
+	}
+	return sb.String()
+}
+
+// text renders a text node with its marks. Whitespace is kept outside
+// emphasis, where markdown would not recognise it.
+func (w *adfWriter) text(n *ADFNode) string {
+	if n.Text == "" {
+		return ""
+	}
+
+	var code bool
+	var open, close []string
+	var href string
+	for _, m := range n.Marks {
+		switch m.Type {
+		case "code":
+			code = true
+		case "strong":
+			open, close = append(open, "**"), append([]string{"**"}, close...)
+		case "em":
+			open, close = append(open, "_"), append([]string{"_"}, close...)
+		case "strike":
+			open, close = append(open, "~~"), append([]string{"~~"}, close...)
+		case "link":
+			if m.Attrs != nil {
+				href, _ = m.Attrs["href"].(string)
+			}
+		}
+	}
+
+	core := strings.TrimSpace(n.Text)
+	if core == "" {
+		return n.Text
+	}
+	lead := n.Text[:strings.Index(n.Text, core)]
+	trail := n.Text[len(lead)+len(core):]
+
+	if code {
+		core = codeSpan(core)
+	} else {
+		core = adfTextEscaper.Replace(core)
+	}
+	core = strings.Join(open, "") + core + strings.Join(close, "")
+	if link, ok := pageLinkFromURL(href); ok {
+		core = link.wikiLink(core)
+	} else if href != "" {
+		core = "[" + core + "](" + linkDestination(href) + ")"
+	}
+	return lead + core + trail
+}
+
+// codeSpan wraps s in enough backticks to hold any it contains.
+func codeSpan(s string) string {
+	ticks := "`"
+	for strings.Contains(s, ticks) {
+		ticks += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return ticks + " " + s + " " + ticks
+	}
+	return ticks + s + ticks
+}
+
+// adfMacroParams returns the macro parameters of an extension node, by
+// their bracket syntax names.
+func adfMacroParams(n *ADFNode) map[string]string {
+	macroType, _ := LookupMacro(adfStringAttr(n, "extensionKey"))
+	params := make(map[string]string)
+	parameters, _ := n.Attrs["parameters"].(map[string]interface{})
+	macroParams, _ := parameters["macroParams"].(map[string]interface{})
+	keys := make([]string, 0, len(macroParams))
+	for k := range macroParams {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if v, ok := macroParams[k].(map[string]interface{}); ok {
+			if s, ok := v["value"].(string); ok && s != "" {
+				params[macroType.bracketParam(k)] = s
+			}
+		}
+	}
+	return params
+}
+
+// adfMacroID returns the macro ID an extension carries in its macro
+// metadata, as Confluence writes it, or "".
+func adfMacroID(n *ADFNode) string {
+	parameters, _ := n.Attrs["parameters"].(map[string]interface{})
+	metadata, _ := parameters["macroMetadata"].(map[string]interface{})
+	id, _ := metadata["macroId"].(map[string]interface{})
+	value, _ := id["value"].(string)
+	return value
+}
+
+// adfStringAttr returns a string attribute, or "".
+func adfStringAttr(n *ADFNode, key string) string {
+	s, _ := n.Attrs[key].(string)
+	return s
+}
+
+// adfIntAttr returns a numeric attribute, or def.
+func adfIntAttr(n *ADFNode, key string, def int) int {
+	switch v := n.Attrs[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	}
+	return def
+}
+
+// adfDate formats an ADF date timestamp (milliseconds since the epoch).
+func adfDate(timestamp string) string {
+	ms, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return timestamp
+	}
+	return time.UnixMilli(ms).UTC().Format("2006-01-02")
+}
+
+// prefixLines prefixes every line of s, trimming the prefix on blank lines.
+func prefixLines(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = strings.TrimRight(prefix, " ")
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentLines indents every line of s after the first.
+func indentLines(s, indent string) string {
+	lines := strings.Split(s, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,119 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromADF(t *testing.T) {
+	tests := []struct {
+		name     string
+		adf      string
+		expected string
+	}{
+		{
+			name:     "empty",
+			adf:      "",
+			expected: "",
+		},
+		{
+			name:     "heading and paragraph",
+			adf:      `{"type":"doc","version":1,"content":[{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Title"}]},{"type":"paragraph","content":[{"type":"text","text":"Hello world"}]}]}`,
+			expected: "## Title\n\nHello world",
+		},
+		{
+			name:     "marks keep whitespace outside",
+			adf:      `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"bold ","marks":[{"type":"strong"}]},{"type":"text","text":"code","marks":[{"type":"code"}]},{"type":"text","text":" and "},{"type":"text","text":"gone","marks":[{"type":"strike"}]}]}]}`,
+			expected: "**bold** `code` and ~~gone~~",
+		},
+		{
+			name:     "link",
+			adf:      `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://example.com"}}]}]}]}`,
+			expected: "[docs](https://example.com)",
+		},
+		{
+			name:     "escapes markdown characters",
+			adf:      `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"a_b *c* [d]"}]}]}`,
+			expected: `a\_b \*c\* \[d\]`,
+		},
+		{
+			name:     "nested lists",
+			adf:      `{"type":"doc","version":1,"content":[{"type":"orderedList","attrs":{"order":3},"content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"one"}]},{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"inner"}]}]}]}]},{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"two"}]}]}]}]}`,
+			expected: "3. one\n   - inner\n4. two",
+		},
+		{
+			name:     "task list",
+			adf:      `{"type":"doc","version":1,"content":[{"type":"taskList","content":[{"type":"taskItem","attrs":{"state":"DONE"},"content":[{"type":"text","text":"done"}]},{"type":"taskItem","attrs":{"state":"TODO"},"content":[{"type":"text","text":"todo"}]}]}]}`,
+			expected: "- [x] done\n- [ ] todo",
+		},
+		{
+			name:     "code block with fence inside",
+			adf:      "{\"type\":\"doc\",\"version\":1,\"content\":[{\"type\":\"codeBlock\",\"attrs\":{\"language\":\"md\"},\"content\":[{\"type\":\"text\",\"text\":\"```\\nx\\n```\"}]}]}",
+			expected: "````md\n```\nx\n```\n````",
+		},
+		{
+			name:     "blockquote and rule",
+			adf:      `{"type":"doc","version":1,"content":[{"type":"blockquote","content":[{"type":"paragraph","content":[{"type":"text","text":"a"}]},{"type":"paragraph","content":[{"type":"text","text":"b"}]}]},{"type":"rule"}]}`,
+			expected: "> a\n>\n> b\n\n---",
+		},
+		{
+			name:     "table",
+			adf:      `{"type":"doc","version":1,"content":[{"type":"table","content":[{"type":"tableRow","content":[{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"A"}]}]},{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"B"}]}]}]},{"type":"tableRow","content":[{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"1|2"}]}]},{"type":"tableCell","content":[{"type":"paragraph"}]}]}]}]}`,
+			expected: "| A | B |\n| --- | --- |\n| 1\\|2 |  |",
+		},
+		{
+			name:     "inline nodes",
+			adf:      `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"mention","attrs":{"text":"@Ann"}},{"type":"text","text":" on "},{"type":"date","attrs":{"timestamp":"1704067200000"}},{"type":"hardBreak"},{"type":"inlineCard","attrs":{"url":"https://example.com"}}]}]}`,
+			expected: "@Ann on 2024-01-01\\\n<https://example.com>",
+		},
+		{
+			name:     "panel keeps body",
+			adf:      `{"type":"doc","version":1,"content":[{"type":"panel","attrs":{"panelType":"warning"},"content":[{"type":"paragraph","content":[{"type":"text","text":"Careful"}]}]}]}`,
+			expected: "Careful",
+		},
+		{
+			name:     "extension dropped",
+			adf:      `{"type":"doc","version":1,"content":[{"type":"extension","attrs":{"extensionKey":"toc"}},{"type":"paragraph","content":[{"type":"text","text":"after"}]}]}`,
+			expected: "after",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromADF(tt.adf)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestFromADFWithOptions_ShowMacros(t *testing.T) {
+	adf := `{"type":"doc","version":1,"content":[
+		{"type":"extension","attrs":{"extensionKey":"toc","parameters":{"macroParams":{"maxLevel":{"value":"2"}}}}},
+		{"type":"panel","attrs":{"panelType":"info"},"content":[{"type":"paragraph","content":[{"type":"text","text":"Note this"}]}]}
+	]}`
+
+	got, err := FromADFWithOptions(adf, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Equal(t, "[TOC maxLevel=2]\n\n[INFO]\nNote this\n[/INFO]", got)
+}
+
+func TestFromADF_InvalidJSON(t *testing.T) {
+	_, err := FromADF("{not json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse ADF")
+}
+
+func TestFromADF_RoundTrip(t *testing.T) {
+	markdown := "# Title\n\nSome **bold** and _italic_ text with `code` and a [link](https://example.com).\n\n" +
+		"- one\n- two\n\n1. first\n2. second\n\n```go\nfmt.Println(1)\n```\n\n> quoted\n\n| A | B |\n| --- | --- |\n| 1 | 2 |"
+
+	adf, err := ToADF([]byte(markdown))
+	require.NoError(t, err)
+
+	got, err := FromADF(adf)
+	require.NoError(t, err)
+	assert.Equal(t, markdown, got)
+}
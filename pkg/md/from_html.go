@@ -3,6 +3,7 @@ package md
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -12,10 +13,15 @@ import (
 	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/table"
 )
 
-// ConvertOptions configures the HTML to markdown conversion.
+// ConvertOptions configures conversions.
 type ConvertOptions struct {
 	// ShowMacros shows placeholder text for Confluence macros instead of stripping them.
 	ShowMacros bool
+
+	// Deterministic makes storage format and ADF generated from markdown
+	// byte-stable, as CanonicalStorage and CanonicalADF describe, so that
+	// output only changes when the content does.
+	Deterministic bool
 }
 
 // Placeholder markers for macro brackets (avoid html-to-markdown escaping)
@@ -36,9 +42,39 @@ func FromConfluenceStorageWithOptions(html string, opts ConvertOptions) (string,
 		return "", nil
 	}
 
+	input, err := runPreParse(DirectionFromStorage, []byte(html))
+	if err != nil {
+		return "", err
+	}
+	html = string(input)
+
+	// Mark titled code blocks so their tab names survive as fence info strings
+	html, tabTitles := markCodeTabs(html)
+
+	// Layout sections become columns fences
+	html, layouts := replaceStorageLayouts(html)
+
+	// Macros without bracket syntax are passed through as written
+	var unknown []string
+	if opts.ShowMacros {
+		html, unknown = replaceUnknownMacros(html)
+	}
+
+	// Links to pages in macro parameters become SPACE:Title values
+	html = flattenPageParams(html)
+
 	// Process Confluence macros before conversion, get placeholders map
 	html, macroMap := processConfluenceMacrosWithPlaceholders(html, opts.ShowMacros)
 
+	// Links to pages become [[Title]] links
+	html, pageLinks := replaceStoragePageLinks(html)
+
+	// Task lists become lists of - [ ] items
+	html = replaceStorageTasks(html)
+
+	// Column widths and merged cells lead the text of table cells
+	html = markTableCells(html)
+
 	// Create converter with table support
 	conv := converter.NewConverter(
 		converter.WithPlugins(
@@ -55,9 +91,14 @@ func FromConfluenceStorageWithOptions(html string, opts ConvertOptions) (string,
 
 	// Replace placeholders with actual bracket syntax
 	markdown = replaceMacroPlaceholders(markdown, macroMap)
+	markdown = replaceCodeTabTitles(markdown, tabTitles)
+	markdown = restorePageLinks(markdown, pageLinks)
+	markdown = restoreTasks(markdown)
+	markdown = restoreLayouts(markdown, layouts)
+	markdown = restoreUnknownMacros(markdown, unknown)
 
 	// Clean up the output - trim whitespace
-	return strings.TrimSpace(markdown), nil
+	return runPostRender(DirectionFromStorage, strings.TrimSpace(markdown))
 }
 
 // macroPlaceholder stores the bracket syntax for a macro placeholder
@@ -66,9 +107,17 @@ type macroPlaceholder struct {
 	closeTag string // e.g., "[/INFO]" (empty for simple macros)
 }
 
-// replaceMacroPlaceholders replaces placeholder markers with actual bracket syntax
+// replaceMacroPlaceholders replaces placeholder markers with actual bracket syntax.
+// Higher IDs go first, so CFMACROOPEN1 doesn't match the start of CFMACROOPEN10.
 func replaceMacroPlaceholders(markdown string, macroMap map[int]macroPlaceholder) string {
-	for id, macro := range macroMap {
+	ids := make([]int, 0, len(macroMap))
+	for id := range macroMap {
+		ids = append(ids, id)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(ids)))
+
+	for _, id := range ids {
+		macro := macroMap[id]
 		openPlaceholder := fmt.Sprintf("%s%d", placeholderOpenPrefix, id)
 		closePlaceholder := fmt.Sprintf("%s%d", placeholderClosePrefix, id)
 
@@ -128,15 +177,28 @@ func (t *macroIDTracker) addMacrosWithPlaceholders(node *MacroNode, output *stri
 	currentID := t.nextID
 	t.nextID++
 
+	if node.Name == "status" {
+		statusFromStorage(node)
+	}
+	paramsFromStorage(node)
+	runTransformMacro(DirectionFromStorage, node)
+
 	// Create placeholder for this macro
 	placeholder := renderMacroToPlaceholders(node, currentID)
 	macroMap[currentID] = placeholder
 
+	// A block macro is set apart as a block of its own, so that it doesn't
+	// share a line with its neighbours
+	macroType, _ := LookupMacro(node.Name)
+	if !macroType.Inline {
+		output.WriteString("<div>")
+		defer output.WriteString("</div>")
+	}
+
 	// Add opening placeholder
 	output.WriteString(placeholderOpenPrefix + strconv.Itoa(currentID))
 
 	// If macro has body, process it
-	macroType, _ := LookupMacro(node.Name)
 	if macroType.HasBody {
 		if len(node.Children) > 0 {
 			// Body contains CFXMLCHILD markers where nested macros should appear.
@@ -187,26 +249,35 @@ func stripConfluenceMacros(html string) string {
 
 	var output strings.Builder
 	for _, seg := range result.Segments {
-		if seg.Type == SegmentText {
+		switch {
+		case seg.Type == SegmentText:
 			output.WriteString(seg.Text)
+		case seg.Macro.Name == "status":
+			// A status lozenge keeps its text
+			output.WriteString(seg.Macro.Parameters["title"])
+		case seg.Macro.Name == "jira":
+			// A Jira issue keeps its key
+			output.WriteString(seg.Macro.Parameters["key"])
 		}
-		// Macros are silently dropped
+		// Other macros are silently dropped
 	}
 	return output.String()
 }
 
+// Patterns for Confluence code macros and their language parameter.
+var (
+	codeBlockPattern    = regexp.MustCompile(`(?s)<ac:structured-macro[^>]*ac:name="code"[^>]*>(.*?)</ac:structured-macro>`)
+	codeLanguagePattern = regexp.MustCompile(`<ac:parameter[^>]*ac:name="language"[^>]*>([^<]*)</ac:parameter>`)
+)
+
 // convertCodeBlockMacros converts Confluence code macro elements to HTML pre/code elements.
 // This preserves code blocks when converting to markdown.
 func convertCodeBlockMacros(html string) string {
-	// Match code block macros - use (?s) flag for . to match newlines
 	// Confluence code blocks: <ac:structured-macro ac:name="code" ...>...</ac:structured-macro>
-	codeBlockPattern := regexp.MustCompile(`(?s)<ac:structured-macro[^>]*ac:name="code"[^>]*>(.*?)</ac:structured-macro>`)
-
 	return codeBlockPattern.ReplaceAllStringFunc(html, func(match string) string {
 		// Extract language parameter if present
 		// <ac:parameter ac:name="language">python</ac:parameter>
-		langPattern := regexp.MustCompile(`<ac:parameter[^>]*ac:name="language"[^>]*>([^<]*)</ac:parameter>`)
-		langMatch := langPattern.FindStringSubmatch(match)
+		langMatch := codeLanguagePattern.FindStringSubmatch(match)
 		language := ""
 		if len(langMatch) > 1 {
 			language = strings.TrimSpace(langMatch[1])
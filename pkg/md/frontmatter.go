@@ -0,0 +1,142 @@
+// frontmatter.go provides YAML front matter parsing and page excerpt rendering.
+package md
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatterDelimiter marks the start and end of a YAML front matter block.
+const frontMatterDelimiter = "---"
+
+// FrontMatter holds page metadata declared at the top of a markdown document.
+type FrontMatter struct {
+	// Title names the page when publishing a directory with page sync.
+	Title string `yaml:"title,omitempty"`
+
+	// Summary is published as the page excerpt (shown in listings and
+	// consumed by excerpt-include macros).
+	Summary string `yaml:"summary,omitempty"`
+
+	// ID, Space, Version and Labels describe the page a document was
+	// exported from, so that it can be published back to it.
+	ID      string   `yaml:"id,omitempty"`
+	Space   string   `yaml:"space,omitempty"`
+	Version int      `yaml:"version,omitempty"`
+	Labels  []string `yaml:"labels,omitempty"`
+}
+
+// Render formats the front matter as a YAML block, delimiters included.
+func (fm *FrontMatter) Render() (string, error) {
+	data, err := yaml.Marshal(fm)
+	if err != nil {
+		return "", fmt.Errorf("failed to render front matter: %w", err)
+	}
+	return frontMatterDelimiter + "\n" + string(data) + frontMatterDelimiter + "\n", nil
+}
+
+// frontMatterKeyPattern matches a line that starts a YAML mapping entry.
+var frontMatterKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+\s*:`)
+
+// ParseFrontMatter splits a leading YAML front matter block from markdown.
+// Returns a nil FrontMatter and the original input when no block is present.
+// A block counts as front matter only when it holds a YAML mapping (or
+// nothing), so a document that opens with a --- horizontal rule keeps it.
+func ParseFrontMatter(markdown []byte) (*FrontMatter, []byte, error) {
+	lines := strings.SplitAfter(string(markdown), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != frontMatterDelimiter {
+		return nil, markdown, nil
+	}
+
+	// Find the closing delimiter on its own line
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) != frontMatterDelimiter {
+			continue
+		}
+
+		var doc yaml.Node
+		if err := yaml.Unmarshal([]byte(strings.Join(lines[1:i], "")), &doc); err != nil {
+			if !looksLikeMapping(lines[1:i]) {
+				return nil, markdown, nil
+			}
+			return nil, markdown, fmt.Errorf("failed to parse front matter: %w", err)
+		}
+		var fm FrontMatter
+		if len(doc.Content) > 0 {
+			if doc.Content[0].Kind != yaml.MappingNode {
+				return nil, markdown, nil
+			}
+			if err := doc.Content[0].Decode(&fm); err != nil {
+				return nil, markdown, fmt.Errorf("failed to parse front matter: %w", err)
+			}
+		}
+		fm.Title = strings.TrimSpace(fm.Title)
+		fm.Summary = strings.TrimSpace(fm.Summary)
+		fm.ID = strings.TrimSpace(fm.ID)
+		fm.Space = strings.TrimSpace(fm.Space)
+
+		return &fm, []byte(strings.Join(lines[i+1:], "")), nil
+	}
+
+	return nil, markdown, nil
+}
+
+// looksLikeMapping reports whether the first line of a block, past blank
+// lines and comments, starts a YAML mapping entry. Invalid YAML in such a
+// block is reported rather than the block being taken for markdown.
+func looksLikeMapping(lines []string) bool {
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return frontMatterKeyPattern.MatchString(line)
+	}
+	return false
+}
+
+// ExcerptStorage renders summary text as a hidden excerpt macro in storage format.
+func ExcerptStorage(summary string) string {
+	return RenderMacroToXML(&MacroNode{
+		Name:       "excerpt",
+		Parameters: map[string]string{"hidden": "true"},
+		Body:       "<p>" + escapeXML(summary) + "</p>",
+	})
+}
+
+// PrependExcerptADF inserts a hidden excerpt extension at the start of an ADF document.
+func PrependExcerptADF(adf string, summary string) (string, error) {
+	var doc ADFDocument
+	if err := json.Unmarshal([]byte(adf), &doc); err != nil {
+		return "", fmt.Errorf("failed to parse ADF document: %w", err)
+	}
+
+	excerpt := &ADFNode{
+		Type: "bodiedExtension",
+		Attrs: map[string]interface{}{
+			"extensionType": "com.atlassian.confluence.macro.core",
+			"extensionKey":  "excerpt",
+			"layout":        "default",
+			"parameters": map[string]interface{}{
+				"macroParams": map[string]interface{}{
+					"hidden": map[string]interface{}{"value": "true"},
+				},
+			},
+		},
+		Content: []*ADFNode{{
+			Type:    "paragraph",
+			Content: []*ADFNode{{Type: "text", Text: summary}},
+		}},
+	}
+	doc.Content = append([]*ADFNode{excerpt}, doc.Content...)
+
+	result, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
@@ -0,0 +1,139 @@
+package md
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantSummary string
+		wantTitle   string
+		wantBody    string
+		wantFM      bool
+	}{
+		{
+			name:        "summary field",
+			input:       "---\nsummary: Short description\n---\n# Heading\n",
+			wantSummary: "Short description",
+			wantBody:    "# Heading\n",
+			wantFM:      true,
+		},
+		{
+			name:      "title field",
+			input:     "---\ntitle: \" User Guide \"\n---\nBody",
+			wantTitle: "User Guide",
+			wantBody:  "Body",
+			wantFM:    true,
+		},
+		{
+			name:     "no front matter",
+			input:    "# Heading\n\nBody",
+			wantBody: "# Heading\n\nBody",
+		},
+		{
+			name:     "unclosed block is content",
+			input:    "---\nsummary: nope\n# Heading",
+			wantBody: "---\nsummary: nope\n# Heading",
+		},
+		{
+			name:     "unknown keys ignored",
+			input:    "---\nauthor: someone\n---\nBody",
+			wantBody: "Body",
+			wantFM:   true,
+		},
+		{
+			name:     "leading horizontal rule",
+			input:    "---\n\nIntro paragraph.\n\n---\nBody",
+			wantBody: "---\n\nIntro paragraph.\n\n---\nBody",
+		},
+		{
+			name:     "leading horizontal rule around a list",
+			input:    "---\n* one\n* two\n---\nBody",
+			wantBody: "---\n* one\n* two\n---\nBody",
+		},
+		{
+			name:     "empty block",
+			input:    "---\n---\nBody",
+			wantBody: "Body",
+			wantFM:   true,
+		},
+		{
+			name:        "summary is trimmed",
+			input:       "---\nsummary: |\n  Multi word summary\n---\nBody",
+			wantSummary: "Multi word summary",
+			wantBody:    "Body",
+			wantFM:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm, body, err := ParseFrontMatter([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBody, string(body))
+			if !tt.wantFM {
+				assert.Nil(t, fm)
+				return
+			}
+			require.NotNil(t, fm)
+			assert.Equal(t, tt.wantSummary, fm.Summary)
+			assert.Equal(t, tt.wantTitle, fm.Title)
+		})
+	}
+}
+
+func TestParseFrontMatter_InvalidYAML(t *testing.T) {
+	_, _, err := ParseFrontMatter([]byte("---\nsummary: [unclosed\n---\nBody"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "front matter")
+}
+
+func TestFrontMatter_RenderRoundTrip(t *testing.T) {
+	fm := &FrontMatter{Title: "Runbook: deploys", ID: "12345", Space: "DEV", Version: 7, Labels: []string{"ops", "runbook"}}
+
+	rendered, err := fm.Render()
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntitle: 'Runbook: deploys'\nid: \"12345\"\nspace: DEV\nversion: 7\nlabels:\n    - ops\n    - runbook\n---\n", rendered)
+
+	parsed, body, err := ParseFrontMatter([]byte(rendered + "# Steps\n"))
+	require.NoError(t, err)
+	assert.Equal(t, fm, parsed)
+	assert.Equal(t, "# Steps\n", string(body))
+}
+
+func TestExcerptStorage(t *testing.T) {
+	result := ExcerptStorage("A & B")
+	assert.Equal(t,
+		`<ac:structured-macro ac:name="excerpt" ac:schema-version="1"><ac:parameter ac:name="hidden">true</ac:parameter><ac:rich-text-body><p>A &amp; B</p></ac:rich-text-body></ac:structured-macro>`,
+		result)
+}
+
+func TestPrependExcerptADF(t *testing.T) {
+	adf, err := ToADF([]byte("Hello"))
+	require.NoError(t, err)
+
+	result, err := PrependExcerptADF(adf, "Summary text")
+	require.NoError(t, err)
+
+	var doc ADFDocument
+	require.NoError(t, json.Unmarshal([]byte(result), &doc))
+	require.Len(t, doc.Content, 2)
+
+	excerpt := doc.Content[0]
+	assert.Equal(t, "bodiedExtension", excerpt.Type)
+	assert.Equal(t, "excerpt", excerpt.Attrs["extensionKey"])
+	require.Len(t, excerpt.Content, 1)
+	assert.Equal(t, "Summary text", excerpt.Content[0].Content[0].Text)
+	assert.Equal(t, "paragraph", doc.Content[1].Type)
+}
+
+func TestPrependExcerptADF_InvalidJSON(t *testing.T) {
+	_, err := PrependExcerptADF("not json", "Summary")
+	require.Error(t, err)
+}
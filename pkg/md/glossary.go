@@ -0,0 +1,214 @@
+// glossary.go links glossary terms to their definition pages.
+package md
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// glossaryExclusionPattern matches an occurrence excluded from linking: {!term}.
+var glossaryExclusionPattern = regexp.MustCompile(`\{!([^{}\n]+)\}`)
+
+// glossaryEdit replaces markdown[start:stop] with text.
+type glossaryEdit struct {
+	start, stop int
+	text        string
+}
+
+// LinkGlossaryTerms links the first occurrence of each glossary term in
+// markdown to its definition URL. Terms are matched as whole words;
+// abbreviations written entirely in capitals match case-sensitively and
+// other terms ignore case. Occurrences in headings, links, code and front
+// matter are not linked.
+//
+// Writing {!term} excludes an occurrence: it is published as plain text
+// and a later occurrence is linked instead.
+func LinkGlossaryTerms(markdown []byte, terms map[string]string) []byte {
+	_, body, err := ParseFrontMatter(markdown)
+	if err != nil {
+		return markdown
+	}
+	offset := len(markdown) - len(body)
+
+	patterns := glossaryPatterns(terms)
+
+	doc := adfParser.Parser().Parse(text.NewReader(body))
+
+	// Exclusions are unwrapped anywhere outside code, and mask their text
+	// from linking
+	var edits []glossaryEdit
+	var excluded [][]int
+	code := codeRanges(doc, body)
+	for _, m := range glossaryExclusionPattern.FindAllSubmatchIndex(body, -1) {
+		if overlaps(code, m[0], m[1]) {
+			continue
+		}
+		edits = append(edits, glossaryEdit{start: m[0], stop: m[1], text: string(body[m[2]:m[3]])})
+		excluded = append(excluded, m[:2])
+	}
+
+	linked := make(map[string]bool)
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		t, ok := n.(*ast.Text)
+		if !entering || !ok || !glossaryLinkable(t) {
+			return ast.WalkContinue, nil
+		}
+		seg := t.Segment
+		value := string(seg.Value(body))
+
+		var masked [][]int
+		for _, p := range patterns {
+			if linked[p.term] {
+				continue
+			}
+			for _, m := range p.re.FindAllStringSubmatchIndex(value, -1) {
+				start, stop := m[2], m[3]
+				if !wordBoundary(value, start, stop) || overlaps(masked, start, stop) ||
+					overlaps(excluded, seg.Start+start, seg.Start+stop) || overlaps(code, seg.Start+start, seg.Start+stop) {
+					continue
+				}
+				// A link after "!" would become an image
+				if at := seg.Start + start; at > 0 && body[at-1] == '!' {
+					continue
+				}
+				edits = append(edits, glossaryEdit{
+					start: seg.Start + start,
+					stop:  seg.Start + stop,
+					text:  "[" + EscapeLinkText(value[start:stop]) + "](" + linkDestination(p.url) + ")",
+				})
+				masked = append(masked, []int{start, stop})
+				linked[p.term] = true
+				break
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	if len(edits) == 0 {
+		return markdown
+	}
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	out := append([]byte{}, markdown[:offset]...)
+	last := 0
+	for _, e := range edits {
+		out = append(out, body[last:e.start]...)
+		out = append(out, e.text...)
+		last = e.stop
+	}
+	out = append(out, body[last:]...)
+	return out
+}
+
+// glossaryPattern matches one glossary term.
+type glossaryPattern struct {
+	term string
+	url  string
+	re   *regexp.Regexp
+}
+
+// glossaryPatterns compiles patterns for terms, longest first so that a
+// term containing a shorter one takes precedence.
+func glossaryPatterns(terms map[string]string) []glossaryPattern {
+	var patterns []glossaryPattern
+	for term, url := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" || url == "" {
+			continue
+		}
+		expr := `(` + regexp.QuoteMeta(term) + `)`
+		if strings.ToUpper(term) != term || strings.ToLower(term) == term {
+			expr = `(?i)` + expr
+		}
+		patterns = append(patterns, glossaryPattern{term: term, url: url, re: regexp.MustCompile(expr)})
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i].term) != len(patterns[j].term) {
+			return len(patterns[i].term) > len(patterns[j].term)
+		}
+		return patterns[i].term < patterns[j].term
+	})
+	return patterns
+}
+
+// codeRanges returns the source ranges of code blocks, code spans, raw HTML
+// and passed through macros in source, which doc was parsed from.
+func codeRanges(doc ast.Node, source []byte) [][]int {
+	ranges := passthroughRanges(source)
+	addLines := func(lines *text.Segments) {
+		for i := 0; i < lines.Len(); i++ {
+			seg := lines.At(i)
+			ranges = append(ranges, []int{seg.Start, seg.Stop})
+		}
+	}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.HTMLBlock:
+			addLines(node.Lines())
+			return ast.WalkSkipChildren, nil
+		case *ast.RawHTML:
+			addLines(node.Segments)
+		case *ast.CodeSpan:
+			for c := node.FirstChild(); c != nil; c = c.NextSibling() {
+				if t, ok := c.(*ast.Text); ok {
+					ranges = append(ranges, []int{t.Segment.Start, t.Segment.Stop})
+				}
+			}
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return ranges
+}
+
+// glossaryLinkable reports whether a text node may be linked: it must not
+// be inside a heading, link, image or code.
+func glossaryLinkable(n ast.Node) bool {
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		switch p.(type) {
+		case *ast.Heading, *ast.Link, *ast.Image, *ast.AutoLink, *ast.CodeSpan:
+			return false
+		}
+	}
+	return true
+}
+
+// wordBoundary reports whether s[start:stop] is not part of a longer word.
+func wordBoundary(s string, start, stop int) bool {
+	isWord := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' }
+	if start > 0 {
+		if r := []rune(s[:start]); isWord(r[len(r)-1]) {
+			return false
+		}
+	}
+	for _, r := range s[stop:] {
+		return !isWord(r)
+	}
+	return true
+}
+
+// overlaps reports whether [start, stop) overlaps any of the ranges.
+func overlaps(ranges [][]int, start, stop int) bool {
+	for _, r := range ranges {
+		if start < r[1] && r[0] < stop {
+			return true
+		}
+	}
+	return false
+}
+
+// linkDestination formats a URL as a markdown link destination.
+func linkDestination(url string) string {
+	if strings.ContainsAny(url, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(url) + ">"
+	}
+	return url
+}
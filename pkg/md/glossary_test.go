@@ -0,0 +1,73 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkGlossaryTerms(t *testing.T) {
+	terms := map[string]string{
+		"API":      "https://example.com/api",
+		"REST API": "https://example.com/rest",
+		"widget":   "https://example.com/widget",
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "first occurrence only",
+			input:    "The API and the API.",
+			expected: "The [API](https://example.com/api) and the API.",
+		},
+		{
+			name:     "longer term wins",
+			input:    "A REST API, then an API.",
+			expected: "A [REST API](https://example.com/rest), then an [API](https://example.com/api).",
+		},
+		{
+			name:     "whole words",
+			input:    "APIs and widgets, then the API and a Widget.",
+			expected: "APIs and widgets, then the [API](https://example.com/api) and a [Widget](https://example.com/widget).",
+		},
+		{
+			name:     "abbreviations are case-sensitive",
+			input:    "An api, an API.",
+			expected: "An api, an [API](https://example.com/api).",
+		},
+		{
+			name:     "exclusion",
+			input:    "The {!API} is an API.",
+			expected: "The API is an [API](https://example.com/api).",
+		},
+		{
+			name:     "skips headings, links and code",
+			input:    "# API\n\n[API](https://other) `API`\n\n```\nAPI\n```\n\nAPI",
+			expected: "# API\n\n[API](https://other) `API`\n\n```\nAPI\n```\n\n[API](https://example.com/api)",
+		},
+		{
+			name:     "skips front matter",
+			input:    "---\ntitle: API\n---\nAPI",
+			expected: "---\ntitle: API\n---\n[API](https://example.com/api)",
+		},
+		{
+			name:     "no terms found",
+			input:    "Nothing here.",
+			expected: "Nothing here.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, string(LinkGlossaryTerms([]byte(tt.input), terms)))
+		})
+	}
+}
+
+func TestLinkGlossaryTerms_EscapesDestination(t *testing.T) {
+	result := LinkGlossaryTerms([]byte("A widget."), map[string]string{"widget": "https://example.com/Widget (v2)"})
+	assert.Equal(t, "A [widget](<https://example.com/Widget (v2)>).", string(result))
+}
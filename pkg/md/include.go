@@ -0,0 +1,49 @@
+// include.go converts the page parameters of macros that include or list
+// pages: page="SPACE:Title" in bracket syntax, a link to the page in
+// storage format.
+package md
+
+import (
+	"regexp"
+	"strings"
+)
+
+// storagePageParamPattern matches a macro parameter linking to a page,
+// capturing its name and the attributes of its <ri:page>.
+var storagePageParamPattern = regexp.MustCompile(`(?s)<ac:parameter ac:name="([^"]*)">\s*<ac:link(?:\s[^>]*)?>\s*<ri:page\s([^>]*?)/?>(?:</ri:page>)?\s*</ac:link>\s*</ac:parameter>`)
+
+// pageParamXML returns the storage format parameter linking to the page
+// value names as SPACE:Title.
+func pageParamXML(name, value string) string {
+	link := parseWikiTarget(value)
+	var sb strings.Builder
+	sb.WriteString(`<ac:parameter ac:name="` + name + `"><ac:link><ri:page `)
+	if link.Space != "" {
+		sb.WriteString(`ri:space-key="` + escapeXML(link.Space) + `" `)
+	}
+	sb.WriteString(`ri:content-title="` + escapeXML(link.Title) + `" /></ac:link></ac:parameter>`)
+	return sb.String()
+}
+
+// flattenPageParams replaces links to pages in macro parameters with
+// parameters naming the page as SPACE:Title, which the macro tokenizer
+// reads like any other parameter.
+func flattenPageParams(storage string) string {
+	return storagePageParamPattern.ReplaceAllStringFunc(storage, func(param string) string {
+		groups := storagePageParamPattern.FindStringSubmatch(param)
+		var link PageLink
+		for _, attr := range riAttrPattern.FindAllStringSubmatch(groups[2], -1) {
+			switch attr[1] {
+			case "space-key":
+				link.Space = attr[2]
+			case "content-title":
+				link.Title = attr[2]
+			}
+		}
+		value := link.Title
+		if link.Space != "" {
+			value = link.Space + ":" + value
+		}
+		return `<ac:parameter ac:name="` + groups[1] + `">` + value + `</ac:parameter>`
+	})
+}
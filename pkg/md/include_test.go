@@ -0,0 +1,74 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToConfluenceStorage_Include(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "page in space",
+			input:    `[INCLUDE page="DEV:Release Notes"]`,
+			expected: `<ac:structured-macro ac:name="include" ac:schema-version="1"><ac:parameter ac:name=""><ac:link><ri:page ri:space-key="DEV" ri:content-title="Release Notes" /></ac:link></ac:parameter></ac:structured-macro>`,
+		},
+		{
+			name:     "page by title",
+			input:    `[INCLUDE page="Runbook: A & B"]`,
+			expected: `<ac:structured-macro ac:name="include" ac:schema-version="1"><ac:parameter ac:name=""><ac:link><ri:page ri:content-title="Runbook: A &amp; B" /></ac:link></ac:parameter></ac:structured-macro>`,
+		},
+		{
+			name:     "excerpt include",
+			input:    `[EXCERPT-INCLUDE page=DEV:Overview nopanel=true]`,
+			expected: `<ac:structured-macro ac:name="excerpt-include" ac:schema-version="1"><ac:parameter ac:name="nopanel">true</ac:parameter><ac:parameter ac:name=""><ac:link><ri:page ri:space-key="DEV" ri:content-title="Overview" /></ac:link></ac:parameter></ac:structured-macro>`,
+		},
+		{
+			name:     "excerpt",
+			input:    "[EXCERPT]\nThe **short** version.\n[/EXCERPT]",
+			expected: `<ac:structured-macro ac:name="excerpt" ac:schema-version="1"><ac:rich-text-body><p>The <strong>short</strong> version.</p>` + "\n" + `</ac:rich-text-body></ac:structured-macro>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToConfluenceStorage([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, trimNewline(got))
+		})
+	}
+}
+
+func TestFromConfluenceStorage_Include(t *testing.T) {
+	input := `<p>Intro</p><ac:structured-macro ac:name="include" ac:schema-version="1"><ac:parameter ac:name="">
+<ac:link><ri:page ri:space-key="DEV" ri:content-title="A &amp; B" /></ac:link></ac:parameter></ac:structured-macro>`
+
+	got, err := FromConfluenceStorageWithOptions(input, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Intro\n\n[INCLUDE page=\"DEV:A & B\"]", got)
+
+	got, err = FromConfluenceStorage(input)
+	require.NoError(t, err)
+	assert.Equal(t, "Intro", got)
+}
+
+func TestInclude_StorageRoundTrip(t *testing.T) {
+	input := "[EXCERPT]\n\nShared summary.\n\n[/EXCERPT]\n\nIncluded:\n\n[INCLUDE page=\"DEV:A & B\"]\n\nSummary:\n\n[EXCERPT-INCLUDE nopanel=true page=Overview]"
+	storage, err := ToConfluenceStorage([]byte(input))
+	require.NoError(t, err)
+	back, err := FromConfluenceStorageWithOptions(storage, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Equal(t, input, back)
+}
+
+func TestFromADF_Include(t *testing.T) {
+	adf := `{"type":"doc","version":1,"content":[{"type":"extension","attrs":{"extensionType":"com.atlassian.confluence.macro.core","extensionKey":"include","parameters":{"macroParams":{"":{"value":"DEV:Release Notes"}}}}}]}`
+	got, err := FromADFWithOptions(adf, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Equal(t, `[INCLUDE page="DEV:Release Notes"]`, got)
+}
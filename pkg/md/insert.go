@@ -0,0 +1,140 @@
+// insert.go inserts content into page bodies, at either end or in the
+// section under a heading.
+package md
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// InsertStorage inserts storage format content into a storage format body,
+// after its content, or with prepend before it. With a heading, content goes
+// at the end of the section under the heading, or with prepend right after
+// the heading; a section runs to the next heading of the same or a higher
+// level. Headings are matched by their text, ignoring case, at the top
+// level of the body or, in a page layout, of its cells.
+func InsertStorage(storage, content, heading string, prepend bool) (string, error) {
+	containers := storageContainers(storage)
+	if heading == "" {
+		pos := containers[len(containers)-1][1]
+		if prepend {
+			pos = containers[0][0]
+		}
+		return storage[:pos] + content + storage[pos:], nil
+	}
+
+	for _, c := range containers {
+		headings := storageHeadings(storage[c[0]:c[1]])
+		i := matchHeading(len(headings), heading, func(i int) string { return headings[i].title })
+		if i < 0 {
+			continue
+		}
+		pos := c[0] + headings[i].end
+		if !prepend {
+			pos = c[1]
+			for _, next := range headings[i+1:] {
+				if next.level <= headings[i].level {
+					pos = c[0] + next.start
+					break
+				}
+			}
+		}
+		return storage[:pos] + content + storage[pos:], nil
+	}
+	return "", fmt.Errorf("heading %q not found", heading)
+}
+
+// storageContainers returns the offsets of the content of each cell of a
+// page layout, or of the whole body if it isn't one.
+func storageContainers(storage string) [][2]int {
+	whole := [][2]int{{0, len(storage)}}
+	if !strings.HasPrefix(strings.TrimSpace(storage), "<ac:layout>") {
+		return whole
+	}
+	var cells [][2]int
+	start := -1
+	for _, m := range splitMarkupPattern.FindAllStringSubmatchIndex(storage, -1) {
+		if m[4] < 0 || storage[m[4]:m[5]] != "ac:layout-cell" || m[7] > m[6] {
+			continue
+		}
+		if m[3] > m[2] {
+			if start >= 0 {
+				cells = append(cells, [2]int{start, m[0]})
+				start = -1
+			}
+		} else {
+			start = m[1]
+		}
+	}
+	if len(cells) == 0 {
+		return whole
+	}
+	return cells
+}
+
+// InsertADF inserts the content of an ADF document into another, as
+// InsertStorage does for storage format.
+func InsertADF(adf, content, heading string, prepend bool) (string, error) {
+	doc := ADFDocument{Type: "doc", Version: 1}
+	if strings.TrimSpace(adf) != "" {
+		if err := json.Unmarshal([]byte(adf), &doc); err != nil {
+			return "", fmt.Errorf("failed to parse ADF document: %w", err)
+		}
+	}
+	var insert ADFDocument
+	if err := json.Unmarshal([]byte(content), &insert); err != nil {
+		return "", fmt.Errorf("failed to parse ADF content: %w", err)
+	}
+
+	if heading == "" {
+		if prepend {
+			return adfDocumentJSON(doc, append(insert.Content, doc.Content...))
+		}
+		return adfDocumentJSON(doc, append(doc.Content, insert.Content...))
+	}
+
+	// Headings are looked for at the top level and in layout columns
+	containers := []*[]*ADFNode{&doc.Content}
+	for _, n := range doc.Content {
+		if n.Type == "layoutSection" {
+			for _, col := range n.Content {
+				containers = append(containers, &col.Content)
+			}
+		}
+	}
+	for _, c := range containers {
+		nodes := *c
+		i := matchHeading(len(nodes), heading, func(i int) string {
+			if nodes[i].Type != "heading" {
+				return ""
+			}
+			return adfNodeText(nodes[i])
+		})
+		if i < 0 {
+			continue
+		}
+		pos := i + 1
+		if !prepend {
+			level := adfIntAttr(nodes[i], "level", 0)
+			for pos < len(nodes) && (nodes[pos].Type != "heading" || adfIntAttr(nodes[pos], "level", 0) > level) {
+				pos++
+			}
+		}
+		*c = append(nodes[:pos:pos], append(insert.Content, nodes[pos:]...)...)
+		return adfDocumentJSON(doc, doc.Content)
+	}
+	return "", fmt.Errorf("heading %q not found", heading)
+}
+
+// matchHeading returns the index of the first of n headings whose text is
+// heading, ignoring case and surrounding space, or -1.
+func matchHeading(n int, heading string, text func(int) string) int {
+	heading = strings.TrimSpace(heading)
+	for i := 0; i < n; i++ {
+		if t := strings.TrimSpace(text(i)); t != "" && strings.EqualFold(t, heading) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,149 @@
+package md
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertStorage(t *testing.T) {
+	storage := `<p>Intro</p><h2>Changelog</h2><p>Old</p><h3>Details</h3><p>More</p><h2>Other</h2><p>End</p>`
+
+	tests := []struct {
+		name     string
+		storage  string
+		heading  string
+		prepend  bool
+		expected string
+		wantErr  string
+	}{
+		{
+			name:     "append",
+			storage:  storage,
+			expected: storage + `<p>New</p>`,
+		},
+		{
+			name:     "prepend",
+			storage:  storage,
+			prepend:  true,
+			expected: `<p>New</p>` + storage,
+		},
+		{
+			name:     "append to section runs past subsections",
+			storage:  storage,
+			heading:  "changelog",
+			expected: `<p>Intro</p><h2>Changelog</h2><p>Old</p><h3>Details</h3><p>More</p><p>New</p><h2>Other</h2><p>End</p>`,
+		},
+		{
+			name:     "prepend to section",
+			storage:  storage,
+			heading:  "Changelog",
+			prepend:  true,
+			expected: `<p>Intro</p><h2>Changelog</h2><p>New</p><p>Old</p><h3>Details</h3><p>More</p><h2>Other</h2><p>End</p>`,
+		},
+		{
+			name:     "last section runs to the end",
+			storage:  storage,
+			heading:  "Other",
+			expected: storage + `<p>New</p>`,
+		},
+		{
+			name:     "heading in a layout cell",
+			storage:  `<ac:layout><ac:layout-section ac:type="two_equal"><ac:layout-cell><h2>Log</h2><p>Old</p></ac:layout-cell><ac:layout-cell><p>Side</p></ac:layout-cell></ac:layout-section></ac:layout>`,
+			heading:  "Log",
+			expected: `<ac:layout><ac:layout-section ac:type="two_equal"><ac:layout-cell><h2>Log</h2><p>Old</p><p>New</p></ac:layout-cell><ac:layout-cell><p>Side</p></ac:layout-cell></ac:layout-section></ac:layout>`,
+		},
+		{
+			name:     "append to a layout goes in its last cell",
+			storage:  `<ac:layout><ac:layout-section ac:type="single"><ac:layout-cell><p>Only</p></ac:layout-cell></ac:layout-section></ac:layout>`,
+			expected: `<ac:layout><ac:layout-section ac:type="single"><ac:layout-cell><p>Only</p><p>New</p></ac:layout-cell></ac:layout-section></ac:layout>`,
+		},
+		{
+			name:    "heading in a macro isn't matched",
+			storage: `<ac:structured-macro ac:name="info"><ac:rich-text-body><h2>Hidden</h2></ac:rich-text-body></ac:structured-macro>`,
+			heading: "Hidden",
+			wantErr: `heading "Hidden" not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := InsertStorage(tt.storage, `<p>New</p>`, tt.heading, tt.prepend)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestInsertADF(t *testing.T) {
+	doc := `{"type":"doc","version":1,"content":[
+		{"type":"paragraph","content":[{"type":"text","text":"Intro"}]},
+		{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Changelog"}]},
+		{"type":"paragraph","content":[{"type":"text","text":"Old"}]},
+		{"type":"heading","attrs":{"level":3},"content":[{"type":"text","text":"Details"}]},
+		{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Other"}]},
+		{"type":"layoutSection","content":[
+			{"type":"layoutColumn","attrs":{"width":50},"content":[{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Log"}]}]},
+			{"type":"layoutColumn","attrs":{"width":50},"content":[{"type":"paragraph","content":[{"type":"text","text":"Side"}]}]}
+		]}
+	]}`
+	content := `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"New"}]}]}`
+
+	// texts returns the text of each top-level node, and of the nodes of
+	// the first layout column
+	texts := func(adf string) ([]string, []string) {
+		var d ADFDocument
+		require.NoError(t, json.Unmarshal([]byte(adf), &d))
+		var top, column []string
+		for _, n := range d.Content {
+			top = append(top, adfNodeText(n))
+			if n.Type == "layoutSection" {
+				for _, c := range n.Content[0].Content {
+					column = append(column, adfNodeText(c))
+				}
+			}
+		}
+		return top, column
+	}
+
+	tests := []struct {
+		name       string
+		heading    string
+		prepend    bool
+		wantTop    []string
+		wantColumn []string
+	}{
+		{"append", "", false, []string{"Intro", "Changelog", "Old", "Details", "Other", "Log Side", "New"}, []string{"Log"}},
+		{"prepend", "", true, []string{"New", "Intro", "Changelog", "Old", "Details", "Other", "Log Side"}, []string{"Log"}},
+		{"append to section", "Changelog", false, []string{"Intro", "Changelog", "Old", "Details", "New", "Other", "Log Side"}, []string{"Log"}},
+		{"prepend to section", "CHANGELOG", true, []string{"Intro", "Changelog", "New", "Old", "Details", "Other", "Log Side"}, []string{"Log"}},
+		{"section in a layout column", "Log", false, []string{"Intro", "Changelog", "Old", "Details", "Other", "Log New Side"}, []string{"Log", "New"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := InsertADF(doc, content, tt.heading, tt.prepend)
+			require.NoError(t, err)
+			top, column := texts(result)
+			assert.Equal(t, tt.wantTop, top)
+			assert.Equal(t, tt.wantColumn, column)
+		})
+	}
+
+	_, err := InsertADF(doc, content, "Missing", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `heading "Missing" not found`)
+}
+
+func TestInsertADF_EmptyPage(t *testing.T) {
+	result, err := InsertADF("", `{"type":"doc","version":1,"content":[{"type":"paragraph"}]}`, "", false)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"doc","version":1,"content":[{"type":"paragraph"}]}`, result)
+}
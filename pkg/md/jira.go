@@ -0,0 +1,158 @@
+// jira.go links Jira issues: [JIRA:PROJ-123], [JIRA key=PROJ-123] and,
+// for configured projects, bare issue keys become the Jira issue macro in
+// storage format and smart links (inlineCards) in ADF.
+package md
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// Patterns for Jira issue references.
+var (
+	// jiraMacroPattern matches a Jira macro in bracket syntax, either the
+	// [JIRA:KEY] shorthand, capturing the key, or [JIRA key=KEY ...].
+	jiraMacroPattern = regexp.MustCompile(`(?i)\[JIRA(?::([A-Z][A-Z0-9_]*-\d+)|\s[^\]\n]*)\]`)
+
+	// jiraKeyPattern matches a Jira issue key, capturing its project.
+	jiraKeyPattern = regexp.MustCompile(`\b([A-Z][A-Z0-9_]*)-\d+\b`)
+
+	// jiraBrowsePattern matches the URL of a Jira issue, capturing its key.
+	jiraBrowsePattern = regexp.MustCompile(`^https?://[^/?#]+(?:/[^?#]*)?/browse/([A-Z][A-Z0-9_]*-\d+)/?$`)
+)
+
+// JiraOptions configures LinkJiraIssues.
+type JiraOptions struct {
+	// Projects are the keys of the Jira projects whose bare issue keys,
+	// such as PROJ-123, are linked. Empty links only [JIRA:...] macros.
+	Projects []string
+
+	// URL is the Jira site, such as https://example.atlassian.net. When
+	// set, issues become links to it, which ADF shows as smart links;
+	// otherwise they become Jira macros for storage format.
+	URL string
+
+	// Server and ServerID name the Jira application link Jira macros use;
+	// empty for the site's default.
+	Server   string
+	ServerID string
+}
+
+// LinkJiraIssues links the Jira issues in markdown: [JIRA:KEY] and
+// [JIRA key=KEY] macros, and bare keys of opts.Projects written as whole
+// words outside headings, links and code. Issues become [JIRA key=KEY]
+// macros, or links to opts.URL when it is set.
+func LinkJiraIssues(markdown []byte, opts JiraOptions) []byte {
+	_, body, err := ParseFrontMatter(markdown)
+	if err != nil {
+		return markdown
+	}
+	offset := len(markdown) - len(body)
+
+	doc := adfParser.Parser().Parse(text.NewReader(body))
+	code := codeRanges(doc, body)
+
+	var edits []glossaryEdit
+	var macros [][]int
+	for _, m := range jiraMacroPattern.FindAllSubmatchIndex(body, -1) {
+		if overlaps(code, m[0], m[1]) {
+			continue
+		}
+		macros = append(macros, m[:2])
+		params := jiraMacroParams(string(body[m[0]:m[1]]))
+		if params["key"] == "" {
+			continue
+		}
+		edits = append(edits, glossaryEdit{start: m[0], stop: m[1], text: jiraIssue(params, opts)})
+	}
+
+	projects := make(map[string]bool, len(opts.Projects))
+	for _, p := range opts.Projects {
+		projects[strings.ToUpper(strings.TrimSpace(p))] = true
+	}
+	if len(projects) > 0 {
+		_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+			t, ok := n.(*ast.Text)
+			if !entering || !ok || !glossaryLinkable(t) {
+				return ast.WalkContinue, nil
+			}
+			seg := t.Segment
+			value := string(seg.Value(body))
+			for _, m := range jiraKeyPattern.FindAllStringSubmatchIndex(value, -1) {
+				start, stop := seg.Start+m[0], seg.Start+m[1]
+				if !projects[value[m[2]:m[3]]] || overlaps(macros, start, stop) || overlaps(code, start, stop) {
+					continue
+				}
+				// Keys within URLs and paths stay as written
+				if start > 0 && strings.ContainsRune("/-.#=", rune(body[start-1])) {
+					continue
+				}
+				edits = append(edits, glossaryEdit{start: start, stop: stop, text: jiraIssue(map[string]string{"key": value[m[0]:m[1]]}, opts)})
+			}
+			return ast.WalkContinue, nil
+		})
+	}
+	if len(edits) == 0 {
+		return markdown
+	}
+	return append(append([]byte{}, markdown[:offset]...), applyEdits(body, edits)...)
+}
+
+// jiraMacroParams returns the parameters of a Jira macro in bracket
+// syntax, with the key of the [JIRA:KEY] shorthand as "key".
+func jiraMacroParams(tag string) map[string]string {
+	if m := jiraMacroPattern.FindStringSubmatch(tag); m != nil && m[1] != "" {
+		return map[string]string{"key": strings.ToUpper(m[1])}
+	}
+	tokens, err := TokenizeBrackets(tag)
+	if err != nil || len(tokens) != 1 || tokens[0].Parameters == nil {
+		return map[string]string{}
+	}
+	return tokens[0].Parameters
+}
+
+// jiraIssue returns the markdown for a Jira issue: a link to it when
+// opts.URL is set, otherwise a Jira macro naming opts' server.
+func jiraIssue(params map[string]string, opts JiraOptions) string {
+	if opts.URL != "" {
+		return "<" + strings.TrimSuffix(opts.URL, "/") + "/browse/" + params["key"] + ">"
+	}
+	node := &MacroNode{Name: "jira", Parameters: params}
+	if _, ok := params["server"]; !ok && opts.Server != "" {
+		params["server"] = opts.Server
+	}
+	if _, ok := params["serverId"]; !ok && opts.ServerID != "" {
+		params["serverId"] = opts.ServerID
+	}
+	return RenderMacroToBracket(node)
+}
+
+// preprocessJiraShorthand rewrites [JIRA:KEY] outside code as the
+// [JIRA key=KEY] macro.
+func preprocessJiraShorthand(markdown []byte) []byte {
+	matches := jiraMacroPattern.FindAllSubmatchIndex(markdown, -1)
+	if len(matches) == 0 {
+		return markdown
+	}
+	code := codeRanges(adfParser.Parser().Parse(text.NewReader(markdown)), markdown)
+	var edits []glossaryEdit
+	for _, m := range matches {
+		if m[2] < 0 || overlaps(code, m[0], m[1]) {
+			continue
+		}
+		edits = append(edits, glossaryEdit{start: m[0], stop: m[1], text: "[JIRA key=" + strings.ToUpper(string(markdown[m[2]:m[3]])) + "]"})
+	}
+	return applyEdits(markdown, edits)
+}
+
+// jiraIssueKey returns the issue key of a Jira issue URL.
+func jiraIssueKey(url string) (string, bool) {
+	m := jiraBrowsePattern.FindStringSubmatch(url)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
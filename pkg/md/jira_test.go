@@ -0,0 +1,124 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkJiraIssues(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     JiraOptions
+		expected string
+	}{
+		{
+			name:     "shorthand",
+			input:    "Fixed in [JIRA:proj-12].",
+			expected: "Fixed in [JIRA key=PROJ-12].",
+		},
+		{
+			name:     "bare keys of configured projects",
+			input:    "See PROJ-1 and OPS-2, not ABC-3.",
+			opts:     JiraOptions{Projects: []string{"proj", "OPS"}},
+			expected: "See [JIRA key=PROJ-1] and [JIRA key=OPS-2], not ABC-3.",
+		},
+		{
+			name:     "server added to macros",
+			input:    "[JIRA key=PROJ-1] and [JIRA key=PROJ-2 server=Other]",
+			opts:     JiraOptions{Server: "System JIRA", ServerID: "abc-123"},
+			expected: "[JIRA key=PROJ-1 server=\"System JIRA\" serverId=abc-123] and [JIRA key=PROJ-2 server=Other serverId=abc-123]",
+		},
+		{
+			name:     "links with URL",
+			input:    "[JIRA:PROJ-1] and PROJ-2",
+			opts:     JiraOptions{Projects: []string{"PROJ"}, URL: "https://example.atlassian.net/"},
+			expected: "<https://example.atlassian.net/browse/PROJ-1> and <https://example.atlassian.net/browse/PROJ-2>",
+		},
+		{
+			name:     "not in headings, links, code or URLs",
+			input:    "# PROJ-1\n\n[PROJ-2](https://x) `PROJ-3` https://x/browse/PROJ-4 XPROJ-5 PROJ-6a\n\n```\n[JIRA:PROJ-7]\n```",
+			opts:     JiraOptions{Projects: []string{"PROJ"}},
+			expected: "# PROJ-1\n\n[PROJ-2](https://x) `PROJ-3` https://x/browse/PROJ-4 XPROJ-5 PROJ-6a\n\n```\n[JIRA:PROJ-7]\n```",
+		},
+		{
+			name:     "front matter unchanged",
+			input:    "---\ntitle: PROJ-1\n---\nPROJ-1",
+			opts:     JiraOptions{Projects: []string{"PROJ"}},
+			expected: "---\ntitle: PROJ-1\n---\n[JIRA key=PROJ-1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LinkJiraIssues([]byte(tt.input), tt.opts)
+			assert.Equal(t, tt.expected, string(got))
+		})
+	}
+}
+
+func TestToConfluenceStorage_Jira(t *testing.T) {
+	got, err := ToConfluenceStorage([]byte("Fixed in [JIRA:PROJ-12] and [JIRA key=PROJ-13 serverId=abc]."))
+	require.NoError(t, err)
+	assert.Equal(t, `<p>Fixed in <ac:structured-macro ac:name="jira" ac:schema-version="1"><ac:parameter ac:name="key">PROJ-12</ac:parameter></ac:structured-macro> and <ac:structured-macro ac:name="jira" ac:schema-version="1"><ac:parameter ac:name="key">PROJ-13</ac:parameter><ac:parameter ac:name="serverId">abc</ac:parameter></ac:structured-macro>.</p>`, trimNewline(got))
+}
+
+func TestFromConfluenceStorage_Jira(t *testing.T) {
+	input := `<p>Fixed in <ac:structured-macro ac:name="jira" ac:schema-version="1"><ac:parameter ac:name="server">System JIRA</ac:parameter><ac:parameter ac:name="key">PROJ-12</ac:parameter></ac:structured-macro>.</p>`
+
+	got, err := FromConfluenceStorageWithOptions(input, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Equal(t, `Fixed in [JIRA key=PROJ-12 server="System JIRA"].`, got)
+
+	// Without macros the issue's key remains
+	got, err = FromConfluenceStorage(input)
+	require.NoError(t, err)
+	assert.Equal(t, "Fixed in PROJ-12.", got)
+}
+
+func TestJira_StorageRoundTrip(t *testing.T) {
+	input := "Fixed in [JIRA key=PROJ-12 serverId=abc]."
+	storage, err := ToConfluenceStorage([]byte(input))
+	require.NoError(t, err)
+	back, err := FromConfluenceStorageWithOptions(storage, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Equal(t, input, back)
+}
+
+func TestToADF_JiraInlineCard(t *testing.T) {
+	adf, err := ToADF([]byte("Fixed in <https://example.atlassian.net/browse/PROJ-12> and <https://example.com/x>."))
+	require.NoError(t, err)
+	assert.Contains(t, adf, `{"type":"inlineCard","attrs":{"url":"https://example.atlassian.net/browse/PROJ-12"}}`)
+	assert.Contains(t, adf, `"href":"https://example.com/x"`)
+
+	back, err := FromADFWithOptions(adf, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Fixed in [JIRA key=PROJ-12] and [https://example.com/x](https://example.com/x).", back)
+
+	back, err = FromADF(adf)
+	require.NoError(t, err)
+	assert.Equal(t, "Fixed in <https://example.atlassian.net/browse/PROJ-12> and [https://example.com/x](https://example.com/x).", back)
+}
+
+func TestJiraIssueKey(t *testing.T) {
+	tests := []struct {
+		url    string
+		want   string
+		wantOK bool
+	}{
+		{"https://example.atlassian.net/browse/PROJ-12", "PROJ-12", true},
+		{"https://jira.example.com/jira/browse/OPS_2-7/", "OPS_2-7", true},
+		{"https://example.atlassian.net/browse/PROJ-12?focusedId=1", "", false},
+		{"https://example.atlassian.net/wiki/spaces/DEV", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, ok := jiraIssueKey(tt.url)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,344 @@
+// layout.go converts page layouts. Each layout section is a columns fence
+// in markdown, its cells separated by +++ lines:
+//
+//	::: columns two_left_sidebar
+//	Sidebar
+//	+++
+//	Main content
+//	:::
+//
+// The section type is optional and defaults by the number of cells. In
+// storage format the sections are <ac:layout-section>s of an <ac:layout>,
+// which holds the whole page; in ADF they are layoutSection nodes.
+package md
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Placeholder markers for layouts (avoid markdown and html-to-markdown escaping)
+const (
+	layoutSectionPlaceholderPrefix = "CFLAYOUTSECTION"
+	layoutSectionPlaceholderSuffix = "END"
+	layoutCellPlaceholder          = "CFLAYOUTCELLEND"
+	layoutClosePlaceholder         = "CFLAYOUTCLOSEEND"
+)
+
+// adfMaxLayoutColumns is the most columns an ADF layout section holds.
+const adfMaxLayoutColumns = 3
+
+// layoutDefaultTypes are the section types implied by the number of cells.
+var layoutDefaultTypes = map[int]string{
+	1: "single",
+	2: "two_equal",
+	3: "three_equal",
+}
+
+// layoutColumnWidths are the ADF column widths, in percent, of section types.
+var layoutColumnWidths = map[string][]float64{
+	"single":              {100},
+	"two_equal":           {50, 50},
+	"two_left_sidebar":    {33.33, 66.66},
+	"two_right_sidebar":   {66.66, 33.33},
+	"three_equal":         {33.33, 33.33, 33.33},
+	"three_with_sidebars": {25, 50, 25},
+}
+
+// Patterns for layouts.
+var (
+	// layoutOpenPattern matches the line opening a columns fence,
+	// capturing the section type.
+	layoutOpenPattern = regexp.MustCompile(`^:::\s*columns(?:\s+(\S+))?\s*$`)
+
+	// layoutCellPattern matches the line separating the cells of a section.
+	layoutCellPattern = regexp.MustCompile(`^\+\+\+\s*$`)
+
+	// layoutClosePattern matches the line closing a columns fence.
+	layoutClosePattern = regexp.MustCompile(`^:::\s*$`)
+
+	// storageLayoutTagPattern matches the layout tags of storage format.
+	storageLayoutTagPattern = regexp.MustCompile(`<(/?)ac:layout(-section|-cell)?\b([^>]*?)(/?)>`)
+
+	// storageLayoutTypePattern matches the type of a layout section.
+	storageLayoutTypePattern = regexp.MustCompile(`\sac:type="([^"]*)"`)
+
+	// layoutPlaceholderPattern matches the placeholders of a section in HTML,
+	// with the paragraphs around them.
+	layoutPlaceholderPattern = regexp.MustCompile(`(?:<p>)?(` + layoutSectionPlaceholderPrefix + `\d+` + layoutSectionPlaceholderSuffix + `|` + layoutCellPlaceholder + `|` + layoutClosePlaceholder + `)(?:</p>)?`)
+)
+
+// layoutSection is a section of a page layout.
+type layoutSection struct {
+	Type  string   // Section type, e.g. two_equal
+	Cells []string // Markdown of each cell
+}
+
+// sectionType returns the section's type, or the default for its cells.
+func (s layoutSection) sectionType() string {
+	if s.Type != "" {
+		return s.Type
+	}
+	if t, ok := layoutDefaultTypes[len(s.Cells)]; ok {
+		return t
+	}
+	return layoutDefaultTypes[1]
+}
+
+// replaceLayouts calls replace for every closed columns fence outside
+// fenced code and substitutes the result for it, fences included.
+func replaceLayouts(markdown []byte, replace func(layoutSection) string) []byte {
+	if !bytes.Contains(markdown, []byte(":::")) {
+		return markdown
+	}
+	lines := strings.SplitAfter(string(markdown), "\n")
+
+	var out strings.Builder
+	for i := 0; i < len(lines); i++ {
+		if marker, _, ok := openingFence(lines[i]); ok {
+			end := closingFence(lines, i+1, marker)
+			if end < 0 {
+				end = len(lines) - 1
+			}
+			out.WriteString(strings.Join(lines[i:end+1], ""))
+			i = end
+			continue
+		}
+		m := layoutOpenPattern.FindStringSubmatch(strings.TrimRight(lines[i], "\r\n"))
+		if m == nil {
+			out.WriteString(lines[i])
+			continue
+		}
+
+		section := layoutSection{Type: m[1]}
+		var cell strings.Builder
+		end := -1
+		for j := i + 1; j < len(lines) && end < 0; j++ {
+			line := strings.TrimRight(lines[j], "\r\n")
+			if marker, _, ok := openingFence(lines[j]); ok {
+				stop := closingFence(lines, j+1, marker)
+				if stop < 0 {
+					break
+				}
+				cell.WriteString(strings.Join(lines[j:stop+1], ""))
+				j = stop
+				continue
+			}
+			switch {
+			case layoutCellPattern.MatchString(line):
+				section.Cells = append(section.Cells, cell.String())
+				cell.Reset()
+			case layoutClosePattern.MatchString(line):
+				section.Cells = append(section.Cells, cell.String())
+				end = j
+			default:
+				cell.WriteString(lines[j])
+			}
+		}
+		if end < 0 {
+			// Unclosed fences are left as written
+			out.WriteString(lines[i])
+			continue
+		}
+
+		out.WriteString(replace(section))
+		if strings.HasSuffix(lines[end], "\n") {
+			out.WriteString("\n")
+		}
+		i = end
+	}
+	return []byte(out.String())
+}
+
+// preprocessLayouts replaces columns fences with placeholders for the tags
+// of layout sections, returning the type of each section.
+func preprocessLayouts(markdown []byte) ([]byte, []string) {
+	var types []string
+	processed := replaceLayouts(markdown, func(s layoutSection) string {
+		id := len(types)
+		types = append(types, s.sectionType())
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "\n%s%d%s\n\n", layoutSectionPlaceholderPrefix, id, layoutSectionPlaceholderSuffix)
+		sb.WriteString(strings.Join(s.Cells, "\n"+layoutCellPlaceholder+"\n\n"))
+		sb.WriteString("\n" + layoutClosePlaceholder + "\n")
+		return sb.String()
+	})
+	return processed, types
+}
+
+// postprocessLayouts replaces layout placeholders with the tags of layout
+// sections. A layout holds the whole page, so content between sections
+// becomes single-cell sections of its own.
+func postprocessLayouts(html string, types []string) string {
+	if len(types) == 0 {
+		return html
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<ac:layout>")
+	loose := func(content string) {
+		if strings.TrimSpace(content) != "" {
+			sb.WriteString(`<ac:layout-section ac:type="single"><ac:layout-cell>` + content + `</ac:layout-cell></ac:layout-section>`)
+		}
+	}
+	last, inSection := 0, false
+	for _, m := range layoutPlaceholderPattern.FindAllStringSubmatchIndex(html, -1) {
+		placeholder := html[m[2]:m[3]]
+		switch {
+		case strings.HasPrefix(placeholder, layoutSectionPlaceholderPrefix):
+			id, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(placeholder, layoutSectionPlaceholderPrefix), layoutSectionPlaceholderSuffix))
+			if err != nil || id >= len(types) {
+				continue
+			}
+			loose(html[last:m[0]])
+			sb.WriteString(`<ac:layout-section ac:type="` + escapeXML(types[id]) + `"><ac:layout-cell>`)
+			inSection = true
+		case !inSection:
+			continue
+		case placeholder == layoutCellPlaceholder:
+			sb.WriteString(html[last:m[0]] + `</ac:layout-cell><ac:layout-cell>`)
+		default:
+			sb.WriteString(html[last:m[0]] + `</ac:layout-cell></ac:layout-section>`)
+			inSection = false
+		}
+		last = m[1]
+	}
+	loose(html[last:])
+	sb.WriteString("</ac:layout>")
+	return sb.String()
+}
+
+// replaceStorageLayouts replaces the tags of layout sections in storage
+// format with placeholder paragraphs, returning the type of each section.
+func replaceStorageLayouts(storage string) (string, []layoutSection) {
+	if !strings.Contains(storage, "<ac:layout") {
+		return storage, nil
+	}
+	var sections []layoutSection
+	cells := 0
+	out := storageLayoutTagPattern.ReplaceAllStringFunc(storage, func(tag string) string {
+		m := storageLayoutTagPattern.FindStringSubmatch(tag)
+		closing, kind := m[1] == "/", m[2]
+		switch {
+		case kind == "-section" && !closing:
+			section := layoutSection{}
+			if t := storageLayoutTypePattern.FindStringSubmatch(m[3]); t != nil {
+				section.Type = t[1]
+			}
+			sections = append(sections, section)
+			cells = 0
+			return fmt.Sprintf("<p>%s%d%s</p>", layoutSectionPlaceholderPrefix, len(sections)-1, layoutSectionPlaceholderSuffix)
+		case kind == "-section":
+			return "<p>" + layoutClosePlaceholder + "</p>"
+		case kind == "-cell" && !closing && len(sections) > 0:
+			cells++
+			sections[len(sections)-1].Cells = make([]string, cells)
+			if cells > 1 {
+				return "<p>" + layoutCellPlaceholder + "</p>"
+			}
+		}
+		return ""
+	})
+	return out, sections
+}
+
+// restoreLayouts replaces layout placeholders in markdown with columns
+// fences. Section types that are the default for their cells are omitted.
+func restoreLayouts(markdown string, sections []layoutSection) string {
+	if len(sections) == 0 {
+		return markdown
+	}
+	return layoutPlaceholderPattern.ReplaceAllStringFunc(markdown, func(placeholder string) string {
+		switch placeholder {
+		case layoutCellPlaceholder:
+			return "+++"
+		case layoutClosePlaceholder:
+			return ":::"
+		}
+		id, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(placeholder, layoutSectionPlaceholderPrefix), layoutSectionPlaceholderSuffix))
+		if err != nil || id >= len(sections) {
+			return placeholder
+		}
+		s := sections[id]
+		if s.Type == "" || s.Type == layoutDefaultTypes[len(s.Cells)] {
+			return "::: columns"
+		}
+		return "::: columns " + s.Type
+	})
+}
+
+// preprocessADFLayouts replaces columns fences with placeholders for ADF
+// layout sections. Sections with one cell, or more cells than ADF layouts
+// have columns, become their cells' content.
+func preprocessADFLayouts(markdown []byte, c *adfConverter) []byte {
+	return replaceLayouts(markdown, func(s layoutSection) string {
+		if len(s.Cells) < 2 || len(s.Cells) > adfMaxLayoutColumns {
+			return strings.Join(s.Cells, "\n")
+		}
+		widths := layoutColumnWidths[s.sectionType()]
+		if len(widths) != len(s.Cells) {
+			widths = nil
+		}
+		section := &ADFNode{Type: "layoutSection"}
+		for i, cell := range s.Cells {
+			width := math.Round(10000/float64(len(s.Cells))) / 100
+			if widths != nil {
+				width = widths[i]
+			}
+			section.Content = append(section.Content, &ADFNode{
+				Type:    "layoutColumn",
+				Attrs:   map[string]interface{}{"width": width},
+				Content: c.adfBody(cell),
+			})
+		}
+		id := len(c.macros)
+		c.macros = append(c.macros, adfMacro{node: section})
+		return fmt.Sprintf("\n%s%d%s\n", adfMacroPlaceholderPrefix, id, adfMacroPlaceholderSuffix)
+	})
+}
+
+// adfLayoutSection renders an ADF layout section as a columns fence.
+func (w *adfWriter) adfLayoutSection(n *ADFNode) string {
+	var widths []float64
+	var cells []string
+	for _, col := range n.Content {
+		width, _ := col.Attrs["width"].(float64)
+		widths = append(widths, width)
+		cells = append(cells, w.blocks(col.Content, "\n\n"))
+	}
+
+	fence := "::: columns"
+	if t := adfLayoutType(widths); t != "" && t != layoutDefaultTypes[len(widths)] {
+		fence += " " + t
+	}
+	return fence + "\n\n" + strings.Join(cells, "\n\n+++\n\n") + "\n\n:::"
+}
+
+// adfLayoutType returns the section type whose columns are closest to
+// widths, or "" if no type has that many columns or a width is missing.
+func adfLayoutType(widths []float64) string {
+	for _, w := range widths {
+		if w <= 0 {
+			return ""
+		}
+	}
+	best, bestDiff := "", math.Inf(1)
+	for t, w := range layoutColumnWidths {
+		if len(w) != len(widths) {
+			continue
+		}
+		diff := 0.0
+		for i := range w {
+			diff += math.Abs(w[i] - widths[i])
+		}
+		// Ties go to the first type by name, so that the choice is stable
+		if diff < bestDiff || (diff == bestDiff && t < best) {
+			best, bestDiff = t, diff
+		}
+	}
+	return best
+}
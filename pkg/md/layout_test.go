@@ -0,0 +1,176 @@
+package md
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromConfluenceStorage_Layouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "sidebar section keeps its type",
+			input:    `<ac:layout><ac:layout-section ac:type="two_left_sidebar"><ac:layout-cell><p>Side</p></ac:layout-cell><ac:layout-cell><h2>Main</h2><p>Body</p></ac:layout-cell></ac:layout-section></ac:layout>`,
+			expected: "::: columns two_left_sidebar\n\nSide\n\n+++\n\n## Main\n\nBody\n\n:::",
+		},
+		{
+			name:     "default type is omitted",
+			input:    `<ac:layout><ac:layout-section ac:type="two_equal"><ac:layout-cell><p>A</p></ac:layout-cell><ac:layout-cell><p>B</p></ac:layout-cell></ac:layout-section></ac:layout>`,
+			expected: "::: columns\n\nA\n\n+++\n\nB\n\n:::",
+		},
+		{
+			name: "several sections",
+			input: `<ac:layout><ac:layout-section ac:type="single"><ac:layout-cell><p>Intro</p></ac:layout-cell></ac:layout-section>` +
+				`<ac:layout-section ac:type="three_equal"><ac:layout-cell><p>A</p></ac:layout-cell><ac:layout-cell><p>B</p></ac:layout-cell><ac:layout-cell><p>C</p></ac:layout-cell></ac:layout-section></ac:layout>`,
+			expected: "::: columns\n\nIntro\n\n:::\n\n::: columns\n\nA\n\n+++\n\nB\n\n+++\n\nC\n\n:::",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FromConfluenceStorage(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestToConfluenceStorage_Layouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "section with type",
+			input:    "::: columns two_right_sidebar\nMain\n+++\nSide\n:::",
+			expected: `<ac:layout><ac:layout-section ac:type="two_right_sidebar"><ac:layout-cell>` + "\n<p>Main</p>\n" + `</ac:layout-cell><ac:layout-cell>` + "\n<p>Side</p>\n" + `</ac:layout-cell></ac:layout-section></ac:layout>`,
+		},
+		{
+			name:     "content around sections becomes single sections",
+			input:    "Intro\n\n::: columns\nA\n+++\nB\n:::\n\nOutro",
+			expected: `<ac:layout><ac:layout-section ac:type="single"><ac:layout-cell><p>Intro</p>` + "\n" + `</ac:layout-cell></ac:layout-section><ac:layout-section ac:type="two_equal"><ac:layout-cell>` + "\n<p>A</p>\n" + `</ac:layout-cell><ac:layout-cell>` + "\n<p>B</p>\n" + `</ac:layout-cell></ac:layout-section><ac:layout-section ac:type="single"><ac:layout-cell>` + "\n<p>Outro</p>\n" + `</ac:layout-cell></ac:layout-section></ac:layout>`,
+		},
+		{
+			name:     "fence in code is left alone",
+			input:    "```\n::: columns\nA\n:::\n```",
+			expected: "<pre><code>::: columns\nA\n:::\n</code></pre>\n",
+		},
+		{
+			name:     "unclosed fence is left as written",
+			input:    "::: columns\nunclosed",
+			expected: "<p>::: columns\nunclosed</p>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToConfluenceStorage([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestLayoutRoundTrip(t *testing.T) {
+	storage := `<ac:layout><ac:layout-section ac:type="three_with_sidebars"><ac:layout-cell><p>Left</p></ac:layout-cell><ac:layout-cell><ul><li>Item</li></ul></ac:layout-cell><ac:layout-cell><p>Right</p></ac:layout-cell></ac:layout-section></ac:layout>`
+
+	markdown, err := FromConfluenceStorage(storage)
+	require.NoError(t, err)
+	result, err := ToConfluenceStorage([]byte(markdown))
+	require.NoError(t, err)
+
+	assert.Contains(t, result, `<ac:layout><ac:layout-section ac:type="three_with_sidebars">`)
+	assert.Contains(t, result, "<p>Left</p>")
+	assert.Contains(t, result, "<li>Item</li>")
+	assert.Contains(t, result, "<p>Right</p>")
+	assert.Equal(t, 3, strings.Count(result, "<ac:layout-cell>"))
+}
+
+func TestToADF_Layouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "section with type",
+			input:    "::: columns two_left_sidebar\nSide\n+++\nMain\n:::",
+			expected: `[{"type":"layoutSection","content":[{"type":"layoutColumn","attrs":{"width":33.33},"content":[{"type":"paragraph","content":[{"type":"text","text":"Side"}]}]},{"type":"layoutColumn","attrs":{"width":66.66},"content":[{"type":"paragraph","content":[{"type":"text","text":"Main"}]}]}]}]`,
+		},
+		{
+			name:     "single cell becomes its content",
+			input:    "::: columns\nOnly\n:::",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"Only"}]}]`,
+		},
+		{
+			name:     "cells beyond ADF columns follow one another",
+			input:    "::: columns four_equal\nA\n+++\nB\n+++\nC\n+++\nD\n:::",
+			expected: `[{"type":"paragraph","content":[{"type":"text","text":"A"}]},{"type":"paragraph","content":[{"type":"text","text":"B"}]},{"type":"paragraph","content":[{"type":"text","text":"C"}]},{"type":"paragraph","content":[{"type":"text","text":"D"}]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToADF([]byte(tt.input))
+			require.NoError(t, err)
+
+			var doc ADFDocument
+			require.NoError(t, json.Unmarshal([]byte(result), &doc))
+			content, err := json.Marshal(doc.Content)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(content))
+		})
+	}
+}
+
+func TestFromADF_Layouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		left     string
+		right    string
+		expected string
+	}{
+		{"equal columns", "50", "50", "::: columns\n\nA\n\n+++\n\nB\n\n:::"},
+		{"sidebar is inferred", "30", "70", "::: columns two_left_sidebar\n\nA\n\n+++\n\nB\n\n:::"},
+		{"missing width", "0", "100", "::: columns\n\nA\n\n+++\n\nB\n\n:::"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adf := `{"type":"doc","version":1,"content":[{"type":"layoutSection","content":[` +
+				`{"type":"layoutColumn","attrs":{"width":` + tt.left + `},"content":[{"type":"paragraph","content":[{"type":"text","text":"A"}]}]},` +
+				`{"type":"layoutColumn","attrs":{"width":` + tt.right + `},"content":[{"type":"paragraph","content":[{"type":"text","text":"B"}]}]}]}]}`
+
+			result, err := FromADF(adf)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestADFLayoutType(t *testing.T) {
+	tests := []struct {
+		widths   []float64
+		expected string
+	}{
+		{[]float64{100}, "single"},
+		{[]float64{50, 50}, "two_equal"},
+		{[]float64{66.66, 33.33}, "two_right_sidebar"},
+		{[]float64{33.33, 33.33, 33.33}, "three_equal"},
+		{[]float64{20, 60, 20}, "three_with_sidebars"},
+		{[]float64{25, 25, 25, 25}, ""},
+		{[]float64{50, 0}, ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, adfLayoutType(tt.widths), "widths %v", tt.widths)
+	}
+}
@@ -1,7 +1,10 @@
 // macro.go defines the core data structures for macro parsing.
 package md
 
-import "strings"
+import (
+	"html"
+	"strings"
+)
 
 // MacroNode represents a parsed macro in either direction (MD↔XHTML).
 type MacroNode struct {
@@ -22,9 +25,15 @@ const (
 
 // MacroType defines the behavior for a specific macro.
 type MacroType struct {
-	Name     string   // canonical lowercase name
-	HasBody  bool     // true for panels/expand/code, false for TOC
-	BodyType BodyType // how to handle body content
+	Name      string   // canonical lowercase name
+	HasBody   bool     // true for panels/expand/code, false for TOC
+	BodyType  BodyType // how to handle body content
+	Inline    bool     // rendered within a paragraph, like a status lozenge
+	PageParam bool     // the page parameter links to a page, as in [INCLUDE page="SPACE:Title"]
+
+	// Params maps bracket syntax parameter names to the storage format
+	// names they differ from, such as include's unnamed page parameter.
+	Params map[string]string
 }
 
 // MacroRegistry maps macro names to their type definitions.
@@ -64,6 +73,107 @@ var MacroRegistry = map[string]MacroType{
 		HasBody:  true,
 		BodyType: BodyTypePlainText,
 	},
+	"excerpt": {
+		Name:     "excerpt",
+		HasBody:  true,
+		BodyType: BodyTypeRichText,
+	},
+	"drawio": {
+		Name:    "drawio",
+		HasBody: false,
+	},
+	"gliffy": {
+		Name:    "gliffy",
+		HasBody: false,
+	},
+	"ui-tabs": {
+		Name:     "ui-tabs",
+		HasBody:  true,
+		BodyType: BodyTypeRichText,
+	},
+	"ui-tab": {
+		Name:     "ui-tab",
+		HasBody:  true,
+		BodyType: BodyTypeRichText,
+	},
+	"status": {
+		Name:     "status",
+		HasBody:  true,
+		BodyType: BodyTypePlainText,
+		Inline:   true,
+	},
+	"include": {
+		Name:      "include",
+		HasBody:   false,
+		PageParam: true,
+		Params:    map[string]string{"page": ""},
+	},
+	"excerpt-include": {
+		Name:      "excerpt-include",
+		HasBody:   false,
+		PageParam: true,
+		Params:    map[string]string{"page": ""},
+	},
+	"children": {
+		Name:      "children",
+		HasBody:   false,
+		PageParam: true,
+	},
+	"childrendisplay": {
+		// The name the macro browser shows for children
+		Name:      "children",
+		HasBody:   false,
+		PageParam: true,
+	},
+	"details": {
+		Name:     "details",
+		HasBody:  true,
+		BodyType: BodyTypeRichText,
+	},
+	"detailssummary": {
+		Name:    "detailssummary",
+		HasBody: false,
+	},
+	passthroughMacro: {
+		// A macro cfl has no syntax for, with its storage format body
+		Name:     passthroughMacro,
+		HasBody:  true,
+		BodyType: BodyTypePlainText,
+	},
+	"jira": {
+		Name:    "jira",
+		HasBody: false,
+		Inline:  true,
+	},
+}
+
+// storageParam returns the storage format name of a bracket syntax parameter.
+func (m MacroType) storageParam(name string) string {
+	if storage, ok := m.Params[name]; ok {
+		return storage
+	}
+	return name
+}
+
+// bracketParam returns the bracket syntax name of a storage format parameter.
+func (m MacroType) bracketParam(name string) string {
+	for bracket, storage := range m.Params {
+		if storage == name {
+			return bracket
+		}
+	}
+	return name
+}
+
+// paramsFromStorage unescapes the parameters of a macro read from storage
+// format and gives them their bracket syntax names.
+func paramsFromStorage(node *MacroNode) {
+	macroType, _ := LookupMacro(node.Name)
+	params := make(map[string]string, len(node.Parameters))
+	for name, value := range node.Parameters {
+		params[macroType.bracketParam(name)] = html.UnescapeString(value)
+	}
+	node.Parameters = params
 }
 
 // LookupMacro returns the MacroType for a given name, normalizing to lowercase.
@@ -0,0 +1,207 @@
+// merge.go provides a structural three-way merge of markdown documents.
+package md
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeConflictError reports sections changed by both sides of a merge.
+type MergeConflictError struct {
+	Sections []string // headings of the conflicting sections ("" = preamble)
+}
+
+func (e *MergeConflictError) Error() string {
+	names := make([]string, len(e.Sections))
+	for i, s := range e.Sections {
+		if s == "" {
+			s = "(content before first heading)"
+		}
+		names[i] = s
+	}
+	return fmt.Sprintf("conflicting changes in sections: %s", strings.Join(names, ", "))
+}
+
+// mdSection is a heading-delimited slice of a markdown document.
+type mdSection struct {
+	key     string // heading line plus occurrence index, unique within a document
+	heading string // heading line as written ("" for the preamble)
+	text    string // full section text including the heading line
+}
+
+// MergeSections performs a three-way merge of markdown documents at the
+// granularity of heading sections. Each side's changes are applied when they
+// touch different sections; a *MergeConflictError is returned when both sides
+// change the same section or both restructure the document differently.
+func MergeSections(base, ours, theirs string) (string, error) {
+	baseSecs := splitSections(base)
+	ourSecs := splitSections(ours)
+	theirSecs := splitSections(theirs)
+
+	baseMap := sectionMap(baseSecs)
+	ourMap := sectionMap(ourSecs)
+	theirMap := sectionMap(theirSecs)
+
+	// Pick the document structure: whichever side added, removed or
+	// reordered sections wins; if both did so differently, give up.
+	structure := baseSecs
+	ourChanged := !sameKeys(baseSecs, ourSecs)
+	theirChanged := !sameKeys(baseSecs, theirSecs)
+	switch {
+	case ourChanged && theirChanged && !sameKeys(ourSecs, theirSecs):
+		return "", &MergeConflictError{Sections: []string{"(document structure)"}}
+	case ourChanged:
+		structure = ourSecs
+	case theirChanged:
+		structure = theirSecs
+	}
+
+	var conflicts []string
+	var merged []string
+
+	for _, sec := range structure {
+		b, inBase := baseMap[sec.key]
+		o, inOurs := ourMap[sec.key]
+		t, inTheirs := theirMap[sec.key]
+
+		switch {
+		case !inBase && inOurs && inTheirs && !sameText(o, t):
+			conflicts = append(conflicts, sec.heading)
+		case !inBase && inOurs:
+			merged = append(merged, o)
+		case !inBase:
+			merged = append(merged, t)
+		case !inOurs:
+			// Removed by us; conflict if they edited it
+			if inTheirs && !sameText(t, b) {
+				conflicts = append(conflicts, sec.heading)
+			}
+		case !inTheirs:
+			if !sameText(o, b) {
+				conflicts = append(conflicts, sec.heading)
+			}
+		case sameText(o, t) || sameText(t, b):
+			merged = append(merged, o)
+		case sameText(o, b):
+			merged = append(merged, t)
+		default:
+			conflicts = append(conflicts, sec.heading)
+		}
+	}
+
+	// Sections deleted by the structure-owning side but edited by the other
+	for _, sec := range baseSecs {
+		if containsKey(structure, sec.key) {
+			continue
+		}
+		if o, ok := ourMap[sec.key]; ok && !sameText(o, sec.text) {
+			conflicts = append(conflicts, sec.heading)
+		}
+		if t, ok := theirMap[sec.key]; ok && !sameText(t, sec.text) {
+			conflicts = append(conflicts, sec.heading)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return "", &MergeConflictError{Sections: conflicts}
+	}
+
+	// Keep sections on separate lines when a side dropped the trailing newline
+	for i := 0; i < len(merged)-1; i++ {
+		if !strings.HasSuffix(merged[i], "\n") {
+			merged[i] += "\n"
+		}
+	}
+
+	return strings.Join(merged, ""), nil
+}
+
+// splitSections splits markdown at ATX headings, ignoring fenced code blocks.
+func splitSections(markdown string) []mdSection {
+	var sections []mdSection
+	seen := make(map[string]int)
+	current := mdSection{}
+	var body strings.Builder
+	inFence := false
+	fence := ""
+
+	flush := func() {
+		current.text = body.String()
+		if current.heading != "" || strings.TrimSpace(current.text) != "" {
+			sections = append(sections, current)
+		}
+		body.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			marker := trimmed[:3]
+			if !inFence {
+				inFence, fence = true, marker
+			} else if marker == fence {
+				inFence = false
+			}
+		}
+
+		if !inFence && isATXHeading(trimmed) {
+			flush()
+			heading := strings.TrimRight(trimmed, " #")
+			seen[heading]++
+			current = mdSection{
+				key:     fmt.Sprintf("%s#%d", heading, seen[heading]),
+				heading: heading,
+			}
+		}
+		body.WriteString(line)
+	}
+	flush()
+
+	return sections
+}
+
+// isATXHeading reports whether a trimmed line is a markdown ATX heading.
+func isATXHeading(line string) bool {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return false
+	}
+	return level == len(line) || line[level] == ' ' || line[level] == '\t'
+}
+
+func sectionMap(sections []mdSection) map[string]string {
+	m := make(map[string]string, len(sections))
+	for _, s := range sections {
+		m[s.key] = s.text
+	}
+	return m
+}
+
+func sameKeys(a, b []mdSection) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].key != b[i].key {
+			return false
+		}
+	}
+	return true
+}
+
+func containsKey(sections []mdSection, key string) bool {
+	for _, s := range sections {
+		if s.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// sameText compares section text ignoring surrounding whitespace.
+func sameText(a, b string) bool {
+	return strings.TrimSpace(a) == strings.TrimSpace(b)
+}
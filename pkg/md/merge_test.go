@@ -0,0 +1,85 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mergeBase = `Intro text
+
+# Alpha
+
+Alpha body
+
+# Beta
+
+Beta body
+`
+
+func TestMergeSections_DisjointEdits(t *testing.T) {
+	ours := "Intro text\n\n# Alpha\n\nAlpha body edited by us\n\n# Beta\n\nBeta body\n"
+	theirs := "Intro text\n\n# Alpha\n\nAlpha body\n\n# Beta\n\nBeta body edited by them\n"
+
+	merged, err := MergeSections(mergeBase, ours, theirs)
+	require.NoError(t, err)
+	assert.Contains(t, merged, "Alpha body edited by us")
+	assert.Contains(t, merged, "Beta body edited by them")
+	assert.Contains(t, merged, "Intro text")
+}
+
+func TestMergeSections_SameSectionConflict(t *testing.T) {
+	ours := "Intro text\n\n# Alpha\n\nOurs\n\n# Beta\n\nBeta body\n"
+	theirs := "Intro text\n\n# Alpha\n\nTheirs\n\n# Beta\n\nBeta body\n"
+
+	_, err := MergeSections(mergeBase, ours, theirs)
+	require.Error(t, err)
+
+	var conflict *MergeConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, []string{"# Alpha"}, conflict.Sections)
+}
+
+func TestMergeSections_IdenticalEdits(t *testing.T) {
+	edited := "Intro text\n\n# Alpha\n\nSame change\n\n# Beta\n\nBeta body\n"
+
+	merged, err := MergeSections(mergeBase, edited, edited)
+	require.NoError(t, err)
+	assert.Equal(t, edited, merged)
+}
+
+func TestMergeSections_TheirsAddsSection(t *testing.T) {
+	ours := "Intro text\n\n# Alpha\n\nOur alpha\n\n# Beta\n\nBeta body\n"
+	theirs := mergeBase + "\n# Gamma\n\nNew section\n"
+
+	merged, err := MergeSections(mergeBase, ours, theirs)
+	require.NoError(t, err)
+	assert.Contains(t, merged, "Our alpha")
+	assert.Contains(t, merged, "# Gamma")
+}
+
+func TestMergeSections_DeletedSectionEditedByOtherSide(t *testing.T) {
+	ours := "Intro text\n\n# Alpha\n\nAlpha body\n"
+	theirs := "Intro text\n\n# Alpha\n\nAlpha body\n\n# Beta\n\nBeta changed\n"
+
+	_, err := MergeSections(mergeBase, ours, theirs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "# Beta")
+}
+
+func TestMergeSections_BothRestructure(t *testing.T) {
+	ours := mergeBase + "\n# Ours\n"
+	theirs := mergeBase + "\n# Theirs\n"
+
+	_, err := MergeSections(mergeBase, ours, theirs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "document structure")
+}
+
+func TestMergeSections_HeadingInCodeFence(t *testing.T) {
+	base := "# Alpha\n\n```\n# not a heading\n```\n"
+	sections := splitSections(base)
+	require.Len(t, sections, 1)
+	assert.Equal(t, "# Alpha", sections[0].heading)
+}
@@ -0,0 +1,30 @@
+// mermaid.go converts mermaid code blocks to a diagram macro.
+package md
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// DefaultMermaidMacro is the macro MermaidMacros uses when none is given.
+const DefaultMermaidMacro = "mermaid"
+
+// mermaidCodePattern matches a mermaid code block in storage format.
+var mermaidCodePattern = regexp.MustCompile(`(?s)<pre><code class="language-mermaid">(.*?)</code></pre>`)
+
+// MermaidMacros replaces mermaid code blocks in storage format with a
+// diagram macro whose plain text body is the diagram source, for instances
+// with a Mermaid macro app installed.
+func MermaidMacros(storage, macro string) string {
+	if macro == "" {
+		macro = DefaultMermaidMacro
+	}
+	return mermaidCodePattern.ReplaceAllStringFunc(storage, func(match string) string {
+		source := html.UnescapeString(mermaidCodePattern.FindStringSubmatch(match)[1])
+		source = strings.ReplaceAll(strings.TrimSuffix(source, "\n"), "]]>", "]]]]><![CDATA[>")
+		return fmt.Sprintf(`<ac:structured-macro ac:name="%s" ac:schema-version="1"><ac:plain-text-body><![CDATA[%s]]></ac:plain-text-body></ac:structured-macro>`,
+			escapeXML(macro), source)
+	})
+}
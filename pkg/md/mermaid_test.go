@@ -0,0 +1,27 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMermaidMacros(t *testing.T) {
+	storage, err := ToConfluenceStorage([]byte("# Flow\n\n```mermaid\ngraph TD; A-->B & C\n```\n\n```go\nx := 1\n```\n"))
+	require.NoError(t, err)
+
+	got := MermaidMacros(storage, "")
+	assert.Contains(t, got, `<ac:structured-macro ac:name="mermaid" ac:schema-version="1"><ac:plain-text-body><![CDATA[graph TD; A-->B & C]]></ac:plain-text-body></ac:structured-macro>`)
+	assert.NotContains(t, got, "language-mermaid")
+	assert.Contains(t, got, `language-go`)
+
+	got = MermaidMacros(storage, "mermaid-cloud")
+	assert.Contains(t, got, `ac:name="mermaid-cloud"`)
+}
+
+func TestMermaidMacros_CDATAEnd(t *testing.T) {
+	got := MermaidMacros(`<pre><code class="language-mermaid">A[&quot;]]&gt;&quot;]
+</code></pre>`, "mermaid")
+	assert.Contains(t, got, `<![CDATA[A["]]]]><![CDATA[>"]]]>`)
+}
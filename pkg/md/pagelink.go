@@ -0,0 +1,325 @@
+// pagelink.go converts links to Confluence pages by title: [[Title]],
+// [[SPACE:Title]] and [[Title|text]] in markdown, and markdown links to
+// confluence://SPACE/Page+Title.
+package md
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/yuin/goldmark/text"
+)
+
+// PageLinkScheme is the URL scheme of links to pages by space and title,
+// as in confluence://DEV/Release+Notes.
+const PageLinkScheme = "confluence"
+
+// Placeholder markers for page links (avoid html-to-markdown escaping)
+const (
+	pageLinkPlaceholderPrefix = "CFPAGELINK"
+	pageLinkPlaceholderSuffix = "END"
+)
+
+// PageLink identifies a page by its space key and title. Space is empty
+// for a page in the same space as the linking page.
+type PageLink struct {
+	Space string
+	Title string
+}
+
+// Patterns for page links.
+var (
+	// wikiLinkPattern matches [[target]] and [[target|text]].
+	wikiLinkPattern = regexp.MustCompile(`\[\[([^\[\]|\n]+)(?:\|([^\[\]\n]+))?\]\]`)
+
+	// pageLinkTextPattern matches a markdown link to a confluence:// URL,
+	// such as preprocessPageLinks writes for [[...]] links.
+	pageLinkTextPattern = regexp.MustCompile(`\[[^\[\]\n]*\]\(` + PageLinkScheme + `://[^()\s]*\)`)
+
+	// spaceKeyPattern matches a space key, including personal spaces (~user).
+	spaceKeyPattern = regexp.MustCompile(`^~?[A-Za-z0-9]+$`)
+
+	// storagePageLinkPattern matches the <a> elements the markdown renderer
+	// produces for page links.
+	storagePageLinkPattern = regexp.MustCompile(`(?s)<a href="(` + PageLinkScheme + `://[^"]*)">(.*?)</a>`)
+
+	// acPageLinkPattern matches a storage format link to a page.
+	acPageLinkPattern = regexp.MustCompile(`(?s)<ac:link(?:\s[^>]*)?>\s*<ri:page\s([^>]*?)/?>(?:</ri:page>)?(.*?)</ac:link>`)
+
+	// riAttrPattern matches an attribute of a resource identifier.
+	riAttrPattern = regexp.MustCompile(`ri:(space-key|content-title)="([^"]*)"`)
+
+	// linkBodyPattern matches the body of a storage format link.
+	linkBodyPattern = regexp.MustCompile(`(?s)<ac:plain-text-link-body>\s*<!\[CDATA\[(.*?)\]\]>\s*</ac:plain-text-link-body>|<ac:link-body>(.*?)</ac:link-body>`)
+
+	// htmlTagPattern matches an HTML or XML tag.
+	htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+	// linkDestPattern matches the destination of an inline markdown link,
+	// capturing it with any angle brackets.
+	linkDestPattern = regexp.MustCompile(`\]\(\s*(<[^<>\n]*>|[^\s()<>]+)`)
+)
+
+// ParsePageLink parses a confluence://SPACE/Page+Title link.
+func ParsePageLink(dest string) (PageLink, bool) {
+	rest, ok := strings.CutPrefix(dest, PageLinkScheme+"://")
+	if !ok {
+		return PageLink{}, false
+	}
+	space, title, ok := strings.Cut(rest, "/")
+	if !ok {
+		return PageLink{}, false
+	}
+	title, err := url.QueryUnescape(title)
+	if err != nil || strings.TrimSpace(title) == "" {
+		return PageLink{}, false
+	}
+	return PageLink{Space: space, Title: title}, true
+}
+
+// URL returns the confluence:// link to the page.
+func (l PageLink) URL() string {
+	return PageLinkScheme + "://" + l.Space + "/" + url.QueryEscape(l.Title)
+}
+
+// displayPath returns the site-relative URL Confluence resolves to the
+// page, or "" when the space isn't known.
+func (l PageLink) displayPath() string {
+	if l.Space == "" {
+		return ""
+	}
+	return "/wiki/display/" + l.Space + "/" + url.QueryEscape(l.Title)
+}
+
+// wikiLink renders the link in [[SPACE:Title|text]] form, leaving out the
+// text when it is the title.
+func (l PageLink) wikiLink(text string) string {
+	target := l.Title
+	if l.Space != "" {
+		target = l.Space + ":" + target
+	}
+	if text == "" || text == adfTextEscaper.Replace(l.Title) {
+		return "[[" + target + "]]"
+	}
+	return "[[" + target + "|" + text + "]]"
+}
+
+// parseWikiTarget parses the target of a [[...]] link. A prefix is taken as
+// the space key only when it looks like one and no space follows the
+// colon, so [[Runbook: Database]] links to a page titled as written.
+func parseWikiTarget(target string) PageLink {
+	target = strings.TrimSpace(target)
+	if space, title, ok := strings.Cut(target, ":"); ok && spaceKeyPattern.MatchString(space) &&
+		title != "" && !strings.ContainsAny(title[:1], " \t") {
+		return PageLink{Space: space, Title: title}
+	}
+	return PageLink{Title: target}
+}
+
+// wikiLinkEdits returns the [[...]] links in markdown outside code, with
+// the replacement render returns for each.
+func wikiLinkEdits(markdown []byte, render func(link PageLink, text string) string) []glossaryEdit {
+	matches := wikiLinkPattern.FindAllSubmatchIndex(markdown, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	code := codeRanges(adfParser.Parser().Parse(text.NewReader(markdown)), markdown)
+	var edits []glossaryEdit
+	for _, m := range matches {
+		if overlaps(code, m[0], m[1]) {
+			continue
+		}
+		link := parseWikiTarget(string(markdown[m[2]:m[3]]))
+		if link.Title == "" {
+			continue
+		}
+		var linkText string
+		if m[4] >= 0 {
+			linkText = strings.TrimSpace(string(markdown[m[4]:m[5]]))
+		}
+		edits = append(edits, glossaryEdit{start: m[0], stop: m[1], text: render(link, linkText)})
+	}
+	return edits
+}
+
+// applyEdits applies non-overlapping edits to markdown.
+func applyEdits(markdown []byte, edits []glossaryEdit) []byte {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	var out []byte
+	last := 0
+	for _, e := range edits {
+		out = append(out, markdown[last:e.start]...)
+		out = append(out, e.text...)
+		last = e.stop
+	}
+	return append(out, markdown[last:]...)
+}
+
+// preprocessPageLinks rewrites [[...]] links as markdown links to
+// confluence:// URLs, which the converters turn into page links.
+func preprocessPageLinks(markdown []byte) []byte {
+	edits := wikiLinkEdits(markdown, func(link PageLink, linkText string) string {
+		if linkText == "" {
+			linkText = adfTextEscaper.Replace(link.Title)
+		}
+		return "[" + linkText + "](" + link.URL() + ")"
+	})
+	if len(edits) == 0 {
+		return markdown
+	}
+	return applyEdits(markdown, edits)
+}
+
+// QualifyPageLinks adds space to [[Title]] links that don't name a space.
+// The cloud editor format links to pages by URL, which needs the space, so
+// such links are only published as links once qualified.
+func QualifyPageLinks(markdown []byte, space string) []byte {
+	if space == "" {
+		return markdown
+	}
+	var edits []glossaryEdit
+	for _, e := range wikiLinkEdits(markdown, func(link PageLink, linkText string) string {
+		if link.Space != "" {
+			return ""
+		}
+		link.Space = space
+		return link.wikiLink(linkText)
+	}) {
+		if e.text != "" {
+			edits = append(edits, e)
+		}
+	}
+	if len(edits) == 0 {
+		return markdown
+	}
+	return applyEdits(markdown, edits)
+}
+
+// LinkLocalPages rewrites markdown links to local files, such as
+// [Setup](guide/setup.md), as links to the pages resolve returns for their
+// destinations. Links resolve returns false for, and links in code, are
+// left alone.
+func LinkLocalPages(markdown []byte, resolve func(dest string) (PageLink, bool)) []byte {
+	matches := linkDestPattern.FindAllSubmatchIndex(markdown, -1)
+	if len(matches) == 0 {
+		return markdown
+	}
+
+	code := codeRanges(adfParser.Parser().Parse(text.NewReader(markdown)), markdown)
+	var edits []glossaryEdit
+	for _, m := range matches {
+		if overlaps(code, m[0], m[1]) {
+			continue
+		}
+		dest := strings.TrimSuffix(strings.TrimPrefix(string(markdown[m[2]:m[3]]), "<"), ">")
+		if !isLocalRef(dest) {
+			continue
+		}
+		if link, ok := resolve(dest); ok {
+			edits = append(edits, glossaryEdit{start: m[2], stop: m[3], text: link.URL()})
+		}
+	}
+	if len(edits) == 0 {
+		return markdown
+	}
+	return applyEdits(markdown, edits)
+}
+
+// postprocessPageLinks replaces the <a> elements rendered for page links
+// with storage format links to the pages.
+func postprocessPageLinks(storage string) string {
+	return storagePageLinkPattern.ReplaceAllStringFunc(storage, func(match string) string {
+		groups := storagePageLinkPattern.FindStringSubmatch(match)
+		link, ok := ParsePageLink(html.UnescapeString(groups[1]))
+		if !ok {
+			return match
+		}
+
+		var sb strings.Builder
+		sb.WriteString("<ac:link><ri:page")
+		if link.Space != "" {
+			sb.WriteString(` ri:space-key="` + html.EscapeString(link.Space) + `"`)
+		}
+		sb.WriteString(` ri:content-title="` + html.EscapeString(link.Title) + `" />`)
+		switch body := groups[2]; {
+		case strings.Contains(body, "<"):
+			sb.WriteString("<ac:link-body>" + body + "</ac:link-body>")
+		case html.UnescapeString(body) != link.Title:
+			sb.WriteString("<ac:plain-text-link-body><![CDATA[" + html.UnescapeString(body) + "]]></ac:plain-text-link-body>")
+		}
+		sb.WriteString("</ac:link>")
+		return sb.String()
+	})
+}
+
+// replaceStoragePageLinks replaces storage format page links with
+// placeholders, returning the [[...]] link for each.
+func replaceStoragePageLinks(storage string) (string, map[int]string) {
+	links := make(map[int]string)
+	storage = acPageLinkPattern.ReplaceAllStringFunc(storage, func(match string) string {
+		groups := acPageLinkPattern.FindStringSubmatch(match)
+		var link PageLink
+		for _, attr := range riAttrPattern.FindAllStringSubmatch(groups[1], -1) {
+			if attr[1] == "space-key" {
+				link.Space = html.UnescapeString(attr[2])
+			} else {
+				link.Title = html.UnescapeString(attr[2])
+			}
+		}
+		if link.Title == "" {
+			return match
+		}
+
+		var linkText string
+		if body := linkBodyPattern.FindStringSubmatch(groups[2]); body != nil {
+			if body[1] != "" {
+				linkText = body[1]
+			} else {
+				linkText = html.UnescapeString(htmlTagPattern.ReplaceAllString(body[2], ""))
+			}
+		}
+		if linkText = strings.TrimSpace(linkText); linkText != "" {
+			linkText = adfTextEscaper.Replace(linkText)
+		}
+
+		id := len(links)
+		links[id] = link.wikiLink(linkText)
+		return fmt.Sprintf("%s%d%s", pageLinkPlaceholderPrefix, id, pageLinkPlaceholderSuffix)
+	})
+	return storage, links
+}
+
+// restorePageLinks replaces page link placeholders with [[...]] links.
+func restorePageLinks(markdown string, links map[int]string) string {
+	for id, link := range links {
+		markdown = strings.Replace(markdown, fmt.Sprintf("%s%d%s", pageLinkPlaceholderPrefix, id, pageLinkPlaceholderSuffix), link, 1)
+	}
+	return markdown
+}
+
+// pageLinkFromURL returns the page a Confluence display URL, such as
+// https://example.atlassian.net/wiki/display/DEV/Release+Notes, links to.
+func pageLinkFromURL(href string) (PageLink, bool) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return PageLink{}, false
+	}
+	rest, ok := strings.CutPrefix(strings.TrimPrefix(u.EscapedPath(), "/wiki"), "/display/")
+	if !ok {
+		return PageLink{}, false
+	}
+	space, title, ok := strings.Cut(rest, "/")
+	if !ok || !spaceKeyPattern.MatchString(space) || strings.Contains(title, "/") {
+		return PageLink{}, false
+	}
+	title, err = url.QueryUnescape(title)
+	if err != nil || title == "" {
+		return PageLink{}, false
+	}
+	return PageLink{Space: space, Title: title}, true
+}
@@ -0,0 +1,181 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePageLink(t *testing.T) {
+	tests := []struct {
+		dest   string
+		want   PageLink
+		wantOK bool
+	}{
+		{"confluence://DEV/Release+Notes", PageLink{Space: "DEV", Title: "Release Notes"}, true},
+		{"confluence://DEV/A%2FB+%26+C", PageLink{Space: "DEV", Title: "A/B & C"}, true},
+		{"confluence:///Home", PageLink{Title: "Home"}, true},
+		{"confluence://DEV", PageLink{}, false},
+		{"confluence://DEV/", PageLink{}, false},
+		{"https://example.com/DEV/Page", PageLink{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dest, func(t *testing.T) {
+			got, ok := ParsePageLink(tt.dest)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToConfluenceStorage_PageLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "title",
+			input:    "See [[Release Notes]].",
+			expected: `<p>See <ac:link><ri:page ri:content-title="Release Notes" /></ac:link>.</p>`,
+		},
+		{
+			name:     "space and text",
+			input:    "See [[DEV:Release Notes|the notes]].",
+			expected: `<p>See <ac:link><ri:page ri:space-key="DEV" ri:content-title="Release Notes" /><ac:plain-text-link-body><![CDATA[the notes]]></ac:plain-text-link-body></ac:link>.</p>`,
+		},
+		{
+			name:     "colon in title",
+			input:    "[[Runbook: DB]]",
+			expected: `<p><ac:link><ri:page ri:content-title="Runbook: DB" /></ac:link></p>`,
+		},
+		{
+			name:     "confluence URL with formatted text",
+			input:    "[**notes**](confluence://DEV/Release+Notes)",
+			expected: `<p><ac:link><ri:page ri:space-key="DEV" ri:content-title="Release Notes" /><ac:link-body><strong>notes</strong></ac:link-body></ac:link></p>`,
+		},
+		{
+			name:     "not in code",
+			input:    "x `[[Title]]` y",
+			expected: `<p>x <code>[[Title]]</code> y</p>`,
+		},
+		{
+			name:     "text named like a macro",
+			input:    "[[Home|toc]]",
+			expected: `<p><ac:link><ri:page ri:content-title="Home" /><ac:plain-text-link-body><![CDATA[toc]]></ac:plain-text-link-body></ac:link></p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToConfluenceStorage([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, trimNewline(got))
+		})
+	}
+}
+
+func TestPageLinks_TextIsNotParsedAsMacro(t *testing.T) {
+	markdown := preprocessPageLinks([]byte("[[My Page|click here]] and [[Click]]"))
+
+	result, err := ParseBracketMacros(string(maskCodeBrackets(markdown)))
+	require.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+	assert.Empty(t, result.GetMacros())
+}
+
+func TestFromConfluenceStorage_PageLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "title",
+			input:    `<p>See <ac:link><ri:page ri:content-title="Release Notes" /></ac:link>.</p>`,
+			expected: "See [[Release Notes]].",
+		},
+		{
+			name:     "space and plain text body",
+			input:    `<p><ac:link><ri:page ri:space-key="DEV" ri:content-title="Release Notes" /><ac:plain-text-link-body><![CDATA[the notes]]></ac:plain-text-link-body></ac:link></p>`,
+			expected: "[[DEV:Release Notes|the notes]]",
+		},
+		{
+			name:     "rich body",
+			input:    `<p><ac:link><ri:page ri:content-title="A &amp; B" /><ac:link-body><strong>both</strong></ac:link-body></ac:link></p>`,
+			expected: "[[A & B|both]]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromConfluenceStorage(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestPageLinks_StorageRoundTrip(t *testing.T) {
+	input := "Read [[DEV:Release Notes]] and [[Home|the home page]]."
+	storage, err := ToConfluenceStorage([]byte(input))
+	require.NoError(t, err)
+	back, err := FromConfluenceStorage(storage)
+	require.NoError(t, err)
+	assert.Equal(t, input, back)
+}
+
+func TestToADF_PageLinks(t *testing.T) {
+	adf, err := ToADF([]byte("See [[DEV:Release Notes|notes]] and [[Home]]."))
+	require.NoError(t, err)
+	assert.Contains(t, adf, `{"type":"text","text":"notes","marks":[{"type":"link","attrs":{"href":"/wiki/display/DEV/Release+Notes"}}]}`)
+	// Without a space there is no URL to link to
+	assert.Contains(t, adf, `{"type":"text","text":"Home"}`)
+
+	back, err := FromADF(adf)
+	require.NoError(t, err)
+	assert.Equal(t, "See [[DEV:Release Notes|notes]] and Home.", back)
+}
+
+func TestFromADF_PageLinkCard(t *testing.T) {
+	adf := `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[
+		{"type":"inlineCard","attrs":{"url":"https://example.atlassian.net/wiki/display/DEV/Release+Notes"}},
+		{"type":"text","text":" and "},
+		{"type":"inlineCard","attrs":{"url":"https://example.com/other"}}]}]}`
+	got, err := FromADF(adf)
+	require.NoError(t, err)
+	assert.Equal(t, "[[DEV:Release Notes]] and <https://example.com/other>", got)
+}
+
+func TestQualifyPageLinks(t *testing.T) {
+	got := QualifyPageLinks([]byte("[[Home]], [[OPS:Runbook]], [[Runbook: DB|db]] and `[[Code]]`"), "DEV")
+	assert.Equal(t, "[[DEV:Home]], [[OPS:Runbook]], [[DEV:Runbook: DB|db]] and `[[Code]]`", string(got))
+}
+
+func TestLinkLocalPages(t *testing.T) {
+	pages := map[string]string{"setup.md": "Setup", "guide/intro.md": "Intro & Overview"}
+	resolve := func(dest string) (PageLink, bool) {
+		title, ok := pages[dest]
+		return PageLink{Space: "DEV", Title: title}, ok
+	}
+
+	input := "See [setup](setup.md), [intro](<guide/intro.md>), [web](https://example.com/setup.md),\n" +
+		"[other](other.md) and `[code](setup.md)`.\n\n```\n[fenced](setup.md)\n```\n"
+	want := "See [setup](confluence://DEV/Setup), [intro](confluence://DEV/Intro+%26+Overview), [web](https://example.com/setup.md),\n" +
+		"[other](other.md) and `[code](setup.md)`.\n\n```\n[fenced](setup.md)\n```\n"
+	assert.Equal(t, want, string(LinkLocalPages([]byte(input), resolve)))
+
+	storage, err := ToConfluenceStorage(LinkLocalPages([]byte("Read [the setup guide](setup.md)."), resolve))
+	require.NoError(t, err)
+	assert.Contains(t, storage, `<ri:page ri:space-key="DEV" ri:content-title="Setup" /><ac:plain-text-link-body><![CDATA[the setup guide]]>`)
+}
+
+// trimNewline removes the trailing newline the markdown renderer adds.
+func trimNewline(s string) string {
+	for len(s) > 0 && s[len(s)-1] == '\n' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
@@ -38,7 +38,9 @@ func ParseBracketMacros(input string) (*ParseResult, error) {
 			macroType, known := LookupMacro(token.MacroName)
 			if !known {
 				// Unknown macro - treat as text
-				result.AddWarning("unknown macro: %s", token.MacroName)
+				if !isCheckBoxTag(token) {
+					result.AddWarning("unknown macro: %s", token.MacroName)
+				}
 				text := reconstructBracketTag(token)
 				if len(stack) > 0 {
 					stack[len(stack)-1].bodyContent += text
@@ -51,7 +53,7 @@ func ParseBracketMacros(input string) (*ParseResult, error) {
 			// Create a new stack frame for this macro
 			frame := &stackFrame{
 				node: &MacroNode{
-					Name:       strings.ToLower(token.MacroName),
+					Name:       macroType.Name,
 					Parameters: token.Parameters,
 				},
 				macroType: macroType,
@@ -105,7 +107,7 @@ func ParseBracketMacros(input string) (*ParseResult, error) {
 			}
 
 			node := &MacroNode{
-				Name:       strings.ToLower(token.MacroName),
+				Name:       macroType.Name,
 				Parameters: token.Parameters,
 			}
 
@@ -121,7 +123,6 @@ func ParseBracketMacros(input string) (*ParseResult, error) {
 				// Top level
 				result.AddMacroSegment(node)
 			}
-			_ = macroType // validated but not needed for self-close
 		}
 	}
 
@@ -175,6 +176,9 @@ func closeMacro(result *ParseResult, stack *[]*stackFrame) {
 			result.Warnings = append(result.Warnings, nested.Warnings...)
 		}
 	}
+	if current.node.Name == passthroughMacro {
+		current.node.Body = unescapePassthroughBody(current.node.Body)
+	}
 
 	if len(*stack) > 0 {
 		parent := (*stack)[len(*stack)-1]
@@ -0,0 +1,210 @@
+// passthrough.go preserves macros cfl has no syntax for. With ShowMacros,
+// an unknown macro becomes [MACRO name=... params...]raw-body[/MACRO], its
+// body kept as storage format, and converting the markdown back rebuilds
+// the macro, so editing a page doesn't lose it. A [MACRO or [/MACRO in the
+// body is escaped as [\MACRO or [\/MACRO, so it can't end the macro early.
+package md
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// passthroughMacro is the name of the macro that carries unknown macros.
+const passthroughMacro = "macro"
+
+// passthroughIDParam is the passthrough parameter carrying a macro's
+// ac:macro-id attribute.
+const passthroughIDParam = "macro-id"
+
+// Placeholder markers for passed through macros (avoid html-to-markdown escaping)
+const (
+	passthroughPlaceholderPrefix = "CFPASSTHROUGH"
+	passthroughPlaceholderSuffix = "END"
+)
+
+// Patterns for passed through macros.
+var (
+	// macroTagPattern matches the opening, self-closing and closing tags of
+	// storage format macros.
+	macroTagPattern = regexp.MustCompile(`<ac:structured-macro\b[^>]*?(/?)>|</ac:structured-macro>`)
+
+	// macroTagNamePattern matches the name of a macro in its opening tag.
+	macroTagNamePattern = regexp.MustCompile(`\sac:name="([^"]*)"`)
+
+	// macroTagIDPattern matches the ID of a macro in its opening tag.
+	macroTagIDPattern = regexp.MustCompile(`\sac:macro-id="([^"]*)"`)
+
+	// leadingParamPattern matches a parameter with a plain value at the
+	// start of a macro's content.
+	leadingParamPattern = regexp.MustCompile(`^\s*<ac:parameter ac:name="([^"]+)">([^<]*)</ac:parameter>`)
+
+	// passthroughPlaceholderPattern matches a passthrough placeholder.
+	passthroughPlaceholderPattern = regexp.MustCompile(passthroughPlaceholderPrefix + `(\d+)` + passthroughPlaceholderSuffix)
+
+	// blockContextPattern matches storage format ending where a block
+	// element can start: after a block element, or at the start of one
+	// that holds blocks.
+	blockContextPattern = regexp.MustCompile(`(?:^|</(?:p|h[1-6]|ul|ol|table|div|blockquote|pre|ac:structured-macro)>|<(?:ac:rich-text-body|ac:layout-cell|td|th|li|div|blockquote)(?:\s[^>]*)?>)\s*$`)
+
+	// passthroughBracketPattern matches a passed through macro in markdown,
+	// capturing its body. Quoted parameter values may hold brackets.
+	passthroughBracketPattern = regexp.MustCompile(`(?is)\[MACRO(?:\s(?:"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|[^\]"'])*)?\](.*?)\[/MACRO\]`)
+
+	// passthroughTagPattern matches a [MACRO or [/MACRO in a passthrough
+	// body, and passthroughEscapedTagPattern one escaped with a backslash,
+	// after any backslashes it had before. The bracket of an escaped tag
+	// may be masked.
+	passthroughTagPattern        = regexp.MustCompile(`(?i)\[(\\*/?macro\b)`)
+	passthroughEscapedTagPattern = regexp.MustCompile(`(?i)([\[\x00])\\(\\*/?macro\b)`)
+)
+
+// replaceUnknownMacros replaces the outermost macros that aren't in the
+// registry with placeholders, returning the bracket syntax for each.
+func replaceUnknownMacros(storage string) (string, []string) {
+	var macros []string
+	var sb strings.Builder
+	last, depth, start := 0, 0, -1
+	for _, loc := range macroTagPattern.FindAllStringSubmatchIndex(storage, -1) {
+		tag := storage[loc[0]:loc[1]]
+		switch {
+		case strings.HasPrefix(tag, "</"):
+			if depth > 0 {
+				depth--
+			}
+			if depth > 0 || start < 0 {
+				continue
+			}
+		case depth > 0:
+			if loc[3] == loc[2] {
+				depth++
+			}
+			continue
+		default:
+			name := macroTagNamePattern.FindStringSubmatch(tag)
+			if name == nil {
+				continue
+			}
+			if _, known := LookupMacro(name[1]); known {
+				// Known macros are parsed as usual; only their own
+				// unknown macros are passed through
+				continue
+			}
+			start = loc[0]
+			if loc[3] == loc[2] {
+				depth++
+				continue
+			}
+		}
+
+		// A macro between blocks is set apart as a block of its own, so
+		// that it doesn't share a line with its neighbours
+		placeholder := fmt.Sprintf("%s%d%s", passthroughPlaceholderPrefix, len(macros), passthroughPlaceholderSuffix)
+		if blockContextPattern.MatchString(storage[:start]) {
+			placeholder = "<div>" + placeholder + "</div>"
+		}
+		sb.WriteString(storage[last:start])
+		sb.WriteString(placeholder)
+		macros = append(macros, passthroughBracket(storage[start:loc[1]]))
+		last, start = loc[1], -1
+	}
+	if len(macros) == 0 {
+		return storage, nil
+	}
+	sb.WriteString(storage[last:])
+	return sb.String(), macros
+}
+
+// passthroughBracket renders a macro's storage format as a passthrough
+// macro: its plain parameters become parameters and the rest of its
+// content is the body, as written.
+func passthroughBracket(macroXML string) string {
+	open := macroTagPattern.FindStringSubmatchIndex(macroXML)
+	params := map[string]string{"name": macroTagNamePattern.FindStringSubmatch(macroXML[:open[1]])[1]}
+	if id := macroTagIDPattern.FindStringSubmatch(macroXML[:open[1]]); id != nil {
+		params[passthroughIDParam] = html.UnescapeString(id[1])
+	}
+	var body string
+	if open[3] == open[2] {
+		body = strings.TrimSuffix(macroXML[open[1]:], "</ac:structured-macro>")
+		for {
+			m := leadingParamPattern.FindStringSubmatch(body)
+			if m == nil || m[1] == "name" || m[1] == passthroughIDParam {
+				break
+			}
+			params[m[1]] = html.UnescapeString(m[2])
+			body = body[len(m[0]):]
+		}
+	}
+	return RenderMacroToBracketOpen(&MacroNode{Name: passthroughMacro, Parameters: params}) + escapePassthroughBody(body) + "[/MACRO]"
+}
+
+// escapePassthroughBody escapes the macro tags in a passthrough body.
+func escapePassthroughBody(body string) string {
+	return passthroughTagPattern.ReplaceAllString(body, `[\$1`)
+}
+
+// unescapePassthroughBody restores a passthrough body escaped by
+// escapePassthroughBody. ParseBracketMacros does so for every passthrough
+// macro it parses, so their bodies are storage format as written.
+func unescapePassthroughBody(body string) string {
+	return passthroughEscapedTagPattern.ReplaceAllString(body, "$1$2")
+}
+
+// parsePassthrough parses a passthrough macro in bracket syntax, as
+// replaceUnknownMacros writes them. Returns nil if it isn't one.
+func parsePassthrough(macro string) *MacroNode {
+	result, err := ParseBracketMacros(string(maskCodeBrackets([]byte(macro))))
+	if err != nil {
+		return nil
+	}
+	for _, seg := range result.Segments {
+		if seg.Type == SegmentMacro && seg.Macro.Name == passthroughMacro {
+			unmaskMacro(seg.Macro)
+			return seg.Macro
+		}
+	}
+	return nil
+}
+
+// restoreUnknownMacros replaces passthrough placeholders with the macros.
+func restoreUnknownMacros(markdown string, macros []string) string {
+	if len(macros) == 0 {
+		return markdown
+	}
+	return passthroughPlaceholderPattern.ReplaceAllStringFunc(markdown, func(placeholder string) string {
+		id, err := strconv.Atoi(passthroughPlaceholderPattern.FindStringSubmatch(placeholder)[1])
+		if err != nil || id >= len(macros) {
+			return placeholder
+		}
+		return macros[id]
+	})
+}
+
+// passthroughXML rebuilds the storage format of a passed through macro.
+func passthroughXML(node *MacroNode) string {
+	params := make(map[string]string, len(node.Parameters))
+	for k, v := range node.Parameters {
+		params[k] = v
+	}
+	name, id := params["name"], params[passthroughIDParam]
+	delete(params, "name")
+	delete(params, passthroughIDParam)
+
+	// The parameters render like any macro's; the body is kept as written
+	xml := RenderMacroToXML(&MacroNode{Name: name, Parameters: params})
+	if id != "" {
+		xml = strings.Replace(xml, ` ac:schema-version="1">`, ` ac:schema-version="1" ac:macro-id="`+escapeXML(id)+`">`, 1)
+	}
+	return strings.TrimSuffix(xml, "</ac:structured-macro>") + node.Body + "</ac:structured-macro>"
+}
+
+// passthroughRanges returns the source ranges of passed through macros,
+// whose bodies are storage format rather than markdown. Each range is
+// followed by the range of the macro's body.
+func passthroughRanges(markdown []byte) [][]int {
+	return passthroughBracketPattern.FindAllSubmatchIndex(markdown, -1)
+}
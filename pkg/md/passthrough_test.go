@@ -0,0 +1,136 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromConfluenceStorage_UnknownMacros(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "parameters and body",
+			input:    `<ac:structured-macro ac:name="roadmap" ac:schema-version="1" ac:macro-id="abc"><ac:parameter ac:name="title">Q1 &amp; Q2</ac:parameter><ac:rich-text-body><p>Plan</p></ac:rich-text-body></ac:structured-macro>`,
+			expected: `[MACRO macro-id=abc name=roadmap title="Q1 & Q2"]<ac:rich-text-body><p>Plan</p></ac:rich-text-body>[/MACRO]`,
+		},
+		{
+			name:     "self-closing",
+			input:    `<p>Here<ac:structured-macro ac:name="anchor" /> there</p>`,
+			expected: `Here[MACRO name=anchor][/MACRO] there`,
+		},
+		{
+			name:     "nested macros stay in the body",
+			input:    `<ac:structured-macro ac:name="section"><ac:rich-text-body><ac:structured-macro ac:name="column"><ac:rich-text-body><p>A</p></ac:rich-text-body></ac:structured-macro></ac:rich-text-body></ac:structured-macro>`,
+			expected: `[MACRO name=section]<ac:rich-text-body><ac:structured-macro ac:name="column"><ac:rich-text-body><p>A</p></ac:rich-text-body></ac:structured-macro></ac:rich-text-body>[/MACRO]`,
+		},
+		{
+			name:     "parameter linking to a page stays in the body",
+			input:    `<ac:structured-macro ac:name="pagetree"><ac:parameter ac:name="root"><ac:link><ri:page ri:content-title="Home" /></ac:link></ac:parameter></ac:structured-macro>`,
+			expected: `[MACRO name=pagetree]<ac:parameter ac:name="root"><ac:link><ri:page ri:content-title="Home" /></ac:link></ac:parameter>[/MACRO]`,
+		},
+		{
+			name:     "inside a known macro",
+			input:    `<ac:structured-macro ac:name="info"><ac:rich-text-body><p>See <ac:structured-macro ac:name="anchor"><ac:parameter ac:name="">top</ac:parameter></ac:structured-macro></p></ac:rich-text-body></ac:structured-macro>`,
+			expected: "[INFO]\n\nSee [MACRO name=anchor]<ac:parameter ac:name=\"\">top</ac:parameter>[/MACRO]\n\n[/INFO]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromConfluenceStorageWithOptions(tt.input, ConvertOptions{ShowMacros: true})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestFromConfluenceStorage_UnknownMacrosStripped(t *testing.T) {
+	got, err := FromConfluenceStorage(`<p>Before</p><ac:structured-macro ac:name="roadmap"><ac:rich-text-body><p>Plan</p></ac:rich-text-body></ac:structured-macro><p>After</p>`)
+	require.NoError(t, err)
+	assert.NotContains(t, got, "MACRO")
+}
+
+func TestUnknownMacros_StorageRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage string
+	}{
+		{
+			name:    "block",
+			storage: `<ac:structured-macro ac:name="roadmap" ac:schema-version="1"><ac:parameter ac:name="title">Q1 &amp; Q2</ac:parameter><ac:rich-text-body><p>Plan <strong>[x]</strong> PROJ-1</p></ac:rich-text-body></ac:structured-macro>`,
+		},
+		{
+			name:    "plain text body",
+			storage: `<ac:structured-macro ac:name="html" ac:schema-version="1"><ac:plain-text-body><![CDATA[<b>*raw*</b>]]></ac:plain-text-body></ac:structured-macro>`,
+		},
+		{
+			name:    "inline",
+			storage: `<p>Jump <ac:structured-macro ac:name="anchor" ac:schema-version="1"><ac:parameter ac:name="">top</ac:parameter></ac:structured-macro> here</p>`,
+		},
+		{
+			name:    "macro ID",
+			storage: `<ac:structured-macro ac:name="roadmap" ac:schema-version="1" ac:macro-id="3f2a-91bc"><ac:rich-text-body><p>Plan</p></ac:rich-text-body></ac:structured-macro>`,
+		},
+		{
+			name:    "parameters with brackets and slashes",
+			storage: `<ac:structured-macro ac:name="roadmap" ac:schema-version="1"><ac:parameter ac:name="link">https://example.com/a</ac:parameter><ac:parameter ac:name="title">[Q1] plan</ac:parameter><ac:parameter ac:name="when">]now[</ac:parameter><ac:rich-text-body><p>Plan</p></ac:rich-text-body></ac:structured-macro>`,
+		},
+		{
+			name:    "macro tags in the body",
+			storage: `<ac:structured-macro ac:name="html" ac:schema-version="1"><ac:plain-text-body><![CDATA[[MACRO name=x]a[/MACRO] [\/macro] [/MACROS]]]></ac:plain-text-body></ac:structured-macro>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markdown, err := FromConfluenceStorageWithOptions(tt.storage, ConvertOptions{ShowMacros: true})
+			require.NoError(t, err)
+			back, err := ToConfluenceStorage([]byte(markdown))
+			require.NoError(t, err)
+			assert.Equal(t, tt.storage, trimNewline(back))
+		})
+	}
+}
+
+func TestPassthroughBody_UnescapedForEveryConverter(t *testing.T) {
+	body := `<ac:plain-text-body><![CDATA[a [/MACRO] b]]></ac:plain-text-body>`
+	storage := `<ac:structured-macro ac:name="html" ac:schema-version="1">` + body + `</ac:structured-macro>`
+
+	markdown, err := FromConfluenceStorageWithOptions(storage, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Equal(t, `[MACRO name=html]<ac:plain-text-body><![CDATA[a [\/MACRO] b]]></ac:plain-text-body>[/MACRO]`, markdown)
+
+	result, err := ParseBracketMacros(markdown)
+	require.NoError(t, err)
+	require.Len(t, result.GetMacros(), 1)
+	assert.Equal(t, body, result.GetMacros()[0].Body, "parsed body")
+
+	got, err := ToConfluenceStorage([]byte(markdown))
+	require.NoError(t, err)
+	assert.Equal(t, storage, trimNewline(got), "markdown to storage")
+
+	adf, err := StorageToADF(storage)
+	require.NoError(t, err)
+	assert.NotContains(t, adf, `\\/MACRO`, "storage to ADF")
+	got, err = ADFToStorage(adf)
+	require.NoError(t, err)
+	assert.NotContains(t, got, `\/MACRO`, "ADF to storage")
+
+	assert.Equal(t, []string{"macro html"}, UnconvertedStorage(storage))
+	assert.Empty(t, UnconvertedStorage(`<ac:structured-macro ac:name="x"><ac:rich-text-body><p>a [/MACRO] b</p></ac:rich-text-body></ac:structured-macro>`))
+}
+
+func TestLinkers_SkipPassthroughMacros(t *testing.T) {
+	input := "API and PROJ-1 [MACRO name=x]<ac:rich-text-body><p>API PROJ-2</p></ac:rich-text-body>[/MACRO]"
+
+	got := LinkJiraIssues([]byte(input), JiraOptions{Projects: []string{"PROJ"}})
+	assert.Equal(t, "API and [JIRA key=PROJ-1] [MACRO name=x]<ac:rich-text-body><p>API PROJ-2</p></ac:rich-text-body>[/MACRO]", string(got))
+
+	got = LinkGlossaryTerms([]byte("[MACRO name=x]<p>API</p>[/MACRO] API"), map[string]string{"API": "https://example.com/api"})
+	assert.Equal(t, "[MACRO name=x]<p>API</p>[/MACRO] [API](https://example.com/api)", string(got))
+}
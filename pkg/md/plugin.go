@@ -0,0 +1,119 @@
+// plugin.go defines the extension interface for customizing conversions.
+package md
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Direction identifies the conversion a plugin hook is running in.
+type Direction string
+
+const (
+	DirectionToStorage   Direction = "markdown-to-storage" // ToConfluenceStorage
+	DirectionFromStorage Direction = "storage-to-markdown" // FromConfluenceStorage*
+	DirectionToADF       Direction = "markdown-to-adf"     // ToADF
+	DirectionFromADF     Direction = "adf-to-markdown"     // FromADF*
+)
+
+// Plugin extends markdown conversion without patching this package.
+//
+// Hooks run in registration order, in every Direction:
+//   - PreParse receives the raw input (markdown, XHTML for DirectionFromStorage,
+//     or ADF JSON for DirectionFromADF) before any parsing happens.
+//   - TransformMacro receives each parsed macro and may modify its name,
+//     parameters, or body in place before it is rendered. It is not called
+//     for macros stripped rather than shown when converting to markdown, or
+//     for status macros converted to ADF.
+//   - PostRender receives the final output (XHTML, markdown, or ADF JSON).
+//
+// Embed NopPlugin to implement only the hooks you need.
+type Plugin interface {
+	Name() string
+	PreParse(dir Direction, input []byte) ([]byte, error)
+	TransformMacro(dir Direction, node *MacroNode)
+	PostRender(dir Direction, output string) (string, error)
+}
+
+// NopPlugin implements every Plugin hook as a no-op.
+type NopPlugin struct{}
+
+// PreParse returns the input unchanged.
+func (NopPlugin) PreParse(_ Direction, input []byte) ([]byte, error) { return input, nil }
+
+// TransformMacro leaves the node unchanged.
+func (NopPlugin) TransformMacro(_ Direction, _ *MacroNode) {}
+
+// PostRender returns the output unchanged.
+func (NopPlugin) PostRender(_ Direction, output string) (string, error) { return output, nil }
+
+var (
+	pluginsMu sync.RWMutex
+	plugins   []Plugin
+)
+
+// RegisterPlugin adds a plugin to every subsequent conversion.
+// Registering a second plugin with the same name replaces the first.
+func RegisterPlugin(p Plugin) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+
+	for i, existing := range plugins {
+		if existing.Name() == p.Name() {
+			plugins[i] = p
+			return
+		}
+	}
+	plugins = append(plugins, p)
+}
+
+// UnregisterPlugin removes a plugin by name. Returns false if it was not registered.
+func UnregisterPlugin(name string) bool {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+
+	for i, p := range plugins {
+		if p.Name() == name {
+			plugins = append(plugins[:i:i], plugins[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// RegisteredPlugins returns the registered plugins in execution order.
+func RegisteredPlugins() []Plugin {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+
+	return append([]Plugin(nil), plugins...)
+}
+
+// runPreParse passes input through every plugin's PreParse hook.
+func runPreParse(dir Direction, input []byte) ([]byte, error) {
+	for _, p := range RegisteredPlugins() {
+		var err error
+		if input, err = p.PreParse(dir, input); err != nil {
+			return nil, fmt.Errorf("plugin %s: %w", p.Name(), err)
+		}
+	}
+	return input, nil
+}
+
+// runTransformMacro passes a macro node through every plugin's TransformMacro hook.
+func runTransformMacro(dir Direction, node *MacroNode) {
+	for _, p := range RegisteredPlugins() {
+		p.TransformMacro(dir, node)
+	}
+}
+
+// runPostRender passes output through every plugin's PostRender hook.
+func runPostRender(dir Direction, output string) (string, error) {
+	for _, p := range RegisteredPlugins() {
+		var err error
+		if output, err = p.PostRender(dir, output); err != nil {
+			return "", fmt.Errorf("plugin %s: %w", p.Name(), err)
+		}
+	}
+	return output, nil
+}
@@ -0,0 +1,121 @@
+package md
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPlugin upper-cases macro titles and records the hooks it saw.
+type recordingPlugin struct {
+	NopPlugin
+	name  string
+	calls []string
+}
+
+func (p *recordingPlugin) Name() string { return p.name }
+
+func (p *recordingPlugin) PreParse(dir Direction, input []byte) ([]byte, error) {
+	p.calls = append(p.calls, "pre:"+string(dir))
+	return input, nil
+}
+
+func (p *recordingPlugin) TransformMacro(dir Direction, node *MacroNode) {
+	p.calls = append(p.calls, "macro:"+node.Name)
+	if title, ok := node.Parameters["title"]; ok {
+		node.Parameters["title"] = strings.ToUpper(title)
+	}
+}
+
+func (p *recordingPlugin) PostRender(dir Direction, output string) (string, error) {
+	p.calls = append(p.calls, "post:"+string(dir))
+	return output + "<!-- plugin -->", nil
+}
+
+func registerTestPlugin(t *testing.T, p Plugin) {
+	t.Helper()
+	RegisterPlugin(p)
+	t.Cleanup(func() { UnregisterPlugin(p.Name()) })
+}
+
+func TestPlugin_ToConfluenceStorage(t *testing.T) {
+	p := &recordingPlugin{name: "recorder"}
+	registerTestPlugin(t, p)
+
+	result, err := ToConfluenceStorage([]byte("[INFO title=note]Hello[/INFO]"))
+	require.NoError(t, err)
+
+	assert.Contains(t, result, ">NOTE</ac:parameter>")
+	assert.True(t, strings.HasSuffix(result, "<!-- plugin -->"))
+	assert.Equal(t, []string{"pre:markdown-to-storage", "macro:info", "post:markdown-to-storage"}, p.calls)
+}
+
+func TestPlugin_FromConfluenceStorage(t *testing.T) {
+	p := &recordingPlugin{name: "recorder"}
+	registerTestPlugin(t, p)
+
+	input := `<ac:structured-macro ac:name="info"><ac:parameter ac:name="title">note</ac:parameter><ac:rich-text-body><p>Hi</p></ac:rich-text-body></ac:structured-macro>`
+	result, err := FromConfluenceStorageWithOptions(input, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+
+	assert.Contains(t, result, "[INFO title=NOTE]")
+	assert.Equal(t, []string{"pre:storage-to-markdown", "macro:info", "post:storage-to-markdown"}, p.calls)
+}
+
+func TestPlugin_ToADF(t *testing.T) {
+	p := &recordingPlugin{name: "recorder"}
+	registerTestPlugin(t, p)
+
+	result, err := ToADF([]byte("[EXPAND title=more]Hello[/EXPAND]"))
+	require.NoError(t, err)
+
+	assert.Contains(t, result, `"title":"MORE"`)
+	assert.Equal(t, []string{"pre:markdown-to-adf", "macro:expand", "post:markdown-to-adf"}, p.calls)
+}
+
+func TestPlugin_FromADF(t *testing.T) {
+	p := &recordingPlugin{name: "recorder"}
+	registerTestPlugin(t, p)
+
+	input := `{"type":"doc","version":1,"content":[{"type":"extension","attrs":{"extensionKey":"toc",` +
+		`"parameters":{"macroParams":{"title":{"value":"contents"}}}}}]}`
+	result, err := FromADFWithOptions(input, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "[TOC title=CONTENTS]<!-- plugin -->", result)
+	assert.Equal(t, []string{"pre:adf-to-markdown", "macro:toc", "post:adf-to-markdown"}, p.calls)
+}
+
+type failingPlugin struct{ NopPlugin }
+
+func (failingPlugin) Name() string { return "failing" }
+
+func (failingPlugin) PreParse(Direction, []byte) ([]byte, error) {
+	return nil, errors.New("boom")
+}
+
+func TestPlugin_ErrorIsWrapped(t *testing.T) {
+	registerTestPlugin(t, failingPlugin{})
+
+	_, err := ToConfluenceStorage([]byte("Hello"))
+	require.Error(t, err)
+	assert.Equal(t, "plugin failing: boom", err.Error())
+}
+
+func TestRegisterPlugin_ReplacesSameName(t *testing.T) {
+	first := &recordingPlugin{name: "dup"}
+	second := &recordingPlugin{name: "dup"}
+	registerTestPlugin(t, first)
+	registerTestPlugin(t, second)
+
+	registered := RegisteredPlugins()
+	require.Len(t, registered, 1)
+	assert.Same(t, second, registered[0])
+}
+
+func TestUnregisterPlugin_Unknown(t *testing.T) {
+	assert.False(t, UnregisterPlugin("does-not-exist"))
+}
@@ -0,0 +1,74 @@
+// preview.go renders Confluence storage format as browser-viewable HTML.
+package md
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// StorageToPreviewHTML converts Confluence storage format (XHTML) into plain
+// HTML that a browser can display. Structured macros, which browsers do not
+// understand, are rendered as styled approximations of their Confluence
+// appearance (panels, code blocks, expand sections) so authors can preview a
+// page before publishing it.
+func StorageToPreviewHTML(storage string) string {
+	result, err := ParseConfluenceXML(storage)
+	if err != nil {
+		return storage
+	}
+
+	var sb strings.Builder
+	for _, seg := range result.Segments {
+		switch seg.Type {
+		case SegmentText:
+			sb.WriteString(seg.Text)
+		case SegmentMacro:
+			sb.WriteString(renderMacroPreview(seg.Macro))
+		}
+	}
+	return sb.String()
+}
+
+// renderMacroPreview renders a single macro (and its nested macros) as HTML.
+func renderMacroPreview(node *MacroNode) string {
+	body := node.Body
+	for i, child := range node.Children {
+		marker := fmt.Sprintf("%s%d", xmlChildPlaceholderPrefix, i)
+		body = strings.Replace(body, marker, renderMacroPreview(child), 1)
+	}
+
+	title := node.Parameters["title"]
+
+	switch node.Name {
+	case "code":
+		lang := node.Parameters["language"]
+		return fmt.Sprintf(`<pre class="cfl-code" data-language="%s"><code>%s</code></pre>`,
+			html.EscapeString(lang), html.EscapeString(body))
+	case "info", "note", "warning", "tip":
+		var sb strings.Builder
+		fmt.Fprintf(&sb, `<div class="cfl-panel cfl-panel-%s">`, node.Name)
+		if title != "" {
+			fmt.Fprintf(&sb, `<div class="cfl-panel-title">%s</div>`, html.EscapeString(title))
+		}
+		sb.WriteString(body)
+		sb.WriteString(`</div>`)
+		return sb.String()
+	case "expand":
+		if title == "" {
+			title = "Click here to expand..."
+		}
+		return fmt.Sprintf(`<details class="cfl-expand"><summary>%s</summary>%s</details>`,
+			html.EscapeString(title), body)
+	case "toc":
+		return `<nav class="cfl-toc">Table of contents</nav>`
+	case "excerpt":
+		if node.Parameters["hidden"] == "true" {
+			return fmt.Sprintf(`<aside class="cfl-excerpt" title="Page excerpt (hidden on page)">%s</aside>`, body)
+		}
+		return body
+	default:
+		return fmt.Sprintf(`<div class="cfl-macro" data-macro="%s"><div class="cfl-macro-title">%s macro</div>%s</div>`,
+			html.EscapeString(node.Name), html.EscapeString(node.Name), body)
+	}
+}
@@ -0,0 +1,61 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageToPreviewHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "plain html passes through",
+			input:    "<h1>Title</h1><p>Hello</p>",
+			contains: []string{"<h1>Title</h1><p>Hello</p>"},
+		},
+		{
+			name:     "info panel with title",
+			input:    `<ac:structured-macro ac:name="info"><ac:parameter ac:name="title">Heads up</ac:parameter><ac:rich-text-body><p>Body</p></ac:rich-text-body></ac:structured-macro>`,
+			contains: []string{`class="cfl-panel cfl-panel-info"`, `<div class="cfl-panel-title">Heads up</div>`, "<p>Body</p>"},
+			excludes: []string{"ac:structured-macro"},
+		},
+		{
+			name:     "code block is escaped",
+			input:    `<ac:structured-macro ac:name="code"><ac:parameter ac:name="language">go</ac:parameter><ac:plain-text-body><![CDATA[if a < b {}]]></ac:plain-text-body></ac:structured-macro>`,
+			contains: []string{`data-language="go"`, "if a &lt; b {}"},
+		},
+		{
+			name:     "expand uses details",
+			input:    `<ac:structured-macro ac:name="expand"><ac:parameter ac:name="title">More</ac:parameter><ac:rich-text-body><p>Hidden</p></ac:rich-text-body></ac:structured-macro>`,
+			contains: []string{"<details", "<summary>More</summary>", "<p>Hidden</p>"},
+		},
+		{
+			name:     "nested macros",
+			input:    `<ac:structured-macro ac:name="expand"><ac:rich-text-body><ac:structured-macro ac:name="warning"><ac:rich-text-body><p>Inner</p></ac:rich-text-body></ac:structured-macro></ac:rich-text-body></ac:structured-macro>`,
+			contains: []string{"<details", "cfl-panel-warning", "<p>Inner</p>"},
+			excludes: []string{"CFXMLCHILD"},
+		},
+		{
+			name:     "unknown macro",
+			input:    `<ac:structured-macro ac:name="jira"><ac:parameter ac:name="key">ABC-1</ac:parameter></ac:structured-macro>`,
+			contains: []string{`data-macro="jira"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StorageToPreviewHTML(tt.input)
+			for _, s := range tt.contains {
+				assert.Contains(t, got, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, got, s)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+// props.go reads and edits the rows of page properties (details macro) tables.
+package md
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// propertiesMacro is the name of the page properties macro.
+const propertiesMacro = "details"
+
+// ErrNoPropertiesTable is returned when a page has no page properties table
+// (with the requested id).
+var ErrNoPropertiesTable = errors.New("no page properties table found")
+
+// Property is a key/value row of a page properties table.
+type Property struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+var (
+	// propsRowPattern matches a table row in storage format.
+	propsRowPattern = regexp.MustCompile(`(?s)<tr\b[^>]*>(.*?)</tr>`)
+	// propsCellPattern matches a header or data cell, capturing its content.
+	propsCellPattern = regexp.MustCompile(`(?s)<(?:th|td)\b[^>]*>(.*?)</(?:th|td)>`)
+	// propsBlockTagPattern matches the tags of block elements, which separate words.
+	propsBlockTagPattern = regexp.MustCompile(`</?(?:p|br|div|li|ul|ol|h[1-6])\b[^>]*>`)
+	// propsTagPattern matches any tag, for reducing cell content to text.
+	propsTagPattern = regexp.MustCompile(`<[^>]*>`)
+)
+
+// StorageProperties returns the rows of a page properties table in storage
+// format. id selects the table by its id parameter; "" selects the first.
+func StorageProperties(storage, id string) ([]Property, error) {
+	start, end, err := storagePropertiesBody(storage, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var props []Property
+	for _, row := range propsRowPattern.FindAllStringSubmatch(storage[start:end], -1) {
+		cells := propsCellPattern.FindAllStringSubmatch(row[1], 2)
+		if len(cells) < 2 {
+			continue
+		}
+		props = append(props, Property{Key: StorageText(cells[0][1]), Value: StorageText(cells[1][1])})
+	}
+	return props, nil
+}
+
+// SetStorageProperty sets the value of the row with the given key in a page
+// properties table in storage format, adding a row when there is none. Keys
+// are matched case-insensitively; the rest of the page is left untouched.
+func SetStorageProperty(storage, id, key, value string) (string, error) {
+	start, end, err := storagePropertiesBody(storage, id)
+	if err != nil {
+		return "", err
+	}
+	body := storage[start:end]
+	cellContent := "<p>" + escapeXML(value) + "</p>"
+
+	for _, row := range propsRowPattern.FindAllStringSubmatchIndex(body, -1) {
+		cells := propsCellPattern.FindAllStringSubmatchIndex(body[row[2]:row[3]], 2)
+		if len(cells) < 2 || !strings.EqualFold(StorageText(body[row[2]+cells[0][2]:row[2]+cells[0][3]]), key) {
+			continue
+		}
+		valueStart, valueEnd := start+row[2]+cells[1][2], start+row[2]+cells[1][3]
+		return storage[:valueStart] + cellContent + storage[valueEnd:], nil
+	}
+
+	// New rows go at the end of the table
+	newRow := "<tr><th><p>" + escapeXML(key) + "</p></th><td>" + cellContent + "</td></tr>"
+	at := strings.LastIndex(body, "</tbody>")
+	if at < 0 {
+		at = strings.LastIndex(body, "</table>")
+	}
+	if at < 0 {
+		return "", fmt.Errorf("page properties table has no table to add %q to", key)
+	}
+	at += start
+	return storage[:at] + newRow + storage[at:], nil
+}
+
+// storagePropertiesBody returns the byte range of the rich text body of the
+// selected page properties macro.
+func storagePropertiesBody(storage, id string) (int, int, error) {
+	tokens, err := TokenizeConfluenceXML(storage)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The macros open around the current token, innermost last
+	type frame struct {
+		details   bool
+		bodyStart int
+	}
+	var stack []*frame
+	for _, tok := range tokens {
+		switch tok.Type {
+		case XMLTokenOpenTag:
+			stack = append(stack, &frame{details: tok.MacroName == propertiesMacro, bodyStart: -1})
+		case XMLTokenCloseTag:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case XMLTokenBody:
+			if len(stack) > 0 && stack[len(stack)-1].details && tok.Value == "rich-text" {
+				stack[len(stack)-1].bodyStart = tok.Position + len("<ac:rich-text-body>")
+			}
+		case XMLTokenBodyEnd:
+			if len(stack) == 0 {
+				continue
+			}
+			if top := stack[len(stack)-1]; top.details && top.bodyStart >= 0 && macroHasID(storage[:top.bodyStart], id) {
+				return top.bodyStart, tok.Position, nil
+			}
+		}
+	}
+	return 0, 0, ErrNoPropertiesTable
+}
+
+// macroHasID reports whether the macro whose opening tag and parameters end
+// storage has the given id parameter. Any macro matches an empty id.
+func macroHasID(storage, id string) bool {
+	if id == "" {
+		return true
+	}
+	open := strings.LastIndex(storage, "<ac:structured-macro")
+	if open < 0 {
+		return false
+	}
+	for _, param := range paramPattern.FindAllStringSubmatch(storage[open:], -1) {
+		if param[1] == "id" && html.UnescapeString(param[2]) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// StorageText reduces storage format markup to its text, with blocks
+// separated by spaces.
+func StorageText(s string) string {
+	s = propsBlockTagPattern.ReplaceAllString(s, " ")
+	s = propsTagPattern.ReplaceAllString(s, "")
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
+
+// ADFProperties returns the rows of a page properties table in an ADF
+// document. id selects the table by its id parameter; "" selects the first.
+func ADFProperties(adf, id string) ([]Property, error) {
+	var doc ADFDocument
+	if err := json.Unmarshal([]byte(adf), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse ADF document: %w", err)
+	}
+	table := adfPropertiesTable(doc.Content, id)
+	if table == nil {
+		return nil, ErrNoPropertiesTable
+	}
+
+	var props []Property
+	for _, row := range table.Content {
+		if len(row.Content) < 2 {
+			continue
+		}
+		props = append(props, Property{Key: adfNodeText(row.Content[0]), Value: adfNodeText(row.Content[1])})
+	}
+	return props, nil
+}
+
+// SetADFProperty sets the value of the row with the given key in a page
+// properties table in an ADF document, adding a row when there is none.
+// Keys are matched case-insensitively.
+func SetADFProperty(adf, id, key, value string) (string, error) {
+	var doc ADFDocument
+	if err := json.Unmarshal([]byte(adf), &doc); err != nil {
+		return "", fmt.Errorf("failed to parse ADF document: %w", err)
+	}
+	table := adfPropertiesTable(doc.Content, id)
+	if table == nil {
+		return "", ErrNoPropertiesTable
+	}
+
+	updated := false
+	for _, row := range table.Content {
+		if len(row.Content) >= 2 && strings.EqualFold(adfNodeText(row.Content[0]), key) {
+			row.Content[1].Content = []*ADFNode{adfParagraph(value)}
+			updated = true
+			break
+		}
+	}
+	if !updated {
+		table.Content = append(table.Content, &ADFNode{
+			Type: "tableRow",
+			Content: []*ADFNode{
+				{Type: "tableHeader", Content: []*ADFNode{adfParagraph(key)}},
+				{Type: "tableCell", Content: []*ADFNode{adfParagraph(value)}},
+			},
+		})
+	}
+
+	result, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+// adfPropertiesTable returns the table of the first page properties
+// extension with the given id ("" for any), searching nested content.
+func adfPropertiesTable(nodes []*ADFNode, id string) *ADFNode {
+	for _, n := range nodes {
+		if n.Type == "bodiedExtension" && adfStringAttr(n, "extensionKey") == propertiesMacro &&
+			(id == "" || adfMacroParams(n)["id"] == id) {
+			for _, child := range n.Content {
+				if child.Type == "table" {
+					return child
+				}
+			}
+		}
+		if table := adfPropertiesTable(n.Content, id); table != nil {
+			return table
+		}
+	}
+	return nil
+}
+
+// adfNodeText returns the text of a node and its descendants, with blocks
+// separated by spaces. Status lozenges give their text, as they do in
+// storage format.
+func adfNodeText(n *ADFNode) string {
+	var sb strings.Builder
+	var walk func(*ADFNode)
+	walk = func(n *ADFNode) {
+		sb.WriteString(n.Text)
+		if n.Type == "status" {
+			sb.WriteString(adfStringAttr(n, "text"))
+		}
+		if len(n.Content) > 0 {
+			sb.WriteString(" ")
+		}
+		for _, child := range n.Content {
+			walk(child)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// adfParagraph returns a paragraph of plain text; empty text gives an empty
+// paragraph, as ADF doesn't allow empty text nodes.
+func adfParagraph(text string) *ADFNode {
+	if text == "" {
+		return &ADFNode{Type: "paragraph"}
+	}
+	return &ADFNode{Type: "paragraph", Content: []*ADFNode{{Type: "text", Text: text}}}
+}
@@ -0,0 +1,148 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const propsStorage = `<p>Intro</p>` +
+	`<ac:structured-macro ac:name="details" ac:schema-version="1"><ac:parameter ac:name="id">release</ac:parameter><ac:rich-text-body>` +
+	`<table><tbody>` +
+	`<tr><th><p>Owner</p></th><td><p>Ann &amp; Bo</p></td></tr>` +
+	`<tr><th><p>Status</p></th><td><ac:structured-macro ac:name="status"><ac:parameter ac:name="title">DRAFT</ac:parameter></ac:structured-macro></td></tr>` +
+	`<tr><th><p>Due</p></th><td><p>2026-<strong>10</strong>-30</p></td></tr>` +
+	`</tbody></table>` +
+	`</ac:rich-text-body></ac:structured-macro>` +
+	`<ac:structured-macro ac:name="details"><ac:parameter ac:name="id">other</ac:parameter><ac:rich-text-body>` +
+	`<table><tbody><tr><th>Team</th><td>Core</td></tr></tbody></table>` +
+	`</ac:rich-text-body></ac:structured-macro>`
+
+func TestStorageProperties(t *testing.T) {
+	props, err := StorageProperties(propsStorage, "")
+	require.NoError(t, err)
+	assert.Equal(t, []Property{
+		{Key: "Owner", Value: "Ann & Bo"},
+		{Key: "Status", Value: "DRAFT"},
+		{Key: "Due", Value: "2026-10-30"},
+	}, props)
+
+	props, err = StorageProperties(propsStorage, "other")
+	require.NoError(t, err)
+	assert.Equal(t, []Property{{Key: "Team", Value: "Core"}}, props)
+
+	_, err = StorageProperties(propsStorage, "missing")
+	assert.ErrorIs(t, err, ErrNoPropertiesTable)
+
+	_, err = StorageProperties(`<p>No table</p>`, "")
+	assert.ErrorIs(t, err, ErrNoPropertiesTable)
+}
+
+func TestSetStorageProperty(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		key   string
+		value string
+		want  []Property
+	}{
+		{
+			name:  "existing row, any case",
+			key:   "status",
+			value: "In Progress <now>",
+			want: []Property{
+				{Key: "Owner", Value: "Ann & Bo"},
+				{Key: "Status", Value: "In Progress <now>"},
+				{Key: "Due", Value: "2026-10-30"},
+			},
+		},
+		{
+			name:  "new row",
+			key:   "Reviewer",
+			value: "Cy",
+			want: []Property{
+				{Key: "Owner", Value: "Ann & Bo"},
+				{Key: "Status", Value: "DRAFT"},
+				{Key: "Due", Value: "2026-10-30"},
+				{Key: "Reviewer", Value: "Cy"},
+			},
+		},
+		{
+			name:  "table by id",
+			id:    "other",
+			key:   "Team",
+			value: "Platform",
+			want:  []Property{{Key: "Team", Value: "Platform"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, err := SetStorageProperty(propsStorage, tt.id, tt.key, tt.value)
+			require.NoError(t, err)
+			assert.Contains(t, storage, "<p>Intro</p>")
+
+			props, err := StorageProperties(storage, tt.id)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, props)
+		})
+	}
+}
+
+func TestSetStorageProperty_LeavesOtherTablesAlone(t *testing.T) {
+	storage, err := SetStorageProperty(propsStorage, "", "Owner", "Dee")
+	require.NoError(t, err)
+
+	props, err := StorageProperties(storage, "other")
+	require.NoError(t, err)
+	assert.Equal(t, []Property{{Key: "Team", Value: "Core"}}, props)
+	assert.Contains(t, storage, `<ac:parameter ac:name="title">DRAFT</ac:parameter>`)
+}
+
+const propsADF = `{"type": "doc", "version": 1, "content": [
+	{"type": "paragraph", "content": [{"type": "text", "text": "Intro"}]},
+	{"type": "layoutSection", "content": [{"type": "layoutColumn", "content": [
+		{"type": "bodiedExtension", "attrs": {"extensionType": "com.atlassian.confluence.macro.core", "extensionKey": "details",
+			"parameters": {"macroParams": {"id": {"value": "release"}}}},
+		"content": [{"type": "table", "content": [
+			{"type": "tableRow", "content": [
+				{"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Owner"}]}]},
+				{"type": "tableCell", "content": [{"type": "paragraph", "content": [
+					{"type": "text", "text": "Ann"}, {"type": "text", "text": "Bo", "marks": [{"type": "strong"}]}]}]}
+			]},
+			{"type": "tableRow", "content": [
+				{"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Status"}]}]},
+				{"type": "tableCell", "content": [{"type": "paragraph", "content": [{"type": "status", "attrs": {"text": "DRAFT"}}]}]}
+			]}
+		]}]}
+	]}]}
+]}`
+
+func TestADFProperties(t *testing.T) {
+	props, err := ADFProperties(propsADF, "")
+	require.NoError(t, err)
+	assert.Equal(t, []Property{
+		{Key: "Owner", Value: "AnnBo"},
+		{Key: "Status", Value: "DRAFT"},
+	}, props)
+
+	_, err = ADFProperties(propsADF, "other")
+	assert.ErrorIs(t, err, ErrNoPropertiesTable)
+}
+
+func TestSetADFProperty(t *testing.T) {
+	adf, err := SetADFProperty(propsADF, "release", "STATUS", "In Progress")
+	require.NoError(t, err)
+	adf, err = SetADFProperty(adf, "release", "Due", "")
+	require.NoError(t, err)
+
+	props, err := ADFProperties(adf, "")
+	require.NoError(t, err)
+	assert.Equal(t, []Property{
+		{Key: "Owner", Value: "AnnBo"},
+		{Key: "Status", Value: "In Progress"},
+		{Key: "Due", Value: ""},
+	}, props)
+	assert.Contains(t, adf, `"text":"Intro"`)
+}
@@ -23,17 +23,21 @@ func RenderMacroToXML(node *MacroNode) string {
 	}
 	sort.Strings(keys)
 
+	macroType, _ := LookupMacro(node.Name)
 	for _, key := range keys {
 		value := node.Parameters[key]
+		if key == "page" && macroType.PageParam {
+			sb.WriteString(pageParamXML(macroType.storageParam(key), value))
+			continue
+		}
 		sb.WriteString(`<ac:parameter ac:name="`)
-		sb.WriteString(key)
+		sb.WriteString(macroType.storageParam(key))
 		sb.WriteString(`">`)
 		sb.WriteString(escapeXML(value))
 		sb.WriteString(`</ac:parameter>`)
 	}
 
 	// Body content
-	macroType, _ := LookupMacro(node.Name)
 	if macroType.HasBody && node.Body != "" {
 		switch macroType.BodyType {
 		case BodyTypeRichText:
@@ -99,7 +103,9 @@ func RenderMacroToBracketOpen(node *MacroNode) string {
 		sb.WriteString(" ")
 		sb.WriteString(key)
 		sb.WriteString("=")
-		if strings.ContainsAny(value, " \t\n\"") {
+		// Values the tokenizer would end early, such as ones holding a
+		// bracket or a slash, are quoted
+		if strings.ContainsAny(value, " \t\n\"'[]/") {
 			sb.WriteString(`"`)
 			sb.WriteString(strings.ReplaceAll(value, `"`, `\"`))
 			sb.WriteString(`"`)
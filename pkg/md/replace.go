@@ -0,0 +1,106 @@
+// replace.go finds and replaces text in page bodies, leaving their markup
+// alone.
+package md
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// replaceSkipElements are storage format elements whose text is markup
+// rather than page text: macro parameters and emoticon fallbacks.
+var replaceSkipElements = map[string]bool{
+	"ac:parameter": true,
+	"ac:emoticon":  true,
+}
+
+// storageTextEscaper escapes text for storage format.
+var storageTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// ReplaceStorageText replaces the matches of re in the text of a storage
+// format body with repl, which may refer to submatches as in
+// regexp.Regexp.Expand, and returns the result and the number of matches.
+// Only text between tags is changed: tags, attributes, macro parameters and
+// CDATA sections, such as the bodies of code blocks, are left as they are.
+// Matches are found in one run of text, so text split by formatting is not
+// matched as a whole.
+func ReplaceStorageText(storage string, re *regexp.Regexp, repl string) (string, int) {
+	var sb strings.Builder
+	count, last, skip := 0, 0, 0
+	replaceText := func(text string) {
+		if skip > 0 || strings.TrimSpace(text) == "" {
+			sb.WriteString(text)
+			return
+		}
+		unescaped := html.UnescapeString(text)
+		n := len(re.FindAllStringIndex(unescaped, -1))
+		if n == 0 {
+			sb.WriteString(text)
+			return
+		}
+		count += n
+		sb.WriteString(storageTextEscaper.Replace(re.ReplaceAllString(unescaped, repl)))
+	}
+
+	for _, m := range splitMarkupPattern.FindAllStringSubmatchIndex(storage, -1) {
+		replaceText(storage[last:m[0]])
+		sb.WriteString(storage[m[0]:m[1]])
+		last = m[1]
+		if m[4] < 0 || m[7] > m[6] || !replaceSkipElements[storage[m[4]:m[5]]] {
+			continue
+		}
+		if m[3] > m[2] {
+			skip--
+		} else {
+			skip++
+		}
+	}
+	replaceText(storage[last:])
+
+	if count == 0 {
+		return storage, 0
+	}
+	return sb.String(), count
+}
+
+// ReplaceADFText replaces the matches of re in the text nodes of an ADF
+// document, as ReplaceStorageText does for storage format. The text of code
+// blocks is left as it is.
+func ReplaceADFText(adf string, re *regexp.Regexp, repl string) (string, int, error) {
+	var doc ADFDocument
+	if err := json.Unmarshal([]byte(adf), &doc); err != nil {
+		return "", 0, fmt.Errorf("failed to parse ADF document: %w", err)
+	}
+
+	count := replaceADFNodes(doc.Content, re, repl)
+	if count == 0 {
+		return adf, 0, nil
+	}
+	result, err := adfDocumentJSON(doc, doc.Content)
+	if err != nil {
+		return "", 0, err
+	}
+	return result, count, nil
+}
+
+// replaceADFNodes replaces the matches of re in the text nodes under
+// nodes, and returns the number of matches.
+func replaceADFNodes(nodes []*ADFNode, re *regexp.Regexp, repl string) int {
+	count := 0
+	for _, n := range nodes {
+		switch n.Type {
+		case "codeBlock":
+			continue
+		case "text":
+			if c := len(re.FindAllStringIndex(n.Text, -1)); c > 0 {
+				n.Text = re.ReplaceAllString(n.Text, repl)
+				count += c
+			}
+		}
+		count += replaceADFNodes(n.Content, re, repl)
+	}
+	return count
+}
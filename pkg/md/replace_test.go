@@ -0,0 +1,105 @@
+package md
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceStorageText(t *testing.T) {
+	tests := []struct {
+		name     string
+		storage  string
+		pattern  string
+		repl     string
+		expected string
+		count    int
+	}{
+		{
+			name:     "text",
+			storage:  `<p>Acme Widget and Acme Gadget</p>`,
+			pattern:  `Acme`,
+			repl:     "Globex",
+			expected: `<p>Globex Widget and Globex Gadget</p>`,
+			count:    2,
+		},
+		{
+			name:     "attributes are left alone",
+			storage:  `<p><a href="https://acme.example.com">acme docs</a></p>`,
+			pattern:  `acme`,
+			repl:     "globex",
+			expected: `<p><a href="https://acme.example.com">globex docs</a></p>`,
+			count:    1,
+		},
+		{
+			name:     "macro parameters and code are left alone",
+			storage:  `<ac:structured-macro ac:name="code"><ac:parameter ac:name="title">Acme</ac:parameter><ac:plain-text-body><![CDATA[acme := Acme{}]]></ac:plain-text-body></ac:structured-macro><p>Acme</p>`,
+			pattern:  `Acme`,
+			repl:     "Globex",
+			expected: `<ac:structured-macro ac:name="code"><ac:parameter ac:name="title">Acme</ac:parameter><ac:plain-text-body><![CDATA[acme := Acme{}]]></ac:plain-text-body></ac:structured-macro><p>Globex</p>`,
+			count:    1,
+		},
+		{
+			name:     "entities are matched as text",
+			storage:  `<p>AT&amp;T &lt;old&gt;</p>`,
+			pattern:  `AT&T <old>`,
+			repl:     "A&B <new>",
+			expected: `<p>A&amp;B &lt;new&gt;</p>`,
+			count:    1,
+		},
+		{
+			name:     "submatches",
+			storage:  `<p>v1.2 and v3.4</p>`,
+			pattern:  `v(\d+)\.(\d+)`,
+			repl:     "version $1.$2",
+			expected: `<p>version 1.2 and version 3.4</p>`,
+			count:    2,
+		},
+		{
+			name:     "no matches",
+			storage:  `<p>A &nbsp;B</p>`,
+			pattern:  `C`,
+			repl:     "D",
+			expected: `<p>A &nbsp;B</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, count := ReplaceStorageText(tt.storage, regexp.MustCompile(tt.pattern), tt.repl)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.count, count)
+		})
+	}
+}
+
+func TestReplaceADFText(t *testing.T) {
+	adf := `{"type":"doc","version":1,"content":[
+		{"type":"paragraph","content":[{"type":"text","text":"Acme docs","marks":[{"type":"link","attrs":{"href":"https://acme.example.com"}}]}]},
+		{"type":"codeBlock","content":[{"type":"text","text":"Acme"}]},
+		{"type":"panel","attrs":{"panelType":"info"},"content":[{"type":"paragraph","content":[{"type":"text","text":"Try Acme"}]}]}
+	]}`
+
+	result, count, err := ReplaceADFText(adf, regexp.MustCompile(`Acme`), "Globex")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	var doc ADFDocument
+	require.NoError(t, json.Unmarshal([]byte(result), &doc))
+	link := doc.Content[0].Content[0]
+	assert.Equal(t, "Globex docs", link.Text)
+	assert.Equal(t, "https://acme.example.com", link.Marks[0].Attrs["href"])
+	assert.Equal(t, "Acme", doc.Content[1].Content[0].Text)
+	assert.Equal(t, "Try Globex", doc.Content[2].Content[0].Content[0].Text)
+
+	unchanged, count, err := ReplaceADFText(adf, regexp.MustCompile(`Initech`), "Globex")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Equal(t, adf, unchanged)
+
+	_, _, err = ReplaceADFText("not json", regexp.MustCompile(`x`), "y")
+	assert.Error(t, err)
+}
@@ -229,3 +229,30 @@ func TestRoundtrip_NestedMacroInParagraph(t *testing.T) {
 	assert.True(t, infoStart < tocPos, "[INFO] should come before [TOC]")
 	assert.True(t, tocPos < infoEnd, "[TOC] should come before [/INFO]")
 }
+
+func TestRoundtrip_ChildrenDisplay(t *testing.T) {
+	input := `[CHILDRENDISPLAY depth=2 page="DEV:Runbooks" sort=title]`
+
+	xhtml, err := ToConfluenceStorage([]byte(input))
+	require.NoError(t, err)
+	assert.Equal(t, `<ac:structured-macro ac:name="children" ac:schema-version="1"><ac:parameter ac:name="depth">2</ac:parameter><ac:parameter ac:name="page"><ac:link><ri:page ri:space-key="DEV" ri:content-title="Runbooks" /></ac:link></ac:parameter><ac:parameter ac:name="sort">title</ac:parameter></ac:structured-macro>`, trimNewline(xhtml))
+
+	md, err := FromConfluenceStorageWithOptions(xhtml, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Equal(t, `[CHILDREN depth=2 page=DEV:Runbooks sort=title]`, md)
+}
+
+func TestRoundtrip_PageProperties(t *testing.T) {
+	input := "[DETAILS id=release]\n\n| Owner  | Ana   |\n|--------|-------|\n| Status | Draft |\n\n[/DETAILS]\n\nReport:\n\n" +
+		`[DETAILSSUMMARY cql="label = \"release\" and space = currentSpace()" headings=Owner,Status]`
+
+	xhtml, err := ToConfluenceStorage([]byte(input))
+	require.NoError(t, err)
+	assert.Contains(t, xhtml, `<ac:structured-macro ac:name="details" ac:schema-version="1"><ac:parameter ac:name="id">release</ac:parameter><ac:rich-text-body><table>`)
+	assert.Contains(t, xhtml, `<ac:parameter ac:name="cql">label = &quot;release&quot; and space = currentSpace()</ac:parameter>`)
+
+	// Parameter values are unescaped, so edits don't escape them twice
+	md, err := FromConfluenceStorageWithOptions(xhtml, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Equal(t, input, md)
+}
@@ -0,0 +1,19 @@
+// selection.go locates text in storage format bodies, as inline comments
+// select it.
+package md
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// tagPattern matches an XML tag.
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// CountStorageText counts the occurrences of text in the text content of a
+// storage format body, ignoring markup and attribute values.
+func CountStorageText(storage, text string) int {
+	plain := html.UnescapeString(tagPattern.ReplaceAllString(storage, ""))
+	return strings.Count(plain, text)
+}
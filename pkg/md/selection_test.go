@@ -0,0 +1,28 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountStorageText(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage string
+		text    string
+		want    int
+	}{
+		{"plain", "<p>foo bar foo</p>", "foo", 2},
+		{"across tags", "<p>foo <strong>bar</strong></p>", "foo bar", 1},
+		{"entities", "<p>a &amp; b</p>", "a & b", 1},
+		{"attribute not counted", `<p><a href="foo">link</a></p>`, "foo", 0},
+		{"missing", "<p>nothing</p>", "foo", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CountStorageText(tt.storage, tt.text))
+		})
+	}
+}
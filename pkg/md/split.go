@@ -0,0 +1,230 @@
+// split.go splits page bodies into sections at headings of one level.
+package md
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+)
+
+// PageSection is a section of a page body: the text of its heading and the
+// content that follows the heading, in the body's format.
+type PageSection struct {
+	Title   string
+	Content string
+}
+
+var (
+	// splitMarkupPattern matches the markup that nests storage content:
+	// CDATA and comments, whose content isn't scanned, and tags
+	splitMarkupPattern = regexp.MustCompile(`(?s)<!\[CDATA\[.*?\]\]>|<!--.*?-->|<(/?)([A-Za-z][\w:.-]*)\b[^>]*?(/?)>`)
+	// attachmentRefPattern matches a reference to an attachment, capturing its filename
+	attachmentRefPattern = regexp.MustCompile(`<ri:attachment\s+[^>]*?ri:filename="([^"]*)"`)
+)
+
+// SplitStorage splits a storage format body at its top-level headings of
+// the given level (1-6). It returns the content before the first of them
+// and a section per heading; headings nested in layouts, tables or macros
+// don't start sections.
+func SplitStorage(storage string, level int) (string, []PageSection) {
+	// Each section runs from the end of its heading to the next heading
+	var headings []storageHeading
+	for _, h := range storageHeadings(storage) {
+		if h.level == level {
+			headings = append(headings, h)
+		}
+	}
+
+	if len(headings) == 0 {
+		return storage, nil
+	}
+	sections := make([]PageSection, 0, len(headings))
+	for i, h := range headings {
+		next := len(storage)
+		if i+1 < len(headings) {
+			next = headings[i+1].start
+		}
+		sections = append(sections, PageSection{
+			Title:   h.title,
+			Content: storage[h.end:next],
+		})
+	}
+	return storage[:headings[0].start], sections
+}
+
+// storageHeading is a top-level heading of a storage format body.
+type storageHeading struct {
+	level      int
+	title      string
+	start, end int // Offsets of the heading's opening tag and of the end of its closing tag
+}
+
+// storageHeadings returns the top-level headings of a storage format body.
+func storageHeadings(storage string) []storageHeading {
+	var headings []storageHeading
+	bodyStart := 0
+	depth := 0
+	for _, m := range splitMarkupPattern.FindAllStringSubmatchIndex(storage, -1) {
+		if m[4] < 0 {
+			continue // CDATA or comment
+		}
+		name := storage[m[4]:m[5]]
+		level := headingTagLevel(name)
+		switch {
+		case m[7] > m[6]: // Self-closing
+		case m[3] > m[2]: // Closing
+			if depth > 0 {
+				depth--
+			}
+			if depth == 0 && level > 0 && len(headings) > 0 && headings[len(headings)-1].end < 0 {
+				h := &headings[len(headings)-1]
+				h.end = m[1]
+				h.title = StorageText(storage[bodyStart:m[0]])
+			}
+		default:
+			if depth == 0 && level > 0 {
+				headings = append(headings, storageHeading{level: level, start: m[0], end: -1})
+				bodyStart = m[1]
+			}
+			depth++
+		}
+	}
+	// An unclosed heading runs to the end of the body
+	if n := len(headings); n > 0 && headings[n-1].end < 0 {
+		headings[n-1].end = len(storage)
+		headings[n-1].title = StorageText(storage[bodyStart:])
+	}
+	return headings
+}
+
+// headingTagLevel returns the level of a heading tag name, or 0 for other
+// tags.
+func headingTagLevel(name string) int {
+	if len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6' {
+		return int(name[1] - '0')
+	}
+	return 0
+}
+
+// StorageAttachmentRefs returns the filenames of the attachments a storage
+// format body references, in order of first reference.
+func StorageAttachmentRefs(storage string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, m := range attachmentRefPattern.FindAllStringSubmatch(storage, -1) {
+		name := html.UnescapeString(m[1])
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// SplitADF splits an ADF document at its top-level headings of the given
+// level (1-6). It returns a document of the content before the first of
+// them and a section per heading, whose content is a document of the nodes
+// that follow the heading.
+func SplitADF(adf string, level int) (string, []PageSection, error) {
+	var doc ADFDocument
+	if err := json.Unmarshal([]byte(adf), &doc); err != nil {
+		return "", nil, fmt.Errorf("failed to parse ADF document: %w", err)
+	}
+
+	var head []*ADFNode
+	var titles []string
+	var contents [][]*ADFNode
+	for _, n := range doc.Content {
+		if n.Type == "heading" && adfIntAttr(n, "level", 0) == level {
+			titles = append(titles, adfNodeText(n))
+			contents = append(contents, []*ADFNode{})
+			continue
+		}
+		if len(contents) == 0 {
+			head = append(head, n)
+		} else {
+			contents[len(contents)-1] = append(contents[len(contents)-1], n)
+		}
+	}
+
+	headDoc, err := adfDocumentJSON(doc, head)
+	if err != nil {
+		return "", nil, err
+	}
+	sections := make([]PageSection, 0, len(titles))
+	for i, title := range titles {
+		content, err := adfDocumentJSON(doc, contents[i])
+		if err != nil {
+			return "", nil, err
+		}
+		sections = append(sections, PageSection{Title: title, Content: content})
+	}
+	return headDoc, sections, nil
+}
+
+// ADFMediaIDs returns the file IDs of the media an ADF document shows, in
+// order of first use.
+func ADFMediaIDs(adf string) ([]string, error) {
+	var doc ADFDocument
+	if err := json.Unmarshal([]byte(adf), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse ADF document: %w", err)
+	}
+
+	var ids []string
+	seen := map[string]bool{}
+	walkADFMedia(doc.Content, func(n *ADFNode) {
+		if id := adfStringAttr(n, "id"); id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	})
+	return ids, nil
+}
+
+// RelinkADFMedia points the media of an ADF document at other files: each
+// media node whose file ID is a key of ids gets the mapped ID and the given
+// media collection.
+func RelinkADFMedia(adf string, ids map[string]string, collection string) (string, error) {
+	var doc ADFDocument
+	if err := json.Unmarshal([]byte(adf), &doc); err != nil {
+		return "", fmt.Errorf("failed to parse ADF document: %w", err)
+	}
+
+	walkADFMedia(doc.Content, func(n *ADFNode) {
+		if id, ok := ids[adfStringAttr(n, "id")]; ok {
+			n.Attrs["id"] = id
+			n.Attrs["collection"] = collection
+		}
+	})
+
+	result, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+// walkADFMedia calls fn for each file media node in nodes and their
+// descendants.
+func walkADFMedia(nodes []*ADFNode, fn func(*ADFNode)) {
+	for _, n := range nodes {
+		if (n.Type == "media" || n.Type == "mediaInline") && adfStringAttr(n, "type") != "external" {
+			fn(n)
+		}
+		walkADFMedia(n.Content, fn)
+	}
+}
+
+// adfDocumentJSON returns a document like doc with the given content.
+func adfDocumentJSON(doc ADFDocument, content []*ADFNode) (string, error) {
+	if content == nil {
+		content = []*ADFNode{}
+	}
+	doc.Content = content
+	result, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
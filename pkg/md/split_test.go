@@ -0,0 +1,87 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitStorage(t *testing.T) {
+	storage := `<p>Intro</p>` +
+		`<h2>Install &amp; setup</h2><p>Run it.</p><h3>Linux</h3><p>apt</p>` +
+		`<ac:structured-macro ac:name="code"><ac:plain-text-body><![CDATA[<h2>not a heading</h2>]]></ac:plain-text-body></ac:structured-macro>` +
+		`<ac:layout><ac:layout-section><ac:layout-cell><h2>Nested</h2></ac:layout-cell></ac:layout-section></ac:layout>` +
+		`<h2 id="faq">FAQ</h2><p>None<br/>yet.</p>`
+
+	head, sections := SplitStorage(storage, 2)
+	assert.Equal(t, `<p>Intro</p>`, head)
+	require.Len(t, sections, 2)
+	assert.Equal(t, "Install & setup", sections[0].Title)
+	assert.Equal(t, `<p>Run it.</p><h3>Linux</h3><p>apt</p>`+
+		`<ac:structured-macro ac:name="code"><ac:plain-text-body><![CDATA[<h2>not a heading</h2>]]></ac:plain-text-body></ac:structured-macro>`+
+		`<ac:layout><ac:layout-section><ac:layout-cell><h2>Nested</h2></ac:layout-cell></ac:layout-section></ac:layout>`,
+		sections[0].Content)
+	assert.Equal(t, "FAQ", sections[1].Title)
+	assert.Equal(t, `<p>None<br/>yet.</p>`, sections[1].Content)
+
+	head, sections = SplitStorage(storage, 3)
+	assert.Equal(t, storage[:len(`<p>Intro</p><h2>Install &amp; setup</h2><p>Run it.</p>`)], head)
+	require.Len(t, sections, 1)
+	assert.Equal(t, "Linux", sections[0].Title)
+
+	head, sections = SplitStorage(`<p>No headings</p>`, 2)
+	assert.Equal(t, `<p>No headings</p>`, head)
+	assert.Empty(t, sections)
+}
+
+func TestStorageAttachmentRefs(t *testing.T) {
+	storage := `<ac:image><ri:attachment ri:filename="a &amp; b.png" /></ac:image>` +
+		`<ac:link><ri:attachment ri:filename="spec.pdf"/></ac:link>` +
+		`<ac:image><ri:attachment ri:filename="a &amp; b.png" /></ac:image>`
+	assert.Equal(t, []string{"a & b.png", "spec.pdf"}, StorageAttachmentRefs(storage))
+	assert.Empty(t, StorageAttachmentRefs(`<p>text</p>`))
+}
+
+func TestSplitADF(t *testing.T) {
+	adf := `{"type":"doc","version":1,"content":[` +
+		`{"type":"paragraph","content":[{"type":"text","text":"Intro"}]},` +
+		`{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Setup"}]},` +
+		`{"type":"heading","attrs":{"level":3},"content":[{"type":"text","text":"Linux"}]},` +
+		`{"type":"paragraph","content":[{"type":"text","text":"apt"}]},` +
+		`{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"FAQ"}]}]}`
+
+	head, sections, err := SplitADF(adf, 2)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"Intro"}]}]}`, head)
+	require.Len(t, sections, 2)
+	assert.Equal(t, "Setup", sections[0].Title)
+	assert.JSONEq(t, `{"type":"doc","version":1,"content":[`+
+		`{"type":"heading","attrs":{"level":3},"content":[{"type":"text","text":"Linux"}]},`+
+		`{"type":"paragraph","content":[{"type":"text","text":"apt"}]}]}`, sections[0].Content)
+	assert.Equal(t, "FAQ", sections[1].Title)
+	assert.JSONEq(t, `{"type":"doc","version":1,"content":[]}`, sections[1].Content)
+
+	_, sections, err = SplitADF(adf, 1)
+	require.NoError(t, err)
+	assert.Empty(t, sections)
+
+	_, _, err = SplitADF(`not json`, 2)
+	assert.Error(t, err)
+}
+
+func TestADFMedia(t *testing.T) {
+	adf := `{"type":"doc","version":1,"content":[` +
+		`{"type":"mediaSingle","content":[{"type":"media","attrs":{"type":"file","id":"f1","collection":"contentId-1"}}]},` +
+		`{"type":"mediaSingle","content":[{"type":"media","attrs":{"type":"external","url":"https://example.com/a.png"}}]},` +
+		`{"type":"paragraph","content":[{"type":"mediaInline","attrs":{"type":"file","id":"f2","collection":"contentId-1"}}]}]}`
+
+	ids, err := ADFMediaIDs(adf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"f1", "f2"}, ids)
+
+	relinked, err := RelinkADFMedia(adf, map[string]string{"f1": "n1"}, "contentId-2")
+	require.NoError(t, err)
+	assert.Contains(t, relinked, `"collection":"contentId-2","id":"n1"`)
+	assert.Contains(t, relinked, `"collection":"contentId-1","id":"f2"`)
+}
@@ -0,0 +1,144 @@
+// status.go converts status lozenges: [STATUS colour=green]DONE[/STATUS]
+// in markdown, the status macro in storage format and status nodes in ADF.
+package md
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/text"
+)
+
+// Placeholder markers for status nodes in markdown converted to ADF.
+const (
+	statusPlaceholderPrefix = "CFSTATUS"
+	statusPlaceholderSuffix = "END"
+)
+
+// statusColours maps the colours of status lozenges to the names storage
+// format uses. ADF uses the lowercase names, with neutral for grey.
+var statusColours = map[string]string{
+	"grey":   "Grey",
+	"red":    "Red",
+	"yellow": "Yellow",
+	"green":  "Green",
+	"blue":   "Blue",
+	"purple": "Purple",
+}
+
+// Patterns for status lozenges.
+var (
+	// statusMacroPattern matches a status macro in bracket syntax.
+	statusMacroPattern = regexp.MustCompile(`(?i)(\[STATUS(?:\s[^\]\n]*)?\])([^\[\n]*)\[/STATUS\]`)
+
+	// statusPlaceholderPattern matches a status placeholder.
+	statusPlaceholderPattern = regexp.MustCompile(statusPlaceholderPrefix + `(\d+)` + statusPlaceholderSuffix)
+
+	// macroNamePattern matches the name of a storage format macro.
+	macroNamePattern = regexp.MustCompile(`^<ac:structured-macro ac:name="([^"]+)"`)
+)
+
+// statusToStorage moves a status macro's text into the title parameter,
+// where storage format keeps it, and names its colour as storage does.
+func statusToStorage(node *MacroNode) {
+	if node.Parameters == nil {
+		node.Parameters = make(map[string]string)
+	}
+	if title := strings.TrimSpace(node.Body); title != "" {
+		node.Parameters["title"] = title
+	}
+	node.Body = ""
+	if colour, ok := statusColours[strings.ToLower(node.Parameters["colour"])]; ok {
+		node.Parameters["colour"] = colour
+	}
+}
+
+// statusFromStorage reverses statusToStorage.
+func statusFromStorage(node *MacroNode) {
+	// Parameter values are still escaped, as the body is
+	node.Body = node.Parameters["title"]
+	delete(node.Parameters, "title")
+	if colour, ok := node.Parameters["colour"]; ok {
+		node.Parameters["colour"] = strings.ToLower(colour)
+	}
+}
+
+// isInlineMacro reports whether rendered macro XML belongs within a
+// paragraph rather than in place of one.
+func isInlineMacro(macroXML string) bool {
+	m := macroNamePattern.FindStringSubmatch(macroXML)
+	if m == nil {
+		return false
+	}
+	macroType, _ := LookupMacro(m[1])
+	return macroType.Inline
+}
+
+// preprocessStatusMacros replaces status macros outside code with
+// placeholders, returning the ADF status node for each.
+func preprocessStatusMacros(markdown []byte, c *adfConverter) []byte {
+	matches := statusMacroPattern.FindAllSubmatchIndex(markdown, -1)
+	if len(matches) == 0 {
+		return markdown
+	}
+
+	code := codeRanges(adfParser.Parser().Parse(text.NewReader(markdown)), markdown)
+	var edits []glossaryEdit
+	for _, m := range matches {
+		if overlaps(code, m[0], m[1]) {
+			continue
+		}
+		var params map[string]string
+		if tokens, err := TokenizeBrackets(string(markdown[m[2]:m[3]])); err == nil && len(tokens) == 1 {
+			params = tokens[0].Parameters
+		}
+		color := strings.ToLower(params["colour"])
+		if _, ok := statusColours[color]; !ok || color == "grey" {
+			color = "neutral"
+		}
+		id := len(c.statuses)
+		c.statuses = append(c.statuses, &ADFNode{
+			Type: "status",
+			Attrs: map[string]interface{}{
+				"text":    strings.TrimSpace(string(markdown[m[4]:m[5]])),
+				"color":   color,
+				"localId": c.nextLocalID(),
+			},
+		})
+		edits = append(edits, glossaryEdit{start: m[0], stop: m[1], text: fmt.Sprintf("%s%d%s", statusPlaceholderPrefix, id, statusPlaceholderSuffix)})
+	}
+	return applyEdits(markdown, edits)
+}
+
+// statusText splits text at status placeholders into text and status nodes.
+func (c *adfConverter) statusText(s string, marks []*ADFMark) []*ADFNode {
+	var nodes []*ADFNode
+	addText := func(t string) {
+		if t != "" {
+			nodes = append(nodes, &ADFNode{Type: "text", Text: t, Marks: marks})
+		}
+	}
+	last := 0
+	for _, m := range statusPlaceholderPattern.FindAllStringSubmatchIndex(s, -1) {
+		id, err := strconv.Atoi(s[m[2]:m[3]])
+		if err != nil || id >= len(c.statuses) {
+			continue
+		}
+		addText(s[last:m[0]])
+		nodes = append(nodes, c.statuses[id])
+		last = m[1]
+	}
+	addText(s[last:])
+	return nodes
+}
+
+// adfStatus renders an ADF status node as a status macro in bracket syntax.
+func adfStatus(n *ADFNode) string {
+	node := &MacroNode{Name: "status", Parameters: map[string]string{}, Body: adfStringAttr(n, "text")}
+	if color := adfStringAttr(n, "color"); color != "" && color != "neutral" {
+		node.Parameters["colour"] = color
+	}
+	return RenderMacroToBracket(node)
+}
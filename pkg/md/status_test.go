@@ -0,0 +1,78 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToConfluenceStorage_Status(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "inline with colour",
+			input:    "Build is [STATUS colour=green]DONE[/STATUS] now",
+			expected: `<p>Build is <ac:structured-macro ac:name="status" ac:schema-version="1"><ac:parameter ac:name="colour">Green</ac:parameter><ac:parameter ac:name="title">DONE</ac:parameter></ac:structured-macro> now</p>`,
+		},
+		{
+			name:     "alone in a paragraph",
+			input:    "[STATUS]In progress[/STATUS]",
+			expected: `<p><ac:structured-macro ac:name="status" ac:schema-version="1"><ac:parameter ac:name="title">In progress</ac:parameter></ac:structured-macro></p>`,
+		},
+		{
+			name:     "title is escaped",
+			input:    "[STATUS colour=Red]R&D[/STATUS]",
+			expected: `<p><ac:structured-macro ac:name="status" ac:schema-version="1"><ac:parameter ac:name="colour">Red</ac:parameter><ac:parameter ac:name="title">R&amp;D</ac:parameter></ac:structured-macro></p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToConfluenceStorage([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, trimNewline(got))
+		})
+	}
+}
+
+func TestFromConfluenceStorage_Status(t *testing.T) {
+	input := `<p>Build is <ac:structured-macro ac:name="status" ac:schema-version="1"><ac:parameter ac:name="colour">Yellow</ac:parameter><ac:parameter ac:name="title">R&amp;D</ac:parameter></ac:structured-macro> now</p>`
+
+	got, err := FromConfluenceStorageWithOptions(input, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Build is [STATUS colour=yellow]R&D[/STATUS] now", got)
+
+	// Without macros the lozenge's text remains
+	got, err = FromConfluenceStorage(input)
+	require.NoError(t, err)
+	assert.Equal(t, "Build is R&D now", got)
+}
+
+func TestStatus_StorageRoundTrip(t *testing.T) {
+	input := "| Task | State |\n| --- | --- |\n| Build | [STATUS colour=green]DONE[/STATUS] |"
+	storage, err := ToConfluenceStorage([]byte(input))
+	require.NoError(t, err)
+	back, err := FromConfluenceStorageWithOptions(storage, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Contains(t, back, "| Build | [STATUS colour=green]DONE[/STATUS] |")
+}
+
+func TestToADF_Status(t *testing.T) {
+	adf, err := ToADF([]byte("Build is [STATUS colour=green]DONE[/STATUS] and [STATUS colour=grey]new[/STATUS], not `[STATUS]code[/STATUS]`"))
+	require.NoError(t, err)
+	assert.Contains(t, adf, `{"type":"status","attrs":{"color":"green","localId":"1","text":"DONE"}}`)
+	assert.Contains(t, adf, `{"type":"status","attrs":{"color":"neutral","localId":"2","text":"new"}}`)
+	assert.Contains(t, adf, `{"type":"text","text":"[STATUS]code[/STATUS]","marks":[{"type":"code"}]}`)
+
+	back, err := FromADFWithOptions(adf, ConvertOptions{ShowMacros: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Build is [STATUS colour=green]DONE[/STATUS] and [STATUS]new[/STATUS], not `[STATUS]code[/STATUS]`", back)
+
+	back, err = FromADF(adf)
+	require.NoError(t, err)
+	assert.Equal(t, "Build is DONE and new, not `[STATUS]code[/STATUS]`", back)
+}
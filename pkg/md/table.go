@@ -0,0 +1,468 @@
+// table.go keeps the column widths, merged cells and header cells of
+// tables, which GFM tables have no syntax for. A cell's attributes lead its
+// text in braces:
+//
+//	| {width=120} Name | {width=240} Notes |
+//	| --- | --- |
+//	| {colspan=2} Spans both columns | |
+//	| {header} Row header | Value |
+//
+// colspan and rowspan merge cells, and the cells a merged cell covers are
+// left empty. width gives the widths in pixels of the columns a cell spans,
+// once per column, header makes a cell outside the header row a header
+// cell, and cell makes a cell in the header row a data cell, for tables
+// with a header column but no header row.
+package md
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Node attributes set on tables and cells by tableTransformer.
+var (
+	tableWidthsAttr = []byte("cfcolwidths")
+	tableCellAttr   = []byte("cfcell")
+)
+
+// cellAttrPattern matches one cell attribute.
+const cellAttrPattern = `(?:(?:colspan|rowspan)=\d+|width=\d+(?:\.\d+)?(?:,\d+(?:\.\d+)?)*|header|cell)`
+
+// Patterns for tables.
+var (
+	// cellAttrsPattern matches the attributes leading a cell's text,
+	// capturing them.
+	cellAttrsPattern = regexp.MustCompile(`^\{(` + cellAttrPattern + `(?:\s+` + cellAttrPattern + `)*)\}[ \t]*`)
+
+	// storageTableTagPattern matches the storage format tags that place
+	// table cells, with the paragraph a cell's content starts with.
+	storageTableTagPattern = regexp.MustCompile(`<(/?)(table|col|tr|td|th)\b([^>]*?)/?>((?:\s*<p(?:\s[^>]*)?>)?)`)
+
+	// storageColWidthPattern matches the width of a column in pixels.
+	storageColWidthPattern = regexp.MustCompile(`(?:width:\s*|\swidth=")(\d+(?:\.\d+)?)`)
+
+	// storageHeaderCellPattern matches the opening tag of a header cell.
+	storageHeaderCellPattern = regexp.MustCompile(`<th[\s>/]`)
+
+	// storageSpanPattern matches a colspan or rowspan attribute.
+	storageSpanPattern = regexp.MustCompile(`\s(colspan|rowspan)="(\d+)"`)
+)
+
+// cellAttrs are the attributes of a table cell.
+type cellAttrs struct {
+	Colspan int       // Columns the cell spans, if more than one
+	Rowspan int       // Rows the cell spans, if more than one
+	Widths  []float64 // Widths of the columns the cell spans, if known
+	Header  bool      // Header cell outside the header row
+	Data    bool      // Data cell in the header row
+}
+
+// String returns the attributes as they lead a cell's text, or "" if the
+// cell has none.
+func (a cellAttrs) String() string {
+	var attrs []string
+	if a.Colspan > 1 {
+		attrs = append(attrs, "colspan="+strconv.Itoa(a.Colspan))
+	}
+	if a.Rowspan > 1 {
+		attrs = append(attrs, "rowspan="+strconv.Itoa(a.Rowspan))
+	}
+	if len(a.Widths) > 0 {
+		widths := make([]string, len(a.Widths))
+		for i, w := range a.Widths {
+			widths[i] = strconv.FormatFloat(w, 'f', -1, 64)
+		}
+		attrs = append(attrs, "width="+strings.Join(widths, ","))
+	}
+	if a.Header {
+		attrs = append(attrs, "header")
+	}
+	if a.Data {
+		attrs = append(attrs, "cell")
+	}
+	if len(attrs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(attrs, " ") + "} "
+}
+
+// parseCellAttrs parses the attributes leading text, returning them and
+// the length of text they take up, or 0 if text has none.
+func parseCellAttrs(text string) (cellAttrs, int) {
+	m := cellAttrsPattern.FindStringSubmatch(text)
+	if m == nil {
+		return cellAttrs{}, 0
+	}
+	attrs := cellAttrs{Colspan: 1, Rowspan: 1}
+	for _, attr := range strings.Fields(m[1]) {
+		name, value, _ := strings.Cut(attr, "=")
+		switch name {
+		case "colspan":
+			attrs.Colspan, _ = strconv.Atoi(value)
+		case "rowspan":
+			attrs.Rowspan, _ = strconv.Atoi(value)
+		case "width":
+			attrs.Widths = nil
+			for _, w := range strings.Split(value, ",") {
+				width, _ := strconv.ParseFloat(w, 64)
+				attrs.Widths = append(attrs.Widths, width)
+			}
+		case "header":
+			attrs.Header = true
+		case "cell":
+			attrs.Data = true
+		}
+	}
+	attrs.Colspan = max(attrs.Colspan, 1)
+	attrs.Rowspan = max(attrs.Rowspan, 1)
+	return attrs, len(m[0])
+}
+
+// tableGrid tracks the cells of a table taken up by merged cells.
+type tableGrid struct {
+	taken map[[2]int]bool
+	row   int
+	col   int
+}
+
+// newRow moves to the first column of the next row.
+func (g *tableGrid) newRow() {
+	if g.taken == nil {
+		g.taken = make(map[[2]int]bool)
+		g.row = -1
+	}
+	g.row++
+	g.col = 0
+}
+
+// covered reports whether the current cell is covered by a merged cell.
+func (g *tableGrid) covered() bool {
+	return g.taken[[2]int{g.row, g.col}]
+}
+
+// place takes up the cells of a cell spanning cols and rows from the
+// current one, returning its column and moving past it.
+func (g *tableGrid) place(cols, rows int) int {
+	for g.covered() {
+		g.col++
+	}
+	col := g.col
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			g.taken[[2]int{g.row + r, col + c}] = true
+		}
+	}
+	g.col += cols
+	return col
+}
+
+// markTableCells writes the attributes of table cells in storage format
+// at the start of their content, where html-to-markdown keeps them.
+func markTableCells(storage string) string {
+	if !strings.Contains(storage, "<table") {
+		return storage
+	}
+
+	type table struct {
+		widths []float64
+		grid   tableGrid
+		header bool // The first row has header cells, so it is the header row
+	}
+	var tables []*table
+	var sb strings.Builder
+	last := 0
+	for _, m := range storageTableTagPattern.FindAllStringSubmatchIndex(storage, -1) {
+		closing, tag, attrs := m[2] < m[3], storage[m[4]:m[5]], storage[m[6]:m[7]]
+		sb.WriteString(storage[last:m[1]])
+		last = m[1]
+		if tag == "table" {
+			if closing {
+				if len(tables) > 0 {
+					tables = tables[:len(tables)-1]
+				}
+			} else {
+				tables = append(tables, &table{})
+			}
+			continue
+		}
+		if closing || len(tables) == 0 {
+			continue
+		}
+
+		t := tables[len(tables)-1]
+		switch tag {
+		case "col":
+			width := 0.0
+			if w := storageColWidthPattern.FindStringSubmatch(attrs); w != nil {
+				width, _ = strconv.ParseFloat(w[1], 64)
+			}
+			t.widths = append(t.widths, width)
+		case "tr":
+			t.grid.newRow()
+			if t.grid.row == 0 {
+				t.header = firstRowHasHeader(storage[m[1]:])
+			}
+		default:
+			if t.grid.taken == nil {
+				t.grid.newRow()
+				t.header = firstRowHasHeader(storage[m[0]:])
+			}
+			cell := cellAttrs{
+				Colspan: 1,
+				Rowspan: 1,
+				Header:  tag == "th" && t.grid.row > 0,
+				Data:    tag == "td" && t.grid.row == 0 && t.header,
+			}
+			for _, span := range storageSpanPattern.FindAllStringSubmatch(attrs, -1) {
+				n, _ := strconv.Atoi(span[2])
+				if span[1] == "colspan" {
+					cell.Colspan = max(n, 1)
+				} else {
+					cell.Rowspan = max(n, 1)
+				}
+			}
+			col := t.grid.place(cell.Colspan, cell.Rowspan)
+			if t.grid.row == 0 {
+				cell.Widths = columnWidths(t.widths, col, cell.Colspan)
+			}
+			sb.WriteString(cell.String())
+		}
+	}
+	sb.WriteString(storage[last:])
+	return sb.String()
+}
+
+// firstRowHasHeader reports whether the table row storage starts with has
+// a header cell. html-to-markdown makes such a row the header row, and
+// gives tables without one an empty header row.
+func firstRowHasHeader(storage string) bool {
+	if end := strings.Index(storage, "</tr>"); end >= 0 {
+		storage = storage[:end]
+	}
+	return storageHeaderCellPattern.MatchString(storage)
+}
+
+// columnWidths returns the widths of count columns from col, or nil unless
+// all are known.
+func columnWidths(widths []float64, col, count int) []float64 {
+	if col+count > len(widths) {
+		return nil
+	}
+	for _, w := range widths[col : col+count] {
+		if w <= 0 {
+			return nil
+		}
+	}
+	return append([]float64{}, widths[col:col+count]...)
+}
+
+// tableCellInfo is where a markdown table cell is placed, as
+// tableTransformer records it.
+type tableCellInfo struct {
+	Column  int
+	Colspan int
+	Rowspan int
+	Header  bool
+	Data    bool
+}
+
+// cellInfo returns the placement of a cell.
+func cellInfo(n *extast.TableCell) tableCellInfo {
+	if v, ok := n.AttributeString(string(tableCellAttr)); ok {
+		if info, ok := v.(tableCellInfo); ok {
+			return info
+		}
+	}
+	return tableCellInfo{Colspan: 1, Rowspan: 1}
+}
+
+// tableWidths returns the column widths of a table, 0 where unknown.
+func tableWidths(n *extast.Table) []float64 {
+	if v, ok := n.AttributeString(string(tableWidthsAttr)); ok {
+		if widths, ok := v.([]float64); ok {
+			return widths
+		}
+	}
+	return nil
+}
+
+// tableExtension parses the attributes of table cells and renders tables
+// with them.
+type tableExtension struct{}
+
+// Extend implements goldmark.Extender.
+func (tableExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(util.Prioritized(tableTransformer{}, 100)))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(tableRenderer{}, 100)))
+}
+
+// tableTransformer takes the attributes leading the text of table cells
+// and records them, with each cell's column, as node attributes. The empty
+// cells merged cells cover are removed.
+type tableTransformer struct{}
+
+// Transform implements parser.ASTTransformer.
+func (tableTransformer) Transform(doc *ast.Document, reader text.Reader, _ parser.Context) {
+	source := reader.Source()
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if table, ok := n.(*extast.Table); ok && entering {
+			transformTable(table, source)
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// transformTable records the attributes of the cells of table.
+func transformTable(table *extast.Table, source []byte) {
+	var grid tableGrid
+	var widths []float64
+	for row := table.FirstChild(); row != nil; row = row.NextSibling() {
+		grid.newRow()
+		spanned := 0 // Cells left to the right of the last merged cell
+		for cell := row.FirstChild(); cell != nil; {
+			next := cell.NextSibling()
+			if (spanned > 0 || grid.covered()) && isEmptyCell(cell, source) {
+				row.RemoveChild(row, cell)
+				if spanned > 0 {
+					spanned--
+				} else {
+					grid.col++
+				}
+				cell = next
+				continue
+			}
+
+			attrs := cellAttrs{Colspan: 1, Rowspan: 1}
+			if t, ok := cell.FirstChild().(*ast.Text); ok {
+				var n int
+				attrs, n = parseCellAttrs(string(t.Segment.Value(source)))
+				t.Segment = t.Segment.WithStart(t.Segment.Start + n)
+				attrs.Colspan = max(attrs.Colspan, 1)
+				attrs.Rowspan = max(attrs.Rowspan, 1)
+			}
+			col := grid.place(attrs.Colspan, attrs.Rowspan)
+			spanned = attrs.Colspan - 1
+			for i, w := range attrs.Widths {
+				for len(widths) <= col+i {
+					widths = append(widths, 0)
+				}
+				if widths[col+i] == 0 {
+					widths[col+i] = w
+				}
+			}
+			cell.SetAttribute(tableCellAttr, tableCellInfo{
+				Column:  col,
+				Colspan: attrs.Colspan,
+				Rowspan: attrs.Rowspan,
+				Header:  attrs.Header,
+				Data:    attrs.Data,
+			})
+			cell = next
+		}
+	}
+	if len(widths) > 0 {
+		table.SetAttribute(tableWidthsAttr, widths)
+	}
+}
+
+// isEmptyCell reports whether a table cell holds nothing but spaces.
+func isEmptyCell(cell ast.Node, source []byte) bool {
+	for child := cell.FirstChild(); child != nil; child = child.NextSibling() {
+		t, ok := child.(*ast.Text)
+		if !ok || strings.TrimSpace(string(t.Segment.Value(source))) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// tableRenderer renders tables with their column widths and the
+// attributes of their cells.
+type tableRenderer struct{}
+
+// RegisterFuncs implements renderer.NodeRendererFuncRegisterer.
+func (r tableRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(extast.KindTable, r.renderTable)
+	reg.Register(extast.KindTableCell, r.renderTableCell)
+}
+
+func (r tableRenderer) renderTable(w util.BufWriter, _ []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		_, _ = w.WriteString("</table>\n")
+		return ast.WalkContinue, nil
+	}
+	_, _ = w.WriteString("<table>\n")
+	widths := tableWidths(n.(*extast.Table))
+	if len(widths) > 0 {
+		_, _ = w.WriteString("<colgroup>")
+		for _, width := range widths {
+			if width > 0 {
+				_, _ = fmt.Fprintf(w, `<col style="width: %spx;" />`, strconv.FormatFloat(width, 'f', -1, 64))
+			} else {
+				_, _ = w.WriteString("<col />")
+			}
+		}
+		_, _ = w.WriteString("</colgroup>\n")
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r tableRenderer) renderTableCell(w util.BufWriter, _ []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*extast.TableCell)
+	info := cellInfo(n)
+	tag := "td"
+	if (n.Parent().Kind() == extast.KindTableHeader || info.Header) && !info.Data {
+		tag = "th"
+	}
+	if !entering {
+		_, _ = fmt.Fprintf(w, "</%s>\n", tag)
+		return ast.WalkContinue, nil
+	}
+	_, _ = w.WriteString("<" + tag)
+	if info.Colspan > 1 {
+		_, _ = fmt.Fprintf(w, ` colspan="%d"`, info.Colspan)
+	}
+	if info.Rowspan > 1 {
+		_, _ = fmt.Fprintf(w, ` rowspan="%d"`, info.Rowspan)
+	}
+	if n.Alignment != extast.AlignNone {
+		_, _ = fmt.Fprintf(w, ` style="text-align:%s"`, n.Alignment.String())
+	}
+	_ = w.WriteByte('>')
+	return ast.WalkContinue, nil
+}
+
+// adfColumnWidths returns the widths of the columns of an ADF table from
+// the colwidth attributes of its cells, 0 where unknown.
+func adfColumnWidths(n *ADFNode) []float64 {
+	var widths []float64
+	var grid tableGrid
+	for _, row := range n.Content {
+		grid.newRow()
+		for _, cell := range row.Content {
+			colspan, rowspan := adfIntAttr(cell, "colspan", 1), adfIntAttr(cell, "rowspan", 1)
+			col := grid.place(max(colspan, 1), max(rowspan, 1))
+			colwidth, _ := cell.Attrs["colwidth"].([]interface{})
+			for i, v := range colwidth {
+				w, _ := v.(float64)
+				for len(widths) <= col+i {
+					widths = append(widths, 0)
+				}
+				if widths[col+i] == 0 {
+					widths[col+i] = w
+				}
+			}
+		}
+	}
+	return widths
+}
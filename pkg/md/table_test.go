@@ -0,0 +1,165 @@
+package md
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mergedTableStorage is a table with column widths, merged cells and a
+// header column.
+const mergedTableStorage = `<table data-layout="default"><colgroup><col style="width: 120.0px;" /><col style="width: 240.0px;" /></colgroup><tbody>` +
+	`<tr><th><p>A</p></th><th><p>B</p></th></tr>` +
+	`<tr><td colspan="2"><p>wide</p></td></tr>` +
+	`<tr><td rowspan="2"><p>tall</p></td><td><p>x</p></td></tr>` +
+	`<tr><td><p>y</p></td></tr>` +
+	`<tr><th><p>H</p></th><td><p>z</p></td></tr>` +
+	`</tbody></table>`
+
+// mergedTableMarkdown is mergedTableStorage as markdown.
+const mergedTableMarkdown = "| {width=120} A | {width=240} B |\n" +
+	"| --- | --- |\n" +
+	"| {colspan=2} wide | |\n" +
+	"| {rowspan=2} tall | x |\n" +
+	"| | y |\n" +
+	"| {header} H | z |"
+
+func TestParseCellAttrs(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected cellAttrs
+		length   int
+	}{
+		{"{colspan=2} text", cellAttrs{Colspan: 2, Rowspan: 1}, 12},
+		{"{rowspan=3 header}", cellAttrs{Colspan: 1, Rowspan: 3, Header: true}, 18},
+		{"{cell} Bob", cellAttrs{Colspan: 1, Rowspan: 1, Data: true}, 7},
+		{"{width=120,80.5}x", cellAttrs{Colspan: 1, Rowspan: 1, Widths: []float64{120, 80.5}}, 16},
+		{"{colspan=0}", cellAttrs{Colspan: 1, Rowspan: 1}, 11},
+		{"{note} text", cellAttrs{}, 0},
+		{"text {colspan=2}", cellAttrs{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			attrs, n := parseCellAttrs(tt.input)
+			assert.Equal(t, tt.expected, attrs)
+			assert.Equal(t, tt.length, n)
+		})
+	}
+}
+
+func TestFromConfluenceStorage_TableAttributes(t *testing.T) {
+	result, err := FromConfluenceStorage(mergedTableStorage)
+	require.NoError(t, err)
+
+	assert.Contains(t, result, "| {width=120} A ")
+	assert.Contains(t, result, "| {width=240} B ")
+	assert.Contains(t, result, "| {colspan=2} wide ")
+	assert.Contains(t, result, "| {rowspan=2} tall ")
+	assert.Contains(t, result, "| {header} H ")
+	assert.NotContains(t, result, "{width=120} wide")
+}
+
+func TestToConfluenceStorage_TableAttributes(t *testing.T) {
+	result, err := ToConfluenceStorage([]byte(mergedTableMarkdown))
+	require.NoError(t, err)
+
+	assert.Contains(t, result, `<colgroup><col style="width: 120px;" /><col style="width: 240px;" /></colgroup>`)
+	assert.Contains(t, result, "<th>A</th>\n<th>B</th>")
+	assert.Contains(t, result, "<tr>\n<td colspan=\"2\">wide</td>\n</tr>")
+	assert.Contains(t, result, "<tr>\n<td rowspan=\"2\">tall</td>\n<td>x</td>\n</tr>")
+	assert.Contains(t, result, "<tr>\n<td>y</td>\n</tr>")
+	assert.Contains(t, result, "<tr>\n<th>H</th>\n<td>z</td>\n</tr>")
+}
+
+func TestToConfluenceStorage_TableWithoutAttributes(t *testing.T) {
+	result, err := ToConfluenceStorage([]byte("| A | B |\n|:--|--:|\n| {note} | |"))
+	require.NoError(t, err)
+
+	assert.NotContains(t, result, "colgroup")
+	assert.Contains(t, result, `<th style="text-align:left">A</th>`)
+	assert.Contains(t, result, `<td style="text-align:left">{note}</td>`)
+	assert.Contains(t, result, `<td style="text-align:right"></td>`)
+}
+
+func TestToADF_TableAttributes(t *testing.T) {
+	result, err := ToADF([]byte(mergedTableMarkdown))
+	require.NoError(t, err)
+
+	var doc ADFDocument
+	require.NoError(t, json.Unmarshal([]byte(result), &doc))
+	require.Len(t, doc.Content, 1)
+	rows := doc.Content[0].Content
+	require.Len(t, rows, 5)
+
+	var cells [][]string
+	for _, row := range rows {
+		var types []string
+		for _, cell := range row.Content {
+			types = append(types, cell.Type)
+		}
+		cells = append(cells, types)
+	}
+	assert.Equal(t, [][]string{
+		{"tableHeader", "tableHeader"},
+		{"tableCell"},
+		{"tableCell", "tableCell"},
+		{"tableCell"},
+		{"tableHeader", "tableCell"},
+	}, cells)
+
+	wide := rows[1].Content[0]
+	assert.Equal(t, float64(2), wide.Attrs["colspan"])
+	assert.Equal(t, []interface{}{float64(120), float64(240)}, wide.Attrs["colwidth"])
+	tall := rows[2].Content[0]
+	assert.Equal(t, float64(2), tall.Attrs["rowspan"])
+	assert.Equal(t, []interface{}{float64(240)}, rows[3].Content[0].Attrs["colwidth"])
+}
+
+func TestFromADF_TableAttributes(t *testing.T) {
+	adf, err := ToADF([]byte(mergedTableMarkdown))
+	require.NoError(t, err)
+
+	result, err := FromADF(adf)
+	require.NoError(t, err)
+	assert.Equal(t, "| {width=120} A | {width=240} B |\n"+
+		"| --- | --- |\n"+
+		"| {colspan=2} wide |  |\n"+
+		"| {rowspan=2} tall | x |\n"+
+		"|  | y |\n"+
+		"| {header} H | z |", result)
+}
+
+func TestTableAttributesRoundTrip(t *testing.T) {
+	markdown, err := FromConfluenceStorage(mergedTableStorage)
+	require.NoError(t, err)
+	result, err := ToConfluenceStorage([]byte(markdown))
+	require.NoError(t, err)
+
+	again, err := FromConfluenceStorage(result)
+	require.NoError(t, err)
+	assert.Equal(t, markdown, again)
+}
+
+func TestHeaderColumnRoundTrip(t *testing.T) {
+	storage := `<table><tbody><tr><th><p>Owner</p></th><td><p>Bob</p></td></tr><tr><th><p>Team</p></th><td><p>Docs</p></td></tr></tbody></table>`
+
+	markdown, err := FromConfluenceStorage(storage)
+	require.NoError(t, err)
+	assert.Contains(t, markdown, "| Owner ")
+	assert.Contains(t, markdown, "| {cell} Bob ")
+	assert.Contains(t, markdown, "| {header} Team ")
+
+	result, err := ToConfluenceStorage([]byte(markdown))
+	require.NoError(t, err)
+	assert.Contains(t, result, "<th>Owner</th>\n<td>Bob</td>")
+	assert.Contains(t, result, "<th>Team</th>\n<td>Docs</td>")
+
+	adf, err := ToADF([]byte(markdown))
+	require.NoError(t, err)
+	back, err := FromADF(adf)
+	require.NoError(t, err)
+	assert.Equal(t, "| Owner | {cell} Bob |\n| --- | --- |\n| {header} Team | Docs |", back)
+}
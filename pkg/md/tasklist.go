@@ -0,0 +1,274 @@
+// tasklist.go converts GFM task lists (- [ ] item, - [x] done) to
+// Confluence task lists, which keep their checkboxes clickable.
+package md
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Placeholder markers for task checkboxes (avoid html-to-markdown escaping)
+const (
+	taskTodoPlaceholder = "CFTASKTODO"
+	taskDonePlaceholder = "CFTASKDONE"
+)
+
+// Patterns for storage format task lists.
+var (
+	// acTaskStatusPattern matches the id and status of a task and the start
+	// of its body, with the body's opening <p> if it has one.
+	acTaskStatusPattern = regexp.MustCompile(`(?s)(?:<ac:task-id>[^<]*</ac:task-id>\s*)?<ac:task-status>\s*(\w+)\s*</ac:task-status>\s*<ac:task-body>(\s*<p>)?`)
+
+	// acTaskTagPattern matches the remaining task list tags.
+	acTaskTagPattern = regexp.MustCompile(`</?ac:task(?:-list|-body)?>`)
+
+	// acTaskIDPattern matches the id of a task.
+	acTaskIDPattern = regexp.MustCompile(`<ac:task-id>[^<]*</ac:task-id>`)
+)
+
+// kindTaskList and kindTaskItem are the kinds of task list nodes.
+var (
+	kindTaskList = ast.NewNodeKind("TaskList")
+	kindTaskItem = ast.NewNodeKind("TaskItem")
+)
+
+// taskList is a bullet list whose items all start with a checkbox.
+type taskList struct {
+	ast.BaseBlock
+}
+
+// Kind implements ast.Node.
+func (n *taskList) Kind() ast.NodeKind { return kindTaskList }
+
+// Dump implements ast.Node.
+func (n *taskList) Dump(source []byte, level int) { ast.DumpHelper(n, source, level, nil, nil) }
+
+// taskItem is an item of a task list, without its checkbox.
+type taskItem struct {
+	ast.BaseBlock
+	Checked bool
+}
+
+// Kind implements ast.Node.
+func (n *taskItem) Kind() ast.NodeKind { return kindTaskItem }
+
+// Dump implements ast.Node.
+func (n *taskItem) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Checked": strconv.FormatBool(n.Checked)}, nil)
+}
+
+// taskListExtension parses GFM task lists into task list nodes and renders
+// them as storage format task lists.
+type taskListExtension struct{}
+
+// Extend implements goldmark.Extender.
+func (taskListExtension) Extend(m goldmark.Markdown) {
+	extension.TaskList.Extend(m)
+	m.Parser().AddOptions(parser.WithASTTransformers(util.Prioritized(taskListTransformer{}, 100)))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(taskListRenderer{}, 100)))
+}
+
+// taskListTransformer replaces bullet lists whose items all start with a
+// checkbox by task lists.
+type taskListTransformer struct{}
+
+// Transform implements parser.ASTTransformer.
+func (taskListTransformer) Transform(doc *ast.Document, _ text.Reader, _ parser.Context) {
+	var lists []*ast.List
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if list, ok := n.(*ast.List); ok && entering && !list.IsOrdered() {
+			lists = append(lists, list)
+		}
+		return ast.WalkContinue, nil
+	})
+	// Nested lists come later in document order, so they are converted
+	// first and their parents can tell they are task lists
+	for i := len(lists) - 1; i >= 0; i-- {
+		convertTaskList(lists[i])
+	}
+}
+
+// convertTaskList replaces list by a task list if all its items are tasks.
+func convertTaskList(list *ast.List) {
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		if !isTaskItem(item) {
+			return
+		}
+	}
+
+	tasks := &taskList{}
+	for item := list.FirstChild(); item != nil; {
+		next := item.NextSibling()
+		box := item.FirstChild().FirstChild()
+		task := &taskItem{Checked: box.(*extast.TaskCheckBox).IsChecked}
+		box.Parent().RemoveChild(box.Parent(), box)
+		for child := item.FirstChild(); child != nil; {
+			nextChild := child.NextSibling()
+			task.AppendChild(task, child)
+			child = nextChild
+		}
+		tasks.AppendChild(tasks, task)
+		item = next
+	}
+	list.Parent().ReplaceChild(list.Parent(), list, tasks)
+}
+
+// isTaskItem reports whether a list item starts with a checkbox and holds
+// nothing but text and nested task lists, which is all a task can hold.
+func isTaskItem(item ast.Node) bool {
+	first := item.FirstChild()
+	if first == nil {
+		return false
+	}
+	if _, ok := first.FirstChild().(*extast.TaskCheckBox); !ok {
+		return false
+	}
+	for child := first; child != nil; child = child.NextSibling() {
+		switch child.(type) {
+		case *ast.TextBlock, *ast.Paragraph, *taskList:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// taskListRenderer renders task lists as storage format task lists.
+type taskListRenderer struct{}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (r taskListRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindTaskList, r.renderTaskList)
+	reg.Register(kindTaskItem, r.renderTaskItem)
+	reg.Register(extast.KindTaskCheckBox, r.renderCheckBox)
+}
+
+func (r taskListRenderer) renderTaskList(w util.BufWriter, _ []byte, _ ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString("<ac:task-list>\n")
+	} else {
+		_, _ = w.WriteString("</ac:task-list>\n")
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r taskListRenderer) renderTaskItem(w util.BufWriter, _ []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		_, _ = w.WriteString("</ac:task-body>\n</ac:task>\n")
+		return ast.WalkContinue, nil
+	}
+	status := "incomplete"
+	if n.(*taskItem).Checked {
+		status = "complete"
+	}
+	// Task ids are numbered once the whole page is rendered
+	_, _ = w.WriteString("<ac:task>\n<ac:task-id></ac:task-id>\n<ac:task-status>" + status + "</ac:task-status>\n<ac:task-body>")
+	return ast.WalkContinue, nil
+}
+
+// renderCheckBox renders a checkbox outside a task list as the text it
+// was written as, since storage format has no form controls.
+func (r taskListRenderer) renderCheckBox(w util.BufWriter, _ []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString(checkBoxText(n.(*extast.TaskCheckBox)))
+	}
+	return ast.WalkContinue, nil
+}
+
+// checkBoxText returns the markdown for a checkbox.
+func checkBoxText(box *extast.TaskCheckBox) string {
+	if box.IsChecked {
+		return "[x] "
+	}
+	return "[ ] "
+}
+
+// isCheckBoxTag reports whether a bracket tag is a task list checkbox,
+// which isn't a macro.
+func isCheckBoxTag(token BracketToken) bool {
+	return token.OriginalTagText == "[x]" || token.OriginalTagText == "[X]"
+}
+
+// numberTasks numbers the tasks in storage in document order.
+func numberTasks(storage string) string {
+	id := 0
+	return acTaskIDPattern.ReplaceAllStringFunc(storage, func(string) string {
+		id++
+		return "<ac:task-id>" + strconv.Itoa(id) + "</ac:task-id>"
+	})
+}
+
+// replaceStorageTasks turns storage format task lists into HTML lists
+// whose items start with placeholders for their checkboxes.
+func replaceStorageTasks(storage string) string {
+	storage = acTaskStatusPattern.ReplaceAllStringFunc(storage, func(match string) string {
+		groups := acTaskStatusPattern.FindStringSubmatch(match)
+		placeholder := taskTodoPlaceholder
+		if groups[1] == "complete" {
+			placeholder = taskDonePlaceholder
+		}
+		return groups[2] + placeholder + " "
+	})
+	return acTaskTagPattern.ReplaceAllStringFunc(storage, func(tag string) string {
+		switch tag {
+		case "<ac:task-list>":
+			return "<ul>"
+		case "</ac:task-list>":
+			return "</ul>"
+		case "<ac:task>":
+			return "<li>"
+		case "</ac:task>":
+			return "</li>"
+		}
+		return ""
+	})
+}
+
+// restoreTasks replaces checkbox placeholders with markdown checkboxes.
+func restoreTasks(markdown string) string {
+	return strings.NewReplacer(taskTodoPlaceholder, "[ ]", taskDonePlaceholder, "[x]").Replace(markdown)
+}
+
+// convertTaskList converts a task list to an ADF taskList. Nested task
+// lists follow the item they belong to, as ADF nests them.
+func (c *adfConverter) convertTaskList(n *taskList) *ADFNode {
+	list := &ADFNode{Type: "taskList", Attrs: map[string]interface{}{"localId": c.nextLocalID()}}
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		task := child.(*taskItem)
+		state := "TODO"
+		if task.Checked {
+			state = "DONE"
+		}
+		item := &ADFNode{Type: "taskItem", Attrs: map[string]interface{}{"localId": c.nextLocalID(), "state": state}}
+		var nested []*ADFNode
+		for block := task.FirstChild(); block != nil; block = block.NextSibling() {
+			if sub, ok := block.(*taskList); ok {
+				nested = append(nested, c.convertTaskList(sub))
+				continue
+			}
+			if len(item.Content) > 0 {
+				item.Content = append(item.Content, &ADFNode{Type: "hardBreak"})
+			}
+			item.Content = append(item.Content, c.convertInlineChildren(block)...)
+		}
+		list.Content = append(list.Content, item)
+		list.Content = append(list.Content, nested...)
+	}
+	return list
+}
+
+// nextLocalID returns the next local id for an ADF node that needs one.
+func (c *adfConverter) nextLocalID() string {
+	c.localIDs++
+	return strconv.Itoa(c.localIDs)
+}
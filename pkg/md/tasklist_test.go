@@ -0,0 +1,94 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToConfluenceStorage_TaskList(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:  "tasks",
+			input: "- [ ] todo\n- [x] **done**",
+			expected: "<ac:task-list>\n" +
+				"<ac:task>\n<ac:task-id>1</ac:task-id>\n<ac:task-status>incomplete</ac:task-status>\n<ac:task-body>todo</ac:task-body>\n</ac:task>\n" +
+				"<ac:task>\n<ac:task-id>2</ac:task-id>\n<ac:task-status>complete</ac:task-status>\n<ac:task-body><strong>done</strong></ac:task-body>\n</ac:task>\n" +
+				"</ac:task-list>",
+		},
+		{
+			name:  "nested",
+			input: "- [ ] parent\n  - [X] child",
+			expected: "<ac:task-list>\n" +
+				"<ac:task>\n<ac:task-id>1</ac:task-id>\n<ac:task-status>incomplete</ac:task-status>\n<ac:task-body>parent\n" +
+				"<ac:task-list>\n" +
+				"<ac:task>\n<ac:task-id>2</ac:task-id>\n<ac:task-status>complete</ac:task-status>\n<ac:task-body>child</ac:task-body>\n</ac:task>\n" +
+				"</ac:task-list>\n" +
+				"</ac:task-body>\n</ac:task>\n" +
+				"</ac:task-list>",
+		},
+		{
+			name:     "mixed list stays a bullet list",
+			input:    "- [ ] todo\n- plain",
+			expected: "<ul>\n<li>[ ] todo</li>\n<li>plain</li>\n</ul>",
+		},
+		{
+			name:     "ordered list stays an ordered list",
+			input:    "1. [x] first",
+			expected: "<ol>\n<li>[x] first</li>\n</ol>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToConfluenceStorage([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, trimNewline(got))
+		})
+	}
+}
+
+func TestFromConfluenceStorage_TaskList(t *testing.T) {
+	input := `<ac:task-list>
+<ac:task><ac:task-id>7</ac:task-id><ac:task-status>incomplete</ac:task-status><ac:task-body><span>Write docs</span></ac:task-body></ac:task>
+<ac:task><ac:task-id>8</ac:task-id><ac:task-status>complete</ac:task-status><ac:task-body><p>Ship <em>it</em></p></ac:task-body></ac:task>
+</ac:task-list>`
+	got, err := FromConfluenceStorage(input)
+	require.NoError(t, err)
+	assert.Equal(t, "- [ ] Write docs\n- [x] Ship *it*", got)
+}
+
+func TestTaskList_StorageRoundTrip(t *testing.T) {
+	input := "Before\n\n- [ ] todo\n- [x] done\n\nAfter"
+	storage, err := ToConfluenceStorage([]byte(input))
+	require.NoError(t, err)
+	back, err := FromConfluenceStorage(storage)
+	require.NoError(t, err)
+	assert.Equal(t, input, back)
+}
+
+func TestToADF_TaskList(t *testing.T) {
+	adf, err := ToADF([]byte("- [ ] todo\n- [x] done\n  - [ ] child"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"doc","version":1,"content":[{"type":"taskList","attrs":{"localId":"1"},"content":[
+		{"type":"taskItem","attrs":{"localId":"2","state":"TODO"},"content":[{"type":"text","text":"todo"}]},
+		{"type":"taskItem","attrs":{"localId":"3","state":"DONE"},"content":[{"type":"text","text":"done"}]},
+		{"type":"taskList","attrs":{"localId":"4"},"content":[
+			{"type":"taskItem","attrs":{"localId":"5","state":"TODO"},"content":[{"type":"text","text":"child"}]}]}]}]}`, adf)
+
+	back, err := FromADF(adf)
+	require.NoError(t, err)
+	assert.Equal(t, "- [ ] todo\n- [x] done\n  - [ ] child", back)
+}
+
+func TestToADF_CheckBoxOutsideTaskList(t *testing.T) {
+	adf, err := ToADF([]byte("- [ ] todo\n- plain"))
+	require.NoError(t, err)
+	assert.Contains(t, adf, `{"type":"bulletList"`)
+	assert.Contains(t, adf, `{"type":"text","text":"[ ] "}`)
+}
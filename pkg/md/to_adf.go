@@ -10,6 +10,7 @@ import (
 	extast "github.com/yuin/goldmark/extension/ast"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
 )
 
 // ADFDocument represents an Atlassian Document Format document.
@@ -39,6 +40,8 @@ var adfParser = goldmark.New(
 	goldmark.WithExtensions(
 		extension.Table,
 		extension.Strikethrough,
+		tableExtension{},
+		taskListExtension{},
 	),
 	goldmark.WithParserOptions(
 		parser.WithAutoHeadingID(),
@@ -48,6 +51,21 @@ var adfParser = goldmark.New(
 // ToADF converts markdown content to Atlassian Document Format (ADF) JSON.
 // The returned string is a JSON-encoded ADF document.
 func ToADF(markdown []byte) (string, error) {
+	return toADF(markdown, nil)
+}
+
+// ToADFWithOptions converts markdown to ADF JSON, in canonical form when
+// opts.Deterministic is set.
+func ToADFWithOptions(markdown []byte, opts ConvertOptions) (string, error) {
+	adf, err := ToADF(markdown)
+	if err != nil || !opts.Deterministic {
+		return adf, err
+	}
+	return CanonicalADF(adf)
+}
+
+// toADF implements ToADF and ToADFWithEmbeds.
+func toADF(markdown []byte, embeds *EmbedOptions) (string, error) {
 	doc := &ADFDocument{
 		Type:    "doc",
 		Version: 1,
@@ -62,29 +80,49 @@ func ToADF(markdown []byte) (string, error) {
 		return string(result), nil
 	}
 
+	markdown, err := runPreParse(DirectionToADF, markdown)
+	if err != nil {
+		return "", err
+	}
+	markdown = preprocessPageLinks(markdown)
+
+	converter := &adfConverter{embeds: embeds}
+	markdown = preprocessStatusMacros(markdown, converter)
+	markdown = preprocessADFMacros(markdown, converter)
+	markdown = preprocessADFLayouts(markdown, converter)
+
 	reader := text.NewReader(markdown)
 	astDoc := adfParser.Parser().Parse(reader)
 
 	// Walk the AST and convert to ADF
-	converter := &adfConverter{source: markdown}
+	converter.source = markdown
 	doc.Content = converter.convertChildren(astDoc)
 
 	result, err := json.Marshal(doc)
 	if err != nil {
 		return "", err
 	}
-	return string(result), nil
+	return runPostRender(DirectionToADF, string(result))
 }
 
 // adfConverter holds state during AST conversion.
 type adfConverter struct {
-	source []byte
+	source   []byte
+	embeds   *EmbedOptions
+	localIDs int
+	statuses []*ADFNode // Status nodes, by placeholder id
+	macros   []adfMacro // Macros, by placeholder id
 }
 
 // convertChildren converts all children of an AST node to ADF nodes.
 func (c *adfConverter) convertChildren(n ast.Node) []*ADFNode {
 	var nodes []*ADFNode
 	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		// ADF has no tab groups, so tabbed code blocks are labeled in sequence;
+		// FromADF reads a bold label before a code block back as its tab title
+		if label := c.codeTabLabel(child); label != nil {
+			nodes = append(nodes, label)
+		}
 		if node := c.convertNode(child); node != nil {
 			nodes = append(nodes, node)
 		}
@@ -101,6 +139,8 @@ func (c *adfConverter) convertNode(n ast.Node) *ADFNode {
 		return c.convertHeading(node)
 	case *ast.List:
 		return c.convertList(node)
+	case *taskList:
+		return c.convertTaskList(node)
 	case *ast.ListItem:
 		return c.convertListItem(node)
 	case *ast.FencedCodeBlock:
@@ -122,6 +162,18 @@ func (c *adfConverter) convertNode(n ast.Node) *ADFNode {
 }
 
 func (c *adfConverter) convertParagraph(n *ast.Paragraph) *ADFNode {
+	// An embedded attachment on its own line becomes a block-level node
+	if img, ok := n.FirstChild().(*ast.Image); ok && img.NextSibling() == nil {
+		if node := c.embeds.embedADFImage(img, c.imageAlt(img)); node != nil {
+			return node
+		}
+	}
+
+	// A macro on its own line becomes a block-level node
+	if node := c.macroBlock(n); node != nil {
+		return node
+	}
+
 	content := c.convertInlineChildren(n)
 	if len(content) == 0 {
 		return nil
@@ -194,6 +246,11 @@ func (c *adfConverter) convertTextBlock(n *ast.TextBlock) *ADFNode {
 }
 
 func (c *adfConverter) convertTextBlockToParagraph(n *ast.TextBlock) *ADFNode {
+	// A macro on its own line becomes a block-level node
+	if node := c.macroBlock(n); node != nil {
+		return node
+	}
+
 	content := c.convertInlineChildren(n)
 	if len(content) == 0 {
 		return nil
@@ -223,13 +280,32 @@ func (c *adfConverter) convertFencedCodeBlock(n *ast.FencedCodeBlock) *ADFNode {
 	}
 
 	// Add language attribute if present
-	if lang := string(n.Language(c.source)); lang != "" {
+	if lang := string(n.Language(c.source)); lang != "" && !strings.HasPrefix(lang, "tab=") {
 		node.Attrs = map[string]interface{}{"language": lang}
 	}
 
 	return node
 }
 
+// codeTabLabel returns a paragraph naming the tab of a tabbed fenced code
+// block, or nil if the node is not one.
+func (c *adfConverter) codeTabLabel(n ast.Node) *ADFNode {
+	fence, ok := n.(*ast.FencedCodeBlock)
+	if !ok || fence.Info == nil {
+		return nil
+	}
+	title, ok := codeTabTitle(string(fence.Info.Segment.Value(c.source)))
+	if !ok {
+		return nil
+	}
+	return &ADFNode{
+		Type: "paragraph",
+		Content: []*ADFNode{
+			{Type: "text", Text: title, Marks: []*ADFMark{{Type: "strong"}}},
+		},
+	}
+}
+
 func (c *adfConverter) convertCodeBlock(n *ast.CodeBlock) *ADFNode {
 	var code strings.Builder
 	lines := n.Lines()
@@ -258,13 +334,14 @@ func (c *adfConverter) convertBlockquote(n *ast.Blockquote) *ADFNode {
 func (c *adfConverter) convertTable(n *extast.Table) *ADFNode {
 	var rows []*ADFNode
 	isFirstRow := true
+	widths := tableWidths(n)
 
 	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
 		if row, ok := child.(*extast.TableRow); ok {
-			rows = append(rows, c.convertTableRow(row, isFirstRow))
+			rows = append(rows, c.convertTableRow(row, isFirstRow, widths))
 			isFirstRow = false
 		} else if header, ok := child.(*extast.TableHeader); ok {
-			rows = append(rows, c.convertTableHeader(header))
+			rows = append(rows, c.convertTableHeader(header, widths))
 			isFirstRow = false
 		}
 	}
@@ -276,11 +353,11 @@ func (c *adfConverter) convertTable(n *extast.Table) *ADFNode {
 	}
 }
 
-func (c *adfConverter) convertTableHeader(n *extast.TableHeader) *ADFNode {
+func (c *adfConverter) convertTableHeader(n *extast.TableHeader, widths []float64) *ADFNode {
 	var cells []*ADFNode
 	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
 		if cell, ok := child.(*extast.TableCell); ok {
-			cells = append(cells, c.convertTableCell(cell, true))
+			cells = append(cells, c.convertTableCell(cell, true, widths))
 		}
 	}
 	return &ADFNode{
@@ -289,11 +366,11 @@ func (c *adfConverter) convertTableHeader(n *extast.TableHeader) *ADFNode {
 	}
 }
 
-func (c *adfConverter) convertTableRow(n *extast.TableRow, isHeader bool) *ADFNode {
+func (c *adfConverter) convertTableRow(n *extast.TableRow, isHeader bool, widths []float64) *ADFNode {
 	var cells []*ADFNode
 	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
 		if cell, ok := child.(*extast.TableCell); ok {
-			cells = append(cells, c.convertTableCell(cell, isHeader))
+			cells = append(cells, c.convertTableCell(cell, isHeader, widths))
 		}
 	}
 	return &ADFNode{
@@ -302,9 +379,10 @@ func (c *adfConverter) convertTableRow(n *extast.TableRow, isHeader bool) *ADFNo
 	}
 }
 
-func (c *adfConverter) convertTableCell(n *extast.TableCell, isHeader bool) *ADFNode {
+func (c *adfConverter) convertTableCell(n *extast.TableCell, isHeader bool, widths []float64) *ADFNode {
+	info := cellInfo(n)
 	cellType := "tableCell"
-	if isHeader {
+	if (isHeader || info.Header) && !info.Data {
 		cellType = "tableHeader"
 	}
 
@@ -317,9 +395,13 @@ func (c *adfConverter) convertTableCell(n *extast.TableCell, isHeader bool) *ADF
 		para = &ADFNode{Type: "paragraph", Content: []*ADFNode{{Type: "text", Text: ""}}}
 	}
 
+	attrs := map[string]interface{}{"colspan": info.Colspan, "rowspan": info.Rowspan}
+	if colwidth := columnWidths(widths, info.Column, info.Colspan); colwidth != nil {
+		attrs["colwidth"] = colwidth
+	}
 	return &ADFNode{
 		Type:    cellType,
-		Attrs:   map[string]interface{}{"colspan": 1, "rowspan": 1},
+		Attrs:   attrs,
 		Content: []*ADFNode{para},
 	}
 }
@@ -338,10 +420,17 @@ func (c *adfConverter) convertInlineChildren(n ast.Node) []*ADFNode {
 func (c *adfConverter) convertInlineNode(n ast.Node, marks []*ADFMark) []*ADFNode {
 	switch node := n.(type) {
 	case *ast.Text:
-		text := string(node.Segment.Value(c.source))
+		// Backslash escapes are markdown syntax, not part of the text
+		text := string(util.UnescapePunctuations(node.Segment.Value(c.source)))
 		if text == "" {
 			return nil
 		}
+		if strings.Contains(text, adfMacroPlaceholderPrefix) {
+			return c.macroText(text, marks)
+		}
+		if strings.Contains(text, statusPlaceholderPrefix) {
+			return c.statusText(text, marks)
+		}
 		adfNode := &ADFNode{Type: "text", Text: text}
 		if len(marks) > 0 {
 			adfNode.Marks = marks
@@ -394,9 +483,21 @@ func (c *adfConverter) convertInlineNode(n ast.Node, marks []*ADFMark) []*ADFNod
 		return []*ADFNode{{Type: "text", Text: text, Marks: newMarks}}
 
 	case *ast.Link:
+		href := string(node.Destination)
+		if link, ok := ParsePageLink(href); ok {
+			// Pages are linked by URL, so a link without a space is left
+			// as its text
+			if href = link.displayPath(); href == "" {
+				var nodes []*ADFNode
+				for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+					nodes = append(nodes, c.convertInlineNode(child, marks)...)
+				}
+				return nodes
+			}
+		}
 		linkMark := &ADFMark{
 			Type:  "link",
-			Attrs: map[string]interface{}{"href": string(node.Destination)},
+			Attrs: map[string]interface{}{"href": href},
 		}
 		newMarks := append(copyMarks(marks), linkMark)
 		var nodes []*ADFNode
@@ -407,6 +508,10 @@ func (c *adfConverter) convertInlineNode(n ast.Node, marks []*ADFMark) []*ADFNod
 
 	case *ast.AutoLink:
 		url := string(node.URL(c.source))
+		if _, ok := jiraIssueKey(url); ok {
+			// Jira issues show as smart links
+			return []*ADFNode{{Type: "inlineCard", Attrs: map[string]interface{}{"url": url}}}
+		}
 		linkMark := &ADFMark{
 			Type:  "link",
 			Attrs: map[string]interface{}{"href": url},
@@ -414,20 +519,17 @@ func (c *adfConverter) convertInlineNode(n ast.Node, marks []*ADFMark) []*ADFNod
 		newMarks := append(copyMarks(marks), linkMark)
 		return []*ADFNode{{Type: "text", Text: url, Marks: newMarks}}
 
+	case *extast.TaskCheckBox:
+		// A checkbox outside a task list stays as written
+		return []*ADFNode{{Type: "text", Text: checkBoxText(node), Marks: marks}}
+
 	case *ast.RawHTML:
 		// Skip raw HTML
 		return nil
 
 	case *ast.Image:
 		// Images would need special handling - for now return alt text
-		// Build alt text from child text nodes (node.Text is deprecated)
-		var altBuilder strings.Builder
-		for child := node.FirstChild(); child != nil; child = child.NextSibling() {
-			if textNode, ok := child.(*ast.Text); ok {
-				altBuilder.Write(textNode.Segment.Value(c.source))
-			}
-		}
-		alt := altBuilder.String()
+		alt := c.imageAlt(node)
 		if alt == "" {
 			alt = string(node.Destination)
 		}
@@ -447,6 +549,17 @@ func (c *adfConverter) convertInlineNode(n ast.Node, marks []*ADFMark) []*ADFNod
 	}
 }
 
+// imageAlt builds alt text from an image's child text nodes (node.Text is deprecated).
+func (c *adfConverter) imageAlt(n *ast.Image) string {
+	var altBuilder strings.Builder
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		if textNode, ok := child.(*ast.Text); ok {
+			altBuilder.Write(util.UnescapePunctuations(textNode.Segment.Value(c.source)))
+		}
+	}
+	return altBuilder.String()
+}
+
 // copyMarks creates a copy of the marks slice.
 func copyMarks(marks []*ADFMark) []*ADFMark {
 	if marks == nil {
@@ -0,0 +1,192 @@
+// toc.go generates a static markdown table of contents.
+package md
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// tocMarkerPattern matches a TOC marker comment, e.g. <!-- toc --> or <!-- toc min=2 max=3 -->.
+var tocMarkerPattern = regexp.MustCompile(`(?i)^\s*<!--\s*toc((?:\s+\w+=\d+)*)\s*-->\s*$`)
+
+// TOCOptions controls table of contents generation.
+type TOCOptions struct {
+	MinLevel int                         // Shallowest heading level included (default 1)
+	MaxLevel int                         // Deepest heading level included (default 3)
+	Anchor   func(heading string) string // Link target for a heading (default CloudAnchor)
+}
+
+// TOCHeading is a heading listed in a table of contents.
+type TOCHeading struct {
+	Level int
+	Text  string
+}
+
+// CloudAnchor returns the anchor Confluence's cloud editor assigns to a heading.
+func CloudAnchor(heading string) string {
+	return strings.Join(strings.Fields(heading), "-")
+}
+
+// LegacyAnchor returns a function giving the anchors Confluence's legacy
+// editor assigns to headings on a page with the given title.
+func LegacyAnchor(title string) func(string) string {
+	prefix := strings.Join(strings.Fields(title), "")
+	return func(heading string) string {
+		return prefix + "-" + strings.Join(strings.Fields(heading), "")
+	}
+}
+
+// Headings returns the headings in markdown, ignoring fenced code and
+// front matter.
+func Headings(markdown []byte) []TOCHeading {
+	markdown = skipFrontMatter(markdown)
+	doc := adfParser.Parser().Parse(text.NewReader(markdown))
+
+	var headings []TOCHeading
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		h, ok := n.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		headings = append(headings, TOCHeading{Level: h.Level, Text: nodeText(h, markdown)})
+		return ast.WalkSkipChildren, nil
+	})
+	return headings
+}
+
+// skipFrontMatter returns markdown without its front matter, whose closing
+// delimiter would otherwise make its last line a setext heading.
+func skipFrontMatter(markdown []byte) []byte {
+	if _, body, err := ParseFrontMatter(markdown); err == nil {
+		return body
+	}
+	return markdown
+}
+
+// nodeText returns the plain text of an inline container, without formatting.
+func nodeText(n ast.Node, source []byte) string {
+	var sb strings.Builder
+	_ = ast.Walk(n, func(c ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := c.(type) {
+		case *ast.Text:
+			sb.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				sb.WriteByte(' ')
+			}
+		case *ast.String:
+			sb.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.TrimSpace(sb.String())
+}
+
+// GenerateTOC renders headings as a nested markdown list of links.
+func GenerateTOC(headings []TOCHeading, opts TOCOptions) string {
+	opts = opts.withDefaults()
+
+	var sb strings.Builder
+	var stack []int // heading levels of the open list items
+	for _, h := range headings {
+		if h.Level < opts.MinLevel || h.Level > opts.MaxLevel || h.Text == "" {
+			continue
+		}
+		for len(stack) > 0 && stack[len(stack)-1] >= h.Level {
+			stack = stack[:len(stack)-1]
+		}
+		// Skipped levels nest one step at a time, so items are never over-indented
+		fmt.Fprintf(&sb, "%s- [%s](#%s)\n", strings.Repeat("  ", len(stack)), EscapeLinkText(h.Text), url.PathEscape(opts.Anchor(h.Text)))
+		stack = append(stack, h.Level)
+	}
+	return sb.String()
+}
+
+// InsertTOC replaces each TOC marker comment in markdown with a table of
+// contents of the document's headings. Markers may override the heading
+// range: <!-- toc min=2 max=4 -->. Markers inside code blocks and front
+// matter are ignored. Returns the markdown unchanged if it has no marker.
+func InsertTOC(markdown []byte, opts TOCOptions) []byte {
+	body := skipFrontMatter(markdown)
+	offset := len(markdown) - len(body)
+	doc := adfParser.Parser().Parse(text.NewReader(body))
+
+	type marker struct {
+		start, stop int
+		opts        TOCOptions
+	}
+	var markers []marker
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		block, ok := n.(*ast.HTMLBlock)
+		if !entering || !ok || block.Lines().Len() != 1 {
+			return ast.WalkContinue, nil
+		}
+		seg := block.Lines().At(0)
+		m := tocMarkerPattern.FindSubmatch(seg.Value(body))
+		if m == nil {
+			return ast.WalkContinue, nil
+		}
+		markers = append(markers, marker{start: offset + seg.Start, stop: offset + seg.Stop, opts: opts.withMarkerArgs(string(m[1]))})
+		return ast.WalkSkipChildren, nil
+	})
+	if len(markers) == 0 {
+		return markdown
+	}
+
+	headings := Headings(markdown)
+
+	var out []byte
+	last := 0
+	for _, m := range markers {
+		out = append(out, markdown[last:m.start]...)
+		out = append(out, GenerateTOC(headings, m.opts)...)
+		last = m.stop
+	}
+	out = append(out, markdown[last:]...)
+	return out
+}
+
+func (o TOCOptions) withDefaults() TOCOptions {
+	if o.MinLevel <= 0 {
+		o.MinLevel = 1
+	}
+	if o.MaxLevel <= 0 {
+		o.MaxLevel = 3
+	}
+	if o.Anchor == nil {
+		o.Anchor = CloudAnchor
+	}
+	return o
+}
+
+// withMarkerArgs applies min=N and max=N arguments from a marker comment.
+func (o TOCOptions) withMarkerArgs(args string) TOCOptions {
+	for _, arg := range strings.Fields(args) {
+		key, value, _ := strings.Cut(arg, "=")
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "min":
+			o.MinLevel = n
+		case "max":
+			o.MaxLevel = n
+		}
+	}
+	return o
+}
+
+// EscapeLinkText escapes characters that would end or alter the text of a
+// markdown link.
+func EscapeLinkText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`).Replace(s)
+}
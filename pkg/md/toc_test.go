@@ -0,0 +1,105 @@
+package md
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadings(t *testing.T) {
+	input := "# Title\n\n## With *emphasis* and `code`\n\n```\n# not a heading\n```\n\nSetext\n------\n"
+
+	assert.Equal(t, []TOCHeading{
+		{Level: 1, Text: "Title"},
+		{Level: 2, Text: "With emphasis and code"},
+		{Level: 2, Text: "Setext"},
+	}, Headings([]byte(input)))
+}
+
+func TestHeadings_FrontMatter(t *testing.T) {
+	input := "---\ntitle: Guide\n---\n\n## Install\n"
+
+	assert.Equal(t, []TOCHeading{{Level: 2, Text: "Install"}}, Headings([]byte(input)))
+}
+
+func TestGenerateTOC(t *testing.T) {
+	headings := []TOCHeading{
+		{Level: 1, Text: "Guide"},
+		{Level: 3, Text: "Skipped Level"},
+		{Level: 2, Text: "Install [beta]"},
+		{Level: 4, Text: "Too Deep"},
+		{Level: 2, Text: "Usage (CLI)"},
+	}
+
+	tests := []struct {
+		name string
+		opts TOCOptions
+		want string
+	}{
+		{
+			name: "defaults",
+			want: "- [Guide](#Guide)\n" +
+				"  - [Skipped Level](#Skipped-Level)\n" +
+				"  - [Install \\[beta\\]](#Install-%5Bbeta%5D)\n" +
+				"  - [Usage (CLI)](#Usage-%28CLI%29)\n",
+		},
+		{
+			name: "level range",
+			opts: TOCOptions{MinLevel: 2, MaxLevel: 2},
+			want: "- [Install \\[beta\\]](#Install-%5Bbeta%5D)\n" +
+				"- [Usage (CLI)](#Usage-%28CLI%29)\n",
+		},
+		{
+			name: "legacy anchors",
+			opts: TOCOptions{MinLevel: 2, MaxLevel: 2, Anchor: LegacyAnchor("User Guide")},
+			want: "- [Install \\[beta\\]](#UserGuide-Install%5Bbeta%5D)\n" +
+				"- [Usage (CLI)](#UserGuide-Usage%28CLI%29)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GenerateTOC(headings, tt.opts))
+		})
+	}
+}
+
+func TestInsertTOC(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "no marker",
+			input: "# A\n\n## B\n",
+			want:  "# A\n\n## B\n",
+		},
+		{
+			name:  "marker replaced",
+			input: "# A\n\n<!-- toc -->\n\n## B\n",
+			want:  "# A\n\n- [A](#A)\n  - [B](#B)\n\n## B\n",
+		},
+		{
+			name:  "marker with range",
+			input: "<!-- TOC min=2 max=2 -->\n# A\n\n## B\n\n### C\n",
+			want:  "- [B](#B)\n# A\n\n## B\n\n### C\n",
+		},
+		{
+			name:  "marker in code block ignored",
+			input: "```\n<!-- toc -->\n```\n\n# A\n",
+			want:  "```\n<!-- toc -->\n```\n\n# A\n",
+		},
+		{
+			name:  "front matter kept and not a heading",
+			input: "---\ntitle: Guide\n---\n<!-- toc -->\n\n## B\n",
+			want:  "---\ntitle: Guide\n---\n- [B](#B)\n\n## B\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(InsertTOC([]byte(tt.input), TOCOptions{})))
+		})
+	}
+}
@@ -23,6 +23,14 @@ func TokenizeBrackets(input string) ([]BracketToken, error) {
 	for pos < len(input) {
 		// Look for opening bracket
 		if input[pos] == '[' {
+			// Try to parse a macro tag
+			token, endPos, err := parseBracketTag(input, pos)
+			if err != nil {
+				// Not a valid macro tag - treat '[' as text
+				pos++
+				continue
+			}
+
 			// Emit any accumulated text before this bracket
 			if pos > textStart {
 				tokens = append(tokens, BracketToken{
@@ -32,14 +40,6 @@ func TokenizeBrackets(input string) ([]BracketToken, error) {
 				})
 			}
 
-			// Try to parse a macro tag
-			token, endPos, err := parseBracketTag(input, pos)
-			if err != nil {
-				// Not a valid macro tag - treat '[' as text
-				pos++
-				continue
-			}
-
 			tokens = append(tokens, token)
 			pos = endPos
 			textStart = pos
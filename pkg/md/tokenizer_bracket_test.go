@@ -133,6 +133,17 @@ func TestTokenizeBrackets_WithSurroundingText(t *testing.T) {
 	assert.Equal(t, " after", tokens[2].Text)
 }
 
+func TestTokenizeBrackets_TextBeforeInvalidBracket(t *testing.T) {
+	input := "x `[[TOC]]` y"
+	tokens, err := TokenizeBrackets(input)
+	require.NoError(t, err)
+	require.Len(t, tokens, 3)
+
+	assert.Equal(t, "x `[", tokens[0].Text)
+	assert.Equal(t, "TOC", tokens[1].MacroName)
+	assert.Equal(t, "]` y", tokens[2].Text)
+}
+
 func TestTokenizeBrackets_NestedMacros(t *testing.T) {
 	input := "[INFO]outer [TOC] content[/INFO]"
 	tokens, err := TokenizeBrackets(input)
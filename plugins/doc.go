@@ -0,0 +1,13 @@
+// Package plugins holds markdown converter plugins compiled into cfl when it
+// is built with the plugins build tag:
+//
+//	go build -tags plugins ./cmd/cfl
+//
+// Add a file to this package that registers a plugin from init:
+//
+//	func init() {
+//		md.RegisterPlugin(myPlugin{})
+//	}
+//
+// See md.Plugin for the available hooks.
+package plugins